@@ -0,0 +1,219 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/core/config"
+	sql2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql"
+	common2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/backup"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configDir   string
+	tms         string
+	output      string
+	input       string
+	driverName  string
+	dataSource  string
+	tablePrefix string
+)
+
+// Cmd returns the Cobra Command for the backup and restore of the stores of a TMS
+func Cmd() *cobra.Command {
+	exportFlags := exportCmd.Flags()
+	exportFlags.StringVarP(&configDir, "config", "c", "", "path to the folder containing the core.yaml of the node")
+	exportFlags.StringVarP(&tms, "tms", "t", "", "the TMS to export, as network,channel,namespace")
+	exportFlags.StringVarP(&output, "output", "o", "backup.gz", "path to the file where to store the archive")
+
+	importFlags := importCmd.Flags()
+	importFlags.StringVarP(&input, "input", "i", "", "path to the archive to import")
+	importFlags.StringVarP(&configDir, "config", "c", "", "path to the folder containing the core.yaml of the target node")
+	importFlags.StringVarP(&tms, "tms", "t", "", "the TMS to import into, as network,channel,namespace. Defaults to the TMS of the archive")
+	importFlags.StringVarP(&driverName, "driver", "d", "", "driver of the target database (sqlite or postgres), used in place of --config")
+	importFlags.StringVarP(&dataSource, "datasource", "s", "", "data source of the target database, used with --driver")
+	importFlags.StringVarP(&tablePrefix, "table-prefix", "p", "", "table prefix in the target database, used with --driver. Defaults to the one derived from the TMS")
+
+	backupCmd.AddCommand(exportCmd, importCmd, verifyCmd)
+	return backupCmd
+}
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Export and import the stores of a TMS.",
+	Long:  `Export the token, transaction, audit, identity and wallet stores of a TMS into a portable archive, and import them into any supported database.`,
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the stores of a TMS.",
+	Long:  `Export the stores of a TMS, as configured for a node, into a portable archive with integrity hashes.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return exportStores()
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import the stores of a TMS.",
+	Long:  `Import an archive into the stores of a TMS. The archive is verified before anything is written, and the target tables must be empty.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return importStores()
+	},
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify [archive]",
+	Short: "Verify an archive.",
+	Long:  `Verify the integrity of an archive and print its content.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("expected the path to the archive")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		file, err := os.Open(args[0])
+		if err != nil {
+			return errors.Wrapf(err, "failed opening [%s]", args[0])
+		}
+		defer file.Close()
+		header, err := backup.Verify(file)
+		if err != nil {
+			return err
+		}
+		printHeader(header)
+		return nil
+	},
+}
+
+func exportStores() error {
+	if len(configDir) == 0 {
+		return errors.New("missing config folder")
+	}
+	tmsID, err := parseTMSID(tms)
+	if err != nil {
+		return err
+	}
+	cp, err := config.NewProvider(configDir)
+	if err != nil {
+		return errors.WithMessagef(err, "failed loading configuration from [%s]", configDir)
+	}
+	stores, err := backup.OpenStores(cp, tmsID)
+	if err != nil {
+		return err
+	}
+	defer stores.Close()
+
+	file, err := os.OpenFile(output, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed creating [%s]", output)
+	}
+	header, err := backup.Export(context.Background(), file, tmsID, stores...)
+	if err != nil {
+		file.Close()
+		os.Remove(output)
+		return errors.WithMessage(err, "failed exporting")
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrapf(err, "failed closing [%s]", output)
+	}
+	printHeader(header)
+	fmt.Printf("Archive stored in [%s]\n", output)
+	return nil
+}
+
+func importStores() error {
+	if len(input) == 0 {
+		return errors.New("missing input archive")
+	}
+	file, err := os.Open(input)
+	if err != nil {
+		return errors.Wrapf(err, "failed opening [%s]", input)
+	}
+	defer file.Close()
+	header, err := backup.Verify(file)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, 0); err != nil {
+		return errors.Wrapf(err, "failed rewinding [%s]", input)
+	}
+
+	tmsID := header.TMSID
+	if len(tms) != 0 {
+		if tmsID, err = parseTMSID(tms); err != nil {
+			return err
+		}
+	}
+	var stores backup.Stores
+	switch {
+	case len(driverName) != 0:
+		if len(configDir) != 0 {
+			return errors.New("--config and --driver are mutually exclusive")
+		}
+		if driverName != string(sql2.SQLite) && driverName != string(sql2.Postgres) {
+			return errors.Errorf("unsupported driver [%s]", driverName)
+		}
+		stores, err = backup.OpenStoresOn(common2.Opts{
+			Driver:      common2.SQLDriverType(driverName),
+			DataSource:  dataSource,
+			TablePrefix: tablePrefix,
+		}, tmsID)
+	case len(configDir) != 0:
+		cp, err2 := config.NewProvider(configDir)
+		if err2 != nil {
+			return errors.WithMessagef(err2, "failed loading configuration from [%s]", configDir)
+		}
+		stores, err = backup.OpenStores(cp, tmsID)
+	default:
+		return errors.New("missing target, pass either --config or --driver")
+	}
+	if err != nil {
+		return err
+	}
+	defer stores.Close()
+
+	if _, err := backup.Import(context.Background(), file, stores...); err != nil {
+		return errors.WithMessage(err, "failed importing")
+	}
+	printHeader(header)
+	fmt.Printf("Archive imported into [%s]\n", tmsID)
+	return nil
+}
+
+func parseTMSID(s string) (token.TMSID, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return token.TMSID{}, errors.Errorf("invalid TMS [%s], expected network,channel,namespace", s)
+	}
+	return token.TMSID{Network: parts[0], Channel: parts[1], Namespace: parts[2]}, nil
+}
+
+func printHeader(header *backup.Header) {
+	fmt.Printf("TMS [%s], created at [%s]\n", header.TMSID, header.CreatedAt)
+	for _, store := range header.Stores {
+		fmt.Printf("  store [%s], schema version [%d], tables %v\n", store.Name, store.SchemaVersion, store.Tables)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package offline
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx/offline"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var input string
+var mspDirs []string
+var output string
+
+// SignCmd returns the Cobra Command for signing offline the requests of signature exported by a node
+func SignCmd() *cobra.Command {
+	flags := cobraCommand.Flags()
+	flags.StringVarP(&input, "input", "i", "", "path to the file containing the signature request envelope")
+	flags.StringSliceVarP(&mspDirs, "msp", "m", nil, "path to an x509 MSP folder containing a signing key, can be repeated")
+	flags.StringVarP(&output, "output", "o", "signatures.json", "path to the file where to store the signature response envelope")
+
+	return cobraCommand
+}
+
+var cobraCommand = &cobra.Command{
+	Use:   "offline-sign",
+	Short: "Sign token requests offline.",
+	Long:  `Sign the signature requests exported by a node for its offline wallets, and produce the envelope to import back.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return sign()
+	},
+}
+
+func sign() error {
+	if len(input) == 0 {
+		return errors.New("missing input file")
+	}
+	if len(mspDirs) == 0 {
+		return errors.New("missing msp folders")
+	}
+	raw, err := os.ReadFile(input)
+	if err != nil {
+		return errors.Wrapf(err, "failed reading signature requests from [%s]", input)
+	}
+	request, err := offline.UnmarshalRequestEnvelope(raw)
+	if err != nil {
+		return errors.WithMessagef(err, "failed loading signature requests from [%s]", input)
+	}
+	fmt.Printf("Transaction [%s] on [%s], [%d] signature requests\n", request.TxID, request.TMSID(), len(request.Requests))
+	for i, req := range request.Requests {
+		tr, _, err := offline.DecodeMessage(req.Message)
+		if err != nil {
+			return errors.WithMessagef(err, "failed decoding signature request [%d]", i)
+		}
+		fmt.Printf("  [%d] wallet [%s], party [%s], [%d] issues, [%d] transfers, message hash [%x]\n", i, req.WalletID, req.Party, len(tr.Issues), len(tr.Transfers), req.MessageHash)
+	}
+	fmt.Printf("Outputs, as reported by the node:\n")
+	for _, output := range request.Outputs {
+		recipient := "redeem"
+		if !output.Recipient.IsNone() {
+			recipient = fmt.Sprintf("[%s][%s]", output.EnrollmentID, output.Recipient)
+		}
+		fmt.Printf("  action [%d], [%s] of type [%s] to %s\n", output.ActionIndex, output.Quantity, output.Type, recipient)
+	}
+
+	sp, err := offline.NewX509SignerProvider(mspDirs...)
+	if err != nil {
+		return errors.WithMessage(err, "failed loading signers")
+	}
+	response, err := offline.Sign(request, sp)
+	if err != nil {
+		return errors.WithMessage(err, "failed signing")
+	}
+	raw, err = response.Bytes()
+	if err != nil {
+		return errors.Wrap(err, "failed marshalling signatures")
+	}
+	fmt.Printf("Store [%d] signatures to [%s]...\n", len(response.Signatures), output)
+	if err := os.WriteFile(output, raw, 0600); err != nil {
+		return errors.Wrapf(err, "failed writing signatures to [%s]", output)
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
@@ -71,6 +72,84 @@ func SetupIssuersAndAuditors(pp PP, Auditors, Issuers []string) error {
 	return nil
 }
 
+// IssuerPoliciesPP is implemented by the public parameters supporting per-token-type issuer policies
+type IssuerPoliciesPP interface {
+	// AddIssuerPolicy restricts the issuers of the token types matching the passed pattern
+	AddIssuerPolicy(tokenType string, issuers []driver.Identity)
+}
+
+// SetupIssuerPolicies parses the passed policies and adds them to the given public parameters.
+// Each policy has the form TYPE=MSPDIR[,MSPDIR...], where TYPE is either a token type or a prefix followed by '*'.
+func SetupIssuerPolicies(pp IssuerPoliciesPP, policies []string) error {
+	for _, policy := range policies {
+		tokenType, dirs, ok := strings.Cut(policy, "=")
+		if !ok || len(tokenType) == 0 || len(dirs) == 0 {
+			return errors.Errorf("invalid issuer policy [%s], expected TYPE=MSPDIR[,MSPDIR...]", policy)
+		}
+		var issuers []driver.Identity
+		for _, dir := range strings.Split(dirs, ",") {
+			id, err := GetX509Identity(dir)
+			if err != nil {
+				return errors.WithMessagef(err, "failed to get issuer identity [%s] for token type [%s]", dir, tokenType)
+			}
+			issuers = append(issuers, id)
+		}
+		pp.AddIssuerPolicy(tokenType, issuers)
+	}
+	return nil
+}
+
+// FeePoliciesPP is implemented by the public parameters supporting transfer fees
+type FeePoliciesPP interface {
+	// AddFeePolicy charges the passed fee on the transfers of the token types matching the passed pattern
+	AddFeePolicy(tokenType string, flat, rate uint64, collector driver.Identity)
+}
+
+// SetupFeePolicies parses the passed policies and adds them to the given public parameters.
+// Each policy has the form TYPE=FLAT:RATE:MSPDIR, where TYPE is either a token type or a prefix followed by '*',
+// RATE is in basis points, and MSPDIR contains the certificate of the fee collector.
+func SetupFeePolicies(pp FeePoliciesPP, policies []string) error {
+	for _, policy := range policies {
+		tokenType, fee, ok := strings.Cut(policy, "=")
+		parts := strings.SplitN(fee, ":", 3)
+		if !ok || len(tokenType) == 0 || len(parts) != 3 || len(parts[2]) == 0 {
+			return errors.Errorf("invalid fee policy [%s], expected TYPE=FLAT:RATE:MSPDIR", policy)
+		}
+		flat, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "invalid flat fee in fee policy [%s]", policy)
+		}
+		rate, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "invalid fee rate in fee policy [%s]", policy)
+		}
+		collector, err := GetX509Identity(parts[2])
+		if err != nil {
+			return errors.WithMessagef(err, "failed to get fee collector identity [%s] for token type [%s]", parts[2], tokenType)
+		}
+		pp.AddFeePolicy(tokenType, flat, rate, collector)
+	}
+	return nil
+}
+
+// EnforcersPP is implemented by the public parameters supporting enforcers
+type EnforcersPP interface {
+	// AddEnforcer adds an enforcer to the public parameters
+	AddEnforcer(id driver.Identity)
+}
+
+// SetupEnforcers adds to the given public parameters the enforcers whose certificates are in the passed MSP directories
+func SetupEnforcers(pp EnforcersPP, enforcers []string) error {
+	for _, enforcer := range enforcers {
+		id, err := GetX509Identity(enforcer)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to get enforcer identity [%s]", enforcer)
+		}
+		pp.AddEnforcer(id)
+	}
+	return nil
+}
+
 // ReadSingleCertificateFromFile reads the passed file and checks that it contains only one
 // certificate in the PEM format.
 // It returns an error if the file contains more than one certificate.
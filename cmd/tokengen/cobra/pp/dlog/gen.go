@@ -29,14 +29,24 @@ type GeneratorArgs struct {
 	GenerateCCPackage bool
 	// Issuers is the list of issuer MSP directories containing the corresponding issuer certificate
 	Issuers []string
+	// IssuerPolicies is the list of per-token-type issuer policies, each in the form TYPE=MSPDIR[,MSPDIR...]
+	IssuerPolicies []string
+	// FeePolicies is the list of per-token-type fee policies, each in the form TYPE=FLAT:RATE:MSPDIR
+	FeePolicies []string
 	// Auditors is the list of auditor MSP directories containing the corresponding auditor certificate
 	Auditors []string
+	// AuditorThreshold is the number of auditors that must sign a token request, zero means all of them
+	AuditorThreshold uint
+	// Enforcers is the list of enforcer MSP directories containing the corresponding enforcer certificate
+	Enforcers []string
 	// Base is a dlog driver related parameter
 	Base uint
 	// Exponent is a dlog driver related parameter
 	Exponent uint
 	// Aries is a flag to indicate that aries should be used as backend for idemix
 	Aries bool
+	// AggregatedRangeProofs is a flag to indicate that the range proofs of the outputs of a transfer should be aggregated
+	AggregatedRangeProofs bool
 }
 
 var (
@@ -48,8 +58,16 @@ var (
 	GenerateCCPackage bool
 	// Issuers is the list of issuer MSP directories containing the corresponding issuer certificate
 	Issuers []string
+	// IssuerPolicies is the list of per-token-type issuer policies, each in the form TYPE=MSPDIR[,MSPDIR...]
+	IssuerPolicies []string
+	// FeePolicies is the list of per-token-type fee policies, each in the form TYPE=FLAT:RATE:MSPDIR
+	FeePolicies []string
 	// Auditors is the list of auditor MSP directories containing the corresponding auditor certificate
 	Auditors []string
+	// AuditorThreshold is the number of auditors that must sign a token request, zero means all of them
+	AuditorThreshold uint
+	// Enforcers is the list of enforcer MSP directories containing the corresponding enforcer certificate
+	Enforcers []string
 	// Base is a dlog driver related parameter.
 	// It is used to define the maximum quantity a token can contain as Base^Exponent
 	Base uint
@@ -58,6 +76,8 @@ var (
 	Exponent uint
 	// Aries is a flag to indicate that aries should be used as backend for idemix
 	Aries bool
+	// AggregatedRangeProofs is a flag to indicate that the range proofs of the outputs of a transfer should be aggregated
+	AggregatedRangeProofs bool
 )
 
 // Cmd returns the Cobra Command for Version
@@ -67,11 +87,16 @@ func Cmd() *cobra.Command {
 	flags.StringVarP(&OutputDir, "output", "o", ".", "output folder")
 	flags.BoolVarP(&GenerateCCPackage, "cc", "", false, "generate chaincode package")
 	flags.StringSliceVarP(&Auditors, "auditors", "a", nil, "list of auditor MSP directories containing the corresponding auditor certificate")
+	flags.UintVarP(&AuditorThreshold, "auditor-threshold", "", 0, "number of auditors that must sign a token request, zero means all of them")
+	flags.StringSliceVarP(&Enforcers, "enforcers", "", nil, "list of enforcer MSP directories containing the corresponding enforcer certificate. Enforcers can freeze tokens and force their transfer")
 	flags.StringSliceVarP(&Issuers, "issuers", "s", nil, "list of issuer MSP directories containing the corresponding issuer certificate")
+	flags.StringArrayVarP(&IssuerPolicies, "issuer-policy", "", nil, "issuers allowed to issue a given token type, in the form TYPE=MSPDIR[,MSPDIR...]. TYPE can be '*' to match all token types, prefixes are not supported. Can be repeated")
+	flags.StringArrayVarP(&FeePolicies, "fee-policy", "", nil, "fee charged on the transfers of a given token type, in the form TYPE=FLAT:RATE:MSPDIR. RATE is in basis points, MSPDIR contains the certificate of the fee collector. TYPE can be '*' to match all token types, prefixes are not supported. Can be repeated")
 	flags.StringVarP(&IdemixMSPDir, "idemix", "i", "", "idemix msp dir")
 	flags.UintVarP(&Base, "base", "b", 100, "base is used to define the maximum quantity a token can contain as Base^Exponent")
 	flags.UintVarP(&Exponent, "exponent", "e", 2, "exponent is used to define the maximum quantity a token can contain as Base^Exponent")
 	flags.BoolVarP(&Aries, "aries", "r", false, "flag to indicate that aries should be used as backend for idemix")
+	flags.BoolVarP(&AggregatedRangeProofs, "aggregated-range-proofs", "", false, "aggregate the range proofs of the outputs of a transfer into a single proof (requires public parameters version 2)")
 
 	return cobraCommand
 }
@@ -87,14 +112,19 @@ var cobraCommand = &cobra.Command{
 		// Parsing of the command line is done so silence cmd usage
 		cmd.SilenceUsage = true
 		raw, err := Gen(&GeneratorArgs{
-			IdemixMSPDir:      IdemixMSPDir,
-			OutputDir:         OutputDir,
-			GenerateCCPackage: GenerateCCPackage,
-			Issuers:           Issuers,
-			Auditors:          Auditors,
-			Base:              Base,
-			Exponent:          Exponent,
-			Aries:             Aries,
+			IdemixMSPDir:          IdemixMSPDir,
+			OutputDir:             OutputDir,
+			GenerateCCPackage:     GenerateCCPackage,
+			Issuers:               Issuers,
+			IssuerPolicies:        IssuerPolicies,
+			FeePolicies:           FeePolicies,
+			Auditors:              Auditors,
+			AuditorThreshold:      AuditorThreshold,
+			Enforcers:             Enforcers,
+			Base:                  Base,
+			Exponent:              Exponent,
+			Aries:                 Aries,
+			AggregatedRangeProofs: AggregatedRangeProofs,
 		})
 		if err != nil {
 			fmt.Printf("failed to generate public parameters [%s]\n", err)
@@ -133,6 +163,19 @@ func Gen(args *GeneratorArgs) ([]byte, error) {
 	if err := common.SetupIssuersAndAuditors(pp, args.Auditors, args.Issuers); err != nil {
 		return nil, errors.Wrap(err, "failed to setup issuer and auditors")
 	}
+	pp.SetAuditorThreshold(uint64(args.AuditorThreshold))
+	if err := common.SetupEnforcers(pp, args.Enforcers); err != nil {
+		return nil, errors.Wrap(err, "failed to setup enforcers")
+	}
+	if err := common.SetupIssuerPolicies(pp, args.IssuerPolicies); err != nil {
+		return nil, errors.Wrap(err, "failed to setup issuer policies")
+	}
+	if err := common.SetupFeePolicies(pp, args.FeePolicies); err != nil {
+		return nil, errors.Wrap(err, "failed to setup fee policies")
+	}
+	if args.AggregatedRangeProofs {
+		pp.EnableAggregatedRangeProofs()
+	}
 	if err := pp.Validate(); err != nil {
 		return nil, errors.Wrapf(err, "failed to validate public parameters")
 	}
@@ -28,8 +28,20 @@ type UpdateArgs struct {
 	OutputDir string
 	// Issuers is the list of issuer MSP directories containing the corresponding issuer certificate
 	Issuers []string
+	// IssuerPolicies is the list of per-token-type issuer policies, each in the form TYPE=MSPDIR[,MSPDIR...].
+	// If not empty, it replaces the existing policies.
+	IssuerPolicies []string
+	// FeePolicies is the list of per-token-type fee policies, each in the form TYPE=FLAT:RATE:MSPDIR.
+	// If not empty, it replaces the existing policies.
+	FeePolicies []string
 	// Auditors is the list of auditor MSP directories containing the corresponding auditor certificate
 	Auditors []string
+	// AuditorThreshold is the number of auditors that must sign a token request, zero means all of them.
+	// It is applied only when Auditors is not empty.
+	AuditorThreshold uint
+	// Enforcers is the list of enforcer MSP directories containing the corresponding enforcer certificate.
+	// If not empty, it replaces the existing enforcers.
+	Enforcers []string
 }
 
 // UpdateCmd returns the Cobra Command for Update
@@ -39,7 +51,11 @@ func UpdateCmd() *cobra.Command {
 	flags.StringVarP(&InputFile, "input", "i", "", "path of the public param file")
 	flags.StringVarP(&OutputDir, "output", "o", ".", "output folder")
 	flags.StringSliceVarP(&Auditors, "auditors", "a", nil, "list of auditor MSP directories containing the corresponding auditor certificate")
+	flags.UintVarP(&AuditorThreshold, "auditor-threshold", "", 0, "number of auditors that must sign a token request, zero means all of them. It is applied only when auditors are provided")
+	flags.StringSliceVarP(&Enforcers, "enforcers", "", nil, "list of enforcer MSP directories containing the corresponding enforcer certificate. If provided, it replaces the existing enforcers")
 	flags.StringSliceVarP(&Issuers, "issuers", "s", nil, "list of issuer MSP directories containing the corresponding issuer certificate")
+	flags.StringArrayVarP(&IssuerPolicies, "issuer-policy", "", nil, "issuers allowed to issue a given token type, in the form TYPE=MSPDIR[,MSPDIR...]. TYPE can be '*' to match all token types, prefixes are not supported. Can be repeated. If provided, it replaces the existing policies")
+	flags.StringArrayVarP(&FeePolicies, "fee-policy", "", nil, "fee charged on the transfers of a given token type, in the form TYPE=FLAT:RATE:MSPDIR. RATE is in basis points, MSPDIR contains the certificate of the fee collector. TYPE can be '*' to match all token types, prefixes are not supported. Can be repeated. If provided, it replaces the existing policies")
 
 	return cmd
 }
@@ -55,10 +71,14 @@ var cmd = &cobra.Command{
 		// Parsing of the command line is done so silence cmd usage
 		cmd.SilenceUsage = true
 		err := Update(&UpdateArgs{
-			InputFile: InputFile,
-			OutputDir: OutputDir,
-			Issuers:   Issuers,
-			Auditors:  Auditors,
+			InputFile:        InputFile,
+			OutputDir:        OutputDir,
+			Issuers:          Issuers,
+			IssuerPolicies:   IssuerPolicies,
+			FeePolicies:      FeePolicies,
+			Auditors:         Auditors,
+			AuditorThreshold: AuditorThreshold,
+			Enforcers:        Enforcers,
 		})
 		if err != nil {
 			return errors.Wrap(err, "failed to generate public parameters")
@@ -90,7 +110,8 @@ func Update(args *UpdateArgs) error {
 	// Clear auditor and issuers if provided, and add them again.
 	// If not provided, do not change them.
 	if len(args.Auditors) > 0 {
-		pp.Auditor = []byte{}
+		pp.AuditorIDs = []driver.Identity{}
+		pp.SetAuditorThreshold(uint64(args.AuditorThreshold))
 	}
 	if len(args.Issuers) > 0 {
 		pp.IssuerIDs = []driver.Identity{}
@@ -98,6 +119,27 @@ func Update(args *UpdateArgs) error {
 	if err := common.SetupIssuersAndAuditors(pp, args.Auditors, args.Issuers); err != nil {
 		return err
 	}
+	if len(args.Enforcers) > 0 {
+		pp.SetEnforcers(nil)
+		if err := common.SetupEnforcers(pp, args.Enforcers); err != nil {
+			return err
+		}
+	}
+	if len(args.IssuerPolicies) > 0 {
+		pp.SetIssuerPolicies(nil)
+		if err := common.SetupIssuerPolicies(pp, args.IssuerPolicies); err != nil {
+			return err
+		}
+	}
+	if len(args.FeePolicies) > 0 {
+		pp.SetFeePolicies(nil)
+		if err := common.SetupFeePolicies(pp, args.FeePolicies); err != nil {
+			return err
+		}
+	}
+	if err := pp.Validate(); err != nil {
+		return errors.Wrapf(err, "failed to validate updated public parameters")
+	}
 
 	// Store Public Params
 	raw, err := pp.Serialize()
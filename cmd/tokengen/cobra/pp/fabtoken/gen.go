@@ -28,8 +28,16 @@ var (
 	GenerateCCPackage bool
 	// Issuers is the list of issuer MSP directories containing the corresponding issuer certificate
 	Issuers []string
+	// IssuerPolicies is the list of per-token-type issuer policies, each in the form TYPE=MSPDIR[,MSPDIR...]
+	IssuerPolicies []string
+	// FeePolicies is the list of per-token-type fee policies, each in the form TYPE=FLAT:RATE:MSPDIR
+	FeePolicies []string
 	// Auditors is the list of auditor MSP directories containing the corresponding auditor certificate
 	Auditors []string
+	// AuditorThreshold is the number of auditors that must sign a token request, zero means all of them
+	AuditorThreshold uint
+	// Enforcers is the list of enforcer MSP directories containing the corresponding enforcer certificate
+	Enforcers []string
 )
 
 // Cmd returns the Cobra Command for Version
@@ -39,7 +47,11 @@ func Cmd() *cobra.Command {
 	flags.StringVarP(&OutputDir, "output", "o", ".", "output folder")
 	flags.BoolVarP(&GenerateCCPackage, "cc", "", false, "generate chaincode package")
 	flags.StringSliceVarP(&Auditors, "auditors", "a", nil, "list of auditor MSP directories containing the corresponding auditor certificate")
+	flags.UintVarP(&AuditorThreshold, "auditor-threshold", "", 0, "number of auditors that must sign a token request, zero means all of them")
+	flags.StringSliceVarP(&Enforcers, "enforcers", "", nil, "list of enforcer MSP directories containing the corresponding enforcer certificate. Enforcers can freeze tokens and force their transfer")
 	flags.StringSliceVarP(&Issuers, "issuers", "s", nil, "list of issuer MSP directories containing the corresponding issuer certificate")
+	flags.StringArrayVarP(&IssuerPolicies, "issuer-policy", "", nil, "issuers allowed to issue a given token type, in the form TYPE=MSPDIR[,MSPDIR...]. TYPE can end with '*' to match a prefix. Can be repeated")
+	flags.StringArrayVarP(&FeePolicies, "fee-policy", "", nil, "fee charged on the transfers of a given token type, in the form TYPE=FLAT:RATE:MSPDIR. RATE is in basis points, MSPDIR contains the certificate of the fee collector. TYPE can end with '*' to match a prefix. Can be repeated")
 	return cobraCommand
 }
 
@@ -57,7 +69,11 @@ var cobraCommand = &cobra.Command{
 			OutputDir:         OutputDir,
 			GenerateCCPackage: GenerateCCPackage,
 			Issuers:           Issuers,
+			IssuerPolicies:    IssuerPolicies,
+			FeePolicies:       FeePolicies,
 			Auditors:          Auditors,
+			AuditorThreshold:  AuditorThreshold,
+			Enforcers:         Enforcers,
 		})
 		if err != nil {
 			return errors.Wrap(err, "failed to generate public parameters")
@@ -80,8 +96,16 @@ type GeneratorArgs struct {
 	GenerateCCPackage bool
 	// Issuers is the list of issuer MSP directories containing the corresponding issuer certificate
 	Issuers []string
+	// IssuerPolicies is the list of per-token-type issuer policies, each in the form TYPE=MSPDIR[,MSPDIR...]
+	IssuerPolicies []string
+	// FeePolicies is the list of per-token-type fee policies, each in the form TYPE=FLAT:RATE:MSPDIR
+	FeePolicies []string
 	// Auditors is the list of auditor MSP directories containing the corresponding auditor certificate
 	Auditors []string
+	// AuditorThreshold is the number of auditors that must sign a token request, zero means all of them
+	AuditorThreshold uint
+	// Enforcers is the list of enforcer MSP directories containing the corresponding enforcer certificate
+	Enforcers []string
 }
 
 // Gen generates the public parameters for the FabToken driver
@@ -94,6 +118,25 @@ func Gen(args *GeneratorArgs) ([]byte, error) {
 	if err := common.SetupIssuersAndAuditors(pp, args.Auditors, args.Issuers); err != nil {
 		return nil, err
 	}
+	if args.AuditorThreshold > uint(len(args.Auditors)) {
+		return nil, errors.Errorf("invalid auditor threshold [%d], greater than the number of auditors [%d]", args.AuditorThreshold, len(args.Auditors))
+	}
+	pp.SetAuditorThreshold(uint64(args.AuditorThreshold))
+	if err := common.SetupEnforcers(pp, args.Enforcers); err != nil {
+		return nil, errors.Wrap(err, "failed to setup enforcers")
+	}
+	if err := common.SetupIssuerPolicies(pp, args.IssuerPolicies); err != nil {
+		return nil, errors.Wrap(err, "failed to setup issuer policies")
+	}
+	if err := pp.IssuerPolicies.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid issuer policies")
+	}
+	if err := common.SetupFeePolicies(pp, args.FeePolicies); err != nil {
+		return nil, errors.Wrap(err, "failed to setup fee policies")
+	}
+	if err := pp.Fees.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid fee policies")
+	}
 	// Store Public Params
 	raw, err := pp.Serialize()
 	if err != nil {
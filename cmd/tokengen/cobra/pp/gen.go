@@ -9,6 +9,7 @@ package pp
 import (
 	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/pp/dlog"
 	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/pp/fabtoken"
+	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/pp/gh"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +17,7 @@ import (
 func GenCmd() *cobra.Command {
 	genCobraCommand.AddCommand(fabtoken.Cmd())
 	genCobraCommand.AddCommand(dlog.Cmd())
+	genCobraCommand.AddCommand(gh.Cmd())
 
 	return genCobraCommand
 }
@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gh
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	math3 "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/pp/cc"
+	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/pp/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/pp/idemix"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type GeneratorArgs struct {
+	// IdemixMSPDir is the directory containing the Idemix MSP config (Issuer Key Pair)
+	IdemixMSPDir string
+	// OutputDir is the directory to output the generated files
+	OutputDir string
+	// GenerateCCPackage indicates whether to generate the chaincode package
+	GenerateCCPackage bool
+	// Issuers is the list of issuer MSP directories containing the corresponding issuer certificate
+	Issuers []string
+	// Auditors is the list of auditor MSP directories containing the corresponding auditor certificate
+	Auditors []string
+	// AuditorThreshold is the number of auditors that must sign a token request, zero means all of them
+	AuditorThreshold uint
+	// AnonymitySetSize is the number of ledger tokens among which a spent token is hidden
+	AnonymitySetSize uint64
+	// Aries is a flag to indicate that aries should be used as backend for idemix
+	Aries bool
+	// AggregatedRangeProofs is a flag to indicate that the range proofs of the outputs of a transfer should be aggregated
+	AggregatedRangeProofs bool
+}
+
+var (
+	// IdemixMSPDir is the directory containing the Idemix MSP config (Issuer Key Pair)
+	IdemixMSPDir string
+	// OutputDir is the directory to output the generated files
+	OutputDir string
+	// GenerateCCPackage indicates whether to generate the chaincode package
+	GenerateCCPackage bool
+	// Issuers is the list of issuer MSP directories containing the corresponding issuer certificate
+	Issuers []string
+	// Auditors is the list of auditor MSP directories containing the corresponding auditor certificate
+	Auditors []string
+	// AuditorThreshold is the number of auditors that must sign a token request, zero means all of them
+	AuditorThreshold uint
+	// AnonymitySetSize is the number of ledger tokens among which a spent token is hidden.
+	// It must be a power of two.
+	AnonymitySetSize uint64
+	// Aries is a flag to indicate that aries should be used as backend for idemix
+	Aries bool
+	// AggregatedRangeProofs is a flag to indicate that the range proofs of the outputs of a transfer should be aggregated
+	AggregatedRangeProofs bool
+)
+
+// Cmd returns the Cobra Command for the generation of the ZKAT DLog public parameters with graph hiding
+func Cmd() *cobra.Command {
+	// Set the flags on the node start command.
+	flags := cobraCommand.Flags()
+	flags.StringVarP(&OutputDir, "output", "o", ".", "output folder")
+	flags.BoolVarP(&GenerateCCPackage, "cc", "", false, "generate chaincode package")
+	flags.StringSliceVarP(&Auditors, "auditors", "a", nil, "list of auditor MSP directories containing the corresponding auditor certificate")
+	flags.UintVarP(&AuditorThreshold, "auditor-threshold", "", 0, "number of auditors that must sign a token request, zero means all of them")
+	flags.StringSliceVarP(&Issuers, "issuers", "s", nil, "list of issuer MSP directories containing the corresponding issuer certificate")
+	flags.StringVarP(&IdemixMSPDir, "idemix", "i", "", "idemix msp dir")
+	flags.Uint64VarP(&AnonymitySetSize, "anonymity-set-size", "", setup.DefaultAnonymitySetSize, fmt.Sprintf("number of ledger tokens among which a spent token is hidden, a power of two between 2 and %d", setup.MaxAnonymitySetSize))
+	flags.BoolVarP(&Aries, "aries", "r", false, "flag to indicate that aries should be used as backend for idemix")
+	flags.BoolVarP(&AggregatedRangeProofs, "aggregated-range-proofs", "", false, "aggregate the range proofs of the outputs of a transfer into a single proof (requires public parameters version 2)")
+
+	return cobraCommand
+}
+
+var cobraCommand = &cobra.Command{
+	Use:   "gh",
+	Short: "Gen ZKAT DLog public parameters with graph hiding.",
+	Long:  `Generates ZKAT DLog public parameters with graph hiding.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		raw, err := Gen(&GeneratorArgs{
+			IdemixMSPDir:          IdemixMSPDir,
+			OutputDir:             OutputDir,
+			GenerateCCPackage:     GenerateCCPackage,
+			Issuers:               Issuers,
+			Auditors:              Auditors,
+			AuditorThreshold:      AuditorThreshold,
+			AnonymitySetSize:      AnonymitySetSize,
+			Aries:                 Aries,
+			AggregatedRangeProofs: AggregatedRangeProofs,
+		})
+		if err != nil {
+			fmt.Printf("failed to generate public parameters [%s]\n", err)
+			return errors.Wrap(err, "failed to generate public parameters")
+		}
+		// generate the chaincode package
+		if GenerateCCPackage {
+			fmt.Println("Generate chaincode package...")
+			if err := cc.GeneratePackage(raw, OutputDir); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// Gen generates the public parameters for the ZKATDLog driver with graph hiding
+func Gen(args *GeneratorArgs) ([]byte, error) {
+	// Load Idemix Issuer Public Key
+	_, ipkBytes, err := idemix.LoadIssuerPublicKey(args.IdemixMSPDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load issuer public key")
+	}
+
+	// Setup
+	curveID := math3.BN254
+	if args.Aries {
+		curveID = math3.BLS12_381_BBS
+	}
+	pp, err := setup.Setup(64, ipkBytes, curveID, args.AnonymitySetSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed setting up public parameters")
+	}
+	if err := common.SetupIssuersAndAuditors(pp, args.Auditors, args.Issuers); err != nil {
+		return nil, errors.Wrap(err, "failed to setup issuer and auditors")
+	}
+	pp.SetAuditorThreshold(uint64(args.AuditorThreshold))
+	if args.AggregatedRangeProofs {
+		pp.EnableAggregatedRangeProofs()
+	}
+	if err := pp.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "failed to validate public parameters")
+	}
+
+	// Store Public Params
+	raw, err := pp.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed serializing public parameters")
+	}
+	path := filepath.Join(args.OutputDir, "zkatdlog_gh_pp.json")
+	if err := os.WriteFile(path, raw, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed writing public parameters to file")
+	}
+
+	return raw, nil
+}
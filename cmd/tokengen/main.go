@@ -12,7 +12,9 @@ import (
 	"strings"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/artifactgen/gen"
+	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/backup"
 	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/certfier"
+	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/offline"
 	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/pp"
 	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/version"
 	"github.com/spf13/cobra"
@@ -49,6 +51,8 @@ func main() {
 	mainCmd.AddCommand(pp.UtilsCmd())
 	mainCmd.AddCommand(certfier.KeyPairGenCmd())
 	mainCmd.AddCommand(gen.Cmd())
+	mainCmd.AddCommand(offline.SignCmd())
+	mainCmd.AddCommand(backup.Cmd())
 	mainCmd.AddCommand(version.Cmd())
 
 	// On failure Cobra prints the usage message and error string, so we only
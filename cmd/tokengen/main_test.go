@@ -14,6 +14,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/pp/common"
+	ghsetup "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
 	v1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/setup"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/slices"
 	. "github.com/onsi/gomega"
@@ -66,6 +67,70 @@ func TestGenFullSuccess(t *testing.T) {
 	)
 }
 
+func TestGenGHSuccess(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	tokengen, err := gexec.Build("github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer gexec.CleanupBuildArtifacts()
+
+	tempOutput, err := os.MkdirTemp("", "tokengen-test")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(tempOutput)
+
+	testGenRun(
+		gt,
+		tokengen,
+		[]string{
+			"gen",
+			"gh",
+			"--idemix",
+			"./testdata/idemix",
+			"--issuers",
+			"./testdata/issuers/msp",
+			"--auditors",
+			"./testdata/auditors/msp",
+			"--anonymity-set-size",
+			"32",
+			"--output",
+			tempOutput,
+		},
+	)
+
+	ppRaw, err := os.ReadFile(filepath.Join(tempOutput, "zkatdlog_gh_pp.json"))
+	gt.Expect(err).NotTo(HaveOccurred())
+	pp, err := ghsetup.NewPublicParamsFromBytes(ppRaw, ghsetup.GHPublicParameters)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(pp.Validate()).NotTo(HaveOccurred())
+	gt.Expect(pp.AnonymitySetSize).To(BeEquivalentTo(32))
+
+	auditor, err := common.GetX509Identity("./testdata/auditors/msp")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(pp.Auditors()[0]).To(Equal(auditor))
+	issuer, err := common.GetX509Identity("./testdata/issuers/msp")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(pp.IssuerIDs[0]).To(BeEquivalentTo(issuer))
+
+	testGenRunWithError(
+		gt,
+		tokengen,
+		[]string{
+			"gen",
+			"gh",
+			"--idemix",
+			"./testdata/idemix",
+			"--issuers",
+			"./testdata/issuers/msp",
+			"--auditors",
+			"./testdata/auditors/msp",
+			"--anonymity-set-size",
+			"24",
+			"--output",
+			tempOutput,
+		},
+		"invalid public parameters: anonymity set size [24] must be a power of two",
+	)
+}
+
 func TestFullUpdate(t *testing.T) {
 	gt := NewWithT(t)
 	tokengen, err := gexec.Build("github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen")
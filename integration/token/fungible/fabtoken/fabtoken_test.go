@@ -46,7 +46,7 @@ func UpdatePublicParams(network *integration.Infrastructure, selector *token2.Re
 	publicParam := fabtokenv1.PublicParams{
 		Label:             "fabtoken",
 		QuantityPrecision: uint64(64),
-		Auditor:           auditorId,
+		AuditorIDs:        []driver.Identity{auditorId},
 		IssuerIDs:         []driver.Identity{issuerId},
 		MaxToken:          math.MaxUint64,
 	}
@@ -47,6 +47,16 @@ func (b *Backend) HasBeenSignedBy(id driver.Identity, verifier driver.Verifier)
 	return sigma, verifier.Verify(b.Message, sigma)
 }
 
+// Window returns a backend over the n signatures following the current cursor.
+// The returned backend shares the ledger and the signed message with this one.
+func (b *Backend) Window(offset, n int) (*Backend, error) {
+	start := b.Cursor + offset
+	if start+n > len(b.Sigs) {
+		return nil, errors.Errorf("invalid state, insufficient number of signatures, expected at least [%d], got [%d]", start+n, len(b.Sigs))
+	}
+	return &Backend{Logger: b.Logger, Ledger: b.Ledger, Message: b.Message, Sigs: b.Sigs[start : start+n]}, nil
+}
+
 func (b *Backend) GetState(id token.ID) ([]byte, error) {
 	return b.Ledger(id)
 }
@@ -75,6 +75,15 @@ func Unmarshal[S Serializer](data []byte, values ...S) error {
 	return nil
 }
 
+// NumValues returns the number of values marshalled by Marshal in the passed data
+func NumValues(data []byte) (int, error) {
+	v := &Values{}
+	if _, err := asn1.Unmarshal(data, v); err != nil {
+		return 0, errors.Wrapf(err, "failed to unmarshal values")
+	}
+	return len(v.Values), nil
+}
+
 func UnmarshalTo[S Serializer](data []byte, newFunction func() S) ([]S, error) {
 	v := &Values{}
 	_, err := asn1.Unmarshal(data, v)
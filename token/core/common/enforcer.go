@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"slices"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// EnforcerVerifier returns the verifier of the passed enforcer.
+// It returns an error if the enforcer is not listed in the passed public parameters.
+// Enforcers, as auditors, sign with long-term identities.
+func EnforcerVerifier(pp driver.PublicParameters, deserializer driver.Deserializer, enforcer driver.Identity) (driver.Verifier, error) {
+	if enforcer.IsNone() {
+		return nil, errors.New("missing enforcer")
+	}
+	if !slices.ContainsFunc(pp.Enforcers(), enforcer.Equal) {
+		return nil, errors.Errorf("[%s] is not an enforcer", enforcer)
+	}
+	verifier, err := deserializer.GetAuditorVerifier(enforcer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed deserializing enforcer [%s]", enforcer)
+	}
+	return verifier, nil
+}
+
+// CheckNotFrozen checks that none of the passed input tokens, and none of their owners, is frozen on the passed ledger.
+// A nil ledger means that the validator has no access to the freezes. Then, the check fails if the passed public parameters
+// declare enforcers, because frozen tokens could be spent, and it is skipped otherwise.
+// Recall that a forced transfer can spend frozen tokens, therefore it must not be checked.
+func CheckNotFrozen(pp driver.PublicParameters, freezes driver.FreezeLedger, inputs []*token.ID, owners []driver.Identity) error {
+	if freezes == nil {
+		if len(pp.Enforcers()) != 0 {
+			return errors.New("cannot check the freezes, no freeze ledger available")
+		}
+		return nil
+	}
+	for _, id := range inputs {
+		frozen, err := freezes.IsTokenFrozen(*id)
+		if err != nil {
+			return errors.Wrapf(err, "failed checking if token [%s] is frozen", id)
+		}
+		if frozen {
+			return errors.Errorf("token [%s] is frozen", id)
+		}
+	}
+	for _, owner := range owners {
+		frozen, err := freezes.IsOwnerFrozen(owner)
+		if err != nil {
+			return errors.Wrapf(err, "failed checking if owner [%s] is frozen", owner)
+		}
+		if frozen {
+			return errors.Errorf("owner [%s] is frozen", owner)
+		}
+	}
+	return nil
+}
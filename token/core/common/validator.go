@@ -9,9 +9,13 @@ package common
 import (
 	"context"
 	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 )
 
@@ -20,8 +24,62 @@ type MetadataCounterID = string
 const (
 	TokenRequestToSign     driver.ValidationAttributeID = "trs"
 	TokenRequestSignatures driver.ValidationAttributeID = "sigs"
+
+	// ValidatorWorkersKey is the configuration key, relative to the TMS, of the number of actions verified concurrently
+	ValidatorWorkersKey = "validator.workers"
+	// ValidatorMaxClockSkewKey is the configuration key, relative to the TMS, of the maximum distance
+	// between the timestamp of a transaction and the clock of the node validating it
+	ValidatorMaxClockSkewKey = "validator.maxClockSkew"
+
+	// DefaultMaxClockSkew is the maximum clock skew used when none is configured
+	DefaultMaxClockSkew = time.Minute
 )
 
+// ValidatorWorkers returns the number of actions to verify concurrently as set in the passed configuration.
+// It returns zero, meaning sequential verification, if the key is not set.
+func ValidatorWorkers(configuration driver.Configuration) (int, error) {
+	if configuration == nil || !configuration.IsSet(ValidatorWorkersKey) {
+		return 0, nil
+	}
+	var workers int
+	if err := configuration.UnmarshalKey(ValidatorWorkersKey, &workers); err != nil {
+		return 0, errors.Wrapf(err, "failed to load [%s]", ValidatorWorkersKey)
+	}
+	return workers, nil
+}
+
+// KeyReader reads the configuration keys of a TMS, as driver.Configuration and token.Configuration do
+type KeyReader interface {
+	IsSet(key string) bool
+	UnmarshalKey(key string, rawVal interface{}) error
+}
+
+// ValidatorMaxClockSkew returns the maximum clock skew set in the passed configuration.
+// It returns DefaultMaxClockSkew if the key is not set.
+func ValidatorMaxClockSkew(configuration KeyReader) (time.Duration, error) {
+	if configuration == nil || !configuration.IsSet(ValidatorMaxClockSkewKey) {
+		return DefaultMaxClockSkew, nil
+	}
+	var skew time.Duration
+	if err := configuration.UnmarshalKey(ValidatorMaxClockSkewKey, &skew); err != nil {
+		return 0, errors.Wrapf(err, "failed to load [%s]", ValidatorMaxClockSkewKey)
+	}
+	if skew <= 0 {
+		return 0, errors.Errorf("invalid [%s], it must be positive, got [%s]", ValidatorMaxClockSkewKey, skew)
+	}
+	return skew, nil
+}
+
+// CheckClockSkew checks that the passed timestamp, set by the creator of a transaction, is within maxSkew of now.
+// The validators check time-dependent conditions against the timestamp of the transaction,
+// a sender must not be able to move it to spend expired tokens or to anticipate not-before times and timelocks.
+func CheckClockSkew(timestamp time.Time, now time.Time, maxSkew time.Duration) error {
+	if d := timestamp.Sub(now); d > maxSkew || d < -maxSkew {
+		return errors.Errorf("timestamp [%s] is too far from the local time [%s], the maximum skew is [%s]", timestamp.UTC(), now.UTC(), maxSkew)
+	}
+	return nil
+}
+
 type Context[P driver.PublicParameters, T any, TA driver.TransferAction, IA driver.IssueAction, DS driver.Deserializer] struct {
 	Logger            logging.Logger
 	PP                P
@@ -34,6 +92,10 @@ type Context[P driver.PublicParameters, T any, TA driver.TransferAction, IA driv
 	Ledger            driver.Ledger
 	MetadataCounter   map[MetadataCounterID]int
 	Attributes        driver.ValidationAttributes
+	// Time is the time the token request is validated against, for instance the timestamp of its transaction
+	Time time.Time
+	// Freezes is the ledger the freezes are checked against, nil if the validator has no access to the ledger
+	Freezes driver.FreezeLedger
 }
 
 func (c *Context[P, T, TA, IA, DS]) CountMetadataKey(key string) {
@@ -55,6 +117,12 @@ type Validator[P driver.PublicParameters, T any, TA driver.TransferAction, IA dr
 	ActionDeserializer ActionDeserializer[TA, IA]
 	TransferValidators []ValidateTransferFunc[P, T, TA, IA, DS]
 	IssueValidators    []ValidateIssueFunc[P, T, TA, IA, DS]
+	// Workers is the number of actions verified concurrently. If lower than 2, actions are verified sequentially.
+	// Concurrent verification requires that the validators consume one signature per issue action and
+	// one signature per transfer input, and that they do not write the validation attributes.
+	// The validators can read the ledger, the reads are serialized because the ledger,
+	// for instance a read-write set, is not required to support concurrent access.
+	Workers int
 }
 
 func NewValidator[P driver.PublicParameters, T any, TA driver.TransferAction, IA driver.IssueAction, DS driver.Deserializer](
@@ -91,8 +159,16 @@ func (v *Validator[P, T, TA, IA, DS]) VerifyTokenRequestFromRaw(ctx context.Cont
 		return nil, nil, errors.Wrap(err, "failed to marshal signed token request")
 	}
 	var signatures [][]byte
-	if len(v.PublicParams.Auditors()) != 0 {
+	if auditors := v.PublicParams.Auditors(); len(auditors) != 0 {
+		// auditor signatures are positional, one per auditor in the public parameters.
+		// Missing trailing signatures correspond to auditors that did not sign.
+		if len(tr.AuditorSignatures) > len(auditors) {
+			return nil, nil, errors.Errorf("invalid number of auditor signatures, expected at most [%d], got [%d]", len(auditors), len(tr.AuditorSignatures))
+		}
 		signatures = append(signatures, tr.AuditorSignatures...)
+		for i := len(tr.AuditorSignatures); i < len(auditors); i++ {
+			signatures = append(signatures, nil)
+		}
 		signatures = append(signatures, tr.Signatures...)
 	} else {
 		signatures = tr.Signatures
@@ -106,10 +182,12 @@ func (v *Validator[P, T, TA, IA, DS]) VerifyTokenRequestFromRaw(ctx context.Cont
 	}
 
 	backend := NewBackend(v.Logger, getState, signed, signatures)
-	return v.VerifyTokenRequest(backend, backend, anchor, tr, attributes)
+	return v.VerifyTokenRequest(ctx, backend, backend, anchor, tr, attributes)
 }
 
-func (v *Validator[P, T, TA, IA, DS]) VerifyTokenRequest(ledger driver.Ledger, signatureProvider driver.SignatureProvider, anchor string, tr *driver.TokenRequest, attributes driver.ValidationAttributes) ([]interface{}, driver.ValidationAttributes, error) {
+// VerifyTokenRequest verifies the passed token request against the passed ledger and signatures.
+// The time-dependent checks use the time carried by the passed context, see driver.ValidationTime.
+func (v *Validator[P, T, TA, IA, DS]) VerifyTokenRequest(ctx context.Context, ledger driver.Ledger, signatureProvider driver.SignatureProvider, anchor string, tr *driver.TokenRequest, attributes driver.ValidationAttributes) ([]interface{}, driver.ValidationAttributes, error) {
 	if err := v.verifyAuditorSignature(signatureProvider, attributes); err != nil {
 		return nil, nil, errors.Wrapf(err, "failed to verifier auditor's signature [%s]", anchor)
 	}
@@ -117,13 +195,29 @@ func (v *Validator[P, T, TA, IA, DS]) VerifyTokenRequest(ledger driver.Ledger, s
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "failed to unmarshal actions [%s]", anchor)
 	}
-	err = v.verifyIssues(ledger, ia, signatureProvider, attributes)
-	if err != nil {
-		return nil, nil, errors.Wrapf(err, "failed to verify issue actions [%s]", anchor)
+	now := driver.ValidationTime(ctx)
+	freezes := driver.GetFreezeLedger(ctx)
+	if backend, ok := signatureProvider.(*Backend); ok && v.Workers > 1 && len(ia)+len(ta) > 1 {
+		issueErr, transferErr := v.verifyActionsInParallel(now, ledger, freezes, backend, ia, ta, attributes)
+		if issueErr != nil {
+			return nil, nil, errors.Wrapf(issueErr, "failed to verify issue actions [%s]", anchor)
+		}
+		if transferErr != nil {
+			return nil, nil, errors.Wrapf(transferErr, "failed to verify transfer actions [%s]", anchor)
+		}
+	} else {
+		err = v.verifyIssues(now, ledger, ia, signatureProvider, attributes)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to verify issue actions [%s]", anchor)
+		}
+		err = v.verifyTransfers(now, ledger, freezes, ta, signatureProvider, attributes)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to verify transfer actions [%s]", anchor)
+		}
 	}
-	err = v.verifyTransfers(ledger, ta, signatureProvider, attributes)
+	fa, err := v.verifyFreezes(tr.Freezes, signatureProvider)
 	if err != nil {
-		return nil, nil, errors.Wrapf(err, "failed to verify transfer actions [%s]", anchor)
+		return nil, nil, errors.Wrapf(err, "failed to verify freeze actions [%s]", anchor)
 	}
 
 	var actions []interface{}
@@ -133,6 +227,9 @@ func (v *Validator[P, T, TA, IA, DS]) VerifyTokenRequest(ledger driver.Ledger, s
 	for _, action := range ta {
 		actions = append(actions, action)
 	}
+	for _, action := range fa {
+		actions = append(actions, action)
+	}
 	return actions, attributes, nil
 }
 
@@ -154,33 +251,58 @@ func (v *Validator[P, T, TA, IA, DS]) UnmarshalActions(raw []byte) ([]interface{
 	for _, action := range ta {
 		res = append(res, action)
 	}
+	for i, raw := range tr.Freezes {
+		action := &driver.FreezeAction{}
+		if err := action.Deserialize(raw); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal freeze action at [%d]", i)
+		}
+		res = append(res, action)
+	}
 	return res, nil
 }
 
+// verifyAuditorSignature checks that the token request has been signed by the number of auditors
+// required by the public parameters. There is a signature slot for each auditor, an empty slot means
+// that the corresponding auditor did not sign. A non-empty invalid signature makes the request invalid.
 func (v *Validator[P, T, TA, IA, DS]) verifyAuditorSignature(signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes) error {
-	if len(v.PublicParams.Auditors()) != 0 {
-		auditor := v.PublicParams.Auditors()[0]
+	auditors := v.PublicParams.Auditors()
+	if len(auditors) == 0 {
+		return nil
+	}
+	// the validation of the public parameters rejects repeated auditors, then each signature counts towards the threshold
+	signed := 0
+	for i, auditor := range auditors {
 		verifier, err := v.Deserializer.GetAuditorVerifier(auditor)
 		if err != nil {
-			return errors.Errorf("failed to deserialize auditor's public key")
+			return errors.Errorf("failed to deserialize auditor's public key at [%d]", i)
 		}
 		v.Logger.Infof("verify auditor signature for [%s]", auditor)
-		_, err = signatureProvider.HasBeenSignedBy(auditor, verifier)
-		return err
+		sigma, err := signatureProvider.HasBeenSignedBy(auditor, verifier)
+		if len(sigma) == 0 {
+			v.Logger.Debugf("auditor [%s] did not sign", auditor)
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "invalid signature for auditor at [%d]", i)
+		}
+		signed++
+	}
+	if required := v.PublicParams.RequiredAuditorSignatures(); signed < required {
+		return errors.Errorf("insufficient number of auditor signatures, expected [%d], got [%d]", required, signed)
 	}
 	return nil
 }
 
-func (v *Validator[P, T, TA, IA, DS]) verifyIssues(ledger driver.Ledger, issues []IA, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes) error {
+func (v *Validator[P, T, TA, IA, DS]) verifyIssues(now time.Time, ledger driver.Ledger, issues []IA, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes) error {
 	for i, issue := range issues {
-		if err := v.verifyIssue(issue, ledger, signatureProvider, attributes); err != nil {
+		if err := v.verifyIssue(now, issue, ledger, signatureProvider, attributes); err != nil {
 			return errors.Wrapf(err, "failed to verify issue action at [%d]", i)
 		}
 	}
 	return nil
 }
 
-func (v *Validator[P, T, TA, IA, DS]) verifyIssue(tr IA, ledger driver.Ledger, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes) error {
+func (v *Validator[P, T, TA, IA, DS]) verifyIssue(now time.Time, tr IA, ledger driver.Ledger, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes) error {
 	context := &Context[P, T, TA, IA, DS]{
 		Logger:            v.Logger,
 		PP:                v.PublicParams,
@@ -190,6 +312,7 @@ func (v *Validator[P, T, TA, IA, DS]) verifyIssue(tr IA, ledger driver.Ledger, s
 		SignatureProvider: signatureProvider,
 		MetadataCounter:   map[string]int{},
 		Attributes:        attributes,
+		Time:              now,
 	}
 	for _, v := range v.IssueValidators {
 		if err := v(context); err != nil {
@@ -212,18 +335,18 @@ func (v *Validator[P, T, TA, IA, DS]) verifyIssue(tr IA, ledger driver.Ledger, s
 	return nil
 }
 
-func (v *Validator[P, T, TA, IA, DS]) verifyTransfers(ledger driver.Ledger, transferActions []TA, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes) error {
+func (v *Validator[P, T, TA, IA, DS]) verifyTransfers(now time.Time, ledger driver.Ledger, freezes driver.FreezeLedger, transferActions []TA, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes) error {
 	v.Logger.Debugf("check sender start...")
 	defer v.Logger.Debugf("check sender finished.")
 	for i, action := range transferActions {
-		if err := v.verifyTransfer(action, ledger, signatureProvider, attributes); err != nil {
+		if err := v.verifyTransfer(now, action, ledger, freezes, signatureProvider, attributes); err != nil {
 			return errors.Wrapf(err, "failed to verify transfer action at [%d]", i)
 		}
 	}
 	return nil
 }
 
-func (v *Validator[P, T, TA, IA, DS]) verifyTransfer(tr TA, ledger driver.Ledger, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes) error {
+func (v *Validator[P, T, TA, IA, DS]) verifyTransfer(now time.Time, tr TA, ledger driver.Ledger, freezes driver.FreezeLedger, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes) error {
 	context := &Context[P, T, TA, IA, DS]{
 		Logger:            v.Logger,
 		PP:                v.PublicParams,
@@ -233,6 +356,8 @@ func (v *Validator[P, T, TA, IA, DS]) verifyTransfer(tr TA, ledger driver.Ledger
 		SignatureProvider: signatureProvider,
 		MetadataCounter:   map[MetadataCounterID]int{},
 		Attributes:        attributes,
+		Time:              now,
+		Freezes:           freezes,
 	}
 	for _, v := range v.TransferValidators {
 		if err := v(context); err != nil {
@@ -255,6 +380,137 @@ func (v *Validator[P, T, TA, IA, DS]) verifyTransfer(tr TA, ledger driver.Ledger
 	return nil
 }
 
+// verifyFreezes checks that the freeze actions are well-formed and signed by an enforcer listed in the public parameters.
+// The signatures of the enforcers follow those of the issue and transfer actions.
+func (v *Validator[P, T, TA, IA, DS]) verifyFreezes(freezes [][]byte, signatureProvider driver.SignatureProvider) ([]*driver.FreezeAction, error) {
+	actions := make([]*driver.FreezeAction, len(freezes))
+	for i, raw := range freezes {
+		action := &driver.FreezeAction{}
+		if err := action.Deserialize(raw); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal freeze action at [%d]", i)
+		}
+		if err := action.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "failed to verify freeze action at [%d]", i)
+		}
+		verifier, err := EnforcerVerifier(v.PublicParams, v.Deserializer, action.Enforcer)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to verify freeze action at [%d]", i)
+		}
+		if _, err := signatureProvider.HasBeenSignedBy(action.Enforcer, verifier); err != nil {
+			return nil, errors.Wrapf(err, "failed to verify the signature of the enforcer of the freeze action at [%d]", i)
+		}
+		actions[i] = action
+	}
+	return actions, nil
+}
+
+// verifyActionsInParallel verifies the passed actions with a pool of v.Workers goroutines.
+// Each action gets its own window of the signatures, in the order they would be consumed by a sequential verification.
+// The reported error is the one of the first failing action in request order, issues first, as in the sequential case.
+func (v *Validator[P, T, TA, IA, DS]) verifyActionsInParallel(now time.Time, ledger driver.Ledger, freezes driver.FreezeLedger, backend *Backend, issues []IA, transfers []TA, attributes driver.ValidationAttributes) (error, error) {
+	n := len(issues) + len(transfers)
+	windows := make([]*Backend, n)
+	expected := make([]int, n)
+	offset := 0
+	for i := 0; i < n; i++ {
+		expected[i] = 1
+		if i >= len(issues) {
+			expected[i] = transfers[i-len(issues)].NumInputs()
+		}
+		window, err := backend.Window(offset, expected[i])
+		if err != nil {
+			if i < len(issues) {
+				return errors.Wrapf(err, "failed to verify issue action at [%d]", i), nil
+			}
+			return nil, errors.Wrapf(err, "failed to verify transfer action at [%d]", i-len(issues))
+		}
+		windows[i] = window
+		offset += expected[i]
+	}
+
+	// the workers share the ledger, serialize its access
+	sl := &syncLedger{Ledger: ledger, Freezes: freezes}
+	ledger = sl
+	if freezes != nil {
+		freezes = sl
+	}
+
+	errs := make([]error, n)
+	var firstFailure atomic.Int64
+	firstFailure.Store(int64(n))
+	indices := make(chan int, n)
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	var wg sync.WaitGroup
+	for w := 0; w < min(v.Workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				// skip the actions following a failing one, their outcome cannot change the result
+				if int64(i) > firstFailure.Load() {
+					continue
+				}
+				var err error
+				if i < len(issues) {
+					err = v.verifyIssue(now, issues[i], ledger, windows[i], attributes)
+				} else {
+					err = v.verifyTransfer(now, transfers[i-len(issues)], ledger, freezes, windows[i], attributes)
+				}
+				if err == nil && windows[i].Cursor != expected[i] {
+					err = errors.Errorf("invalid number of signatures verified, expected [%d], got [%d]", expected[i], windows[i].Cursor)
+				}
+				if err != nil {
+					errs[i] = err
+					for {
+						current := firstFailure.Load()
+						if int64(i) >= current || firstFailure.CompareAndSwap(current, int64(i)) {
+							break
+						}
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if first := int(firstFailure.Load()); first < n {
+		if first < len(issues) {
+			return errors.Wrapf(errs[first], "failed to verify issue action at [%d]", first), nil
+		}
+		return nil, errors.Wrapf(errs[first], "failed to verify transfer action at [%d]", first-len(issues))
+	}
+	backend.Cursor += offset
+	return nil, nil
+}
+
+// syncLedger serializes the access to a ledger, and its freezes, shared by concurrent validators
+type syncLedger struct {
+	lock    sync.Mutex
+	Ledger  driver.Ledger
+	Freezes driver.FreezeLedger
+}
+
+func (l *syncLedger) GetState(id token.ID) ([]byte, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.Ledger.GetState(id)
+}
+
+func (l *syncLedger) IsTokenFrozen(id token.ID) (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.Freezes.IsTokenFrozen(id)
+}
+
+func (l *syncLedger) IsOwnerFrozen(owner driver.Identity) (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.Freezes.IsOwnerFrozen(owner)
+}
+
 func IsAnyNil[T any](args ...*T) bool {
 	for _, arg := range args {
 		if arg == nil {
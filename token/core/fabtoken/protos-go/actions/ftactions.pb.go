@@ -256,6 +256,7 @@ type TransferAction struct {
 	Inputs   []*TransferActionInput  `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`                                                                                             // inputs
 	Outputs  []*TransferActionOutput `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`                                                                                           // outputs
 	Metadata map[string][]byte       `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Metadata contains the transfer action's metadata
+	Enforcer *pp.Identity            `protobuf:"bytes,5,opt,name=enforcer,proto3" json:"enforcer,omitempty"`                                                                                         // is the enforcer that forces the transfer, if any. It signs in place of the owners of the inputs
 }
 
 func (x *TransferAction) Reset() {
@@ -318,6 +319,13 @@ func (x *TransferAction) GetMetadata() map[string][]byte {
 	return nil
 }
 
+func (x *TransferAction) GetEnforcer() *pp.Identity {
+	if x != nil {
+		return x.Enforcer
+	}
+	return nil
+}
+
 type IssueActionInput struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -523,7 +531,7 @@ var file_ftactions_proto_rawDesc = []byte{
 	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x25, 0x0a, 0x05, 0x74,
 	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x66, 0x61, 0x62,
 	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x05, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x22, 0x9c, 0x02, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41,
+	0x65, 0x6e, 0x22, 0xcc, 0x02, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41,
 	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
 	0x35, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
@@ -537,7 +545,10 @@ var file_ftactions_proto_rawDesc = []byte{
 	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x66, 0x61, 0x62, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2e, 0x54, 0x72,
 	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74,
 	0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x2e, 0x0a, 0x08, 0x65, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x61, 0x62, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x08, 0x65, 0x6e, 0x66, 0x6f,
+	0x72, 0x63, 0x65, 0x72, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
 	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
@@ -611,17 +622,18 @@ var file_ftactions_proto_depIdxs = []int32{
 	2,  // 3: fabtoken.TransferAction.inputs:type_name -> fabtoken.TransferActionInput
 	3,  // 4: fabtoken.TransferAction.outputs:type_name -> fabtoken.TransferActionOutput
 	8,  // 5: fabtoken.TransferAction.metadata:type_name -> fabtoken.TransferAction.MetadataEntry
-	1,  // 6: fabtoken.IssueActionInput.id:type_name -> fabtoken.TokenID
-	0,  // 7: fabtoken.IssueActionOutput.token:type_name -> fabtoken.Token
-	10, // 8: fabtoken.IssueAction.issuer:type_name -> fabtoken.Identity
-	5,  // 9: fabtoken.IssueAction.inputs:type_name -> fabtoken.IssueActionInput
-	6,  // 10: fabtoken.IssueAction.outputs:type_name -> fabtoken.IssueActionOutput
-	9,  // 11: fabtoken.IssueAction.metadata:type_name -> fabtoken.IssueAction.MetadataEntry
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	10, // 6: fabtoken.TransferAction.enforcer:type_name -> fabtoken.Identity
+	1,  // 7: fabtoken.IssueActionInput.id:type_name -> fabtoken.TokenID
+	0,  // 8: fabtoken.IssueActionOutput.token:type_name -> fabtoken.Token
+	10, // 9: fabtoken.IssueAction.issuer:type_name -> fabtoken.Identity
+	5,  // 10: fabtoken.IssueAction.inputs:type_name -> fabtoken.IssueActionInput
+	6,  // 11: fabtoken.IssueAction.outputs:type_name -> fabtoken.IssueActionOutput
+	9,  // 12: fabtoken.IssueAction.metadata:type_name -> fabtoken.IssueAction.MetadataEntry
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_ftactions_proto_init() }
@@ -73,24 +73,157 @@ func (x *Identity) GetRaw() []byte {
 	return nil
 }
 
+// IssuerPolicy restricts the issuers of the token types matching a pattern
+type IssuerPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TokenType string      `protobuf:"bytes,1,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"` // is a token type, or a prefix of token types followed by '*'
+	Issuers   []*Identity `protobuf:"bytes,2,rep,name=issuers,proto3" json:"issuers,omitempty"`                      // are the public keys of the entities that can issue the matching token types
+}
+
+func (x *IssuerPolicy) Reset() {
+	*x = IssuerPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ftpp_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IssuerPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssuerPolicy) ProtoMessage() {}
+
+func (x *IssuerPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_ftpp_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssuerPolicy.ProtoReflect.Descriptor instead.
+func (*IssuerPolicy) Descriptor() ([]byte, []int) {
+	return file_ftpp_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IssuerPolicy) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *IssuerPolicy) GetIssuers() []*Identity {
+	if x != nil {
+		return x.Issuers
+	}
+	return nil
+}
+
+// FeePolicy charges a fee on the transfers of the token types matching a pattern
+type FeePolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TokenType string    `protobuf:"bytes,1,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"` // is a token type, or a prefix of token types followed by '*'
+	Flat      uint64    `protobuf:"varint,2,opt,name=flat,proto3" json:"flat,omitempty"`                           // is the fee charged on each transfer with at least one payment
+	Rate      uint64    `protobuf:"varint,3,opt,name=rate,proto3" json:"rate,omitempty"`                           // is the fee charged on the value of the payments, in basis points
+	Collector *Identity `protobuf:"bytes,4,opt,name=collector,proto3" json:"collector,omitempty"`                  // is the owner of the fee outputs
+}
+
+func (x *FeePolicy) Reset() {
+	*x = FeePolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ftpp_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeePolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeePolicy) ProtoMessage() {}
+
+func (x *FeePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_ftpp_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeePolicy.ProtoReflect.Descriptor instead.
+func (*FeePolicy) Descriptor() ([]byte, []int) {
+	return file_ftpp_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FeePolicy) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *FeePolicy) GetFlat() uint64 {
+	if x != nil {
+		return x.Flat
+	}
+	return 0
+}
+
+func (x *FeePolicy) GetRate() uint64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *FeePolicy) GetCollector() *Identity {
+	if x != nil {
+		return x.Collector
+	}
+	return nil
+}
+
 // PublicParameters describes typed public parameters
 type PublicParameters struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Identifier        string      `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`                                          // the identifier of the public parameters
-	Version           uint64      `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`                                               // the version of these public params
-	Auditor           *Identity   `protobuf:"bytes,7,opt,name=auditor,proto3" json:"auditor,omitempty"`                                                // is the public key of the auditor.
-	Issuers           []*Identity `protobuf:"bytes,8,rep,name=issuers,proto3" json:"issuers,omitempty"`                                                // is a list of public keys of the entities that can issue tokens.
-	MaxToken          uint64      `protobuf:"varint,9,opt,name=max_token,json=maxToken,proto3" json:"max_token,omitempty"`                             // is the maximum quantity a token can hold
-	QuantityPrecision uint64      `protobuf:"varint,10,opt,name=quantity_precision,json=quantityPrecision,proto3" json:"quantity_precision,omitempty"` // is the precision used to represent quantities
+	Identifier         string          `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`                                            // the identifier of the public parameters
+	Version            uint64          `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`                                                 // the version of these public params
+	Auditor            *Identity       `protobuf:"bytes,7,opt,name=auditor,proto3" json:"auditor,omitempty"`                                                  // is the public key of the auditor.
+	Issuers            []*Identity     `protobuf:"bytes,8,rep,name=issuers,proto3" json:"issuers,omitempty"`                                                  // is a list of public keys of the entities that can issue tokens.
+	MaxToken           uint64          `protobuf:"varint,9,opt,name=max_token,json=maxToken,proto3" json:"max_token,omitempty"`                               // is the maximum quantity a token can hold
+	QuantityPrecision  uint64          `protobuf:"varint,10,opt,name=quantity_precision,json=quantityPrecision,proto3" json:"quantity_precision,omitempty"`   // is the precision used to represent quantities
+	AdditionalAuditors []*Identity     `protobuf:"bytes,11,rep,name=additional_auditors,json=additionalAuditors,proto3" json:"additional_auditors,omitempty"` // are the public keys of the auditors other than the one in auditor.
+	AuditorThreshold   uint64          `protobuf:"varint,12,opt,name=auditor_threshold,json=auditorThreshold,proto3" json:"auditor_threshold,omitempty"`      // is the number of auditors that must sign a token request. Zero means all of them.
+	IssuerPolicies     []*IssuerPolicy `protobuf:"bytes,13,rep,name=issuer_policies,json=issuerPolicies,proto3" json:"issuer_policies,omitempty"`             // restrict the issuers of specific token types. Token types not matched by any policy can be issued by the entities in issuers.
+	FeePolicies        []*FeePolicy    `protobuf:"bytes,14,rep,name=fee_policies,json=feePolicies,proto3" json:"fee_policies,omitempty"`                      // are the fees charged on transfers, by token type.
+	Enforcers          []*Identity     `protobuf:"bytes,15,rep,name=enforcers,proto3" json:"enforcers,omitempty"`                                             // are the public keys of the enforcers that can freeze tokens and owners, and force transfers.
 }
 
 func (x *PublicParameters) Reset() {
 	*x = PublicParameters{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ftpp_proto_msgTypes[1]
+		mi := &file_ftpp_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -103,7 +236,7 @@ func (x *PublicParameters) String() string {
 func (*PublicParameters) ProtoMessage() {}
 
 func (x *PublicParameters) ProtoReflect() protoreflect.Message {
-	mi := &file_ftpp_proto_msgTypes[1]
+	mi := &file_ftpp_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -116,7 +249,7 @@ func (x *PublicParameters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublicParameters.ProtoReflect.Descriptor instead.
 func (*PublicParameters) Descriptor() ([]byte, []int) {
-	return file_ftpp_proto_rawDescGZIP(), []int{1}
+	return file_ftpp_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PublicParameters) GetIdentifier() string {
@@ -161,34 +294,101 @@ func (x *PublicParameters) GetQuantityPrecision() uint64 {
 	return 0
 }
 
+func (x *PublicParameters) GetAdditionalAuditors() []*Identity {
+	if x != nil {
+		return x.AdditionalAuditors
+	}
+	return nil
+}
+
+func (x *PublicParameters) GetAuditorThreshold() uint64 {
+	if x != nil {
+		return x.AuditorThreshold
+	}
+	return 0
+}
+
+func (x *PublicParameters) GetIssuerPolicies() []*IssuerPolicy {
+	if x != nil {
+		return x.IssuerPolicies
+	}
+	return nil
+}
+
+func (x *PublicParameters) GetFeePolicies() []*FeePolicy {
+	if x != nil {
+		return x.FeePolicies
+	}
+	return nil
+}
+
+func (x *PublicParameters) GetEnforcers() []*Identity {
+	if x != nil {
+		return x.Enforcers
+	}
+	return nil
+}
+
 var File_ftpp_proto protoreflect.FileDescriptor
 
 var file_ftpp_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x66, 0x74, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x66, 0x61,
 	0x62, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x1c, 0x0a, 0x08, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
 	0x74, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x03, 0x72, 0x61, 0x77, 0x22, 0xf4, 0x01, 0x0a, 0x10, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x50,
-	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x64, 0x65,
-	0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69,
-	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x07, 0x61, 0x75, 0x64, 0x69, 0x74, 0x6f, 0x72, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x61, 0x62, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2e,
-	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x07, 0x61, 0x75, 0x64, 0x69, 0x74, 0x6f,
-	0x72, 0x12, 0x2c, 0x0a, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x61, 0x62, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2e, 0x49, 0x64,
-	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x12,
-	0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2d, 0x0a, 0x12,
-	0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69,
-	0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69,
-	0x74, 0x79, 0x50, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x4f, 0x5a, 0x4d, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c,
-	0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x66, 0x61, 0x62, 0x72, 0x69,
-	0x63, 0x2d, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2d, 0x73, 0x64, 0x6b, 0x2f, 0x74, 0x6f, 0x6b, 0x65,
-	0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x66, 0x61, 0x62, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2d, 0x67, 0x6f, 0x2f, 0x70, 0x70, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x03, 0x72, 0x61, 0x77, 0x22, 0x5b, 0x0a, 0x0c, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x61, 0x62, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2e,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72,
+	0x73, 0x22, 0x84, 0x01, 0x0a, 0x09, 0x46, 0x65, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
+	0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x6c, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x6c,
+	0x61, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x61, 0x62, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x09, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x91, 0x04, 0x0a, 0x10, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x07, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x61, 0x62, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x07, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x2c, 0x0a, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x61, 0x62, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x07, 0x69, 0x73, 0x73, 0x75,
+	0x65, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x2d, 0x0a, 0x12, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x72, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x50, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x43, 0x0a, 0x13, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66,
+	0x61, 0x62, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x52, 0x12, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x41, 0x75, 0x64, 0x69,
+	0x74, 0x6f, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x75, 0x64, 0x69, 0x74, 0x6f, 0x72, 0x5f,
+	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x10, 0x61, 0x75, 0x64, 0x69, 0x74, 0x6f, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x12, 0x3f, 0x0a, 0x0f, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x6c, 0x69,
+	0x63, 0x69, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x61, 0x62,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x52, 0x0e, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69,
+	0x65, 0x73, 0x12, 0x36, 0x0a, 0x0c, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69,
+	0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x66, 0x61, 0x62, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x2e, 0x46, 0x65, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0b, 0x66,
+	0x65, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x09, 0x65, 0x6e,
+	0x66, 0x6f, 0x72, 0x63, 0x65, 0x72, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x66, 0x61, 0x62, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x52, 0x09, 0x65, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x72, 0x73, 0x42, 0x4f, 0x5a, 0x4d,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72,
+	0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x66, 0x61, 0x62, 0x72,
+	0x69, 0x63, 0x2d, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2d, 0x73, 0x64, 0x6b, 0x2f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x66, 0x61, 0x62, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2d, 0x67, 0x6f, 0x2f, 0x70, 0x70, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -203,19 +403,27 @@ func file_ftpp_proto_rawDescGZIP() []byte {
 	return file_ftpp_proto_rawDescData
 }
 
-var file_ftpp_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_ftpp_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_ftpp_proto_goTypes = []interface{}{
 	(*Identity)(nil),         // 0: fabtoken.Identity
-	(*PublicParameters)(nil), // 1: fabtoken.PublicParameters
+	(*IssuerPolicy)(nil),     // 1: fabtoken.IssuerPolicy
+	(*FeePolicy)(nil),        // 2: fabtoken.FeePolicy
+	(*PublicParameters)(nil), // 3: fabtoken.PublicParameters
 }
 var file_ftpp_proto_depIdxs = []int32{
-	0, // 0: fabtoken.PublicParameters.auditor:type_name -> fabtoken.Identity
-	0, // 1: fabtoken.PublicParameters.issuers:type_name -> fabtoken.Identity
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0, // 0: fabtoken.IssuerPolicy.issuers:type_name -> fabtoken.Identity
+	0, // 1: fabtoken.FeePolicy.collector:type_name -> fabtoken.Identity
+	0, // 2: fabtoken.PublicParameters.auditor:type_name -> fabtoken.Identity
+	0, // 3: fabtoken.PublicParameters.issuers:type_name -> fabtoken.Identity
+	0, // 4: fabtoken.PublicParameters.additional_auditors:type_name -> fabtoken.Identity
+	1, // 5: fabtoken.PublicParameters.issuer_policies:type_name -> fabtoken.IssuerPolicy
+	2, // 6: fabtoken.PublicParameters.fee_policies:type_name -> fabtoken.FeePolicy
+	0, // 7: fabtoken.PublicParameters.enforcers:type_name -> fabtoken.Identity
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_ftpp_proto_init() }
@@ -237,6 +445,30 @@ func file_ftpp_proto_init() {
 			}
 		}
 		file_ftpp_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IssuerPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ftpp_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeePolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ftpp_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PublicParameters); i {
 			case 0:
 				return &v.state
@@ -255,7 +487,7 @@ func file_ftpp_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ftpp_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
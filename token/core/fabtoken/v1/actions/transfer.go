@@ -10,6 +10,7 @@ import (
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/proto"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/protos-go/actions"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/protos-go/pp"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/protos"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/slices"
@@ -72,6 +73,9 @@ type TransferAction struct {
 	Outputs []*Output
 	// Metadata contains the transfer action's metadata
 	Metadata map[string][]byte
+	// Enforcer is the enforcer that forces the transfer, if any.
+	// It signs in place of the owners of the inputs.
+	Enforcer driver.Identity
 }
 
 func (t *TransferAction) NumInputs() int {
@@ -231,6 +235,11 @@ func (t *TransferAction) Serialize() ([]byte, error) {
 		Outputs:  outputs,
 		Metadata: t.Metadata,
 	}
+	if !t.Enforcer.IsNone() {
+		action.Enforcer = &pp.Identity{
+			Raw: t.Enforcer,
+		}
+	}
 	return proto.Marshal(action)
 }
 
@@ -268,6 +277,9 @@ func (t *TransferAction) Deserialize(raw []byte) error {
 	}
 
 	t.Metadata = action.Metadata
+	if action.Enforcer != nil {
+		t.Enforcer = action.Enforcer.Raw
+	}
 
 	return nil
 }
@@ -11,9 +11,12 @@ import (
 	v1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/setup"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/deserializer"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/htlc"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/timelock"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/multisig"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/validity"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/x509"
 	htlc2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/htlc"
+	timelock2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
 )
 
 // Deserializer deserializes verifiers associated with issuers, owners, and auditors
@@ -26,7 +29,9 @@ func NewDeserializer() *Deserializer {
 	des := deserializer.NewTypedVerifierDeserializerMultiplex()
 	des.AddTypedVerifierDeserializer(x509.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(&x509.IdentityDeserializer{}, &x509.AuditMatcherDeserializer{}))
 	des.AddTypedVerifierDeserializer(htlc2.ScriptType, htlc.NewTypedIdentityDeserializer(des))
+	des.AddTypedVerifierDeserializer(timelock2.ScriptType, timelock.NewTypedIdentityDeserializer(des))
 	des.AddTypedVerifierDeserializer(multisig.Multisig, multisig.NewTypedIdentityDeserializer(des, des))
+	des.AddTypedVerifierDeserializer(validity.Validity, validity.NewTypedIdentityDeserializer(des))
 
 	return &Deserializer{Deserializer: common.NewDeserializer(x509.IdentityType, des, des, des, des, des)}
 }
@@ -45,6 +50,8 @@ func NewEIDRHDeserializer() *EIDRHDeserializer {
 	d := deserializer.NewEIDRHDeserializer()
 	d.AddDeserializer(x509.IdentityType, &x509.AuditInfoDeserializer{})
 	d.AddDeserializer(htlc2.ScriptType, htlc.NewAuditDeserializer(&x509.AuditInfoDeserializer{}))
+	d.AddDeserializer(timelock2.ScriptType, htlc.NewAuditDeserializer(&x509.AuditInfoDeserializer{}))
 	d.AddDeserializer(multisig.Multisig, &multisig.AuditInfoDeserializer{})
+	d.AddDeserializer(validity.Validity, &x509.AuditInfoDeserializer{})
 	return d
 }
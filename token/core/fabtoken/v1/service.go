@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/wallet"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
 )
 
 type TokenLoader interface {
@@ -64,5 +65,11 @@ func NewService(
 }
 
 func (s *Service) Validator() (driver.Validator, error) {
-	return validator.NewValidator(s.Logger, s.PublicParametersManager.PublicParams(), s.Deserializer()), nil
+	v := validator.NewValidator(s.Logger, s.PublicParametersManager.PublicParams(), s.Deserializer())
+	workers, err := common.ValidatorWorkers(s.Configuration())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to instantiate validator")
+	}
+	v.Workers = workers
+	return v, nil
 }
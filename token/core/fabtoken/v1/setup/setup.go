@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver/protos-go/pp"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/protos"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 )
 
@@ -36,9 +37,19 @@ type PublicParams struct {
 	// MaxToken is the maximum quantity a token can hold
 	MaxToken uint64
 	// This is set when audit is enabled
-	Auditor []byte
+	AuditorIDs []driver.Identity
+	// AuditorThreshold is the number of auditors that must sign a token request.
+	// Zero means that all auditors must sign.
+	AuditorThreshold uint64
 	// This encodes the list of authorized issuers
 	IssuerIDs []driver.Identity
+	// IssuerPolicies restricts the issuers of specific token types.
+	// Token types not matched by any policy can be issued by the entities in IssuerIDs.
+	IssuerPolicies driver.IssuerPolicies
+	// Fees are the policies of the fees charged on transfers, by token type
+	Fees driver.FeePolicies
+	// EnforcerIDs encodes the list of the entities that can freeze tokens and owners, and force transfers
+	EnforcerIDs []driver.Identity
 }
 
 // Setup initializes PublicParams
@@ -111,16 +122,52 @@ func (p *PublicParams) Bytes() ([]byte, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to serialize issuer")
 	}
+	// the first auditor goes in the auditor field, as in previous versions, the others are appended
+	var auditor driver.Identity
+	var additionalAuditors []*fabpp.Identity
+	if len(p.AuditorIDs) != 0 {
+		auditor = p.AuditorIDs[0]
+		additionalAuditors, err = protos.ToProtosSliceFunc(p.AuditorIDs[1:], func(id driver.Identity) (*fabpp.Identity, error) {
+			return &fabpp.Identity{
+				Raw: id,
+			}, nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize auditors")
+		}
+	}
+
+	issuerPolicies, err := issuerPoliciesToProtos(p.IssuerPolicies)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize issuer policies")
+	}
+	feePolicies, err := feePoliciesToProtos(p.Fees)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize fee policies")
+	}
+	enforcers, err := protos.ToProtosSliceFunc(p.EnforcerIDs, func(id driver.Identity) (*fabpp.Identity, error) {
+		return &fabpp.Identity{
+			Raw: id,
+		}, nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize enforcers")
+	}
 
 	pp := &fabpp.PublicParameters{
 		Identifier: p.Label,
 		Version:    p.Ver,
 		Auditor: &fabpp.Identity{
-			Raw: p.Auditor,
+			Raw: auditor,
 		},
-		Issuers:           issuers,
-		MaxToken:          p.MaxToken,
-		QuantityPrecision: p.QuantityPrecision,
+		Issuers:            issuers,
+		MaxToken:           p.MaxToken,
+		QuantityPrecision:  p.QuantityPrecision,
+		AdditionalAuditors: additionalAuditors,
+		AuditorThreshold:   p.AuditorThreshold,
+		IssuerPolicies:     issuerPolicies,
+		FeePolicies:        feePolicies,
+		Enforcers:          enforcers,
 	}
 	return proto.Marshal(pp)
 }
@@ -143,8 +190,32 @@ func (p *PublicParams) FromBytes(data []byte) error {
 		return errors.Wrapf(err, "failed to deserialize issuers")
 	}
 	p.IssuerIDs = issuers
-	if publicParams.Auditor != nil {
-		p.Auditor = publicParams.Auditor.Raw
+	if publicParams.Auditor != nil && len(publicParams.Auditor.Raw) != 0 {
+		p.AuditorIDs = append(p.AuditorIDs, publicParams.Auditor.Raw)
+	}
+	for _, auditor := range publicParams.AdditionalAuditors {
+		if auditor == nil || len(auditor.Raw) == 0 {
+			return errors.New("failed to deserialize auditors: empty auditor identity")
+		}
+		p.AuditorIDs = append(p.AuditorIDs, auditor.Raw)
+	}
+	p.AuditorThreshold = publicParams.AuditorThreshold
+	p.IssuerPolicies, err = issuerPoliciesFromProtos(publicParams.IssuerPolicies)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize issuer policies")
+	}
+	p.Fees, err = feePoliciesFromProtos(publicParams.FeePolicies)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize fee policies")
+	}
+	p.EnforcerIDs, err = protos.FromProtosSliceFunc2(publicParams.Enforcers, func(id *fabpp.Identity) (driver.Identity, error) {
+		if id == nil || len(id.Raw) == 0 {
+			return nil, errors.New("empty enforcer identity")
+		}
+		return id.Raw, nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize enforcers")
 	}
 	return nil
 }
@@ -173,14 +244,17 @@ func (p *PublicParams) Deserialize(raw []byte) error {
 	return p.FromBytes(container.Raw)
 }
 
-// AuditorIdentity returns the auditor identity encoded in PublicParams
+// AuditorIdentity returns the first auditor identity encoded in PublicParams
 func (p *PublicParams) AuditorIdentity() driver.Identity {
-	return p.Auditor
+	if len(p.AuditorIDs) == 0 {
+		return nil
+	}
+	return p.AuditorIDs[0]
 }
 
-// AddAuditor sets the Auditor field in PublicParams to the passed identity
+// AddAuditor adds the passed auditor to the array of auditors in PublicParams
 func (p *PublicParams) AddAuditor(auditor driver.Identity) {
-	p.Auditor = auditor
+	p.AuditorIDs = append(p.AuditorIDs, auditor)
 }
 
 // AddIssuer adds the passed issuer to the array of Issuers in PublicParams
@@ -195,21 +269,89 @@ func (p *PublicParams) SetIssuers(ids []driver.Identity) {
 
 // SetAuditors sets the auditors to the passed identities
 func (p *PublicParams) SetAuditors(ids []driver.Identity) {
-	p.Auditor = ids[0]
+	p.AuditorIDs = ids
+}
+
+// SetAuditorThreshold sets the number of auditors that must sign a token request, zero means all of them
+func (p *PublicParams) SetAuditorThreshold(threshold uint64) {
+	p.AuditorThreshold = threshold
 }
 
 // Auditors returns the list of authorized auditors
-// fabtoken only supports a single auditor
 func (p *PublicParams) Auditors() []driver.Identity {
-	if len(p.Auditor) == 0 {
+	if len(p.AuditorIDs) == 0 {
 		return []driver.Identity{}
 	}
-	return []driver.Identity{p.Auditor}
+	return p.AuditorIDs
+}
+
+// RequiredAuditorSignatures returns the number of auditors that must sign a token request
+func (p *PublicParams) RequiredAuditorSignatures() int {
+	if p.AuditorThreshold == 0 {
+		return len(p.AuditorIDs)
+	}
+	return int(p.AuditorThreshold)
 }
 
-// Issuers returns the list of authorized issuers
+// Issuers returns the list of authorized issuers, including those listed in the issuer policies
 func (p *PublicParams) Issuers() []driver.Identity {
-	return p.IssuerIDs
+	if len(p.IssuerPolicies) == 0 {
+		return p.IssuerIDs
+	}
+	issuers := append([]driver.Identity{}, p.IssuerIDs...)
+	for _, policy := range p.IssuerPolicies {
+		for _, issuer := range policy.Issuers {
+			if !driver.ContainsIssuer(issuers, issuer) {
+				issuers = append(issuers, issuer)
+			}
+		}
+	}
+	return issuers
+}
+
+// AuthorizedIssuers returns the issuers that can issue the passed token type
+func (p *PublicParams) AuthorizedIssuers(tokenType token2.Type) []driver.Identity {
+	return p.IssuerPolicies.AuthorizedIssuers(tokenType, p.IssuerIDs)
+}
+
+// AddIssuerPolicy restricts the issuers of the token types matching the passed pattern to the passed identities
+func (p *PublicParams) AddIssuerPolicy(tokenType string, issuers []driver.Identity) {
+	p.IssuerPolicies = append(p.IssuerPolicies, &driver.IssuerPolicy{TokenType: tokenType, Issuers: issuers})
+}
+
+// SetIssuerPolicies sets the issuer policies to the passed ones
+func (p *PublicParams) SetIssuerPolicies(policies driver.IssuerPolicies) {
+	p.IssuerPolicies = policies
+}
+
+// FeePolicies returns the fees charged on transfers
+func (p *PublicParams) FeePolicies() driver.FeePolicies {
+	return p.Fees
+}
+
+// AddFeePolicy charges the passed fee on the transfers of the token types matching the passed pattern
+func (p *PublicParams) AddFeePolicy(tokenType string, flat, rate uint64, collector driver.Identity) {
+	p.Fees = append(p.Fees, &driver.FeePolicy{TokenType: tokenType, Flat: flat, Rate: rate, Collector: collector})
+}
+
+// SetFeePolicies sets the fee policies to the passed ones
+func (p *PublicParams) SetFeePolicies(policies driver.FeePolicies) {
+	p.Fees = policies
+}
+
+// Enforcers returns the list of authorized enforcers
+func (p *PublicParams) Enforcers() []driver.Identity {
+	return p.EnforcerIDs
+}
+
+// AddEnforcer adds the passed enforcer to the array of enforcers in PublicParams
+func (p *PublicParams) AddEnforcer(enforcer driver.Identity) {
+	p.EnforcerIDs = append(p.EnforcerIDs, enforcer)
+}
+
+// SetEnforcers sets the enforcers to the passed identities
+func (p *PublicParams) SetEnforcers(ids []driver.Identity) {
+	p.EnforcerIDs = ids
 }
 
 // Precision returns the quantity precision encoded in PublicParams
@@ -229,9 +371,33 @@ func (p *PublicParams) Validate() error {
 	if p.MaxToken > maxTokenValue {
 		return errors.Errorf("max token value is invalid [%d]>[%d]", p.MaxToken, maxTokenValue)
 	}
-	if len(p.IssuerIDs) == 0 {
+	if len(p.IssuerIDs) == 0 && len(p.IssuerPolicies) == 0 {
 		return errors.New("invalid public parameters: empty list of issuers")
 	}
+	if err := p.IssuerPolicies.Validate(); err != nil {
+		return errors.Wrap(err, "invalid public parameters")
+	}
+	if err := p.Fees.Validate(); err != nil {
+		return errors.Wrap(err, "invalid public parameters")
+	}
+	for i, enforcer := range p.EnforcerIDs {
+		if enforcer.IsNone() {
+			return errors.Errorf("invalid public parameters: empty enforcer at index [%d]", i)
+		}
+	}
+	auditors := make(map[string]struct{}, len(p.AuditorIDs))
+	for i, auditor := range p.AuditorIDs {
+		if auditor.IsNone() {
+			return errors.Errorf("invalid public parameters: empty auditor at index [%d]", i)
+		}
+		if _, ok := auditors[auditor.UniqueID()]; ok {
+			return errors.Errorf("invalid public parameters: duplicate auditor at index [%d]", i)
+		}
+		auditors[auditor.UniqueID()] = struct{}{}
+	}
+	if p.AuditorThreshold > uint64(len(p.AuditorIDs)) {
+		return errors.Errorf("invalid public parameters: auditor threshold [%d] greater than the number of auditors [%d]", p.AuditorThreshold, len(p.AuditorIDs))
+	}
 	return nil
 }
 
@@ -242,3 +408,72 @@ func (p *PublicParams) String() string {
 	}
 	return string(res)
 }
+
+func issuerPoliciesToProtos(policies driver.IssuerPolicies) ([]*fabpp.IssuerPolicy, error) {
+	return protos.ToProtosSliceFunc(policies, func(policy *driver.IssuerPolicy) (*fabpp.IssuerPolicy, error) {
+		issuers, err := protos.ToProtosSliceFunc(policy.Issuers, func(id driver.Identity) (*fabpp.Identity, error) {
+			return &fabpp.Identity{
+				Raw: id,
+			}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &fabpp.IssuerPolicy{
+			TokenType: policy.TokenType,
+			Issuers:   issuers,
+		}, nil
+	})
+}
+
+func issuerPoliciesFromProtos(policies []*fabpp.IssuerPolicy) (driver.IssuerPolicies, error) {
+	return protos.FromProtosSliceFunc2(policies, func(policy *fabpp.IssuerPolicy) (*driver.IssuerPolicy, error) {
+		if policy == nil {
+			return nil, errors.New("nil issuer policy")
+		}
+		issuers, err := protos.FromProtosSliceFunc2(policy.Issuers, func(id *fabpp.Identity) (driver.Identity, error) {
+			if id == nil {
+				return nil, nil
+			}
+			return id.Raw, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &driver.IssuerPolicy{
+			TokenType: policy.TokenType,
+			Issuers:   issuers,
+		}, nil
+	})
+}
+
+func feePoliciesToProtos(policies driver.FeePolicies) ([]*fabpp.FeePolicy, error) {
+	return protos.ToProtosSliceFunc(policies, func(policy *driver.FeePolicy) (*fabpp.FeePolicy, error) {
+		return &fabpp.FeePolicy{
+			TokenType: policy.TokenType,
+			Flat:      policy.Flat,
+			Rate:      policy.Rate,
+			Collector: &fabpp.Identity{
+				Raw: policy.Collector,
+			},
+		}, nil
+	})
+}
+
+func feePoliciesFromProtos(policies []*fabpp.FeePolicy) (driver.FeePolicies, error) {
+	return protos.FromProtosSliceFunc2(policies, func(policy *fabpp.FeePolicy) (*driver.FeePolicy, error) {
+		if policy == nil {
+			return nil, errors.New("nil fee policy")
+		}
+		var collector driver.Identity
+		if policy.Collector != nil {
+			collector = policy.Collector.Raw
+		}
+		return &driver.FeePolicy{
+			TokenType: policy.TokenType,
+			Flat:      policy.Flat,
+			Rate:      policy.Rate,
+			Collector: collector,
+		}, nil
+	})
+}
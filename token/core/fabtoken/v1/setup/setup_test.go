@@ -39,7 +39,7 @@ func TestNewPublicParamsFromBytes_Valid(t *testing.T) {
 	assert.Equal(t, uint64(32), pp2.QuantityPrecision)
 	assert.Equal(t, uint64(4294967295), pp2.MaxToken)
 	assert.Nil(t, pp2.IssuerIDs)
-	assert.Nil(t, pp2.Auditor)
+	assert.Nil(t, pp2.AuditorIDs)
 	assert.Equal(t, pp, pp2)
 }
 
@@ -75,3 +75,89 @@ func TestPublicParams_Validate_InvalidMaxToken(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "max token value is invalid [4294967296]>[4294967295]", err.Error())
 }
+
+func TestPublicParams_Auditors(t *testing.T) {
+	pp, err := Setup(32)
+	assert.NoError(t, err)
+	pp.IssuerIDs = []driver.Identity{[]byte("issuer")}
+	assert.Empty(t, pp.Auditors())
+	assert.Equal(t, 0, pp.RequiredAuditorSignatures())
+
+	pp.SetAuditors([]driver.Identity{[]byte("regulator"), []byte("compliance")})
+	assert.Equal(t, 2, pp.RequiredAuditorSignatures())
+	pp.SetAuditorThreshold(1)
+	assert.Equal(t, 1, pp.RequiredAuditorSignatures())
+	assert.NoError(t, pp.Validate())
+
+	raw, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(raw, "fabtoken")
+	assert.NoError(t, err)
+	assert.Equal(t, pp, pp2)
+	assert.Equal(t, driver.Identity("regulator"), pp2.AuditorIdentity())
+
+	pp.SetAuditorThreshold(3)
+	assert.EqualError(t, pp.Validate(), "invalid public parameters: auditor threshold [3] greater than the number of auditors [2]")
+
+	// a repeated auditor would count twice towards the threshold
+	pp.SetAuditors([]driver.Identity{[]byte("regulator"), []byte("regulator")})
+	pp.SetAuditorThreshold(2)
+	assert.EqualError(t, pp.Validate(), "invalid public parameters: duplicate auditor at index [1]")
+}
+
+func TestPublicParams_IssuerPolicies(t *testing.T) {
+	pp, err := Setup(32)
+	assert.NoError(t, err)
+	pp.IssuerIDs = []driver.Identity{[]byte("issuer")}
+	pp.AddIssuerPolicy("USD", []driver.Identity{[]byte("fed"), []byte("issuer")})
+	assert.NoError(t, pp.Validate())
+
+	raw, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(raw, "fabtoken")
+	assert.NoError(t, err)
+	assert.Equal(t, pp, pp2)
+	assert.Equal(t, []driver.Identity{[]byte("issuer"), []byte("fed")}, pp2.Issuers())
+	assert.Equal(t, []driver.Identity{[]byte("fed"), []byte("issuer")}, pp2.AuthorizedIssuers("USD"))
+	assert.Equal(t, []driver.Identity{[]byte("issuer")}, pp2.AuthorizedIssuers("EUR"))
+
+	pp.AddIssuerPolicy("EUR", nil)
+	assert.EqualError(t, pp.Validate(), "invalid public parameters: invalid issuer policy: no issuers for token type [EUR]")
+}
+
+func TestPublicParams_FeePolicies(t *testing.T) {
+	pp, err := Setup(32)
+	assert.NoError(t, err)
+	pp.IssuerIDs = []driver.Identity{[]byte("issuer")}
+	pp.AddFeePolicy("USD", 1, 25, []byte("collector"))
+	assert.NoError(t, pp.Validate())
+
+	raw, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(raw, "fabtoken")
+	assert.NoError(t, err)
+	assert.Equal(t, pp, pp2)
+	assert.Equal(t, &driver.FeePolicy{TokenType: "USD", Flat: 1, Rate: 25, Collector: []byte("collector")}, pp2.FeePolicies().Lookup("USD"))
+
+	pp.AddFeePolicy("EUR", 1, 0, nil)
+	assert.EqualError(t, pp.Validate(), "invalid public parameters: invalid fee policy: no fee collector for token type [EUR]")
+}
+
+func TestPublicParams_Enforcers(t *testing.T) {
+	pp, err := Setup(32)
+	assert.NoError(t, err)
+	pp.IssuerIDs = []driver.Identity{[]byte("issuer")}
+	pp.AddEnforcer([]byte("enforcer1"))
+	pp.AddEnforcer([]byte("enforcer2"))
+	assert.NoError(t, pp.Validate())
+
+	raw, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(raw, "fabtoken")
+	assert.NoError(t, err)
+	assert.Equal(t, pp, pp2)
+	assert.Equal(t, []driver.Identity{[]byte("enforcer1"), []byte("enforcer2")}, pp2.Enforcers())
+
+	pp.AddEnforcer(nil)
+	assert.EqualError(t, pp.Validate(), "invalid public parameters: empty enforcer at index [2]")
+}
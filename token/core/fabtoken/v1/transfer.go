@@ -8,12 +8,14 @@ package v1
 
 import (
 	"context"
+	"time"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/meta"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/actions"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/setup"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/validity"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
@@ -53,17 +55,27 @@ func (s *TransferService) Transfer(ctx context.Context, _ string, _ driver.Owner
 	}
 
 	var inputs []*actions.Output
+	inputOwners := make([]driver.Identity, 0, len(inputTokens))
 	for _, tok := range inputTokens {
 		s.Logger.Debugf("Selected output [%s,%s,%s]", tok.Type, tok.Quantity, driver.Identity(tok.Owner))
 		t := actions.Output(*tok)
 		inputs = append(inputs, &t)
+		inputOwners = append(inputOwners, tok.Owner)
 	}
 
-	// prepare outputs
+	// prepare outputs, they inherit the validity window of the inputs, if any
+	outputOwners := make([]driver.Identity, len(Outputs))
+	for i, output := range Outputs {
+		outputOwners[i] = output.Owner
+	}
+	outputOwners, err = validity.CarryOver(inputOwners, outputOwners, time.Now())
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to carry over the validity window of the inputs")
+	}
 	var outs []*actions.Output
-	for _, output := range Outputs {
+	for i, output := range Outputs {
 		outs = append(outs, &actions.Output{
-			Owner:    output.Owner,
+			Owner:    outputOwners[i],
 			Type:     output.Type,
 			Quantity: output.Quantity,
 		})
@@ -153,11 +165,13 @@ func (s *TransferService) Transfer(ctx context.Context, _ string, _ driver.Owner
 		Inputs:   actionInputs,
 		Outputs:  outs,
 		Metadata: meta.TransferActionMetadata(opts.Attributes),
+		Enforcer: opts.Enforcer,
 	}
 	transferMetadata := &driver.TransferMetadata{
 		Inputs:       transferInputsMetadata,
 		Outputs:      transferOutputsMetadata,
 		ExtraSigners: nil,
+		Enforcer:     opts.Enforcer,
 	}
 	return transfer, transferMetadata, nil
 }
@@ -50,13 +50,18 @@ func NewValidator(logger logging.Logger, pp *setup.PublicParams, deserializer dr
 	transferValidators := []ValidateTransferFunc{
 		TransferActionValidate,
 		TransferSignatureValidate,
+		TransferNotFrozenValidate,
 		TransferBalanceValidate,
+		TransferFeeValidate,
 		TransferHTLCValidate,
+		TransferTimelockValidate,
+		TransferValidityValidate,
 	}
 	transferValidators = append(transferValidators, extraValidators...)
 
 	issueValidators := []ValidateIssueFunc{
 		IssueValidate,
+		IssueValidityValidate,
 	}
 
 	return common.NewValidator[*setup.PublicParams, *actions.Output, *actions.TransferAction, *actions.IssueAction, driver.Deserializer](
@@ -7,9 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package validator
 
 import (
-	"bytes"
-
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/actions"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/validity"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 )
@@ -37,18 +37,12 @@ func IssueValidate(ctx *Context) error {
 		}
 	}
 
-	issuers := ctx.PP.IssuerIDs
-	if len(issuers) != 0 {
-		// check that issuer of this issue action is authorized
-		found := false
-		for _, issuer := range issuers {
-			if bytes.Equal(action.Issuer, issuer) {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return errors.Errorf("issuer [%s] is not in issuers", action.Issuer.String())
+	// check that issuer of this issue action is authorized for the type of each output
+	for _, output := range action.GetOutputs() {
+		tokenType := output.(*actions.Output).Type
+		issuers := ctx.PP.AuthorizedIssuers(tokenType)
+		if len(issuers) != 0 && !driver.ContainsIssuer(issuers, action.Issuer) {
+			return errors.Errorf("issuer [%s] is not in issuers for token type [%s]", action.Issuer.String(), tokenType)
 		}
 	}
 
@@ -63,3 +57,16 @@ func IssueValidate(ctx *Context) error {
 	}
 	return nil
 }
+
+// IssueValidityValidate checks that the issued tokens bound to a validity window name the issuer of the action
+// and are not already expired
+func IssueValidityValidate(ctx *Context) error {
+	outputOwners := make([]driver.Identity, len(ctx.IssueAction.Outputs))
+	for i, out := range ctx.IssueAction.Outputs {
+		outputOwners[i] = out.Owner
+	}
+	if err := validity.VerifyIssue(ctx.IssueAction.Issuer, outputOwners, ctx.Time); err != nil {
+		return errors.WithMessage(err, "invalid issue action")
+	}
+	return nil
+}
@@ -7,14 +7,19 @@ SPDX-License-Identifier: Apache-2.0
 package validator
 
 import (
+	"math/big"
 	"time"
 
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/json"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/actions"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
 	htlc2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/htlc"
+	timelock2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/timelock"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/validity"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/htlc"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 )
@@ -24,27 +29,47 @@ func TransferActionValidate(ctx *Context) error {
 	return ctx.TransferAction.Validate()
 }
 
-// TransferSignatureValidate validates the signatures for the inputs spent by an action
+// TransferSignatureValidate validates the signatures for the inputs spent by an action.
+// A forced transfer is signed by its enforcer in place of the owners, once per input.
 func TransferSignatureValidate(ctx *Context) error {
 	if len(ctx.TransferAction.Inputs) == 0 {
 		return errors.Errorf("invalid number of token inputs, expected at least 1")
 	}
 
+	enforcer := ctx.TransferAction.Enforcer
+	var enforcerVerifier driver.Verifier
+	if !enforcer.IsNone() {
+		var err error
+		enforcerVerifier, err = common.EnforcerVerifier(ctx.PP, ctx.Deserializer, enforcer)
+		if err != nil {
+			return errors.WithMessage(err, "invalid forced transfer")
+		}
+	}
+
 	var inputToken []*actions.Output
 	for _, in := range ctx.TransferAction.Inputs {
 		tok := in.Input
 
 		inputToken = append(inputToken, tok)
-		owner := tok.GetOwner()
-		ctx.Logger.Debugf("check sender [%s]", driver.Identity(owner).UniqueID())
-		verifier, err := ctx.Deserializer.GetOwnerVerifier(owner)
-		if err != nil {
-			return errors.Wrapf(err, "failed deserializing owner [%v][%s]", tok, driver.Identity(owner).UniqueID())
+		signer := driver.Identity(tok.GetOwner())
+		verifier := enforcerVerifier
+		if verifier == nil {
+			ctx.Logger.Debugf("check sender [%s]", signer.UniqueID())
+			var err error
+			verifier, err = ctx.Deserializer.GetOwnerVerifier(signer)
+			if err != nil {
+				return errors.Wrapf(err, "failed deserializing owner [%v][%s]", tok, signer.UniqueID())
+			}
+			// scripts like timelocks are evaluated at the validation time
+			verifier = driver.VerifierAt(verifier, ctx.Time)
+		} else {
+			ctx.Logger.Debugf("check enforcer [%s] of sender [%s]", enforcer.UniqueID(), signer.UniqueID())
+			signer = enforcer
 		}
-		ctx.Logger.Debugf("signature verification [%v][%s]", tok, driver.Identity(owner).UniqueID())
-		sigma, err := ctx.SignatureProvider.HasBeenSignedBy(owner, verifier)
+		ctx.Logger.Debugf("signature verification [%v][%s]", tok, signer.UniqueID())
+		sigma, err := ctx.SignatureProvider.HasBeenSignedBy(signer, verifier)
 		if err != nil {
-			return errors.Wrapf(err, "failed signature verification [%v][%s]", tok, driver.Identity(owner).UniqueID())
+			return errors.Wrapf(err, "failed signature verification [%v][%s]", tok, signer.UniqueID())
 		}
 		ctx.Signatures = append(ctx.Signatures, sigma)
 	}
@@ -52,6 +77,23 @@ func TransferSignatureValidate(ctx *Context) error {
 	return nil
 }
 
+// TransferNotFrozenValidate checks that the inputs spent by an action, and their owners, are not frozen.
+// A forced transfer can spend frozen tokens.
+func TransferNotFrozenValidate(ctx *Context) error {
+	// recall that TransferActionValidate has been called before this function
+	if !ctx.TransferAction.Enforcer.IsNone() {
+		return nil
+	}
+	owners := make([]driver.Identity, len(ctx.TransferAction.Inputs))
+	for i, in := range ctx.TransferAction.Inputs {
+		owners[i] = in.Input.Owner
+	}
+	if err := common.CheckNotFrozen(ctx.PP, ctx.Freezes, ctx.TransferAction.GetInputs(), owners); err != nil {
+		return errors.Wrap(err, "invalid transfer")
+	}
+	return nil
+}
+
 // TransferBalanceValidate checks that the sum of the inputs is equal to the sum of the outputs
 func TransferBalanceValidate(ctx *Context) error {
 	if ctx.TransferAction.NumOutputs() == 0 {
@@ -100,6 +142,65 @@ func TransferBalanceValidate(ctx *Context) error {
 	return nil
 }
 
+// TransferFeeValidate checks that the action pays at least the fee required by the public parameters for its token type.
+// The transfers spending an htlc or a timelock script are exempt, the fee has been paid when the script was created.
+// The transfers forced by an enforcer are exempt as well.
+func TransferFeeValidate(ctx *Context) error {
+	if len(ctx.InputTokens) == 0 || ctx.InputTokens[0] == nil {
+		return errors.New("there is no input")
+	}
+	if !ctx.TransferAction.Enforcer.IsNone() {
+		return nil
+	}
+	policy := ctx.PP.FeePolicies().Lookup(ctx.InputTokens[0].Type)
+	if policy == nil {
+		return nil
+	}
+	inputOwners := make([]driver.Identity, len(ctx.InputTokens))
+	for i, in := range ctx.InputTokens {
+		if owner, err := identity.UnmarshalTypedIdentity(in.Owner); err == nil && (owner.Type == htlc.ScriptType || owner.Type == timelock.ScriptType) {
+			return nil
+		}
+		inputOwners[i] = in.Owner
+	}
+	outputOwners := make([]driver.Identity, len(ctx.TransferAction.Outputs))
+	for i, out := range ctx.TransferAction.Outputs {
+		outputOwners[i] = out.Owner
+	}
+	fees, payments := policy.SplitOutputs(inputOwners, outputOwners)
+
+	sum := func(indexes []int) (uint64, error) {
+		total := new(big.Int)
+		for _, i := range indexes {
+			q, err := token.ToQuantity(ctx.TransferAction.Outputs[i].Quantity, ctx.PP.QuantityPrecision)
+			if err != nil {
+				return 0, errors.Wrapf(err, "failed parsing quantity [%s]", ctx.TransferAction.Outputs[i].Quantity)
+			}
+			total.Add(total, q.ToBigInt())
+		}
+		if !total.IsUint64() {
+			return 0, errors.Errorf("sum [%s] overflows", total)
+		}
+		return total.Uint64(), nil
+	}
+	paymentsValue, err := sum(payments)
+	if err != nil {
+		return errors.WithMessagef(err, "invalid payments")
+	}
+	paid, err := sum(fees)
+	if err != nil {
+		return errors.WithMessagef(err, "invalid fee")
+	}
+	required, err := policy.Fee(paymentsValue)
+	if err != nil {
+		return err
+	}
+	if paid < required {
+		return errors.Errorf("insufficient fee [%d] for token type [%s], required [%d]", paid, ctx.InputTokens[0].Type, required)
+	}
+	return nil
+}
+
 // TransferHTLCValidate checks the validity of the HTLC scripts, if any
 func TransferHTLCValidate(ctx *Context) error {
 	now := time.Now()
@@ -180,3 +281,74 @@ func TransferHTLCValidate(ctx *Context) error {
 	}
 	return nil
 }
+
+// TransferTimelockValidate checks the validity of the timelock scripts, if any.
+// A transfer spending a timelock script only transfers the ownership of the token to the recipient of the script,
+// once unlocked, or back to the sender, before the unlock time, if the script is revocable.
+// The transfers forced by an enforcer are exempt.
+func TransferTimelockValidate(ctx *Context) error {
+	if !ctx.TransferAction.Enforcer.IsNone() {
+		return nil
+	}
+	for _, in := range ctx.InputTokens {
+		owner, err := identity.UnmarshalTypedIdentity(in.Owner)
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshal owner of input token")
+		}
+		if owner.Type != timelock.ScriptType {
+			continue
+		}
+		if len(ctx.InputTokens) != 1 || len(ctx.TransferAction.GetOutputs()) != 1 {
+			return errors.New("invalid transfer action: a timelock script only transfers the ownership of a token")
+		}
+		out := ctx.TransferAction.GetOutputs()[0].(*actions.Output)
+		if out.IsRedeem() {
+			return errors.New("invalid transfer action: the output corresponding to a timelock spending should not be a redeem")
+		}
+		if _, _, err := timelock2.VerifyOwner(in.Owner, out.Owner, ctx.Time); err != nil {
+			return errors.Wrap(err, "failed to verify transfer from timelock script")
+		}
+	}
+
+	for _, o := range ctx.TransferAction.GetOutputs() {
+		out := o.(*actions.Output)
+		if out.IsRedeem() {
+			continue
+		}
+		owner, err := identity.UnmarshalTypedIdentity(out.Owner)
+		if err != nil {
+			return err
+		}
+		if owner.Type != timelock.ScriptType {
+			continue
+		}
+		script := &timelock.Script{}
+		if err := script.FromBytes(owner.Identity); err != nil {
+			return err
+		}
+		if err := script.Validate(ctx.Time); err != nil {
+			return errors.WithMessagef(err, "timelock script invalid")
+		}
+	}
+	return nil
+}
+
+// TransferValidityValidate checks that the action respects the validity windows of the tokens it spends, if any.
+// The transfers forced by an enforcer are exempt.
+func TransferValidityValidate(ctx *Context) error {
+	if !ctx.TransferAction.Enforcer.IsNone() {
+		return nil
+	}
+	inputOwners := make([]driver.Identity, len(ctx.InputTokens))
+	for i, in := range ctx.InputTokens {
+		inputOwners[i] = in.Owner
+	}
+	outputOwners := make([]driver.Identity, len(ctx.TransferAction.Outputs))
+	for i, out := range ctx.TransferAction.Outputs {
+		outputOwners[i] = out.Owner
+	}
+	if err := validity.VerifyTransfer(inputOwners, ctx.Signatures, outputOwners, ctx.Time); err != nil {
+		return errors.WithMessage(err, "invalid transfer action")
+	}
+	return nil
+}
@@ -40,6 +40,7 @@ type TMSProvider struct {
 	configProvider          ConfigProvider
 	publicParametersStorage PublicParametersStorage
 	callback                CallbackFunc
+	registeredCallback      CallbackFunc
 	tokenDriverService      *TokenDriverService
 
 	lock     sync.RWMutex
@@ -81,10 +82,9 @@ func (m *TMSProvider) GetTokenManagerService(opts driver.ServiceOptions) (servic
 	m.logger.Debugf("lock to create token manager service for [%s] with key [%s]", opts, key)
 
 	m.lock.Lock()
-	defer m.lock.Unlock()
-
 	service, ok = m.services[key]
 	if ok {
+		m.lock.Unlock()
 		m.logger.Debugf("token manager service for [%s] with key [%s] exists, return it", opts, key)
 		return service, nil
 	}
@@ -92,9 +92,13 @@ func (m *TMSProvider) GetTokenManagerService(opts driver.ServiceOptions) (servic
 	m.logger.Debugf("creating new token manager service for [%s] with key [%s]", opts, key)
 	service, err = m.getTokenManagerService(opts)
 	if err != nil {
+		m.lock.Unlock()
 		return nil, err
 	}
 	m.services[key] = service
+	m.lock.Unlock()
+
+	m.registered(service, opts)
 	return service, nil
 }
 
@@ -128,6 +132,17 @@ func (m *TMSProvider) Update(opts driver.ServiceOptions) (err error) {
 	key := tmsKey(opts)
 	m.logger.Debugf("update tms for [%s] with key [%s]", opts, key)
 
+	newService, err := m.update(key, opts)
+	if err != nil || newService == nil {
+		return err
+	}
+	m.registered(newService, opts)
+	return nil
+}
+
+// update replaces the service registered with the passed key, if the public params changed.
+// It returns the new service, nil if nothing changed.
+func (m *TMSProvider) update(key string, opts driver.ServiceOptions) (driver.TokenManagerService, error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	service, ok := m.services[key]
@@ -137,7 +152,7 @@ func (m *TMSProvider) Update(opts driver.ServiceOptions) (err error) {
 		// update only if the public params are different from the current
 		if bytes.Equal(service.PublicParamsManager().PublicParamsHash(), hash.Hashable(opts.PublicParams).Raw()) {
 			m.logger.Debugf("service found, no need to update token management system for [%s:%s:%s] for key [%s], public params are the same", opts.Network, opts.Channel, opts.Namespace, key)
-			return nil
+			return nil, nil
 		}
 
 		m.logger.Debugf("service found, unload token management system for [%s:%s:%s] for key [%s] and reload it", opts.Network, opts.Channel, opts.Namespace, key)
@@ -145,17 +160,18 @@ func (m *TMSProvider) Update(opts driver.ServiceOptions) (err error) {
 
 	// create the service for the new public params
 	newService, err := m.getTokenManagerService(opts)
-	if err == nil {
-		// unload the old service, if set
-		if service != nil {
-			if err := service.Done(); err != nil {
-				return errors.WithMessage(err, "failed to unload token service")
-			}
+	if err != nil {
+		return nil, err
+	}
+	// unload the old service, if set
+	if service != nil {
+		if err := service.Done(); err != nil {
+			return nil, errors.WithMessage(err, "failed to unload token service")
 		}
-		// register the new service
-		m.services[key] = newService
 	}
-	return
+	// register the new service
+	m.services[key] = newService
+	return newService, nil
 }
 
 func (m *TMSProvider) Configurations() ([]driver.Configuration, error) {
@@ -172,6 +188,22 @@ func (m *TMSProvider) SetCallback(callback CallbackFunc) {
 	m.callback = callback
 }
 
+// SetRegisteredCallback sets the function invoked once a new token manager service has been registered.
+// Unlike the callback set with SetCallback, this function can retrieve the service with GetTokenManagerService.
+func (m *TMSProvider) SetRegisteredCallback(callback CallbackFunc) {
+	m.registeredCallback = callback
+}
+
+// registered invokes the registered callback, if any, on the passed service
+func (m *TMSProvider) registered(service driver.TokenManagerService, opts driver.ServiceOptions) {
+	if m.registeredCallback == nil {
+		return
+	}
+	if err := m.registeredCallback(service, opts.Network, opts.Channel, opts.Namespace); err != nil {
+		m.logger.Errorf("failed to run registered callback for tms [%s]: [%s]", opts, err)
+	}
+}
+
 func (m *TMSProvider) getTokenManagerService(opts driver.ServiceOptions) (service driver.TokenManagerService, err error) {
 	m.logger.Debugf("creating new token manager service for [%s]", opts)
 	service, err = m.newTMS(&opts)
@@ -0,0 +1,925 @@
+//
+//Copyright IBM Corp. All Rights Reserved.
+//
+//SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v5.28.1
+// source: ghactions.proto
+
+package actions
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	actions "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/actions"
+	math "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/math"
+	pp "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/pp"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Token struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data     *math.G1 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`                         // Data is the Pedersen commitment to type, value and serial number
+	SpendKey *math.G1 `protobuf:"bytes,2,opt,name=spend_key,json=spendKey,proto3" json:"spend_key,omitempty"` // SpendKey is the commitment to the spend key of the owner
+}
+
+func (x *Token) Reset() {
+	*x = Token{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ghactions_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Token) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Token) ProtoMessage() {}
+
+func (x *Token) ProtoReflect() protoreflect.Message {
+	mi := &file_ghactions_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Token.ProtoReflect.Descriptor instead.
+func (*Token) Descriptor() ([]byte, []int) {
+	return file_ghactions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Token) GetData() *math.G1 {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Token) GetSpendKey() *math.G1 {
+	if x != nil {
+		return x.SpendKey
+	}
+	return nil
+}
+
+type TokenMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type                   string       `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                                                                       // Type is the type of the token
+	Value                  *math.Zr     `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`                                                                     // Value is the quantity of the token
+	BlindingFactor         *math.Zr     `protobuf:"bytes,3,opt,name=blinding_factor,json=blindingFactor,proto3" json:"blinding_factor,omitempty"`                             // BlindingFactor is the blinding factor used to commit the token
+	SerialNumber           *math.Zr     `protobuf:"bytes,4,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`                                   // SerialNumber is the serial number of the token
+	Owner                  []byte       `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`                                                                     // Owner is the owner of the token
+	Issuer                 *pp.Identity `protobuf:"bytes,6,opt,name=issuer,proto3" json:"issuer,omitempty"`                                                                   // Issuer is the issuer of the token, if defined
+	SpendKey               *SpendKey    `protobuf:"bytes,7,opt,name=spend_key,json=spendKey,proto3" json:"spend_key,omitempty"`                                               // SpendKey is the spend key of the owner
+	SpendKeyBlindingFactor *math.Zr     `protobuf:"bytes,8,opt,name=spend_key_blinding_factor,json=spendKeyBlindingFactor,proto3" json:"spend_key_blinding_factor,omitempty"` // SpendKeyBlindingFactor is the blinding factor of the commitment to the spend key
+}
+
+func (x *TokenMetadata) Reset() {
+	*x = TokenMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ghactions_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TokenMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenMetadata) ProtoMessage() {}
+
+func (x *TokenMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_ghactions_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenMetadata.ProtoReflect.Descriptor instead.
+func (*TokenMetadata) Descriptor() ([]byte, []int) {
+	return file_ghactions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TokenMetadata) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TokenMetadata) GetValue() *math.Zr {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *TokenMetadata) GetBlindingFactor() *math.Zr {
+	if x != nil {
+		return x.BlindingFactor
+	}
+	return nil
+}
+
+func (x *TokenMetadata) GetSerialNumber() *math.Zr {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return nil
+}
+
+func (x *TokenMetadata) GetOwner() []byte {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *TokenMetadata) GetIssuer() *pp.Identity {
+	if x != nil {
+		return x.Issuer
+	}
+	return nil
+}
+
+func (x *TokenMetadata) GetSpendKey() *SpendKey {
+	if x != nil {
+		return x.SpendKey
+	}
+	return nil
+}
+
+func (x *TokenMetadata) GetSpendKeyBlindingFactor() *math.Zr {
+	if x != nil {
+		return x.SpendKeyBlindingFactor
+	}
+	return nil
+}
+
+type SpendKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key       *math.G1 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`             // Key is the spend key of an owner
+	Signature []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"` // Signature is the signature of the owner on the key
+}
+
+func (x *SpendKey) Reset() {
+	*x = SpendKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ghactions_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpendKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpendKey) ProtoMessage() {}
+
+func (x *SpendKey) ProtoReflect() protoreflect.Message {
+	mi := &file_ghactions_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpendKey.ProtoReflect.Descriptor instead.
+func (*SpendKey) Descriptor() ([]byte, []int) {
+	return file_ghactions_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SpendKey) GetKey() *math.G1 {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *SpendKey) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type SpendAuditInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Owner          []byte    `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`                                           // Owner is the owner of the spent token
+	OwnerAuditInfo []byte    `protobuf:"bytes,2,opt,name=owner_audit_info,json=ownerAuditInfo,proto3" json:"owner_audit_info,omitempty"` // OwnerAuditInfo is the audit information of the owner
+	SpendKey       *SpendKey `protobuf:"bytes,3,opt,name=spend_key,json=spendKey,proto3" json:"spend_key,omitempty"`                     // SpendKey is the spend key of the owner
+	BlindingFactor *math.Zr  `protobuf:"bytes,4,opt,name=blinding_factor,json=blindingFactor,proto3" json:"blinding_factor,omitempty"`   // BlindingFactor opens the commitment to the spend key revealed by the input
+}
+
+func (x *SpendAuditInfo) Reset() {
+	*x = SpendAuditInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ghactions_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpendAuditInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpendAuditInfo) ProtoMessage() {}
+
+func (x *SpendAuditInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_ghactions_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpendAuditInfo.ProtoReflect.Descriptor instead.
+func (*SpendAuditInfo) Descriptor() ([]byte, []int) {
+	return file_ghactions_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SpendAuditInfo) GetOwner() []byte {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *SpendAuditInfo) GetOwnerAuditInfo() []byte {
+	if x != nil {
+		return x.OwnerAuditInfo
+	}
+	return nil
+}
+
+func (x *SpendAuditInfo) GetSpendKey() *SpendKey {
+	if x != nil {
+		return x.SpendKey
+	}
+	return nil
+}
+
+func (x *SpendAuditInfo) GetBlindingFactor() *math.Zr {
+	if x != nil {
+		return x.BlindingFactor
+	}
+	return nil
+}
+
+type Output struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token      *Token   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`           // Token is the new token, it is empty for redeemed outputs
+	Commitment *math.G1 `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment,omitempty"` // Commitment is the Pedersen commitment to type and value the validity proofs refer to
+	Proof      []byte   `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`           // Proof shows that token and commitment hide the same type and value
+}
+
+func (x *Output) Reset() {
+	*x = Output{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ghactions_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Output) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Output) ProtoMessage() {}
+
+func (x *Output) ProtoReflect() protoreflect.Message {
+	mi := &file_ghactions_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Output.ProtoReflect.Descriptor instead.
+func (*Output) Descriptor() ([]byte, []int) {
+	return file_ghactions_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Output) GetToken() *Token {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *Output) GetCommitment() *math.G1 {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+func (x *Output) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type TransferActionInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber *math.Zr           `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"` // SerialNumber is the serial number of the spent token
+	SpendKey     *math.G1           `protobuf:"bytes,2,opt,name=spend_key,json=spendKey,proto3" json:"spend_key,omitempty"`             // SpendKey is a fresh commitment to the spend key of the owner of the spent token
+	Commitment   *math.G1           `protobuf:"bytes,3,opt,name=commitment,proto3" json:"commitment,omitempty"`                         // Commitment is a fresh Pedersen commitment to type and value of the spent token
+	AnonymitySet []*actions.TokenID `protobuf:"bytes,4,rep,name=anonymity_set,json=anonymitySet,proto3" json:"anonymity_set,omitempty"` // AnonymitySet lists the ledger tokens among which the spent token is hidden
+	Proof        []byte             `protobuf:"bytes,5,opt,name=proof,proto3" json:"proof,omitempty"`                                   // Proof shows that the spent token is in the anonymity set
+	Nullifier    *math.G1           `protobuf:"bytes,6,opt,name=nullifier,proto3" json:"nullifier,omitempty"`                           // Nullifier marks the spent token as spent, only the owner of the token can compute it
+}
+
+func (x *TransferActionInput) Reset() {
+	*x = TransferActionInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ghactions_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferActionInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferActionInput) ProtoMessage() {}
+
+func (x *TransferActionInput) ProtoReflect() protoreflect.Message {
+	mi := &file_ghactions_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferActionInput.ProtoReflect.Descriptor instead.
+func (*TransferActionInput) Descriptor() ([]byte, []int) {
+	return file_ghactions_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TransferActionInput) GetSerialNumber() *math.Zr {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return nil
+}
+
+func (x *TransferActionInput) GetSpendKey() *math.G1 {
+	if x != nil {
+		return x.SpendKey
+	}
+	return nil
+}
+
+func (x *TransferActionInput) GetCommitment() *math.G1 {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+func (x *TransferActionInput) GetAnonymitySet() []*actions.TokenID {
+	if x != nil {
+		return x.AnonymitySet
+	}
+	return nil
+}
+
+func (x *TransferActionInput) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *TransferActionInput) GetNullifier() *math.G1 {
+	if x != nil {
+		return x.Nullifier
+	}
+	return nil
+}
+
+type TransferAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version  uint64                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Inputs   []*TransferActionInput `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`                                                                                             // inputs
+	Outputs  []*Output              `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`                                                                                           // outputs
+	Proof    *actions.Proof         `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof,omitempty"`                                                                                               // ZK Proof that shows that the transfer is correct
+	Metadata map[string][]byte      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Metadata contains the transfer action's metadata
+}
+
+func (x *TransferAction) Reset() {
+	*x = TransferAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ghactions_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferAction) ProtoMessage() {}
+
+func (x *TransferAction) ProtoReflect() protoreflect.Message {
+	mi := &file_ghactions_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferAction.ProtoReflect.Descriptor instead.
+func (*TransferAction) Descriptor() ([]byte, []int) {
+	return file_ghactions_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TransferAction) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TransferAction) GetInputs() []*TransferActionInput {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *TransferAction) GetOutputs() []*Output {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *TransferAction) GetProof() *actions.Proof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *TransferAction) GetMetadata() map[string][]byte {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type IssueAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version  uint64            `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Issuer   *pp.Identity      `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`                                                                                             // is the identity of issuer
+	Outputs  []*Output         `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`                                                                                           // are the newly issued tokens
+	Proof    *actions.Proof    `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof,omitempty"`                                                                                               // carries the ZKP of IssueAction validity
+	Metadata map[string][]byte `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Metadata of the issue action
+}
+
+func (x *IssueAction) Reset() {
+	*x = IssueAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ghactions_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IssueAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueAction) ProtoMessage() {}
+
+func (x *IssueAction) ProtoReflect() protoreflect.Message {
+	mi := &file_ghactions_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueAction.ProtoReflect.Descriptor instead.
+func (*IssueAction) Descriptor() ([]byte, []int) {
+	return file_ghactions_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *IssueAction) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *IssueAction) GetIssuer() *pp.Identity {
+	if x != nil {
+		return x.Issuer
+	}
+	return nil
+}
+
+func (x *IssueAction) GetOutputs() []*Output {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *IssueAction) GetProof() *actions.Proof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *IssueAction) GetMetadata() map[string][]byte {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+var File_ghactions_proto protoreflect.FileDescriptor
+
+var file_ghactions_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x67, 0x68, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x02, 0x67, 0x68, 0x1a, 0x0e, 0x6e, 0x6f, 0x67, 0x68, 0x6d, 0x61, 0x74, 0x68, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0c, 0x6e, 0x6f, 0x67, 0x68, 0x70, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x6e, 0x6f, 0x67, 0x68, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4c, 0x0a, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x1c, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e,
+	0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x47, 0x31, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x25, 0x0a,
+	0x09, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x08, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x47, 0x31, 0x52, 0x08, 0x73, 0x70, 0x65, 0x6e,
+	0x64, 0x4b, 0x65, 0x79, 0x22, 0xd3, 0x02, 0x0a, 0x0d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x6e, 0x6f, 0x67, 0x68,
+	0x2e, 0x5a, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x31, 0x0a, 0x0f, 0x62, 0x6c,
+	0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x5a, 0x72, 0x52, 0x0e, 0x62,
+	0x6c, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x2d, 0x0a,
+	0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x5a, 0x72, 0x52, 0x0c,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x12, 0x26, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x09, 0x73, 0x70,
+	0x65, 0x6e, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x67, 0x68, 0x2e, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x52, 0x08, 0x73, 0x70, 0x65,
+	0x6e, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x43, 0x0a, 0x19, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x5f, 0x6b,
+	0x65, 0x79, 0x5f, 0x62, 0x6c, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x61, 0x63, 0x74,
+	0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e,
+	0x5a, 0x72, 0x52, 0x16, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x42, 0x6c, 0x69, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x44, 0x0a, 0x08, 0x53, 0x70,
+	0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x47, 0x31, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x22, 0xae, 0x01, 0x0a, 0x0e, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x41, 0x75, 0x64, 0x69, 0x74, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x10, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x5f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0e, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x41, 0x75, 0x64, 0x69, 0x74, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x29, 0x0a, 0x09, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x68, 0x2e, 0x53, 0x70, 0x65, 0x6e,
+	0x64, 0x4b, 0x65, 0x79, 0x52, 0x08, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x31,
+	0x0a, 0x0f, 0x62, 0x6c, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x5a,
+	0x72, 0x52, 0x0e, 0x62, 0x6c, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x22, 0x69, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x1f, 0x0a, 0x05, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x67, 0x68, 0x2e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x28, 0x0a, 0x0a,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x08, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x47, 0x31, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x87, 0x02, 0x0a,
+	0x13, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x6e, 0x70, 0x75, 0x74, 0x12, 0x2d, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x6e, 0x6f,
+	0x67, 0x68, 0x2e, 0x5a, 0x72, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x09, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x47, 0x31,
+	0x52, 0x08, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08,
+	0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x47, 0x31, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x0d, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x69, 0x74,
+	0x79, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x6f,
+	0x67, 0x68, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x44, 0x52, 0x0c, 0x61, 0x6e, 0x6f, 0x6e,
+	0x79, 0x6d, 0x69, 0x74, 0x79, 0x53, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x26,
+	0x0a, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x08, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x47, 0x31, 0x52, 0x09, 0x6e, 0x75, 0x6c,
+	0x6c, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x9f, 0x02, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x68, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x67, 0x68, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6e, 0x6f, 0x67, 0x68,
+	0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x3c, 0x0a,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x67, 0x68, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x90, 0x02, 0x0a, 0x0b, 0x49, 0x73, 0x73,
+	0x75, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x07, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x67, 0x68,
+	0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
+	0x12, 0x21, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x12, 0x39, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x68, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b,
+	0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x57, 0x5a, 0x55, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c,
+	0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x66, 0x61, 0x62, 0x72, 0x69,
+	0x63, 0x2d, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2d, 0x73, 0x64, 0x6b, 0x2f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x7a, 0x6b, 0x61, 0x74, 0x64, 0x6c, 0x6f, 0x67, 0x2f,
+	0x67, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2d, 0x67, 0x6f, 0x2f, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ghactions_proto_rawDescOnce sync.Once
+	file_ghactions_proto_rawDescData = file_ghactions_proto_rawDesc
+)
+
+func file_ghactions_proto_rawDescGZIP() []byte {
+	file_ghactions_proto_rawDescOnce.Do(func() {
+		file_ghactions_proto_rawDescData = protoimpl.X.CompressGZIP(file_ghactions_proto_rawDescData)
+	})
+	return file_ghactions_proto_rawDescData
+}
+
+var file_ghactions_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_ghactions_proto_goTypes = []interface{}{
+	(*Token)(nil),               // 0: gh.Token
+	(*TokenMetadata)(nil),       // 1: gh.TokenMetadata
+	(*SpendKey)(nil),            // 2: gh.SpendKey
+	(*SpendAuditInfo)(nil),      // 3: gh.SpendAuditInfo
+	(*Output)(nil),              // 4: gh.Output
+	(*TransferActionInput)(nil), // 5: gh.TransferActionInput
+	(*TransferAction)(nil),      // 6: gh.TransferAction
+	(*IssueAction)(nil),         // 7: gh.IssueAction
+	nil,                         // 8: gh.TransferAction.MetadataEntry
+	nil,                         // 9: gh.IssueAction.MetadataEntry
+	(*math.G1)(nil),             // 10: nogh.G1
+	(*math.Zr)(nil),             // 11: nogh.Zr
+	(*pp.Identity)(nil),         // 12: nogh.Identity
+	(*actions.TokenID)(nil),     // 13: nogh.TokenID
+	(*actions.Proof)(nil),       // 14: nogh.Proof
+}
+var file_ghactions_proto_depIdxs = []int32{
+	10, // 0: gh.Token.data:type_name -> nogh.G1
+	10, // 1: gh.Token.spend_key:type_name -> nogh.G1
+	11, // 2: gh.TokenMetadata.value:type_name -> nogh.Zr
+	11, // 3: gh.TokenMetadata.blinding_factor:type_name -> nogh.Zr
+	11, // 4: gh.TokenMetadata.serial_number:type_name -> nogh.Zr
+	12, // 5: gh.TokenMetadata.issuer:type_name -> nogh.Identity
+	2,  // 6: gh.TokenMetadata.spend_key:type_name -> gh.SpendKey
+	11, // 7: gh.TokenMetadata.spend_key_blinding_factor:type_name -> nogh.Zr
+	10, // 8: gh.SpendKey.key:type_name -> nogh.G1
+	2,  // 9: gh.SpendAuditInfo.spend_key:type_name -> gh.SpendKey
+	11, // 10: gh.SpendAuditInfo.blinding_factor:type_name -> nogh.Zr
+	0,  // 11: gh.Output.token:type_name -> gh.Token
+	10, // 12: gh.Output.commitment:type_name -> nogh.G1
+	11, // 13: gh.TransferActionInput.serial_number:type_name -> nogh.Zr
+	10, // 14: gh.TransferActionInput.spend_key:type_name -> nogh.G1
+	10, // 15: gh.TransferActionInput.commitment:type_name -> nogh.G1
+	13, // 16: gh.TransferActionInput.anonymity_set:type_name -> nogh.TokenID
+	10, // 17: gh.TransferActionInput.nullifier:type_name -> nogh.G1
+	5,  // 18: gh.TransferAction.inputs:type_name -> gh.TransferActionInput
+	4,  // 19: gh.TransferAction.outputs:type_name -> gh.Output
+	14, // 20: gh.TransferAction.proof:type_name -> nogh.Proof
+	8,  // 21: gh.TransferAction.metadata:type_name -> gh.TransferAction.MetadataEntry
+	12, // 22: gh.IssueAction.issuer:type_name -> nogh.Identity
+	4,  // 23: gh.IssueAction.outputs:type_name -> gh.Output
+	14, // 24: gh.IssueAction.proof:type_name -> nogh.Proof
+	9,  // 25: gh.IssueAction.metadata:type_name -> gh.IssueAction.MetadataEntry
+	26, // [26:26] is the sub-list for method output_type
+	26, // [26:26] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_ghactions_proto_init() }
+func file_ghactions_proto_init() {
+	if File_ghactions_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ghactions_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Token); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ghactions_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TokenMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ghactions_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpendKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ghactions_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpendAuditInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ghactions_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Output); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ghactions_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferActionInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ghactions_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferAction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ghactions_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IssueAction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ghactions_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ghactions_proto_goTypes,
+		DependencyIndexes: file_ghactions_proto_depIdxs,
+		MessageInfos:      file_ghactions_proto_msgTypes,
+	}.Build()
+	File_ghactions_proto = out.File
+	file_ghactions_proto_rawDesc = nil
+	file_ghactions_proto_goTypes = nil
+	file_ghactions_proto_depIdxs = nil
+}
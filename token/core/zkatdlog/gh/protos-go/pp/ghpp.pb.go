@@ -0,0 +1,183 @@
+//
+//Copyright IBM Corp. All Rights Reserved.
+//
+//SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v5.28.1
+// source: ghpp.proto
+
+package pp
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PublicParameters describes the public parameters of zkatdlog with graph hiding
+type PublicParameters struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Identifier       string `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`                                        // the identifier of the public parameters
+	Version          uint64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`                                             // the version of these public params
+	Dlog             []byte `protobuf:"bytes,3,opt,name=dlog,proto3" json:"dlog,omitempty"`                                                    // the serialized zkatdlog public parameters these public parameters extend
+	AnonymitySetSize uint64 `protobuf:"varint,4,opt,name=anonymity_set_size,json=anonymitySetSize,proto3" json:"anonymity_set_size,omitempty"` // the maximum number of ledger tokens among which a spent token is hidden
+}
+
+func (x *PublicParameters) Reset() {
+	*x = PublicParameters{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ghpp_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublicParameters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicParameters) ProtoMessage() {}
+
+func (x *PublicParameters) ProtoReflect() protoreflect.Message {
+	mi := &file_ghpp_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicParameters.ProtoReflect.Descriptor instead.
+func (*PublicParameters) Descriptor() ([]byte, []int) {
+	return file_ghpp_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PublicParameters) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+func (x *PublicParameters) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PublicParameters) GetDlog() []byte {
+	if x != nil {
+		return x.Dlog
+	}
+	return nil
+}
+
+func (x *PublicParameters) GetAnonymitySetSize() uint64 {
+	if x != nil {
+		return x.AnonymitySetSize
+	}
+	return 0
+}
+
+var File_ghpp_proto protoreflect.FileDescriptor
+
+var file_ghpp_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x67, 0x68, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x67, 0x68,
+	0x22, 0x8e, 0x01, 0x0a, 0x10, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66,
+	0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x6c, 0x6f, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64,
+	0x6c, 0x6f, 0x67, 0x12, 0x2c, 0x0a, 0x12, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x69, 0x74, 0x79,
+	0x5f, 0x73, 0x65, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x10, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x69, 0x74, 0x79, 0x53, 0x65, 0x74, 0x53, 0x69, 0x7a,
+	0x65, 0x42, 0x52, 0x5a, 0x50, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73,
+	0x2f, 0x66, 0x61, 0x62, 0x72, 0x69, 0x63, 0x2d, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2d, 0x73, 0x64,
+	0x6b, 0x2f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x7a, 0x6b, 0x61,
+	0x74, 0x64, 0x6c, 0x6f, 0x67, 0x2f, 0x67, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2d,
+	0x67, 0x6f, 0x2f, 0x70, 0x70, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ghpp_proto_rawDescOnce sync.Once
+	file_ghpp_proto_rawDescData = file_ghpp_proto_rawDesc
+)
+
+func file_ghpp_proto_rawDescGZIP() []byte {
+	file_ghpp_proto_rawDescOnce.Do(func() {
+		file_ghpp_proto_rawDescData = protoimpl.X.CompressGZIP(file_ghpp_proto_rawDescData)
+	})
+	return file_ghpp_proto_rawDescData
+}
+
+var file_ghpp_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_ghpp_proto_goTypes = []interface{}{
+	(*PublicParameters)(nil), // 0: gh.PublicParameters
+}
+var file_ghpp_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_ghpp_proto_init() }
+func file_ghpp_proto_init() {
+	if File_ghpp_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ghpp_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PublicParameters); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ghpp_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ghpp_proto_goTypes,
+		DependencyIndexes: file_ghpp_proto_depIdxs,
+		MessageInfos:      file_ghpp_proto_msgTypes,
+	}.Build()
+	File_ghpp_proto = out.File
+	file_ghpp_proto_rawDesc = nil
+	file_ghpp_proto_goTypes = nil
+	file_ghpp_proto_depIdxs = nil
+}
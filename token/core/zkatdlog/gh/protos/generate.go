@@ -0,0 +1,10 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protos
+
+//go:generate protoc -I=. -I=../../nogh/protos ghpp.proto --go_out=../protos-go/pp --go_opt=paths=source_relative
+//go:generate protoc -I=. -I=../../nogh/protos -I=../../../fabtoken/protos ghactions.proto --go_out=../protos-go/actions --go_opt=paths=source_relative
@@ -0,0 +1,217 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"sync"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// AnonymitySetProvider selects the ledger tokens among which a spent token is hidden
+type AnonymitySetProvider interface {
+	// AnonymitySet returns exactly size distinct ledger tokens, the passed one included, and their identifiers
+	AnonymitySet(ctx context.Context, id *token2.ID, tok *token.Token, size int) ([]*token2.ID, []*token.Token, error)
+}
+
+// LedgerScanner gives access to the tokens committed on the ledger
+type LedgerScanner interface {
+	// CanScan returns true if the ledger can enumerate the committed transactions
+	CanScan() bool
+	// ScanValidTransactions invokes the callback, in commit order, on the valid transactions committed from the passed block on
+	ScanValidTransactions(ctx context.Context, fromBlock uint64, callback func(block uint64, txID string) error) error
+	// QueryToken returns the token stored on the ledger with the passed identifier, nil if it does not exist.
+	// Any other failure is returned as an error.
+	QueryToken(ctx context.Context, id *token2.ID) ([]byte, error)
+}
+
+// maxOutputGap is the number of consecutive missing outputs after which a transaction is considered fully indexed.
+// Redeemed outputs are not stored on the ledger and leave gaps in the output indexes of a transaction.
+const maxOutputGap = 16
+
+// DefaultMaxIndexedTokens is the default maximum number of ledger tokens kept in the index of a LedgerAnonymitySetProvider
+const DefaultMaxIndexedTokens = 100000
+
+// LedgerAnonymitySetProvider samples the anonymity sets among the spendable tokens on the ledger.
+// Recall that, with graph hiding, the ledger does not record which tokens have been spent,
+// then every token with a spend key is a valid decoy, independently of the sender and the other parties.
+// The provider keeps an in-memory index of the ledger tokens that is updated, by scanning the new blocks, at each request.
+// The index is a uniform sample of at most MaxIndexedTokens of the tokens found on the ledger.
+// If the ledger cannot be scanned, no anonymity set can be built: the tokens the node knows about are linked to it,
+// and would not hide the spent token.
+type LedgerAnonymitySetProvider struct {
+	Logger logging.Logger
+	Ledger LedgerScanner
+	// MaxIndexedTokens is the maximum number of tokens kept in the index
+	MaxIndexedTokens int
+
+	// updateLock serializes the updates of the index, which query the ledger
+	updateLock sync.Mutex
+	nextBlock  uint64
+	// lastBlockTxs are the transactions of block nextBlock already indexed, the block is scanned again at the next update
+	lastBlockTxs map[string]struct{}
+
+	// lock protects the index
+	lock sync.RWMutex
+	// found is the number of tokens found on the ledger, indexed or not
+	found  int
+	ids    []*token2.ID
+	tokens []*token.Token
+}
+
+func NewLedgerAnonymitySetProvider(logger logging.Logger, ledger LedgerScanner) *LedgerAnonymitySetProvider {
+	return &LedgerAnonymitySetProvider{
+		Logger:           logger,
+		Ledger:           ledger,
+		MaxIndexedTokens: DefaultMaxIndexedTokens,
+		lastBlockTxs:     map[string]struct{}{},
+	}
+}
+
+func (p *LedgerAnonymitySetProvider) AnonymitySet(ctx context.Context, id *token2.ID, tok *token.Token, size int) ([]*token2.ID, []*token.Token, error) {
+	if size < 1 {
+		return nil, nil, errors.Errorf("invalid anonymity set size [%d]", size)
+	}
+	if !p.Ledger.CanScan() {
+		return nil, nil, errors.Errorf("the ledger cannot be scanned, cannot build the anonymity set of [%s]", id)
+	}
+	if err := p.update(ctx); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to update the index of the ledger tokens")
+	}
+
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	// sample size-1 distinct decoys
+	candidates := make([]int, 0, len(p.ids))
+	for i, candidate := range p.ids {
+		if !candidate.Equal(*id) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) < size-1 {
+		return nil, nil, errors.Errorf("not enough tokens on the ledger to build an anonymity set of size [%d], found [%d]", size, len(candidates)+1)
+	}
+	ids := make([]*token2.ID, 0, size)
+	tokens := make([]*token.Token, 0, size)
+	for i := 0; i < size-1; i++ {
+		// partial Fisher-Yates shuffle
+		j, err := randomIndex(len(candidates) - i)
+		if err != nil {
+			return nil, nil, err
+		}
+		candidates[i], candidates[i+j] = candidates[i+j], candidates[i]
+		ids = append(ids, p.ids[candidates[i]])
+		tokens = append(tokens, p.tokens[candidates[i]])
+	}
+
+	// insert the spent token at a random position
+	position, err := randomIndex(len(ids) + 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids, tokens = insertAt(ids, tokens, id, tok, position)
+	return ids, tokens, nil
+}
+
+// update indexes the tokens committed since the last update.
+// The last scanned block is scanned again, the transactions already indexed are skipped.
+// The ledger is queried without holding the lock on the index.
+func (p *LedgerAnonymitySetProvider) update(ctx context.Context) error {
+	p.updateLock.Lock()
+	defer p.updateLock.Unlock()
+	return p.Ledger.ScanValidTransactions(ctx, p.nextBlock, func(block uint64, txID string) error {
+		if block != p.nextBlock {
+			p.nextBlock = block
+			p.lastBlockTxs = map[string]struct{}{}
+		}
+		if _, ok := p.lastBlockTxs[txID]; ok {
+			return nil
+		}
+		ids, tokens, err := p.spendableOutputs(ctx, txID)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to index the outputs of [%s]", txID)
+		}
+		if err := p.add(ids, tokens); err != nil {
+			return err
+		}
+		p.lastBlockTxs[txID] = struct{}{}
+		return nil
+	})
+}
+
+// spendableOutputs returns the outputs of the passed transaction that have a spend key
+func (p *LedgerAnonymitySetProvider) spendableOutputs(ctx context.Context, txID string) ([]*token2.ID, []*token.Token, error) {
+	var ids []*token2.ID
+	var tokens []*token.Token
+	gap := 0
+	for index := uint64(0); gap < maxOutputGap; index++ {
+		id := &token2.ID{TxId: txID, Index: index}
+		raw, err := p.Ledger.QueryToken(ctx, id)
+		if err != nil {
+			return nil, nil, errors.WithMessagef(err, "failed to query token [%s]", id)
+		}
+		if len(raw) == 0 {
+			gap++
+			continue
+		}
+		gap = 0
+		tok := &token.Token{}
+		if err := tok.Deserialize(raw); err != nil || tok.SpendKey == nil {
+			// not a token of this driver, or a redeemed output
+			continue
+		}
+		ids = append(ids, id)
+		tokens = append(tokens, tok)
+	}
+	return ids, tokens, nil
+}
+
+// add adds the passed tokens to the index by reservoir sampling,
+// so that the index stays a uniform sample of the tokens found on the ledger
+func (p *LedgerAnonymitySetProvider) add(ids []*token2.ID, tokens []*token.Token) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for i := range ids {
+		p.found++
+		if len(p.ids) < p.MaxIndexedTokens {
+			p.ids = append(p.ids, ids[i])
+			p.tokens = append(p.tokens, tokens[i])
+			continue
+		}
+		j, err := randomIndex(p.found)
+		if err != nil {
+			return err
+		}
+		if j < len(p.ids) {
+			p.ids[j] = ids[i]
+			p.tokens[j] = tokens[i]
+		}
+	}
+	return nil
+}
+
+// insertAt inserts the passed token and its identifier at the passed position
+func insertAt(ids []*token2.ID, tokens []*token.Token, id *token2.ID, tok *token.Token, position int) ([]*token2.ID, []*token.Token) {
+	ids = append(ids[:position], append([]*token2.ID{id}, ids[position:]...)...)
+	tokens = append(tokens[:position], append([]*token.Token{tok}, tokens[position:]...)...)
+	return ids, tokens
+}
+
+// randomIndex returns a random integer in [0, n)
+func randomIndex(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to sample random index")
+	}
+	return int(i.Int64()), nil
+}
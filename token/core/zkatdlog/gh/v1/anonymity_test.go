@@ -0,0 +1,168 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/stretchr/testify/assert"
+)
+
+type ledgerTx struct {
+	block uint64
+	txID  string
+}
+
+// ledgerScanner is an in-memory ledger
+type ledgerScanner struct {
+	canScan bool
+	txs     []ledgerTx
+	tokens  map[string][]byte
+	scans   []uint64
+	err     error
+}
+
+func (l *ledgerScanner) CanScan() bool {
+	return l.canScan
+}
+
+func (l *ledgerScanner) ScanValidTransactions(_ context.Context, fromBlock uint64, callback func(block uint64, txID string) error) error {
+	l.scans = append(l.scans, fromBlock)
+	for _, tx := range l.txs {
+		if tx.block < fromBlock {
+			continue
+		}
+		if err := callback(tx.block, tx.txID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *ledgerScanner) QueryToken(_ context.Context, id *token2.ID) ([]byte, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	return l.tokens[id.String()], nil
+}
+
+// commit appends a transaction with the passed outputs, nil outputs are redeemed
+func (l *ledgerScanner) commit(t *testing.T, block uint64, txID string, outputs ...*token.Token) {
+	l.txs = append(l.txs, ledgerTx{block: block, txID: txID})
+	for i, output := range outputs {
+		if output == nil {
+			continue
+		}
+		raw, err := output.Serialize()
+		assert.NoError(t, err)
+		l.tokens[(&token2.ID{TxId: txID, Index: uint64(i)}).String()] = raw
+	}
+}
+
+func newToken(c *math.Curve, i int, spendable bool) *token.Token {
+	tok := &token.Token{Data: c.GenG1.Mul(c.NewZrFromInt(int64(i + 1)))}
+	if spendable {
+		tok.SpendKey = c.GenG1.Mul(c.NewZrFromInt(int64(i + 100)))
+	}
+	return tok
+}
+
+func TestLedgerAnonymitySet(t *testing.T) {
+	c := math.Curves[math.BN254]
+	ledger := &ledgerScanner{canScan: true, tokens: map[string][]byte{}}
+	// a transfer with a redeemed output in the middle
+	ledger.commit(t, 1, "tx1", newToken(c, 0, true), newToken(c, 1, false), newToken(c, 2, true))
+	// an issue with a gap larger than the redeemed outputs
+	ledger.commit(t, 2, "tx2", newToken(c, 3, true), nil, nil, newToken(c, 4, true))
+	provider := NewLedgerAnonymitySetProvider(logging.MustGetLogger("test"), ledger)
+
+	spent := &token2.ID{TxId: "tx1", Index: 0}
+	spentToken := newToken(c, 0, true)
+	for i := 0; i < 20; i++ {
+		ids, tokens, err := provider.AnonymitySet(context.Background(), spent, spentToken, 4)
+		assert.NoError(t, err)
+		assert.Len(t, ids, 4)
+		assert.Len(t, tokens, 4)
+
+		seen := map[string]struct{}{}
+		found := false
+		for j, id := range ids {
+			seen[id.String()] = struct{}{}
+			// the redeemed output cannot be a decoy
+			assert.NotEqual(t, (&token2.ID{TxId: "tx1", Index: 1}).String(), id.String())
+			assert.NotNil(t, tokens[j].SpendKey)
+			if id.Equal(*spent) {
+				found = true
+				assert.Equal(t, spentToken, tokens[j])
+			}
+		}
+		assert.Len(t, seen, 4, "the anonymity set must have distinct tokens")
+		assert.True(t, found, "the anonymity set must contain the spent token")
+	}
+
+	// not enough decoys
+	_, _, err := provider.AnonymitySet(context.Background(), spent, spentToken, 5)
+	assert.ErrorContains(t, err, "not enough tokens on the ledger to build an anonymity set of size [5], found [4]")
+
+	// new blocks are indexed, the last scanned block is scanned again without indexing its transactions twice
+	ledger.commit(t, 2, "tx3", newToken(c, 5, true))
+	ledger.commit(t, 3, "tx4", newToken(c, 6, true))
+	ids, _, err := provider.AnonymitySet(context.Background(), spent, spentToken, 6)
+	assert.NoError(t, err)
+	assert.Len(t, ids, 6)
+	assert.Equal(t, uint64(2), ledger.scans[len(ledger.scans)-1])
+	_, _, err = provider.AnonymitySet(context.Background(), spent, spentToken, 7)
+	assert.ErrorContains(t, err, "found [6]")
+}
+
+func TestLedgerAnonymitySetFailures(t *testing.T) {
+	c := math.Curves[math.BN254]
+	spent, spentToken := &token2.ID{TxId: "tx1"}, newToken(c, 0, true)
+
+	// the ledger cannot be scanned, the vault is not a fallback
+	provider := NewLedgerAnonymitySetProvider(logging.MustGetLogger("test"), &ledgerScanner{})
+	_, _, err := provider.AnonymitySet(context.Background(), spent, spentToken, 4)
+	assert.ErrorContains(t, err, "the ledger cannot be scanned, cannot build the anonymity set of [[tx1:0]]")
+	_, _, err = provider.AnonymitySet(context.Background(), spent, spentToken, 0)
+	assert.ErrorContains(t, err, "invalid anonymity set size [0]")
+
+	// a failed query is not a missing output, the transaction is indexed at the next update
+	ledger := &ledgerScanner{canScan: true, tokens: map[string][]byte{}, err: fmt.Errorf("timeout")}
+	ledger.commit(t, 1, "tx1", newToken(c, 0, true), newToken(c, 1, true))
+	provider = NewLedgerAnonymitySetProvider(logging.MustGetLogger("test"), ledger)
+	_, _, err = provider.AnonymitySet(context.Background(), spent, spentToken, 2)
+	assert.ErrorContains(t, err, "failed to index the outputs of [tx1]: failed to query token [[tx1:0]]: timeout")
+	ledger.err = nil
+	ids, _, err := provider.AnonymitySet(context.Background(), spent, spentToken, 2)
+	assert.NoError(t, err)
+	assert.Len(t, ids, 2)
+}
+
+func TestLedgerAnonymitySetBoundedIndex(t *testing.T) {
+	c := math.Curves[math.BN254]
+	ledger := &ledgerScanner{canScan: true, tokens: map[string][]byte{}}
+	for i := 0; i < 10; i++ {
+		ledger.commit(t, uint64(i), fmt.Sprintf("tx%d", i), newToken(c, 2*i, true), newToken(c, 2*i+1, true))
+	}
+	provider := NewLedgerAnonymitySetProvider(logging.MustGetLogger("test"), ledger)
+	provider.MaxIndexedTokens = 5
+
+	spent := &token2.ID{TxId: "spent"}
+	ids, _, err := provider.AnonymitySet(context.Background(), spent, newToken(c, 100, true), 6)
+	assert.NoError(t, err)
+	assert.Len(t, ids, 6)
+	assert.Len(t, provider.ids, 5)
+	assert.Equal(t, 20, provider.found)
+	_, _, err = provider.AnonymitySet(context.Background(), spent, newToken(c, 100, true), 7)
+	assert.ErrorContains(t, err, "found [6]")
+}
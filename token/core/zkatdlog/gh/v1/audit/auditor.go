@@ -0,0 +1,185 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit
+
+import (
+	"context"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/issue"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/transfer"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/audit"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InfoMatcher matches identities to their audit information and returns the verifiers of the owners
+type InfoMatcher interface {
+	audit.InfoMatcher
+	GetOwnerVerifier(id driver.Identity) (driver.Verifier, error)
+}
+
+// Auditor inspects token requests with graph hiding.
+// The metadata of each output opens its token, owner and spend key included.
+// The inputs disclose only spenders, the audit information of each sender links its spender to the owner of the spent token.
+type Auditor struct {
+	*audit.Auditor
+	PublicParams *setup.PublicParams
+	Matcher      InfoMatcher
+}
+
+func NewAuditor(logger logging.Logger, tracer trace.Tracer, infoMatcher InfoMatcher, pp *setup.PublicParams, signer audit.SigningIdentity) *Auditor {
+	return &Auditor{
+		Auditor:      audit.NewAuditor(logger, tracer, infoMatcher, pp.PedersenGenerators, signer, math.Curves[pp.Curve]),
+		PublicParams: pp,
+		Matcher:      infoMatcher,
+	}
+}
+
+// Check validates TokenRequest against TokenRequestMetadata
+func (a *Auditor) Check(ctx context.Context, tokenRequest *driver.TokenRequest, tokenRequestMetadata *driver.TokenRequestMetadata, txID string) error {
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent("start_check")
+	defer span.AddEvent("end_check")
+
+	if len(tokenRequest.Issues) != len(tokenRequestMetadata.Issues) {
+		return errors.Errorf("number of issues does not match number of provided metadata")
+	}
+	span.AddEvent("check_issue_requests")
+	for k, md := range tokenRequestMetadata.Issues {
+		if err := a.checkIssue(tokenRequest.Issues[k], md); err != nil {
+			return errors.Wrapf(err, "audit of %d th issue in tx [%s] failed", k, txID)
+		}
+	}
+
+	if len(tokenRequest.Transfers) != len(tokenRequestMetadata.Transfers) {
+		return errors.Errorf("number of transfers does not match the number of provided metadata")
+	}
+	span.AddEvent("check_transfer_requests")
+	for k, md := range tokenRequestMetadata.Transfers {
+		if err := a.checkTransfer(tokenRequest.Transfers[k], md); err != nil {
+			return errors.Wrapf(err, "audit of %d th transfer in tx [%s] failed", k, txID)
+		}
+	}
+	return nil
+}
+
+func (a *Auditor) checkIssue(raw []byte, md *driver.IssueMetadata) error {
+	action := &issue.Action{}
+	if err := action.Deserialize(raw); err != nil {
+		return errors.Wrap(err, "failed to deserialize issue action")
+	}
+	if len(action.Outputs) != len(md.Outputs) {
+		return errors.Errorf("number of output does not match number of provided metadata")
+	}
+	for i, o := range md.Outputs {
+		if o == nil || len(o.Receivers) == 0 || o.Receivers[0] == nil {
+			return errors.Errorf("output at index [%d] has no receiver", i)
+		}
+		if action.Outputs[i] == nil || action.Outputs[i].IsRedeem() {
+			return errors.Errorf("issue cannot redeem tokens")
+		}
+		if err := a.inspectOutput(action.Outputs[i], o.OutputMetadata, o.Receivers[0].Identity, o.Receivers[0].AuditInfo, i); err != nil {
+			return err
+		}
+	}
+	return a.InspectIdentity(a.InfoMatcher, &audit.InspectableIdentity{
+		Identity:         action.Issuer,
+		IdentityFromMeta: md.Issuer.Identity,
+		AuditInfo:        md.Issuer.AuditInfo,
+	}, 0)
+}
+
+func (a *Auditor) checkTransfer(raw []byte, md *driver.TransferMetadata) error {
+	action := &transfer.Action{}
+	if err := action.Deserialize(raw); err != nil {
+		return errors.Wrap(err, "failed to deserialize transfer action")
+	}
+	if err := action.Validate(); err != nil {
+		return errors.Wrap(err, "failed to validate transfer")
+	}
+
+	// inputs
+	if len(action.Inputs) != len(md.Inputs) {
+		return errors.Errorf("number of inputs does not match the number of senders [%d]!=[%d]", len(md.Inputs), len(action.Inputs))
+	}
+	for i, input := range action.Inputs {
+		if md.Inputs[i] == nil || len(md.Inputs[i].Senders) == 0 || md.Inputs[i].Senders[0] == nil {
+			return errors.Errorf("input at index [%d] has no sender", i)
+		}
+		spender, err := input.Spender().Identity()
+		if err != nil {
+			return errors.Wrapf(err, "failed getting spender of input at index [%d]", i)
+		}
+		// the audit information of a spender is matched against the owner of the spent token and its spend key
+		if err := a.InspectIdentity(a.InfoMatcher, &audit.InspectableIdentity{
+			Identity:         spender,
+			IdentityFromMeta: md.Inputs[i].Senders[0].Identity,
+			AuditInfo:        md.Inputs[i].Senders[0].AuditInfo,
+		}, i); err != nil {
+			return errors.Wrapf(err, "failed inspecting input at index [%d]", i)
+		}
+	}
+
+	// outputs
+	if len(action.Outputs) != len(md.Outputs) {
+		return errors.Errorf("number of outputs does not match the number of output metadata [%d]!=[%d]", len(action.Outputs), len(md.Outputs))
+	}
+	for i, output := range action.Outputs {
+		if md.Outputs[i] == nil {
+			return errors.Errorf("output metadata at index [%d] is nil", i)
+		}
+		var identityFromMeta driver.Identity
+		if len(md.Outputs[i].Receivers) != 0 && md.Outputs[i].Receivers[0] != nil {
+			identityFromMeta = md.Outputs[i].Receivers[0].Identity
+		}
+		if err := a.inspectOutput(output, md.Outputs[i].OutputMetadata, identityFromMeta, md.Outputs[i].OutputAuditInfo, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inspectOutput checks that the metadata open the output and that the audit information matches its owner
+func (a *Auditor) inspectOutput(output *token.Output, rawMetadata []byte, identityFromMeta driver.Identity, auditInfo []byte, index int) error {
+	metadata := &token.Metadata{}
+	if err := metadata.Deserialize(rawMetadata); err != nil {
+		return errors.Wrapf(err, "failed to deserialize metadata of output [%d]", index)
+	}
+	// a redeemed output is stored on the ledger as its commitment to type and value
+	tok := output.Token
+	if output.IsRedeem() {
+		tok = &token.Token{Data: output.Commitment}
+	}
+	if _, err := tok.ToClear(metadata, a.PublicParams); err != nil {
+		return errors.Wrapf(err, "output at index [%d] does not match the provided opening", index)
+	}
+	if output.IsRedeem() {
+		return nil
+	}
+	// the commitment, on which the validity proofs are computed, hides the same type and value
+	if err := output.Verify(a.PublicParams); err != nil {
+		return errors.Wrapf(err, "invalid output at index [%d]", index)
+	}
+	// the spend key must belong to the owner, otherwise the owner could not spend the token
+	verifier, err := a.Matcher.GetOwnerVerifier(metadata.Owner)
+	if err != nil {
+		return errors.Wrapf(err, "failed getting verifier for the owner of output [%d]", index)
+	}
+	if err := metadata.GetSpendKey().Verify(verifier); err != nil {
+		return errors.Wrapf(err, "invalid spend key for output [%d]", index)
+	}
+	return a.InspectIdentity(a.InfoMatcher, &audit.InspectableIdentity{
+		Identity:         metadata.Owner,
+		IdentityFromMeta: identityFromMeta,
+		AuditInfo:        auditInfo,
+	}, index)
+}
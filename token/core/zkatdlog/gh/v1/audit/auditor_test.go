@@ -0,0 +1,280 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit_test
+
+import (
+	"context"
+	"testing"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/audit"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/issue"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/transfer"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// signer signs on behalf of the issuer, the auditor and the owners
+type signer struct {
+	id string
+}
+
+func (s *signer) Sign([]byte) ([]byte, error) {
+	return []byte(s.id + " signature"), nil
+}
+
+func (s *signer) Serialize() ([]byte, error) {
+	return []byte(s.id), nil
+}
+
+// ownerVerifier accepts the signatures of the passed owner
+type ownerVerifier struct {
+	id string
+}
+
+func (v *ownerVerifier) Verify(_, sigma []byte) error {
+	if string(sigma) != v.id+" signature" {
+		return errors.Errorf("invalid signature of [%s]", v.id)
+	}
+	return nil
+}
+
+// matcher accepts the audit info "audit:<id>" for the identity <id>, and the spend audit info that opens a spender
+type matcher struct {
+	pp *setup.PublicParams
+}
+
+func (m *matcher) MatchIdentity(id driver.Identity, ai []byte) error {
+	if typed, err := identity.UnmarshalTypedIdentity(id); err == nil && typed.Type == transfer.SpenderIdentityType {
+		spender := &transfer.Spender{}
+		if err := spender.Deserialize(typed.Identity); err != nil {
+			return err
+		}
+		info := &transfer.SpendAuditInfo{}
+		if err := info.Deserialize(ai); err != nil {
+			return err
+		}
+		if err := info.Match(spender, m.pp); err != nil {
+			return err
+		}
+		return m.MatchIdentity(info.Owner, info.OwnerAuditInfo)
+	}
+	if string(ai) != "audit:"+string(id) {
+		return errors.Errorf("audit info does not match [%s]", id)
+	}
+	return nil
+}
+
+func (m *matcher) GetOwnerVerifier(id driver.Identity) (driver.Verifier, error) {
+	return &ownerVerifier{id: string(id)}, nil
+}
+
+type environment struct {
+	pp      *setup.PublicParams
+	auditor *audit.Auditor
+	secrets map[string]*math.Zr
+}
+
+func newEnvironment(t *testing.T) *environment {
+	pp, err := setup.Setup(32, nil, math.BN254, 4)
+	assert.NoError(t, err)
+	return &environment{
+		pp:      pp,
+		auditor: audit.NewAuditor(logging.MustGetLogger("auditor"), &noop.Tracer{}, &matcher{pp: pp}, pp, &signer{id: "auditor"}),
+		secrets: map[string]*math.Zr{},
+	}
+}
+
+// spendKeys returns the spend keys of the passed owners, signed by the owners, nil for the redeemed outputs
+func (e *environment) spendKeys(t *testing.T, owners [][]byte) []*token.SpendKey {
+	curve := math.Curves[e.pp.Curve]
+	rand, err := curve.Rand()
+	assert.NoError(t, err)
+	keys := make([]*token.SpendKey, len(owners))
+	for i, owner := range owners {
+		if len(owner) == 0 {
+			continue
+		}
+		secret, ok := e.secrets[string(owner)]
+		if !ok {
+			secret = curve.NewRandomZr(rand)
+			e.secrets[string(owner)] = secret
+		}
+		keys[i], err = token.NewSpendKey(secret, &signer{id: string(owner)}, e.pp)
+		assert.NoError(t, err)
+	}
+	return keys
+}
+
+// outputMetadata returns the metadata of the passed outputs, as the creator of the outputs shares them with the auditors
+func outputMetadata(t *testing.T, metas []*token.Metadata) []*driver.TransferOutputMetadata {
+	res := make([]*driver.TransferOutputMetadata, len(metas))
+	for i, meta := range metas {
+		raw, err := meta.Serialize()
+		assert.NoError(t, err)
+		res[i] = &driver.TransferOutputMetadata{OutputMetadata: raw}
+		if len(meta.Owner) != 0 {
+			res[i].OutputAuditInfo = []byte("audit:" + string(meta.Owner))
+			res[i].Receivers = []*driver.AuditableIdentity{{Identity: meta.Owner, AuditInfo: res[i].OutputAuditInfo}}
+		}
+	}
+	return res
+}
+
+// issue returns an issue action that assigns tokens of value 10 and 20 to alice, with its metadata
+func (e *environment) issue(t *testing.T) (*issue.Action, []*token.Metadata, *driver.IssueMetadata) {
+	issuer := &issue.Issuer{}
+	issuer.New("ABC", &signer{id: "issuer"}, e.pp)
+	owners := [][]byte{[]byte("alice"), []byte("alice")}
+	action, metas, err := issuer.GenerateZKIssue([]uint64{10, 20}, owners, e.spendKeys(t, owners))
+	assert.NoError(t, err)
+
+	md := &driver.IssueMetadata{Issuer: driver.AuditableIdentity{Identity: []byte("issuer"), AuditInfo: []byte("audit:issuer")}}
+	for _, output := range outputMetadata(t, metas) {
+		md.Outputs = append(md.Outputs, &driver.IssueOutputMetadata{OutputMetadata: output.OutputMetadata, Receivers: output.Receivers})
+	}
+	return action, metas, md
+}
+
+// transfer returns a transfer action that spends the issued tokens, with its metadata
+func (e *environment) transfer(t *testing.T, issued *issue.Action, issuedMetas []*token.Metadata) (*transfer.Action, *driver.TransferMetadata) {
+	ids := []*token2.ID{{TxId: "issue", Index: 0}, {TxId: "issue", Index: 1}}
+	tokens := []*token.Token{issued.Outputs[0].Token, issued.Outputs[1].Token}
+	inputs := make([]*transfer.Input, len(ids))
+	for i := range inputs {
+		inputs[i] = &transfer.Input{
+			ID:              ids[i],
+			Metadata:        issuedMetas[i],
+			AnonymitySet:    ids,
+			AnonymityTokens: tokens,
+			SpendSecret:     e.secrets["alice"],
+		}
+	}
+	sender, err := transfer.NewSender(inputs, e.pp)
+	assert.NoError(t, err)
+	owners := [][]byte{[]byte("bob"), nil}
+	action, metas, err := sender.GenerateZKTransfer(context.Background(), []uint64{25, 5}, owners, e.spendKeys(t, owners))
+	assert.NoError(t, err)
+
+	md := &driver.TransferMetadata{Outputs: outputMetadata(t, metas)}
+	for i, input := range action.Inputs {
+		spender, err := input.Spender().Identity()
+		assert.NoError(t, err)
+		info := &transfer.SpendAuditInfo{
+			Owner:          []byte("alice"),
+			OwnerAuditInfo: []byte("audit:alice"),
+			SpendKey:       issuedMetas[i].GetSpendKey(),
+			BlindingFactor: sender.Signers[i].BlindingFactor(),
+		}
+		raw, err := info.Serialize()
+		assert.NoError(t, err)
+		md.Inputs = append(md.Inputs, &driver.TransferInputMetadata{
+			TokenID: ids[i],
+			Senders: []*driver.AuditableIdentity{{Identity: spender, AuditInfo: raw}},
+		})
+	}
+	return action, md
+}
+
+func TestAuditor(t *testing.T) {
+	env := newEnvironment(t)
+	issued, issuedMetas, issueMetadata := env.issue(t)
+	transferred, transferMetadata := env.transfer(t, issued, issuedMetas)
+
+	rawIssue, err := issued.Serialize()
+	assert.NoError(t, err)
+	rawTransfer, err := transferred.Serialize()
+	assert.NoError(t, err)
+	request := &driver.TokenRequest{Issues: [][]byte{rawIssue}, Transfers: [][]byte{rawTransfer}}
+	metadata := &driver.TokenRequestMetadata{
+		Issues:    []*driver.IssueMetadata{issueMetadata},
+		Transfers: []*driver.TransferMetadata{transferMetadata},
+	}
+	assert.NoError(t, env.auditor.Check(context.Background(), request, metadata, "tx"))
+
+	sigma, err := env.auditor.Endorse(request, "tx")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("auditor signature"), sigma)
+}
+
+func TestAuditorFailures(t *testing.T) {
+	env := newEnvironment(t)
+	issued, issuedMetas, issueMetadata := env.issue(t)
+	transferred, transferMetadata := env.transfer(t, issued, issuedMetas)
+	rawIssue, err := issued.Serialize()
+	assert.NoError(t, err)
+	rawTransfer, err := transferred.Serialize()
+	assert.NoError(t, err)
+
+	check := func(issueMetadata *driver.IssueMetadata, transferMetadata *driver.TransferMetadata) error {
+		return env.auditor.Check(
+			context.Background(),
+			&driver.TokenRequest{Issues: [][]byte{rawIssue}, Transfers: [][]byte{rawTransfer}},
+			&driver.TokenRequestMetadata{Issues: []*driver.IssueMetadata{issueMetadata}, Transfers: []*driver.TransferMetadata{transferMetadata}},
+			"tx",
+		)
+	}
+
+	// the metadata of an output must open it
+	md := *transferMetadata
+	md.Outputs = []*driver.TransferOutputMetadata{transferMetadata.Outputs[1], transferMetadata.Outputs[0]}
+	assert.ErrorContains(t, check(issueMetadata, &md), "audit of 0 th transfer in tx [tx] failed: output at index [0] does not match the provided opening")
+
+	// the audit info must match the owner of an output
+	output := *transferMetadata.Outputs[0]
+	output.OutputAuditInfo = []byte("audit:charlie")
+	md = *transferMetadata
+	md.Outputs = []*driver.TransferOutputMetadata{&output, transferMetadata.Outputs[1]}
+	assert.ErrorContains(t, check(issueMetadata, &md), "audit of 0 th transfer in tx [tx] failed: owner at index [0] does not match the provided opening")
+
+	// the spend audit info must open the spender of an input
+	input := *transferMetadata.Inputs[0]
+	input.Senders = transferMetadata.Inputs[1].Senders
+	md = *transferMetadata
+	md.Inputs = []*driver.TransferInputMetadata{&input, transferMetadata.Inputs[1]}
+	assert.ErrorContains(t, check(issueMetadata, &md), "audit of 0 th transfer in tx [tx] failed: failed inspecting input at index [0]")
+
+	md = *transferMetadata
+	md.Inputs = transferMetadata.Inputs[:1]
+	assert.ErrorContains(t, check(issueMetadata, &md), "audit of 0 th transfer in tx [tx] failed: number of inputs does not match the number of senders [1]!=[2]")
+
+	// the audit info of the issuer must match the issuer
+	imd := *issueMetadata
+	imd.Issuer = driver.AuditableIdentity{Identity: []byte("issuer"), AuditInfo: []byte("audit:another issuer")}
+	assert.ErrorContains(t, check(&imd, transferMetadata), "audit of 0 th issue in tx [tx] failed: owner at index [0] does not match the provided opening")
+
+	imd = *issueMetadata
+	imd.Outputs = []*driver.IssueOutputMetadata{issueMetadata.Outputs[0], {OutputMetadata: issueMetadata.Outputs[1].OutputMetadata}}
+	assert.ErrorContains(t, check(&imd, transferMetadata), "audit of 0 th issue in tx [tx] failed: output at index [1] has no receiver")
+
+	// the spend key of an output must be signed by its owner
+	metas := make([]*token.Metadata, len(issuedMetas))
+	for i, meta := range issuedMetas {
+		other := *meta
+		other.SpendKeySignature = []byte("charlie signature")
+		metas[i] = &other
+	}
+	raw, err := metas[0].Serialize()
+	assert.NoError(t, err)
+	imd = *issueMetadata
+	imd.Outputs = []*driver.IssueOutputMetadata{{OutputMetadata: raw, Receivers: issueMetadata.Outputs[0].Receivers}, issueMetadata.Outputs[1]}
+	assert.ErrorContains(t, check(&imd, transferMetadata), "audit of 0 th issue in tx [tx] failed: invalid spend key for output [0]")
+
+	assert.EqualError(t, env.auditor.Check(
+		context.Background(),
+		&driver.TokenRequest{Issues: [][]byte{rawIssue}},
+		&driver.TokenRequestMetadata{},
+		"tx",
+	), "number of issues does not match number of provided metadata")
+}
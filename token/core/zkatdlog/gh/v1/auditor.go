@@ -0,0 +1,58 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"context"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/tracing"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/audit"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type AuditorService struct {
+	Logger                  logging.Logger
+	PublicParametersManager common.PublicParametersManager[*setup.PublicParams]
+	Deserializer            driver.Deserializer
+	Metrics                 *Metrics
+	tracer                  trace.Tracer
+}
+
+func NewAuditorService(
+	logger logging.Logger,
+	publicParametersManager common.PublicParametersManager[*setup.PublicParams],
+	deserializer driver.Deserializer,
+	metrics *Metrics,
+	tracerProvider trace.TracerProvider,
+) *AuditorService {
+	return &AuditorService{
+		Logger:                  logger,
+		PublicParametersManager: publicParametersManager,
+		Deserializer:            deserializer,
+		Metrics:                 metrics,
+		tracer:                  tracerProvider.Tracer("auditor_service", tracing.WithMetricsOpts(tracing.MetricsOpts{Namespace: "gh"})),
+	}
+}
+
+// AuditorCheck verifies if the passed tokenRequest matches the tokenRequestMetadata
+func (s *AuditorService) AuditorCheck(ctx context.Context, request *driver.TokenRequest, metadata *driver.TokenRequestMetadata, txID string) error {
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent("start_auditor_check")
+	defer span.AddEvent("end_auditor_check")
+	s.Logger.Debugf("[%s] check token request validity, number of transfer actions [%d]...", txID, len(metadata.Transfers))
+
+	auditor := audit.NewAuditor(s.Logger, s.tracer, s.Deserializer, s.PublicParametersManager.PublicParams(), nil)
+	if err := auditor.Check(ctx, request, metadata, txID); err != nil {
+		return errors.WithMessagef(err, "failed to perform auditor check")
+	}
+	return nil
+}
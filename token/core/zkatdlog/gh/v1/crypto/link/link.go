@@ -0,0 +1,155 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package link
+
+import (
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/asn1"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/common"
+	"github.com/pkg/errors"
+)
+
+// Proof shows that a token, a commitment to type, value and serial number,
+// and a commitment to type and value hide the same type and value.
+// That is, token/commitment = S^sn * H^bf, for a serial number sn and a blinding factor bf known to the prover.
+type Proof struct {
+	// Commitment is the commitment to the randomness used by the prover
+	Commitment *math.G1
+	// SerialNumber is the proof of knowledge of the serial number
+	SerialNumber *math.Zr
+	// BlindingFactor is the proof of knowledge of the difference of the blinding factors
+	BlindingFactor *math.Zr
+}
+
+// Serialize marshals Proof
+func (p *Proof) Serialize() ([]byte, error) {
+	return asn1.MarshalMath(p.Commitment, p.SerialNumber, p.BlindingFactor)
+}
+
+// Deserialize un-marshals Proof
+func (p *Proof) Deserialize(raw []byte) error {
+	unmarshaller, err := asn1.NewUnmarshaller(raw)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize raw")
+	}
+	p.Commitment, err = unmarshaller.NextG1()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize commitment")
+	}
+	p.SerialNumber, err = unmarshaller.NextZr()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize serial number")
+	}
+	p.BlindingFactor, err = unmarshaller.NextZr()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize blinding factor")
+	}
+	return nil
+}
+
+// Prover produces a Proof
+type Prover struct {
+	// Token and Commitment are the commitments to link
+	Token, Commitment *math.G1
+	// Generators are the serial number and blinding factor generators
+	Generators []*math.G1
+	Curve      *math.Curve
+	// witness
+	serialNumber   *math.Zr
+	blindingFactor *math.Zr
+}
+
+// NewProver returns a Prover for the passed arguments.
+// blindingFactor is the difference between the blinding factors of token and commitment.
+func NewProver(token, commitment *math.G1, serialNumber, blindingFactor *math.Zr, generators []*math.G1, c *math.Curve) *Prover {
+	return &Prover{
+		Token:          token,
+		Commitment:     commitment,
+		Generators:     generators,
+		Curve:          c,
+		serialNumber:   serialNumber,
+		blindingFactor: blindingFactor,
+	}
+}
+
+// Prove returns a Proof
+func (p *Prover) Prove() (*Proof, error) {
+	if len(p.Generators) != 2 {
+		return nil, errors.Errorf("invalid number of generators [%d], expected 2", len(p.Generators))
+	}
+	rand, err := p.Curve.Rand()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get random number generator")
+	}
+	randomness := []*math.Zr{p.Curve.NewRandomZr(rand), p.Curve.NewRandomZr(rand)}
+	proof := &Proof{Commitment: p.Curve.NewG1()}
+	for i, r := range randomness {
+		proof.Commitment.Add(p.Generators[i].Mul(r))
+	}
+	x, err := challenge(p.Token, p.Commitment, proof.Commitment, p.Curve)
+	if err != nil {
+		return nil, err
+	}
+	order := p.Curve.GroupOrder
+	proof.SerialNumber = p.Curve.ModAdd(randomness[0], p.Curve.ModMul(x, p.serialNumber, order), order)
+	proof.BlindingFactor = p.Curve.ModAdd(randomness[1], p.Curve.ModMul(x, p.blindingFactor, order), order)
+	return proof, nil
+}
+
+// Verifier checks a Proof
+type Verifier struct {
+	// Token and Commitment are the commitments to link
+	Token, Commitment *math.G1
+	// Generators are the serial number and blinding factor generators
+	Generators []*math.G1
+	Curve      *math.Curve
+}
+
+// NewVerifier returns a Verifier for the passed arguments
+func NewVerifier(token, commitment *math.G1, generators []*math.G1, c *math.Curve) *Verifier {
+	return &Verifier{
+		Token:      token,
+		Commitment: commitment,
+		Generators: generators,
+		Curve:      c,
+	}
+}
+
+// Verify returns an error if the passed proof does not link the token to the commitment
+func (v *Verifier) Verify(proof *Proof) error {
+	if len(v.Generators) != 2 {
+		return errors.Errorf("invalid number of generators [%d], expected 2", len(v.Generators))
+	}
+	if proof == nil || proof.Commitment == nil || proof.SerialNumber == nil || proof.BlindingFactor == nil {
+		return errors.New("invalid link proof: nil elements")
+	}
+	if v.Token == nil || v.Commitment == nil {
+		return errors.New("invalid link proof: nil commitments")
+	}
+	x, err := challenge(v.Token, v.Commitment, proof.Commitment, v.Curve)
+	if err != nil {
+		return err
+	}
+	statement := v.Token.Copy()
+	statement.Sub(v.Commitment)
+	left := v.Generators[0].Mul(proof.SerialNumber)
+	left.Add(v.Generators[1].Mul(proof.BlindingFactor))
+	right := statement.Mul(x)
+	right.Add(proof.Commitment)
+	if !left.Equals(right) {
+		return errors.New("invalid link proof")
+	}
+	return nil
+}
+
+func challenge(token, commitment, randomness *math.G1, c *math.Curve) (*math.Zr, error) {
+	raw, err := common.GetG1Array([]*math.G1{token, commitment, randomness}).Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute the challenge of the link proof")
+	}
+	return c.HashToZr(raw), nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+package link_test
+
+import (
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/crypto/link"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Link Proof", func() {
+	var (
+		curve             *math.Curve
+		generators        []*math.G1
+		token, commitment *math.G1
+		serialNumber, bf  *math.Zr
+	)
+	BeforeEach(func() {
+		curve = math.Curves[math.BN254]
+		rand, err := curve.Rand()
+		Expect(err).NotTo(HaveOccurred())
+		generators = make([]*math.G1, 2)
+		for i := range generators {
+			generators[i] = curve.GenG1.Mul(curve.NewRandomZr(rand))
+		}
+		commitment = curve.GenG1.Mul(curve.NewRandomZr(rand))
+		serialNumber = curve.NewRandomZr(rand)
+		bf = curve.NewRandomZr(rand)
+		token = commitment.Copy()
+		token.Add(generators[0].Mul(serialNumber))
+		token.Add(generators[1].Mul(bf))
+	})
+
+	Context("when the token and the commitment hide the same type and value", func() {
+		It("succeeds", func() {
+			proof, err := link.NewProver(token, commitment, serialNumber, bf, generators, curve).Prove()
+			Expect(err).NotTo(HaveOccurred())
+			raw, err := proof.Serialize()
+			Expect(err).NotTo(HaveOccurred())
+			proof2 := &link.Proof{}
+			Expect(proof2.Deserialize(raw)).To(Succeed())
+			Expect(link.NewVerifier(token, commitment, generators, curve).Verify(proof2)).To(Succeed())
+		})
+	})
+
+	Context("when the commitment hides a different value", func() {
+		It("fails", func() {
+			proof, err := link.NewProver(token, commitment, serialNumber, bf, generators, curve).Prove()
+			Expect(err).NotTo(HaveOccurred())
+			other := commitment.Copy()
+			other.Add(curve.GenG1)
+			Expect(link.NewVerifier(token, other, generators, curve).Verify(proof)).To(MatchError("invalid link proof"))
+		})
+	})
+
+	Context("when the generators are missing", func() {
+		It("fails", func() {
+			_, err := link.NewProver(token, commitment, serialNumber, bf, generators[:1], curve).Prove()
+			Expect(err).To(MatchError("invalid number of generators [1], expected 2"))
+		})
+	})
+})
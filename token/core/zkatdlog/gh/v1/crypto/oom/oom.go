@@ -0,0 +1,388 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oom
+
+import (
+	"math/bits"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/asn1"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/common"
+	"github.com/pkg/errors"
+)
+
+// Proof is a one-out-of-many proof as described by Groth and Kohlweiss in
+// "One-out-of-Many Proofs: Or How to Leak a Secret and Spend a Coin".
+// It shows that one of a list of commitments opens to zero, that is, it is of the form H^r,
+// without revealing which one.
+// The size of the proof is logarithmic in the size of the list.
+type Proof struct {
+	// L contains the commitments to the bits of the secret index
+	L []*math.G1
+	// A contains the commitments to the randomness used to hide the bits of the index
+	A []*math.G1
+	// B contains the commitments to the product of each bit and its randomness
+	B []*math.G1
+	// D contains the commitments to the coefficients of the polynomials
+	D []*math.G1
+	// F contains the bits of the index, hidden by the randomness in A
+	F []*math.Zr
+	// ZA contains the openings of L^xA
+	ZA []*math.Zr
+	// ZB contains the openings of L^(x-f)B
+	ZB []*math.Zr
+	// ZD is the opening of the product of the commitments, raised to the polynomials evaluated at the challenge, divided by D
+	ZD *math.Zr
+}
+
+// Serialize marshals Proof
+func (p *Proof) Serialize() ([]byte, error) {
+	l, err := asn1.NewElementArray(p.L)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize L")
+	}
+	a, err := asn1.NewElementArray(p.A)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize A")
+	}
+	b, err := asn1.NewElementArray(p.B)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize B")
+	}
+	d, err := asn1.NewElementArray(p.D)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize D")
+	}
+	f, err := asn1.NewElementArray(p.F)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize F")
+	}
+	za, err := asn1.NewElementArray(p.ZA)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize ZA")
+	}
+	zb, err := asn1.NewElementArray(p.ZB)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize ZB")
+	}
+	return asn1.MarshalMath(l, a, b, d, f, za, zb, p.ZD)
+}
+
+// Deserialize un-marshals Proof
+func (p *Proof) Deserialize(raw []byte) error {
+	unmarshaller, err := asn1.NewUnmarshaller(raw)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize raw")
+	}
+	p.L, err = unmarshaller.NextG1Array()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize L")
+	}
+	p.A, err = unmarshaller.NextG1Array()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize A")
+	}
+	p.B, err = unmarshaller.NextG1Array()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize B")
+	}
+	p.D, err = unmarshaller.NextG1Array()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize D")
+	}
+	p.F, err = unmarshaller.NextZrArray()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize F")
+	}
+	p.ZA, err = unmarshaller.NextZrArray()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize ZA")
+	}
+	p.ZB, err = unmarshaller.NextZrArray()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize ZB")
+	}
+	p.ZD, err = unmarshaller.NextZr()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize ZD")
+	}
+	return nil
+}
+
+// Pad returns the passed commitments followed by copies of the last one,
+// so that the length of the result is the smallest power of two, greater than one, not smaller than the number of commitments
+func Pad(commitments []*math.G1) []*math.G1 {
+	if len(commitments) == 0 {
+		return commitments
+	}
+	size := 1 << bitLength(len(commitments))
+	if size < 2 {
+		size = 2
+	}
+	padded := make([]*math.G1, size)
+	copy(padded, commitments)
+	for i := len(commitments); i < size; i++ {
+		padded[i] = commitments[len(commitments)-1]
+	}
+	return padded
+}
+
+// Prover produces a one-out-of-many proof
+type Prover struct {
+	// Commitments is the list of commitments, its length must be a power of two greater than one
+	Commitments []*math.G1
+	// G and H are the generators of the commitments to the bits of the index
+	G, H  *math.G1
+	Curve *math.Curve
+	// index is the position of the commitment that opens to zero
+	index int
+	// randomness is such that Commitments[index] = H^randomness
+	randomness *math.Zr
+}
+
+// NewProver returns a Prover for the passed arguments
+func NewProver(commitments []*math.G1, index int, randomness *math.Zr, G, H *math.G1, c *math.Curve) *Prover {
+	return &Prover{
+		Commitments: commitments,
+		G:           G,
+		H:           H,
+		Curve:       c,
+		index:       index,
+		randomness:  randomness,
+	}
+}
+
+// Prove returns a one-out-of-many proof
+func (p *Prover) Prove() (*Proof, error) {
+	N := len(p.Commitments)
+	if N < 2 || N&(N-1) != 0 {
+		return nil, errors.Errorf("invalid number of commitments [%d], it must be a power of two greater than one", N)
+	}
+	if p.index < 0 || p.index >= N {
+		return nil, errors.Errorf("invalid index [%d]", p.index)
+	}
+	n := bitLength(N)
+	rand, err := p.Curve.Rand()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get random number generator")
+	}
+	order := p.Curve.GroupOrder
+	zero := p.Curve.NewZrFromInt(0)
+	one := p.Curve.NewZrFromInt(1)
+
+	// commit to the bits of the index
+	l := make([]*math.Zr, n)
+	r := make([]*math.Zr, n)
+	a := make([]*math.Zr, n)
+	s := make([]*math.Zr, n)
+	t := make([]*math.Zr, n)
+	rho := make([]*math.Zr, n)
+	proof := &Proof{
+		L: make([]*math.G1, n),
+		A: make([]*math.G1, n),
+		B: make([]*math.G1, n),
+		D: make([]*math.G1, n),
+	}
+	for j := 0; j < n; j++ {
+		l[j] = zero
+		if p.index>>j&1 == 1 {
+			l[j] = one
+		}
+		r[j] = p.Curve.NewRandomZr(rand)
+		a[j] = p.Curve.NewRandomZr(rand)
+		s[j] = p.Curve.NewRandomZr(rand)
+		t[j] = p.Curve.NewRandomZr(rand)
+		rho[j] = p.Curve.NewRandomZr(rand)
+
+		proof.L[j] = p.commit(l[j], r[j])
+		proof.A[j] = p.commit(a[j], s[j])
+		proof.B[j] = p.commit(p.Curve.ModMul(l[j], a[j], order), t[j])
+	}
+
+	// compute the coefficients of the polynomials and commit to them
+	for k := 0; k < n; k++ {
+		proof.D[k] = p.H.Mul(rho[k])
+	}
+	for i := 0; i < N; i++ {
+		coefficients := p.coefficients(i, l, a)
+		for k := 0; k < n; k++ {
+			proof.D[k].Add(p.Commitments[i].Mul(coefficients[k]))
+		}
+	}
+
+	x, err := challenge(p.Commitments, proof, p.Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	proof.F = make([]*math.Zr, n)
+	proof.ZA = make([]*math.Zr, n)
+	proof.ZB = make([]*math.Zr, n)
+	for j := 0; j < n; j++ {
+		proof.F[j] = p.Curve.ModAdd(p.Curve.ModMul(l[j], x, order), a[j], order)
+		proof.ZA[j] = p.Curve.ModAdd(p.Curve.ModMul(r[j], x, order), s[j], order)
+		proof.ZB[j] = p.Curve.ModAdd(p.Curve.ModMul(r[j], p.Curve.ModSub(x, proof.F[j], order), order), t[j], order)
+	}
+	// zd = randomness * x^n - sum_k rho_k * x^k
+	xPow := one
+	proof.ZD = zero
+	for k := 0; k < n; k++ {
+		proof.ZD = p.Curve.ModSub(proof.ZD, p.Curve.ModMul(rho[k], xPow, order), order)
+		xPow = p.Curve.ModMul(xPow, x, order)
+	}
+	proof.ZD = p.Curve.ModAdd(proof.ZD, p.Curve.ModMul(p.randomness, xPow, order), order)
+
+	return proof, nil
+}
+
+// coefficients returns the coefficients of degree less than n of the polynomial
+// prod_j f_{j,i_j}(x), where f_{j,1}(x) = l_j x + a_j and f_{j,0}(x) = x - f_{j,1}(x)
+func (p *Prover) coefficients(i int, l, a []*math.Zr) []*math.Zr {
+	order := p.Curve.GroupOrder
+	n := len(l)
+	one := p.Curve.NewZrFromInt(1)
+	// poly[k] is the coefficient of x^k
+	poly := make([]*math.Zr, n+1)
+	poly[0] = one
+	for k := 1; k <= n; k++ {
+		poly[k] = p.Curve.NewZrFromInt(0)
+	}
+	for j := 0; j < n; j++ {
+		// f(x) = c1 x + c0
+		var c1, c0 *math.Zr
+		if i>>j&1 == 1 {
+			c1, c0 = l[j], a[j]
+		} else {
+			c1, c0 = p.Curve.ModSub(one, l[j], order), p.Curve.ModNeg(a[j], order)
+		}
+		for k := j + 1; k > 0; k-- {
+			poly[k] = p.Curve.ModAdd(p.Curve.ModMul(poly[k], c0, order), p.Curve.ModMul(poly[k-1], c1, order), order)
+		}
+		poly[0] = p.Curve.ModMul(poly[0], c0, order)
+	}
+	return poly[:n]
+}
+
+func (p *Prover) commit(value, randomness *math.Zr) *math.G1 {
+	com := p.G.Mul(value)
+	com.Add(p.H.Mul(randomness))
+	return com
+}
+
+// Verifier checks one-out-of-many proofs
+type Verifier struct {
+	// Commitments is the list of commitments, its length must be a power of two greater than one
+	Commitments []*math.G1
+	// G and H are the generators of the commitments to the bits of the index
+	G, H  *math.G1
+	Curve *math.Curve
+}
+
+// NewVerifier returns a Verifier for the passed arguments
+func NewVerifier(commitments []*math.G1, G, H *math.G1, c *math.Curve) *Verifier {
+	return &Verifier{
+		Commitments: commitments,
+		G:           G,
+		H:           H,
+		Curve:       c,
+	}
+}
+
+// Verify returns an error if the passed proof is not a valid one-out-of-many proof for the commitments of the verifier
+func (v *Verifier) Verify(proof *Proof) error {
+	N := len(v.Commitments)
+	if N < 2 || N&(N-1) != 0 {
+		return errors.Errorf("invalid number of commitments [%d], it must be a power of two greater than one", N)
+	}
+	if proof == nil || proof.ZD == nil {
+		return errors.New("invalid one-out-of-many proof: nil elements")
+	}
+	n := bitLength(N)
+	if len(proof.L) != n || len(proof.A) != n || len(proof.B) != n || len(proof.D) != n ||
+		len(proof.F) != n || len(proof.ZA) != n || len(proof.ZB) != n {
+		return errors.Errorf("invalid one-out-of-many proof: expected vectors of length [%d]", n)
+	}
+	for _, elements := range [][]*math.G1{proof.L, proof.A, proof.B, proof.D} {
+		for _, e := range elements {
+			if e == nil {
+				return errors.New("invalid one-out-of-many proof: nil elements")
+			}
+		}
+	}
+	for _, elements := range [][]*math.Zr{proof.F, proof.ZA, proof.ZB} {
+		for _, e := range elements {
+			if e == nil {
+				return errors.New("invalid one-out-of-many proof: nil elements")
+			}
+		}
+	}
+
+	x, err := challenge(v.Commitments, proof, v.Curve)
+	if err != nil {
+		return err
+	}
+	order := v.Curve.GroupOrder
+
+	// check that L commits to bits
+	for j := 0; j < n; j++ {
+		left := proof.L[j].Mul(x)
+		left.Add(proof.A[j])
+		right := v.G.Mul(proof.F[j])
+		right.Add(v.H.Mul(proof.ZA[j]))
+		if !left.Equals(right) {
+			return errors.Errorf("invalid one-out-of-many proof: invalid commitment to bit [%d]", j)
+		}
+		left = proof.L[j].Mul(v.Curve.ModSub(x, proof.F[j], order))
+		left.Add(proof.B[j])
+		if !left.Equals(v.H.Mul(proof.ZB[j])) {
+			return errors.Errorf("invalid one-out-of-many proof: bit [%d] is not binary", j)
+		}
+	}
+
+	// check that one commitment opens to zero
+	fx := make([]*math.Zr, n)
+	for j := 0; j < n; j++ {
+		fx[j] = v.Curve.ModSub(x, proof.F[j], order)
+	}
+	left := v.Curve.NewG1()
+	for i := 0; i < N; i++ {
+		exp := v.Curve.NewZrFromInt(1)
+		for j := 0; j < n; j++ {
+			if i>>j&1 == 1 {
+				exp = v.Curve.ModMul(exp, proof.F[j], order)
+			} else {
+				exp = v.Curve.ModMul(exp, fx[j], order)
+			}
+		}
+		left.Add(v.Commitments[i].Mul(exp))
+	}
+	xPow := v.Curve.NewZrFromInt(1)
+	for k := 0; k < n; k++ {
+		left.Sub(proof.D[k].Mul(xPow))
+		xPow = v.Curve.ModMul(xPow, x, order)
+	}
+	if !left.Equals(v.H.Mul(proof.ZD)) {
+		return errors.New("invalid one-out-of-many proof")
+	}
+	return nil
+}
+
+func challenge(commitments []*math.G1, proof *Proof, c *math.Curve) (*math.Zr, error) {
+	raw, err := common.GetG1Array(commitments, proof.L, proof.A, proof.B, proof.D).Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute the challenge of the one-out-of-many proof")
+	}
+	return c.HashToZr(raw), nil
+}
+
+// bitLength returns the number of bits needed to index size elements
+func bitLength(size int) int {
+	if size <= 1 {
+		return 0
+	}
+	return bits.Len(uint(size - 1))
+}
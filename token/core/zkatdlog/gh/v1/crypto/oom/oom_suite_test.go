@@ -0,0 +1,18 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+package oom_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestOneOutOfMany(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "One-out-of-Many Proof Suite")
+}
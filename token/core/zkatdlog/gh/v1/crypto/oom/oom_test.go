@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+package oom_test
+
+import (
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/crypto/oom"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("One-out-of-Many Proof", func() {
+	var (
+		curve       *math.Curve
+		G, H        *math.G1
+		commitments []*math.G1
+		randomness  *math.Zr
+		index       int
+	)
+	BeforeEach(func() {
+		curve = math.Curves[math.BN254]
+		rand, err := curve.Rand()
+		Expect(err).NotTo(HaveOccurred())
+		G = curve.GenG1.Mul(curve.NewRandomZr(rand))
+		H = curve.GenG1.Mul(curve.NewRandomZr(rand))
+		commitments = make([]*math.G1, 6)
+		for i := range commitments {
+			commitments[i] = G.Mul(curve.NewRandomZr(rand))
+			commitments[i].Add(H.Mul(curve.NewRandomZr(rand)))
+		}
+		index = 4
+		randomness = curve.NewRandomZr(rand)
+		commitments[index] = H.Mul(randomness)
+		commitments = oom.Pad(commitments)
+	})
+
+	It("pads to a power of two", func() {
+		Expect(commitments).To(HaveLen(8))
+		Expect(commitments[7]).To(Equal(commitments[5]))
+		Expect(oom.Pad(commitments[:1])).To(HaveLen(2))
+		Expect(oom.Pad(commitments[:4])).To(HaveLen(4))
+	})
+
+	Context("when the prover knows the opening", func() {
+		It("succeeds", func() {
+			proof, err := oom.NewProver(commitments, index, randomness, G, H, curve).Prove()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proof.L).To(HaveLen(3))
+			Expect(oom.NewVerifier(commitments, G, H, curve).Verify(proof)).To(Succeed())
+		})
+		It("survives serialization", func() {
+			proof, err := oom.NewProver(commitments, index, randomness, G, H, curve).Prove()
+			Expect(err).NotTo(HaveOccurred())
+			raw, err := proof.Serialize()
+			Expect(err).NotTo(HaveOccurred())
+			proof2 := &oom.Proof{}
+			Expect(proof2.Deserialize(raw)).To(Succeed())
+			Expect(oom.NewVerifier(commitments, G, H, curve).Verify(proof2)).To(Succeed())
+		})
+	})
+
+	Context("when the index is wrong", func() {
+		It("fails", func() {
+			proof, err := oom.NewProver(commitments, 2, randomness, G, H, curve).Prove()
+			Expect(err).NotTo(HaveOccurred())
+			err = oom.NewVerifier(commitments, G, H, curve).Verify(proof)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("invalid one-out-of-many proof"))
+		})
+	})
+
+	Context("when the commitments do not match", func() {
+		It("fails", func() {
+			proof, err := oom.NewProver(commitments, index, randomness, G, H, curve).Prove()
+			Expect(err).NotTo(HaveOccurred())
+			other := append([]*math.G1{}, commitments...)
+			other[index] = other[0]
+			Expect(oom.NewVerifier(other, G, H, curve).Verify(proof)).NotTo(Succeed())
+		})
+	})
+
+	Context("when the proof is malformed", func() {
+		It("fails", func() {
+			proof, err := oom.NewProver(commitments, index, randomness, G, H, curve).Prove()
+			Expect(err).NotTo(HaveOccurred())
+			proof.F = proof.F[1:]
+			err = oom.NewVerifier(commitments, G, H, curve).Verify(proof)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("expected vectors of length [3]"))
+		})
+		It("fails if the number of commitments is not a power of two", func() {
+			_, err := oom.NewProver(commitments[:3], 1, randomness, G, H, curve).Prove()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	v1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/transfer"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/deserializer"
+	idemix2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/idemix"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/x509"
+	"github.com/pkg/errors"
+)
+
+// Deserializer deserializes verifiers associated with issuers, owners, spenders, and auditors.
+// Owners can only be idemix or x509 identities, scripts are not supported with graph hiding.
+// Spenders sign the transfers in place of the owners of the spent tokens.
+type Deserializer struct {
+	*common.Deserializer
+}
+
+// NewDeserializer returns a deserializer
+func NewDeserializer(pp *v1.PublicParams) (*Deserializer, error) {
+	if pp == nil {
+		return nil, errors.New("failed to get deserializer: nil public parameters")
+	}
+
+	des := deserializer.NewTypedVerifierDeserializerMultiplex()
+	for _, idemixIssuerPublicKey := range pp.IdemixIssuerPublicKeys {
+		idemixDes, err := idemix2.NewDeserializer(idemixIssuerPublicKey.PublicKey, idemixIssuerPublicKey.Curve)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed getting idemix deserializer for passed public params [%d]", idemixIssuerPublicKey.Curve)
+		}
+		des.AddTypedVerifierDeserializer(idemix2.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(idemixDes, idemixDes))
+	}
+	des.AddTypedVerifierDeserializer(x509.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(&x509.IdentityDeserializer{}, &x509.AuditMatcherDeserializer{}))
+	des.AddTypedVerifierDeserializer(transfer.SpenderIdentityType, &SpenderDeserializer{PublicParams: pp, Owners: des})
+
+	return &Deserializer{Deserializer: common.NewDeserializer(idemix2.IdentityType, des, des, des, des, des)}, nil
+}
+
+type PublicParamsDeserializer struct{}
+
+func (p *PublicParamsDeserializer) DeserializePublicParams(raw []byte, label string) (*v1.PublicParams, error) {
+	return v1.NewPublicParamsFromBytes(raw, label)
+}
+
+// EIDRHDeserializer returns enrollment ID and revocation handle behind the owners of token
+type EIDRHDeserializer = deserializer.EIDRHDeserializer
+
+// NewEIDRHDeserializer returns an enrollmentService
+func NewEIDRHDeserializer() *EIDRHDeserializer {
+	d := deserializer.NewEIDRHDeserializer()
+	d.AddDeserializer(idemix2.IdentityType, &idemix2.AuditInfoDeserializer{})
+	d.AddDeserializer(x509.IdentityType, &x509.AuditInfoDeserializer{})
+	d.AddDeserializer(transfer.SpenderIdentityType, &SpendAuditInfoDeserializer{Owners: d})
+	return d
+}
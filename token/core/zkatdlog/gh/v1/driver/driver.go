@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/hash"
+	view2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/server/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/metrics"
+	v1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	token3 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/sdk/vault"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/config"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Driver struct {
+	*base
+	metricsProvider  metrics.Provider
+	tracerProvider   trace.TracerProvider
+	configService    *config.Service
+	storageProvider  identity.StorageProvider
+	identityProvider view2.IdentityProvider
+	endpointService  *view.EndpointService
+	networkProvider  *network.Provider
+	vaultProvider    *vault.Provider
+}
+
+func NewDriver(
+	metricsProvider metrics.Provider,
+	tracerProvider trace.TracerProvider,
+	configService *config.Service,
+	storageProvider identity.StorageProvider,
+	identityProvider view2.IdentityProvider,
+	endpointService *view.EndpointService,
+	networkProvider *network.Provider,
+	vaultProvider *vault.Provider,
+) core.NamedFactory[driver.Driver] {
+	return core.NamedFactory[driver.Driver]{
+		Name: setup.GHPublicParameters,
+		Driver: &Driver{
+			base:             &base{},
+			metricsProvider:  metricsProvider,
+			tracerProvider:   tracerProvider,
+			configService:    configService,
+			storageProvider:  storageProvider,
+			identityProvider: identityProvider,
+			endpointService:  endpointService,
+			networkProvider:  networkProvider,
+			vaultProvider:    vaultProvider,
+		},
+	}
+}
+
+func (d *Driver) NewTokenService(tmsID driver.TMSID, publicParams []byte) (driver.TokenManagerService, error) {
+	logger := logging.DriverLogger("token-sdk.driver.zkatdlog-gh", tmsID.Network, tmsID.Channel, tmsID.Namespace)
+
+	logger.Debugf("creating new token service with public parameters [%s]", hash.Hashable(publicParams))
+
+	if len(publicParams) == 0 {
+		return nil, errors.Errorf("empty public parameters")
+	}
+	// get network
+	n, err := d.networkProvider.GetNetwork(tmsID.Network, tmsID.Channel)
+	if err != nil {
+		return nil, errors.Errorf("failed getting network [%s]", err)
+	}
+
+	// get vault
+	vault, err := d.vaultProvider.Vault(tmsID.Network, tmsID.Channel, tmsID.Namespace)
+	if err != nil {
+		return nil, errors.Errorf("failed getting vault [%s]", err)
+	}
+
+	networkLocalMembership := n.LocalMembership()
+
+	tmsConfig, err := d.configService.ConfigurationFor(tmsID.Network, tmsID.Channel, tmsID.Namespace)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get config for token service for [%s:%s:%s]", tmsID.Network, tmsID.Channel, tmsID.Namespace)
+	}
+
+	ppm, err := common.NewPublicParamsManager[*setup.PublicParams](
+		&PublicParamsDeserializer{},
+		setup.GHPublicParameters,
+		publicParams,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to initiliaze public params manager")
+	}
+
+	pp := ppm.PublicParams()
+	logger.Infof("new token driver for tms id [%s] with label and version [%s:%s]: [%s]", tmsID, pp.Identifier(), pp.Version(), pp)
+
+	qe := vault.QueryEngine()
+	ws, spendKeyManager, err := d.newWalletService(
+		tmsConfig,
+		d.endpointService,
+		d.storageProvider,
+		qe,
+		logger,
+		d.identityProvider.DefaultIdentity(),
+		networkLocalMembership.DefaultIdentity(),
+		ppm,
+		false,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to initiliaze wallet service for [%s:%s]", tmsID.Network, tmsID.Namespace)
+	}
+	deserializer := ws.Deserializer
+	ip := ws.IdentityProvider
+
+	// scripts are not supported, the owner of a token is never disclosed to the validators
+	authorization := common.NewAuthorizationMultiplexer(
+		common.NewTMSAuthorization(logger, ppm.PublicParams(), ws),
+	)
+
+	metricsProvider := metrics.NewTMSProvider(tmsConfig.ID(), d.metricsProvider)
+	driverMetrics := v1.NewMetrics(metricsProvider)
+	tokensService, err := token3.NewTokensService(logger, ppm, deserializer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to initiliaze token service for [%s:%s]", tmsID.Network, tmsID.Namespace)
+	}
+	service, err := v1.NewTokenService(
+		logger,
+		ws,
+		ppm,
+		ip,
+		deserializer,
+		tmsConfig,
+		v1.NewIssueService(logger, ppm, ws, deserializer, spendKeyManager, driverMetrics),
+		v1.NewTransferService(
+			logger,
+			ppm,
+			ws,
+			common.NewVaultLedgerTokenAndMetadataLoader[[]byte, []byte](qe, &common.IdentityTokenAndMetadataDeserializer{}),
+			deserializer,
+			driverMetrics,
+			d.tracerProvider,
+			tokensService,
+			v1.NewLedgerAnonymitySetProvider(logger, NewLedgerScanner(n, tmsID.Namespace)),
+			ip,
+			spendKeyManager,
+		),
+		v1.NewAuditorService(
+			logger,
+			ppm,
+			deserializer,
+			driverMetrics,
+			d.tracerProvider,
+		),
+		tokensService,
+		&v1.TokensUpgradeService{},
+		authorization,
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create token service")
+	}
+
+	return service, err
+}
+
+func (d *Driver) NewDefaultValidator(params driver.PublicParameters) (driver.Validator, error) {
+	pp, ok := params.(*setup.PublicParams)
+	if !ok {
+		return nil, errors.Errorf("invalid public parameters type [%T]", params)
+	}
+
+	return d.DefaultValidator(pp)
+}
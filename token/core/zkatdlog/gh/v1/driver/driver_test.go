@@ -0,0 +1,285 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	v1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1"
+	ghdriver "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/transfer"
+	noghsetup "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// message is the token request signed by the spenders
+var message = []byte("token request")
+
+// signer signs on behalf of the owners
+type signer struct {
+	id string
+}
+
+func (s *signer) Sign([]byte) ([]byte, error) {
+	return []byte(s.id + " signature"), nil
+}
+
+// verifier accepts the signatures of signer
+type verifier struct {
+	id string
+}
+
+func (v *verifier) Verify(_, sigma []byte) error {
+	if string(sigma) != v.id+" signature" {
+		return errors.Errorf("invalid signature of [%s]", v.id)
+	}
+	return nil
+}
+
+// owners deserializes the verifiers of the owners and accepts the audit info "audit:<id>" for the identity <id>
+type owners struct{}
+
+func (o *owners) DeserializeVerifier(id driver.Identity) (driver.Verifier, error) {
+	return &verifier{id: string(id)}, nil
+}
+
+func (o *owners) GetOwnerVerifier(id driver.Identity) (driver.Verifier, error) {
+	return &verifier{id: string(id)}, nil
+}
+
+func (o *owners) MatchIdentity(id driver.Identity, ai []byte) error {
+	if string(ai) != "audit:"+string(id) {
+		return errors.Errorf("audit info does not match [%s]", id)
+	}
+	return nil
+}
+
+// identityProvider knows the signers of alice only
+type identityProvider struct{}
+
+func (p *identityProvider) IsMe(id driver.Identity) bool {
+	return string(id) == "alice"
+}
+
+func (p *identityProvider) GetSigner(id driver.Identity) (driver.Signer, error) {
+	return &signer{id: string(id)}, nil
+}
+
+type keystore map[string][]byte
+
+func (k keystore) Put(id string, state interface{}) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	k[id] = raw
+	return nil
+}
+
+func (k keystore) Get(id string, state interface{}) error {
+	raw, ok := k[id]
+	if !ok {
+		return errors.Errorf("state [%s] not found", id)
+	}
+	return json.Unmarshal(raw, state)
+}
+
+// tokenInfoStorage returns the spend keys registered with the recipient identities
+type tokenInfoStorage map[string][]byte
+
+func (s tokenInfoStorage) GetTokenInfo(id []byte) ([]byte, []byte, error) {
+	return s[string(id)], nil, nil
+}
+
+// ownerWallet returns alice as recipient identity
+type ownerWallet struct {
+	driver.OwnerWallet
+	remote bool
+}
+
+func (w *ownerWallet) GetRecipientData() (*driver.RecipientData, error) {
+	return &driver.RecipientData{Identity: []byte("alice"), AuditInfo: []byte("audit:alice")}, nil
+}
+
+func (w *ownerWallet) Remote() bool {
+	return w.remote
+}
+
+func newPublicParams(t *testing.T) *setup.PublicParams {
+	ipk, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := setup.Setup(32, ipk, math.FP256BN_AMCL, 4)
+	assert.NoError(t, err)
+	pp.IssuerIDs = []driver.Identity{[]byte("issuer")}
+	return pp
+}
+
+// newSpender spends a token of alice and returns the spender of the input, its signature on message, and its audit info
+func newSpender(t *testing.T, pp *setup.PublicParams) (*transfer.Spender, []byte, *transfer.SpendAuditInfo) {
+	curve := math.Curves[pp.Curve]
+	rand, err := curve.Rand()
+	assert.NoError(t, err)
+	secret := curve.NewRandomZr(rand)
+	spendKey, err := token.NewSpendKey(secret, &signer{id: "alice"}, pp)
+	assert.NoError(t, err)
+	outputs, metas, _, err := token.NewOutputs([]uint64{10, 20}, [][]byte{[]byte("alice"), []byte("alice")}, []*token.SpendKey{spendKey, spendKey}, "ABC", pp)
+	assert.NoError(t, err)
+
+	ids := []*token2.ID{{TxId: "issue", Index: 0}, {TxId: "issue", Index: 1}}
+	sender, err := transfer.NewSender([]*transfer.Input{{
+		ID:              ids[0],
+		Metadata:        metas[0],
+		AnonymitySet:    ids,
+		AnonymityTokens: []*token.Token{outputs[0].Token, outputs[1].Token},
+		SpendSecret:     secret,
+	}}, pp)
+	assert.NoError(t, err)
+	action, _, err := sender.GenerateZKTransfer(context.Background(), []uint64{10}, [][]byte{nil}, []*token.SpendKey{nil})
+	assert.NoError(t, err)
+	signatures, err := sender.SignTokenActions(message)
+	assert.NoError(t, err)
+	return action.Inputs[0].Spender(), signatures[0], &transfer.SpendAuditInfo{
+		Owner:          []byte("alice"),
+		OwnerAuditInfo: []byte("audit:alice"),
+		SpendKey:       spendKey,
+		BlindingFactor: sender.Signers[0].BlindingFactor(),
+	}
+}
+
+func TestPPMFactory(t *testing.T) {
+	pp := newPublicParams(t)
+	raw, err := pp.Serialize()
+	assert.NoError(t, err)
+
+	factory := ghdriver.NewPPMFactory()
+	assert.Equal(t, setup.GHPublicParameters, string(factory.Name))
+	params, err := factory.Driver.PublicParametersFromBytes(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, pp.AnonymitySetSize, params.(*setup.PublicParams).AnonymitySetSize)
+	ppm, err := factory.Driver.NewPublicParametersManager(params)
+	assert.NoError(t, err)
+	assert.Equal(t, params, ppm.PublicParameters())
+	validator, err := factory.Driver.DefaultValidator(params)
+	assert.NoError(t, err)
+	assert.NotNil(t, validator)
+
+	_, err = factory.Driver.PublicParametersFromBytes([]byte("invalid"))
+	assert.ErrorContains(t, err, "failed to unmarshal public parameters")
+
+	// the public parameters of zkatdlog do not have graph hiding
+	dlog, err := noghsetup.Setup(32, nil, math.BN254)
+	assert.NoError(t, err)
+	_, err = factory.Driver.NewPublicParametersManager(dlog)
+	assert.EqualError(t, err, "invalid public parameters type [*setup.PublicParams]")
+	raw, err = dlog.Serialize()
+	assert.NoError(t, err)
+	_, err = factory.Driver.PublicParametersFromBytes(raw)
+	assert.Error(t, err)
+}
+
+func TestDeserializer(t *testing.T) {
+	_, err := ghdriver.NewDeserializer(nil)
+	assert.EqualError(t, err, "failed to get deserializer: nil public parameters")
+
+	pp := newPublicParams(t)
+	des, err := ghdriver.NewDeserializer(pp)
+	assert.NoError(t, err)
+
+	// the spenders sign the transfers in place of the owners of the spent tokens
+	spender, sigma, _ := newSpender(t, pp)
+	id, err := spender.Identity()
+	assert.NoError(t, err)
+	v, err := des.GetOwnerVerifier(id)
+	assert.NoError(t, err)
+	assert.NoError(t, v.Verify(message, sigma))
+	assert.Error(t, v.Verify([]byte("another request"), sigma))
+	recipients, err := des.Recipients(id)
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.Identity{id}, recipients)
+}
+
+func TestSpenderMatcher(t *testing.T) {
+	pp := newPublicParams(t)
+	spender, _, info := newSpender(t, pp)
+	raw, err := spender.Bytes()
+	assert.NoError(t, err)
+	auditInfo, err := info.Serialize()
+	assert.NoError(t, err)
+
+	des := &ghdriver.SpenderDeserializer{PublicParams: pp, Owners: &owners{}}
+	matcher, err := des.GetAuditInfoMatcher(nil, auditInfo)
+	assert.NoError(t, err)
+	assert.NoError(t, matcher.Match(raw))
+
+	// the owner must match its audit info
+	info.OwnerAuditInfo = []byte("audit:bob")
+	matcher = &ghdriver.SpenderMatcher{PublicParams: pp, Owners: &owners{}, AuditInfo: info}
+	assert.ErrorContains(t, matcher.Match(raw), "does not match its audit info")
+
+	// the owner must have signed the spend key
+	info.OwnerAuditInfo = []byte("audit:alice")
+	info.SpendKey = &token.SpendKey{Key: info.SpendKey.Key, Signature: []byte("bob signature")}
+	assert.ErrorContains(t, matcher.Match(raw), "invalid signature on spend key: invalid signature of [alice]")
+
+	// the spender must commit to the spend key of the owner
+	curve := math.Curves[pp.Curve]
+	rand, err := curve.Rand()
+	assert.NoError(t, err)
+	info.BlindingFactor = curve.NewRandomZr(rand)
+	assert.EqualError(t, matcher.Match(raw), "spender does not match the spend key of the owner")
+
+	_, err = des.GetAuditInfoMatcher(nil, []byte("invalid"))
+	assert.Error(t, err)
+	_, err = des.DeserializeVerifier("", []byte("invalid"))
+	assert.Error(t, err)
+}
+
+func TestOwnerWallet(t *testing.T) {
+	pp := newPublicParams(t)
+	ppm, err := common.NewPublicParamsManagerFromParams[*setup.PublicParams](pp)
+	assert.NoError(t, err)
+	ks := keystore{}
+	manager := v1.NewSpendKeyManager(ppm, ks, "seed", &identityProvider{}, tokenInfoStorage{}, &owners{})
+
+	// a local wallet shares the spend key of its recipient identity, signed by the identity
+	w := &ghdriver.OwnerWallet{OwnerWallet: &ownerWallet{}, SpendKeyManager: manager}
+	data, err := w.GetRecipientData()
+	assert.NoError(t, err)
+	assert.Equal(t, driver.Identity("alice"), data.Identity)
+	key := &token.SpendKey{}
+	assert.NoError(t, key.Deserialize(data.TokenMetadata))
+	assert.NoError(t, key.Verify(&verifier{id: "alice"}))
+	secret, err := manager.SpendSecret([]byte("alice"))
+	assert.NoError(t, err)
+	assert.True(t, pp.TokenGenerators()[4].Mul(secret).Equals(key.Key))
+
+	// the spend secrets survive a restart, the seed is in the keystore
+	restarted := v1.NewSpendKeyManager(ppm, ks, "seed", &identityProvider{}, tokenInfoStorage{}, &owners{})
+	again, err := restarted.SpendSecret([]byte("alice"))
+	assert.NoError(t, err)
+	assert.True(t, secret.Equals(again))
+
+	// a remote wallet cannot share spend keys
+	w = &ghdriver.OwnerWallet{OwnerWallet: &ownerWallet{remote: true}, SpendKeyManager: manager}
+	data, err = w.GetRecipientData()
+	assert.NoError(t, err)
+	assert.Empty(t, data.TokenMetadata)
+
+	// the spend key of a remote owner is the one registered with its identity
+	_, err = manager.SpendKey([]byte("bob"))
+	assert.ErrorContains(t, err, "no spend key registered for owner")
+}
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"context"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// LedgerScanner gives access to the tokens committed on the ledger of a namespace
+type LedgerScanner struct {
+	Network   *network.Network
+	Namespace string
+}
+
+func NewLedgerScanner(network *network.Network, namespace string) *LedgerScanner {
+	return &LedgerScanner{Network: network, Namespace: namespace}
+}
+
+func (l *LedgerScanner) CanScan() bool {
+	ledger, err := l.Network.Ledger()
+	return err == nil && ledger.CanScan()
+}
+
+func (l *LedgerScanner) ScanValidTransactions(ctx context.Context, fromBlock uint64, callback func(block uint64, txID string) error) error {
+	ledger, err := l.Network.Ledger()
+	if err != nil {
+		return errors.Wrap(err, "failed getting ledger")
+	}
+	return ledger.ScanTransactions(ctx, l.Namespace, fromBlock, func(tx *network.LedgerTransaction) (bool, error) {
+		if tx.Status != network.Valid {
+			return false, nil
+		}
+		return false, callback(tx.Block, tx.TxID)
+	})
+}
+
+func (l *LedgerScanner) QueryToken(ctx context.Context, id *token.ID) ([]byte, error) {
+	res, err := l.Network.QueryTokens(ctx, l.Namespace, []*token.ID{id})
+	if network.IsTokenNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	return res[0], nil
+}
@@ -0,0 +1,32 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	v1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/pkg/errors"
+)
+
+type PPMFactory struct{ *base }
+
+func NewPPMFactory() core.NamedFactory[driver.PPMFactory] {
+	return core.NamedFactory[driver.PPMFactory]{
+		Name:   v1.GHPublicParameters,
+		Driver: &PPMFactory{},
+	}
+}
+
+func (d *PPMFactory) NewPublicParametersManager(params driver.PublicParameters) (driver.PublicParamsManager, error) {
+	pp, ok := params.(*v1.PublicParams)
+	if !ok {
+		return nil, errors.Errorf("invalid public parameters type [%T]", params)
+	}
+	return common.NewPublicParamsManagerFromParams[*v1.PublicParams](pp)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/transfer"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/deserializer"
+	idriver "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/driver"
+	"github.com/pkg/errors"
+)
+
+// OwnerDeserializer deserializes the verifiers of the owners and matches them to their audit information
+type OwnerDeserializer interface {
+	DeserializeVerifier(id driver.Identity) (driver.Verifier, error)
+	MatchIdentity(id driver.Identity, ai []byte) error
+}
+
+// SpenderDeserializer deserializes the spenders that sign the transfers in place of the owners of the spent tokens
+type SpenderDeserializer struct {
+	PublicParams *setup.PublicParams
+	Owners       OwnerDeserializer
+}
+
+func (d *SpenderDeserializer) DeserializeVerifier(_ identity.Type, raw []byte) (driver.Verifier, error) {
+	spender := &transfer.Spender{}
+	if err := spender.Deserialize(raw); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize spender")
+	}
+	return transfer.NewSpendVerifier(spender, d.PublicParams), nil
+}
+
+func (d *SpenderDeserializer) Recipients(id driver.Identity, _ identity.Type, _ []byte) ([]driver.Identity, error) {
+	return []driver.Identity{id}, nil
+}
+
+func (d *SpenderDeserializer) GetAuditInfo(id driver.Identity, _ identity.Type, _ []byte, p driver.AuditInfoProvider) ([]byte, error) {
+	auditInfo, err := p.GetAuditInfo(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting audit info for spender [%s]", id)
+	}
+	return auditInfo, nil
+}
+
+func (d *SpenderDeserializer) GetAuditInfoMatcher(_ driver.Identity, auditInfo []byte) (driver.Matcher, error) {
+	info := &transfer.SpendAuditInfo{}
+	if err := info.Deserialize(auditInfo); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize spend audit info")
+	}
+	return &SpenderMatcher{PublicParams: d.PublicParams, Owners: d.Owners, AuditInfo: info}, nil
+}
+
+// SpenderMatcher matches a spender to the owner of the spent token
+type SpenderMatcher struct {
+	PublicParams *setup.PublicParams
+	Owners       OwnerDeserializer
+	AuditInfo    *transfer.SpendAuditInfo
+}
+
+// Match checks that the spender commits to the spend key of the audit information,
+// that the owner signed the spend key, and that the owner matches its own audit information
+func (m *SpenderMatcher) Match(raw []byte) error {
+	spender := &transfer.Spender{}
+	if err := spender.Deserialize(raw); err != nil {
+		return errors.Wrap(err, "failed to deserialize spender")
+	}
+	if err := m.AuditInfo.Match(spender, m.PublicParams); err != nil {
+		return err
+	}
+	verifier, err := m.Owners.DeserializeVerifier(m.AuditInfo.Owner)
+	if err != nil {
+		return errors.Wrapf(err, "failed getting verifier for owner [%s]", m.AuditInfo.Owner)
+	}
+	if err := m.AuditInfo.SpendKey.Verify(verifier); err != nil {
+		return errors.Wrapf(err, "spend key does not belong to owner [%s]", m.AuditInfo.Owner)
+	}
+	if err := m.Owners.MatchIdentity(m.AuditInfo.Owner, m.AuditInfo.OwnerAuditInfo); err != nil {
+		return errors.Wrapf(err, "owner [%s] does not match its audit info", m.AuditInfo.Owner)
+	}
+	return nil
+}
+
+// SpendAuditInfoDeserializer returns enrollment ID and revocation handle of the owner behind a spender
+type SpendAuditInfoDeserializer struct {
+	Owners *deserializer.EIDRHDeserializer
+}
+
+func (d *SpendAuditInfoDeserializer) DeserializeAuditInfo(raw []byte) (idriver.AuditInfo, error) {
+	info := &transfer.SpendAuditInfo{}
+	if err := info.Deserialize(raw); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize spend audit info")
+	}
+	eID, rH, err := d.Owners.GetEIDAndRH(info.Owner, info.OwnerAuditInfo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting enrollment id of owner [%s]", info.Owner)
+	}
+	return &spendAuditInfo{enrollmentID: eID, revocationHandle: rH}, nil
+}
+
+type spendAuditInfo struct {
+	enrollmentID     string
+	revocationHandle string
+}
+
+func (i *spendAuditInfo) EnrollmentID() string {
+	return i.enrollmentID
+}
+
+func (i *spendAuditInfo) RevocationHandle() string {
+	return i.revocationHandle
+}
@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	v1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/wallet"
+	"github.com/pkg/errors"
+)
+
+// WalletFactory creates owner wallets that share the spend keys of their identities
+type WalletFactory struct {
+	*wallet.Factory
+	SpendKeyManager *v1.SpendKeyManager
+}
+
+func (f *WalletFactory) NewWallet(id string, role identity.RoleType, walletRegistry wallet.Registry, identityInfo identity.Info) (driver.Wallet, error) {
+	w, err := f.Factory.NewWallet(id, role, walletRegistry, identityInfo)
+	if err != nil {
+		return nil, err
+	}
+	ow, ok := w.(driver.OwnerWallet)
+	if role != identity.OwnerRole || !ok {
+		return w, nil
+	}
+	return &OwnerWallet{OwnerWallet: ow, SpendKeyManager: f.SpendKeyManager}, nil
+}
+
+// OwnerWallet attaches the spend key of a recipient identity to its recipient data, as token metadata.
+// The creator of a token needs the spend key of the recipient, and checks it against the recipient identity.
+// Remote wallets do not hold the signing keys of their identities, then they cannot share spend keys.
+type OwnerWallet struct {
+	driver.OwnerWallet
+	SpendKeyManager *v1.SpendKeyManager
+}
+
+func (w *OwnerWallet) GetRecipientData() (*driver.RecipientData, error) {
+	data, err := w.OwnerWallet.GetRecipientData()
+	if err != nil {
+		return nil, err
+	}
+	if w.Remote() {
+		return data, nil
+	}
+	data.TokenMetadata, err = w.GetTokenMetadata(data.Identity)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (w *OwnerWallet) GetTokenMetadata(id driver.Identity) ([]byte, error) {
+	key, err := w.SpendKeyManager.SpendKey(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting spend key for [%s]", id)
+	}
+	return key.Serialize()
+}
@@ -0,0 +1,163 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	common2 "github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/issue"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/common"
+	nogh "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/issue"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+type IssueService struct {
+	Logger                  logging.Logger
+	PublicParametersManager common2.PublicParametersManager[*setup.PublicParams]
+	WalletService           driver.WalletService
+	Deserializer            driver.Deserializer
+	SpendKeyManager         *SpendKeyManager
+	Metrics                 *Metrics
+}
+
+func NewIssueService(
+	logger logging.Logger,
+	publicParametersManager common2.PublicParametersManager[*setup.PublicParams],
+	walletService driver.WalletService,
+	deserializer driver.Deserializer,
+	spendKeyManager *SpendKeyManager,
+	metrics *Metrics,
+) *IssueService {
+	return &IssueService{
+		Logger:                  logger,
+		PublicParametersManager: publicParametersManager,
+		WalletService:           walletService,
+		Deserializer:            deserializer,
+		SpendKeyManager:         spendKeyManager,
+		Metrics:                 metrics,
+	}
+}
+
+// Issue returns an IssueAction as a function of the passed arguments
+// Issue also returns a serialization TokenInformation associated with issued tokens
+// and the identity of the issuer
+func (s *IssueService) Issue(ctx context.Context, issuerIdentity driver.Identity, tokenType token.Type, values []uint64, owners [][]byte, opts *driver.IssueOptions) (driver.IssueAction, *driver.IssueMetadata, error) {
+	spendKeys := make([]*token2.SpendKey, len(owners))
+	for i, owner := range owners {
+		// a recipient cannot be empty
+		if len(owner) == 0 {
+			return nil, nil, errors.Errorf("all recipients should be defined")
+		}
+		var err error
+		spendKeys[i], err = s.SpendKeyManager.SpendKey(owner)
+		if err != nil {
+			return nil, nil, errors.WithMessagef(err, "failed getting spend key of recipient [%d]", i)
+		}
+	}
+	if opts != nil && opts.TokensUpgradeRequest != nil {
+		return nil, nil, errors.New("tokens upgrade is not supported with graph hiding")
+	}
+
+	w, err := s.WalletService.IssuerWallet(issuerIdentity)
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, err := w.GetSigner(issuerIdentity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issuer := &issue.Issuer{}
+	issuer.New(tokenType, &common.WrappedSigningIdentity{
+		Identity: issuerIdentity,
+		Signer:   signer,
+	}, s.PublicParametersManager.PublicParams())
+
+	start := time.Now()
+	issueAction, zkOutputsMetadata, err := issuer.GenerateZKIssue(values, owners, spendKeys)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "failed to generate zk issue")
+	}
+	duration := time.Since(start)
+	s.Metrics.zkIssueDuration.Observe(float64(duration.Milliseconds()))
+
+	var outputsMetadata []*driver.IssueOutputMetadata
+	for i, owner := range owners {
+		raw, err := zkOutputsMetadata[i].Serialize()
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "failed serializing token info")
+		}
+		auditInfo, err := s.Deserializer.GetAuditInfo(owner, s.WalletService)
+		if err != nil {
+			return nil, nil, err
+		}
+		outputsMetadata = append(outputsMetadata, &driver.IssueOutputMetadata{
+			OutputMetadata: raw,
+			Receivers: []*driver.AuditableIdentity{
+				{
+					Identity:  owner,
+					AuditInfo: auditInfo,
+				},
+			},
+		})
+	}
+
+	issuerAuditInfo, err := s.Deserializer.GetAuditInfo(issuerIdentity, s.WalletService)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get audit info for issuer identity")
+	}
+
+	meta := &driver.IssueMetadata{
+		Issuer: driver.AuditableIdentity{
+			Identity:  issuerIdentity,
+			AuditInfo: issuerAuditInfo,
+		},
+		Outputs:      outputsMetadata,
+		ExtraSigners: nil,
+	}
+
+	return issueAction, meta, err
+}
+
+// VerifyIssue checks if the outputs of an IssueAction match the passed metadata
+func (s *IssueService) VerifyIssue(ia driver.IssueAction, metadata []*driver.IssueOutputMetadata) error {
+	if ia == nil {
+		return errors.New("failed to verify issue: nil issue action")
+	}
+	action, ok := ia.(*issue.Action)
+	if !ok {
+		return errors.New("failed to verify issue: expected *gh.IssueAction")
+	}
+	pp := s.PublicParametersManager.PublicParams()
+	for i, output := range action.Outputs {
+		if err := output.Verify(pp); err != nil {
+			return errors.Wrapf(err, "failed to verify issue: invalid output [%d]", i)
+		}
+	}
+	coms, err := action.GetCommitments()
+	if err != nil {
+		return errors.New("failed to verify issue")
+	}
+	return nogh.NewVerifier(coms, pp.PublicParams).Verify(action.GetProof())
+}
+
+// DeserializeIssueAction un-marshals raw bytes into an IssueAction
+func (s *IssueService) DeserializeIssueAction(raw []byte) (driver.IssueAction, error) {
+	issue := &issue.Action{}
+	err := issue.Deserialize(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize issue action")
+	}
+	return issue, nil
+}
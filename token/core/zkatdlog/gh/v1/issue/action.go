@@ -0,0 +1,188 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issue
+
+import (
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/proto"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/protos-go/actions"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	noghactions "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/actions"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/pp"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/protos"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/slices"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+const ProtocolV1 = 1
+
+// Action specifies an issue of one or more tokens with graph hiding
+type Action struct {
+	// Issuer is the identity of issuer
+	Issuer driver.Identity
+	// Outputs are the newly issued tokens
+	Outputs []*token.Output
+	// Proof carries the ZKP of IssueAction validity
+	Proof []byte
+	// Metadata of the issue action
+	Metadata map[string][]byte
+}
+
+// NumInputs returns 0, an issue action with graph hiding spends no token
+func (i *Action) NumInputs() int {
+	return 0
+}
+
+func (i *Action) GetInputs() []*token2.ID {
+	return nil
+}
+
+func (i *Action) GetSerializedInputs() ([][]byte, error) {
+	return nil, nil
+}
+
+func (i *Action) GetSerialNumbers() []string {
+	return nil
+}
+
+// GetMetadata returns IssueAction metadata if there is any.
+func (i *Action) GetMetadata() map[string][]byte {
+	return i.Metadata
+}
+
+// IsAnonymous returns a Boolean. True if IssueAction is anonymous, and False otherwise.
+func (i *Action) IsAnonymous() bool {
+	return false
+}
+
+// NumOutputs returns the number of outputs in IssueAction
+func (i *Action) NumOutputs() int {
+	return len(i.Outputs)
+}
+
+// GetOutputs returns the Outputs in IssueAction
+func (i *Action) GetOutputs() []driver.Output {
+	res := make([]driver.Output, len(i.Outputs))
+	for i, output := range i.Outputs {
+		res[i] = output
+	}
+	return res
+}
+
+// GetSerializedOutputs returns the serialization of Outputs
+func (i *Action) GetSerializedOutputs() ([][]byte, error) {
+	res := make([][]byte, len(i.Outputs))
+	for i, output := range i.Outputs {
+		if output == nil {
+			return nil, errors.New("invalid issue: there is a nil output")
+		}
+		var err error
+		res[i], err = output.Serialize()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// GetIssuer returns the Issuer of IssueAction
+func (i *Action) GetIssuer() []byte {
+	return i.Issuer
+}
+
+// IsGraphHiding returns true
+func (i *Action) IsGraphHiding() bool {
+	return true
+}
+
+func (i *Action) Validate() error {
+	if i.Issuer.IsNone() {
+		return errors.Errorf("issuer is not set")
+	}
+	if len(i.Outputs) == 0 {
+		return errors.Errorf("no outputs in issue action")
+	}
+	for j, output := range i.Outputs {
+		if output == nil {
+			return errors.Errorf("nil output in issue action")
+		}
+		if output.IsRedeem() {
+			return errors.Errorf("invalid output at index [%d], issued tokens must have an owner", j)
+		}
+		if err := output.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid output at index [%d]", j)
+		}
+	}
+	return nil
+}
+
+func (i *Action) ExtraSigners() []driver.Identity {
+	return nil
+}
+
+// Serialize marshal IssueAction
+func (i *Action) Serialize() ([]byte, error) {
+	outputs, err := protos.ToProtosSlice[actions.Output, *token.Output](i.Outputs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize outputs")
+	}
+	issueAction := &actions.IssueAction{
+		Version: ProtocolV1,
+		Issuer: &pp.Identity{
+			Raw: i.Issuer,
+		},
+		Outputs: outputs,
+		Proof: &noghactions.Proof{
+			Proof: i.Proof,
+		},
+		Metadata: i.Metadata,
+	}
+	return proto.Marshal(issueAction)
+}
+
+// Deserialize un-marshals IssueAction
+func (i *Action) Deserialize(raw []byte) error {
+	issueAction := &actions.IssueAction{}
+	err := proto.Unmarshal(raw, issueAction)
+	if err != nil {
+		return errors.Wrap(err, "failed to deserialize issue action")
+	}
+	if issueAction.Version != ProtocolV1 {
+		return errors.Errorf("invalid issue version, expected [%d], got [%d]", ProtocolV1, issueAction.Version)
+	}
+	i.Outputs = slices.GenericSliceOfPointers[token.Output](len(issueAction.Outputs))
+	if err := protos.FromProtosSlice(issueAction.Outputs, i.Outputs); err != nil {
+		return errors.Wrap(err, "failed to deserialize outputs")
+	}
+	if issueAction.Proof != nil {
+		i.Proof = issueAction.Proof.Proof
+	}
+	if issueAction.Issuer != nil {
+		i.Issuer = issueAction.Issuer.Raw
+	}
+	i.Metadata = issueAction.Metadata
+	return nil
+}
+
+// GetCommitments return the Pedersen commitments to type and value of the Outputs
+func (i *Action) GetCommitments() ([]*math.G1, error) {
+	com := make([]*math.G1, len(i.Outputs))
+	for j := 0; j < len(com); j++ {
+		if i.Outputs[j] == nil {
+			return nil, errors.New("invalid issue: there is a nil output")
+		}
+		com[j] = i.Outputs[j].Commitment
+	}
+	return com, nil
+}
+
+// GetProof returns IssueAction ZKP
+func (i *Action) GetProof() []byte {
+	return i.Proof
+}
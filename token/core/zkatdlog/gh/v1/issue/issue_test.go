@@ -0,0 +1,148 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issue_test
+
+import (
+	"testing"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/issue"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	nogh "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/issue"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/stretchr/testify/assert"
+)
+
+type signer struct{}
+
+func (s *signer) Sign(message []byte) ([]byte, error) {
+	return append([]byte("signed:"), message...), nil
+}
+
+func (s *signer) Serialize() ([]byte, error) {
+	return []byte("issuer"), nil
+}
+
+func newSpendKeys(t *testing.T, pp *setup.PublicParams, n int) []*token.SpendKey {
+	curve := math.Curves[pp.Curve]
+	rand, err := curve.Rand()
+	assert.NoError(t, err)
+	keys := make([]*token.SpendKey, n)
+	for i := range keys {
+		keys[i], err = token.NewSpendKey(curve.NewRandomZr(rand), &signer{}, pp)
+		assert.NoError(t, err)
+	}
+	return keys
+}
+
+func TestIssue(t *testing.T) {
+	pp, err := setup.Setup(32, nil, math.BN254, 4)
+	assert.NoError(t, err)
+	issuer := &issue.Issuer{}
+	issuer.New("ABC", &signer{}, pp)
+
+	action, metas, err := issuer.GenerateZKIssue(
+		[]uint64{10, 20},
+		[][]byte{[]byte("alice"), []byte("bob")},
+		newSpendKeys(t, pp, 2),
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, action.Validate())
+	assert.Equal(t, driver.Identity("issuer"), driver.Identity(action.GetIssuer()))
+	assert.Equal(t, 0, action.NumInputs())
+	assert.Equal(t, 2, action.NumOutputs())
+	assert.True(t, action.IsGraphHiding())
+	assert.False(t, action.IsAnonymous())
+	for i, meta := range metas {
+		assert.Equal(t, []byte("issuer"), meta.Issuer)
+		assert.NoError(t, action.Outputs[i].Verify(pp))
+		_, err := action.Outputs[i].Token.ToClear(meta, pp)
+		assert.NoError(t, err)
+		// the owners are never disclosed
+		assert.Nil(t, action.GetOutputs()[i].GetOwner())
+	}
+
+	// the proof shows that the outputs have the same type and values in range
+	commitments, err := action.GetCommitments()
+	assert.NoError(t, err)
+	assert.NoError(t, nogh.NewVerifier(commitments, pp.PublicParams).Verify(action.GetProof()))
+
+	// go through the serialization, as the validators do
+	raw, err := action.Serialize()
+	assert.NoError(t, err)
+	deserialized := &issue.Action{}
+	assert.NoError(t, deserialized.Deserialize(raw))
+	assert.Equal(t, action, deserialized)
+	serializedOutputs, err := deserialized.GetSerializedOutputs()
+	assert.NoError(t, err)
+	for i, output := range action.Outputs {
+		expected, err := output.Serialize()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, serializedOutputs[i])
+	}
+
+	sigma, err := issuer.SignTokenActions([]byte("request"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("signed:request"), sigma)
+}
+
+func TestIssueFailures(t *testing.T) {
+	pp, err := setup.Setup(32, nil, math.BN254, 4)
+	assert.NoError(t, err)
+
+	issuer := &issue.Issuer{}
+	issuer.New("ABC", nil, pp)
+	_, _, err = issuer.GenerateZKIssue([]uint64{10}, [][]byte{[]byte("alice")}, newSpendKeys(t, pp, 1))
+	assert.EqualError(t, err, "failed to generate ZK Issue: please initialize signer")
+	_, err = issuer.SignTokenActions([]byte("request"))
+	assert.EqualError(t, err, "failed to sign Token Actions: please initialize signer")
+
+	issuer.New("ABC", &signer{}, nil)
+	_, _, err = issuer.GenerateZKIssue([]uint64{10}, [][]byte{[]byte("alice")}, newSpendKeys(t, pp, 1))
+	assert.EqualError(t, err, "failed to generate ZK Issue: nil public parameters")
+
+	issuer.New("ABC", &signer{}, pp)
+	_, _, err = issuer.GenerateZKIssue([]uint64{10}, [][]byte{nil}, newSpendKeys(t, pp, 1))
+	assert.EqualError(t, err, "failed to generate ZK Issue: empty owner at index [0]")
+
+	_, _, err = issuer.GenerateZKIssue([]uint64{10}, [][]byte{[]byte("alice")}, nil)
+	assert.EqualError(t, err, "number of spend keys [0] does not match number of owners [1]")
+}
+
+func TestActionValidate(t *testing.T) {
+	pp, err := setup.Setup(32, nil, math.BN254, 4)
+	assert.NoError(t, err)
+	outputs, _, _, err := token.NewOutputs(
+		[]uint64{10, 20},
+		[][]byte{[]byte("alice"), nil},
+		append(newSpendKeys(t, pp, 1), nil),
+		"ABC",
+		pp,
+	)
+	assert.NoError(t, err)
+
+	action := &issue.Action{Outputs: outputs[:1]}
+	assert.EqualError(t, action.Validate(), "issuer is not set")
+
+	action = &issue.Action{Issuer: []byte("issuer")}
+	assert.EqualError(t, action.Validate(), "no outputs in issue action")
+
+	action = &issue.Action{Issuer: []byte("issuer"), Outputs: []*token.Output{nil}}
+	assert.EqualError(t, action.Validate(), "nil output in issue action")
+	_, err = action.GetSerializedOutputs()
+	assert.EqualError(t, err, "invalid issue: there is a nil output")
+	_, err = action.GetCommitments()
+	assert.EqualError(t, err, "invalid issue: there is a nil output")
+
+	// issued tokens cannot be redeemed
+	action = &issue.Action{Issuer: []byte("issuer"), Outputs: outputs}
+	assert.EqualError(t, action.Validate(), "invalid output at index [1], issued tokens must have an owner")
+
+	action = &issue.Action{Issuer: []byte("issuer"), Outputs: []*token.Output{{Token: outputs[0].Token, Commitment: outputs[0].Commitment}}}
+	assert.EqualError(t, action.Validate(), "invalid output at index [0]: output proof cannot be empty")
+}
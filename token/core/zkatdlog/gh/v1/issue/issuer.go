@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issue
+
+import (
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/common"
+	nogh "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/issue"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// Issuer is the entity that issues tokens with graph hiding
+type Issuer struct {
+	Signer       common.SigningIdentity
+	PublicParams *setup.PublicParams
+	Type         token2.Type
+}
+
+// New returns an Issuer as a function of the passed parameters
+func (i *Issuer) New(ttype token2.Type, signer common.SigningIdentity, pp *setup.PublicParams) {
+	i.Signer = signer
+	i.Type = ttype
+	i.PublicParams = pp
+}
+
+// GenerateZKIssue returns an issue action that assigns the passed values to the passed owners.
+// spendKeys contains the spend key of each owner.
+func (i *Issuer) GenerateZKIssue(values []uint64, owners [][]byte, spendKeys []*token.SpendKey) (*Action, []*token.Metadata, error) {
+	if i.PublicParams == nil {
+		return nil, nil, errors.New("failed to generate ZK Issue: nil public parameters")
+	}
+	if len(math.Curves) < int(i.PublicParams.Curve)+1 {
+		return nil, nil, errors.New("failed to generate ZK Issue: please initialize public parameters with an admissible curve")
+	}
+	for j, owner := range owners {
+		if len(owner) == 0 {
+			return nil, nil, errors.Errorf("failed to generate ZK Issue: empty owner at index [%d]", j)
+		}
+	}
+	if i.Signer == nil {
+		return nil, nil, errors.New("failed to generate ZK Issue: please initialize signer")
+	}
+	signerRaw, err := i.Signer.Serialize()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outputs, metas, tw, err := token.NewOutputs(values, owners, spendKeys, i.Type, i.PublicParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	action := &Action{Issuer: signerRaw, Outputs: outputs}
+	commitments, err := action.GetCommitments()
+	if err != nil {
+		return nil, nil, err
+	}
+	prover, err := nogh.NewProver(tw, commitments, i.PublicParams.PublicParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	action.Proof, err = prover.Prove()
+	if err != nil {
+		return nil, nil, errors.Errorf("failed to generate zero knowledge proof for issue")
+	}
+	for _, meta := range metas {
+		meta.Issuer = signerRaw
+	}
+	return action, metas, nil
+}
+
+func (i *Issuer) SignTokenActions(raw []byte) ([]byte, error) {
+	if i.Signer == nil {
+		return nil, errors.New("failed to sign Token Actions: please initialize signer")
+	}
+	return i.Signer.Sign(raw)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/validator"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/wallet"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/pkg/errors"
+)
+
+type Service struct {
+	*common.Service[*setup.PublicParams]
+	validator *validator.Validator
+}
+
+func NewTokenService(
+	logger logging.Logger,
+	ws *wallet.Service,
+	ppm common.PublicParametersManager[*setup.PublicParams],
+	identityProvider driver.IdentityProvider,
+	deserializer driver.Deserializer,
+	configuration driver.Configuration,
+	issueService driver.IssueService,
+	transferService driver.TransferService,
+	auditorService driver.AuditorService,
+	tokensService driver.TokensService,
+	tokensUpgradeService driver.TokensUpgradeService,
+	authorization driver.Authorization,
+) (*Service, error) {
+	root, err := common.NewTokenService[*setup.PublicParams](
+		logger,
+		ws,
+		ppm,
+		identityProvider,
+		deserializer,
+		configuration,
+		nil,
+		issueService,
+		transferService,
+		auditorService,
+		tokensService,
+		tokensUpgradeService,
+		authorization,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	validator := validator.New(logger, ppm.PublicParams(), deserializer)
+	validator.Workers, err = common.ValidatorWorkers(configuration)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to instantiate validator")
+	}
+	s := &Service{
+		Service:   root,
+		validator: validator,
+	}
+	return s, nil
+}
+
+func (s *Service) Validator() (driver.Validator, error) {
+	return s.validator, nil
+}
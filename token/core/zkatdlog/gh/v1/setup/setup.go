@@ -0,0 +1,198 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package setup
+
+import (
+	"crypto/sha256"
+
+	mathlib "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/proto"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/json"
+	pp3 "github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/pp"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/protos-go/pp"
+	noghsetup "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/setup"
+	pp2 "github.com/hyperledger-labs/fabric-token-sdk/token/driver/protos-go/pp"
+	"github.com/pkg/errors"
+)
+
+const (
+	GHPublicParameters = "zkatdlog-gh"
+	// DefaultAnonymitySetSize is the anonymity set size used when none is specified
+	DefaultAnonymitySetSize = 16
+	// MaxAnonymitySetSize bounds the size of the membership proofs the validators must verify
+	MaxAnonymitySetSize = 1024
+)
+
+// PublicParams are the public parameters of zkatdlog with graph hiding.
+// They extend the zkatdlog public parameters with the size of the anonymity sets and
+// with two generators, derived by hashing to the curve: the first commits to the serial number of a token, the second is the base of the spend keys of the owners.
+type PublicParams struct {
+	*noghsetup.PublicParams
+	// AnonymitySetSize is the maximum number of ledger tokens among which a spent token is hidden.
+	// It must be a power of two.
+	AnonymitySetSize uint64
+
+	// tokenGenerators are the Pedersen generators followed by the serial number and spend key generators
+	tokenGenerators []*mathlib.G1
+}
+
+func NewPublicParamsFromBytes(raw []byte, label string) (*PublicParams, error) {
+	pp := &PublicParams{
+		PublicParams: &noghsetup.PublicParams{Label: label},
+	}
+	if err := pp.Deserialize(raw); err != nil {
+		return nil, errors.Wrap(err, "failed parsing public parameters")
+	}
+	return pp, nil
+}
+
+// Setup generates public parameters with graph hiding whose anonymity sets contain up to anonymitySetSize tokens
+func Setup(bitLength uint64, idemixIssuerPK []byte, idemixCurveID mathlib.CurveID, anonymitySetSize uint64) (*PublicParams, error) {
+	dlog, err := noghsetup.Setup(bitLength, idemixIssuerPK, idemixCurveID)
+	if err != nil {
+		return nil, err
+	}
+	dlog.Label = GHPublicParameters
+	pp := &PublicParams{
+		PublicParams:     dlog,
+		AnonymitySetSize: anonymitySetSize,
+	}
+	pp.deriveTokenGenerators()
+	return pp, nil
+}
+
+func (p *PublicParams) CertificationDriver() string {
+	// tokens are never disclosed, there is nothing to certify
+	return ""
+}
+
+func (p *PublicParams) GraphHiding() bool {
+	return true
+}
+
+func (p *PublicParams) Bytes() ([]byte, error) {
+	return p.Serialize()
+}
+
+// TokenGenerators returns the generators of the commitment stored on the ledger.
+// The first three are the Pedersen generators of the zkatdlog public parameters, committing to type, value and blinding factor,
+// the fourth commits to the serial number, and the fifth is the base of the spend keys.
+func (p *PublicParams) TokenGenerators() []*mathlib.G1 {
+	if len(p.tokenGenerators) == 0 {
+		p.deriveTokenGenerators()
+	}
+	return p.tokenGenerators
+}
+
+func (p *PublicParams) Serialize() ([]byte, error) {
+	dlog, err := p.PublicParams.Serialize()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize zkatdlog public parameters")
+	}
+	raw, err := proto.Marshal(&pp.PublicParameters{
+		Identifier:       p.Label,
+		Version:          p.Ver,
+		Dlog:             dlog,
+		AnonymitySetSize: p.AnonymitySetSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pp3.Marshal(&pp2.PublicParameters{
+		Identifier: p.Label,
+		Raw:        raw,
+	})
+}
+
+func (p *PublicParams) Deserialize(raw []byte) error {
+	container, err := pp3.Unmarshal(raw)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize public parameters")
+	}
+	if p.PublicParams == nil {
+		p.PublicParams = &noghsetup.PublicParams{Label: GHPublicParameters}
+	}
+	if container.Identifier != p.Label {
+		return errors.Errorf("invalid identifier, expecting [%s], got [%s]", p.Label, container.Identifier)
+	}
+	publicParams := &pp.PublicParameters{}
+	if err := proto.Unmarshal(container.Raw, publicParams); err != nil {
+		return errors.Wrapf(err, "failed unmarshalling public parameters")
+	}
+	if err := p.PublicParams.Deserialize(publicParams.Dlog); err != nil {
+		return errors.Wrapf(err, "failed to deserialize zkatdlog public parameters")
+	}
+	if publicParams.Identifier != p.Label || publicParams.Version != p.Ver {
+		return errors.Errorf("invalid public parameters, label and version [%s:%d] do not match those of the zkatdlog public parameters [%s:%d]", publicParams.Identifier, publicParams.Version, p.Label, p.Ver)
+	}
+	p.AnonymitySetSize = publicParams.AnonymitySetSize
+	p.deriveTokenGenerators()
+	return nil
+}
+
+func (p *PublicParams) ComputeHash() ([]byte, error) {
+	raw, err := p.Bytes()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to serialize public params")
+	}
+	hash := sha256.Sum256(raw)
+	return hash[:], nil
+}
+
+func (p *PublicParams) String() string {
+	res, err := json.MarshalIndent(p, " ", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(res)
+}
+
+func (p *PublicParams) Validate() error {
+	if p.PublicParams == nil {
+		return errors.New("invalid public parameters: nil zkatdlog public parameters")
+	}
+	if p.Label != GHPublicParameters {
+		return errors.Errorf("invalid public parameters: invalid label [%s], expected [%s]", p.Label, GHPublicParameters)
+	}
+	if err := p.PublicParams.Validate(); err != nil {
+		return err
+	}
+	if len(p.IssuerPolicies) != 0 {
+		// enforcing the policies would require the issuers to disclose the type of the issued tokens
+		return errors.New("invalid public parameters: issuer policies are not supported with graph hiding")
+	}
+	if len(p.Fees) != 0 {
+		// enforcing the fees would require the owners of the outputs to be in the clear
+		return errors.New("invalid public parameters: fee policies are not supported with graph hiding")
+	}
+	if len(p.EnforcerIDs) != 0 {
+		// freezing and forcing require the spent tokens and their owners to be in the clear
+		return errors.New("invalid public parameters: enforcers are not supported with graph hiding")
+	}
+	if p.AnonymitySetSize < 2 || p.AnonymitySetSize > MaxAnonymitySetSize {
+		return errors.Errorf("invalid public parameters: anonymity set size [%d] must be between 2 and %d", p.AnonymitySetSize, MaxAnonymitySetSize)
+	}
+	if p.AnonymitySetSize&(p.AnonymitySetSize-1) != 0 {
+		return errors.Errorf("invalid public parameters: anonymity set size [%d] must be a power of two", p.AnonymitySetSize)
+	}
+	return nil
+}
+
+func (p *PublicParams) deriveTokenGenerators() {
+	if int(p.Curve) > len(mathlib.Curves)-1 {
+		// Validate reports the invalid curve
+		return
+	}
+	curve := mathlib.Curves[p.Curve]
+	generators := make([]*mathlib.G1, 0, len(p.PedersenGenerators)+2)
+	generators = append(generators, p.PedersenGenerators...)
+	generators = append(generators,
+		curve.HashToG1([]byte(GHPublicParameters+".SerialNumber")),
+		curve.HashToG1([]byte(GHPublicParameters+".SpendKey")),
+	)
+	p.tokenGenerators = generators
+}
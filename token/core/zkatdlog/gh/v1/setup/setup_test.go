@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+package setup
+
+import (
+	"os"
+	"testing"
+
+	math3 "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerialization(t *testing.T) {
+	issuerPK, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := Setup(32, issuerPK, math3.BN254, DefaultAnonymitySetSize)
+	assert.NoError(t, err)
+	assert.True(t, pp.GraphHiding())
+	assert.Empty(t, pp.CertificationDriver())
+
+	ser, err := pp.Serialize()
+	assert.NoError(t, err)
+
+	pp2, err := NewPublicParamsFromBytes(ser, GHPublicParameters)
+	assert.NoError(t, err)
+
+	ser2, err := pp2.Serialize()
+	assert.NoError(t, err)
+
+	assert.Equal(t, pp.AnonymitySetSize, pp2.AnonymitySetSize)
+	assert.Equal(t, pp.TokenGenerators(), pp2.TokenGenerators())
+	assert.Equal(t, pp, pp2)
+	assert.Equal(t, ser, ser2)
+
+	// the zkatdlog driver does not accept these public parameters
+	_, err = NewPublicParamsFromBytes(ser, "zkatdlog")
+	assert.Error(t, err)
+
+	assert.Error(t, pp.Validate())
+
+	pp.IssuerIDs = []driver.Identity{[]byte("issuer")}
+	assert.NoError(t, pp.Validate())
+}
+
+func TestValidate(t *testing.T) {
+	issuerPK, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := Setup(32, issuerPK, math3.BN254, 12)
+	assert.NoError(t, err)
+	pp.IssuerIDs = []driver.Identity{[]byte("issuer")}
+	assert.EqualError(t, pp.Validate(), "invalid public parameters: anonymity set size [12] must be a power of two")
+
+	pp.AnonymitySetSize = 1
+	assert.EqualError(t, pp.Validate(), "invalid public parameters: anonymity set size [1] must be between 2 and 1024")
+
+	pp.AnonymitySetSize = 2 * MaxAnonymitySetSize
+	assert.EqualError(t, pp.Validate(), "invalid public parameters: anonymity set size [2048] must be between 2 and 1024")
+
+	pp.AnonymitySetSize = 8
+	assert.NoError(t, pp.Validate())
+
+	pp.AddIssuerPolicy("ABC", []driver.Identity{[]byte("issuer")})
+	assert.EqualError(t, pp.Validate(), "invalid public parameters: issuer policies are not supported with graph hiding")
+}
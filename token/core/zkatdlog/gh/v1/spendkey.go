@@ -0,0 +1,185 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"sync"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/pkg/errors"
+)
+
+const spendSeedSize = 32
+
+// Keystore stores the seed from which the spend secrets are derived
+type Keystore interface {
+	Put(id string, state interface{}) error
+	Get(id string, state interface{}) error
+}
+
+// TokenInfoStorage returns the token metadata registered together with a recipient identity
+type TokenInfoStorage interface {
+	GetTokenInfo(id []byte) ([]byte, []byte, error)
+}
+
+// OwnerIdentityProvider gives access to the signers of the local owners
+type OwnerIdentityProvider interface {
+	IsMe(identity driver.Identity) bool
+	GetSigner(identity driver.Identity) (driver.Signer, error)
+}
+
+// OwnerVerifierDeserializer returns the verifiers of the owners
+type OwnerVerifierDeserializer interface {
+	GetOwnerVerifier(id driver.Identity) (driver.Verifier, error)
+}
+
+type spendSeed struct {
+	Seed []byte
+}
+
+// SpendKeyManager handles the spend keys of the owners.
+// The spend secret of a local owner is derived from a seed, kept in the keystore, and the owner identity.
+// The spend key of a remote owner is the one the owner shared, as token metadata, with its recipient identity.
+type SpendKeyManager struct {
+	PublicParametersManager common.PublicParametersManager[*setup.PublicParams]
+	Keystore                Keystore
+	SeedID                  string
+	IdentityProvider        OwnerIdentityProvider
+	TokenInfoStorage        TokenInfoStorage
+	Deserializer            OwnerVerifierDeserializer
+
+	seedLock sync.Mutex
+	seed     []byte
+	keysLock sync.RWMutex
+	keys     map[string]*token.SpendKey
+}
+
+func NewSpendKeyManager(
+	publicParametersManager common.PublicParametersManager[*setup.PublicParams],
+	keystore Keystore,
+	seedID string,
+	identityProvider OwnerIdentityProvider,
+	tokenInfoStorage TokenInfoStorage,
+	deserializer OwnerVerifierDeserializer,
+) *SpendKeyManager {
+	return &SpendKeyManager{
+		PublicParametersManager: publicParametersManager,
+		Keystore:                keystore,
+		SeedID:                  seedID,
+		IdentityProvider:        identityProvider,
+		TokenInfoStorage:        tokenInfoStorage,
+		Deserializer:            deserializer,
+		keys:                    map[string]*token.SpendKey{},
+	}
+}
+
+// SpendSecret returns the spend secret of the passed local owner
+func (m *SpendKeyManager) SpendSecret(owner driver.Identity) (*math.Zr, error) {
+	if owner.IsNone() {
+		return nil, errors.New("cannot derive the spend secret of an empty owner")
+	}
+	seed, err := m.getSeed()
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, seed)
+	mac.Write(owner)
+	return math.Curves[m.PublicParametersManager.PublicParams().Curve].HashToZr(mac.Sum(nil)), nil
+}
+
+// SpendKey returns the spend key of the passed owner.
+// If the owner is local, the spend key is derived from its spend secret and signed by the owner.
+// Otherwise, the spend key must have been registered together with the owner identity, and it is checked against the owner.
+func (m *SpendKeyManager) SpendKey(owner driver.Identity) (*token.SpendKey, error) {
+	m.keysLock.RLock()
+	key, ok := m.keys[owner.UniqueID()]
+	m.keysLock.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	var err error
+	if m.IdentityProvider.IsMe(owner) {
+		key, err = m.localSpendKey(owner)
+	} else {
+		key, err = m.remoteSpendKey(owner)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m.keysLock.Lock()
+	m.keys[owner.UniqueID()] = key
+	m.keysLock.Unlock()
+	return key, nil
+}
+
+func (m *SpendKeyManager) localSpendKey(owner driver.Identity) (*token.SpendKey, error) {
+	secret, err := m.SpendSecret(owner)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := m.IdentityProvider.GetSigner(owner)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting signer for owner [%s]", owner)
+	}
+	return token.NewSpendKey(secret, signer, m.PublicParametersManager.PublicParams())
+}
+
+func (m *SpendKeyManager) remoteSpendKey(owner driver.Identity) (*token.SpendKey, error) {
+	raw, _, err := m.TokenInfoStorage.GetTokenInfo(owner)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting spend key of owner [%s]", owner)
+	}
+	if len(raw) == 0 {
+		return nil, errors.Errorf("no spend key registered for owner [%s]", owner)
+	}
+	key := &token.SpendKey{}
+	if err := key.Deserialize(raw); err != nil {
+		return nil, errors.Wrapf(err, "failed deserializing spend key of owner [%s]", owner)
+	}
+	verifier, err := m.Deserializer.GetOwnerVerifier(owner)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting verifier for owner [%s]", owner)
+	}
+	if err := key.Verify(verifier); err != nil {
+		return nil, errors.Wrapf(err, "invalid spend key for owner [%s]", owner)
+	}
+	return key, nil
+}
+
+// getSeed loads the seed from the keystore, it generates and stores a new one the first time
+func (m *SpendKeyManager) getSeed() ([]byte, error) {
+	m.seedLock.Lock()
+	defer m.seedLock.Unlock()
+	if m.seed != nil {
+		return m.seed, nil
+	}
+	state := &spendSeed{}
+	if err := m.Keystore.Get(m.SeedID, state); err != nil || len(state.Seed) == 0 {
+		// never overwrite an existing seed, the tokens of the local owners would become unspendable
+		if ks, ok := m.Keystore.(interface{ Exists(id string) bool }); ok && ks.Exists(m.SeedID) {
+			return nil, errors.Wrap(err, "failed to load spend seed")
+		}
+		state.Seed = make([]byte, spendSeedSize)
+		if _, err := rand.Read(state.Seed); err != nil {
+			return nil, errors.Wrap(err, "failed to generate spend seed")
+		}
+		if err := m.Keystore.Put(m.SeedID, state); err != nil {
+			return nil, errors.Wrap(err, "failed to store spend seed")
+		}
+	}
+	m.seed = state.Seed
+	return m.seed, nil
+}
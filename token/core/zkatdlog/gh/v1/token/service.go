@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	errors2 "errors"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/math"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokens/core/ghcomm"
+	utils2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/utils"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+type TokensService struct {
+	Logger                  logging.Logger
+	PublicParametersManager common.PublicParametersManager[*setup.PublicParams]
+	IdentityDeserializer    driver.Deserializer
+
+	OutputTokenFormat token.Format
+}
+
+func NewTokensService(logger logging.Logger, publicParametersManager common.PublicParametersManager[*setup.PublicParams], identityDeserializer driver.Deserializer) (*TokensService, error) {
+	outputTokenFormat, err := SupportedTokenFormat(publicParametersManager.PublicParams())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed computing token format")
+	}
+	return &TokensService{
+		Logger:                  logger,
+		PublicParametersManager: publicParametersManager,
+		IdentityDeserializer:    identityDeserializer,
+		OutputTokenFormat:       outputTokenFormat,
+	}, nil
+}
+
+// Recipients returns an error, the owner of a token can be derived only from its metadata
+func (s *TokensService) Recipients(driver.TokenOutput) ([]driver.Identity, error) {
+	return nil, errors.New("the owner of a token with graph hiding is not disclosed")
+}
+
+// Deobfuscate unmarshals a token and token metadata from raw bytes.
+// It checks if the un-marshalled token matches the token info. If not, it returns
+// an error. Else it returns the token in cleartext and the identity of its issuer
+func (s *TokensService) Deobfuscate(output driver.TokenOutput, outputMetadata driver.TokenOutputMetadata) (*token.Token, driver.Identity, []driver.Identity, token.Format, error) {
+	_, metadata, tok, err := s.deserializeToken(output, outputMetadata)
+	if err != nil {
+		return nil, nil, nil, "", errors.Wrapf(err, "failed to deobfuscate token")
+	}
+	var recipients []driver.Identity
+	if len(tok.Owner) != 0 {
+		// a token bound to a spend key that is not the owner's cannot be spent by the owner
+		verifier, err := s.IdentityDeserializer.GetOwnerVerifier(tok.Owner)
+		if err != nil {
+			return nil, nil, nil, "", errors.Wrapf(err, "failed to get the verifier of the owner")
+		}
+		if err := metadata.GetSpendKey().Verify(verifier); err != nil {
+			return nil, nil, nil, "", errors.Wrapf(err, "failed to deobfuscate token")
+		}
+		recipients, err = s.IdentityDeserializer.Recipients(tok.Owner)
+		if err != nil {
+			return nil, nil, nil, "", errors.Wrapf(err, "failed to get recipients")
+		}
+	}
+	return tok, metadata.Issuer, recipients, s.OutputTokenFormat, nil
+}
+
+// SupportedTokenFormats returns the format of the tokens with graph hiding, tokens of other formats cannot be upgraded
+func (s *TokensService) SupportedTokenFormats() []token.Format {
+	return []token.Format{s.OutputTokenFormat}
+}
+
+// DeserializeToken returns the token and its metadata, if they match
+func (s *TokensService) DeserializeToken(outputFormat token.Format, outputRaw []byte, metadataRaw []byte) (*Token, *Metadata, error) {
+	if outputFormat != s.OutputTokenFormat {
+		return nil, nil, errors.Errorf("invalid token type [%s], expected [%s]", outputFormat, s.OutputTokenFormat)
+	}
+	output, metadata, _, err := s.deserializeToken(outputRaw, metadataRaw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return output, metadata, nil
+}
+
+func (s *TokensService) deserializeToken(outputRaw []byte, metadataRaw []byte) (*Token, *Metadata, *token.Token, error) {
+	pp := s.PublicParametersManager.PublicParams()
+	output := &Token{}
+	if err := output.Deserialize(outputRaw); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to deserialize token")
+	}
+	if err := math.CheckElement(output.Data, pp.Curve); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "data in invalid in output")
+	}
+	metadata := &Metadata{}
+	if err := metadata.Deserialize(metadataRaw); err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "failed to deserialize token metadata [%d][%v]", len(metadataRaw), metadataRaw)
+	}
+	tok, err := output.ToClear(metadata, pp)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to deserialize token")
+	}
+	return output, metadata, tok, nil
+}
+
+// SupportedTokenFormat returns the format of the tokens with graph hiding for the passed public parameters
+func SupportedTokenFormat(pp *setup.PublicParams) (token.Format, error) {
+	hasher := utils2.NewSHA256Hasher()
+	if err := errors2.Join(
+		hasher.AddInt32(ghcomm.Type),
+		hasher.AddInt(int(pp.Curve)),
+		hasher.AddUInt64(pp.RangeProofParams.BitLength),
+		hasher.AddG1s(pp.TokenGenerators()),
+	); err != nil {
+		return "", errors.Wrapf(err, "failed to generator token type")
+	}
+	return token.Format(hasher.HexDigest()), nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/proto"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/protos-go/actions"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/utils"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/pkg/errors"
+)
+
+// spendKeyLabel separates the signatures on spend keys from any other signature of the owners
+const spendKeyLabel = "zkatdlog-gh.SpendKey"
+
+// SpendKey is the public key whose secret authorizes the spending of the tokens of an owner.
+// The key is g^k, where g is the spend key generator of the public parameters and k is a secret known only to the owner.
+// The owner signs the key, so that the creator of a token and the auditors can check that the key belongs to the owner.
+type SpendKey struct {
+	// Key is the spend key
+	Key *math.G1
+	// Signature is the signature of the owner on the key
+	Signature []byte
+}
+
+// NewSpendKey returns the spend key for the passed secret, signed with the passed signer of the owner
+func NewSpendKey(secret *math.Zr, signer driver.Signer, pp *setup.PublicParams) (*SpendKey, error) {
+	key := pp.TokenGenerators()[4].Mul(secret)
+	signature, err := signer.Sign(SpendKeyMessage(key))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign spend key")
+	}
+	return &SpendKey{Key: key, Signature: signature}, nil
+}
+
+// SpendKeyMessage returns the message the owner signs to vouch for the passed spend key
+func SpendKeyMessage(key *math.G1) []byte {
+	return append([]byte(spendKeyLabel), key.Bytes()...)
+}
+
+// Verify checks the signature of the owner on the key
+func (k *SpendKey) Verify(verifier driver.Verifier) error {
+	if k.Key == nil || len(k.Signature) == 0 {
+		return errors.New("invalid spend key: nil elements")
+	}
+	if err := verifier.Verify(SpendKeyMessage(k.Key), k.Signature); err != nil {
+		return errors.Wrap(err, "invalid signature on spend key")
+	}
+	return nil
+}
+
+func (k *SpendKey) ToProtos() (*actions.SpendKey, error) {
+	key, err := utils.ToProtoG1(k.Key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize spend key")
+	}
+	return &actions.SpendKey{Key: key, Signature: k.Signature}, nil
+}
+
+func (k *SpendKey) FromProtos(key *actions.SpendKey) error {
+	var err error
+	k.Key, err = utils.FromG1Proto(key.Key)
+	if err != nil {
+		return errors.Wrap(err, "failed to deserialize spend key")
+	}
+	k.Signature = key.Signature
+	return nil
+}
+
+// Serialize marshals SpendKey
+func (k *SpendKey) Serialize() ([]byte, error) {
+	key, err := k.ToProtos()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(key)
+}
+
+// Deserialize un-marshals SpendKey
+func (k *SpendKey) Deserialize(raw []byte) error {
+	key := &actions.SpendKey{}
+	if err := proto.Unmarshal(raw, key); err != nil {
+		return errors.Wrap(err, "failed unmarshalling spend key")
+	}
+	return k.FromProtos(key)
+}
@@ -0,0 +1,403 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/proto"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/protos-go/actions"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/crypto/link"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	math2 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/math"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/pp"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/utils"
+	noghtoken "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokens/core/ghcomm"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// Token is a Pedersen commitment to type, value and serial number of a token, together with
+// a commitment to the spend key of its owner.
+// Only the holders of the token metadata know who owns the token.
+// Redeemed outputs are stored as a commitment to type and value only, with no spend key, and cannot be spent.
+type Token ghcomm.Token
+
+// Serialize marshals Token
+func (t *Token) Serialize() ([]byte, error) {
+	data, err := utils.ToProtoG1(t.Data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize token")
+	}
+	var spendKey *math2.G1
+	if t.SpendKey != nil {
+		spendKey, err = utils.ToProtoG1(t.SpendKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize token spend key")
+		}
+	}
+	raw, err := proto.Marshal(&actions.Token{Data: data, SpendKey: spendKey})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed serializing token")
+	}
+	return ghcomm.WrapTokenWithType(raw)
+}
+
+// Deserialize unmarshals Token
+func (t *Token) Deserialize(bytes []byte) error {
+	typed, err := ghcomm.UnmarshalTypedToken(bytes)
+	if err != nil {
+		return errors.Wrapf(err, "failed deserializing token")
+	}
+	tok := &actions.Token{}
+	if err := proto.Unmarshal(typed.Token, tok); err != nil {
+		return errors.Wrapf(err, "failed unmarshalling token")
+	}
+	t.Data, err = utils.FromG1Proto(tok.Data)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize token data")
+	}
+	t.SpendKey = nil
+	if tok.SpendKey != nil {
+		t.SpendKey, err = utils.FromG1Proto(tok.SpendKey)
+		if err != nil {
+			return errors.Wrapf(err, "failed to deserialize token spend key")
+		}
+	}
+	return nil
+}
+
+// ToClear returns Token in the clear, if the passed metadata opens it
+func (t *Token) ToClear(meta *Metadata, pp *setup.PublicParams) (*token.Token, error) {
+	com, err := meta.Commit(pp)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot retrieve token in the clear: failed to check token data")
+	}
+	if !com.Equals(t.Data) {
+		return nil, errors.New("cannot retrieve token in the clear: output does not match provided opening")
+	}
+	if len(meta.Owner) != 0 {
+		spendKey, err := meta.CommitSpendKey(pp)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot retrieve token in the clear: failed to check token spend key")
+		}
+		if t.SpendKey == nil || !spendKey.Equals(t.SpendKey) {
+			return nil, errors.New("cannot retrieve token in the clear: spend key does not match provided opening")
+		}
+	}
+	return &token.Token{
+		Type:     meta.Type,
+		Quantity: "0x" + meta.Value.String(),
+		Owner:    meta.Owner,
+	}, nil
+}
+
+func (t *Token) Validate() error {
+	if t.Data == nil {
+		return errors.Errorf("token data cannot be empty")
+	}
+	if t.SpendKey == nil {
+		return errors.Errorf("token spend key cannot be empty")
+	}
+	return nil
+}
+
+// Metadata contains the opening of a token
+type Metadata ghcomm.Metadata
+
+// Commit returns the token these metadata open
+func (m *Metadata) Commit(pp *setup.PublicParams) (*math.G1, error) {
+	if m.Value == nil || m.BlindingFactor == nil || m.SerialNumber == nil {
+		return nil, errors.New("cannot commit a nil element")
+	}
+	curve := math.Curves[pp.Curve]
+	generators := pp.TokenGenerators()
+	com := generators[0].Mul(curve.HashToZr([]byte(m.Type)))
+	com.Add(generators[1].Mul(m.Value))
+	com.Add(generators[2].Mul(m.BlindingFactor))
+	com.Add(generators[3].Mul(m.SerialNumber))
+	return com, nil
+}
+
+// CommitSpendKey returns the commitment to the spend key of the owner these metadata open
+func (m *Metadata) CommitSpendKey(pp *setup.PublicParams) (*math.G1, error) {
+	if m.SpendKey == nil || m.SpendKeyBlindingFactor == nil {
+		return nil, errors.New("cannot commit a nil spend key")
+	}
+	com := m.SpendKey.Copy()
+	com.Add(pp.TokenGenerators()[2].Mul(m.SpendKeyBlindingFactor))
+	return com, nil
+}
+
+// GetSpendKey returns the spend key of the owner, with its signature
+func (m *Metadata) GetSpendKey() *SpendKey {
+	return &SpendKey{Key: m.SpendKey, Signature: m.SpendKeySignature}
+}
+
+// Deserialize un-marshals Metadata
+func (m *Metadata) Deserialize(b []byte) error {
+	typed, err := ghcomm.UnmarshalTypedMetadata(b)
+	if err != nil {
+		return errors.Wrapf(err, "failed deserializing metadata")
+	}
+	metadata := &actions.TokenMetadata{}
+	if err := proto.Unmarshal(typed.Metadata, metadata); err != nil {
+		return errors.Wrapf(err, "failed unmarshalling metadata")
+	}
+	m.Type = token.Type(metadata.Type)
+	m.Value, err = utils.FromZrProto(metadata.Value)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize metadata")
+	}
+	m.BlindingFactor, err = utils.FromZrProto(metadata.BlindingFactor)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize metadata")
+	}
+	m.SerialNumber, err = utils.FromZrProto(metadata.SerialNumber)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize metadata")
+	}
+	m.Owner = metadata.Owner
+	if metadata.Issuer != nil {
+		m.Issuer = metadata.Issuer.Raw
+	}
+	m.SpendKey, m.SpendKeySignature, m.SpendKeyBlindingFactor = nil, nil, nil
+	if metadata.SpendKey != nil {
+		spendKey := &SpendKey{}
+		if err := spendKey.FromProtos(metadata.SpendKey); err != nil {
+			return errors.Wrapf(err, "failed to deserialize metadata")
+		}
+		m.SpendKey, m.SpendKeySignature = spendKey.Key, spendKey.Signature
+		m.SpendKeyBlindingFactor, err = utils.FromZrProto(metadata.SpendKeyBlindingFactor)
+		if err != nil {
+			return errors.Wrapf(err, "failed to deserialize metadata")
+		}
+	}
+	return nil
+}
+
+// Serialize marshals Metadata
+func (m *Metadata) Serialize() ([]byte, error) {
+	value, err := utils.ToProtoZr(m.Value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize metadata")
+	}
+	blindingFactor, err := utils.ToProtoZr(m.BlindingFactor)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize metadata")
+	}
+	serialNumber, err := utils.ToProtoZr(m.SerialNumber)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize metadata")
+	}
+	var spendKey *actions.SpendKey
+	var spendKeyBlindingFactor *math2.Zr
+	if m.SpendKey != nil {
+		spendKey, err = m.GetSpendKey().ToProtos()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize metadata")
+		}
+		spendKeyBlindingFactor, err = utils.ToProtoZr(m.SpendKeyBlindingFactor)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize metadata")
+		}
+	}
+	raw, err := proto.Marshal(&actions.TokenMetadata{
+		Type:                   string(m.Type),
+		Value:                  value,
+		BlindingFactor:         blindingFactor,
+		SerialNumber:           serialNumber,
+		Owner:                  m.Owner,
+		Issuer:                 &pp.Identity{Raw: m.Issuer},
+		SpendKey:               spendKey,
+		SpendKeyBlindingFactor: spendKeyBlindingFactor,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed serializing metadata")
+	}
+	return ghcomm.WrapMetadataWithType(raw)
+}
+
+// Output is an output of an issue or transfer action.
+// It carries the token to be stored on the ledger and a commitment to type and value only, on which the validity proofs of the action are computed.
+// Redeemed outputs have no token.
+type Output struct {
+	// Token is the new token, nil if the output is redeemed
+	Token *Token
+	// Commitment is the Pedersen commitment to type and value of the token
+	Commitment *math.G1
+	// Proof shows that Token and Commitment hide the same type and value
+	Proof []byte
+}
+
+// Serialize returns the output as stored on the ledger.
+// A redeemed output is serialized as the token with no serial number and no spend key, that is, its commitment to type and value.
+func (o *Output) Serialize() ([]byte, error) {
+	if o.IsRedeem() {
+		return (&Token{Data: o.Commitment}).Serialize()
+	}
+	return o.Token.Serialize()
+}
+
+// IsRedeem returns true if the output has no token
+func (o *Output) IsRedeem() bool {
+	return o.Token == nil
+}
+
+// GetOwner returns nil, the owner of a token is never disclosed
+func (o *Output) GetOwner() []byte {
+	return nil
+}
+
+func (o *Output) Validate() error {
+	if o.Commitment == nil {
+		return errors.New("output commitment cannot be empty")
+	}
+	if o.IsRedeem() {
+		return nil
+	}
+	if err := o.Token.Validate(); err != nil {
+		return err
+	}
+	if len(o.Proof) == 0 {
+		return errors.New("output proof cannot be empty")
+	}
+	return nil
+}
+
+// Verify checks that the token and the commitment of the output hide the same type and value
+func (o *Output) Verify(pp *setup.PublicParams) error {
+	if o.IsRedeem() {
+		return nil
+	}
+	proof := &link.Proof{}
+	if err := proof.Deserialize(o.Proof); err != nil {
+		return errors.Wrap(err, "failed to deserialize output proof")
+	}
+	return link.NewVerifier(o.Token.Data, o.Commitment, linkGenerators(pp), math.Curves[pp.Curve]).Verify(proof)
+}
+
+func (o *Output) ToProtos() (*actions.Output, error) {
+	var tok *actions.Token
+	if o.Token != nil {
+		data, err := utils.ToProtoG1(o.Token.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize token")
+		}
+		spendKey, err := utils.ToProtoG1(o.Token.SpendKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize token spend key")
+		}
+		tok = &actions.Token{Data: data, SpendKey: spendKey}
+	}
+	commitment, err := utils.ToProtoG1(o.Commitment)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize commitment")
+	}
+	return &actions.Output{
+		Token:      tok,
+		Commitment: commitment,
+		Proof:      o.Proof,
+	}, nil
+}
+
+func (o *Output) FromProtos(output *actions.Output) error {
+	var err error
+	if output.Token != nil {
+		o.Token = &Token{}
+		o.Token.Data, err = utils.FromG1Proto(output.Token.Data)
+		if err != nil {
+			return errors.Wrapf(err, "failed to deserialize token")
+		}
+		o.Token.SpendKey, err = utils.FromG1Proto(output.Token.SpendKey)
+		if err != nil {
+			return errors.Wrapf(err, "failed to deserialize token spend key")
+		}
+	}
+	o.Commitment, err = utils.FromG1Proto(output.Commitment)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize commitment")
+	}
+	o.Proof = output.Proof
+	return nil
+}
+
+// NewOutputs returns the outputs, one per value and owner, and their metadata.
+// Each output is bound to the spend key of its owner, spendKeys has an entry for each owner.
+// An empty owner means that the corresponding output is redeemed, the spend key is ignored.
+// It also returns the openings of the commitments to type and value, to be used as witnesses of the validity proofs.
+func NewOutputs(values []uint64, owners [][]byte, spendKeys []*SpendKey, tokenType token.Type, pp *setup.PublicParams) ([]*Output, []*Metadata, []*noghtoken.Metadata, error) {
+	if len(values) != len(owners) {
+		return nil, nil, nil, errors.Errorf("number of values [%d] does not match number of owners [%d]", len(values), len(owners))
+	}
+	if len(spendKeys) != len(owners) {
+		return nil, nil, nil, errors.Errorf("number of spend keys [%d] does not match number of owners [%d]", len(spendKeys), len(owners))
+	}
+	curve := math.Curves[pp.Curve]
+	commitments, witnesses, err := noghtoken.GetTokensWithWitness(values, tokenType, pp.PedersenGenerators, curve)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to compute commitments")
+	}
+	rand, err := curve.Rand()
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to get random number generator")
+	}
+	outputs := make([]*Output, len(values))
+	metas := make([]*Metadata, len(values))
+	for i, owner := range owners {
+		outputs[i] = &Output{Commitment: commitments[i]}
+		if len(owner) == 0 {
+			// the ledger sees the commitment to type and value
+			metas[i] = &Metadata{
+				Type:           tokenType,
+				Value:          witnesses[i].Value,
+				BlindingFactor: witnesses[i].BlindingFactor,
+				SerialNumber:   curve.NewZrFromInt(0),
+			}
+			continue
+		}
+		if spendKeys[i] == nil || spendKeys[i].Key == nil {
+			return nil, nil, nil, errors.Errorf("no spend key for the owner of output [%d]", i)
+		}
+		// the token has a fresh blinding factor, the link proof shows the difference is known
+		delta := curve.NewRandomZr(rand)
+		metas[i] = &Metadata{
+			Type:                   tokenType,
+			Value:                  witnesses[i].Value,
+			BlindingFactor:         curve.ModAdd(witnesses[i].BlindingFactor, delta, curve.GroupOrder),
+			SerialNumber:           curve.NewRandomZr(rand),
+			Owner:                  owner,
+			SpendKey:               spendKeys[i].Key,
+			SpendKeySignature:      spendKeys[i].Signature,
+			SpendKeyBlindingFactor: curve.NewRandomZr(rand),
+		}
+		data, err := metas[i].Commit(pp)
+		if err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "failed to compute token [%d]", i)
+		}
+		spendKey, err := metas[i].CommitSpendKey(pp)
+		if err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "failed to compute token [%d]", i)
+		}
+		proof, err := link.NewProver(data, commitments[i], metas[i].SerialNumber, delta, linkGenerators(pp), curve).Prove()
+		if err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "failed to link token [%d]", i)
+		}
+		outputs[i].Token = &Token{Data: data, SpendKey: spendKey}
+		outputs[i].Proof, err = proof.Serialize()
+		if err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "failed to serialize proof of token [%d]", i)
+		}
+	}
+	return outputs, metas, witnesses, nil
+}
+
+// linkGenerators returns the generators of the serial number and the blinding factor
+func linkGenerators(pp *setup.PublicParams) []*math.G1 {
+	generators := pp.TokenGenerators()
+	return []*math.G1{generators[3], generators[2]}
+}
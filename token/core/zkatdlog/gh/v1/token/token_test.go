@@ -0,0 +1,233 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token_test
+
+import (
+	"os"
+	"testing"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// ownerSigner signs the spend keys of the owners
+type ownerSigner struct{}
+
+func (s *ownerSigner) Sign([]byte) ([]byte, error) {
+	return []byte("owner signature"), nil
+}
+
+// ownerVerifier accepts the signatures of ownerSigner
+type ownerVerifier struct{}
+
+func (v *ownerVerifier) Verify(_, sigma []byte) error {
+	if string(sigma) != "owner signature" {
+		return errors.New("invalid owner signature")
+	}
+	return nil
+}
+
+type deserializer struct {
+	driver.Deserializer
+}
+
+func (d *deserializer) GetOwnerVerifier(driver.Identity) (driver.Verifier, error) {
+	return &ownerVerifier{}, nil
+}
+
+func (d *deserializer) Recipients(id driver.Identity) ([]driver.Identity, error) {
+	return []driver.Identity{id}, nil
+}
+
+func newPublicParams(t *testing.T) *setup.PublicParams {
+	ipk, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := setup.Setup(32, ipk, math.BN254, 4)
+	assert.NoError(t, err)
+	pp.IssuerIDs = []driver.Identity{[]byte("issuer")}
+	return pp
+}
+
+func newSpendKey(t *testing.T, pp *setup.PublicParams) *token.SpendKey {
+	curve := math.Curves[pp.Curve]
+	rand, err := curve.Rand()
+	assert.NoError(t, err)
+	key, err := token.NewSpendKey(curve.NewRandomZr(rand), &ownerSigner{}, pp)
+	assert.NoError(t, err)
+	return key
+}
+
+func TestNewOutputs(t *testing.T) {
+	pp := newPublicParams(t)
+	outputs, metas, witnesses, err := token.NewOutputs(
+		[]uint64{10, 20},
+		[][]byte{[]byte("alice"), nil},
+		[]*token.SpendKey{newSpendKey(t, pp), nil},
+		"ABC",
+		pp,
+	)
+	assert.NoError(t, err)
+	assert.Len(t, outputs, 2)
+	assert.Len(t, metas, 2)
+	assert.Len(t, witnesses, 2)
+
+	// the first output is a token owned by alice
+	assert.False(t, outputs[0].IsRedeem())
+	assert.NoError(t, outputs[0].Validate())
+	assert.NoError(t, outputs[0].Verify(pp))
+	assert.Nil(t, outputs[0].GetOwner())
+	tok, err := outputs[0].Token.ToClear(metas[0], pp)
+	assert.NoError(t, err)
+	assert.Equal(t, &token2.Token{Type: "ABC", Quantity: "0xa", Owner: []byte("alice")}, tok)
+
+	// the second output is redeemed, the ledger sees its commitment to type and value
+	assert.True(t, outputs[1].IsRedeem())
+	assert.NoError(t, outputs[1].Validate())
+	assert.NoError(t, outputs[1].Verify(pp))
+	raw, err := outputs[1].Serialize()
+	assert.NoError(t, err)
+	redeemed := &token.Token{}
+	assert.NoError(t, redeemed.Deserialize(raw))
+	assert.True(t, redeemed.Data.Equals(outputs[1].Commitment))
+	assert.Nil(t, redeemed.SpendKey)
+	assert.EqualError(t, redeemed.Validate(), "token spend key cannot be empty")
+	tok, err = redeemed.ToClear(metas[1], pp)
+	assert.NoError(t, err)
+	assert.Equal(t, &token2.Token{Type: "ABC", Quantity: "0x14"}, tok)
+
+	// the token of an output does not open with the metadata of another one
+	_, err = outputs[0].Token.ToClear(metas[1], pp)
+	assert.EqualError(t, err, "cannot retrieve token in the clear: output does not match provided opening")
+
+	// a token bound to another spend key does not open
+	other := *metas[0]
+	other.SpendKey = newSpendKey(t, pp).Key
+	_, err = outputs[0].Token.ToClear(&other, pp)
+	assert.EqualError(t, err, "cannot retrieve token in the clear: spend key does not match provided opening")
+
+	// the link proof binds the token to the commitment
+	tampered := &token.Output{Token: outputs[0].Token, Commitment: witnessCommitment(pp, metas[1]), Proof: outputs[0].Proof}
+	assert.Error(t, tampered.Verify(pp))
+}
+
+func TestNewOutputsFailures(t *testing.T) {
+	pp := newPublicParams(t)
+
+	_, _, _, err := token.NewOutputs([]uint64{10}, nil, nil, "ABC", pp)
+	assert.EqualError(t, err, "number of values [1] does not match number of owners [0]")
+
+	_, _, _, err = token.NewOutputs([]uint64{10}, [][]byte{[]byte("alice")}, nil, "ABC", pp)
+	assert.EqualError(t, err, "number of spend keys [0] does not match number of owners [1]")
+
+	_, _, _, err = token.NewOutputs([]uint64{10}, [][]byte{[]byte("alice")}, []*token.SpendKey{nil}, "ABC", pp)
+	assert.EqualError(t, err, "no spend key for the owner of output [0]")
+}
+
+func TestSerialization(t *testing.T) {
+	pp := newPublicParams(t)
+	outputs, metas, _, err := token.NewOutputs([]uint64{10}, [][]byte{[]byte("alice")}, []*token.SpendKey{newSpendKey(t, pp)}, "ABC", pp)
+	assert.NoError(t, err)
+
+	raw, err := outputs[0].Serialize()
+	assert.NoError(t, err)
+	tok := &token.Token{}
+	assert.NoError(t, tok.Deserialize(raw))
+	assert.True(t, tok.Data.Equals(outputs[0].Token.Data))
+	assert.True(t, tok.SpendKey.Equals(outputs[0].Token.SpendKey))
+
+	protos, err := outputs[0].ToProtos()
+	assert.NoError(t, err)
+	output := &token.Output{}
+	assert.NoError(t, output.FromProtos(protos))
+	assert.Equal(t, outputs[0], output)
+
+	metas[0].Issuer = []byte("issuer")
+	raw, err = metas[0].Serialize()
+	assert.NoError(t, err)
+	meta := &token.Metadata{}
+	assert.NoError(t, meta.Deserialize(raw))
+	assert.Equal(t, metas[0], meta)
+
+	spendKey := metas[0].GetSpendKey()
+	raw, err = spendKey.Serialize()
+	assert.NoError(t, err)
+	key := &token.SpendKey{}
+	assert.NoError(t, key.Deserialize(raw))
+	assert.Equal(t, spendKey, key)
+
+	assert.Error(t, tok.Deserialize([]byte("invalid")))
+	assert.Error(t, meta.Deserialize([]byte("invalid")))
+}
+
+func TestSpendKey(t *testing.T) {
+	pp := newPublicParams(t)
+	key := newSpendKey(t, pp)
+	assert.NoError(t, key.Verify(&ownerVerifier{}))
+
+	key.Signature = []byte("another signature")
+	assert.EqualError(t, key.Verify(&ownerVerifier{}), "invalid signature on spend key: invalid owner signature")
+
+	key.Signature = nil
+	assert.EqualError(t, key.Verify(&ownerVerifier{}), "invalid spend key: nil elements")
+}
+
+func TestTokensService(t *testing.T) {
+	pp := newPublicParams(t)
+	ppm, err := common.NewPublicParamsManagerFromParams[*setup.PublicParams](pp)
+	assert.NoError(t, err)
+	service, err := token.NewTokensService(logging.MustGetLogger("test"), ppm, &deserializer{})
+	assert.NoError(t, err)
+	format, err := token.SupportedTokenFormat(pp)
+	assert.NoError(t, err)
+	assert.Equal(t, []token2.Format{format}, service.SupportedTokenFormats())
+
+	outputs, metas, _, err := token.NewOutputs([]uint64{10}, [][]byte{[]byte("alice")}, []*token.SpendKey{newSpendKey(t, pp)}, "ABC", pp)
+	assert.NoError(t, err)
+	metas[0].Issuer = []byte("issuer")
+	output, err := outputs[0].Serialize()
+	assert.NoError(t, err)
+	metadata, err := metas[0].Serialize()
+	assert.NoError(t, err)
+
+	tok, issuer, recipients, tokenFormat, err := service.Deobfuscate(output, metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, &token2.Token{Type: "ABC", Quantity: "0xa", Owner: []byte("alice")}, tok)
+	assert.Equal(t, driver.Identity("issuer"), issuer)
+	assert.Equal(t, []driver.Identity{driver.Identity("alice")}, recipients)
+	assert.Equal(t, format, tokenFormat)
+
+	_, meta, err := service.DeserializeToken(format, output, metadata)
+	assert.NoError(t, err)
+	assert.Equal(t, metas[0], meta)
+	_, _, err = service.DeserializeToken("another format", output, metadata)
+	assert.EqualError(t, err, "invalid token type [another format], expected ["+string(format)+"]")
+
+	// the owner did not sign the spend key
+	metas[0].SpendKeySignature = []byte("another signature")
+	metadata, err = metas[0].Serialize()
+	assert.NoError(t, err)
+	_, _, _, _, err = service.Deobfuscate(output, metadata)
+	assert.EqualError(t, err, "failed to deobfuscate token: invalid signature on spend key: invalid owner signature")
+
+	_, err = service.Recipients(output)
+	assert.EqualError(t, err, "the owner of a token with graph hiding is not disclosed")
+}
+
+// witnessCommitment returns the commitment to type and value opened by the passed metadata
+func witnessCommitment(pp *setup.PublicParams, meta *token.Metadata) *math.G1 {
+	curve := math.Curves[pp.Curve]
+	com := pp.PedersenGenerators[0].Mul(curve.HashToZr([]byte(meta.Type)))
+	com.Add(pp.PedersenGenerators[1].Mul(meta.Value))
+	return com
+}
@@ -0,0 +1,321 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/tracing"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/meta"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/transfer"
+	nogh "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/transfer"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type LoadedToken = common.LoadedToken[[]byte, []byte]
+
+type TokenLoader interface {
+	LoadTokens(ctx context.Context, ids []*token2.ID) ([]LoadedToken, error)
+}
+
+type TokenDeserializer interface {
+	DeserializeToken(outputFormat token2.Format, outputRaw []byte, metadataRaw []byte) (*token.Token, *token.Metadata, error)
+}
+
+type TransferService struct {
+	Logger                  logging.Logger
+	PublicParametersManager common.PublicParametersManager[*setup.PublicParams]
+	WalletService           driver.WalletService
+	TokenLoader             TokenLoader
+	IdentityDeserializer    driver.Deserializer
+	TokenDeserializer       TokenDeserializer
+	AnonymitySetProvider    AnonymitySetProvider
+	IdentityProvider        driver.IdentityProvider
+	SpendKeyManager         *SpendKeyManager
+	Metrics                 *Metrics
+	tracer                  trace.Tracer
+}
+
+func NewTransferService(
+	logger logging.Logger,
+	publicParametersManager common.PublicParametersManager[*setup.PublicParams],
+	walletService driver.WalletService,
+	tokenLoader TokenLoader,
+	identityDeserializer driver.Deserializer,
+	metrics *Metrics,
+	tracerProvider trace.TracerProvider,
+	tokenDeserializer TokenDeserializer,
+	anonymitySetProvider AnonymitySetProvider,
+	identityProvider driver.IdentityProvider,
+	spendKeyManager *SpendKeyManager,
+) *TransferService {
+	return &TransferService{
+		Logger:                  logger,
+		PublicParametersManager: publicParametersManager,
+		WalletService:           walletService,
+		TokenLoader:             tokenLoader,
+		IdentityDeserializer:    identityDeserializer,
+		Metrics:                 metrics,
+		tracer: tracerProvider.Tracer("transfer_service", tracing.WithMetricsOpts(tracing.MetricsOpts{
+			Namespace:  "tokensdk_dlog_gh",
+			LabelNames: []tracing.LabelName{},
+		})),
+		TokenDeserializer:    tokenDeserializer,
+		AnonymitySetProvider: anonymitySetProvider,
+		IdentityProvider:     identityProvider,
+		SpendKeyManager:      spendKeyManager,
+	}
+}
+
+// Transfer returns a TransferActionMetadata as a function of the passed arguments
+// It also returns the corresponding TransferMetadata
+func (s *TransferService) Transfer(
+	ctx context.Context,
+	txID string,
+	_ driver.OwnerWallet,
+	tokenIDs []*token2.ID,
+	outputTokens []*token2.Token,
+	opts *driver.TransferOptions,
+) (driver.TransferAction, *driver.TransferMetadata, error) {
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent("start_transfer")
+	defer span.AddEvent("end_transfer")
+
+	s.Logger.Debugf("Prepare Transfer Action [%s,%v]", txID, tokenIDs)
+	if common.IsAnyNil(tokenIDs...) {
+		return nil, nil, errors.New("failed to prepare transfer action: nil token id")
+	}
+	if common.IsAnyNil(outputTokens...) {
+		return nil, nil, errors.New("failed to prepare transfer action: nil output token")
+	}
+
+	// load tokens with the passed token identifiers
+	span.AddEvent("load_tokens")
+	loadedTokens, err := s.TokenLoader.LoadTokens(ctx, tokenIDs)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to load tokens")
+	}
+	pp := s.PublicParametersManager.PublicParams()
+	span.AddEvent("prepare_inputs")
+	inputs, err := s.prepareInputs(ctx, tokenIDs, loadedTokens, pp)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to prepare inputs")
+	}
+
+	sender, err := transfer.NewSender(inputs, pp)
+	if err != nil {
+		return nil, nil, err
+	}
+	values := make([]uint64, 0, len(outputTokens))
+	owners := make([][]byte, 0, len(outputTokens))
+	spendKeys := make([]*token.SpendKey, 0, len(outputTokens))
+	// get values and owners of outputs
+	span.AddEvent("prepare_output_tokens")
+	for i, output := range outputTokens {
+		q, err := token2.ToQuantity(output.Quantity, pp.Precision())
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to get value for %dth output", i)
+		}
+		values = append(values, q.ToBigInt().Uint64())
+		owners = append(owners, output.Owner)
+		var spendKey *token.SpendKey
+		if len(output.Owner) != 0 {
+			spendKey, err = s.SpendKeyManager.SpendKey(output.Owner)
+			if err != nil {
+				return nil, nil, errors.WithMessagef(err, "failed getting spend key for %dth output", i)
+			}
+		}
+		spendKeys = append(spendKeys, spendKey)
+	}
+	start := time.Now()
+	span.AddEvent("start_generate_zk_transfer")
+	action, outputsMetadata, err := sender.GenerateZKTransfer(ctx, values, owners, spendKeys)
+	span.AddEvent("end_generate_zk_transfer")
+	duration := time.Since(start)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to generate zkatdlog transfer action for txid [%s]", txID)
+	}
+	s.Metrics.zkTransferDuration.Observe(float64(duration.Milliseconds()))
+
+	// add transfer action's transferMetadata
+	if opts != nil {
+		action.Metadata = meta.TransferActionMetadata(opts.Attributes)
+	}
+
+	// prepare transferMetadata.
+	// Each input is signed by its spender, in place of the owner of the spent token.
+	// The audit information of the spender links it to the owner.
+	ws := s.WalletService
+	var transferInputsMetadata []*driver.TransferInputMetadata
+	for i, input := range inputs {
+		owner := driver.Identity(input.Metadata.Owner)
+		ownerAuditInfo, err := s.IdentityDeserializer.GetAuditInfo(owner, ws)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed getting audit info for sender identity [%s]", owner)
+		}
+		if len(ownerAuditInfo) == 0 {
+			s.Logger.Errorf("empty audit info for the owner [%s] of the i^th token [%s]", tokenIDs[i], owner)
+		}
+		signer := sender.Signers[i]
+		spender, err := signer.Spender.Identity()
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed getting spender of the i^th token [%s]", tokenIDs[i])
+		}
+		if err := s.IdentityProvider.RegisterSigner(spender, signer, transfer.NewSpendVerifier(signer.Spender, pp), nil); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed registering spender of the i^th token [%s]", tokenIDs[i])
+		}
+		auditInfo, err := (&transfer.SpendAuditInfo{
+			Owner:          owner,
+			OwnerAuditInfo: ownerAuditInfo,
+			SpendKey:       input.Metadata.GetSpendKey(),
+			BlindingFactor: signer.BlindingFactor(),
+		}).Serialize()
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed serializing spend audit info of the i^th token [%s]", tokenIDs[i])
+		}
+		if err := s.IdentityProvider.RegisterRecipientData(&driver.RecipientData{Identity: spender, AuditInfo: auditInfo}); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed registering audit info of the spender of the i^th token [%s]", tokenIDs[i])
+		}
+		transferInputsMetadata = append(transferInputsMetadata, &driver.TransferInputMetadata{
+			TokenID: tokenIDs[i],
+			Senders: []*driver.AuditableIdentity{
+				{
+					Identity:  spender,
+					AuditInfo: auditInfo,
+				},
+			},
+		})
+	}
+
+	var transferOutputsMetadata []*driver.TransferOutputMetadata
+	for i, output := range outputTokens {
+		var outputAudiInfo []byte
+		var outputReceivers []*driver.AuditableIdentity
+		if len(output.Owner) != 0 {
+			outputAudiInfo, err = s.IdentityDeserializer.GetAuditInfo(output.Owner, ws)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "failed getting audit info for recipient identity [%s]", driver.Identity(output.Owner))
+			}
+			recipients, err := s.IdentityDeserializer.Recipients(output.Owner)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "failed getting recipients")
+			}
+			for _, receiver := range recipients {
+				receiverAudiInfo, err := s.IdentityDeserializer.GetAuditInfo(receiver, ws)
+				if err != nil {
+					return nil, nil, errors.Wrapf(err, "failed getting audit info for receiver identity [%s]", receiver)
+				}
+				outputReceivers = append(outputReceivers, &driver.AuditableIdentity{
+					Identity:  receiver,
+					AuditInfo: receiverAudiInfo,
+				})
+			}
+		}
+
+		outputMetadata, err := outputsMetadata[i].Serialize()
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "failed serializing token info for zkatdlog transfer action")
+		}
+		transferOutputsMetadata = append(transferOutputsMetadata, &driver.TransferOutputMetadata{
+			OutputMetadata:  outputMetadata,
+			OutputAuditInfo: outputAudiInfo,
+			Receivers:       outputReceivers,
+		})
+	}
+
+	s.Logger.Debugf("Transfer Action Prepared [id:%s,ins:%d,outs:%d]", txID, len(tokenIDs), action.NumOutputs())
+
+	transferMetadata := &driver.TransferMetadata{
+		Inputs:       transferInputsMetadata,
+		Outputs:      transferOutputsMetadata,
+		ExtraSigners: nil,
+	}
+
+	return action, transferMetadata, nil
+}
+
+// VerifyTransfer checks the outputs in the TransferActionMetadata against the passed metadata
+func (s *TransferService) VerifyTransfer(action driver.TransferAction, outputMetadata []*driver.TransferOutputMetadata) error {
+	if action == nil {
+		return errors.New("failed to verify transfer: nil transfer action")
+	}
+	tr, ok := action.(*transfer.Action)
+	if !ok {
+		return errors.New("failed to verify transfer: expected *gh.TransferAction")
+	}
+
+	pp := s.PublicParametersManager.PublicParams()
+	for i, output := range tr.Outputs {
+		if err := output.Verify(pp); err != nil {
+			return errors.Wrapf(err, "failed to verify transfer: invalid output [%d]", i)
+		}
+		if i >= len(outputMetadata) || outputMetadata[i] == nil || len(outputMetadata[i].OutputMetadata) == 0 || output.IsRedeem() {
+			continue
+		}
+		metadata := &token.Metadata{}
+		if err := metadata.Deserialize(outputMetadata[i].OutputMetadata); err != nil {
+			return errors.Wrap(err, "failed unmarshalling token information")
+		}
+		tok, err := output.Token.ToClear(metadata, pp)
+		if err != nil {
+			return errors.Wrap(err, "failed getting token in the clear")
+		}
+		s.Logger.Debugf("transfer output [%s,%s,%s]", tok.Type, tok.Quantity, driver.Identity(tok.Owner))
+	}
+
+	// the membership proofs can be checked only against the ledger, this is done by the validator
+	return nogh.NewVerifier(tr.GetInputCommitments(), tr.GetOutputCommitments(), pp.PublicParams).Verify(tr.Proof)
+}
+
+// DeserializeTransferAction un-marshals a TransferActionMetadata from the passed array of bytes.
+// DeserializeTransferAction returns an error, if the un-marshalling fails.
+func (s *TransferService) DeserializeTransferAction(raw []byte) (driver.TransferAction, error) {
+	transferAction := &transfer.Action{}
+	err := transferAction.Deserialize(raw)
+	if err != nil {
+		return nil, err
+	}
+	return transferAction, nil
+}
+
+// prepareInputs opens the tokens to spend and selects their anonymity sets
+func (s *TransferService) prepareInputs(ctx context.Context, ids []*token2.ID, loadedTokens []LoadedToken, pp *setup.PublicParams) ([]*transfer.Input, error) {
+	inputs := make([]*transfer.Input, len(loadedTokens))
+	for i, loadedToken := range loadedTokens {
+		tok, tokenMetadata, err := s.TokenDeserializer.DeserializeToken(loadedToken.TokenFormat, loadedToken.Token, loadedToken.Metadata)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed deserializing token [%s]", ids[i])
+		}
+		spendSecret, err := s.SpendKeyManager.SpendSecret(tokenMetadata.Owner)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed getting spend secret for token [%s]", ids[i])
+		}
+		if tokenMetadata.SpendKey == nil || !pp.TokenGenerators()[4].Mul(spendSecret).Equals(tokenMetadata.SpendKey) {
+			return nil, errors.Errorf("token [%s] cannot be spent, its spend key does not belong to a local owner", ids[i])
+		}
+		anonymitySet, anonymityTokens, err := s.AnonymitySetProvider.AnonymitySet(ctx, ids[i], tok, int(pp.AnonymitySetSize))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to select the anonymity set of token [%s]", ids[i])
+		}
+		inputs[i] = &transfer.Input{
+			ID:              ids[i],
+			Metadata:        tokenMetadata,
+			AnonymitySet:    anonymitySet,
+			AnonymityTokens: anonymityTokens,
+			SpendSecret:     spendSecret,
+		}
+	}
+	return inputs, nil
+}
@@ -0,0 +1,335 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transfer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/proto"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/protos-go/actions"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	noghactions "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/actions"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/utils"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/protos"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/slices"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+const ProtocolV1 = 1
+
+// ActionInput spends a token without disclosing which one.
+// It reveals the serial number of the spent token, a fresh commitment to the spend key of its owner and the nullifier,
+// and proves that one of the tokens in the anonymity set opens to them and to the type and value committed in Commitment.
+// The owner of the spent token is never revealed: the input is signed by the Spender it defines.
+type ActionInput struct {
+	// SerialNumber is the serial number of the spent token
+	SerialNumber *math.Zr
+	// SpendKey is a fresh commitment to the spend key of the owner of the spent token
+	SpendKey *math.G1
+	// Nullifier marks the spent token as spent, only the owner of the token can compute it
+	Nullifier *math.G1
+	// Commitment is a fresh Pedersen commitment to type and value of the spent token
+	Commitment *math.G1
+	// AnonymitySet lists the ledger tokens among which the spent token is hidden
+	AnonymitySet []*token2.ID
+	// Proof is a one-out-of-many proof that the spent token is in the anonymity set
+	Proof []byte
+}
+
+// NullifierKey returns the value that marks the spent token as spent on the ledger
+func (a *ActionInput) NullifierKey() string {
+	hash := sha256.Sum256(a.Nullifier.Bytes())
+	return hex.EncodeToString(hash[:])
+}
+
+// Spender returns the spender that must sign the input
+func (a *ActionInput) Spender() *Spender {
+	return &Spender{
+		SerialNumber: a.SerialNumber,
+		SpendKey:     a.SpendKey,
+		Nullifier:    a.Nullifier,
+	}
+}
+
+func (a *ActionInput) ToProtos() (*actions.TransferActionInput, error) {
+	serialNumber, err := utils.ToProtoZr(a.SerialNumber)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize serial number")
+	}
+	spendKey, err := utils.ToProtoG1(a.SpendKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize spend key")
+	}
+	nullifier, err := utils.ToProtoG1(a.Nullifier)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize nullifier")
+	}
+	commitment, err := utils.ToProtoG1(a.Commitment)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize commitment")
+	}
+	anonymitySet, err := protos.ToProtosSliceFunc(a.AnonymitySet, func(id *token2.ID) (*noghactions.TokenID, error) {
+		if id == nil {
+			return nil, errors.New("nil token id")
+		}
+		return &noghactions.TokenID{Id: id.TxId, Index: id.Index}, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize anonymity set")
+	}
+	return &actions.TransferActionInput{
+		SerialNumber: serialNumber,
+		SpendKey:     spendKey,
+		Commitment:   commitment,
+		AnonymitySet: anonymitySet,
+		Proof:        a.Proof,
+		Nullifier:    nullifier,
+	}, nil
+}
+
+func (a *ActionInput) FromProtos(input *actions.TransferActionInput) error {
+	var err error
+	a.SerialNumber, err = utils.FromZrProto(input.SerialNumber)
+	if err != nil {
+		return errors.Wrap(err, "failed to deserialize serial number")
+	}
+	a.SpendKey, err = utils.FromG1Proto(input.SpendKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to deserialize spend key")
+	}
+	a.Nullifier, err = utils.FromG1Proto(input.Nullifier)
+	if err != nil {
+		return errors.Wrap(err, "failed to deserialize nullifier")
+	}
+	a.Commitment, err = utils.FromG1Proto(input.Commitment)
+	if err != nil {
+		return errors.Wrap(err, "failed to deserialize commitment")
+	}
+	a.AnonymitySet, err = protos.FromProtosSliceFunc2(input.AnonymitySet, func(id *noghactions.TokenID) (*token2.ID, error) {
+		if id == nil {
+			return nil, errors.New("nil token id")
+		}
+		return &token2.ID{TxId: id.Id, Index: id.Index}, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to deserialize anonymity set")
+	}
+	a.Proof = input.Proof
+	return nil
+}
+
+// Action specifies a transfer of one or more tokens with graph hiding
+type Action struct {
+	// Inputs spend the tokens without disclosing them
+	Inputs []*ActionInput
+	// Outputs are the new tokens resulting from the transfer
+	Outputs []*token.Output
+	// ZK Proof that shows that the transfer is correct
+	Proof []byte
+	// Metadata contains the transfer action's metadata
+	Metadata map[string][]byte
+}
+
+func (t *Action) NumInputs() int {
+	return len(t.Inputs)
+}
+
+// GetInputs returns nil, the spent tokens are not disclosed
+func (t *Action) GetInputs() []*token2.ID {
+	return nil
+}
+
+// GetSerializedInputs returns nil, the spent tokens are not disclosed
+func (t *Action) GetSerializedInputs() ([][]byte, error) {
+	return nil, nil
+}
+
+// GetSerialNumbers returns the nullifiers of the inputs
+func (t *Action) GetSerialNumbers() []string {
+	res := make([]string, len(t.Inputs))
+	for i, input := range t.Inputs {
+		res[i] = input.NullifierKey()
+	}
+	return res
+}
+
+// NumOutputs returns the number of outputs in the Action
+func (t *Action) NumOutputs() int {
+	return len(t.Outputs)
+}
+
+// GetOutputs returns the outputs in the Action
+func (t *Action) GetOutputs() []driver.Output {
+	res := make([]driver.Output, len(t.Outputs))
+	for i, output := range t.Outputs {
+		res[i] = output
+	}
+	return res
+}
+
+// IsRedeemAt checks if output in the Action at the passed index is redeemed
+func (t *Action) IsRedeemAt(index int) bool {
+	return t.Outputs[index].IsRedeem()
+}
+
+// SerializeOutputAt marshals the output in the Action at the passed index
+func (t *Action) SerializeOutputAt(index int) ([]byte, error) {
+	return t.Outputs[index].Serialize()
+}
+
+// GetSerializedOutputs returns the outputs in the Action serialized
+func (t *Action) GetSerializedOutputs() ([][]byte, error) {
+	res := make([][]byte, len(t.Outputs))
+	var err error
+	for i, output := range t.Outputs {
+		res[i], err = output.Serialize()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// IsGraphHiding returns true
+func (t *Action) IsGraphHiding() bool {
+	return true
+}
+
+// GetMetadata returns metadata of the Action
+func (t *Action) GetMetadata() map[string][]byte {
+	return t.Metadata
+}
+
+func (t *Action) Validate() error {
+	if len(t.Inputs) == 0 {
+		return errors.Errorf("invalid number of token inputs, expected at least 1")
+	}
+	nullifiers := map[string]struct{}{}
+	for i, in := range t.Inputs {
+		if in == nil {
+			return errors.Errorf("invalid input at index [%d], empty input", i)
+		}
+		if in.SerialNumber == nil {
+			return errors.Errorf("invalid input's serial number at index [%d], it is empty", i)
+		}
+		if in.SpendKey == nil {
+			return errors.Errorf("invalid input's spend key at index [%d], it is empty", i)
+		}
+		if in.Nullifier == nil {
+			return errors.Errorf("invalid input's nullifier at index [%d], it is empty", i)
+		}
+		if in.Commitment == nil {
+			return errors.Errorf("invalid input's commitment at index [%d], it is empty", i)
+		}
+		if len(in.AnonymitySet) == 0 {
+			return errors.Errorf("invalid input's anonymity set at index [%d], it is empty", i)
+		}
+		for j, id := range in.AnonymitySet {
+			if id == nil || len(id.TxId) == 0 {
+				return errors.Errorf("invalid input's anonymity set at index [%d], token id [%d] is empty", i, j)
+			}
+		}
+		if len(in.Proof) == 0 {
+			return errors.Errorf("invalid input's proof at index [%d], it is empty", i)
+		}
+		nullifier := in.NullifierKey()
+		if _, ok := nullifiers[nullifier]; ok {
+			return errors.Errorf("invalid input at index [%d], the token is spent twice", i)
+		}
+		nullifiers[nullifier] = struct{}{}
+	}
+	if len(t.Outputs) == 0 {
+		return errors.Errorf("invalid number of token outputs, expected at least 1")
+	}
+	for i, out := range t.Outputs {
+		if out == nil {
+			return errors.Errorf("invalid output token at index [%d]", i)
+		}
+		if err := out.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid output at index [%d]", i)
+		}
+	}
+	return nil
+}
+
+func (t *Action) ExtraSigners() []driver.Identity {
+	return nil
+}
+
+// Serialize marshal TransferAction
+func (t *Action) Serialize() ([]byte, error) {
+	inputs, err := protos.ToProtosSlice[actions.TransferActionInput, *ActionInput](t.Inputs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize inputs")
+	}
+	outputs, err := protos.ToProtosSlice[actions.Output, *token.Output](t.Outputs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize outputs")
+	}
+	action := &actions.TransferAction{
+		Version: ProtocolV1,
+		Inputs:  inputs,
+		Outputs: outputs,
+		Proof: &noghactions.Proof{
+			Proof: t.Proof,
+		},
+		Metadata: t.Metadata,
+	}
+	return proto.Marshal(action)
+}
+
+// Deserialize un-marshals TransferAction
+func (t *Action) Deserialize(raw []byte) error {
+	action := &actions.TransferAction{}
+	err := proto.Unmarshal(raw, action)
+	if err != nil {
+		return errors.Wrap(err, "failed to deserialize transfer action")
+	}
+	if action.Version != ProtocolV1 {
+		return errors.Errorf("invalid transfer version, expected [%d], got [%d]", ProtocolV1, action.Version)
+	}
+	t.Inputs = slices.GenericSliceOfPointers[ActionInput](len(action.Inputs))
+	if err := protos.FromProtosSlice(action.Inputs, t.Inputs); err != nil {
+		return errors.Wrap(err, "failed to deserialize inputs")
+	}
+	t.Outputs = slices.GenericSliceOfPointers[token.Output](len(action.Outputs))
+	if err := protos.FromProtosSlice(action.Outputs, t.Outputs); err != nil {
+		return errors.Wrap(err, "failed to deserialize outputs")
+	}
+	if action.Proof != nil {
+		t.Proof = action.Proof.Proof
+	}
+	t.Metadata = action.Metadata
+	return nil
+}
+
+// GetProof returns the proof in the Action
+func (t *Action) GetProof() []byte {
+	return t.Proof
+}
+
+// GetInputCommitments returns the commitments to type and value of the spent tokens
+func (t *Action) GetInputCommitments() []*math.G1 {
+	com := make([]*math.G1, len(t.Inputs))
+	for i, input := range t.Inputs {
+		com[i] = input.Commitment
+	}
+	return com
+}
+
+// GetOutputCommitments returns the commitments to type and value of the outputs
+func (t *Action) GetOutputCommitments() []*math.G1 {
+	com := make([]*math.G1, len(t.Outputs))
+	for i, output := range t.Outputs {
+		com[i] = output.Commitment
+	}
+	return com
+}
@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transfer
+
+import (
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/crypto/oom"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/pkg/errors"
+)
+
+// MembershipStatement returns the commitments on which the one-out-of-many proof of the passed input is computed,
+// together with the challenge that binds the two components of the tokens.
+// For each token of the anonymity set, the first component is divided by the serial number and the commitment
+// revealed by the input, the spend key is divided by the spend key commitment revealed by the input,
+// and the two results are combined with the challenge.
+// The result opens to zero, under the blinding factor generator, exactly for the spent token.
+// The list is padded to a power of two.
+func MembershipStatement(tokens []*token.Token, input *ActionInput, pp *setup.PublicParams) ([]*math.G1, *math.Zr, error) {
+	if len(tokens) == 0 {
+		return nil, nil, errors.New("empty anonymity set")
+	}
+	if input.SerialNumber == nil || input.Commitment == nil || input.SpendKey == nil || input.Nullifier == nil {
+		return nil, nil, errors.New("invalid input: nil elements")
+	}
+	curve := math.Curves[pp.Curve]
+	generators := pp.TokenGenerators()
+	revealed := generators[3].Mul(input.SerialNumber)
+	revealed.Add(input.Commitment)
+
+	raw := input.SerialNumber.Bytes()
+	raw = append(raw, input.Commitment.Bytes()...)
+	raw = append(raw, input.SpendKey.Bytes()...)
+	raw = append(raw, input.Nullifier.Bytes()...)
+	for i, tok := range tokens {
+		if tok == nil || tok.Data == nil {
+			return nil, nil, errors.Errorf("invalid anonymity set: nil token at index [%d]", i)
+		}
+		if tok.SpendKey == nil {
+			return nil, nil, errors.Errorf("invalid anonymity set: token at index [%d] cannot be spent", i)
+		}
+		raw = append(raw, tok.Data.Bytes()...)
+		raw = append(raw, tok.SpendKey.Bytes()...)
+	}
+	challenge := curve.HashToZr(raw)
+
+	commitments := make([]*math.G1, len(tokens))
+	for i, tok := range tokens {
+		commitments[i] = tok.Data.Copy()
+		commitments[i].Sub(revealed)
+		spendKey := tok.SpendKey.Copy()
+		spendKey.Sub(input.SpendKey)
+		commitments[i].Add(spendKey.Mul(challenge))
+	}
+	return oom.Pad(commitments), challenge, nil
+}
+
+// VerifyMembership checks that the passed input spends one of the passed tokens, the ledger tokens of its anonymity set
+func VerifyMembership(tokens []*token.Token, input *ActionInput, pp *setup.PublicParams) error {
+	commitments, _, err := MembershipStatement(tokens, input, pp)
+	if err != nil {
+		return err
+	}
+	proof := &oom.Proof{}
+	if err := proof.Deserialize(input.Proof); err != nil {
+		return errors.Wrap(err, "failed to deserialize membership proof")
+	}
+	generators := pp.TokenGenerators()
+	return oom.NewVerifier(commitments, generators[0], generators[2], math.Curves[pp.Curve]).Verify(proof)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transfer
+
+import (
+	"context"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/crypto/oom"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	noghtoken "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/token"
+	nogh "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/transfer"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Input is a token to be spent together with the anonymity set that hides it
+type Input struct {
+	// ID is the identifier of the token
+	ID *token2.ID
+	// Metadata is the opening of the token
+	Metadata *token.Metadata
+	// AnonymitySet lists the identifiers of the ledger tokens among which the token is hidden, the token included
+	AnonymitySet []*token2.ID
+	// AnonymityTokens are the ledger tokens of the anonymity set, in the same order
+	AnonymityTokens []*token.Token
+	// SpendSecret is the secret of the spend key of the owner of the token
+	SpendSecret *math.Zr
+}
+
+// Sender produces a transfer action with graph hiding
+type Sender struct {
+	// Signers sign on behalf of the spenders of the inputs, they are available once the transfer is generated
+	Signers []*SpendSigner
+	// Inputs to be spent in the transfer
+	Inputs []*Input
+	// PublicParams refers to the public cryptographic parameters to be used
+	PublicParams *setup.PublicParams
+}
+
+// NewSender returns a Sender
+func NewSender(inputs []*Input, pp *setup.PublicParams) (*Sender, error) {
+	for i, input := range inputs {
+		if input == nil || input.ID == nil || input.Metadata == nil || input.SpendSecret == nil {
+			return nil, errors.Errorf("invalid input at index [%d]", i)
+		}
+		if len(input.AnonymitySet) != len(input.AnonymityTokens) {
+			return nil, errors.Errorf("invalid input at index [%d], number of identifiers [%d] does not match number of tokens [%d] in the anonymity set", i, len(input.AnonymitySet), len(input.AnonymityTokens))
+		}
+	}
+	return &Sender{Inputs: inputs, PublicParams: pp}, nil
+}
+
+// GenerateZKTransfer produces an Action and the metadata of its outputs.
+// spendKeys contains the spend key of each owner, nil for redeemed outputs.
+func (s *Sender) GenerateZKTransfer(ctx context.Context, values []uint64, owners [][]byte, spendKeys []*token.SpendKey) (*Action, []*token.Metadata, error) {
+	span := trace.SpanFromContext(ctx)
+	if len(values) != len(owners) {
+		return nil, nil, errors.Errorf("cannot generate transfer: number of values [%d] does not match number of recipients [%d]", len(values), len(owners))
+	}
+	if len(s.Inputs) == 0 {
+		return nil, nil, errors.New("cannot generate transfer: no inputs")
+	}
+	tokenType := s.Inputs[0].Metadata.Type
+	for _, input := range s.Inputs {
+		if input.Metadata.Type != tokenType {
+			return nil, nil, errors.New("cannot generate transfer: please choose inputs of the same token type")
+		}
+	}
+
+	span.AddEvent("generate_inputs")
+	inputs, inw, signers, err := s.generateInputs()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot generate transfer")
+	}
+
+	span.AddEvent("generate_outputs")
+	outputs, metas, outw, err := token.NewOutputs(values, owners, spendKeys, tokenType, s.PublicParams)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot generate transfer")
+	}
+
+	span.AddEvent("create_new_prover")
+	action := &Action{Inputs: inputs, Outputs: outputs}
+	prover, err := nogh.NewProver(inw, outw, action.GetInputCommitments(), action.GetOutputCommitments(), s.PublicParams.PublicParams)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot generate transfer")
+	}
+	span.AddEvent("prove")
+	action.Proof, err = prover.Prove()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot generate zero-knowledge proof for transfer")
+	}
+	s.Signers = signers
+	return action, metas, nil
+}
+
+// SignTokenActions produces a signature for each input spent by the Sender
+func (s *Sender) SignTokenActions(raw []byte) ([][]byte, error) {
+	signatures := make([][]byte, len(s.Signers))
+	var err error
+	for i := 0; i < len(signatures); i++ {
+		signatures[i], err = s.Signers[i].Sign(raw)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to sign token requests")
+		}
+	}
+	return signatures, nil
+}
+
+// generateInputs returns the action inputs, the openings of their commitments to type and value,
+// and the signers of their spenders
+func (s *Sender) generateInputs() ([]*ActionInput, []*noghtoken.Metadata, []*SpendSigner, error) {
+	curve := math.Curves[s.PublicParams.Curve]
+	rand, err := curve.Rand()
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to get random number generator")
+	}
+	generators := s.PublicParams.TokenGenerators()
+	inputs := make([]*ActionInput, len(s.Inputs))
+	witnesses := make([]*noghtoken.Metadata, len(s.Inputs))
+	signers := make([]*SpendSigner, len(s.Inputs))
+	for i, in := range s.Inputs {
+		index := -1
+		for j, id := range in.AnonymitySet {
+			if id.TxId == in.ID.TxId && id.Index == in.ID.Index {
+				index = j
+			}
+		}
+		if index < 0 {
+			return nil, nil, nil, errors.Errorf("invalid anonymity set of input [%d]: it does not contain [%s]", i, in.ID)
+		}
+		if in.Metadata.SpendKeyBlindingFactor == nil {
+			return nil, nil, nil, errors.Errorf("invalid input [%d]: missing blinding factor of the spend key", i)
+		}
+
+		// fresh commitment to type and value
+		witnesses[i] = &noghtoken.Metadata{
+			Type:           in.Metadata.Type,
+			Value:          in.Metadata.Value,
+			BlindingFactor: curve.NewRandomZr(rand),
+		}
+		commitment := generators[0].Mul(curve.HashToZr([]byte(in.Metadata.Type)))
+		commitment.Add(generators[1].Mul(in.Metadata.Value))
+		commitment.Add(generators[2].Mul(witnesses[i].BlindingFactor))
+
+		// fresh commitment to the spend key and nullifier
+		spendKeyBlindingFactor := curve.NewRandomZr(rand)
+		spendKey := generators[4].Mul(in.SpendSecret)
+		spendKey.Add(generators[2].Mul(spendKeyBlindingFactor))
+		inputs[i] = &ActionInput{
+			SerialNumber: in.Metadata.SerialNumber,
+			SpendKey:     spendKey,
+			Nullifier:    NullifierBase(in.Metadata.SerialNumber, curve).Mul(in.SpendSecret),
+			Commitment:   commitment,
+			AnonymitySet: in.AnonymitySet,
+		}
+		signers[i] = NewSpendSigner(inputs[i].Spender(), in.SpendSecret, spendKeyBlindingFactor, s.PublicParams)
+
+		statement, challenge, err := MembershipStatement(in.AnonymityTokens, inputs[i], s.PublicParams)
+		if err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "failed to compute membership statement of input [%d]", i)
+		}
+		order := curve.GroupOrder
+		randomness := curve.ModAdd(
+			curve.ModSub(in.Metadata.BlindingFactor, witnesses[i].BlindingFactor, order),
+			curve.ModMul(challenge, curve.ModSub(in.Metadata.SpendKeyBlindingFactor, spendKeyBlindingFactor, order), order),
+			order,
+		)
+		proof, err := oom.NewProver(statement, index, randomness, generators[0], generators[2], curve).Prove()
+		if err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "failed to generate membership proof of input [%d]", i)
+		}
+		inputs[i].Proof, err = proof.Serialize()
+		if err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "failed to serialize membership proof of input [%d]", i)
+		}
+	}
+	return inputs, witnesses, signers, nil
+}
@@ -0,0 +1,300 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transfer
+
+import (
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/proto"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/asn1"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/protos-go/actions"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/utils"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/pkg/errors"
+)
+
+// SpenderIdentityType is the type of the identities that sign a transfer on behalf of the owners of the spent tokens
+const SpenderIdentityType identity.Type = "ghs"
+
+// nullifierLabel separates the nullifier bases from any other point hashed to the curve
+const nullifierLabel = "zkatdlog-gh.Nullifier"
+
+// Spender is revealed by an input in place of the owner of the spent token.
+// It consists of the serial number of the token, a fresh commitment B = g^k h^b to the spend key of the owner,
+// and the nullifier N = U^k, where U is derived from the serial number and k is the spend secret of the owner.
+// Only the owner knows k, then only the owner can compute the nullifier and sign on behalf of the spender.
+// B is re-randomized at each spend, then different spenders of the same owner are unlinkable.
+type Spender struct {
+	// SerialNumber is the serial number of the spent token
+	SerialNumber *math.Zr
+	// SpendKey is the commitment to the spend key of the owner
+	SpendKey *math.G1
+	// Nullifier marks the spent token as spent
+	Nullifier *math.G1
+}
+
+// Bytes marshals Spender
+func (s *Spender) Bytes() ([]byte, error) {
+	return asn1.MarshalMath(s.SerialNumber, s.SpendKey, s.Nullifier)
+}
+
+// Deserialize un-marshals Spender
+func (s *Spender) Deserialize(raw []byte) error {
+	unmarshaller, err := asn1.NewUnmarshaller(raw)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize raw")
+	}
+	s.SerialNumber, err = unmarshaller.NextZr()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize serial number")
+	}
+	s.SpendKey, err = unmarshaller.NextG1()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize spend key")
+	}
+	s.Nullifier, err = unmarshaller.NextG1()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize nullifier")
+	}
+	return nil
+}
+
+// Identity returns the spender as a typed identity, the identity that signs the transfer
+func (s *Spender) Identity() (driver.Identity, error) {
+	raw, err := s.Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize spender")
+	}
+	return identity.WrapWithType(SpenderIdentityType, raw)
+}
+
+// NullifierBase returns the base of the nullifier of the token with the passed serial number
+func NullifierBase(serialNumber *math.Zr, c *math.Curve) *math.G1 {
+	return c.HashToG1(append([]byte(nullifierLabel), serialNumber.Bytes()...))
+}
+
+// SpendProof is a signature of knowledge of the spend secret k and of the blinding factor b
+// such that B = g^k h^b and N = U^k, where B, N and U are the spend key, nullifier and nullifier base of a Spender
+type SpendProof struct {
+	// SpendKey is the commitment to the randomness of the spend key
+	SpendKey *math.G1
+	// Nullifier is the commitment to the randomness of the nullifier
+	Nullifier *math.G1
+	// Secret is the proof of knowledge of the spend secret
+	Secret *math.Zr
+	// BlindingFactor is the proof of knowledge of the blinding factor of the spend key
+	BlindingFactor *math.Zr
+}
+
+// Serialize marshals SpendProof
+func (p *SpendProof) Serialize() ([]byte, error) {
+	return asn1.MarshalMath(p.SpendKey, p.Nullifier, p.Secret, p.BlindingFactor)
+}
+
+// Deserialize un-marshals SpendProof
+func (p *SpendProof) Deserialize(raw []byte) error {
+	unmarshaller, err := asn1.NewUnmarshaller(raw)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize raw")
+	}
+	p.SpendKey, err = unmarshaller.NextG1()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize spend key commitment")
+	}
+	p.Nullifier, err = unmarshaller.NextG1()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize nullifier commitment")
+	}
+	p.Secret, err = unmarshaller.NextZr()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize secret")
+	}
+	p.BlindingFactor, err = unmarshaller.NextZr()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize blinding factor")
+	}
+	return nil
+}
+
+// SpendSigner signs on behalf of a Spender
+type SpendSigner struct {
+	*Spender
+	PublicParams *setup.PublicParams
+	// witness
+	secret         *math.Zr
+	blindingFactor *math.Zr
+}
+
+// NewSpendSigner returns a SpendSigner for the spender with the passed spend secret and blinding factor of the spend key
+func NewSpendSigner(spender *Spender, secret, blindingFactor *math.Zr, pp *setup.PublicParams) *SpendSigner {
+	return &SpendSigner{
+		Spender:        spender,
+		PublicParams:   pp,
+		secret:         secret,
+		blindingFactor: blindingFactor,
+	}
+}
+
+// BlindingFactor returns the blinding factor of the spend key of the spender
+func (s *SpendSigner) BlindingFactor() *math.Zr {
+	return s.blindingFactor
+}
+
+// Sign returns a SpendProof on the passed message
+func (s *SpendSigner) Sign(message []byte) ([]byte, error) {
+	curve := math.Curves[s.PublicParams.Curve]
+	rand, err := curve.Rand()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get random number generator")
+	}
+	generators := s.PublicParams.TokenGenerators()
+	rs, rb := curve.NewRandomZr(rand), curve.NewRandomZr(rand)
+	proof := &SpendProof{
+		SpendKey:  generators[4].Mul(rs),
+		Nullifier: NullifierBase(s.SerialNumber, curve).Mul(rs),
+	}
+	proof.SpendKey.Add(generators[2].Mul(rb))
+	x, err := spendChallenge(s.Spender, proof, message, curve)
+	if err != nil {
+		return nil, err
+	}
+	order := curve.GroupOrder
+	proof.Secret = curve.ModAdd(rs, curve.ModMul(x, s.secret, order), order)
+	proof.BlindingFactor = curve.ModAdd(rb, curve.ModMul(x, s.blindingFactor, order), order)
+	return proof.Serialize()
+}
+
+// SpendVerifier checks the signatures of a Spender
+type SpendVerifier struct {
+	*Spender
+	PublicParams *setup.PublicParams
+}
+
+// NewSpendVerifier returns a SpendVerifier for the passed spender
+func NewSpendVerifier(spender *Spender, pp *setup.PublicParams) *SpendVerifier {
+	return &SpendVerifier{Spender: spender, PublicParams: pp}
+}
+
+// Verify returns an error if the passed signature is not a valid SpendProof on the passed message
+func (v *SpendVerifier) Verify(message, sigma []byte) error {
+	if v.SerialNumber == nil || v.SpendKey == nil || v.Nullifier == nil {
+		return errors.New("invalid spender: nil elements")
+	}
+	proof := &SpendProof{}
+	if err := proof.Deserialize(sigma); err != nil {
+		return errors.Wrap(err, "failed to deserialize spend proof")
+	}
+	if proof.SpendKey == nil || proof.Nullifier == nil || proof.Secret == nil || proof.BlindingFactor == nil {
+		return errors.New("invalid spend proof: nil elements")
+	}
+	curve := math.Curves[v.PublicParams.Curve]
+	x, err := spendChallenge(v.Spender, proof, message, curve)
+	if err != nil {
+		return err
+	}
+	generators := v.PublicParams.TokenGenerators()
+
+	left := generators[4].Mul(proof.Secret)
+	left.Add(generators[2].Mul(proof.BlindingFactor))
+	right := v.SpendKey.Mul(x)
+	right.Add(proof.SpendKey)
+	if !left.Equals(right) {
+		return errors.New("invalid spend proof")
+	}
+
+	left = NullifierBase(v.SerialNumber, curve).Mul(proof.Secret)
+	right = v.Nullifier.Mul(x)
+	right.Add(proof.Nullifier)
+	if !left.Equals(right) {
+		return errors.New("invalid spend proof")
+	}
+	return nil
+}
+
+func spendChallenge(spender *Spender, proof *SpendProof, message []byte, c *math.Curve) (*math.Zr, error) {
+	raw, err := asn1.MarshalMath(spender.SerialNumber, spender.SpendKey, spender.Nullifier, proof.SpendKey, proof.Nullifier)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute the challenge of the spend proof")
+	}
+	return c.HashToZr(append(raw, message...)), nil
+}
+
+// SpendAuditInfo links a Spender to the owner of the spent token.
+// It is shared with the auditors only, the owner is never disclosed to the validators.
+type SpendAuditInfo struct {
+	// Owner is the owner of the spent token
+	Owner driver.Identity
+	// OwnerAuditInfo is the audit information of the owner
+	OwnerAuditInfo []byte
+	// SpendKey is the spend key of the owner
+	SpendKey *token.SpendKey
+	// BlindingFactor is the blinding factor of the commitment to the spend key revealed by the Spender
+	BlindingFactor *math.Zr
+}
+
+// Match checks that the spender reveals a commitment to the spend key of the audit information.
+// Notice that Match does not check the signature on the spend key, the caller must verify it against the owner.
+func (i *SpendAuditInfo) Match(spender *Spender, pp *setup.PublicParams) error {
+	if i.SpendKey == nil || i.SpendKey.Key == nil || i.BlindingFactor == nil {
+		return errors.New("invalid spend audit info: nil elements")
+	}
+	if spender.SpendKey == nil {
+		return errors.New("invalid spender: nil spend key")
+	}
+	expected := pp.TokenGenerators()[2].Mul(i.BlindingFactor)
+	expected.Add(i.SpendKey.Key)
+	if !expected.Equals(spender.SpendKey) {
+		return errors.New("spender does not match the spend key of the owner")
+	}
+	return nil
+}
+
+// Serialize marshals SpendAuditInfo
+func (i *SpendAuditInfo) Serialize() ([]byte, error) {
+	if i.SpendKey == nil {
+		return nil, errors.New("invalid spend audit info: nil spend key")
+	}
+	spendKey, err := i.SpendKey.ToProtos()
+	if err != nil {
+		return nil, err
+	}
+	blindingFactor, err := utils.ToProtoZr(i.BlindingFactor)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize blinding factor")
+	}
+	return proto.Marshal(&actions.SpendAuditInfo{
+		Owner:          i.Owner,
+		OwnerAuditInfo: i.OwnerAuditInfo,
+		SpendKey:       spendKey,
+		BlindingFactor: blindingFactor,
+	})
+}
+
+// Deserialize un-marshals SpendAuditInfo
+func (i *SpendAuditInfo) Deserialize(raw []byte) error {
+	info := &actions.SpendAuditInfo{}
+	if err := proto.Unmarshal(raw, info); err != nil {
+		return errors.Wrap(err, "failed unmarshalling spend audit info")
+	}
+	if info.SpendKey == nil {
+		return errors.New("invalid spend audit info: nil spend key")
+	}
+	i.Owner = info.Owner
+	i.OwnerAuditInfo = info.OwnerAuditInfo
+	i.SpendKey = &token.SpendKey{}
+	if err := i.SpendKey.FromProtos(info.SpendKey); err != nil {
+		return err
+	}
+	var err error
+	i.BlindingFactor, err = utils.FromZrProto(info.BlindingFactor)
+	if err != nil {
+		return errors.Wrap(err, "failed to deserialize blinding factor")
+	}
+	return nil
+}
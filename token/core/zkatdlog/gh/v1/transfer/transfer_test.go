@@ -0,0 +1,264 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transfer_test
+
+import (
+	"context"
+	"testing"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/transfer"
+	nogh "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/transfer"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/stretchr/testify/assert"
+)
+
+// message is the token request signed by the spenders
+var message = []byte("token request")
+
+// ownerSigner signs the spend keys of the owners
+type ownerSigner struct{}
+
+func (s *ownerSigner) Sign([]byte) ([]byte, error) {
+	return []byte("owner signature"), nil
+}
+
+type environment struct {
+	pp      *setup.PublicParams
+	curve   *math.Curve
+	ids     []*token2.ID
+	tokens  []*token.Token
+	metas   []*token.Metadata
+	secrets map[string]*math.Zr
+}
+
+// newEnvironment creates the ledger tokens of value 10*(i+1), owned by alice
+func newEnvironment(t *testing.T, size int) *environment {
+	pp, err := setup.Setup(32, nil, math.BN254, 4)
+	assert.NoError(t, err)
+	env := &environment{pp: pp, curve: math.Curves[pp.Curve], secrets: map[string]*math.Zr{}}
+
+	values := make([]uint64, size)
+	owners := make([][]byte, size)
+	for i := range values {
+		values[i] = uint64(10 * (i + 1))
+		owners[i] = []byte("alice")
+	}
+	outputs, metas, _, err := token.NewOutputs(values, owners, env.spendKeys(t, owners), "ABC", pp)
+	assert.NoError(t, err)
+	env.metas = metas
+	for i, output := range outputs {
+		env.ids = append(env.ids, &token2.ID{TxId: "issue", Index: uint64(i)})
+		env.tokens = append(env.tokens, output.Token)
+	}
+	return env
+}
+
+// spendKeys returns the spend keys of the passed owners, nil for the redeemed outputs
+func (e *environment) spendKeys(t *testing.T, owners [][]byte) []*token.SpendKey {
+	rand, err := e.curve.Rand()
+	assert.NoError(t, err)
+	keys := make([]*token.SpendKey, len(owners))
+	for i, owner := range owners {
+		if len(owner) == 0 {
+			continue
+		}
+		secret, ok := e.secrets[string(owner)]
+		if !ok {
+			secret = e.curve.NewRandomZr(rand)
+			e.secrets[string(owner)] = secret
+		}
+		keys[i], err = token.NewSpendKey(secret, &ownerSigner{}, e.pp)
+		assert.NoError(t, err)
+	}
+	return keys
+}
+
+// inputs returns the inputs spending the tokens at the passed indices, each hidden among the first ledger tokens
+func (e *environment) inputs(indices ...int) []*transfer.Input {
+	size := e.pp.AnonymitySetSize
+	inputs := make([]*transfer.Input, len(indices))
+	for i, index := range indices {
+		inputs[i] = &transfer.Input{
+			ID:              e.ids[index],
+			Metadata:        e.metas[index],
+			AnonymitySet:    e.ids[:size],
+			AnonymityTokens: e.tokens[:size],
+			SpendSecret:     e.secrets[string(e.metas[index].Owner)],
+		}
+	}
+	return inputs
+}
+
+func TestTransfer(t *testing.T) {
+	env := newEnvironment(t, 4)
+	sender, err := transfer.NewSender(env.inputs(1, 2), env.pp)
+	assert.NoError(t, err)
+	owners := [][]byte{[]byte("bob"), nil}
+	action, metas, err := sender.GenerateZKTransfer(context.Background(), []uint64{40, 10}, owners, env.spendKeys(t, owners))
+	assert.NoError(t, err)
+	assert.Len(t, metas, 2)
+	assert.NoError(t, action.Validate())
+	assert.True(t, action.IsGraphHiding())
+	assert.Equal(t, 2, action.NumInputs())
+	assert.Equal(t, 2, action.NumOutputs())
+	assert.False(t, action.IsRedeemAt(0))
+	assert.True(t, action.IsRedeemAt(1))
+
+	// go through the serialization, as the validators do
+	raw, err := action.Serialize()
+	assert.NoError(t, err)
+	deserialized := &transfer.Action{}
+	assert.NoError(t, deserialized.Deserialize(raw))
+	assert.Equal(t, action, deserialized)
+
+	// each input spends one of the tokens of its anonymity set, without disclosing which one
+	for i, input := range deserialized.Inputs {
+		assert.Equal(t, env.ids[:env.pp.AnonymitySetSize], input.AnonymitySet)
+		assert.True(t, input.SerialNumber.Equals(env.metas[i+1].SerialNumber))
+		assert.NoError(t, transfer.VerifyMembership(env.tokens[:env.pp.AnonymitySetSize], input, env.pp))
+	}
+	// the spent tokens must be the ones of the anonymity set
+	assert.Error(t, transfer.VerifyMembership(env.tokens[1:], deserialized.Inputs[0], env.pp))
+
+	// the proof shows that the inputs and the outputs have the same type and the same total value
+	assert.NoError(t, nogh.NewVerifier(deserialized.GetInputCommitments(), deserialized.GetOutputCommitments(), env.pp.PublicParams).Verify(deserialized.GetProof()))
+
+	// the spenders sign the token request
+	signatures, err := sender.SignTokenActions(message)
+	assert.NoError(t, err)
+	assert.Len(t, signatures, 2)
+	for i, input := range deserialized.Inputs {
+		verifier := transfer.NewSpendVerifier(input.Spender(), env.pp)
+		assert.NoError(t, verifier.Verify(message, signatures[i]))
+		assert.Error(t, verifier.Verify([]byte("another request"), signatures[i]))
+		// the signature of an input does not verify for another input
+		assert.Error(t, transfer.NewSpendVerifier(deserialized.Inputs[1-i].Spender(), env.pp).Verify(message, signatures[i]))
+	}
+
+	// the nullifiers depend on the spent token only
+	resender, err := transfer.NewSender(env.inputs(1), env.pp)
+	assert.NoError(t, err)
+	again, _, err := resender.GenerateZKTransfer(context.Background(), []uint64{20}, [][]byte{[]byte("alice")}, env.spendKeys(t, [][]byte{[]byte("alice")}))
+	assert.NoError(t, err)
+	assert.Equal(t, action.Inputs[0].NullifierKey(), again.Inputs[0].NullifierKey())
+	assert.False(t, action.Inputs[0].SpendKey.Equals(again.Inputs[0].SpendKey))
+}
+
+func TestSenderFailures(t *testing.T) {
+	env := newEnvironment(t, 4)
+
+	_, err := transfer.NewSender([]*transfer.Input{nil}, env.pp)
+	assert.EqualError(t, err, "invalid input at index [0]")
+
+	inputs := env.inputs(0)
+	inputs[0].AnonymityTokens = inputs[0].AnonymityTokens[1:]
+	_, err = transfer.NewSender(inputs, env.pp)
+	assert.EqualError(t, err, "invalid input at index [0], number of identifiers [4] does not match number of tokens [3] in the anonymity set")
+
+	sender, err := transfer.NewSender(nil, env.pp)
+	assert.NoError(t, err)
+	_, _, err = sender.GenerateZKTransfer(context.Background(), []uint64{10}, [][]byte{nil}, []*token.SpendKey{nil})
+	assert.EqualError(t, err, "cannot generate transfer: no inputs")
+
+	sender, err = transfer.NewSender(env.inputs(0), env.pp)
+	assert.NoError(t, err)
+	_, _, err = sender.GenerateZKTransfer(context.Background(), []uint64{10}, nil, nil)
+	assert.EqualError(t, err, "cannot generate transfer: number of values [1] does not match number of recipients [0]")
+
+	// the token must be in its anonymity set
+	inputs = env.inputs(0)
+	inputs[0].AnonymitySet = env.ids[1:]
+	inputs[0].AnonymityTokens = env.tokens[1:]
+	sender, err = transfer.NewSender(inputs, env.pp)
+	assert.NoError(t, err)
+	_, _, err = sender.GenerateZKTransfer(context.Background(), []uint64{10}, [][]byte{nil}, []*token.SpendKey{nil})
+	assert.EqualError(t, err, "cannot generate transfer: invalid anonymity set of input [0]: it does not contain [[issue:0]]")
+
+	// the inputs must have the same type
+	inputs = env.inputs(0, 1)
+	other := *inputs[1].Metadata
+	other.Type = "DEF"
+	inputs[1].Metadata = &other
+	sender, err = transfer.NewSender(inputs, env.pp)
+	assert.NoError(t, err)
+	_, _, err = sender.GenerateZKTransfer(context.Background(), []uint64{30}, [][]byte{nil}, []*token.SpendKey{nil})
+	assert.EqualError(t, err, "cannot generate transfer: please choose inputs of the same token type")
+}
+
+func TestActionValidate(t *testing.T) {
+	env := newEnvironment(t, 4)
+	sender, err := transfer.NewSender(env.inputs(0), env.pp)
+	assert.NoError(t, err)
+	action, _, err := sender.GenerateZKTransfer(context.Background(), []uint64{10}, [][]byte{nil}, []*token.SpendKey{nil})
+	assert.NoError(t, err)
+	assert.NoError(t, action.Validate())
+
+	// the same token cannot be spent twice in the same action
+	twice := &transfer.Action{Inputs: []*transfer.ActionInput{action.Inputs[0], action.Inputs[0]}, Outputs: action.Outputs}
+	assert.EqualError(t, twice.Validate(), "invalid input at index [1], the token is spent twice")
+
+	assert.EqualError(t, (&transfer.Action{Outputs: action.Outputs}).Validate(), "invalid number of token inputs, expected at least 1")
+	assert.EqualError(t, (&transfer.Action{Inputs: action.Inputs}).Validate(), "invalid number of token outputs, expected at least 1")
+
+	input := *action.Inputs[0]
+	input.AnonymitySet = nil
+	invalid := &transfer.Action{Inputs: []*transfer.ActionInput{&input}, Outputs: action.Outputs}
+	assert.EqualError(t, invalid.Validate(), "invalid input's anonymity set at index [0], it is empty")
+
+	input = *action.Inputs[0]
+	input.Proof = nil
+	assert.EqualError(t, invalid.Validate(), "invalid input's proof at index [0], it is empty")
+
+	input = *action.Inputs[0]
+	input.Nullifier = nil
+	assert.EqualError(t, invalid.Validate(), "invalid input's nullifier at index [0], it is empty")
+}
+
+func TestSpender(t *testing.T) {
+	env := newEnvironment(t, 4)
+	sender, err := transfer.NewSender(env.inputs(0), env.pp)
+	assert.NoError(t, err)
+	action, _, err := sender.GenerateZKTransfer(context.Background(), []uint64{10}, [][]byte{nil}, []*token.SpendKey{nil})
+	assert.NoError(t, err)
+
+	spender := action.Inputs[0].Spender()
+	raw, err := spender.Bytes()
+	assert.NoError(t, err)
+	deserialized := &transfer.Spender{}
+	assert.NoError(t, deserialized.Deserialize(raw))
+	assert.Equal(t, spender, deserialized)
+	id, err := spender.Identity()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	// the spend audit info links the spender to the spend key of the owner
+	info := &transfer.SpendAuditInfo{
+		Owner:          env.metas[0].Owner,
+		OwnerAuditInfo: []byte("audit info"),
+		SpendKey:       env.metas[0].GetSpendKey(),
+		BlindingFactor: sender.Signers[0].BlindingFactor(),
+	}
+	assert.NoError(t, info.Match(spender, env.pp))
+	raw, err = info.Serialize()
+	assert.NoError(t, err)
+	deserializedInfo := &transfer.SpendAuditInfo{}
+	assert.NoError(t, deserializedInfo.Deserialize(raw))
+	assert.Equal(t, info, deserializedInfo)
+
+	// another blinding factor does not match
+	rand, err := env.curve.Rand()
+	assert.NoError(t, err)
+	info.BlindingFactor = env.curve.NewRandomZr(rand)
+	assert.EqualError(t, info.Match(spender, env.pp), "spender does not match the spend key of the owner")
+	info.SpendKey = nil
+	assert.EqualError(t, info.Match(spender, env.pp), "invalid spend audit info: nil elements")
+	_, err = info.Serialize()
+	assert.EqualError(t, err, "invalid spend audit info: nil spend key")
+}
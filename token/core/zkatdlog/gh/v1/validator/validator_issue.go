@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validator
+
+import (
+	nogh "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/issue"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/pkg/errors"
+)
+
+func IssueValidate(ctx *Context) error {
+	action := ctx.IssueAction
+
+	if err := action.Validate(); err != nil {
+		return errors.Wrapf(err, "failed validating issue action")
+	}
+	for i, output := range action.Outputs {
+		if err := output.Verify(ctx.PP); err != nil {
+			return errors.Wrapf(err, "invalid output at index [%d]", i)
+		}
+	}
+
+	commitments, err := action.GetCommitments()
+	if err != nil {
+		return errors.New("failed to verify issue")
+	}
+	if err := nogh.NewVerifier(
+		commitments,
+		ctx.PP.PublicParams).Verify(action.GetProof()); err != nil {
+		return err
+	}
+
+	// Check the issuer is among those known
+	issuers := ctx.PP.IssuerIDs
+	if len(issuers) != 0 && !driver.ContainsIssuer(issuers, action.Issuer) {
+		return errors.Errorf("issuer [%s] is not in issuers", driver.Identity(action.Issuer).String())
+	}
+
+	verifier, err := ctx.Deserializer.GetIssuerVerifier(action.Issuer)
+	if err != nil {
+		return errors.Wrapf(err, "failed getting verifier for issuer [%s]", action.Issuer.String())
+	}
+	if _, err := ctx.SignatureProvider.HasBeenSignedBy(action.Issuer, verifier); err != nil {
+		return errors.Wrapf(err, "failed verifying signature")
+	}
+	return nil
+}
@@ -0,0 +1,439 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validator_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	math "github.com/IBM/mathlib"
+	common2 "github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/issue"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/transfer"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/validator"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type ledger map[string][]byte
+
+func (l ledger) GetState(id token2.ID) ([]byte, error) {
+	return l[id.String()], nil
+}
+
+// readSet records the reads, like the read-write set of a transaction it does not support concurrent access.
+// It detects overlapping reads.
+type readSet struct {
+	ledger     ledger
+	reads      []string
+	inFlight   atomic.Int32
+	concurrent atomic.Bool
+}
+
+func (r *readSet) GetState(id token2.ID) ([]byte, error) {
+	if r.inFlight.Add(1) > 1 {
+		r.concurrent.Store(true)
+	}
+	defer r.inFlight.Add(-1)
+	// give the other workers the chance to overlap
+	time.Sleep(time.Millisecond)
+	r.reads = append(r.reads, id.String())
+	return r.ledger.GetState(id)
+}
+
+// message is the token request signed by the spenders
+var message = []byte("token request")
+
+// signatureProvider checks the signatures of the senders, in order
+type signatureProvider struct {
+	signatures [][]byte
+	signers    []driver.Identity
+}
+
+func (s *signatureProvider) HasBeenSignedBy(id driver.Identity, verifier driver.Verifier) ([]byte, error) {
+	if len(s.signatures) == 0 {
+		return nil, errors.Errorf("no signature from [%s]", id)
+	}
+	sigma := s.signatures[0]
+	s.signatures = s.signatures[1:]
+	if err := verifier.Verify(message, sigma); err != nil {
+		return nil, err
+	}
+	s.signers = append(s.signers, id)
+	return sigma, nil
+}
+
+func (s *signatureProvider) Signatures() [][]byte {
+	return nil
+}
+
+type deserializer struct {
+	driver.Deserializer
+}
+
+func (d *deserializer) GetOwnerVerifier(id driver.Identity) (driver.Verifier, error) {
+	if len(id) == 0 {
+		return nil, errors.New("empty identity")
+	}
+	return nil, nil
+}
+
+func (d *deserializer) GetIssuerVerifier(id driver.Identity) (driver.Verifier, error) {
+	return &issuerVerifier{}, nil
+}
+
+// issuerVerifier accepts the signatures of the issuer
+type issuerVerifier struct{}
+
+func (v *issuerVerifier) Verify(_, sigma []byte) error {
+	if string(sigma) != "issuer signature" {
+		return errors.New("invalid issuer signature")
+	}
+	return nil
+}
+
+// ownerSigner signs the spend keys of the owners
+type ownerSigner struct{}
+
+func (s *ownerSigner) Sign([]byte) ([]byte, error) {
+	return []byte("owner signature"), nil
+}
+
+type environment struct {
+	pp      *setup.PublicParams
+	ledger  ledger
+	ids     []*token2.ID
+	tokens  []*token.Token
+	metas   []*token.Metadata
+	secrets map[string]*math.Zr
+}
+
+// spendKeys returns the spend keys of the passed owners, nil for the redeemed outputs
+func (e *environment) spendKeys(t *testing.T, owners [][]byte) []*token.SpendKey {
+	curve := math.Curves[e.pp.Curve]
+	rand, err := curve.Rand()
+	assert.NoError(t, err)
+	keys := make([]*token.SpendKey, len(owners))
+	for i, owner := range owners {
+		if len(owner) == 0 {
+			continue
+		}
+		secret, ok := e.secrets[string(owner)]
+		if !ok {
+			secret = curve.NewRandomZr(rand)
+			e.secrets[string(owner)] = secret
+		}
+		keys[i], err = token.NewSpendKey(secret, &ownerSigner{}, e.pp)
+		assert.NoError(t, err)
+	}
+	return keys
+}
+
+// newEnvironment stores on the ledger tokens of value 10*(i+1), owned by alice
+func newEnvironment(t *testing.T, size int) *environment {
+	ipk, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := setup.Setup(32, ipk, math.BN254, 4)
+	assert.NoError(t, err)
+	pp.IssuerIDs = []driver.Identity{[]byte("issuer")}
+
+	values := make([]uint64, size)
+	owners := make([][]byte, size)
+	for i := range values {
+		values[i] = uint64(10 * (i + 1))
+		owners[i] = []byte("alice")
+	}
+	env := &environment{pp: pp, ledger: ledger{}, secrets: map[string]*math.Zr{}}
+	outputs, metas, _, err := token.NewOutputs(values, owners, env.spendKeys(t, owners), "ABC", pp)
+	assert.NoError(t, err)
+	env.metas = metas
+	for i, output := range outputs {
+		id := &token2.ID{TxId: "issue", Index: uint64(i)}
+		raw, err := output.Serialize()
+		assert.NoError(t, err)
+		env.ledger[id.String()] = raw
+		env.ids = append(env.ids, id)
+		env.tokens = append(env.tokens, output.Token)
+	}
+	return env
+}
+
+// transfer spends the tokens at the passed indices, each hidden among the first ledger tokens.
+// It returns the action and the signatures of its spenders.
+func (e *environment) transfer(t *testing.T, indices []int, values []uint64, owners [][]byte) (*transfer.Action, *signatureProvider) {
+	size := e.pp.AnonymitySetSize
+	inputs := make([]*transfer.Input, len(indices))
+	for i, index := range indices {
+		inputs[i] = &transfer.Input{
+			ID:              e.ids[index],
+			Metadata:        e.metas[index],
+			AnonymitySet:    e.ids[:size],
+			AnonymityTokens: e.tokens[:size],
+			SpendSecret:     e.secrets[string(e.metas[index].Owner)],
+		}
+	}
+	sender, err := transfer.NewSender(inputs, e.pp)
+	assert.NoError(t, err)
+	action, _, err := sender.GenerateZKTransfer(context.Background(), values, owners, e.spendKeys(t, owners))
+	assert.NoError(t, err)
+	signatures, err := sender.SignTokenActions(message)
+	assert.NoError(t, err)
+
+	// go through the serialization, as the validators do
+	raw, err := action.Serialize()
+	assert.NoError(t, err)
+	action = &transfer.Action{}
+	assert.NoError(t, action.Deserialize(raw))
+	return action, &signatureProvider{signatures: signatures}
+}
+
+func (e *environment) verify(action *transfer.Action, sp *signatureProvider) error {
+	ctx := &validator.Context{
+		Logger:            logging.MustGetLogger("validator"),
+		PP:                e.pp,
+		Deserializer:      &deserializer{},
+		TransferAction:    action,
+		Ledger:            e.ledger,
+		SignatureProvider: sp,
+	}
+	for _, validate := range []validator.ValidateTransferFunc{
+		validator.TransferActionValidate,
+		validator.TransferOutputsValidate,
+		validator.TransferMembershipValidate,
+		validator.TransferSignatureValidate,
+		validator.TransferZKProofValidate,
+	} {
+		if err := validate(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestTransfer(t *testing.T) {
+	env := newEnvironment(t, 5)
+
+	action, sp := env.transfer(t, []int{0, 2}, []uint64{15, 25}, [][]byte{[]byte("bob"), []byte("alice")})
+	assert.NoError(t, env.verify(action, sp))
+
+	// the spenders sign in place of the owner, they do not disclose it and cannot be linked to each other
+	assert.Len(t, sp.signers, 2)
+	for i, signer := range sp.signers {
+		assert.NotContains(t, string(signer), "alice")
+		expected, err := action.Inputs[i].Spender().Identity()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, signer)
+	}
+	assert.False(t, action.Inputs[0].SpendKey.Equals(action.Inputs[1].SpendKey))
+
+	// the inputs are not disclosed, the nullifiers are
+	assert.Nil(t, action.GetInputs())
+	assert.Len(t, action.GetSerialNumbers(), 2)
+	assert.NotEqual(t, action.GetSerialNumbers()[0], action.GetSerialNumbers()[1])
+
+	// spending the same token again gives the same nullifier
+	again, _ := env.transfer(t, []int{0}, []uint64{10}, [][]byte{[]byte("bob")})
+	assert.Equal(t, action.GetSerialNumbers()[0], again.GetSerialNumbers()[0])
+
+	// the signatures of another transfer of the same tokens are not valid
+	_, other := env.transfer(t, []int{0, 2}, []uint64{15, 25}, [][]byte{[]byte("bob"), []byte("alice")})
+	assert.ErrorContains(t, env.verify(action, other), "failed signature verification [0]")
+
+	// the recipients can open their outputs
+	outputs, err := action.GetSerializedOutputs()
+	assert.NoError(t, err)
+	assert.Len(t, outputs, 2)
+
+	// a redeem
+	action, sp = env.transfer(t, []int{1}, []uint64{5, 15}, [][]byte{nil, []byte("alice")})
+	assert.NoError(t, env.verify(action, sp))
+	assert.True(t, action.IsRedeemAt(0))
+	assert.False(t, action.IsRedeemAt(1))
+}
+
+func TestTransferFailures(t *testing.T) {
+	env := newEnvironment(t, 5)
+	curve := math.Curves[env.pp.Curve]
+
+	tests := []struct {
+		name   string
+		tamper func(action *transfer.Action)
+		err    string
+	}{
+		{
+			name: "unbalanced",
+			tamper: func(action *transfer.Action) {
+				other, _ := env.transfer(t, []int{0}, []uint64{11}, [][]byte{[]byte("bob")})
+				action.Outputs = other.Outputs
+			},
+			err: "invalid sum and type proof",
+		},
+		{
+			name: "another serial number",
+			tamper: func(action *transfer.Action) {
+				action.Inputs[0].SerialNumber = math.Curves[env.pp.Curve].NewZrFromInt(1)
+			},
+			err: "invalid membership proof for input [0]",
+		},
+		{
+			name: "another spend key",
+			tamper: func(action *transfer.Action) {
+				action.Inputs[0].SpendKey = curve.GenG1.Mul(curve.NewZrFromInt(7))
+			},
+			err: "invalid membership proof for input [0]",
+		},
+		{
+			name: "another nullifier",
+			tamper: func(action *transfer.Action) {
+				action.Inputs[0].Nullifier = curve.GenG1.Mul(curve.NewZrFromInt(7))
+			},
+			// the nullifier is bound to the membership proof
+			err: "invalid membership proof for input [0]",
+		},
+		{
+			name: "token not in the anonymity set",
+			tamper: func(action *transfer.Action) {
+				action.Inputs[0].AnonymitySet = env.ids[1:]
+			},
+			err: "invalid membership proof for input [0]",
+		},
+		{
+			name: "unknown token",
+			tamper: func(action *transfer.Action) {
+				action.Inputs[0].AnonymitySet[1] = &token2.ID{TxId: "unknown"}
+			},
+			err: "token [[unknown:0]] of the anonymity set of input [0] does not exist",
+		},
+		{
+			name: "duplicate token",
+			tamper: func(action *transfer.Action) {
+				action.Inputs[0].AnonymitySet[1] = action.Inputs[0].AnonymitySet[0]
+			},
+			err: "appears more than once in the anonymity set",
+		},
+		{
+			name: "anonymity set too large",
+			tamper: func(action *transfer.Action) {
+				for i := 0; i < 4; i++ {
+					action.Inputs[0].AnonymitySet = append(action.Inputs[0].AnonymitySet, &token2.ID{TxId: fmt.Sprintf("tx%d", i)})
+				}
+			},
+			err: "the anonymity set has [8] tokens, exactly [4] are required",
+		},
+		{
+			name: "anonymity set too small",
+			tamper: func(action *transfer.Action) {
+				action.Inputs[0].AnonymitySet = action.Inputs[0].AnonymitySet[:1]
+			},
+			err: "the anonymity set has [1] tokens, exactly [4] are required",
+		},
+		{
+			name: "redeemed output in the anonymity set",
+			tamper: func(action *transfer.Action) {
+				// a redeemed output is stored as its commitment to type and value, it has no spend key
+				raw, err := (&token.Token{Data: env.tokens[4].Data}).Serialize()
+				assert.NoError(t, err)
+				env.ledger[(&token2.ID{TxId: "redeem"}).String()] = raw
+				action.Inputs[0].AnonymitySet[3] = &token2.ID{TxId: "redeem"}
+			},
+			err: "token at index [3] cannot be spent",
+		},
+		{
+			name: "double spending",
+			tamper: func(action *transfer.Action) {
+				action.Inputs = append(action.Inputs, action.Inputs[0])
+			},
+			err: "invalid input at index [1], the token is spent twice",
+		},
+		{
+			name: "output not linked",
+			tamper: func(action *transfer.Action) {
+				action.Outputs[0].Token, action.Outputs[1].Token = action.Outputs[1].Token, action.Outputs[0].Token
+			},
+			err: "invalid output at index [0]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, sp := env.transfer(t, []int{0}, []uint64{4, 6}, [][]byte{[]byte("bob"), []byte("alice")})
+			tt.tamper(action)
+			err := env.verify(action, sp)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+// TestConcurrentValidation verifies a request with several transfers with a pool of workers.
+// Run it with -race, the workers share the ledger.
+func TestConcurrentValidation(t *testing.T) {
+	env := newEnvironment(t, 5)
+
+	tr := &driver.TokenRequest{}
+	var signatures [][]byte
+	for _, index := range []int{0, 1, 2} {
+		action, sp := env.transfer(t, []int{index}, []uint64{uint64(10 * (index + 1))}, [][]byte{[]byte("bob")})
+		raw, err := action.Serialize()
+		assert.NoError(t, err)
+		tr.Transfers = append(tr.Transfers, raw)
+		signatures = append(signatures, sp.signatures...)
+	}
+
+	v := validator.New(logging.MustGetLogger("validator"), env.pp, &deserializer{})
+	v.Workers = 4
+	rs := &readSet{ledger: env.ledger}
+	backend := common2.NewBackend(logging.MustGetLogger("validator"), rs.GetState, message, signatures)
+	actions, _, err := v.VerifyTokenRequest(context.Background(), rs, backend, "tx", tr, driver.ValidationAttributes{})
+	assert.NoError(t, err)
+	assert.Len(t, actions, 3)
+	// each transfer reads its anonymity set
+	assert.Len(t, rs.reads, 3*int(env.pp.AnonymitySetSize))
+	assert.False(t, rs.concurrent.Load(), "the ledger has been accessed concurrently")
+
+	// a signature of another action is not valid
+	backend = common2.NewBackend(logging.MustGetLogger("validator"), rs.GetState, message, [][]byte{signatures[0], signatures[0], signatures[2]})
+	_, _, err = v.VerifyTokenRequest(context.Background(), rs, backend, "tx", tr, driver.ValidationAttributes{})
+	assert.ErrorContains(t, err, "failed to verify transfer action at [1]")
+}
+
+func TestIssue(t *testing.T) {
+	env := newEnvironment(t, 0)
+
+	issuer := &issue.Issuer{}
+	issuer.New("ABC", &common.WrappedSigningIdentity{Identity: []byte("issuer")}, env.pp)
+	owners := [][]byte{[]byte("alice"), []byte("bob")}
+	action, metas, err := issuer.GenerateZKIssue([]uint64{10, 20}, owners, env.spendKeys(t, owners))
+	assert.NoError(t, err)
+	assert.Len(t, metas, 2)
+	for i, output := range action.Outputs {
+		tok, err := output.Token.ToClear(metas[i], env.pp)
+		assert.NoError(t, err)
+		assert.Equal(t, token2.Type("ABC"), tok.Type)
+	}
+
+	ctx := &validator.Context{
+		Logger:            logging.MustGetLogger("validator"),
+		PP:                env.pp,
+		Deserializer:      &deserializer{},
+		IssueAction:       action,
+		SignatureProvider: &signatureProvider{signatures: [][]byte{[]byte("issuer signature")}},
+	}
+	assert.NoError(t, validator.IssueValidate(ctx))
+
+	env.pp.IssuerIDs = []driver.Identity{[]byte("another issuer")}
+	assert.ErrorContains(t, validator.IssueValidate(ctx), "is not in issuers")
+}
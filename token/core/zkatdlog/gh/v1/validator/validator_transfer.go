@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validator
+
+import (
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/gh/v1/transfer"
+	nogh "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/transfer"
+	"github.com/pkg/errors"
+)
+
+// TransferActionValidate validates the transfer action
+func TransferActionValidate(ctx *Context) error {
+	return ctx.TransferAction.Validate()
+}
+
+// TransferOutputsValidate checks that each output token hides the type and value of its commitment
+func TransferOutputsValidate(ctx *Context) error {
+	for i, output := range ctx.TransferAction.Outputs {
+		if err := output.Verify(ctx.PP); err != nil {
+			return errors.Wrapf(err, "invalid output at index [%d]", i)
+		}
+	}
+	return nil
+}
+
+// TransferMembershipValidate checks that each input spends a token of its anonymity set.
+// The tokens of the anonymity set are read from the ledger.
+func TransferMembershipValidate(ctx *Context) error {
+	for i, in := range ctx.TransferAction.Inputs {
+		// a smaller anonymity set would weaken the privacy of the sender
+		if uint64(len(in.AnonymitySet)) != ctx.PP.AnonymitySetSize {
+			return errors.Errorf("invalid input at index [%d], the anonymity set has [%d] tokens, exactly [%d] are required", i, len(in.AnonymitySet), ctx.PP.AnonymitySetSize)
+		}
+		seen := map[string]struct{}{}
+		tokens := make([]*token.Token, len(in.AnonymitySet))
+		for j, id := range in.AnonymitySet {
+			if _, ok := seen[id.String()]; ok {
+				return errors.Errorf("invalid input at index [%d], token [%s] appears more than once in the anonymity set", i, id)
+			}
+			seen[id.String()] = struct{}{}
+
+			raw, err := ctx.Ledger.GetState(*id)
+			if err != nil {
+				return errors.Wrapf(err, "failed to retrieve token [%s] of the anonymity set of input [%d]", id, i)
+			}
+			if len(raw) == 0 {
+				return errors.Errorf("token [%s] of the anonymity set of input [%d] does not exist", id, i)
+			}
+			tok := &token.Token{}
+			if err := tok.Deserialize(raw); err != nil {
+				return errors.Wrapf(err, "failed to deserialize token [%s] of the anonymity set of input [%d]", id, i)
+			}
+			tokens[j] = tok
+		}
+		if err := transfer.VerifyMembership(tokens, in, ctx.PP); err != nil {
+			return errors.Wrapf(err, "invalid membership proof for input [%d]", i)
+		}
+	}
+	return nil
+}
+
+// TransferSignatureValidate checks that the spenders of the inputs signed the request.
+// The signature of a spender proves knowledge of the spend secret behind its nullifier and spend key,
+// then only the owner of the spent token can produce it.
+func TransferSignatureValidate(ctx *Context) error {
+	// recall that TransferActionValidate has been called before this function
+	var signatures [][]byte
+	for i, in := range ctx.TransferAction.Inputs {
+		spender := in.Spender()
+		id, err := spender.Identity()
+		if err != nil {
+			return errors.Wrapf(err, "failed getting spender [%d]", i)
+		}
+		ctx.Logger.Debugf("check sender [%d][%s]", i, id.UniqueID())
+		sigma, err := ctx.SignatureProvider.HasBeenSignedBy(id, transfer.NewSpendVerifier(spender, ctx.PP))
+		if err != nil {
+			return errors.Wrapf(err, "failed signature verification [%d][%s]", i, id)
+		}
+		signatures = append(signatures, sigma)
+	}
+	ctx.Signatures = signatures
+	return nil
+}
+
+// TransferZKProofValidate checks that inputs and outputs have the same type and that the values balance
+func TransferZKProofValidate(ctx *Context) error {
+	return nogh.NewVerifier(
+		ctx.TransferAction.GetInputCommitments(),
+		ctx.TransferAction.GetOutputCommitments(),
+		ctx.PP.PublicParams,
+	).Verify(ctx.TransferAction.GetProof())
+}
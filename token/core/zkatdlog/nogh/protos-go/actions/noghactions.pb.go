@@ -422,6 +422,69 @@ func (x *Proof) GetProof() []byte {
 	return nil
 }
 
+type TransferActionFee struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Policy int32  `protobuf:"varint,1,opt,name=policy,proto3" json:"policy,omitempty"` // is the index of the fee policy applying to the type of the inputs and outputs, -1 if none does
+	Value  uint64 `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`   // is the total value of the outputs owned by the fee collector
+	Proof  []byte `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`    // shows that policy and value are correct, and that the fee covers the payments, without revealing the type
+}
+
+func (x *TransferActionFee) Reset() {
+	*x = TransferActionFee{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_noghactions_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferActionFee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferActionFee) ProtoMessage() {}
+
+func (x *TransferActionFee) ProtoReflect() protoreflect.Message {
+	mi := &file_noghactions_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferActionFee.ProtoReflect.Descriptor instead.
+func (*TransferActionFee) Descriptor() ([]byte, []int) {
+	return file_noghactions_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TransferActionFee) GetPolicy() int32 {
+	if x != nil {
+		return x.Policy
+	}
+	return 0
+}
+
+func (x *TransferActionFee) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *TransferActionFee) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
 type TransferAction struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -432,12 +495,14 @@ type TransferAction struct {
 	Outputs  []*TransferActionOutput `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`                                                                                           // outputs
 	Proof    *Proof                  `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof,omitempty"`                                                                                               // ZK Proof that shows that the transfer is correct
 	Metadata map[string][]byte       `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Metadata contains the transfer action's metadata
+	Fee      *TransferActionFee      `protobuf:"bytes,6,opt,name=fee,proto3" json:"fee,omitempty"`                                                                                                   // discloses the fee policy and the fee of the transfer, when the public parameters charge fees
+	Enforcer *pp.Identity            `protobuf:"bytes,7,opt,name=enforcer,proto3" json:"enforcer,omitempty"`                                                                                         // is the enforcer that forces the transfer, if any. It signs in place of the owners of the inputs
 }
 
 func (x *TransferAction) Reset() {
 	*x = TransferAction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[7]
+		mi := &file_noghactions_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -450,7 +515,7 @@ func (x *TransferAction) String() string {
 func (*TransferAction) ProtoMessage() {}
 
 func (x *TransferAction) ProtoReflect() protoreflect.Message {
-	mi := &file_noghactions_proto_msgTypes[7]
+	mi := &file_noghactions_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -463,7 +528,7 @@ func (x *TransferAction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransferAction.ProtoReflect.Descriptor instead.
 func (*TransferAction) Descriptor() ([]byte, []int) {
-	return file_noghactions_proto_rawDescGZIP(), []int{7}
+	return file_noghactions_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *TransferAction) GetVersion() uint64 {
@@ -501,6 +566,20 @@ func (x *TransferAction) GetMetadata() map[string][]byte {
 	return nil
 }
 
+func (x *TransferAction) GetFee() *TransferActionFee {
+	if x != nil {
+		return x.Fee
+	}
+	return nil
+}
+
+func (x *TransferAction) GetEnforcer() *pp.Identity {
+	if x != nil {
+		return x.Enforcer
+	}
+	return nil
+}
+
 type IssueActionInput struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -513,7 +592,7 @@ type IssueActionInput struct {
 func (x *IssueActionInput) Reset() {
 	*x = IssueActionInput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[8]
+		mi := &file_noghactions_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -526,7 +605,7 @@ func (x *IssueActionInput) String() string {
 func (*IssueActionInput) ProtoMessage() {}
 
 func (x *IssueActionInput) ProtoReflect() protoreflect.Message {
-	mi := &file_noghactions_proto_msgTypes[8]
+	mi := &file_noghactions_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -539,7 +618,7 @@ func (x *IssueActionInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IssueActionInput.ProtoReflect.Descriptor instead.
 func (*IssueActionInput) Descriptor() ([]byte, []int) {
-	return file_noghactions_proto_rawDescGZIP(), []int{8}
+	return file_noghactions_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *IssueActionInput) GetId() *TokenID {
@@ -567,7 +646,7 @@ type IssueActionOutput struct {
 func (x *IssueActionOutput) Reset() {
 	*x = IssueActionOutput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[9]
+		mi := &file_noghactions_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -580,7 +659,7 @@ func (x *IssueActionOutput) String() string {
 func (*IssueActionOutput) ProtoMessage() {}
 
 func (x *IssueActionOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_noghactions_proto_msgTypes[9]
+	mi := &file_noghactions_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -593,7 +672,7 @@ func (x *IssueActionOutput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IssueActionOutput.ProtoReflect.Descriptor instead.
 func (*IssueActionOutput) Descriptor() ([]byte, []int) {
-	return file_noghactions_proto_rawDescGZIP(), []int{9}
+	return file_noghactions_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *IssueActionOutput) GetToken() *Token {
@@ -603,23 +682,79 @@ func (x *IssueActionOutput) GetToken() *Token {
 	return nil
 }
 
+type TypeOpening struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type           string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                                           // is the type of the issued tokens
+	BlindingFactor *math.Zr `protobuf:"bytes,2,opt,name=blinding_factor,json=blindingFactor,proto3" json:"blinding_factor,omitempty"` // is the blinding factor of the commitment to type in the issue proof
+}
+
+func (x *TypeOpening) Reset() {
+	*x = TypeOpening{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_noghactions_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TypeOpening) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeOpening) ProtoMessage() {}
+
+func (x *TypeOpening) ProtoReflect() protoreflect.Message {
+	mi := &file_noghactions_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeOpening.ProtoReflect.Descriptor instead.
+func (*TypeOpening) Descriptor() ([]byte, []int) {
+	return file_noghactions_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TypeOpening) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TypeOpening) GetBlindingFactor() *math.Zr {
+	if x != nil {
+		return x.BlindingFactor
+	}
+	return nil
+}
+
 type IssueAction struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Version  uint64               `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
-	Issuer   *pp.Identity         `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`                                                                                             // is the identity of issuer
-	Inputs   []*IssueActionInput  `protobuf:"bytes,3,rep,name=inputs,proto3" json:"inputs,omitempty"`                                                                                             // are the tokens to be redeemed by this issue action
-	Outputs  []*IssueActionOutput `protobuf:"bytes,4,rep,name=outputs,proto3" json:"outputs,omitempty"`                                                                                           // are the newly issued tokens
-	Proof    *Proof               `protobuf:"bytes,5,opt,name=proof,proto3" json:"proof,omitempty"`                                                                                               // carries the ZKP of IssueAction validity
-	Metadata map[string][]byte    `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Metadata of the issue action
+	Version     uint64               `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Issuer      *pp.Identity         `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`                                                                                             // is the identity of issuer
+	Inputs      []*IssueActionInput  `protobuf:"bytes,3,rep,name=inputs,proto3" json:"inputs,omitempty"`                                                                                             // are the tokens to be redeemed by this issue action
+	Outputs     []*IssueActionOutput `protobuf:"bytes,4,rep,name=outputs,proto3" json:"outputs,omitempty"`                                                                                           // are the newly issued tokens
+	Proof       *Proof               `protobuf:"bytes,5,opt,name=proof,proto3" json:"proof,omitempty"`                                                                                               // carries the ZKP of IssueAction validity
+	Metadata    map[string][]byte    `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Metadata of the issue action
+	TypeOpening *TypeOpening         `protobuf:"bytes,7,opt,name=type_opening,json=typeOpening,proto3" json:"type_opening,omitempty"`                                                                // discloses the type of the issued tokens, when required by the public parameters
 }
 
 func (x *IssueAction) Reset() {
 	*x = IssueAction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[10]
+		mi := &file_noghactions_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -632,7 +767,7 @@ func (x *IssueAction) String() string {
 func (*IssueAction) ProtoMessage() {}
 
 func (x *IssueAction) ProtoReflect() protoreflect.Message {
-	mi := &file_noghactions_proto_msgTypes[10]
+	mi := &file_noghactions_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -645,7 +780,7 @@ func (x *IssueAction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IssueAction.ProtoReflect.Descriptor instead.
 func (*IssueAction) Descriptor() ([]byte, []int) {
-	return file_noghactions_proto_rawDescGZIP(), []int{10}
+	return file_noghactions_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *IssueAction) GetVersion() uint64 {
@@ -690,6 +825,13 @@ func (x *IssueAction) GetMetadata() map[string][]byte {
 	return nil
 }
 
+func (x *IssueAction) GetTypeOpening() *TypeOpening {
+	if x != nil {
+		return x.TypeOpening
+	}
+	return nil
+}
+
 var File_noghactions_proto protoreflect.FileDescriptor
 
 var file_noghactions_proto_rawDesc = []byte{
@@ -739,23 +881,34 @@ var file_noghactions_proto_rawDesc = []byte{
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x54, 0x6f,
 	0x6b, 0x65, 0x6e, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x1d, 0x0a, 0x05, 0x50, 0x72,
 	0x6f, 0x6f, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0xb3, 0x02, 0x0a, 0x0e, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07,
-	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x70, 0x75,
-	0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x6f, 0x75, 0x74,
-	0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e, 0x6f, 0x67,
-	0x68, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12,
-	0x21, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b,
-	0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f,
-	0x6f, 0x66, 0x12, 0x3e, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x54, 0x72, 0x61, 0x6e,
-	0x73, 0x66, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e,
+	0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x57, 0x0a, 0x11, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x22, 0x8a, 0x03, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x31, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52,
+	0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x3e, 0x0a, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x29, 0x0a, 0x03, 0x66,
+	0x65, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65,
+	0x65, 0x52, 0x03, 0x66, 0x65, 0x65, 0x12, 0x2a, 0x0a, 0x08, 0x65, 0x6e, 0x66, 0x6f, 0x72, 0x63,
+	0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x08, 0x65, 0x6e, 0x66, 0x6f, 0x72, 0x63,
+	0x65, 0x72, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e,
 	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
@@ -767,34 +920,43 @@ var file_noghactions_proto_rawDesc = []byte{
 	0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x21, 0x0a,
 	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6e,
 	0x6f, 0x67, 0x68, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x22, 0xcf, 0x02, 0x0a, 0x0b, 0x49, 0x73, 0x73, 0x75, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x06, 0x69, 0x73,
-	0x73, 0x75, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67,
-	0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75,
-	0x65, 0x72, 0x12, 0x2e, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75,
-	0x74, 0x73, 0x12, 0x31, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65,
-	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x50, 0x72, 0x6f, 0x6f,
-	0x66, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x3b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x6f, 0x67,
-	0x68, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x42, 0x59, 0x5a, 0x57, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62,
-	0x73, 0x2f, 0x66, 0x61, 0x62, 0x72, 0x69, 0x63, 0x2d, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2d, 0x73,
-	0x64, 0x6b, 0x2f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x7a, 0x6b,
-	0x61, 0x74, 0x64, 0x6c, 0x6f, 0x67, 0x2f, 0x6e, 0x6f, 0x67, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2d, 0x67, 0x6f, 0x2f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x22, 0x54, 0x0a, 0x0b, 0x54, 0x79, 0x70, 0x65, 0x4f, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x31, 0x0a, 0x0f, 0x62, 0x6c, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f,
+	0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x6e,
+	0x6f, 0x67, 0x68, 0x2e, 0x5a, 0x72, 0x52, 0x0e, 0x62, 0x6c, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x85, 0x03, 0x0a, 0x0b, 0x49, 0x73, 0x73, 0x75, 0x65,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x26, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e,
+	0x49, 0x73, 0x73, 0x75, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6e, 0x6f, 0x67, 0x68,
+	0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6e, 0x6f, 0x67,
+	0x68, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x3b,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x34, 0x0a, 0x0c, 0x74,
+	0x79, 0x70, 0x65, 0x5f, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x4f, 0x70, 0x65,
+	0x6e, 0x69, 0x6e, 0x67, 0x52, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x4f, 0x70, 0x65, 0x6e, 0x69, 0x6e,
+	0x67, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x59,
+	0x5a, 0x57, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70,
+	0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x66, 0x61,
+	0x62, 0x72, 0x69, 0x63, 0x2d, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2d, 0x73, 0x64, 0x6b, 0x2f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x7a, 0x6b, 0x61, 0x74, 0x64, 0x6c,
+	0x6f, 0x67, 0x2f, 0x6e, 0x6f, 0x67, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2d, 0x67,
+	0x6f, 0x2f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -809,7 +971,7 @@ func file_noghactions_proto_rawDescGZIP() []byte {
 	return file_noghactions_proto_rawDescData
 }
 
-var file_noghactions_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_noghactions_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_noghactions_proto_goTypes = []interface{}{
 	(*Token)(nil),                             // 0: nogh.Token
 	(*TokenMetadata)(nil),                     // 1: nogh.TokenMetadata
@@ -818,44 +980,50 @@ var file_noghactions_proto_goTypes = []interface{}{
 	(*TransferActionInputUpgradeWitness)(nil), // 4: nogh.TransferActionInputUpgradeWitness
 	(*TransferActionOutput)(nil),              // 5: nogh.TransferActionOutput
 	(*Proof)(nil),                             // 6: nogh.Proof
-	(*TransferAction)(nil),                    // 7: nogh.TransferAction
-	(*IssueActionInput)(nil),                  // 8: nogh.IssueActionInput
-	(*IssueActionOutput)(nil),                 // 9: nogh.IssueActionOutput
-	(*IssueAction)(nil),                       // 10: nogh.IssueAction
-	nil,                                       // 11: nogh.TransferAction.MetadataEntry
-	nil,                                       // 12: nogh.IssueAction.MetadataEntry
-	(*math.G1)(nil),                           // 13: nogh.G1
-	(*math.Zr)(nil),                           // 14: nogh.Zr
-	(*pp.Identity)(nil),                       // 15: nogh.Identity
-	(*actions.Token)(nil),                     // 16: fabtoken.Token
+	(*TransferActionFee)(nil),                 // 7: nogh.TransferActionFee
+	(*TransferAction)(nil),                    // 8: nogh.TransferAction
+	(*IssueActionInput)(nil),                  // 9: nogh.IssueActionInput
+	(*IssueActionOutput)(nil),                 // 10: nogh.IssueActionOutput
+	(*TypeOpening)(nil),                       // 11: nogh.TypeOpening
+	(*IssueAction)(nil),                       // 12: nogh.IssueAction
+	nil,                                       // 13: nogh.TransferAction.MetadataEntry
+	nil,                                       // 14: nogh.IssueAction.MetadataEntry
+	(*math.G1)(nil),                           // 15: nogh.G1
+	(*math.Zr)(nil),                           // 16: nogh.Zr
+	(*pp.Identity)(nil),                       // 17: nogh.Identity
+	(*actions.Token)(nil),                     // 18: fabtoken.Token
 }
 var file_noghactions_proto_depIdxs = []int32{
-	13, // 0: nogh.Token.data:type_name -> nogh.G1
-	14, // 1: nogh.TokenMetadata.value:type_name -> nogh.Zr
-	14, // 2: nogh.TokenMetadata.blinding_factor:type_name -> nogh.Zr
-	15, // 3: nogh.TokenMetadata.issuer:type_name -> nogh.Identity
+	15, // 0: nogh.Token.data:type_name -> nogh.G1
+	16, // 1: nogh.TokenMetadata.value:type_name -> nogh.Zr
+	16, // 2: nogh.TokenMetadata.blinding_factor:type_name -> nogh.Zr
+	17, // 3: nogh.TokenMetadata.issuer:type_name -> nogh.Identity
 	2,  // 4: nogh.TransferActionInput.token_id:type_name -> nogh.TokenID
 	0,  // 5: nogh.TransferActionInput.input:type_name -> nogh.Token
 	4,  // 6: nogh.TransferActionInput.upgrade_witness:type_name -> nogh.TransferActionInputUpgradeWitness
-	16, // 7: nogh.TransferActionInputUpgradeWitness.output:type_name -> fabtoken.Token
-	14, // 8: nogh.TransferActionInputUpgradeWitness.blinding_factor:type_name -> nogh.Zr
+	18, // 7: nogh.TransferActionInputUpgradeWitness.output:type_name -> fabtoken.Token
+	16, // 8: nogh.TransferActionInputUpgradeWitness.blinding_factor:type_name -> nogh.Zr
 	0,  // 9: nogh.TransferActionOutput.token:type_name -> nogh.Token
 	3,  // 10: nogh.TransferAction.inputs:type_name -> nogh.TransferActionInput
 	5,  // 11: nogh.TransferAction.outputs:type_name -> nogh.TransferActionOutput
 	6,  // 12: nogh.TransferAction.proof:type_name -> nogh.Proof
-	11, // 13: nogh.TransferAction.metadata:type_name -> nogh.TransferAction.MetadataEntry
-	2,  // 14: nogh.IssueActionInput.id:type_name -> nogh.TokenID
-	0,  // 15: nogh.IssueActionOutput.token:type_name -> nogh.Token
-	15, // 16: nogh.IssueAction.issuer:type_name -> nogh.Identity
-	8,  // 17: nogh.IssueAction.inputs:type_name -> nogh.IssueActionInput
-	9,  // 18: nogh.IssueAction.outputs:type_name -> nogh.IssueActionOutput
-	6,  // 19: nogh.IssueAction.proof:type_name -> nogh.Proof
-	12, // 20: nogh.IssueAction.metadata:type_name -> nogh.IssueAction.MetadataEntry
-	21, // [21:21] is the sub-list for method output_type
-	21, // [21:21] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	13, // 13: nogh.TransferAction.metadata:type_name -> nogh.TransferAction.MetadataEntry
+	7,  // 14: nogh.TransferAction.fee:type_name -> nogh.TransferActionFee
+	17, // 15: nogh.TransferAction.enforcer:type_name -> nogh.Identity
+	2,  // 16: nogh.IssueActionInput.id:type_name -> nogh.TokenID
+	0,  // 17: nogh.IssueActionOutput.token:type_name -> nogh.Token
+	16, // 18: nogh.TypeOpening.blinding_factor:type_name -> nogh.Zr
+	17, // 19: nogh.IssueAction.issuer:type_name -> nogh.Identity
+	9,  // 20: nogh.IssueAction.inputs:type_name -> nogh.IssueActionInput
+	10, // 21: nogh.IssueAction.outputs:type_name -> nogh.IssueActionOutput
+	6,  // 22: nogh.IssueAction.proof:type_name -> nogh.Proof
+	14, // 23: nogh.IssueAction.metadata:type_name -> nogh.IssueAction.MetadataEntry
+	11, // 24: nogh.IssueAction.type_opening:type_name -> nogh.TypeOpening
+	25, // [25:25] is the sub-list for method output_type
+	25, // [25:25] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_noghactions_proto_init() }
@@ -949,7 +1117,7 @@ func file_noghactions_proto_init() {
 			}
 		}
 		file_noghactions_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TransferAction); i {
+			switch v := v.(*TransferActionFee); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -961,7 +1129,7 @@ func file_noghactions_proto_init() {
 			}
 		}
 		file_noghactions_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IssueActionInput); i {
+			switch v := v.(*TransferAction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -973,7 +1141,7 @@ func file_noghactions_proto_init() {
 			}
 		}
 		file_noghactions_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IssueActionOutput); i {
+			switch v := v.(*IssueActionInput); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -985,6 +1153,30 @@ func file_noghactions_proto_init() {
 			}
 		}
 		file_noghactions_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IssueActionOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_noghactions_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TypeOpening); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_noghactions_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*IssueAction); i {
 			case 0:
 				return &v.state
@@ -1003,7 +1195,7 @@ func file_noghactions_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_noghactions_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
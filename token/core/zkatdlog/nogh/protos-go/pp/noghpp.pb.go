@@ -216,6 +216,134 @@ func (x *RangeProofParams) GetNumberOfRounds() uint64 {
 	return 0
 }
 
+// IssuerPolicy restricts the issuers of the token types matching a pattern
+type IssuerPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TokenType string      `protobuf:"bytes,1,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"` // is a token type, or a prefix of token types followed by '*'
+	Issuers   []*Identity `protobuf:"bytes,2,rep,name=issuers,proto3" json:"issuers,omitempty"`                      // are the public keys of the entities that can issue the matching token types
+}
+
+func (x *IssuerPolicy) Reset() {
+	*x = IssuerPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_noghpp_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IssuerPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssuerPolicy) ProtoMessage() {}
+
+func (x *IssuerPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_noghpp_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssuerPolicy.ProtoReflect.Descriptor instead.
+func (*IssuerPolicy) Descriptor() ([]byte, []int) {
+	return file_noghpp_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *IssuerPolicy) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *IssuerPolicy) GetIssuers() []*Identity {
+	if x != nil {
+		return x.Issuers
+	}
+	return nil
+}
+
+// FeePolicy charges a fee on the transfers of the token types matching a pattern
+type FeePolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TokenType string    `protobuf:"bytes,1,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"` // is a token type, or a prefix of token types followed by '*'
+	Flat      uint64    `protobuf:"varint,2,opt,name=flat,proto3" json:"flat,omitempty"`                           // is the fee charged on each transfer with at least one payment
+	Rate      uint64    `protobuf:"varint,3,opt,name=rate,proto3" json:"rate,omitempty"`                           // is the fee charged on the value of the payments, in basis points
+	Collector *Identity `protobuf:"bytes,4,opt,name=collector,proto3" json:"collector,omitempty"`                  // is the owner of the fee outputs
+}
+
+func (x *FeePolicy) Reset() {
+	*x = FeePolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_noghpp_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeePolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeePolicy) ProtoMessage() {}
+
+func (x *FeePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_noghpp_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeePolicy.ProtoReflect.Descriptor instead.
+func (*FeePolicy) Descriptor() ([]byte, []int) {
+	return file_noghpp_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FeePolicy) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *FeePolicy) GetFlat() uint64 {
+	if x != nil {
+		return x.Flat
+	}
+	return 0
+}
+
+func (x *FeePolicy) GetRate() uint64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *FeePolicy) GetCollector() *Identity {
+	if x != nil {
+		return x.Collector
+	}
+	return nil
+}
+
 // PublicParameters describes typed public parameters
 type PublicParameters struct {
 	state         protoimpl.MessageState
@@ -232,12 +360,18 @@ type PublicParameters struct {
 	Issuers                []*Identity              `protobuf:"bytes,8,rep,name=issuers,proto3" json:"issuers,omitempty"`                                                                 // is a list of public keys of the entities that can issue tokens.
 	MaxToken               uint64                   `protobuf:"varint,9,opt,name=max_token,json=maxToken,proto3" json:"max_token,omitempty"`                                              // is the maximum quantity a token can hold
 	QuantityPrecision      uint64                   `protobuf:"varint,10,opt,name=quantity_precision,json=quantityPrecision,proto3" json:"quantity_precision,omitempty"`                  // is the precision used to represent quantities
+	AdditionalAuditors     []*Identity              `protobuf:"bytes,11,rep,name=additional_auditors,json=additionalAuditors,proto3" json:"additional_auditors,omitempty"`                // are the public keys of the auditors other than the one in auditor.
+	AuditorThreshold       uint64                   `protobuf:"varint,12,opt,name=auditor_threshold,json=auditorThreshold,proto3" json:"auditor_threshold,omitempty"`                     // is the number of auditors that must sign a token request. Zero means all of them.
+	IssuerPolicies         []*IssuerPolicy          `protobuf:"bytes,13,rep,name=issuer_policies,json=issuerPolicies,proto3" json:"issuer_policies,omitempty"`                            // restrict the issuers of specific token types. Token types not matched by any policy can be issued by the entities in issuers.
+	AggregatedRangeProofs  bool                     `protobuf:"varint,14,opt,name=aggregated_range_proofs,json=aggregatedRangeProofs,proto3" json:"aggregated_range_proofs,omitempty"`    // if true, the range proofs of the outputs of a transfer are aggregated into a single proof. It requires version 2.
+	FeePolicies            []*FeePolicy             `protobuf:"bytes,15,rep,name=fee_policies,json=feePolicies,proto3" json:"fee_policies,omitempty"`                                     // are the fees charged on transfers, by token type. Transfers then disclose their token type.
+	Enforcers              []*Identity              `protobuf:"bytes,16,rep,name=enforcers,proto3" json:"enforcers,omitempty"`                                                            // are the public keys of the enforcers that can freeze tokens and owners, and force transfers.
 }
 
 func (x *PublicParameters) Reset() {
 	*x = PublicParameters{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_noghpp_proto_msgTypes[3]
+		mi := &file_noghpp_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -250,7 +384,7 @@ func (x *PublicParameters) String() string {
 func (*PublicParameters) ProtoMessage() {}
 
 func (x *PublicParameters) ProtoReflect() protoreflect.Message {
-	mi := &file_noghpp_proto_msgTypes[3]
+	mi := &file_noghpp_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -263,7 +397,7 @@ func (x *PublicParameters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublicParameters.ProtoReflect.Descriptor instead.
 func (*PublicParameters) Descriptor() ([]byte, []int) {
-	return file_noghpp_proto_rawDescGZIP(), []int{3}
+	return file_noghpp_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *PublicParameters) GetIdentifier() string {
@@ -336,6 +470,48 @@ func (x *PublicParameters) GetQuantityPrecision() uint64 {
 	return 0
 }
 
+func (x *PublicParameters) GetAdditionalAuditors() []*Identity {
+	if x != nil {
+		return x.AdditionalAuditors
+	}
+	return nil
+}
+
+func (x *PublicParameters) GetAuditorThreshold() uint64 {
+	if x != nil {
+		return x.AuditorThreshold
+	}
+	return 0
+}
+
+func (x *PublicParameters) GetIssuerPolicies() []*IssuerPolicy {
+	if x != nil {
+		return x.IssuerPolicies
+	}
+	return nil
+}
+
+func (x *PublicParameters) GetAggregatedRangeProofs() bool {
+	if x != nil {
+		return x.AggregatedRangeProofs
+	}
+	return false
+}
+
+func (x *PublicParameters) GetFeePolicies() []*FeePolicy {
+	if x != nil {
+		return x.FeePolicies
+	}
+	return nil
+}
+
+func (x *PublicParameters) GetEnforcers() []*Identity {
+	if x != nil {
+		return x.Enforcers
+	}
+	return nil
+}
+
 var File_noghpp_proto protoreflect.FileDescriptor
 
 var file_noghpp_proto_rawDesc = []byte{
@@ -364,44 +540,78 @@ var file_noghpp_proto_rawDesc = []byte{
 	0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x69, 0x74, 0x4c, 0x65, 0x6e, 0x67,
 	0x74, 0x68, 0x12, 0x28, 0x0a, 0x10, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f,
 	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x22, 0xef, 0x03, 0x0a,
-	0x10, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
-	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
-	0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x08, 0x63,
-	0x75, 0x72, 0x76, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x49, 0x44, 0x52, 0x07, 0x63, 0x75,
-	0x72, 0x76, 0x65, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x13, 0x70, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65,
-	0x6e, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x08, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x47, 0x31, 0x52, 0x12, 0x70, 0x65,
-	0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73,
-	0x12, 0x44, 0x0a, 0x12, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f,
-	0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e,
-	0x6f, 0x67, 0x68, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x50, 0x61,
-	0x72, 0x61, 0x6d, 0x73, 0x52, 0x10, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x56, 0x0a, 0x19, 0x69, 0x64, 0x65, 0x6d, 0x69, 0x78,
-	0x5f, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b,
-	0x65, 0x79, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e, 0x6f, 0x67, 0x68,
-	0x2e, 0x49, 0x64, 0x65, 0x6d, 0x69, 0x78, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x50, 0x75, 0x62,
-	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x52, 0x16, 0x69, 0x64, 0x65, 0x6d, 0x69, 0x78, 0x49, 0x73,
-	0x73, 0x75, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x28,
-	0x0a, 0x07, 0x61, 0x75, 0x64, 0x69, 0x74, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52,
-	0x07, 0x61, 0x75, 0x64, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x07, 0x69, 0x73, 0x73, 0x75,
-	0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68,
-	0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65,
-	0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
-	0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
-	0x2d, 0x0a, 0x12, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x72, 0x65, 0x63,
-	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x71, 0x75, 0x61,
-	0x6e, 0x74, 0x69, 0x74, 0x79, 0x50, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x54,
-	0x5a, 0x52, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70,
-	0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x66, 0x61,
-	0x62, 0x72, 0x69, 0x63, 0x2d, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2d, 0x73, 0x64, 0x6b, 0x2f, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x7a, 0x6b, 0x61, 0x74, 0x64, 0x6c,
-	0x6f, 0x67, 0x2f, 0x6e, 0x6f, 0x67, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2d, 0x67,
-	0x6f, 0x2f, 0x70, 0x70, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x22, 0x57, 0x0a, 0x0c,
+	0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1d, 0x0a, 0x0a,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x69,
+	0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e,
+	0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x07, 0x69, 0x73,
+	0x73, 0x75, 0x65, 0x72, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x09, 0x46, 0x65, 0x65, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6c, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x66, 0x6c, 0x61, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x09, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x09, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0xb4, 0x06, 0x0a, 0x10, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x76, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x6f, 0x67, 0x68,
+	0x2e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x49, 0x44, 0x52, 0x07, 0x63, 0x75, 0x72, 0x76, 0x65, 0x49,
+	0x64, 0x12, 0x39, 0x0a, 0x13, 0x70, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x5f, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08,
+	0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x47, 0x31, 0x52, 0x12, 0x70, 0x65, 0x64, 0x65, 0x72, 0x73,
+	0x65, 0x6e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x44, 0x0a, 0x12,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x70, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x52, 0x10, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x12, 0x56, 0x0a, 0x19, 0x69, 0x64, 0x65, 0x6d, 0x69, 0x78, 0x5f, 0x69, 0x73, 0x73,
+	0x75, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65,
+	0x6d, 0x69, 0x78, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b,
+	0x65, 0x79, 0x52, 0x16, 0x69, 0x64, 0x65, 0x6d, 0x69, 0x78, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72,
+	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x28, 0x0a, 0x07, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f,
+	0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x07, 0x61, 0x75, 0x64,
+	0x69, 0x74, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x71,
+	0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x50, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x13, 0x61, 0x64,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x6f, 0x72,
+	0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x12, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x6f, 0x72, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x61, 0x75, 0x64, 0x69, 0x74, 0x6f, 0x72, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x3b, 0x0a, 0x0f, 0x69, 0x73, 0x73, 0x75,
+	0x65, 0x72, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0e, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x69, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x12, 0x32, 0x0a,
+	0x0c, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x0f, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x46, 0x65, 0x65, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x52, 0x0b, 0x66, 0x65, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65,
+	0x73, 0x12, 0x2c, 0x0a, 0x09, 0x65, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x72, 0x73, 0x18, 0x10,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x52, 0x09, 0x65, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x72, 0x73, 0x42,
+	0x54, 0x5a, 0x52, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79,
+	0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x66,
+	0x61, 0x62, 0x72, 0x69, 0x63, 0x2d, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2d, 0x73, 0x64, 0x6b, 0x2f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x7a, 0x6b, 0x61, 0x74, 0x64,
+	0x6c, 0x6f, 0x67, 0x2f, 0x6e, 0x6f, 0x67, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2d,
+	0x67, 0x6f, 0x2f, 0x70, 0x70, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -416,32 +626,40 @@ func file_noghpp_proto_rawDescGZIP() []byte {
 	return file_noghpp_proto_rawDescData
 }
 
-var file_noghpp_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_noghpp_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_noghpp_proto_goTypes = []interface{}{
 	(*Identity)(nil),              // 0: nogh.Identity
 	(*IdemixIssuerPublicKey)(nil), // 1: nogh.IdemixIssuerPublicKey
 	(*RangeProofParams)(nil),      // 2: nogh.RangeProofParams
-	(*PublicParameters)(nil),      // 3: nogh.PublicParameters
-	(*math.CurveID)(nil),          // 4: nogh.CurveID
-	(*math.G1)(nil),               // 5: nogh.G1
+	(*IssuerPolicy)(nil),          // 3: nogh.IssuerPolicy
+	(*FeePolicy)(nil),             // 4: nogh.FeePolicy
+	(*PublicParameters)(nil),      // 5: nogh.PublicParameters
+	(*math.CurveID)(nil),          // 6: nogh.CurveID
+	(*math.G1)(nil),               // 7: nogh.G1
 }
 var file_noghpp_proto_depIdxs = []int32{
-	4,  // 0: nogh.IdemixIssuerPublicKey.curver_id:type_name -> nogh.CurveID
-	5,  // 1: nogh.RangeProofParams.left_generators:type_name -> nogh.G1
-	5,  // 2: nogh.RangeProofParams.right_generators:type_name -> nogh.G1
-	5,  // 3: nogh.RangeProofParams.P:type_name -> nogh.G1
-	5,  // 4: nogh.RangeProofParams.Q:type_name -> nogh.G1
-	4,  // 5: nogh.PublicParameters.curve_id:type_name -> nogh.CurveID
-	5,  // 6: nogh.PublicParameters.pedersen_generators:type_name -> nogh.G1
-	2,  // 7: nogh.PublicParameters.range_proof_params:type_name -> nogh.RangeProofParams
-	1,  // 8: nogh.PublicParameters.idemix_issuer_public_keys:type_name -> nogh.IdemixIssuerPublicKey
-	0,  // 9: nogh.PublicParameters.auditor:type_name -> nogh.Identity
-	0,  // 10: nogh.PublicParameters.issuers:type_name -> nogh.Identity
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	6,  // 0: nogh.IdemixIssuerPublicKey.curver_id:type_name -> nogh.CurveID
+	7,  // 1: nogh.RangeProofParams.left_generators:type_name -> nogh.G1
+	7,  // 2: nogh.RangeProofParams.right_generators:type_name -> nogh.G1
+	7,  // 3: nogh.RangeProofParams.P:type_name -> nogh.G1
+	7,  // 4: nogh.RangeProofParams.Q:type_name -> nogh.G1
+	0,  // 5: nogh.IssuerPolicy.issuers:type_name -> nogh.Identity
+	0,  // 6: nogh.FeePolicy.collector:type_name -> nogh.Identity
+	6,  // 7: nogh.PublicParameters.curve_id:type_name -> nogh.CurveID
+	7,  // 8: nogh.PublicParameters.pedersen_generators:type_name -> nogh.G1
+	2,  // 9: nogh.PublicParameters.range_proof_params:type_name -> nogh.RangeProofParams
+	1,  // 10: nogh.PublicParameters.idemix_issuer_public_keys:type_name -> nogh.IdemixIssuerPublicKey
+	0,  // 11: nogh.PublicParameters.auditor:type_name -> nogh.Identity
+	0,  // 12: nogh.PublicParameters.issuers:type_name -> nogh.Identity
+	0,  // 13: nogh.PublicParameters.additional_auditors:type_name -> nogh.Identity
+	3,  // 14: nogh.PublicParameters.issuer_policies:type_name -> nogh.IssuerPolicy
+	4,  // 15: nogh.PublicParameters.fee_policies:type_name -> nogh.FeePolicy
+	0,  // 16: nogh.PublicParameters.enforcers:type_name -> nogh.Identity
+	17, // [17:17] is the sub-list for method output_type
+	17, // [17:17] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_noghpp_proto_init() }
@@ -487,6 +705,30 @@ func file_noghpp_proto_init() {
 			}
 		}
 		file_noghpp_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IssuerPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_noghpp_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeePolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_noghpp_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PublicParameters); i {
 			case 0:
 				return &v.state
@@ -505,7 +747,7 @@ func file_noghpp_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_noghpp_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,389 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rp
+
+import (
+	"math/bits"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/common"
+	"github.com/pkg/errors"
+)
+
+// AggregatedLength returns the number of left (and right) generators needed to aggregate
+// the range proofs of the passed number of values of the passed bit length.
+// The number of values is rounded up to the next power of two.
+func AggregatedLength(numberOfValues int, bitLength uint64) uint64 {
+	if numberOfValues <= 1 {
+		return bitLength
+	}
+	return bitLength << bits.Len(uint(numberOfValues-1))
+}
+
+// AggregatedRangeProver proves with a single RangeProof that several committed values are < 2^BitLength.
+// The size of the proof is logarithmic in the number of values.
+type AggregatedRangeProver struct {
+	// Commitments are hiding Pedersen commitments to Values: Commitments[j] = G^Values[j]H^BlindingFactors[j]
+	Commitments []*math.G1
+	// Values are the values committed in Commitments
+	Values []uint64
+	// BlindingFactors are the randomness used to compute Commitments
+	BlindingFactors []*math.Zr
+	// CommitmentGenerators are the generators (G, H) used to compute Commitments
+	CommitmentGenerators []*math.G1
+	// LeftGenerators are the generators used to commit to the bits of all values.
+	// Their number is AggregatedLength(len(Values), BitLength)
+	LeftGenerators []*math.G1
+	// RightGenerators are the generators used to commit to the bits minus one of all values
+	RightGenerators []*math.G1
+	// P is a random generator of G1
+	P *math.G1
+	// Q is a random generator of G1
+	Q *math.G1
+	// BitLength is the size of the binary representation of each value
+	BitLength uint64
+	// Curve is the curve over which the computation is performed
+	Curve *math.Curve
+}
+
+// NewAggregatedRangeProver returns an AggregatedRangeProver based on the passed arguments
+func NewAggregatedRangeProver(
+	coms []*math.G1,
+	values []uint64,
+	blindingFactors []*math.Zr,
+	commitmentGen []*math.G1,
+	leftGen []*math.G1,
+	rightGen []*math.G1,
+	P, Q *math.G1,
+	bitLength uint64,
+	curve *math.Curve,
+) *AggregatedRangeProver {
+	return &AggregatedRangeProver{
+		Commitments:          coms,
+		Values:               values,
+		BlindingFactors:      blindingFactors,
+		CommitmentGenerators: commitmentGen,
+		LeftGenerators:       leftGen,
+		RightGenerators:      rightGen,
+		P:                    P,
+		Q:                    Q,
+		BitLength:            bitLength,
+		Curve:                curve,
+	}
+}
+
+// AggregatedRangeVerifier verifies a RangeProof produced by an AggregatedRangeProver
+type AggregatedRangeVerifier struct {
+	// Commitments are the commitments to the values in range
+	Commitments []*math.G1
+	// CommitmentGenerators are the generators (G, H) used to compute Commitments
+	CommitmentGenerators []*math.G1
+	// LeftGenerators are the generators used to commit to the bits of all values.
+	// Their number is AggregatedLength(len(Commitments), BitLength)
+	LeftGenerators []*math.G1
+	// RightGenerators are the generators used to commit to the bits minus one of all values
+	RightGenerators []*math.G1
+	// P is a random generator of G1
+	P *math.G1
+	// Q is a random generator of G1
+	Q *math.G1
+	// BitLength is the size of the binary representation of each value
+	BitLength uint64
+	// Curve is the curve over which the computation is performed
+	Curve *math.Curve
+}
+
+// NewAggregatedRangeVerifier returns an AggregatedRangeVerifier based on the passed arguments
+func NewAggregatedRangeVerifier(
+	coms []*math.G1,
+	commitmentGen []*math.G1,
+	leftGen []*math.G1,
+	rightGen []*math.G1,
+	P, Q *math.G1,
+	bitLength uint64,
+	curve *math.Curve,
+) *AggregatedRangeVerifier {
+	return &AggregatedRangeVerifier{
+		Commitments:          coms,
+		CommitmentGenerators: commitmentGen,
+		LeftGenerators:       leftGen,
+		RightGenerators:      rightGen,
+		P:                    P,
+		Q:                    Q,
+		BitLength:            bitLength,
+		Curve:                curve,
+	}
+}
+
+// Prove produces a RangeProof that shows that each committed value
+// v_j = \sum_{i=0}^{BitLength} b_{j,i} 2^i; b_{j,i} in {0, 1}.
+// The number of values is padded to a power of two with zeros committed with zero randomness.
+func (p *AggregatedRangeProver) Prove() (*RangeProof, error) {
+	if len(p.Values) == 0 || len(p.Values) != len(p.Commitments) || len(p.Values) != len(p.BlindingFactors) {
+		return nil, errors.New("invalid aggregated range prover: the number of values, commitments, and blinding factors must match")
+	}
+	n := AggregatedLength(len(p.Values), p.BitLength)
+	if uint64(len(p.LeftGenerators)) != n || uint64(len(p.RightGenerators)) != n {
+		return nil, errors.Errorf("invalid aggregated range prover: expected [%d] generators", n)
+	}
+	c := p.Curve
+	rand, err := c.Rand()
+	if err != nil {
+		return nil, err
+	}
+
+	left := make([]*math.Zr, n)
+	right := make([]*math.Zr, n)
+	randomLeft := make([]*math.Zr, n)
+	randomRight := make([]*math.Zr, n)
+	rho := c.NewRandomZr(rand)
+	eta := c.NewRandomZr(rand)
+	for k := uint64(0); k < n; k++ {
+		// the k-th bit is the bit i of value j
+		j, i := k/p.BitLength, k%p.BitLength
+		b := uint64(0)
+		if j < uint64(len(p.Values)) && p.Values[j]&(1<<i) > 0 {
+			b = 1
+		}
+		left[k] = c.NewZrFromUint64(b)
+		right[k] = c.ModSub(left[k], c.NewZrFromInt(1), c.GroupOrder)
+		randomLeft[k] = c.NewRandomZr(rand)
+		randomRight[k] = c.NewRandomZr(rand)
+	}
+
+	// C commits to the bits of all values and to the bits minus one
+	C := commitVector(left, right, p.LeftGenerators, p.RightGenerators, c)
+	C.Add(p.P.Mul(rho))
+	// D commits two random vectors
+	D := commitVector(randomLeft, randomRight, p.LeftGenerators, p.RightGenerators, c)
+	D.Add(p.P.Mul(eta))
+
+	// compute challenges y and z
+	y, z, err := aggregatedChallengesYZ(C, D, p.Commitments, c)
+	if err != nil {
+		return nil, err
+	}
+	zPrime := aggregatedZPrime(z, n, p.BitLength, c)
+
+	leftPrime := make([]*math.Zr, n)
+	rightPrime := make([]*math.Zr, n)
+	randRightPrime := make([]*math.Zr, n)
+	var yPow *math.Zr
+	for k := uint64(0); k < n; k++ {
+		if k == 0 {
+			yPow = c.NewZrFromInt(1)
+		} else {
+			yPow = c.ModMul(y, yPow, c.GroupOrder)
+		}
+		// L_k - z
+		leftPrime[k] = c.ModSub(left[k], z, c.GroupOrder)
+		// (R_k + z)y^k
+		rightPrime[k] = c.ModMul(c.ModAdd(right[k], z, c.GroupOrder), yPow, c.GroupOrder)
+		// V_ky^k
+		randRightPrime[k] = c.ModMul(randomRight[k], yPow, c.GroupOrder)
+	}
+
+	t1 := innerProduct(leftPrime, randRightPrime, c)
+	t1 = c.ModAdd(t1, innerProduct(rightPrime, randomLeft, c), c.GroupOrder)
+	t1 = c.ModAdd(t1, innerProduct(zPrime, randomLeft, c), c.GroupOrder)
+	tau1 := c.NewRandomZr(rand)
+	T1 := p.CommitmentGenerators[0].Mul(t1)
+	T1.Add(p.CommitmentGenerators[1].Mul(tau1))
+
+	t2 := innerProduct(randomLeft, randRightPrime, c)
+	tau2 := c.NewRandomZr(rand)
+	T2 := p.CommitmentGenerators[0].Mul(t2)
+	T2.Add(p.CommitmentGenerators[1].Mul(tau2))
+
+	// compute challenge x
+	raw, err := common.GetG1Array([]*math.G1{T1, T2}).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	x := c.HashToZr(raw)
+
+	for k := uint64(0); k < n; k++ {
+		// (L_k-z) + xU_k
+		left[k] = c.ModAdd(leftPrime[k], c.ModMul(x, randomLeft[k], c.GroupOrder), c.GroupOrder)
+		// y^k((R_k+z)+xV_k) + z^{2+j}2^i
+		right[k] = c.ModAdd(rightPrime[k], c.ModMul(x, randRightPrime[k], c.GroupOrder), c.GroupOrder)
+		right[k] = c.ModAdd(right[k], zPrime[k], c.GroupOrder)
+	}
+	// tau = tau1x + tau2x^2 + \sum z^{2+j}blindingFactor_j
+	tau := c.ModMul(x, tau1, c.GroupOrder)
+	tau = c.ModAdd(tau, c.ModMul(tau2, c.ModMul(x, x, c.GroupOrder), c.GroupOrder), c.GroupOrder)
+	zj := c.ModMul(z, z, c.GroupOrder)
+	for j := 0; j < len(p.BlindingFactors); j++ {
+		tau = c.ModAdd(tau, c.ModMul(zj, p.BlindingFactors[j], c.GroupOrder), c.GroupOrder)
+		zj = c.ModMul(zj, z, c.GroupOrder)
+	}
+	// delta = rho + eta*x
+	delta := c.ModAdd(rho, c.ModMul(eta, x, c.GroupOrder), c.GroupOrder)
+
+	rp := &RangeProof{
+		Data: &RangeProofData{
+			T1:    T1,
+			T2:    T2,
+			C:     C,
+			D:     D,
+			Tau:   tau,
+			Delta: delta,
+		},
+	}
+
+	// compute the new generators H'_k = H_k^{1/y^k}
+	yInv := y.Copy()
+	yInv.InvModP(c.GroupOrder)
+	rightGeneratorsPrime := make([]*math.G1, n)
+	yInvPow := c.NewZrFromInt(1)
+	for k := uint64(0); k < n; k++ {
+		rightGeneratorsPrime[k] = p.RightGenerators[k].Mul(yInvPow)
+		yInvPow = c.ModMul(yInvPow, yInv, c.GroupOrder)
+	}
+	com := commitVector(left, right, p.LeftGenerators, rightGeneratorsPrime, c)
+	rp.Data.InnerProduct = innerProduct(left, right, c)
+	ipp := NewIPAProver(
+		rp.Data.InnerProduct,
+		left,
+		right,
+		p.Q,
+		p.LeftGenerators,
+		rightGeneratorsPrime,
+		com,
+		uint64(bits.Len64(n)-1),
+		c,
+	)
+	rp.IPA, err = ipp.Prove()
+	if err != nil {
+		return nil, err
+	}
+	return rp, nil
+}
+
+// Verify checks that the passed proof shows that all values committed in v.Commitments are in range
+func (v *AggregatedRangeVerifier) Verify(rp *RangeProof) error {
+	if rp == nil {
+		return errors.New("invalid aggregated range proof: nil proof")
+	}
+	if err := checkRangeProof(rp); err != nil {
+		return err
+	}
+	if len(v.Commitments) == 0 {
+		return errors.New("invalid aggregated range proof: no commitments")
+	}
+	n := AggregatedLength(len(v.Commitments), v.BitLength)
+	if uint64(len(v.LeftGenerators)) != n || uint64(len(v.RightGenerators)) != n {
+		return errors.Errorf("invalid aggregated range verifier: expected [%d] generators", n)
+	}
+	c := v.Curve
+
+	raw, err := common.GetG1Array([]*math.G1{rp.Data.T1, rp.Data.T2}).Bytes()
+	if err != nil {
+		return err
+	}
+	x := c.HashToZr(raw)
+	xSquare := c.ModMul(x, x, c.GroupOrder)
+	y, z, err := aggregatedChallengesYZ(rp.Data.C, rp.Data.D, v.Commitments, c)
+	if err != nil {
+		return err
+	}
+	zSquare := c.ModMul(z, z, c.GroupOrder)
+	zPrime := aggregatedZPrime(z, n, v.BitLength, c)
+
+	// ipy = \sum y^k, ip2 = \sum_{i < BitLength} 2^i
+	yPow := make([]*math.Zr, n)
+	ipy := c.NewZrFromInt(0)
+	for k := uint64(0); k < n; k++ {
+		if k == 0 {
+			yPow[0] = c.NewZrFromInt(1)
+		} else {
+			yPow[k] = c.ModMul(y, yPow[k-1], c.GroupOrder)
+		}
+		ipy = c.ModAdd(ipy, yPow[k], c.GroupOrder)
+	}
+	ip2 := c.NewZrFromInt(0)
+	power2 := c.NewZrFromInt(1)
+	for i := uint64(0); i < v.BitLength; i++ {
+		ip2 = c.ModAdd(ip2, power2, c.GroupOrder)
+		power2 = c.ModMul(power2, c.NewZrFromInt(2), c.GroupOrder)
+	}
+
+	// polEval = (z - z^2)\sum y^k - \sum_j z^{3+j}\sum 2^i
+	// comPrime = \prod V_j^{z^{2+j}}
+	polEval := c.ModMul(c.ModSub(z, zSquare, c.GroupOrder), ipy, c.GroupOrder)
+	comPrime := c.NewG1()
+	zj := zSquare
+	for j := uint64(0); j < n/v.BitLength; j++ {
+		polEval = c.ModSub(polEval, c.ModMul(c.ModMul(zj, z, c.GroupOrder), ip2, c.GroupOrder), c.GroupOrder)
+		if j < uint64(len(v.Commitments)) {
+			comPrime.Add(v.Commitments[j].Mul(zj))
+		}
+		zj = c.ModMul(zj, z, c.GroupOrder)
+	}
+	comPrime.Add(v.CommitmentGenerators[0].Mul(polEval))
+
+	com := v.CommitmentGenerators[0].Mul(rp.Data.InnerProduct)
+	com.Add(v.CommitmentGenerators[1].Mul(rp.Data.Tau))
+	com.Sub(rp.Data.T1.Mul(x))
+	com.Sub(rp.Data.T2.Mul(xSquare))
+	if !com.Equals(comPrime) {
+		return errors.New("invalid aggregated range proof")
+	}
+
+	// recompute the commitment to the vectors of the IPA, and the generators H'_k = H_k^{1/y^k}
+	ipaCom := rp.Data.D.Mul(x)
+	ipaCom.Add(rp.Data.C)
+	rightGeneratorsPrime := make([]*math.G1, n)
+	for k := uint64(0); k < n; k++ {
+		ipaCom.Sub(v.LeftGenerators[k].Mul(z))
+		yInv := yPow[k].Copy()
+		yInv.InvModP(c.GroupOrder)
+		rightGeneratorsPrime[k] = v.RightGenerators[k].Mul(yInv)
+		// zy^k + z^{2+j}2^i
+		zk := c.ModAdd(c.ModMul(z, yPow[k], c.GroupOrder), zPrime[k], c.GroupOrder)
+		ipaCom.Add(rightGeneratorsPrime[k].Mul(zk))
+	}
+	ipaCom.Sub(v.P.Mul(rp.Data.Delta))
+
+	ipv := NewIPAVerifier(
+		rp.Data.InnerProduct,
+		v.Q,
+		v.LeftGenerators,
+		rightGeneratorsPrime,
+		ipaCom,
+		uint64(bits.Len64(n)-1),
+		c,
+	)
+	return ipv.Verify(rp.IPA)
+}
+
+// aggregatedChallengesYZ computes the challenges y and z of an aggregated range proof
+func aggregatedChallengesYZ(C, D *math.G1, coms []*math.G1, c *math.Curve) (*math.Zr, *math.Zr, error) {
+	raw, err := common.GetG1Array([]*math.G1{C, D}, coms).Bytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	y := c.HashToZr(raw)
+	z := c.HashToZr(y.Bytes())
+	return y, z, nil
+}
+
+// aggregatedZPrime returns the vector whose k-th element is z^{2+j}2^i, where k = j*bitLength + i
+func aggregatedZPrime(z *math.Zr, n, bitLength uint64, c *math.Curve) []*math.Zr {
+	zPrime := make([]*math.Zr, n)
+	zj := c.ModMul(z, z, c.GroupOrder)
+	power2 := c.NewZrFromInt(1)
+	for k := uint64(0); k < n; k++ {
+		if k > 0 && k%bitLength == 0 {
+			zj = c.ModMul(zj, z, c.GroupOrder)
+			power2 = c.NewZrFromInt(1)
+		}
+		zPrime[k] = c.ModMul(zj, power2, c.GroupOrder)
+		power2 = c.ModMul(power2, c.NewZrFromInt(2), c.GroupOrder)
+	}
+	return zPrime
+}
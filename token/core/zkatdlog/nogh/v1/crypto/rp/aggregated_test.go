@@ -0,0 +1,133 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+package rp_test
+
+import (
+	"strconv"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/rp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Aggregated Range Proof", func() {
+	var (
+		curve     *math.Curve
+		comGens   []*math.G1
+		P, Q      *math.G1
+		bitLength uint64
+	)
+	BeforeEach(func() {
+		curve = math.Curves[math.BN254]
+		rand, err := curve.Rand()
+		Expect(err).NotTo(HaveOccurred())
+		comGens = []*math.G1{curve.GenG1.Mul(curve.NewRandomZr(rand)), curve.GenG1.Mul(curve.NewRandomZr(rand))}
+		P = curve.GenG1.Mul(curve.NewRandomZr(rand))
+		Q = curve.GenG1.Mul(curve.NewRandomZr(rand))
+		bitLength = 16
+	})
+
+	generators := func(n uint64) ([]*math.G1, []*math.G1) {
+		left := make([]*math.G1, n)
+		right := make([]*math.G1, n)
+		for i := uint64(0); i < n; i++ {
+			left[i] = curve.HashToG1([]byte("left." + strconv.FormatUint(i, 10)))
+			right[i] = curve.HashToG1([]byte("right." + strconv.FormatUint(i, 10)))
+		}
+		return left, right
+	}
+
+	prove := func(values []uint64) ([]*math.G1, *rp.RangeProof, error) {
+		rand, err := curve.Rand()
+		Expect(err).NotTo(HaveOccurred())
+		coms := make([]*math.G1, len(values))
+		bfs := make([]*math.Zr, len(values))
+		for i, value := range values {
+			bfs[i] = curve.NewRandomZr(rand)
+			coms[i] = comGens[0].Mul(curve.NewZrFromUint64(value))
+			coms[i].Add(comGens[1].Mul(bfs[i]))
+		}
+		left, right := generators(rp.AggregatedLength(len(values), bitLength))
+		proof, err := rp.NewAggregatedRangeProver(coms, values, bfs, comGens, left, right, P, Q, bitLength, curve).Prove()
+		return coms, proof, err
+	}
+
+	verify := func(coms []*math.G1, proof *rp.RangeProof) error {
+		left, right := generators(rp.AggregatedLength(len(coms), bitLength))
+		return rp.NewAggregatedRangeVerifier(coms, comGens, left, right, P, Q, bitLength, curve).Verify(proof)
+	}
+
+	It("computes the number of generators", func() {
+		Expect(rp.AggregatedLength(1, 64)).To(Equal(uint64(64)))
+		Expect(rp.AggregatedLength(2, 64)).To(Equal(uint64(128)))
+		Expect(rp.AggregatedLength(3, 64)).To(Equal(uint64(256)))
+		Expect(rp.AggregatedLength(8, 32)).To(Equal(uint64(256)))
+		Expect(rp.AggregatedLength(9, 32)).To(Equal(uint64(512)))
+	})
+
+	Context("when the values are in range", func() {
+		It("succeeds for a power of two number of values", func() {
+			coms, proof, err := prove([]uint64{0, 1, 1000, 1<<16 - 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verify(coms, proof)).To(Succeed())
+			Expect(proof.IPA.L).To(HaveLen(6))
+		})
+		It("succeeds for a single value", func() {
+			coms, proof, err := prove([]uint64{42})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verify(coms, proof)).To(Succeed())
+		})
+		It("succeeds when the values need padding", func() {
+			coms, proof, err := prove([]uint64{5, 6, 7})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verify(coms, proof)).To(Succeed())
+		})
+		It("survives serialization", func() {
+			coms, proof, err := prove([]uint64{5, 6, 7})
+			Expect(err).NotTo(HaveOccurred())
+			raw, err := proof.Serialize()
+			Expect(err).NotTo(HaveOccurred())
+			proof2 := &rp.RangeProof{}
+			Expect(proof2.Deserialize(raw)).To(Succeed())
+			Expect(verify(coms, proof2)).To(Succeed())
+		})
+	})
+
+	Context("when a value is out of range", func() {
+		It("fails", func() {
+			coms, proof, err := prove([]uint64{5, 1 << 16})
+			Expect(err).NotTo(HaveOccurred())
+			err = verify(coms, proof)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid aggregated range proof"))
+		})
+	})
+
+	Context("when the commitments do not match", func() {
+		It("fails if they are swapped", func() {
+			coms, proof, err := prove([]uint64{5, 6})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verify([]*math.G1{coms[1], coms[0]}, proof)).NotTo(Succeed())
+		})
+		It("fails if one is missing", func() {
+			coms, proof, err := prove([]uint64{5, 6, 7})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verify(coms[:2], proof)).NotTo(Succeed())
+		})
+	})
+
+	Context("when the IPA is tampered with", func() {
+		It("fails", func() {
+			coms, proof, err := prove([]uint64{5, 6})
+			Expect(err).NotTo(HaveOccurred())
+			proof.IPA.Right = curve.ModAdd(proof.IPA.Right, curve.NewZrFromInt(1), curve.GroupOrder)
+			err = verify(coms, proof)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid IPA"))
+		})
+	})
+})
@@ -0,0 +1,263 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rp
+
+import (
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/common"
+	"github.com/pkg/errors"
+)
+
+// BatchVerifier verifies at once several range proofs generated with the same parameters.
+// Instead of checking the equations of each proof on its own, it checks a random linear combination of them.
+// The exponentiations of the generators shared by all proofs are then computed once per batch.
+// The proofs can come from different transfers, and from different requests.
+type BatchVerifier struct {
+	// CommitmentGenerators are the generators (G, H) used to compute the commitments
+	CommitmentGenerators []*math.G1
+	// LeftGenerators are the generators used to commit to the bits of the committed values
+	LeftGenerators []*math.G1
+	// RightGenerators are the generators used to commit to the bits minus one of the committed values
+	RightGenerators []*math.G1
+	// P is a random generator of G1
+	P *math.G1
+	// Q is a random generator of G1
+	Q *math.G1
+	// NumberOfRounds correspond to log_2(BitLength)
+	NumberOfRounds uint64
+	// BitLength is the size of the binary representation of the committed values
+	BitLength uint64
+	// Curve is the curve over which the computation is performed
+	Curve *math.Curve
+
+	commitments []*math.G1
+	proofs      []*RangeProof
+}
+
+// NewBatchVerifier returns a BatchVerifier based on the passed arguments
+func NewBatchVerifier(
+	commitmentGen []*math.G1,
+	leftGen []*math.G1,
+	rightGen []*math.G1,
+	P, Q *math.G1,
+	numberOfRounds, bitLength uint64,
+	curve *math.Curve,
+) *BatchVerifier {
+	return &BatchVerifier{
+		CommitmentGenerators: commitmentGen,
+		LeftGenerators:       leftGen,
+		RightGenerators:      rightGen,
+		P:                    P,
+		Q:                    Q,
+		NumberOfRounds:       numberOfRounds,
+		BitLength:            bitLength,
+		Curve:                curve,
+	}
+}
+
+// Add adds to the batch the proof that the value committed in com is in range
+func (b *BatchVerifier) Add(com *math.G1, proof *RangeProof) {
+	b.commitments = append(b.commitments, com)
+	b.proofs = append(b.proofs, proof)
+}
+
+// Len returns the number of proofs in the batch
+func (b *BatchVerifier) Len() int {
+	return len(b.proofs)
+}
+
+// Verify checks all proofs in the batch.
+// If the batch is not valid, the proofs are verified one by one to report the first invalid one.
+func (b *BatchVerifier) Verify() error {
+	if len(b.proofs) == 0 {
+		return nil
+	}
+	if len(b.LeftGenerators) != len(b.RightGenerators) || uint64(len(b.LeftGenerators)) != 1<<b.NumberOfRounds {
+		return errors.New("invalid range proof parameters")
+	}
+	for i, proof := range b.proofs {
+		if proof == nil {
+			return errors.Errorf("invalid range proof: nil proof at index %d", i)
+		}
+		if err := checkRangeProof(proof); err != nil {
+			return errors.Wrapf(err, "invalid range proof at index %d", i)
+		}
+		if len(proof.IPA.L) != len(proof.IPA.R) || uint64(len(proof.IPA.L)) != b.NumberOfRounds {
+			return errors.Wrapf(errors.New("invalid IPA proof"), "invalid range proof at index %d", i)
+		}
+	}
+
+	ok, err := b.verifyBatch()
+	if err != nil {
+		return errors.Wrapf(err, "failed to verify range proofs in batch")
+	}
+	if ok {
+		return nil
+	}
+	// the batch is not valid, look for the culprit
+	for i, proof := range b.proofs {
+		if err := b.verifier(b.commitments[i]).Verify(proof); err != nil {
+			return errors.Wrapf(err, "invalid range proof at index %d", i)
+		}
+	}
+	return errors.New("invalid range proofs")
+}
+
+// verifyBatch checks that a random linear combination of the verification equations of all proofs holds.
+// For each proof, the equations are:
+// 1. G^{InnerProduct - polEval} H^{Tau} T1^{-x} T2^{-x^2} V^{-z^2} = 1, where V is the commitment
+// 2. \prod G_i^{Left s_i} H'_i^{Right/s_i} Q^{x_0 (Left Right - InnerProduct)} com^{-1} \prod (L_j^{x_j^2} R_j^{x_j^{-2}})^{-1} = 1,
+// where the second equation is the IPA verification with the reduction of the generators unrolled,
+// x_j are the round challenges, and s_i is the product over the rounds of x_j or 1/x_j, depending on the bits of i.
+func (b *BatchVerifier) verifyBatch() (bool, error) {
+	c := b.Curve
+	n := len(b.LeftGenerators)
+	rand, err := c.Rand()
+	if err != nil {
+		return false, err
+	}
+	// \sum G_i, needed to recompute the commitment com of each IPA
+	sumLeftGenerators := c.NewG1()
+	for _, g := range b.LeftGenerators {
+		sumLeftGenerators.Add(g)
+	}
+
+	// aggregated exponents of the generators shared by all proofs
+	leftExps := make([]*math.Zr, n)
+	rightExps := make([]*math.Zr, n)
+	for i := 0; i < n; i++ {
+		leftExps[i] = c.NewZrFromInt(0)
+		rightExps[i] = c.NewZrFromInt(0)
+	}
+	gExp := c.NewZrFromInt(0)
+	hExp := c.NewZrFromInt(0)
+	qExp := c.NewZrFromInt(0)
+	// acc accumulates the terms that depend on a single proof
+	acc := c.NewG1()
+
+	for k, proof := range b.proofs {
+		v := b.verifier(b.commitments[k])
+		ch, err := v.challenges(proof)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to compute challenges of range proof at index %d", k)
+		}
+		// random weights of the two equations of this proof
+		r1 := c.NewRandomZr(rand)
+		r2 := c.NewRandomZr(rand)
+
+		// first equation
+		gExp = c.ModAdd(gExp, c.ModMul(r1, c.ModSub(proof.Data.InnerProduct, ch.polEval, c.GroupOrder), c.GroupOrder), c.GroupOrder)
+		hExp = c.ModAdd(hExp, c.ModMul(r1, proof.Data.Tau, c.GroupOrder), c.GroupOrder)
+		acc.Sub(proof.Data.T1.Mul(c.ModMul(r1, ch.x, c.GroupOrder)))
+		acc.Sub(proof.Data.T2.Mul(c.ModMul(r1, ch.xSquare, c.GroupOrder)))
+		acc.Sub(b.commitments[k].Mul(c.ModMul(r1, ch.zSquare, c.GroupOrder)))
+
+		// second equation.
+		// recompute the generators H'_i = H_i^{1/y^i} and the commitment com, they are part of the IPA transcript
+		yInv := ch.y.Copy()
+		yInv.InvModP(c.GroupOrder)
+		yInvPow := make([]*math.Zr, n)
+		rightGeneratorsPrime := make([]*math.G1, n)
+		com := proof.Data.D.Mul(ch.x)
+		com.Add(proof.Data.C)
+		com.Sub(sumLeftGenerators.Mul(ch.z))
+		power2 := c.NewZrFromInt(1)
+		for i := 0; i < n; i++ {
+			if i == 0 {
+				yInvPow[0] = c.NewZrFromInt(1)
+			} else {
+				yInvPow[i] = c.ModMul(yInvPow[i-1], yInv, c.GroupOrder)
+				power2 = c.ModMul(power2, c.NewZrFromInt(2), c.GroupOrder)
+			}
+			rightGeneratorsPrime[i] = b.RightGenerators[i].Mul(yInvPow[i])
+			// zy^i + z^2 2^i
+			zi := c.ModAdd(c.ModMul(ch.z, ch.yPow[i], c.GroupOrder), c.ModMul(ch.zSquare, power2, c.GroupOrder), c.GroupOrder)
+			com.Add(b.RightGenerators[i].Mul(c.ModMul(zi, yInvPow[i], c.GroupOrder)))
+		}
+		com.Sub(b.P.Mul(proof.Data.Delta))
+
+		x0, err := ipaChallenge(proof.Data.InnerProduct, b.Q, b.LeftGenerators, rightGeneratorsPrime, com, c)
+		if err != nil {
+			return false, err
+		}
+
+		// round challenges
+		xs := make([]*math.Zr, b.NumberOfRounds)
+		for j := uint64(0); j < b.NumberOfRounds; j++ {
+			if proof.IPA.L[j] == nil || proof.IPA.R[j] == nil {
+				return false, errors.Errorf("invalid range proof at index %d: nil elements", k)
+			}
+			raw, err := common.GetG1Array([]*math.G1{proof.IPA.L[j], proof.IPA.R[j]}).Bytes()
+			if err != nil {
+				return false, err
+			}
+			xs[j] = c.HashToZr(raw)
+			xSquare := c.ModMul(xs[j], xs[j], c.GroupOrder)
+			xSquareInv := xSquare.Copy()
+			xSquareInv.InvModP(c.GroupOrder)
+			acc.Sub(proof.IPA.L[j].Mul(c.ModMul(r2, xSquare, c.GroupOrder)))
+			acc.Sub(proof.IPA.R[j].Mul(c.ModMul(r2, xSquareInv, c.GroupOrder)))
+		}
+		acc.Sub(com.Mul(r2))
+
+		// exponents of the reduced generators
+		s, sInv := reductionExponents(xs, n, c)
+		left := c.ModMul(r2, proof.IPA.Left, c.GroupOrder)
+		right := c.ModMul(r2, proof.IPA.Right, c.GroupOrder)
+		for i := 0; i < n; i++ {
+			leftExps[i] = c.ModAdd(leftExps[i], c.ModMul(left, s[i], c.GroupOrder), c.GroupOrder)
+			rightExps[i] = c.ModAdd(rightExps[i], c.ModMul(right, c.ModMul(sInv[i], yInvPow[i], c.GroupOrder), c.GroupOrder), c.GroupOrder)
+		}
+		ab := c.ModMul(proof.IPA.Left, proof.IPA.Right, c.GroupOrder)
+		qExp = c.ModAdd(qExp, c.ModMul(r2, c.ModMul(x0, c.ModSub(ab, proof.Data.InnerProduct, c.GroupOrder), c.GroupOrder), c.GroupOrder), c.GroupOrder)
+	}
+
+	acc.Add(b.CommitmentGenerators[0].Mul(gExp))
+	acc.Add(b.CommitmentGenerators[1].Mul(hExp))
+	acc.Add(b.Q.Mul(qExp))
+	for i := 0; i < n; i++ {
+		acc.Add(b.LeftGenerators[i].Mul(leftExps[i]))
+		acc.Add(b.RightGenerators[i].Mul(rightExps[i]))
+	}
+	return acc.IsInfinity(), nil
+}
+
+func (b *BatchVerifier) verifier(com *math.G1) *rangeVerifier {
+	return NewRangeVerifier(com, b.CommitmentGenerators, b.LeftGenerators, b.RightGenerators, b.P, b.Q, b.NumberOfRounds, b.BitLength, b.Curve)
+}
+
+// reductionExponents returns the exponents s_i such that, after the reduction of the IPA with challenges xs,
+// the left generator is \prod G_i^{s_i} and the right one \prod H_i^{1/s_i}, together with the inverses 1/s_i.
+// The first round splits the generators in halves, therefore s_i contains x_j if the bit of i of weight 2^{rounds-1-j} is set,
+// 1/x_j otherwise.
+func reductionExponents(xs []*math.Zr, n int, c *math.Curve) ([]*math.Zr, []*math.Zr) {
+	rounds := len(xs)
+	s := make([]*math.Zr, n)
+	sInv := make([]*math.Zr, n)
+	s[0] = c.NewZrFromInt(1)
+	sInv[0] = c.NewZrFromInt(1)
+	xSquares := make([]*math.Zr, rounds)
+	xSquaresInv := make([]*math.Zr, rounds)
+	for j, x := range xs {
+		xInv := x.Copy()
+		xInv.InvModP(c.GroupOrder)
+		s[0] = c.ModMul(s[0], xInv, c.GroupOrder)
+		sInv[0] = c.ModMul(sInv[0], x, c.GroupOrder)
+		xSquares[j] = c.ModMul(x, x, c.GroupOrder)
+		xSquaresInv[j] = c.ModMul(xInv, xInv, c.GroupOrder)
+	}
+	for i := 1; i < n; i++ {
+		// k is the position of the most significant bit of i
+		k := 0
+		for 1<<(k+1) <= i {
+			k++
+		}
+		s[i] = c.ModMul(s[i-1<<k], xSquares[rounds-1-k], c.GroupOrder)
+		sInv[i] = c.ModMul(sInv[i-1<<k], xSquaresInv[rounds-1-k], c.GroupOrder)
+	}
+	return s, sInv
+}
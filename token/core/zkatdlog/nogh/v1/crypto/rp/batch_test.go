@@ -0,0 +1,191 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+package rp_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/rp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Batch Verification", func() {
+	var (
+		params *rangeProofParams
+		coms   []*math.G1
+		proofs []*rp.RangeProof
+	)
+	BeforeEach(func() {
+		params = newRangeProofParams(math.Curves[math.BN254], 5)
+		coms, proofs = params.prove([]uint64{0, 1, 115, 1<<32 - 1})
+	})
+
+	Context("when all proofs are valid", func() {
+		It("succeeds", func() {
+			batch := params.batch()
+			for i := range proofs {
+				batch.Add(coms[i], proofs[i])
+			}
+			Expect(batch.Len()).To(Equal(4))
+			Expect(batch.Verify()).To(Succeed())
+		})
+	})
+
+	Context("when the batch is empty", func() {
+		It("succeeds", func() {
+			Expect(params.batch().Verify()).To(Succeed())
+		})
+	})
+
+	Context("when a proof does not match its commitment", func() {
+		It("reports the invalid proof", func() {
+			batch := params.batch()
+			batch.Add(coms[0], proofs[0])
+			batch.Add(coms[1], proofs[1])
+			batch.Add(coms[3], proofs[2])
+			err := batch.Verify()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid range proof at index 2"))
+		})
+	})
+
+	Context("when the IPA of a proof is tampered with", func() {
+		It("reports the invalid proof", func() {
+			proofs[1].IPA.Left = params.curve.ModAdd(proofs[1].IPA.Left, params.curve.NewZrFromInt(1), params.curve.GroupOrder)
+			batch := params.batch()
+			for i := range proofs {
+				batch.Add(coms[i], proofs[i])
+			}
+			err := batch.Verify()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid range proof at index 1: invalid IPA"))
+		})
+	})
+
+	Context("when a proof is not well-formed", func() {
+		It("fails", func() {
+			proofs[3].IPA.L = proofs[3].IPA.L[1:]
+			batch := params.batch()
+			for i := range proofs {
+				batch.Add(coms[i], proofs[i])
+			}
+			err := batch.Verify()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid range proof at index 3: invalid IPA proof"))
+		})
+	})
+
+	Context("when a value is out of range", func() {
+		It("fails", func() {
+			c := params.curve
+			rand, err := c.Rand()
+			Expect(err).NotTo(HaveOccurred())
+			// commit to 2^32, the prover only uses the first 32 bits
+			bf := c.NewRandomZr(rand)
+			com := params.commitmentGens[0].Mul(c.NewZrFromUint64(1 << 32))
+			com.Add(params.commitmentGens[1].Mul(bf))
+			proof, err := rp.NewRangeProver(com, 1<<32, params.commitmentGens, bf, params.leftGens, params.rightGens, params.P, params.Q, params.rounds, params.bitLength, c).Prove()
+			Expect(err).NotTo(HaveOccurred())
+
+			batch := params.batch()
+			batch.Add(coms[0], proofs[0])
+			batch.Add(com, proof)
+			err = batch.Verify()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid range proof at index 1"))
+		})
+	})
+})
+
+type rangeProofParams struct {
+	curve          *math.Curve
+	commitmentGens []*math.G1
+	leftGens       []*math.G1
+	rightGens      []*math.G1
+	P, Q           *math.G1
+	rounds         uint64
+	bitLength      uint64
+}
+
+func newRangeProofParams(curve *math.Curve, rounds uint64) *rangeProofParams {
+	rand, err := curve.Rand()
+	Expect(err).NotTo(HaveOccurred())
+	p := &rangeProofParams{
+		curve:     curve,
+		rounds:    rounds,
+		bitLength: 1 << rounds,
+		P:         curve.GenG1.Mul(curve.NewRandomZr(rand)),
+		Q:         curve.GenG1.Mul(curve.NewRandomZr(rand)),
+		commitmentGens: []*math.G1{
+			curve.GenG1.Mul(curve.NewRandomZr(rand)),
+			curve.GenG1.Mul(curve.NewRandomZr(rand)),
+		},
+	}
+	p.leftGens = make([]*math.G1, p.bitLength)
+	p.rightGens = make([]*math.G1, p.bitLength)
+	for i := 0; i < len(p.leftGens); i++ {
+		p.leftGens[i] = curve.HashToG1([]byte(strconv.Itoa(2 * i)))
+		p.rightGens[i] = curve.HashToG1([]byte(strconv.Itoa(2*i + 1)))
+	}
+	return p
+}
+
+func (p *rangeProofParams) prove(values []uint64) ([]*math.G1, []*rp.RangeProof) {
+	rand, err := p.curve.Rand()
+	Expect(err).NotTo(HaveOccurred())
+	coms := make([]*math.G1, len(values))
+	proofs := make([]*rp.RangeProof, len(values))
+	for i, value := range values {
+		bf := p.curve.NewRandomZr(rand)
+		coms[i] = p.commitmentGens[0].Mul(p.curve.NewZrFromUint64(value))
+		coms[i].Add(p.commitmentGens[1].Mul(bf))
+		proofs[i], err = rp.NewRangeProver(coms[i], value, p.commitmentGens, bf, p.leftGens, p.rightGens, p.P, p.Q, p.rounds, p.bitLength, p.curve).Prove()
+		Expect(err).NotTo(HaveOccurred())
+	}
+	return coms, proofs
+}
+
+func (p *rangeProofParams) batch() *rp.BatchVerifier {
+	return rp.NewBatchVerifier(p.commitmentGens, p.leftGens, p.rightGens, p.P, p.Q, p.rounds, p.bitLength, p.curve)
+}
+
+func BenchmarkRangeProofVerification(b *testing.B) {
+	RegisterTestingT(b)
+	params := newRangeProofParams(math.Curves[math.BN254], 6)
+	for _, n := range []int{1, 8, 32} {
+		values := make([]uint64, n)
+		for i := range values {
+			values[i] = uint64(i) * 1000
+		}
+		coms, proofs := params.prove(values)
+
+		b.Run(fmt.Sprintf("proofs=%d/individual", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j := range proofs {
+					err := rp.NewRangeVerifier(coms[j], params.commitmentGens, params.leftGens, params.rightGens, params.P, params.Q, params.rounds, params.bitLength, params.curve).Verify(proofs[j])
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("proofs=%d/batch", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				batch := params.batch()
+				for j := range proofs {
+					batch.Add(coms[j], proofs[j])
+				}
+				if err := batch.Verify(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -250,7 +250,54 @@ func (p *rangeProver) Prove() (*RangeProof, error) {
 
 // Verify enable a rangeVerifier to checks the validity of a RangeProof
 func (v *rangeVerifier) Verify(rp *RangeProof) error {
-	// check that the proof is well-formed
+	if err := checkRangeProof(rp); err != nil {
+		return err
+	}
+	ch, err := v.challenges(rp)
+	if err != nil {
+		return err
+	}
+
+	// com is should be equal to v.Commitment^{z^2} if p.Value falls within range
+	com := v.CommitmentGenerators[0].Mul(rp.Data.InnerProduct)
+	com.Add(v.CommitmentGenerators[1].Mul(rp.Data.Tau))
+	com.Sub(rp.Data.T1.Mul(ch.x))
+	com.Sub(rp.Data.T2.Mul(ch.xSquare))
+
+	comPrime := v.Commitment.Mul(ch.zSquare)
+	comPrime.Add(v.CommitmentGenerators[0].Mul(ch.polEval))
+
+	if !com.Equals(comPrime) {
+		return errors.New("invalid range proof")
+	}
+
+	// verify the IPA
+	err = v.verifyIPA(rp, ch.x, ch.yPow, ch.z, ch.zSquare)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// rangeChallenges contains the challenges of a range proof, as recomputed by the verifier
+type rangeChallenges struct {
+	x       *math.Zr
+	xSquare *math.Zr
+	y       *math.Zr
+	z       *math.Zr
+	zSquare *math.Zr
+	// yPow contains y^i
+	yPow []*math.Zr
+	// polEval = (z -z^2)\sum y^i - z^3\sum 2^i
+	polEval *math.Zr
+}
+
+// checkRangeProof checks that the passed proof is well-formed
+func checkRangeProof(rp *RangeProof) error {
+	if rp.Data == nil || rp.IPA == nil {
+		return errors.New("invalid range proof: nil elements")
+	}
 	if rp.Data.InnerProduct == nil || rp.Data.C == nil || rp.Data.D == nil {
 		return errors.New("invalid range proof: nil elements")
 	}
@@ -260,13 +307,15 @@ func (v *rangeVerifier) Verify(rp *RangeProof) error {
 	if rp.Data.Tau == nil || rp.Data.Delta == nil {
 		return errors.New("invalid range proof: nil elements")
 	}
-	if rp.IPA == nil {
-		return errors.New("invalid range proof: nil elements")
-	}
+	return nil
+}
+
+// challenges recomputes the challenges of the passed well-formed proof
+func (v *rangeVerifier) challenges(rp *RangeProof) (*rangeChallenges, error) {
 	array := common.GetG1Array([]*math.G1{rp.Data.T1, rp.Data.T2})
 	bytesToHash, err := array.Bytes()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// compute x and x^2
 	x := v.Curve.HashToZr(bytesToHash)
@@ -276,7 +325,7 @@ func (v *rangeVerifier) Verify(rp *RangeProof) error {
 	array = common.GetG1Array([]*math.G1{rp.Data.C, rp.Data.D, v.Commitment})
 	bytesToHash, err = array.Bytes()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	y := v.Curve.HashToZr(bytesToHash)
 	z := v.Curve.HashToZr(y.Bytes())
@@ -310,26 +359,15 @@ func (v *rangeVerifier) Verify(rp *RangeProof) error {
 
 	polEval = v.Curve.ModSub(polEval, zCube, v.Curve.GroupOrder)
 
-	// com is should be equal to v.Commitment^{z^2} if p.Value falls within range
-	com := v.CommitmentGenerators[0].Mul(rp.Data.InnerProduct)
-	com.Add(v.CommitmentGenerators[1].Mul(rp.Data.Tau))
-	com.Sub(rp.Data.T1.Mul(x))
-	com.Sub(rp.Data.T2.Mul(xSquare))
-
-	comPrime := v.Commitment.Mul(zSquare)
-	comPrime.Add(v.CommitmentGenerators[0].Mul(polEval))
-
-	if !com.Equals(comPrime) {
-		return errors.New("invalid range proof")
-	}
-
-	// verify the IPA
-	err = v.verifyIPA(rp, x, yPow, z, zSquare)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return &rangeChallenges{
+		x:       x,
+		xSquare: xSquare,
+		y:       y,
+		z:       z,
+		zSquare: zSquare,
+		yPow:    yPow,
+		polEval: polEval,
+	}, nil
 }
 
 // preprocess prepares data for the inner product argument
@@ -156,21 +156,11 @@ func NewIPAVerifier(
 
 // Prove returns an IPA proof if no error occurs, else, it returns an error
 func (p *ipaProver) Prove() (*IPA, error) {
-	array := common.GetG1Array(p.RightGenerators, p.LeftGenerators, []*mathlib.G1{p.Q, p.Commitment})
-	bytesToHash := make([][]byte, 3)
-	var err error
-	bytesToHash[0], err = array.Bytes()
-	if err != nil {
-		return nil, err
-	}
-	bytesToHash[1] = []byte(common.Separator)
-	bytesToHash[2] = p.InnerProduct.Bytes()
-	raw, err := asn1.MarshalStd(bytesToHash)
+	// compute first challenge
+	x, err := ipaChallenge(p.InnerProduct, p.Q, p.LeftGenerators, p.RightGenerators, p.Commitment, p.Curve)
 	if err != nil {
 		return nil, err
 	}
-	// compute first challenge
-	x := p.Curve.HashToZr(raw)
 	// compute a commitment to inner product value and the vectors
 	C := p.Q.Mul(p.Curve.ModMul(x, p.InnerProduct, p.Curve.GroupOrder))
 	C.Add(p.Commitment)
@@ -197,20 +187,10 @@ func (v *ipaVerifier) Verify(proof *IPA) error {
 		return errors.New("invalid IPA proof")
 	}
 	// compute the first challenge x
-	array := common.GetG1Array(v.RightGenerators, v.LeftGenerators, []*mathlib.G1{v.Q, v.Commitment})
-	bytesToHash := make([][]byte, 3)
-	var err error
-	bytesToHash[0], err = array.Bytes()
+	x, err := ipaChallenge(v.InnerProduct, v.Q, v.LeftGenerators, v.RightGenerators, v.Commitment, v.Curve)
 	if err != nil {
 		return err
 	}
-	bytesToHash[1] = []byte(common.Separator)
-	bytesToHash[2] = v.InnerProduct.Bytes()
-	raw, err := asn1.MarshalStd(bytesToHash)
-	if err != nil {
-		return err
-	}
-	x := v.Curve.HashToZr(raw)
 	// C is commitment to leftVector, rightVector and their inner product
 	C := v.Q.Mul(v.Curve.ModMul(x, v.InnerProduct, v.Curve.GroupOrder))
 	C.Add(v.Commitment)
@@ -227,8 +207,8 @@ func (v *ipaVerifier) Verify(proof *IPA) error {
 			return errors.New("invalid IPA proof: nil elements")
 		}
 		// compute the challenge x for each round of reduction
-		array = common.GetG1Array([]*mathlib.G1{proof.L[i], proof.R[i]})
-		raw, err = array.Bytes()
+		array := common.GetG1Array([]*mathlib.G1{proof.L[i], proof.R[i]})
+		raw, err := array.Bytes()
 		if err != nil {
 			return err
 		}
@@ -261,6 +241,24 @@ func (v *ipaVerifier) Verify(proof *IPA) error {
 
 }
 
+// ipaChallenge returns the first challenge of an IPA on the passed inner product, generators and commitment
+func ipaChallenge(innerProduct *mathlib.Zr, Q *mathlib.G1, leftGens, rightGens []*mathlib.G1, com *mathlib.G1, c *mathlib.Curve) (*mathlib.Zr, error) {
+	array := common.GetG1Array(rightGens, leftGens, []*mathlib.G1{Q, com})
+	bytesToHash := make([][]byte, 3)
+	var err error
+	bytesToHash[0], err = array.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	bytesToHash[1] = []byte(common.Separator)
+	bytesToHash[2] = innerProduct.Bytes()
+	raw, err := asn1.MarshalStd(bytesToHash)
+	if err != nil {
+		return nil, err
+	}
+	return c.HashToZr(raw), nil
+}
+
 // reduce returns two values left and right such that left is a function
 // of the left vector and right is a function of right vector.
 // Both vectors are committed in com which is passed as a parameter to reduce
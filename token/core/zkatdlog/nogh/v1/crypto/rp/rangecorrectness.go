@@ -134,7 +134,27 @@ func NewRangeCorrectnessVerifier(
 
 }
 
+// Verify checks the passed proofs against v.Commitments, in a single batch
 func (v *RangeCorrectnessVerifier) Verify(rc *RangeCorrectness) error {
+	batch := NewBatchVerifier(
+		v.PedersenParameters,
+		v.LeftGenerators,
+		v.RightGenerators,
+		v.P,
+		v.Q,
+		v.NumberOfRounds,
+		v.BitLength,
+		v.Curve,
+	)
+	if err := v.AddToBatch(batch, rc); err != nil {
+		return err
+	}
+	return batch.Verify()
+}
+
+// AddToBatch adds the passed proofs, for v.Commitments, to the passed batch.
+// The batch must have been created with the same parameters of this verifier.
+func (v *RangeCorrectnessVerifier) AddToBatch(batch *BatchVerifier, rc *RangeCorrectness) error {
 	if len(rc.Proofs) != len(v.Commitments) {
 		return errors.New("invalid range proof")
 	}
@@ -142,21 +162,7 @@ func (v *RangeCorrectnessVerifier) Verify(rc *RangeCorrectness) error {
 		if rc.Proofs[i] == nil {
 			return errors.Errorf("invalid range proof: nil proof at index %d", i)
 		}
-		bv := NewRangeVerifier(
-			v.Commitments[i],
-			v.PedersenParameters,
-			v.LeftGenerators,
-			v.RightGenerators,
-			v.P,
-			v.Q,
-			v.NumberOfRounds,
-			v.BitLength,
-			v.Curve,
-		)
-		err := bv.Verify(rc.Proofs[i])
-		if err != nil {
-			return errors.Wrapf(err, "invalid range proof at index %d", i)
-		}
+		batch.Add(v.Commitments[i], rc.Proofs[i])
 	}
 	return nil
 }
@@ -13,9 +13,12 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/deserializer"
 	idemix2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/idemix"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/htlc"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/timelock"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/multisig"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/validity"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/x509"
 	htlc2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/htlc"
+	timelock2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
 	"github.com/pkg/errors"
 )
 
@@ -40,7 +43,9 @@ func NewDeserializer(pp *v1.PublicParams) (*Deserializer, error) {
 	}
 	des.AddTypedVerifierDeserializer(x509.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(&x509.IdentityDeserializer{}, &x509.AuditMatcherDeserializer{}))
 	des.AddTypedVerifierDeserializer(htlc2.ScriptType, htlc.NewTypedIdentityDeserializer(des))
+	des.AddTypedVerifierDeserializer(timelock2.ScriptType, timelock.NewTypedIdentityDeserializer(des))
 	des.AddTypedVerifierDeserializer(multisig.Multisig, multisig.NewTypedIdentityDeserializer(des, des))
+	des.AddTypedVerifierDeserializer(validity.Validity, validity.NewTypedIdentityDeserializer(des))
 
 	return &Deserializer{Deserializer: common.NewDeserializer(idemix2.IdentityType, des, des, des, des, des)}, nil
 }
@@ -78,6 +83,8 @@ func NewEIDRHDeserializer() *EIDRHDeserializer {
 	d.AddDeserializer(idemix2.IdentityType, &idemix2.AuditInfoDeserializer{})
 	d.AddDeserializer(x509.IdentityType, &x509.AuditInfoDeserializer{})
 	d.AddDeserializer(htlc2.ScriptType, htlc.NewAuditDeserializer(&idemix2.AuditInfoDeserializer{}))
+	d.AddDeserializer(timelock2.ScriptType, htlc.NewAuditDeserializer(&idemix2.AuditInfoDeserializer{}))
 	d.AddDeserializer(multisig.Multisig, &multisig.AuditInfoDeserializer{})
+	d.AddDeserializer(validity.Validity, &idemix2.AuditInfoDeserializer{})
 	return d
 }
@@ -46,6 +46,38 @@ func (i *ActionInput) FromProtos(p *actions.IssueActionInput) error {
 	return nil
 }
 
+// TypeOpening discloses the type of the issued tokens by opening the commitment to type of the issue proof
+type TypeOpening struct {
+	// Type is the type of the issued tokens
+	Type token2.Type
+	// BlindingFactor is the blinding factor of the commitment to type
+	BlindingFactor *math.Zr
+}
+
+func (o *TypeOpening) ToProtos() (*actions.TypeOpening, error) {
+	bf, err := utils.ToProtoZr(o.BlindingFactor)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize blinding factor")
+	}
+	return &actions.TypeOpening{
+		Type:           string(o.Type),
+		BlindingFactor: bf,
+	}, nil
+}
+
+func (o *TypeOpening) FromProtos(p *actions.TypeOpening) error {
+	bf, err := utils.FromZrProto(p.BlindingFactor)
+	if err != nil {
+		return errors.Wrap(err, "failed to deserialize blinding factor")
+	}
+	if bf == nil {
+		return errors.New("invalid type opening: nil blinding factor")
+	}
+	o.Type = token2.Type(p.Type)
+	o.BlindingFactor = bf
+	return nil
+}
+
 // Action specifies an issue of one or more tokens
 type Action struct {
 	// Issuer is the identity of issuer
@@ -58,6 +90,8 @@ type Action struct {
 	Proof []byte
 	// Metadata of the issue action
 	Metadata map[string][]byte
+	// TypeOpening discloses the type of the issued tokens, it is set when the public parameters contain issuer policies
+	TypeOpening *TypeOpening
 }
 
 // NewAction instantiates an IssueAction given the passed arguments
@@ -215,6 +249,14 @@ func (i *Action) Serialize() ([]byte, error) {
 		return nil, errors.Wrap(err, "failed to serialize outputs")
 	}
 
+	var typeOpening *actions.TypeOpening
+	if i.TypeOpening != nil {
+		typeOpening, err = i.TypeOpening.ToProtos()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to serialize type opening")
+		}
+	}
+
 	issueAction := &actions.IssueAction{
 		Version: ProtocolV1,
 		Issuer: &pp.Identity{
@@ -225,7 +267,8 @@ func (i *Action) Serialize() ([]byte, error) {
 		Proof: &actions.Proof{
 			Proof: i.Proof,
 		},
-		Metadata: i.Metadata,
+		Metadata:    i.Metadata,
+		TypeOpening: typeOpening,
 	}
 	return proto.Marshal(issueAction)
 }
@@ -273,6 +316,12 @@ func (i *Action) Deserialize(raw []byte) error {
 		i.Issuer = issueAction.Issuer.Raw
 	}
 	i.Metadata = issueAction.Metadata
+	if issueAction.TypeOpening != nil {
+		i.TypeOpening = &TypeOpening{}
+		if err := i.TypeOpening.FromProtos(issueAction.TypeOpening); err != nil {
+			return errors.Wrap(err, "failed to deserialize type opening")
+		}
+	}
 
 	return nil
 }
@@ -69,6 +69,13 @@ func (i *Issuer) GenerateZKIssue(values []uint64, owners [][]byte) (*Action, []*
 	if err != nil {
 		return nil, nil, err
 	}
+	// the issuer policies can be enforced only if the type is disclosed
+	if len(i.PublicParams.IssuerPolicies) != 0 {
+		issue.TypeOpening = &TypeOpening{
+			Type:           i.Type,
+			BlindingFactor: prover.SameType.blindingFactor,
+		}
+	}
 
 	inf := make([]*token.Metadata, len(values))
 	for j := 0; j < len(inf); j++ {
@@ -28,6 +28,27 @@ func NewVerifier(tokens []*math.G1, pp *v1.PublicParams) *Verifier {
 	return v
 }
 
+// VerifyTypeOpening returns an error if the passed opening does not open the commitment to type contained in the passed proof
+func VerifyTypeOpening(opening *TypeOpening, proof []byte, pp *v1.PublicParams) error {
+	if opening == nil || opening.BlindingFactor == nil {
+		return errors.New("invalid type opening: it is nil")
+	}
+	tp := &Proof{}
+	if err := tp.Deserialize(proof); err != nil {
+		return errors.Wrap(err, "failed to deserialize issue proof")
+	}
+	if tp.SameType == nil || tp.SameType.CommitmentToType == nil {
+		return errors.New("invalid issue proof: nil commitment to type")
+	}
+	c := math.Curves[pp.Curve]
+	com := pp.PedersenGenerators[0].Mul(c.HashToZr([]byte(opening.Type)))
+	com.Add(pp.PedersenGenerators[2].Mul(opening.BlindingFactor))
+	if !com.Equals(tp.SameType.CommitmentToType) {
+		return errors.Errorf("invalid type opening for type [%s]", opening.Type)
+	}
+	return nil
+}
+
 // Verify returns an error if Proof of an IssueAction is invalid
 func (v *Verifier) Verify(proof []byte) error {
 	tp := &Proof{}
@@ -58,6 +58,10 @@ func NewTokenService(
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to instantiate validator")
 	}
+	validator.Workers, err = common.ValidatorWorkers(configuration)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to instantiate validator")
+	}
 	s := &Service{
 		Service:   root,
 		validator: validator,
@@ -0,0 +1,47 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package setup
+
+import (
+	"strconv"
+	"sync"
+
+	mathlib "github.com/IBM/mathlib"
+)
+
+var aggregatedGenerators = &generatorsCache{
+	left:  map[mathlib.CurveID][]*mathlib.G1{},
+	right: map[mathlib.CurveID][]*mathlib.G1{},
+}
+
+// generatorsCache keeps, for each curve, the longest vectors of aggregated range proof generators derived so far.
+// The i-th generator does not depend on the length of the vector, therefore shorter vectors are prefixes of longer ones.
+type generatorsCache struct {
+	mutex sync.RWMutex
+	left  map[mathlib.CurveID][]*mathlib.G1
+	right map[mathlib.CurveID][]*mathlib.G1
+}
+
+func (c *generatorsCache) get(curveID mathlib.CurveID, length uint64) ([]*mathlib.G1, []*mathlib.G1) {
+	c.mutex.RLock()
+	left, right := c.left[curveID], c.right[curveID]
+	c.mutex.RUnlock()
+	if uint64(len(left)) >= length {
+		return left[:length], right[:length]
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	left, right = c.left[curveID], c.right[curveID]
+	curve := mathlib.Curves[curveID]
+	for i := uint64(len(left)); i < length; i++ {
+		left = append(left, curve.HashToG1([]byte("RangeProof.Aggregated."+strconv.FormatUint(2*(i+1), 10))))
+		right = append(right, curve.HashToG1([]byte("RangeProof.Aggregated."+strconv.FormatUint(2*(i+1)+1, 10))))
+	}
+	c.left[curveID], c.right[curveID] = left, right
+	return left[:length], right[:length]
+}
@@ -10,6 +10,7 @@ import (
 	"crypto/sha256"
 	"math/bits"
 	"strconv"
+	"strings"
 
 	mathlib "github.com/IBM/mathlib"
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/proto"
@@ -24,12 +25,15 @@ import (
 	pp2 "github.com/hyperledger-labs/fabric-token-sdk/token/driver/protos-go/pp"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/protos"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/slices"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 )
 
 const (
 	DLogPublicParameters = "zkatdlog"
 	ProtocolV1           = 1
+	// ProtocolV2 adds the support for aggregated range proofs
+	ProtocolV2 = 2
 )
 
 var (
@@ -170,14 +174,28 @@ type PublicParams struct {
 	// IdemixIssuerPublicKeys contains the idemix issuer public keys
 	// Wallets should prefer the use of keys valid under the public key whose index in the array is the smallest.
 	IdemixIssuerPublicKeys []*IdemixIssuerPublicKey
-	// Auditor is the public key of the auditor.
-	Auditor driver.Identity
+	// AuditorIDs is a list of public keys of the auditors.
+	AuditorIDs []driver.Identity
+	// AuditorThreshold is the number of auditors that must sign a token request.
+	// Zero means that all auditors must sign.
+	AuditorThreshold uint64
 	// IssuerIDs is a list of public keys of the entities that can issue tokens.
 	IssuerIDs []driver.Identity
+	// IssuerPolicies restricts the issuers of specific token types.
+	// Token types not matched by any policy can be issued by the entities in IssuerIDs.
+	IssuerPolicies driver.IssuerPolicies
+	// Fees are the policies of the fees charged on transfers, by token type
+	Fees driver.FeePolicies
+	// EnforcerIDs is a list of public keys of the entities that can freeze tokens and owners, and force transfers.
+	EnforcerIDs []driver.Identity
 	// MaxToken is the maximum quantity a token can hold
 	MaxToken uint64
 	// QuantityPrecision is the precision used to represent quantities
 	QuantityPrecision uint64
+	// AggregatedRangeProofs, if true, tells the provers to aggregate the range proofs of the outputs of a transfer
+	// into a single proof of logarithmic size. It requires ProtocolV2.
+	// Transfers with a range proof per output remain valid.
+	AggregatedRangeProofs bool
 }
 
 func NewPublicParamsFromBytes(raw []byte, label string) (*PublicParams, error) {
@@ -253,15 +271,79 @@ func (p *PublicParams) Bytes() ([]byte, error) {
 }
 
 func (p *PublicParams) Auditors() []driver.Identity {
-	if len(p.Auditor) == 0 {
+	if len(p.AuditorIDs) == 0 {
 		return []driver.Identity{}
 	}
-	return []driver.Identity{p.Auditor}
+	return p.AuditorIDs
 }
 
-// Issuers returns the list of authorized issuers
+// RequiredAuditorSignatures returns the number of auditors that must sign a token request
+func (p *PublicParams) RequiredAuditorSignatures() int {
+	if p.AuditorThreshold == 0 {
+		return len(p.AuditorIDs)
+	}
+	return int(p.AuditorThreshold)
+}
+
+// Issuers returns the list of authorized issuers, including those listed in the issuer policies
 func (p *PublicParams) Issuers() []driver.Identity {
-	return p.IssuerIDs
+	if len(p.IssuerPolicies) == 0 {
+		return p.IssuerIDs
+	}
+	issuers := append([]driver.Identity{}, p.IssuerIDs...)
+	for _, policy := range p.IssuerPolicies {
+		for _, issuer := range policy.Issuers {
+			if !driver.ContainsIssuer(issuers, issuer) {
+				issuers = append(issuers, issuer)
+			}
+		}
+	}
+	return issuers
+}
+
+// AuthorizedIssuers returns the issuers that can issue the passed token type
+func (p *PublicParams) AuthorizedIssuers(tokenType token2.Type) []driver.Identity {
+	return p.IssuerPolicies.AuthorizedIssuers(tokenType, p.IssuerIDs)
+}
+
+// AddIssuerPolicy restricts the issuers of the token types matching the passed pattern to the passed identities
+func (p *PublicParams) AddIssuerPolicy(tokenType string, issuers []driver.Identity) {
+	p.IssuerPolicies = append(p.IssuerPolicies, &driver.IssuerPolicy{TokenType: tokenType, Issuers: issuers})
+}
+
+// SetIssuerPolicies sets the issuer policies to the passed ones
+func (p *PublicParams) SetIssuerPolicies(policies driver.IssuerPolicies) {
+	p.IssuerPolicies = policies
+}
+
+// FeePolicies returns the fees charged on transfers
+func (p *PublicParams) FeePolicies() driver.FeePolicies {
+	return p.Fees
+}
+
+// AddFeePolicy charges the passed fee on the transfers of the token types matching the passed pattern
+func (p *PublicParams) AddFeePolicy(tokenType string, flat, rate uint64, collector driver.Identity) {
+	p.Fees = append(p.Fees, &driver.FeePolicy{TokenType: tokenType, Flat: flat, Rate: rate, Collector: collector})
+}
+
+// SetFeePolicies sets the fee policies to the passed ones
+func (p *PublicParams) SetFeePolicies(policies driver.FeePolicies) {
+	p.Fees = policies
+}
+
+// Enforcers returns the list of authorized enforcers
+func (p *PublicParams) Enforcers() []driver.Identity {
+	return p.EnforcerIDs
+}
+
+// AddEnforcer adds the passed enforcer to the array of enforcers in PublicParams
+func (p *PublicParams) AddEnforcer(enforcer driver.Identity) {
+	p.EnforcerIDs = append(p.EnforcerIDs, enforcer)
+}
+
+// SetEnforcers sets the enforcers to the passed identities
+func (p *PublicParams) SetEnforcers(ids []driver.Identity) {
+	p.EnforcerIDs = ids
 }
 
 func (p *PublicParams) Precision() uint64 {
@@ -285,6 +367,36 @@ func (p *PublicParams) Serialize() ([]byte, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to serialize issuer")
 	}
+	// the first auditor goes in the auditor field, as in previous versions, the others are appended
+	var auditor driver.Identity
+	var additionalAuditors []*pp.Identity
+	if len(p.AuditorIDs) != 0 {
+		auditor = p.AuditorIDs[0]
+		additionalAuditors, err = protos.ToProtosSliceFunc(p.AuditorIDs[1:], func(id driver.Identity) (*pp.Identity, error) {
+			return &pp.Identity{
+				Raw: id,
+			}, nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to serialize auditors")
+		}
+	}
+	issuerPolicies, err := issuerPoliciesToProtos(p.IssuerPolicies)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize issuer policies")
+	}
+	feePolicies, err := feePoliciesToProtos(p.Fees)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize fee policies")
+	}
+	enforcers, err := protos.ToProtosSliceFunc(p.EnforcerIDs, func(id driver.Identity) (*pp.Identity, error) {
+		return &pp.Identity{
+			Raw: id,
+		}, nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize enforcers")
+	}
 	idemixIssuerPublicKeys, err := protos.ToProtosSlice[pp.IdemixIssuerPublicKey, *IdemixIssuerPublicKey](p.IdemixIssuerPublicKeys)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to serialize idemix issuer public keys")
@@ -300,11 +412,17 @@ func (p *PublicParams) Serialize() ([]byte, error) {
 		RangeProofParams:       rpp,
 		IdemixIssuerPublicKeys: idemixIssuerPublicKeys,
 		Auditor: &pp.Identity{
-			Raw: p.Auditor,
+			Raw: auditor,
 		},
-		Issuers:           issuers,
-		MaxToken:          p.MaxToken,
-		QuantityPrecision: p.QuantityPrecision,
+		Issuers:               issuers,
+		MaxToken:              p.MaxToken,
+		QuantityPrecision:     p.QuantityPrecision,
+		AdditionalAuditors:    additionalAuditors,
+		AuditorThreshold:      p.AuditorThreshold,
+		IssuerPolicies:        issuerPolicies,
+		FeePolicies:           feePolicies,
+		AggregatedRangeProofs: p.AggregatedRangeProofs,
+		Enforcers:             enforcers,
 	}
 	raw, err := proto.Marshal(publicParams)
 	if err != nil {
@@ -359,8 +477,28 @@ func (p *PublicParams) Deserialize(raw []byte) error {
 	if err != nil {
 		return errors.Wrapf(err, "failed to deserialize idemix issuer public keys")
 	}
-	if publicParams.Auditor != nil {
-		p.Auditor = publicParams.Auditor.Raw
+	if publicParams.Auditor != nil && len(publicParams.Auditor.Raw) != 0 {
+		p.AuditorIDs = append(p.AuditorIDs, publicParams.Auditor.Raw)
+	}
+	for _, auditor := range publicParams.AdditionalAuditors {
+		if auditor == nil || len(auditor.Raw) == 0 {
+			return errors.New("failed to deserialize auditors: empty auditor identity")
+		}
+		p.AuditorIDs = append(p.AuditorIDs, auditor.Raw)
+	}
+	p.AuditorThreshold = publicParams.AuditorThreshold
+	p.AggregatedRangeProofs = publicParams.AggregatedRangeProofs
+	p.IssuerPolicies, err = issuerPoliciesFromProtos(publicParams.IssuerPolicies)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize issuer policies")
+	}
+	p.Fees, err = feePoliciesFromProtos(publicParams.FeePolicies)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize fee policies")
+	}
+	p.EnforcerIDs, err = enforcersFromProtos(publicParams.Enforcers)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize enforcers")
 	}
 
 	p.RangeProofParams = &RangeProofParams{}
@@ -405,8 +543,21 @@ func (p *PublicParams) GenerateRangeProofParameters(bitLength uint64) error {
 	return nil
 }
 
+// EnableAggregatedRangeProofs switches these public parameters to ProtocolV2 with aggregated range proofs
+func (p *PublicParams) EnableAggregatedRangeProofs() {
+	p.Ver = ProtocolV2
+	p.AggregatedRangeProofs = true
+}
+
+// AggregatedRangeProofGenerators returns the left and right generators of an aggregated range proof
+// whose vectors have the passed length.
+// The generators are derived by hashing to the curve, and they are cached per curve.
+func (p *PublicParams) AggregatedRangeProofGenerators(length uint64) ([]*mathlib.G1, []*mathlib.G1) {
+	return aggregatedGenerators.get(p.Curve, length)
+}
+
 func (p *PublicParams) AddAuditor(auditor driver.Identity) {
-	p.Auditor = auditor
+	p.AuditorIDs = append(p.AuditorIDs, auditor)
 }
 
 func (p *PublicParams) AddIssuer(id driver.Identity) {
@@ -420,7 +571,12 @@ func (p *PublicParams) SetIssuers(ids []driver.Identity) {
 
 // SetAuditors sets the auditors to the passed identities
 func (p *PublicParams) SetAuditors(ids []driver.Identity) {
-	p.Auditor = ids[0]
+	p.AuditorIDs = ids
+}
+
+// SetAuditorThreshold sets the number of auditors that must sign a token request, zero means all of them
+func (p *PublicParams) SetAuditorThreshold(threshold uint64) {
+	p.AuditorThreshold = threshold
 }
 
 func (p *PublicParams) ComputeHash() ([]byte, error) {
@@ -452,8 +608,11 @@ func (p *PublicParams) String() string {
 }
 
 func (p *PublicParams) Validate() error {
-	if p.Ver != ProtocolV1 {
-		return errors.Errorf("invalid version [%d], expected [%d]", p.Ver, ProtocolV1)
+	if p.Ver != ProtocolV1 && p.Ver != ProtocolV2 {
+		return errors.Errorf("invalid version [%d], expected [%d] or [%d]", p.Ver, ProtocolV1, ProtocolV2)
+	}
+	if p.AggregatedRangeProofs && p.Ver < ProtocolV2 {
+		return errors.Errorf("invalid public parameters: aggregated range proofs require version [%d], got [%d]", ProtocolV2, p.Ver)
 	}
 	if int(p.Curve) > len(mathlib.Curves)-1 {
 		return errors.Errorf("invalid public parameters: invalid curveID [%d > %d]", int(p.Curve), len(mathlib.Curves)-1)
@@ -495,12 +654,120 @@ func (p *PublicParams) Validate() error {
 	if maxToken != p.MaxToken {
 		return errors.Errorf("invalid maxt token, [%d]!=[%d]", maxToken, p.MaxToken)
 	}
-	if len(p.IssuerIDs) == 0 {
+	if len(p.IssuerIDs) == 0 && len(p.IssuerPolicies) == 0 {
 		return errors.New("invalid public parameters: empty list of issuers")
 	}
+	if err := p.IssuerPolicies.Validate(); err != nil {
+		return errors.Wrap(err, "invalid public parameters")
+	}
+	if err := p.Fees.Validate(); err != nil {
+		return errors.Wrap(err, "invalid public parameters")
+	}
+	for _, policy := range p.Fees {
+		// transfers hide their type, the validators can check that it is, or it is not, a given type, but not that it has a given prefix
+		if policy.TokenType != "*" && strings.HasSuffix(policy.TokenType, "*") {
+			return errors.Errorf("invalid public parameters: invalid fee policy for token type [%s], prefixes are not supported, use either a token type or '*'", policy.TokenType)
+		}
+	}
+	for i, enforcer := range p.EnforcerIDs {
+		if enforcer.IsNone() {
+			return errors.Errorf("invalid public parameters: empty enforcer at index [%d]", i)
+		}
+	}
+	auditors := make(map[string]struct{}, len(p.AuditorIDs))
+	for i, auditor := range p.AuditorIDs {
+		if auditor.IsNone() {
+			return errors.Errorf("invalid public parameters: empty auditor at index [%d]", i)
+		}
+		if _, ok := auditors[auditor.UniqueID()]; ok {
+			return errors.Errorf("invalid public parameters: duplicate auditor at index [%d]", i)
+		}
+		auditors[auditor.UniqueID()] = struct{}{}
+	}
+	if p.AuditorThreshold > uint64(len(p.AuditorIDs)) {
+		return errors.Errorf("invalid public parameters: auditor threshold [%d] greater than the number of auditors [%d]", p.AuditorThreshold, len(p.AuditorIDs))
+	}
 	return nil
 }
 
 func log2(x uint64) uint64 {
 	return 63 - uint64(bits.LeadingZeros64(x))
 }
+
+func issuerPoliciesToProtos(policies driver.IssuerPolicies) ([]*pp.IssuerPolicy, error) {
+	return protos.ToProtosSliceFunc(policies, func(policy *driver.IssuerPolicy) (*pp.IssuerPolicy, error) {
+		issuers, err := protos.ToProtosSliceFunc(policy.Issuers, func(id driver.Identity) (*pp.Identity, error) {
+			return &pp.Identity{
+				Raw: id,
+			}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &pp.IssuerPolicy{
+			TokenType: policy.TokenType,
+			Issuers:   issuers,
+		}, nil
+	})
+}
+
+func issuerPoliciesFromProtos(policies []*pp.IssuerPolicy) (driver.IssuerPolicies, error) {
+	return protos.FromProtosSliceFunc2(policies, func(policy *pp.IssuerPolicy) (*driver.IssuerPolicy, error) {
+		if policy == nil {
+			return nil, errors.New("nil issuer policy")
+		}
+		issuers, err := protos.FromProtosSliceFunc2(policy.Issuers, func(id *pp.Identity) (driver.Identity, error) {
+			if id == nil {
+				return nil, nil
+			}
+			return id.Raw, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &driver.IssuerPolicy{
+			TokenType: policy.TokenType,
+			Issuers:   issuers,
+		}, nil
+	})
+}
+
+func feePoliciesToProtos(policies driver.FeePolicies) ([]*pp.FeePolicy, error) {
+	return protos.ToProtosSliceFunc(policies, func(policy *driver.FeePolicy) (*pp.FeePolicy, error) {
+		return &pp.FeePolicy{
+			TokenType: policy.TokenType,
+			Flat:      policy.Flat,
+			Rate:      policy.Rate,
+			Collector: &pp.Identity{
+				Raw: policy.Collector,
+			},
+		}, nil
+	})
+}
+
+func feePoliciesFromProtos(policies []*pp.FeePolicy) (driver.FeePolicies, error) {
+	return protos.FromProtosSliceFunc2(policies, func(policy *pp.FeePolicy) (*driver.FeePolicy, error) {
+		if policy == nil {
+			return nil, errors.New("nil fee policy")
+		}
+		var collector driver.Identity
+		if policy.Collector != nil {
+			collector = policy.Collector.Raw
+		}
+		return &driver.FeePolicy{
+			TokenType: policy.TokenType,
+			Flat:      policy.Flat,
+			Rate:      policy.Rate,
+			Collector: collector,
+		}, nil
+	})
+}
+
+func enforcersFromProtos(enforcers []*pp.Identity) ([]driver.Identity, error) {
+	return protos.FromProtosSliceFunc2(enforcers, func(id *pp.Identity) (driver.Identity, error) {
+		if id == nil || len(id.Raw) == 0 {
+			return nil, errors.New("empty enforcer identity")
+		}
+		return id.Raw, nil
+	})
+}
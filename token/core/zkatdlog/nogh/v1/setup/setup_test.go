@@ -42,6 +42,48 @@ func TestSerialization(t *testing.T) {
 	assert.NoError(t, pp.Validate())
 }
 
+func TestSerializationWithAuditors(t *testing.T) {
+	issuerPK, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := Setup(32, issuerPK, math3.BN254)
+	assert.NoError(t, err)
+	pp.IssuerIDs = []driver.Identity{[]byte("issuer")}
+
+	// a single auditor is encoded as in previous versions
+	pp.AddAuditor([]byte("regulator"))
+	assert.Equal(t, 1, pp.RequiredAuditorSignatures())
+	ser, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(ser, DLogPublicParameters)
+	assert.NoError(t, err)
+	assert.Equal(t, pp, pp2)
+
+	// more auditors with a 1-of-2 policy
+	pp.AddAuditor([]byte("compliance"))
+	pp.SetAuditorThreshold(1)
+	assert.NoError(t, pp.Validate())
+	ser, err = pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err = NewPublicParamsFromBytes(ser, DLogPublicParameters)
+	assert.NoError(t, err)
+	assert.Equal(t, pp, pp2)
+	assert.Equal(t, []driver.Identity{[]byte("regulator"), []byte("compliance")}, pp2.Auditors())
+	assert.Equal(t, 1, pp2.RequiredAuditorSignatures())
+
+	// all auditors
+	pp.SetAuditorThreshold(0)
+	assert.Equal(t, 2, pp.RequiredAuditorSignatures())
+
+	// the threshold cannot exceed the number of auditors
+	pp.SetAuditorThreshold(3)
+	assert.EqualError(t, pp.Validate(), "invalid public parameters: auditor threshold [3] greater than the number of auditors [2]")
+
+	// a repeated auditor would count twice towards the threshold
+	pp.SetAuditors([]driver.Identity{[]byte("regulator"), []byte("regulator")})
+	pp.SetAuditorThreshold(2)
+	assert.EqualError(t, pp.Validate(), "invalid public parameters: duplicate auditor at index [1]")
+}
+
 func TestComputeMaxTokenValue(t *testing.T) {
 	pp := PublicParams{
 		RangeProofParams: &RangeProofParams{
@@ -66,3 +108,105 @@ func TestNewG1(t *testing.T) {
 		assert.Equal(t, c.NewG1().IsInfinity(), true)
 	}
 }
+
+func TestSerializationWithIssuerPolicies(t *testing.T) {
+	issuerPK, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := Setup(32, issuerPK, math3.BN254)
+	assert.NoError(t, err)
+	pp.AddAuditor([]byte("auditor"))
+
+	// policies alone are enough to have valid issuers
+	pp.AddIssuerPolicy("USD", []driver.Identity{[]byte("fed")})
+	pp.AddIssuerPolicy("EUR*", []driver.Identity{[]byte("ecb"), []byte("bde")})
+	assert.NoError(t, pp.Validate())
+	ser, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(ser, DLogPublicParameters)
+	assert.NoError(t, err)
+	assert.Equal(t, pp, pp2)
+	assert.Equal(t, []driver.Identity{[]byte("fed"), []byte("ecb"), []byte("bde")}, pp2.Issuers())
+	assert.Equal(t, []driver.Identity{[]byte("ecb"), []byte("bde")}, pp2.AuthorizedIssuers("EUR.digital"))
+	assert.Empty(t, pp2.AuthorizedIssuers("CHF"))
+
+	// duplicate patterns are rejected
+	pp.AddIssuerPolicy("USD", []driver.Identity{[]byte("bank")})
+	assert.Error(t, pp.Validate())
+}
+
+func TestSerializationWithFeePolicies(t *testing.T) {
+	issuerPK, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := Setup(32, issuerPK, math3.BN254)
+	assert.NoError(t, err)
+	pp.IssuerIDs = []driver.Identity{[]byte("issuer")}
+
+	pp.AddFeePolicy("*", 2, 0, []byte("collector"))
+	pp.AddFeePolicy("EUR", 0, 50, []byte("collector"))
+	assert.NoError(t, pp.Validate())
+	ser, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(ser, DLogPublicParameters)
+	assert.NoError(t, err)
+	assert.Equal(t, pp, pp2)
+	assert.Equal(t, uint64(50), pp2.FeePolicies().Lookup("EUR").Rate)
+	assert.Equal(t, uint64(2), pp2.FeePolicies().Lookup("USD").Flat)
+
+	pp.AddFeePolicy("CHF", 0, 0, []byte("collector"))
+	assert.EqualError(t, pp.Validate(), "invalid public parameters: invalid fee policy: no fee for token type [CHF]")
+
+	// transfers hide their type, a policy cannot apply to a prefix
+	pp.SetFeePolicies(driver.FeePolicies{{TokenType: "US*", Flat: 1, Collector: []byte("collector")}})
+	assert.EqualError(t, pp.Validate(), "invalid public parameters: invalid fee policy for token type [US*], prefixes are not supported, use either a token type or '*'")
+}
+
+func TestSerializationWithEnforcers(t *testing.T) {
+	issuerPK, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := Setup(32, issuerPK, math3.BN254)
+	assert.NoError(t, err)
+	pp.IssuerIDs = []driver.Identity{[]byte("issuer")}
+
+	pp.AddEnforcer([]byte("enforcer"))
+	assert.NoError(t, pp.Validate())
+	ser, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(ser, DLogPublicParameters)
+	assert.NoError(t, err)
+	assert.Equal(t, pp, pp2)
+	assert.Equal(t, []driver.Identity{[]byte("enforcer")}, pp2.Enforcers())
+
+	pp.SetEnforcers([]driver.Identity{[]byte("enforcer"), {}})
+	assert.EqualError(t, pp.Validate(), "invalid public parameters: empty enforcer at index [1]")
+}
+
+func TestSerializationWithAggregatedRangeProofs(t *testing.T) {
+	issuerPK, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := Setup(32, issuerPK, math3.BN254)
+	assert.NoError(t, err)
+	pp.IssuerIDs = []driver.Identity{[]byte("issuer")}
+
+	pp.EnableAggregatedRangeProofs()
+	assert.Equal(t, uint64(ProtocolV2), pp.Version())
+	assert.NoError(t, pp.Validate())
+	ser, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(ser, DLogPublicParameters)
+	assert.NoError(t, err)
+	assert.Equal(t, pp, pp2)
+	assert.True(t, pp2.AggregatedRangeProofs)
+
+	// aggregated range proofs are not available in version 1
+	pp.Ver = ProtocolV1
+	assert.Error(t, pp.Validate())
+
+	// generators are stable, shorter vectors are prefixes of longer ones
+	left, right := pp.AggregatedRangeProofGenerators(64)
+	assert.Len(t, left, 64)
+	assert.Len(t, right, 64)
+	left2, right2 := pp.AggregatedRangeProofGenerators(128)
+	assert.Equal(t, left, left2[:64])
+	assert.Equal(t, right, right2[:64])
+	assert.False(t, left2[0].Equals(right2[0]))
+}
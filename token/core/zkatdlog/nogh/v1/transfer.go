@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/transfer"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/validity"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
 	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
@@ -142,6 +143,24 @@ func (s *TransferService) Transfer(
 	if err != nil {
 		return nil, nil, err
 	}
+	var enforcer driver.Identity
+	if opts != nil {
+		enforcer = opts.Enforcer
+	}
+	sender.Forced = !enforcer.IsNone()
+	// the outputs inherit the validity window of the inputs, if any
+	inputOwners := make([]driver.Identity, 0, len(prepareInputs))
+	for _, input := range prepareInputs {
+		inputOwners = append(inputOwners, input.Owner)
+	}
+	outputOwners := make([]driver.Identity, len(outputTokens))
+	for i, output := range outputTokens {
+		outputOwners[i] = output.Owner
+	}
+	outputOwners, err = validity.CarryOver(inputOwners, outputOwners, time.Now())
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed to carry over the validity window of the inputs")
+	}
 	values := make([]uint64, 0, len(outputTokens))
 	owners := make([][]byte, 0, len(outputTokens))
 	// get values and owners of outputs
@@ -152,7 +171,7 @@ func (s *TransferService) Transfer(
 			return nil, nil, errors.Wrapf(err, "failed to get value for %dth output", i)
 		}
 		values = append(values, q.ToBigInt().Uint64())
-		owners = append(owners, output.Owner)
+		owners = append(owners, outputOwners[i])
 	}
 	// produce zkatdlog transfer action
 	// return for each output its information in the clear
@@ -170,6 +189,7 @@ func (s *TransferService) Transfer(
 	if opts != nil {
 		transfer.Metadata = meta.TransferActionMetadata(opts.Attributes)
 	}
+	transfer.Enforcer = enforcer
 
 	// add upgrade witness
 	for i, input := range transfer.Inputs {
@@ -202,23 +222,24 @@ func (s *TransferService) Transfer(
 	}
 
 	var transferOutputsMetadata []*driver.TransferOutputMetadata
-	for i, output := range outputTokens {
+	for i := range outputTokens {
 		var outputAudiInfo []byte
 		var receivers []driver.Identity
 		var receiversAuditInfo [][]byte
 		var outputReceivers []*driver.AuditableIdentity
 
-		if len(output.Owner) == 0 { // redeem
+		owner := outputOwners[i]
+		if len(owner) == 0 { // redeem
 			outputAudiInfo = nil
-			receivers = append(receivers, output.Owner)
+			receivers = append(receivers, owner)
 			receiversAuditInfo = append(receiversAuditInfo, []byte{})
 			outputReceivers = make([]*driver.AuditableIdentity, 0, 1)
 		} else {
-			outputAudiInfo, err = s.IdentityDeserializer.GetAuditInfo(output.Owner, ws)
+			outputAudiInfo, err = s.IdentityDeserializer.GetAuditInfo(owner, ws)
 			if err != nil {
-				return nil, nil, errors.Wrapf(err, "failed getting audit info for sender identity [%s]", driver.Identity(output.Owner))
+				return nil, nil, errors.Wrapf(err, "failed getting audit info for sender identity [%s]", owner)
 			}
-			recipients, err := s.IdentityDeserializer.Recipients(output.Owner)
+			recipients, err := s.IdentityDeserializer.Recipients(owner)
 			if err != nil {
 				return nil, nil, errors.Wrap(err, "failed getting recipients")
 			}
@@ -257,6 +278,7 @@ func (s *TransferService) Transfer(
 		Inputs:       transferInputsMetadata,
 		Outputs:      transferOutputsMetadata,
 		ExtraSigners: nil,
+		Enforcer:     enforcer,
 	}
 
 	return transfer, transferMetadata, nil
@@ -13,6 +13,7 @@ import (
 	factions "github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/protos-go/actions"
 	fv1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/actions"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/actions"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/pp"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/utils"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
@@ -123,6 +124,11 @@ type Action struct {
 	Proof []byte
 	// Metadata contains the transfer action's metadata
 	Metadata map[string][]byte
+	// Fee discloses the fee policy and the fee of the transfer, it is set when the public parameters contain fee policies
+	Fee *Fee
+	// Enforcer is the enforcer that forces the transfer, if any.
+	// It signs in place of the owners of the inputs.
+	Enforcer driver.Identity
 }
 
 // NewTransfer returns the Action that matches the passed arguments
@@ -322,6 +328,18 @@ func (t *Action) Serialize() ([]byte, error) {
 		},
 		Metadata: t.Metadata,
 	}
+	if t.Fee != nil {
+		action.Fee = &actions.TransferActionFee{
+			Policy: int32(t.Fee.Policy),
+			Value:  t.Fee.Value,
+			Proof:  t.Fee.Proof,
+		}
+	}
+	if !t.Enforcer.IsNone() {
+		action.Enforcer = &pp.Identity{
+			Raw: t.Enforcer,
+		}
+	}
 	return proto.Marshal(action)
 }
 
@@ -365,6 +383,16 @@ func (t *Action) Deserialize(raw []byte) error {
 		t.Proof = action.Proof.Proof
 	}
 	t.Metadata = action.Metadata
+	if action.Fee != nil {
+		t.Fee = &Fee{
+			Policy: int(action.Fee.Policy),
+			Value:  action.Fee.Value,
+			Proof:  action.Fee.Proof,
+		}
+	}
+	if action.Enforcer != nil {
+		t.Enforcer = action.Enforcer.Raw
+	}
 
 	return nil
 }
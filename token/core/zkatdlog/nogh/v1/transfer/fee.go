@@ -0,0 +1,583 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transfer
+
+import (
+	"math/big"
+	"strings"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/asn1"
+	crypto "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/rp"
+	v1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// NoFeePolicy is the policy of a Fee when no fee policy applies to the type of the transfer action
+const NoFeePolicy = -1
+
+// Fee discloses the fee policy applying to a transfer action and the total value of its outputs owned by the fee collector,
+// without revealing the token type of the action.
+// It is set when the public parameters contain fee policies.
+type Fee struct {
+	// Policy is the index in the public parameters of the fee policy applying to the type of the action, NoFeePolicy if none does
+	Policy int
+	// Value is the total value of the outputs owned by the collector of the policy, if any
+	Value uint64
+	// Proof is a serialized FeeProof
+	Proof []byte
+}
+
+// FeeProof is a zero-knowledge proof that shows that the disclosed policy and fee are correct,
+// and that the fee covers the rate charged on the payments, without revealing the type of the action and the value of the other outputs
+type FeeProof struct {
+	// Type is a commitment G_0^type G_2^r to the type of the action
+	Type *math.G1
+	// TypeOpening shows that Type commits to the type of the first output, that is, the knowledge of the opening of outputs[0]/Type
+	// in the bases G_1 and G_2.
+	// Recall that the proof of the transfer action shows that the inputs and outputs have all the same type.
+	TypeOpening *SchnorrProof
+	// PolicyType shows that Type commits to the token type of the disclosed policy, that is, the knowledge of the opening of
+	// Type/G_0^{policy type} in the base G_2.
+	// It is set only when the disclosed policy applies to a single token type.
+	PolicyType *SchnorrProof
+	// OtherTypes show that Type does not commit to the token type of any policy applying to a single token type, in order.
+	// For each such policy, with D = Type/G_0^{policy type} = G_0^d G_2^r, it shows the knowledge of the opening of G_0 in the bases D and G_2,
+	// that exists only if d is not zero.
+	// They are set only when the disclosed policy applies to all token types, or when there is no disclosed policy.
+	OtherTypes []*SchnorrProof
+	// Opening shows the knowledge of the opening of the fee outputs, given the disclosed value and the committed type,
+	// that is, of \prod_{i in fees} (outputs[i]/Type) / G_1^fee in the base G_2.
+	// It is set only when the action has fee outputs.
+	Opening *SchnorrProof
+	// RangeCorrectness shows that FeeRateDenominator*(fee - flat) - rate*payments is in [0, 2^BitLength).
+	// It is set only when the policy has a rate and the action has payments and fee outputs.
+	RangeCorrectness *rp.RangeProof
+}
+
+// Serialize marshals FeeProof
+func (p *FeeProof) Serialize() ([]byte, error) {
+	typ, err := asn1.MarshalMath(p.Type)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize type commitment")
+	}
+	others, err := asn1.NewArray(p.OtherTypes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize proofs of other types")
+	}
+	return asn1.Marshal[asn1.Serializer](&rawValue{raw: typ}, p.TypeOpening, p.PolicyType, others, p.Opening, p.RangeCorrectness)
+}
+
+// Deserialize unmarshals FeeProof
+func (p *FeeProof) Deserialize(bytes []byte) error {
+	typ := &rawValue{}
+	p.TypeOpening = &SchnorrProof{}
+	p.PolicyType = &SchnorrProof{}
+	others, err := asn1.NewArrayWithNew(func() *SchnorrProof { return &SchnorrProof{} })
+	if err != nil {
+		return err
+	}
+	p.Opening = &SchnorrProof{}
+	p.RangeCorrectness = &rp.RangeProof{}
+	if err := asn1.Unmarshal[asn1.Serializer](bytes, typ, p.TypeOpening, p.PolicyType, others, p.Opening, p.RangeCorrectness); err != nil {
+		return err
+	}
+	unmarshaller, err := asn1.NewUnmarshaller(typ.raw)
+	if err != nil {
+		return errors.Wrap(err, "failed to deserialize type commitment")
+	}
+	if p.Type, err = unmarshaller.NextG1(); err != nil {
+		return errors.Wrap(err, "failed to deserialize type commitment")
+	}
+	p.OtherTypes = others.Values
+	// the optional proofs are left empty when they are not marshalled
+	if p.PolicyType.Challenge == nil {
+		p.PolicyType = nil
+	}
+	if p.Opening.Challenge == nil {
+		p.Opening = nil
+	}
+	if p.RangeCorrectness.Data == nil {
+		p.RangeCorrectness = nil
+	}
+	return nil
+}
+
+// SchnorrProof is a proof of knowledge of the opening of a commitment in some bases B_1, ..., B_n,
+// that is, of exponents x_1, ..., x_n such that com = B_1^{x_1} ... B_n^{x_n}
+type SchnorrProof struct {
+	// Challenge is the Fiat-Shamir challenge
+	Challenge *math.Zr
+	// Responses are the responses for the exponents, one per base
+	Responses []*math.Zr
+}
+
+// Serialize marshals SchnorrProof
+func (p *SchnorrProof) Serialize() ([]byte, error) {
+	responses, err := asn1.NewElementArray(p.Responses)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.MarshalMath(p.Challenge, responses)
+}
+
+// Deserialize unmarshals SchnorrProof
+func (p *SchnorrProof) Deserialize(bytes []byte) error {
+	unmarshaller, err := asn1.NewUnmarshaller(bytes)
+	if err != nil {
+		return errors.Wrapf(err, "failed to prepare unmarshaller")
+	}
+	p.Challenge, err = unmarshaller.NextZr()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize challenge")
+	}
+	p.Responses, err = unmarshaller.NextZrArray()
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize responses")
+	}
+	return nil
+}
+
+// rawValue is an already serialized value
+type rawValue struct {
+	raw []byte
+}
+
+func (v *rawValue) Serialize() ([]byte, error) {
+	return v.raw, nil
+}
+
+func (v *rawValue) Deserialize(bytes []byte) error {
+	v.raw = bytes
+	return nil
+}
+
+// FeeProver produces the Fee of a transfer action
+type FeeProver struct {
+	pp      *v1.PublicParams
+	curve   *math.Curve
+	typ     token2.Type
+	outputs []*math.G1
+	witness []*token.Metadata
+	// policy is the index of the fee policy applying to typ, NoFeePolicy if none does
+	policy   int
+	fees     []int
+	payments []int
+}
+
+// NewFeeProver returns a FeeProver for the passed outputs and their openings
+func NewFeeProver(typ token2.Type, inputOwners, outputOwners []driver.Identity, outputs []*math.G1, outputWitness []*token.Metadata, pp *v1.PublicParams) (*FeeProver, error) {
+	if len(outputs) == 0 || len(outputs) != len(outputWitness) || len(outputs) != len(outputOwners) {
+		return nil, errors.Errorf("invalid number of outputs [%d], witnesses [%d], and owners [%d]", len(outputs), len(outputWitness), len(outputOwners))
+	}
+	p := &FeeProver{
+		pp:      pp,
+		curve:   math.Curves[pp.Curve],
+		typ:     typ,
+		outputs: outputs,
+		witness: outputWitness,
+		policy:  lookupPolicy(pp.FeePolicies(), typ),
+	}
+	if p.policy != NoFeePolicy {
+		p.fees, p.payments = pp.FeePolicies()[p.policy].SplitOutputs(inputOwners, outputOwners)
+	}
+	return p, nil
+}
+
+// Prove returns the Fee disclosing the policy and the fee paid by the outputs
+func (p *FeeProver) Prove() (*Fee, error) {
+	fee, err := sumValues(p.witness, p.fees)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid fee outputs")
+	}
+	if !fee.IsUint64() {
+		return nil, errors.Errorf("fee [%s] overflows", fee)
+	}
+	if _, err := sumValues(p.witness, []int{0}); err != nil {
+		return nil, err
+	}
+	c := p.curve
+	rand, err := c.Rand()
+	if err != nil {
+		return nil, err
+	}
+	policies := p.pp.FeePolicies()
+	g := p.pp.PedersenGenerators
+
+	// type
+	typ := c.HashToZr([]byte(p.typ))
+	typeBF := c.NewRandomZr(rand)
+	proof := &FeeProof{Type: g[0].Mul(typ)}
+	proof.Type.Add(g[2].Mul(typeBF))
+	com := p.outputs[0].Copy()
+	com.Sub(proof.Type)
+	proof.TypeOpening, err = proveRepresentation(p.pp, c, []*math.G1{g[1], g[2]}, com, []*math.Zr{
+		p.witness[0].Value,
+		c.ModSub(p.witness[0].BlindingFactor, typeBF, c.GroupOrder),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to prove the type commitment")
+	}
+
+	// policy
+	if p.policy != NoFeePolicy && appliesToSingleType(policies[p.policy]) {
+		proof.PolicyType, err = proveRepresentation(p.pp, c, []*math.G1{g[2]}, policyTypeCommitment(p.pp, c, proof.Type, policies[p.policy]), []*math.Zr{typeBF})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to prove the type of the policy")
+		}
+	} else {
+		for _, other := range policies {
+			if !appliesToSingleType(other) {
+				continue
+			}
+			// G_0 = D^{1/d} G_2^{-r/d}
+			d := c.ModSub(typ, c.HashToZr([]byte(other.TokenType)), c.GroupOrder)
+			if d.Equals(c.NewZrFromInt(0)) {
+				return nil, errors.Errorf("token type [%s] collides with the one of policy [%s]", p.typ, other.TokenType)
+			}
+			dInv := d.Copy()
+			dInv.InvModP(c.GroupOrder)
+			otherProof, err := proveRepresentation(p.pp, c, []*math.G1{policyTypeCommitment(p.pp, c, proof.Type, other), g[2]}, g[0], []*math.Zr{
+				dInv,
+				c.ModNeg(c.ModMul(typeBF, dInv, c.GroupOrder), c.GroupOrder),
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to prove that the type is not the one of policy [%s]", other.TokenType)
+			}
+			proof.OtherTypes = append(proof.OtherTypes, otherProof)
+		}
+	}
+
+	// opening of the fee outputs
+	if len(p.fees) != 0 {
+		bf := c.ModNeg(c.ModMul(c.NewZrFromInt(int64(len(p.fees))), typeBF, c.GroupOrder), c.GroupOrder)
+		for _, i := range p.fees {
+			bf = c.ModAdd(bf, p.witness[i].BlindingFactor, c.GroupOrder)
+		}
+		proof.Opening, err = proveRepresentation(p.pp, c, []*math.G1{g[2]}, openedCommitment(p.pp, c, proof.Type, fee.Uint64(), p.outputs, p.fees), []*math.Zr{bf})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to prove the opening of the fee")
+		}
+	}
+
+	// range, without fee outputs there is nothing to prove, the validators decide whether the action is exempt
+	if p.policy != NoFeePolicy && policies[p.policy].Rate != 0 && len(p.payments) != 0 && len(p.fees) != 0 {
+		policy := policies[p.policy]
+		payments, err := sumValues(p.witness, p.payments)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid payments")
+		}
+		slack := new(big.Int).Sub(new(big.Int).SetUint64(fee.Uint64()), new(big.Int).SetUint64(policy.Flat))
+		slack.Mul(slack, big.NewInt(driver.FeeRateDenominator))
+		slack.Sub(slack, new(big.Int).Mul(payments, new(big.Int).SetUint64(policy.Rate)))
+		if slack.Sign() < 0 {
+			return nil, errors.Errorf("insufficient fee [%s] for token type [%s], payments [%s]", fee, p.typ, payments)
+		}
+		if slack.BitLen() > int(p.pp.RangeProofParams.BitLength) {
+			return nil, errors.Errorf("fee [%s] for token type [%s] exceeds the required one by too much", fee, p.typ)
+		}
+		// FeeRateDenominator*(bf_fees - #fees*typeBF) - rate*(bf_payments - #payments*typeBF)
+		feesBF := c.ModNeg(c.ModMul(c.NewZrFromInt(int64(len(p.fees))), typeBF, c.GroupOrder), c.GroupOrder)
+		for _, i := range p.fees {
+			feesBF = c.ModAdd(feesBF, p.witness[i].BlindingFactor, c.GroupOrder)
+		}
+		paymentsBF := c.ModNeg(c.ModMul(c.NewZrFromInt(int64(len(p.payments))), typeBF, c.GroupOrder), c.GroupOrder)
+		for _, i := range p.payments {
+			paymentsBF = c.ModAdd(paymentsBF, p.witness[i].BlindingFactor, c.GroupOrder)
+		}
+		bf := c.ModSub(
+			c.ModMul(feesBF, c.NewZrFromUint64(driver.FeeRateDenominator), c.GroupOrder),
+			c.ModMul(paymentsBF, c.NewZrFromUint64(policy.Rate), c.GroupOrder),
+			c.GroupOrder,
+		)
+		proof.RangeCorrectness, err = rp.NewRangeProver(
+			feeRangeCommitment(p.pp, c, policy, proof.Type, p.outputs, p.fees, p.payments),
+			slack.Uint64(),
+			p.pp.PedersenGenerators[1:],
+			bf,
+			p.pp.RangeProofParams.LeftGenerators,
+			p.pp.RangeProofParams.RightGenerators,
+			p.pp.RangeProofParams.P,
+			p.pp.RangeProofParams.Q,
+			p.pp.RangeProofParams.NumberOfRounds,
+			p.pp.RangeProofParams.BitLength,
+			c,
+		).Prove()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to prove that the fee covers the payments")
+		}
+	}
+
+	raw, err := proof.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize fee proof")
+	}
+	return &Fee{Policy: p.policy, Value: fee.Uint64(), Proof: raw}, nil
+}
+
+// FeeVerifier checks that a transfer action pays the fee required by the public parameters
+type FeeVerifier struct {
+	pp           *v1.PublicParams
+	curve        *math.Curve
+	inputOwners  []driver.Identity
+	outputOwners []driver.Identity
+	outputs      []*math.G1
+}
+
+// NewFeeVerifier returns a FeeVerifier for the passed transfer action
+func NewFeeVerifier(inputOwners, outputOwners []driver.Identity, outputs []*math.G1, pp *v1.PublicParams) *FeeVerifier {
+	return &FeeVerifier{
+		pp:           pp,
+		curve:        math.Curves[pp.Curve],
+		inputOwners:  inputOwners,
+		outputOwners: outputOwners,
+		outputs:      outputs,
+	}
+}
+
+// Verify returns an error if the passed Fee does not show that the action pays the fee required by the policy applying to its type.
+func (v *FeeVerifier) Verify(fee *Fee) error {
+	if fee == nil {
+		return errors.New("invalid fee: it is nil")
+	}
+	if len(v.outputs) == 0 || len(v.outputs) != len(v.outputOwners) {
+		return errors.Errorf("invalid number of outputs [%d] and owners [%d]", len(v.outputs), len(v.outputOwners))
+	}
+	proof := &FeeProof{}
+	if err := proof.Deserialize(fee.Proof); err != nil {
+		return errors.Wrap(err, "failed to deserialize fee proof")
+	}
+	if proof.Type == nil {
+		return errors.New("invalid fee proof: missing type commitment")
+	}
+	c := v.curve
+	g := v.pp.PedersenGenerators
+	policies := v.pp.FeePolicies()
+
+	// type
+	com := v.outputs[0].Copy()
+	com.Sub(proof.Type)
+	if err := verifyRepresentation(v.pp, c, []*math.G1{g[1], g[2]}, com, proof.TypeOpening); err != nil {
+		return errors.Wrap(err, "invalid fee proof: invalid type commitment")
+	}
+
+	// policy
+	var policy *driver.FeePolicy
+	switch {
+	case fee.Policy == NoFeePolicy:
+	case fee.Policy >= 0 && fee.Policy < len(policies):
+		policy = policies[fee.Policy]
+	default:
+		return errors.Errorf("invalid fee policy [%d]", fee.Policy)
+	}
+	if policy != nil && appliesToSingleType(policy) {
+		if proof.PolicyType == nil || len(proof.OtherTypes) != 0 {
+			return errors.New("invalid fee proof: invalid type proofs")
+		}
+		if err := verifyRepresentation(v.pp, c, []*math.G1{g[2]}, policyTypeCommitment(v.pp, c, proof.Type, policy), proof.PolicyType); err != nil {
+			return errors.Wrapf(err, "invalid fee proof: the type is not [%s]", policy.TokenType)
+		}
+	} else {
+		// the type applies to no single-type policy, then to the policy applying to all types, if any
+		for _, other := range policies {
+			if policy == nil && !appliesToSingleType(other) {
+				return errors.Errorf("invalid fee policy [%d], policy [%s] applies", fee.Policy, other.TokenType)
+			}
+		}
+		if proof.PolicyType != nil {
+			return errors.New("invalid fee proof: invalid type proofs")
+		}
+		others := proof.OtherTypes
+		for _, other := range policies {
+			if !appliesToSingleType(other) {
+				continue
+			}
+			if len(others) == 0 {
+				return errors.New("invalid fee proof: invalid type proofs")
+			}
+			if err := verifyRepresentation(v.pp, c, []*math.G1{policyTypeCommitment(v.pp, c, proof.Type, other), g[2]}, g[0], others[0]); err != nil {
+				return errors.Wrapf(err, "invalid fee proof: the type might be [%s]", other.TokenType)
+			}
+			others = others[1:]
+		}
+		if len(others) != 0 {
+			return errors.New("invalid fee proof: invalid type proofs")
+		}
+	}
+
+	var fees, payments []int
+	if policy != nil {
+		fees, payments = policy.SplitOutputs(v.inputOwners, v.outputOwners)
+	}
+	if len(fees) == 0 && fee.Value != 0 {
+		return errors.Errorf("invalid fee [%d], there is no fee output", fee.Value)
+	}
+
+	// opening of the fee outputs
+	if (len(fees) != 0) != (proof.Opening != nil) {
+		return errors.New("invalid fee proof: invalid opening")
+	}
+	if len(fees) != 0 {
+		if err := verifyRepresentation(v.pp, c, []*math.G1{g[2]}, openedCommitment(v.pp, c, proof.Type, fee.Value, v.outputs, fees), proof.Opening); err != nil {
+			return errors.Wrapf(err, "invalid fee proof for token type [%s]", policy.TokenType)
+		}
+	}
+
+	if policy == nil || len(payments) == 0 {
+		return nil
+	}
+	if policy.Rate == 0 {
+		if fee.Value < policy.Flat {
+			return errors.Errorf("insufficient fee [%d] for token type [%s], required [%d]", fee.Value, policy.TokenType, policy.Flat)
+		}
+		return nil
+	}
+
+	// range
+	if len(fees) == 0 {
+		return errors.Errorf("insufficient fee for token type [%s], there is no fee output", policy.TokenType)
+	}
+	if proof.RangeCorrectness == nil {
+		return errors.New("invalid fee proof: missing range proof")
+	}
+	if err := rp.NewRangeVerifier(
+		feeRangeCommitment(v.pp, c, policy, proof.Type, v.outputs, fees, payments),
+		v.pp.PedersenGenerators[1:],
+		v.pp.RangeProofParams.LeftGenerators,
+		v.pp.RangeProofParams.RightGenerators,
+		v.pp.RangeProofParams.P,
+		v.pp.RangeProofParams.Q,
+		v.pp.RangeProofParams.NumberOfRounds,
+		v.pp.RangeProofParams.BitLength,
+		c,
+	).Verify(proof.RangeCorrectness); err != nil {
+		return errors.Wrapf(err, "insufficient fee [%d] for token type [%s]", fee.Value, policy.TokenType)
+	}
+	return nil
+}
+
+// lookupPolicy returns the index of the policy applying to the passed type, NoFeePolicy if none does
+func lookupPolicy(policies driver.FeePolicies, typ token2.Type) int {
+	policy := policies.Lookup(typ)
+	for i, p := range policies {
+		if policy != nil && p == policy {
+			return i
+		}
+	}
+	return NoFeePolicy
+}
+
+// appliesToSingleType returns true if the passed policy applies to a single token type
+func appliesToSingleType(policy *driver.FeePolicy) bool {
+	return !strings.HasSuffix(policy.TokenType, "*")
+}
+
+// policyTypeCommitment returns Type/G_0^{policy type}
+func policyTypeCommitment(pp *v1.PublicParams, c *math.Curve, typ *math.G1, policy *driver.FeePolicy) *math.G1 {
+	com := typ.Copy()
+	com.Sub(pp.PedersenGenerators[0].Mul(c.HashToZr([]byte(policy.TokenType))))
+	return com
+}
+
+// openedCommitment returns the commitment whose opening is shown by the fee proof: \prod_{i in fees} (outputs[i]/Type) / G_1^fee
+func openedCommitment(pp *v1.PublicParams, c *math.Curve, typ *math.G1, fee uint64, outputs []*math.G1, fees []int) *math.G1 {
+	com := sumCommitments(c, typ, outputs, fees)
+	com.Sub(pp.PedersenGenerators[1].Mul(c.NewZrFromUint64(fee)))
+	return com
+}
+
+// feeRangeCommitment returns the commitment to FeeRateDenominator*(fee - flat) - rate*payments
+func feeRangeCommitment(pp *v1.PublicParams, c *math.Curve, policy *driver.FeePolicy, typ *math.G1, outputs []*math.G1, fees, payments []int) *math.G1 {
+	com := sumCommitments(c, typ, outputs, fees)
+	com.Sub(pp.PedersenGenerators[1].Mul(c.NewZrFromUint64(policy.Flat)))
+	com = com.Mul(c.NewZrFromUint64(driver.FeeRateDenominator))
+	com.Sub(sumCommitments(c, typ, outputs, payments).Mul(c.NewZrFromUint64(policy.Rate)))
+	return com
+}
+
+// sumCommitments returns \prod_{i in indexes} outputs[i]/Type
+func sumCommitments(c *math.Curve, typ *math.G1, outputs []*math.G1, indexes []int) *math.G1 {
+	com := c.NewG1()
+	for _, i := range indexes {
+		com.Add(outputs[i])
+	}
+	com.Sub(typ.Mul(c.NewZrFromInt(int64(len(indexes)))))
+	return com
+}
+
+func sumValues(witness []*token.Metadata, indexes []int) (*big.Int, error) {
+	sum := new(big.Int)
+	for _, i := range indexes {
+		if witness[i] == nil || witness[i].Value == nil || witness[i].BlindingFactor == nil {
+			return nil, errors.Errorf("invalid witness for output [%d]", i)
+		}
+		v, err := witness[i].Value.Uint()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for output [%d]", i)
+		}
+		sum.Add(sum, new(big.Int).SetUint64(v))
+	}
+	return sum, nil
+}
+
+// proveRepresentation proves the knowledge of the passed exponents, such that com = \prod_i bases[i]^{exponents[i]}
+func proveRepresentation(pp *v1.PublicParams, c *math.Curve, bases []*math.G1, com *math.G1, exponents []*math.Zr) (*SchnorrProof, error) {
+	rand, err := c.Rand()
+	if err != nil {
+		return nil, err
+	}
+	randomness := make([]*math.Zr, len(exponents))
+	commitment := c.NewG1()
+	for i := range exponents {
+		randomness[i] = c.NewRandomZr(rand)
+		commitment.Add(bases[i].Mul(randomness[i]))
+	}
+	chal, err := representationChallenge(pp, c, bases, com, commitment)
+	if err != nil {
+		return nil, err
+	}
+	proof := &SchnorrProof{Challenge: chal, Responses: make([]*math.Zr, len(exponents))}
+	for i, exponent := range exponents {
+		proof.Responses[i] = c.ModAdd(c.ModMul(chal, exponent, c.GroupOrder), randomness[i], c.GroupOrder)
+	}
+	return proof, nil
+}
+
+// verifyRepresentation returns an error if the passed proof is not a valid proof of knowledge of the opening of com in the passed bases
+func verifyRepresentation(pp *v1.PublicParams, c *math.Curve, bases []*math.G1, com *math.G1, proof *SchnorrProof) error {
+	if proof == nil || proof.Challenge == nil || len(proof.Responses) != len(bases) {
+		return errors.New("invalid opening proof")
+	}
+	commitment := c.NewG1()
+	for i, base := range bases {
+		if proof.Responses[i] == nil {
+			return errors.New("invalid opening proof")
+		}
+		commitment.Add(base.Mul(proof.Responses[i]))
+	}
+	commitment.Sub(com.Mul(proof.Challenge))
+	chal, err := representationChallenge(pp, c, bases, com, commitment)
+	if err != nil {
+		return err
+	}
+	if !chal.Equals(proof.Challenge) {
+		return errors.New("invalid opening proof")
+	}
+	return nil
+}
+
+func representationChallenge(pp *v1.PublicParams, c *math.Curve, bases []*math.G1, com, commitment *math.G1) (*math.Zr, error) {
+	raw, err := crypto.GetG1Array(pp.PedersenGenerators, bases, []*math.G1{com, commitment}).Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot compute opening challenge")
+	}
+	return c.HashToZr(raw), nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+package transfer_test
+
+import (
+	math "github.com/IBM/mathlib"
+	v1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/setup"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/transfer"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fee", func() {
+	var (
+		pp          *v1.PublicParams
+		inputOwners []driver.Identity
+	)
+	BeforeEach(func() {
+		var err error
+		pp, err = v1.Setup(32, nil, math.FP256BN_AMCL)
+		Expect(err).NotTo(HaveOccurred())
+		pp.AddFeePolicy("EUR", 1, 50, []byte("collector"))
+		pp.AddFeePolicy("USD", 2, 150, []byte("collector"))
+		inputOwners = []driver.Identity{[]byte("alice")}
+	})
+
+	proveType := func(typ token2.Type, values []uint64, owners []driver.Identity) (*transfer.Fee, []*math.G1) {
+		out, outtw, err := token.GetTokensWithWitness(values, typ, pp.PedersenGenerators, math.Curves[pp.Curve])
+		Expect(err).NotTo(HaveOccurred())
+		prover, err := transfer.NewFeeProver(typ, inputOwners, owners, out, outtw, pp)
+		Expect(err).NotTo(HaveOccurred())
+		fee, err := prover.Prove()
+		Expect(err).NotTo(HaveOccurred())
+		return fee, out
+	}
+	prove := func(values []uint64, owners []driver.Identity) (*transfer.Fee, []*math.G1) {
+		return proveType("USD", values, owners)
+	}
+
+	It("accepts a fee that covers the payments", func() {
+		// 2 + ceil(1000 * 1.5%) = 17
+		owners := []driver.Identity{[]byte("bob"), []byte("alice"), []byte("collector")}
+		fee, out := prove([]uint64{1000, 50, 17}, owners)
+		Expect(fee.Policy).To(Equal(1))
+		Expect(fee.Value).To(Equal(uint64(17)))
+		Expect(string(fee.Proof)).NotTo(ContainSubstring("USD"))
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).To(Succeed())
+
+		// the fee is correct but the policy is not the one of the type
+		fee.Policy = 0
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).To(MatchError(ContainSubstring("the type is not [EUR]")))
+		fee.Policy = transfer.NoFeePolicy
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).NotTo(Succeed())
+		fee.Policy = 2
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).To(MatchError("invalid fee policy [2]"))
+	})
+	It("accepts a transfer without payments and without fee", func() {
+		owners := []driver.Identity{[]byte("alice"), nil}
+		fee, out := prove([]uint64{10, 20}, owners)
+		Expect(fee.Value).To(BeZero())
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).To(Succeed())
+	})
+	It("accepts a transfer of a type without policy", func() {
+		owners := []driver.Identity{[]byte("bob")}
+		fee, out := proveType("JPY", []uint64{1000}, owners)
+		Expect(fee.Policy).To(Equal(transfer.NoFeePolicy))
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).To(Succeed())
+	})
+	It("rejects a transfer that hides the policy of its type", func() {
+		owners := []driver.Identity{[]byte("bob")}
+		// the prover does not know about the USD policy
+		policies := pp.FeePolicies()
+		pp.SetFeePolicies(policies[:1])
+		fee, out := prove([]uint64{1000}, owners)
+		Expect(fee.Policy).To(Equal(transfer.NoFeePolicy))
+		pp.SetFeePolicies(policies)
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).To(MatchError("invalid fee proof: invalid type proofs"))
+
+		// the prover knows about the USD policy and claims there is none
+		fee, _ = proveType("JPY", []uint64{1000}, owners)
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).To(MatchError(ContainSubstring("invalid type commitment")))
+	})
+	It("applies the policy of all types to the other types", func() {
+		pp.AddFeePolicy("*", 3, 0, []byte("collector"))
+		owners := []driver.Identity{[]byte("bob"), []byte("collector")}
+		fee, out := proveType("JPY", []uint64{1000, 3}, owners)
+		Expect(fee.Policy).To(Equal(2))
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).To(Succeed())
+
+		// the USD policy applies to USD
+		fee, out = prove([]uint64{1000, 17}, owners)
+		Expect(fee.Policy).To(Equal(1))
+		fee.Policy = 2
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).NotTo(Succeed())
+
+		// there is always a policy
+		fee, out = proveType("JPY", []uint64{1000, 3}, owners)
+		fee.Policy = transfer.NoFeePolicy
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).To(MatchError("invalid fee policy [-1], policy [*] applies"))
+	})
+	It("rejects a fee that does not cover the payments", func() {
+		owners := []driver.Identity{[]byte("bob"), []byte("collector")}
+		fee, out := prove([]uint64{1000, 17}, owners)
+
+		// the validators charge a higher rate than the prover
+		pp.SetFeePolicies(driver.FeePolicies{pp.FeePolicies()[0], {TokenType: "USD", Flat: 2, Rate: 300, Collector: []byte("collector")}})
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).To(MatchError(ContainSubstring("insufficient fee [17] for token type [USD]")))
+	})
+	It("rejects a transfer with payments and without fee", func() {
+		owners := []driver.Identity{[]byte("bob")}
+		fee, out := prove([]uint64{1000}, owners)
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).To(MatchError("insufficient fee for token type [USD], there is no fee output"))
+
+		pp.SetFeePolicies(driver.FeePolicies{{TokenType: "USD", Flat: 2, Collector: []byte("collector")}})
+		fee, out = prove([]uint64{1000}, owners)
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).To(MatchError("insufficient fee [0] for token type [USD], required [2]"))
+	})
+	It("rejects a wrong fee value", func() {
+		owners := []driver.Identity{[]byte("bob"), []byte("collector")}
+		fee, out := prove([]uint64{1000, 17}, owners)
+		fee.Value = 100
+		Expect(transfer.NewFeeVerifier(inputOwners, owners, out, pp).Verify(fee)).NotTo(Succeed())
+	})
+	It("refuses to prove an insufficient fee", func() {
+		owners := []driver.Identity{[]byte("bob"), []byte("collector")}
+		out, outtw, err := token.GetTokensWithWitness([]uint64{1000, 16}, "USD", pp.PedersenGenerators, math.Curves[pp.Curve])
+		Expect(err).NotTo(HaveOccurred())
+		prover, err := transfer.NewFeeProver("USD", inputOwners, owners, out, outtw, pp)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = prover.Prove()
+		Expect(err).To(MatchError("insufficient fee [16] for token type [USD], payments [1000]"))
+	})
+})
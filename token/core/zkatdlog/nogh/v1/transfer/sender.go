@@ -39,6 +39,8 @@ type Sender struct {
 	// PublicParams refers to the public cryptographic parameters to be used
 	// to produce the TokenRequest
 	PublicParams *v1.PublicParams
+	// Forced is true if the transfer is forced by an enforcer, forced transfers do not pay fees
+	Forced bool
 }
 
 // NewSender returns a Sender
@@ -90,6 +92,25 @@ func (s *Sender) GenerateZKTransfer(ctx context.Context, values []uint64, owners
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to produce transfer action")
 	}
+	if len(s.PublicParams.FeePolicies()) != 0 && !s.Forced {
+		span.AddEvent("prove_fee")
+		inputOwners := make([]driver.Identity, len(s.Inputs))
+		for i, in := range s.Inputs {
+			inputOwners[i] = in.Owner
+		}
+		outputOwners := make([]driver.Identity, len(owners))
+		for i, owner := range owners {
+			outputOwners[i] = owner
+		}
+		feeProver, err := NewFeeProver(s.InputInformation[0].Type, inputOwners, outputOwners, out, outtw, s.PublicParams)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "cannot generate fee proof")
+		}
+		transfer.Fee, err = feeProver.Prove()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "cannot generate fee proof")
+		}
+	}
 	inf := make([]*token.Metadata, len(owners))
 	for i := 0; i < len(inf); i++ {
 		inf[i] = &token.Metadata{
@@ -25,24 +25,40 @@ type Proof struct {
 	TypeAndSum *TypeAndSumProof
 	// Proof that the outputs have value in the authorized range
 	RangeCorrectness *rp.RangeCorrectness
+	// AggregatedRangeCorrectness is a single proof that all outputs have value in the authorized range.
+	// It replaces RangeCorrectness when the public parameters enable aggregated range proofs.
+	AggregatedRangeCorrectness *rp.RangeProof
 }
 
 // Serialize marshals Proof
 func (p *Proof) Serialize() ([]byte, error) {
-	return asn1.Marshal[asn1.Serializer](p.TypeAndSum, p.RangeCorrectness)
+	if p.AggregatedRangeCorrectness == nil {
+		return asn1.Marshal[asn1.Serializer](p.TypeAndSum, p.RangeCorrectness)
+	}
+	return asn1.Marshal[asn1.Serializer](p.TypeAndSum, p.RangeCorrectness, p.AggregatedRangeCorrectness)
 }
 
 // Deserialize unmarshals Proof
 func (p *Proof) Deserialize(bytes []byte) error {
+	n, err := asn1.NumValues(bytes)
+	if err != nil {
+		return err
+	}
 	p.TypeAndSum = &TypeAndSumProof{}
 	p.RangeCorrectness = &rp.RangeCorrectness{}
-	return asn1.Unmarshal[asn1.Serializer](bytes, p.TypeAndSum, p.RangeCorrectness)
+	if n == 2 {
+		return asn1.Unmarshal[asn1.Serializer](bytes, p.TypeAndSum, p.RangeCorrectness)
+	}
+	p.AggregatedRangeCorrectness = &rp.RangeProof{}
+	return asn1.Unmarshal[asn1.Serializer](bytes, p.TypeAndSum, p.RangeCorrectness, p.AggregatedRangeCorrectness)
 }
 
 // Verifier verifies if a Action is valid
 type Verifier struct {
 	TypeAndSum       *TypeAndSumVerifier
 	RangeCorrectness *rp.RangeCorrectnessVerifier
+	// AggregatedRangeCorrectness is set if the public parameters enable aggregated range proofs
+	AggregatedRangeCorrectness *rp.AggregatedRangeVerifier
 }
 
 // NewVerifier returns a Action Verifier as a function of the passed parameters
@@ -54,6 +70,10 @@ func NewVerifier(inputs, outputs []*math.G1, pp *v1.PublicParams) *Verifier {
 	// if so, skip range proof, well-formedness proof is enough
 	if len(inputs) != 1 || len(outputs) != 1 {
 		v.RangeCorrectness = rp.NewRangeCorrectnessVerifier(pp.PedersenGenerators[1:], pp.RangeProofParams.LeftGenerators, pp.RangeProofParams.RightGenerators, pp.RangeProofParams.P, pp.RangeProofParams.Q, pp.RangeProofParams.BitLength, pp.RangeProofParams.NumberOfRounds, math.Curves[pp.Curve])
+		if pp.AggregatedRangeProofs {
+			left, right := pp.AggregatedRangeProofGenerators(rp.AggregatedLength(len(outputs), pp.RangeProofParams.BitLength))
+			v.AggregatedRangeCorrectness = rp.NewAggregatedRangeVerifier(nil, pp.PedersenGenerators[1:], left, right, pp.RangeProofParams.P, pp.RangeProofParams.Q, pp.RangeProofParams.BitLength, math.Curves[pp.Curve])
+		}
 	}
 
 	return v
@@ -63,6 +83,8 @@ func NewVerifier(inputs, outputs []*math.G1, pp *v1.PublicParams) *Verifier {
 type Prover struct {
 	TypeAndSum       *TypeAndSumProver
 	RangeCorrectness *rp.RangeCorrectnessProver
+	// AggregatedRangeCorrectness is set, in place of RangeCorrectness, if the public parameters enable aggregated range proofs
+	AggregatedRangeCorrectness *rp.AggregatedRangeProver
 }
 
 // NewProver returns a Action Prover that corresponds to the passed arguments
@@ -110,6 +132,22 @@ func NewProver(inputWitness, outputWitness []*token.Metadata, inputs, outputs []
 			coms[i] = outputs[i].Copy()
 			coms[i].Sub(commitmentToType)
 		}
+		if pp.AggregatedRangeProofs {
+			left, right := pp.AggregatedRangeProofGenerators(rp.AggregatedLength(len(coms), pp.RangeProofParams.BitLength))
+			p.AggregatedRangeCorrectness = rp.NewAggregatedRangeProver(
+				coms,
+				values,
+				blindingFactors,
+				pp.PedersenGenerators[1:],
+				left,
+				right,
+				pp.RangeProofParams.P,
+				pp.RangeProofParams.Q,
+				pp.RangeProofParams.BitLength,
+				math.Curves[pp.Curve],
+			)
+			return p, nil
+		}
 		p.RangeCorrectness = rp.NewRangeCorrectnessProver(
 			coms,
 			values,
@@ -135,6 +173,7 @@ func (p *Prover) Prove() ([]byte, error) {
 
 	var tsProof *TypeAndSumProof
 	var rangeProof *rp.RangeCorrectness
+	var aggregatedRangeProof *rp.RangeProof
 	var tsErr, rangeErr error
 
 	go func() {
@@ -142,6 +181,9 @@ func (p *Prover) Prove() ([]byte, error) {
 		if p.RangeCorrectness != nil {
 			rangeProof, rangeErr = p.RangeCorrectness.Prove()
 		}
+		if p.AggregatedRangeCorrectness != nil {
+			aggregatedRangeProof, rangeErr = p.AggregatedRangeCorrectness.Prove()
+		}
 	}()
 
 	tsProof, tsErr = p.TypeAndSum.Prove()
@@ -157,8 +199,9 @@ func (p *Prover) Prove() ([]byte, error) {
 	}
 
 	proof := &Proof{
-		TypeAndSum:       tsProof,
-		RangeCorrectness: rangeProof,
+		TypeAndSum:                 tsProof,
+		RangeCorrectness:           rangeProof,
+		AggregatedRangeCorrectness: aggregatedRangeProof,
 	}
 
 	return proof.Serialize()
@@ -187,18 +230,27 @@ func (v *Verifier) Verify(proof []byte) error {
 		defer wg.Done()
 		// verify range proof
 		if v.RangeCorrectness != nil {
-			if tp.RangeCorrectness == nil {
+			if tp.RangeCorrectness == nil && tp.AggregatedRangeCorrectness == nil {
 				rangeErr = errors.New("invalid transfer proof")
-			} else {
-				commitmentToType := tp.TypeAndSum.CommitmentToType.Copy()
-				coms := make([]*math.G1, len(v.TypeAndSum.Outputs))
-				for i := 0; i < len(v.TypeAndSum.Outputs); i++ {
-					coms[i] = v.TypeAndSum.Outputs[i].Copy()
-					coms[i].Sub(commitmentToType)
+				return
+			}
+			commitmentToType := tp.TypeAndSum.CommitmentToType.Copy()
+			coms := make([]*math.G1, len(v.TypeAndSum.Outputs))
+			for i := 0; i < len(v.TypeAndSum.Outputs); i++ {
+				coms[i] = v.TypeAndSum.Outputs[i].Copy()
+				coms[i].Sub(commitmentToType)
+			}
+			if tp.AggregatedRangeCorrectness != nil {
+				if v.AggregatedRangeCorrectness == nil {
+					rangeErr = errors.New("invalid transfer proof: aggregated range proofs are not enabled")
+					return
 				}
-				v.RangeCorrectness.Commitments = coms
-				rangeErr = v.RangeCorrectness.Verify(tp.RangeCorrectness)
+				v.AggregatedRangeCorrectness.Commitments = coms
+				rangeErr = v.AggregatedRangeCorrectness.Verify(tp.AggregatedRangeCorrectness)
+				return
 			}
+			v.RangeCorrectness.Commitments = coms
+			rangeErr = v.RangeCorrectness.Verify(tp.RangeCorrectness)
 		}
 	}()
 
@@ -56,6 +56,49 @@ var _ = Describe("Transfer", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
+		Context("aggregated range proofs are enabled", func() {
+			BeforeEach(func() {
+				prover, verifier = prepareZKTransferWithAggregatedRangeProofs(8)
+			})
+			It("Succeeds", func() {
+				proof, err := prover.Prove()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proof).NotTo(BeNil())
+				tp := &transfer.Proof{}
+				Expect(tp.Deserialize(proof)).To(Succeed())
+				Expect(tp.AggregatedRangeCorrectness).NotTo(BeNil())
+				Expect(tp.RangeCorrectness.Proofs).To(BeEmpty())
+				err = verifier.Verify(proof)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("accepts proofs with a range proof per output", func() {
+				pp, err := v1.Setup(32, nil, math.FP256BN_AMCL)
+				Expect(err).NotTo(HaveOccurred())
+				intw, outtw, in, out := prepareInputsForZKTransfer(pp)
+				prover, err := transfer.NewProver(intw, outtw, in, out, pp)
+				Expect(err).NotTo(HaveOccurred())
+				proof, err := prover.Prove()
+				Expect(err).NotTo(HaveOccurred())
+
+				pp.EnableAggregatedRangeProofs()
+				Expect(transfer.NewVerifier(in, out, pp).Verify(proof)).To(Succeed())
+			})
+			It("rejects aggregated proofs if they are not enabled", func() {
+				pp, err := v1.Setup(32, nil, math.FP256BN_AMCL)
+				Expect(err).NotTo(HaveOccurred())
+				intw, outtw, in, out := prepareInputsForZKTransfer(pp)
+				pp.EnableAggregatedRangeProofs()
+				prover, err := transfer.NewProver(intw, outtw, in, out, pp)
+				Expect(err).NotTo(HaveOccurred())
+				proof, err := prover.Prove()
+				Expect(err).NotTo(HaveOccurred())
+
+				pp.AggregatedRangeProofs = false
+				err = transfer.NewVerifier(in, out, pp).Verify(proof)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("aggregated range proofs are not enabled"))
+			})
+		})
 		Context("Output Values > Input Values", func() {
 			BeforeEach(func() {
 				prover, verifier = prepareZKTransferWithWrongSum()
@@ -98,6 +141,39 @@ func prepareZKTransfer() (*transfer.Prover, *transfer.Verifier) {
 	return prover, verifier
 }
 
+func prepareZKTransferWithAggregatedRangeProofs(outputs int) (*transfer.Prover, *transfer.Verifier) {
+	pp, err := v1.Setup(32, nil, math.FP256BN_AMCL)
+	Expect(err).NotTo(HaveOccurred())
+	pp.EnableAggregatedRangeProofs()
+
+	c := math.Curves[pp.Curve]
+	rand, err := c.Rand()
+	Expect(err).NotTo(HaveOccurred())
+	inValues := []uint64{uint64(outputs) * 100}
+	outValues := make([]uint64, outputs)
+	for i := range outValues {
+		outValues[i] = 100
+	}
+	inBF := []*math.Zr{c.NewRandomZr(rand)}
+	outBF := make([]*math.Zr, outputs)
+	for i := range outBF {
+		outBF[i] = c.NewRandomZr(rand)
+	}
+	ttype := token2.Type("ABC")
+	in, out := prepareInputsOutputs(inValues, outValues, inBF, outBF, ttype, pp.PedersenGenerators, c)
+	intw := []*token.Metadata{{BlindingFactor: inBF[0], Value: c.NewZrFromUint64(inValues[0]), Type: ttype}}
+	outtw := make([]*token.Metadata, outputs)
+	for i := range outtw {
+		outtw[i] = &token.Metadata{BlindingFactor: outBF[i], Value: c.NewZrFromUint64(outValues[i]), Type: ttype}
+	}
+
+	prover, err := transfer.NewProver(intw, outtw, in, out, pp)
+	Expect(err).NotTo(HaveOccurred())
+	verifier := transfer.NewVerifier(in, out, pp)
+
+	return prover, verifier
+}
+
 func prepareZKTransferWithWrongSum() (*transfer.Prover, *transfer.Verifier) {
 	pp, err := v1.Setup(32, nil, math.FP256BN_AMCL)
 	Expect(err).NotTo(HaveOccurred())
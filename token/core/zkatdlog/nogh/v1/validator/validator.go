@@ -59,14 +59,19 @@ func New(
 	transferValidators := []ValidateTransferFunc{
 		TransferActionValidate,
 		TransferSignatureValidate,
+		TransferNotFrozenValidate,
 		TransferUpgradeWitnessValidate,
 		TransferZKProofValidate,
+		TransferFeeValidate,
 		TransferHTLCValidate,
+		TransferTimelockValidate,
+		TransferValidityValidate,
 	}
 	transferValidators = append(transferValidators, extraValidators...)
 
 	issueValidators := []ValidateIssueFunc{
 		IssueValidate,
+		IssueValidityValidate,
 	}
 
 	return common.NewValidator[*v1.PublicParams, *token.Token, *transfer.Action, *issue.Action, driver.Deserializer](
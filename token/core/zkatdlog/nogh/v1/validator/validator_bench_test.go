@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validator_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/audit"
+	zkatdlog "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/driver"
+	v1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/setup"
+	enginedlog "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/validator"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/validator/mock"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/deserializer"
+	idemix2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/idemix"
+	ix509 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/x509"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/slices"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// BenchmarkVerifyTokenRequest compares sequential and concurrent verification of a request with several transfer actions
+func BenchmarkVerifyTokenRequest(b *testing.B) {
+	RegisterTestingT(b)
+	fakeLedger = &mock.Ledger{}
+
+	ipk, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	Expect(err).NotTo(HaveOccurred())
+	pp, err := v1.Setup(32, ipk, math.FP256BN_AMCL)
+	Expect(err).NotTo(HaveOccurred())
+
+	asigner, _ := prepareECDSASigner()
+	idemixDes, err := idemix2.NewDeserializer(slices.GetUnique(pp.IdemixIssuerPublicKeys).PublicKey, math.FP256BN_AMCL)
+	Expect(err).NotTo(HaveOccurred())
+	des := deserializer.NewTypedVerifierDeserializerMultiplex()
+	des.AddTypedVerifierDeserializer(idemix2.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(idemixDes, idemixDes))
+	des.AddTypedVerifierDeserializer(ix509.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(&Deserializer{}, &Deserializer{}))
+	auditor := audit.NewAuditor(logging.MustGetLogger("auditor"), &noop.Tracer{}, des, pp.PedersenGenerators, asigner, math.Curves[pp.Curve])
+	araw, err := asigner.Serialize()
+	Expect(err).NotTo(HaveOccurred())
+	pp.AuditorIDs = []driver.Identity{araw}
+
+	deserializer, err := zkatdlog.NewDeserializer(pp)
+	Expect(err).NotTo(HaveOccurred())
+
+	sender, tr, trmetadata, inputs := prepareTransferRequest(pp, auditor)
+
+	for _, actions := range []int{1, 4, 16} {
+		raw, err := prepareMultiTransferRequest(sender, auditor, tr, trmetadata, inputs, actions, "1").Bytes()
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, workers := range []int{0, runtime.NumCPU()} {
+			b.Run(fmt.Sprintf("actions=%d/workers=%d", actions, workers), func(b *testing.B) {
+				engine := enginedlog.New(logging.MustGetLogger("validator"), pp, deserializer)
+				engine.Workers = workers
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
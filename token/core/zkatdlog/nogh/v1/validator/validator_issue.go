@@ -7,10 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package validator
 
 import (
-	"bytes"
-
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/issue"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/validity"
 	"github.com/pkg/errors"
 )
 
@@ -32,18 +31,19 @@ func IssueValidate(ctx *Context) error {
 	}
 
 	issuers := ctx.PP.IssuerIDs
-	if len(issuers) != 0 {
-		// Check the issuer is among those known
-		found := false
-		for _, issuer := range issuers {
-			if bytes.Equal(action.Issuer, issuer) {
-				found = true
-				break
-			}
+	if len(ctx.PP.IssuerPolicies) != 0 {
+		// the type is hidden, the issuer must disclose it to let the policies be enforced
+		if action.TypeOpening == nil {
+			return errors.New("the public parameters contain issuer policies, the issue action must disclose the token type")
 		}
-		if !found {
-			return errors.Errorf("issuer [%s] is not in issuers", driver.Identity(action.Issuer).String())
+		if err := issue.VerifyTypeOpening(action.TypeOpening, action.GetProof(), ctx.PP); err != nil {
+			return errors.Wrap(err, "failed to verify token type")
 		}
+		issuers = ctx.PP.AuthorizedIssuers(action.TypeOpening.Type)
+	}
+	// Check the issuer is among those known
+	if len(issuers) != 0 && !driver.ContainsIssuer(issuers, action.Issuer) {
+		return errors.Errorf("issuer [%s] is not in issuers", driver.Identity(action.Issuer).String())
 	}
 
 	verifier, err := ctx.Deserializer.GetIssuerVerifier(action.Issuer)
@@ -55,3 +55,16 @@ func IssueValidate(ctx *Context) error {
 	}
 	return nil
 }
+
+// IssueValidityValidate checks that the issued tokens bound to a validity window name the issuer of the action
+// and are not already expired
+func IssueValidityValidate(ctx *Context) error {
+	outputOwners := make([]driver.Identity, len(ctx.IssueAction.Outputs))
+	for i, out := range ctx.IssueAction.Outputs {
+		outputOwners[i] = out.Owner
+	}
+	if err := validity.VerifyIssue(ctx.IssueAction.Issuer, outputOwners, ctx.Time); err != nil {
+		return errors.WithMessage(err, "invalid issue action")
+	}
+	return nil
+}
@@ -71,6 +71,8 @@ var _ = Describe("validator", func() {
 		rr *driver.TokenRequest // redeem request
 		tr *driver.TokenRequest // transfer request
 		ar *driver.TokenRequest // atomic action request
+
+		trmetadata *driver.TokenRequestMetadata
 	)
 	BeforeEach(func() {
 		fakeLedger = &mock.Ledger{}
@@ -92,7 +94,7 @@ var _ = Describe("validator", func() {
 		auditor = audit.NewAuditor(logging.MustGetLogger("auditor"), &noop.Tracer{}, des, pp.PedersenGenerators, asigner, c)
 		araw, err := asigner.Serialize()
 		Expect(err).NotTo(HaveOccurred())
-		pp.Auditor = araw
+		pp.AuditorIDs = []driver.Identity{araw}
 
 		// initialize enginw with pp
 		deserializer, err := zkatdlog.NewDeserializer(pp)
@@ -108,7 +110,6 @@ var _ = Describe("validator", func() {
 		Expect(sender).NotTo(BeNil())
 
 		// prepare transfer
-		var trmetadata *driver.TokenRequestMetadata
 		sender, tr, trmetadata, inputsForTransfer = prepareTransferRequest(pp, auditor)
 		Expect(sender).NotTo(BeNil())
 		Expect(trmetadata).NotTo(BeNil())
@@ -155,6 +156,79 @@ var _ = Describe("validator", func() {
 			})
 		})
 
+		Context("public parameters with two auditors", func() {
+			var (
+				err error
+				raw []byte
+			)
+			BeforeEach(func() {
+				// only the first auditor signed the issue request
+				signer, _ := prepareECDSASigner()
+				compliance, err := signer.Serialize()
+				Expect(err).NotTo(HaveOccurred())
+				pp.AddAuditor(compliance)
+				raw, err = ir.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("succeeds when any one auditor is enough", func() {
+				pp.SetAuditorThreshold(1)
+				actions, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), fakeLedger.GetStateStub, "1", raw)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(actions)).To(Equal(1))
+			})
+			It("fails when all auditors are required", func() {
+				pp.SetAuditorThreshold(0)
+				_, _, err = engine.VerifyTokenRequestFromRaw(context.TODO(), fakeLedger.GetStateStub, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("insufficient number of auditor signatures, expected [2], got [1]"))
+			})
+			It("fails when an auditor is repeated", func() {
+				pp.SetAuditors([]driver.Identity{pp.AuditorIDs[0], pp.AuditorIDs[0]})
+				pp.SetAuditorThreshold(2)
+				_, _, err = engine.VerifyTokenRequestFromRaw(context.TODO(), fakeLedger.GetStateStub, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("insufficient number of auditor signatures, expected [2], got [1]"))
+			})
+		})
+
+		Context("public parameters with issuer policies", func() {
+			var (
+				err            error
+				raw            []byte
+				issuerIdentity []byte
+			)
+			BeforeEach(func() {
+				signer, err := NewECDSASigner()
+				Expect(err).NotTo(HaveOccurred())
+				issuerIdentity, err = signer.Serialize()
+				Expect(err).NotTo(HaveOccurred())
+				pp.AddIssuerPolicy("ABC", []driver.Identity{issuerIdentity})
+				issuer := &issue2.Issuer{}
+				issuer.New("ABC", signer, pp)
+				pir, _ := prepareIssue(auditor, issuer, issuerIdentity)
+				raw, err = pir.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("succeeds when the issuer is authorized for the token type", func() {
+				actions, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), fakeLedger.GetStateStub, "1", raw)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(actions)).To(Equal(1))
+			})
+			It("fails when the issuer is not authorized for the token type", func() {
+				pp.SetIssuerPolicies(driver.IssuerPolicies{{TokenType: "AB*", Issuers: []driver.Identity{[]byte("another issuer")}}})
+				_, _, err = engine.VerifyTokenRequestFromRaw(context.TODO(), fakeLedger.GetStateStub, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("is not in issuers"))
+			})
+			It("fails when the issue action does not disclose the token type", func() {
+				raw, err = ir.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				_, _, err = engine.VerifyTokenRequestFromRaw(context.TODO(), fakeLedger.GetStateStub, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("the issue action must disclose the token type"))
+			})
+		})
+
 		Context("validator is called correctly with a transfer action", func() {
 			var (
 				err error
@@ -188,6 +262,109 @@ var _ = Describe("validator", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(len(actions)).To(Equal(1))
 			})
+			It("fails when an input is frozen", func() {
+				ctx := driver.WithFreezeLedger(context.TODO(), &freezeLedger{tokens: true})
+				_, _, err := engine.VerifyTokenRequestFromRaw(ctx, getState, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid transfer: token"))
+				Expect(err.Error()).To(ContainSubstring("is frozen"))
+			})
+			It("fails when the owner of an input is frozen", func() {
+				ctx := driver.WithFreezeLedger(context.TODO(), &freezeLedger{owners: true})
+				_, _, err := engine.VerifyTokenRequestFromRaw(ctx, getState, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid transfer: owner"))
+			})
+			It("fails when enforcers are declared and the freezes cannot be checked", func() {
+				enforcer, _ := prepareECDSASigner()
+				eraw, err := enforcer.Serialize()
+				Expect(err).NotTo(HaveOccurred())
+				pp.AddEnforcer(eraw)
+				_, _, err = engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid transfer: cannot check the freezes, no freeze ledger available"))
+			})
+		})
+		Context("validator is called correctly with a forced transfer action", func() {
+			var raw []byte
+			BeforeEach(func() {
+				for i := 0; i < 4; i++ {
+					input, err := inputsForTransfer[i%2].Serialize()
+					Expect(err).NotTo(HaveOccurred())
+					fakeLedger.GetStateReturnsOnCall(i, input, nil)
+				}
+
+				// the enforcer signs in place of the owner, once per input
+				enforcer, _ := prepareECDSASigner()
+				eraw, err := enforcer.Serialize()
+				Expect(err).NotTo(HaveOccurred())
+				pp.AddEnforcer(eraw)
+				action := &transfer.Action{}
+				Expect(action.Deserialize(tr.Transfers[0])).To(Succeed())
+				action.Enforcer = eraw
+				araw, err := action.Serialize()
+				Expect(err).NotTo(HaveOccurred())
+				ftr := &driver.TokenRequest{Transfers: [][]byte{araw}}
+				msg, err := ftr.MarshalToMessageToSign([]byte("1"))
+				Expect(err).NotTo(HaveOccurred())
+				sigma, err := enforcer.Sign(msg)
+				Expect(err).NotTo(HaveOccurred())
+				ftr.Signatures = [][]byte{sigma, sigma}
+				asigma, err := auditor.Endorse(ftr, "1")
+				Expect(err).NotTo(HaveOccurred())
+				ftr.AuditorSignatures = [][]byte{asigma}
+				raw, err = ftr.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("succeeds", func() {
+				actions, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(actions)).To(Equal(1))
+			})
+			It("succeeds when the inputs and their owners are frozen", func() {
+				ctx := driver.WithFreezeLedger(context.TODO(), &freezeLedger{tokens: true, owners: true})
+				actions, _, err := engine.VerifyTokenRequestFromRaw(ctx, getState, "1", raw)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(actions)).To(Equal(1))
+			})
+			It("fails when the enforcer is not in the public parameters", func() {
+				pp.SetEnforcers(nil)
+				_, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid forced transfer"))
+				Expect(err.Error()).To(ContainSubstring("is not an enforcer"))
+			})
+		})
+		Context("public parameters with fee policies", func() {
+			setInputs := func(inputs []*tokn.Token) {
+				for i := 0; i < 4; i++ {
+					raw, err := inputs[i%2].Serialize()
+					Expect(err).NotTo(HaveOccurred())
+					fakeLedger.GetStateReturnsOnCall(i, raw, nil)
+				}
+				fakeLedger.GetStateReturnsOnCall(4, nil, nil)
+				fakeLedger.GetStateReturnsOnCall(5, nil, nil)
+			}
+			BeforeEach(func() {
+				pp.AddFeePolicy("ABC", 1, 100, []byte("collector"))
+			})
+			It("succeeds when the transfer proves its fee policy and has no payments", func() {
+				_, ftr, _, inputs := prepareTransferRequest(pp, auditor)
+				setInputs(inputs)
+				raw, err := ftr.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				actions, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(actions)).To(Equal(1))
+			})
+			It("fails when the transfer does not prove its fee policy", func() {
+				setInputs(inputsForTransfer)
+				raw, err := tr.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				_, _, err = engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid fee: it is nil"))
+			})
 		})
 		Context("validator is called correctly with a redeem action", func() {
 			var (
@@ -282,9 +459,80 @@ var _ = Describe("validator", func() {
 				})
 			})
 		})
+		Context("validator verifies the actions concurrently", func() {
+			var (
+				err error
+				raw []byte
+				mr  *driver.TokenRequest // request with multiple transfer actions
+			)
+			BeforeEach(func() {
+				engine.Workers = 4
+				mr = prepareMultiTransferRequest(sender, auditor, tr, trmetadata, inputsForTransfer, 3, "4")
+				raw, err = mr.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("succeeds", func() {
+				actions, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "4", raw)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(actions)).To(Equal(3))
+			})
+			Context("when the signature of one action is not valid", func() {
+				BeforeEach(func() {
+					// two signatures per transfer action, replace the first of the second action with one over another request
+					mr.Signatures[2] = ar.Signatures[0]
+					raw, err = mr.Bytes()
+					Expect(err).NotTo(HaveOccurred())
+				})
+				It("reports the failing action", func() {
+					_, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "4", raw)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("failed to verify transfer action at [1]"))
+				})
+			})
+			Context("when there are not enough signatures", func() {
+				BeforeEach(func() {
+					mr.Signatures = mr.Signatures[:len(mr.Signatures)-1]
+					raw, err = mr.Bytes()
+					Expect(err).NotTo(HaveOccurred())
+				})
+				It("fails", func() {
+					_, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "4", raw)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("failed to verify transfer action at [2]"))
+				})
+			})
+		})
 	})
 })
 
+// prepareMultiTransferRequest returns a request containing n copies of the transfer action of the passed request,
+// endorsed by the passed auditor and signed by the passed sender for the passed anchor
+func prepareMultiTransferRequest(sender *transfer.Sender, auditor *audit.Auditor, tr *driver.TokenRequest, trmetadata *driver.TokenRequestMetadata, inputs []*tokn.Token, n int, anchor string) *driver.TokenRequest {
+	mr := &driver.TokenRequest{}
+	metadata := &driver.TokenRequestMetadata{}
+	tokens := make([][]*tokn.Token, n)
+	for i := 0; i < n; i++ {
+		mr.Transfers = append(mr.Transfers, tr.Transfers[0])
+		metadata.Transfers = append(metadata.Transfers, trmetadata.Transfers[0])
+		tokens[i] = inputs
+	}
+	raw, err := mr.MarshalToMessageToSign([]byte(anchor))
+	Expect(err).NotTo(HaveOccurred())
+
+	err = auditor.Check(context.Background(), mr, metadata, tokens, anchor)
+	Expect(err).NotTo(HaveOccurred())
+	sigma, err := auditor.Endorse(mr, anchor)
+	Expect(err).NotTo(HaveOccurred())
+	mr.AuditorSignatures = append(mr.AuditorSignatures, sigma)
+
+	for i := 0; i < n; i++ {
+		signatures, err := sender.SignTokenActions(raw)
+		Expect(err).NotTo(HaveOccurred())
+		mr.Signatures = append(mr.Signatures, signatures...)
+	}
+	return mr
+}
+
 func prepareECDSASigner() (*Signer, *Verifier) {
 	signer, err := NewECDSASigner()
 	Expect(err).NotTo(HaveOccurred())
@@ -584,6 +832,20 @@ func getState(id token2.ID) ([]byte, error) {
 	return fakeLedger.GetState(id)
 }
 
+// freezeLedger reports as frozen all the tokens, or all the owners
+type freezeLedger struct {
+	tokens bool
+	owners bool
+}
+
+func (f *freezeLedger) IsTokenFrozen(token2.ID) (bool, error) {
+	return f.tokens, nil
+}
+
+func (f *freezeLedger) IsOwnerFrozen(driver.Identity) (bool, error) {
+	return f.owners, nil
+}
+
 var (
 	// curveHalfOrders contains the precomputed curve group orders halved.
 	// It is used to ensure that signature' S value is lower or equal to the
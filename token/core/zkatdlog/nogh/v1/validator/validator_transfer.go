@@ -11,12 +11,16 @@ import (
 	"time"
 
 	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/transfer"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
 	htlc2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/htlc"
+	timelock2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/timelock"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/validity"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/htlc"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
 	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 )
@@ -26,6 +30,8 @@ func TransferActionValidate(ctx *Context) error {
 	return ctx.TransferAction.Validate()
 }
 
+// TransferSignatureValidate validates the signatures for the inputs spent by an action.
+// A forced transfer is signed by its enforcer in place of the owners, once per input.
 func TransferSignatureValidate(ctx *Context) error {
 	// recall that TransferActionValidate has been called before this function
 	var signatures [][]byte
@@ -34,6 +40,16 @@ func TransferSignatureValidate(ctx *Context) error {
 		return errors.Errorf("invalid number of token inputs, expected at least 1")
 	}
 
+	enforcer := ctx.TransferAction.Enforcer
+	var enforcerVerifier driver.Verifier
+	if !enforcer.IsNone() {
+		var err error
+		enforcerVerifier, err = common.EnforcerVerifier(ctx.PP, ctx.Deserializer, enforcer)
+		if err != nil {
+			return errors.WithMessage(err, "invalid forced transfer")
+		}
+	}
+
 	var inputToken []*token.Token
 	for i, in := range ctx.TransferAction.Inputs {
 		tok := in.Token
@@ -41,16 +57,26 @@ func TransferSignatureValidate(ctx *Context) error {
 
 		// TODO check witness
 
-		// check sender signature
-		ctx.Logger.Debugf("check sender [%d][%s]", i, driver.Identity(tok.Owner).UniqueID())
-		verifier, err := ctx.Deserializer.GetOwnerVerifier(tok.Owner)
-		if err != nil {
-			return errors.Wrapf(err, "failed deserializing owner [%d][%v][%s]", i, in, driver.Identity(tok.Owner))
+		// check sender signature, or the enforcer's one
+		signer := driver.Identity(tok.Owner)
+		verifier := enforcerVerifier
+		if verifier == nil {
+			ctx.Logger.Debugf("check sender [%d][%s]", i, signer.UniqueID())
+			var err error
+			verifier, err = ctx.Deserializer.GetOwnerVerifier(signer)
+			if err != nil {
+				return errors.Wrapf(err, "failed deserializing owner [%d][%v][%s]", i, in, signer)
+			}
+			// scripts like timelocks are evaluated at the validation time
+			verifier = driver.VerifierAt(verifier, ctx.Time)
+		} else {
+			ctx.Logger.Debugf("check enforcer [%d][%s] of sender [%s]", i, enforcer.UniqueID(), signer.UniqueID())
+			signer = enforcer
 		}
-		ctx.Logger.Debugf("signature verification [%d][%v][%s]", i, in, driver.Identity(tok.Owner).UniqueID())
-		sigma, err := ctx.SignatureProvider.HasBeenSignedBy(tok.Owner, verifier)
+		ctx.Logger.Debugf("signature verification [%d][%v][%s]", i, in, signer.UniqueID())
+		sigma, err := ctx.SignatureProvider.HasBeenSignedBy(signer, verifier)
 		if err != nil {
-			return errors.Wrapf(err, "failed signature verification [%d][%v][%s]", i, in, driver.Identity(tok.Owner))
+			return errors.Wrapf(err, "failed signature verification [%d][%v][%s]", i, in, signer)
 		}
 		signatures = append(signatures, sigma)
 	}
@@ -61,6 +87,23 @@ func TransferSignatureValidate(ctx *Context) error {
 	return nil
 }
 
+// TransferNotFrozenValidate checks that the inputs spent by an action, and their owners, are not frozen.
+// A forced transfer can spend frozen tokens.
+func TransferNotFrozenValidate(ctx *Context) error {
+	// recall that TransferActionValidate has been called before this function
+	if !ctx.TransferAction.Enforcer.IsNone() {
+		return nil
+	}
+	owners := make([]driver.Identity, len(ctx.TransferAction.Inputs))
+	for i, in := range ctx.TransferAction.Inputs {
+		owners[i] = in.Token.Owner
+	}
+	if err := common.CheckNotFrozen(ctx.PP, ctx.Freezes, ctx.TransferAction.GetInputs(), owners); err != nil {
+		return errors.Wrap(err, "invalid transfer")
+	}
+	return nil
+}
+
 func TransferUpgradeWitnessValidate(ctx *Context) error {
 	// recall that TransferActionValidate has been called before this function
 
@@ -109,6 +152,31 @@ func TransferZKProofValidate(ctx *Context) error {
 	return nil
 }
 
+// TransferFeeValidate checks that the action pays at least the fee required by the public parameters for its token type.
+// The transfers spending an htlc or a timelock script are exempt, the fee has been paid when the script was created.
+// The transfers forced by an enforcer are exempt as well.
+func TransferFeeValidate(ctx *Context) error {
+	if len(ctx.PP.FeePolicies()) == 0 || !ctx.TransferAction.Enforcer.IsNone() {
+		return nil
+	}
+	inputOwners := make([]driver.Identity, len(ctx.InputTokens))
+	for i, in := range ctx.InputTokens {
+		if owner, err := identity.UnmarshalTypedIdentity(in.Owner); err == nil && (owner.Type == htlc.ScriptType || owner.Type == timelock.ScriptType) {
+			return nil
+		}
+		inputOwners[i] = in.Owner
+	}
+	outputOwners := make([]driver.Identity, len(ctx.TransferAction.Outputs))
+	for i, out := range ctx.TransferAction.Outputs {
+		outputOwners[i] = out.Owner
+	}
+	return transfer.NewFeeVerifier(
+		inputOwners,
+		outputOwners,
+		ctx.TransferAction.GetOutputCommitments(),
+		ctx.PP).Verify(ctx.TransferAction.Fee)
+}
+
 func TransferHTLCValidate(ctx *Context) error {
 	now := time.Now()
 
@@ -173,3 +241,74 @@ func TransferHTLCValidate(ctx *Context) error {
 	}
 	return nil
 }
+
+// TransferTimelockValidate checks the validity of the timelock scripts, if any.
+// A transfer spending a timelock script only transfers the ownership of the token to the recipient of the script,
+// once unlocked, or back to the sender, before the unlock time, if the script is revocable.
+// The transfers forced by an enforcer are exempt.
+func TransferTimelockValidate(ctx *Context) error {
+	if !ctx.TransferAction.Enforcer.IsNone() {
+		return nil
+	}
+	for _, in := range ctx.InputTokens {
+		owner, err := identity.UnmarshalTypedIdentity(in.Owner)
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshal owner of input token")
+		}
+		if owner.Type != timelock.ScriptType {
+			continue
+		}
+		if len(ctx.InputTokens) != 1 || len(ctx.TransferAction.GetOutputs()) != 1 {
+			return errors.New("invalid transfer action: a timelock script only transfers the ownership of a token")
+		}
+		out := ctx.TransferAction.GetOutputs()[0].(*token.Token)
+		if out.IsRedeem() {
+			return errors.New("invalid transfer action: the output corresponding to a timelock spending should not be a redeem")
+		}
+		if _, _, err := timelock2.VerifyOwner(in.Owner, out.Owner, ctx.Time); err != nil {
+			return errors.Wrap(err, "failed to verify transfer from timelock script")
+		}
+	}
+
+	for _, o := range ctx.TransferAction.GetOutputs() {
+		out := o.(*token.Token)
+		if out.IsRedeem() {
+			continue
+		}
+		owner, err := identity.UnmarshalTypedIdentity(out.Owner)
+		if err != nil {
+			return err
+		}
+		if owner.Type != timelock.ScriptType {
+			continue
+		}
+		script := &timelock.Script{}
+		if err := script.FromBytes(owner.Identity); err != nil {
+			return err
+		}
+		if err := script.Validate(ctx.Time); err != nil {
+			return errors.WithMessagef(err, "timelock script invalid")
+		}
+	}
+	return nil
+}
+
+// TransferValidityValidate checks that the action respects the validity windows of the tokens it spends, if any.
+// The transfers forced by an enforcer are exempt.
+func TransferValidityValidate(ctx *Context) error {
+	if !ctx.TransferAction.Enforcer.IsNone() {
+		return nil
+	}
+	inputOwners := make([]driver.Identity, len(ctx.InputTokens))
+	for i, in := range ctx.InputTokens {
+		inputOwners[i] = in.Owner
+	}
+	outputOwners := make([]driver.Identity, len(ctx.TransferAction.Outputs))
+	for i, out := range ctx.TransferAction.Outputs {
+		outputOwners[i] = out.Owner
+	}
+	if err := validity.VerifyTransfer(inputOwners, ctx.Signatures, outputOwners, ctx.Time); err != nil {
+		return errors.WithMessage(err, "invalid transfer action")
+	}
+	return nil
+}
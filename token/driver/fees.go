@@ -0,0 +1,170 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"bytes"
+	"math/big"
+	"math/bits"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// FeeRateDenominator is the denominator of the fee rates, a rate is expressed in basis points: 1 is 0.01%
+const FeeRateDenominator = 10000
+
+// FeePolicy charges a fee on the transfers of the token types matching a pattern.
+// The fee is paid with an output of the same type, owned by the collector.
+//
+// The fee is computed on the payments of a transfer action, that is, the outputs owned by neither the collector
+// nor the owner of one of the inputs. Outputs owned by the owner of an input are change, and redeemed outputs are not payments.
+// A transfer action with no payments pays no fee.
+type FeePolicy struct {
+	// TokenType is either a token type, or a prefix of token types followed by '*'.
+	// A single '*' matches all token types.
+	TokenType string
+	// Flat is the fee charged on each transfer action with at least one payment
+	Flat uint64
+	// Rate is the fee charged on the value of the payments, in basis points. The result is rounded up.
+	Rate uint64
+	// Collector is the owner of the fee outputs
+	Collector Identity
+}
+
+// Matches returns true if the passed token type matches the pattern of this policy
+func (p *FeePolicy) Matches(tokenType token.Type) bool {
+	return matchTokenType(p.TokenType, tokenType)
+}
+
+// Fee returns the fee due for payments of the passed total value
+func (p *FeePolicy) Fee(payments uint64) (uint64, error) {
+	if payments == 0 {
+		return 0, nil
+	}
+	// fee = flat + ceil(payments * rate / denominator)
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(payments), new(big.Int).SetUint64(p.Rate))
+	fee.Add(fee, big.NewInt(FeeRateDenominator-1))
+	fee.Quo(fee, big.NewInt(FeeRateDenominator))
+	fee.Add(fee, new(big.Int).SetUint64(p.Flat))
+	if !fee.IsUint64() {
+		return 0, errors.Errorf("fee for payments [%d] overflows", payments)
+	}
+	return fee.Uint64(), nil
+}
+
+// SplitBudget splits the passed budget into the largest payment that can be made with it and the fee, given the value of
+// the other payments of the same transfer action. The fee covers the other payments and the returned payment,
+// and payment plus fee equals the budget.
+// If the budget covers the fee on the other payments but no payment on top of it, the whole budget goes to the fee.
+// It fails if the budget does not cover the fee on the other payments.
+func (p *FeePolicy) SplitBudget(otherPayments, budget uint64) (payment uint64, fee uint64, err error) {
+	required, err := p.Fee(otherPayments)
+	if err != nil {
+		return 0, 0, err
+	}
+	if required > budget {
+		return 0, 0, errors.Errorf("budget [%d] does not cover the fee [%d]", budget, required)
+	}
+	// payment + fee(otherPayments + payment) grows with payment, look for the largest payment that fits the budget
+	fits := func(payment uint64) bool {
+		total, carry := bits.Add64(otherPayments, payment, 0)
+		if carry != 0 {
+			return false
+		}
+		fee, err := p.Fee(total)
+		if err != nil {
+			return false
+		}
+		spent, carry := bits.Add64(payment, fee, 0)
+		return carry == 0 && spent <= budget
+	}
+	lo, hi := uint64(0), budget
+	for lo < hi {
+		mid := hi - (hi-lo)/2
+		if fits(mid) {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo, budget - lo, nil
+}
+
+// SplitOutputs returns the indexes of the fee outputs, owned by the collector, and of the payments,
+// given the owners of the inputs and of the outputs of a transfer action
+func (p *FeePolicy) SplitOutputs(inputOwners []Identity, outputOwners []Identity) (fees []int, payments []int) {
+	for i, owner := range outputOwners {
+		switch {
+		case owner.IsNone():
+			// redeem
+		case bytes.Equal(owner, p.Collector):
+			fees = append(fees, i)
+		case ContainsIssuer(inputOwners, owner):
+			// change
+		default:
+			payments = append(payments, i)
+		}
+	}
+	return fees, payments
+}
+
+// Validate checks that the pattern is well-formed, that the policy charges something, and that there is a collector
+func (p *FeePolicy) Validate() error {
+	if err := validateTokenTypePattern(p.TokenType); err != nil {
+		return err
+	}
+	if p.Flat == 0 && p.Rate == 0 {
+		return errors.Errorf("no fee for token type [%s]", p.TokenType)
+	}
+	if p.Rate > FeeRateDenominator {
+		return errors.Errorf("invalid fee rate [%d] for token type [%s], it must be at most %d", p.Rate, p.TokenType, FeeRateDenominator)
+	}
+	if p.Collector.IsNone() {
+		return errors.Errorf("no fee collector for token type [%s]", p.TokenType)
+	}
+	return nil
+}
+
+// FeePolicies is a list of fee policies
+type FeePolicies []*FeePolicy
+
+// Lookup returns the most specific policy matching the passed token type, nil if none does.
+// An exact match wins over a prefix, and a longer prefix wins over a shorter one.
+func (ps FeePolicies) Lookup(tokenType token.Type) *FeePolicy {
+	var res *FeePolicy
+	for _, p := range ps {
+		if !p.Matches(tokenType) {
+			continue
+		}
+		if p.TokenType == string(tokenType) {
+			return p
+		}
+		if res == nil || len(p.TokenType) > len(res.TokenType) {
+			res = p
+		}
+	}
+	return res
+}
+
+// Validate checks that each policy is well-formed and that no two policies have the same pattern
+func (ps FeePolicies) Validate() error {
+	patterns := make(map[string]struct{}, len(ps))
+	for _, p := range ps {
+		if p == nil {
+			return errors.New("nil fee policy")
+		}
+		if err := p.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid fee policy")
+		}
+		if _, ok := patterns[p.TokenType]; ok {
+			return errors.Errorf("duplicate fee policy for token type [%s]", p.TokenType)
+		}
+		patterns[p.TokenType] = struct{}{}
+	}
+	return nil
+}
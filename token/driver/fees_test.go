@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeePolicy_Fee(t *testing.T) {
+	flat := &FeePolicy{TokenType: "USD", Flat: 5, Collector: []byte("collector")}
+	rate := &FeePolicy{TokenType: "USD", Rate: 150, Collector: []byte("collector")}
+	both := &FeePolicy{TokenType: "USD", Flat: 5, Rate: 150, Collector: []byte("collector")}
+
+	for _, tt := range []struct {
+		policy   *FeePolicy
+		payments uint64
+		expected uint64
+	}{
+		{flat, 0, 0},
+		{flat, 1000, 5},
+		{rate, 0, 0},
+		{rate, 1000, 15},
+		// rounded up
+		{rate, 1001, 16},
+		{rate, 1, 1},
+		{both, 1000, 20},
+	} {
+		fee, err := tt.policy.Fee(tt.payments)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, fee)
+	}
+
+	_, err := (&FeePolicy{Flat: math.MaxUint64, Rate: 1}).Fee(math.MaxUint64)
+	assert.Error(t, err)
+}
+
+func TestFeePolicy_SplitBudget(t *testing.T) {
+	p := &FeePolicy{TokenType: "USD", Flat: 5, Rate: 100, Collector: []byte("collector")}
+
+	for _, tt := range []struct {
+		others, budget   uint64
+		payment, feePaid uint64
+	}{
+		// 990 + fee(990) = 990 + 5 + 10 = 1005 > 1000, 985 + 5 + 10 = 1000
+		{0, 1000, 985, 15},
+		// the other payments are charged as well: fee(500 + 485) = 5 + 10
+		{500, 500, 485, 15},
+		// fee(500) = 5 + 5, fee(501) = 5 + 6
+		{500, 12, 1, 11},
+		// nothing fits on top of the fee of the other payments
+		{500, 10, 0, 10},
+		// a payment alone would cost more than the budget, it goes to the fee
+		{0, 5, 0, 5},
+		{0, 0, 0, 0},
+	} {
+		payment, fee, err := p.SplitBudget(tt.others, tt.budget)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.payment, payment, "others [%d], budget [%d]", tt.others, tt.budget)
+		assert.Equal(t, tt.feePaid, fee, "others [%d], budget [%d]", tt.others, tt.budget)
+		required, err := p.Fee(tt.others + payment)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, fee, required)
+	}
+
+	_, _, err := p.SplitBudget(500, 9)
+	assert.Error(t, err)
+	payment, fee, err := p.SplitBudget(0, math.MaxUint64)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), payment+fee)
+}
+
+func TestFeePolicy_SplitOutputs(t *testing.T) {
+	p := &FeePolicy{TokenType: "USD", Flat: 1, Collector: []byte("collector")}
+	fees, payments := p.SplitOutputs(
+		[]Identity{[]byte("alice"), []byte("alice2")},
+		[]Identity{[]byte("bob"), []byte("collector"), []byte("alice2"), nil, []byte("charlie")},
+	)
+	assert.Equal(t, []int{1}, fees)
+	assert.Equal(t, []int{0, 4}, payments)
+}
+
+func TestFeePolicies(t *testing.T) {
+	collector := []byte("collector")
+	policies := FeePolicies{
+		{TokenType: "*", Flat: 1, Collector: collector},
+		{TokenType: "EUR*", Rate: 10, Collector: collector},
+		{TokenType: "EUR.bond", Flat: 2, Rate: 20, Collector: collector},
+	}
+	assert.NoError(t, policies.Validate())
+	assert.Equal(t, uint64(1), policies.Lookup("USD").Flat)
+	assert.Equal(t, uint64(10), policies.Lookup("EUR").Rate)
+	assert.Equal(t, uint64(20), policies.Lookup("EUR.bond").Rate)
+	assert.Nil(t, policies[1:].Lookup("USD"))
+
+	assert.EqualError(t, FeePolicies{nil}.Validate(), "nil fee policy")
+	assert.EqualError(t, FeePolicies{{TokenType: "E*R", Flat: 1, Collector: collector}}.Validate(), "invalid fee policy: invalid token type pattern [E*R], '*' is allowed only at the end")
+	assert.EqualError(t, FeePolicies{{TokenType: "EUR", Collector: collector}}.Validate(), "invalid fee policy: no fee for token type [EUR]")
+	assert.EqualError(t, FeePolicies{{TokenType: "EUR", Rate: 10001, Collector: collector}}.Validate(), "invalid fee policy: invalid fee rate [10001] for token type [EUR], it must be at most 10000")
+	assert.EqualError(t, FeePolicies{{TokenType: "EUR", Flat: 1}}.Validate(), "invalid fee policy: no fee collector for token type [EUR]")
+	assert.EqualError(t, FeePolicies{{TokenType: "EUR", Flat: 1, Collector: collector}, {TokenType: "EUR", Rate: 1, Collector: collector}}.Validate(), "duplicate fee policy for token type [EUR]")
+}
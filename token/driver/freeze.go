@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/proto"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver/protos-go/request"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver/protos-go/utils"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/protos"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// FreezeAction freezes, or unfreezes, tokens and owners on behalf of an enforcer listed in the public parameters.
+// A frozen token, or a token owned by a frozen owner, can only be spent by a transfer forced by an enforcer.
+// The action does not depend on the driver, the freeze list is kept on the ledger by the network.
+type FreezeAction struct {
+	// Enforcer is the enforcer that signs the action
+	Enforcer Identity
+	// Unfreeze is true if the tokens and owners must be unfrozen
+	Unfreeze bool
+	// Tokens are the identifiers of the tokens to freeze or unfreeze
+	Tokens []*token.ID
+	// Owners are the owners to freeze or unfreeze.
+	// An owner is matched by its raw identity, therefore freezing an idemix pseudonym does not freeze
+	// the tokens sent to the other pseudonyms of the same user.
+	Owners []Identity
+}
+
+// Validate checks that the action has an enforcer and something to freeze or unfreeze
+func (f *FreezeAction) Validate() error {
+	if f.Enforcer.IsNone() {
+		return errors.New("invalid freeze action: missing enforcer")
+	}
+	if len(f.Tokens) == 0 && len(f.Owners) == 0 {
+		return errors.New("invalid freeze action: no tokens and no owners")
+	}
+	for i, id := range f.Tokens {
+		if id == nil {
+			return errors.Errorf("invalid freeze action: nil token at index [%d]", i)
+		}
+	}
+	for i, owner := range f.Owners {
+		if owner.IsNone() {
+			return errors.Errorf("invalid freeze action: empty owner at index [%d]", i)
+		}
+	}
+	return nil
+}
+
+// IsUnfreeze returns true if the action unfreezes its tokens and owners
+func (f *FreezeAction) IsUnfreeze() bool {
+	return f.Unfreeze
+}
+
+// GetEnforcer returns the enforcer that signs the action
+func (f *FreezeAction) GetEnforcer() Identity {
+	return f.Enforcer
+}
+
+// GetTokens returns the identifiers of the tokens to freeze or unfreeze
+func (f *FreezeAction) GetTokens() []*token.ID {
+	return f.Tokens
+}
+
+// GetOwners returns the owners to freeze or unfreeze
+func (f *FreezeAction) GetOwners() [][]byte {
+	owners := make([][]byte, len(f.Owners))
+	for i, owner := range f.Owners {
+		owners[i] = owner
+	}
+	return owners
+}
+
+// Serialize marshals the action
+func (f *FreezeAction) Serialize() ([]byte, error) {
+	tokens, err := protos.ToProtosSliceFunc(f.Tokens, utils.ToTokenID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshalling tokens")
+	}
+	return proto.Marshal(&request.FreezeAction{
+		Enforcer: toProtoIdentity(f.Enforcer),
+		Unfreeze: f.Unfreeze,
+		Tokens:   tokens,
+		Owners:   ToProtoIdentitySlice(f.Owners),
+	})
+}
+
+// Deserialize un-marshals the action from the passed bytes
+func (f *FreezeAction) Deserialize(raw []byte) error {
+	action := &request.FreezeAction{}
+	if err := proto.Unmarshal(raw, action); err != nil {
+		return errors.Wrap(err, "failed unmarshalling freeze action")
+	}
+	f.Enforcer = ToIdentity(action.Enforcer)
+	f.Unfreeze = action.Unfreeze
+	f.Tokens = make([]*token.ID, len(action.Tokens))
+	for i, id := range action.Tokens {
+		f.Tokens[i] = ToTokenID(id)
+	}
+	f.Owners = FromProtoIdentitySlice(action.Owners)
+	return nil
+}
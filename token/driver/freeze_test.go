@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"encoding/asn1"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreezeAction_Serialization(t *testing.T) {
+	action := &FreezeAction{
+		Enforcer: []byte("enforcer"),
+		Unfreeze: true,
+		Tokens:   []*token.ID{{TxId: "txid1", Index: 1}, {TxId: "txid2", Index: 0}},
+		Owners:   []Identity{[]byte("owner1")},
+	}
+	assert.NoError(t, action.Validate())
+	raw, err := action.Serialize()
+	assert.NoError(t, err)
+
+	action2 := &FreezeAction{}
+	assert.NoError(t, action2.Deserialize(raw))
+	assert.Equal(t, action, action2)
+	assert.True(t, action2.IsUnfreeze())
+	assert.Equal(t, [][]byte{[]byte("owner1")}, action2.GetOwners())
+}
+
+func TestFreezeAction_Validate(t *testing.T) {
+	assert.EqualError(t, (&FreezeAction{Owners: []Identity{[]byte("owner")}}).Validate(), "invalid freeze action: missing enforcer")
+	assert.EqualError(t, (&FreezeAction{Enforcer: []byte("enforcer")}).Validate(), "invalid freeze action: no tokens and no owners")
+	assert.EqualError(t, (&FreezeAction{Enforcer: []byte("enforcer"), Tokens: []*token.ID{nil}}).Validate(), "invalid freeze action: nil token at index [0]")
+	assert.EqualError(t, (&FreezeAction{Enforcer: []byte("enforcer"), Owners: []Identity{nil}}).Validate(), "invalid freeze action: empty owner at index [0]")
+}
+
+func TestTokenRequest_MessageToSignWithFreezes(t *testing.T) {
+	req := &TokenRequest{
+		Issues:    [][]byte{[]byte("issue1")},
+		Transfers: [][]byte{[]byte("transfer1")},
+	}
+	legacy, err := req.MarshalToMessageToSign([]byte("anchor"))
+	assert.NoError(t, err)
+	// without freeze actions, the message to sign is the one of the requests predating them
+	type legacyTokenRequest struct {
+		Issues            [][]byte
+		Transfers         [][]byte
+		Signatures        [][]byte
+		AuditorSignatures [][]byte
+	}
+	legacyRaw, err := asn1.Marshal(legacyTokenRequest{Issues: req.Issues, Transfers: req.Transfers})
+	assert.NoError(t, err)
+	assert.Equal(t, append(legacyRaw, []byte("anchor")...), legacy)
+
+	// the freeze actions are signed too
+	req.Freezes = [][]byte{[]byte("freeze1")}
+	withFreezes, err := req.MarshalToMessageToSign([]byte("anchor"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, legacy, withFreezes)
+
+	raw, err := req.Bytes()
+	assert.NoError(t, err)
+	req2 := &TokenRequest{}
+	assert.NoError(t, req2.FromBytes(raw))
+	assert.Equal(t, req, req2)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// IssuerPolicy restricts the issuers of the token types matching a pattern
+type IssuerPolicy struct {
+	// TokenType is either a token type, or a prefix of token types followed by '*'.
+	// A single '*' matches all token types.
+	TokenType string
+	// Issuers are the identities that can issue the matching token types
+	Issuers []Identity
+}
+
+// Matches returns true if the passed token type matches the pattern of this policy
+func (p *IssuerPolicy) Matches(tokenType token.Type) bool {
+	return matchTokenType(p.TokenType, tokenType)
+}
+
+// Validate checks that the pattern is well-formed and that there is at least one issuer
+func (p *IssuerPolicy) Validate() error {
+	if err := validateTokenTypePattern(p.TokenType); err != nil {
+		return err
+	}
+	if len(p.Issuers) == 0 {
+		return errors.Errorf("no issuers for token type [%s]", p.TokenType)
+	}
+	for _, issuer := range p.Issuers {
+		if issuer.IsNone() {
+			return errors.Errorf("empty issuer for token type [%s]", p.TokenType)
+		}
+	}
+	return nil
+}
+
+// IssuerPolicies is a list of issuer policies
+type IssuerPolicies []*IssuerPolicy
+
+// Lookup returns the most specific policy matching the passed token type, nil if none does.
+// An exact match wins over a prefix, and a longer prefix wins over a shorter one.
+func (ps IssuerPolicies) Lookup(tokenType token.Type) *IssuerPolicy {
+	var res *IssuerPolicy
+	for _, p := range ps {
+		if !p.Matches(tokenType) {
+			continue
+		}
+		if p.TokenType == string(tokenType) {
+			return p
+		}
+		if res == nil || len(p.TokenType) > len(res.TokenType) {
+			res = p
+		}
+	}
+	return res
+}
+
+// AuthorizedIssuers returns the issuers that can issue the passed token type.
+// If no policy matches the token type, it returns the passed default issuers.
+func (ps IssuerPolicies) AuthorizedIssuers(tokenType token.Type, defaults []Identity) []Identity {
+	if p := ps.Lookup(tokenType); p != nil {
+		return p.Issuers
+	}
+	return defaults
+}
+
+// Validate checks that each policy is well-formed and that no two policies have the same pattern
+func (ps IssuerPolicies) Validate() error {
+	patterns := make(map[string]struct{}, len(ps))
+	for _, p := range ps {
+		if p == nil {
+			return errors.New("nil issuer policy")
+		}
+		if err := p.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid issuer policy")
+		}
+		if _, ok := patterns[p.TokenType]; ok {
+			return errors.Errorf("duplicate issuer policy for token type [%s]", p.TokenType)
+		}
+		patterns[p.TokenType] = struct{}{}
+	}
+	return nil
+}
+
+// ContainsIssuer returns true if the passed issuer is in the passed list
+func ContainsIssuer(issuers []Identity, issuer Identity) bool {
+	for _, id := range issuers {
+		if bytes.Equal(id, issuer) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTokenType returns true if the passed token type matches the passed pattern.
+// A pattern is either a token type, or a prefix of token types followed by '*'.
+func matchTokenType(pattern string, tokenType token.Type) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(string(tokenType), prefix)
+	}
+	return pattern == string(tokenType)
+}
+
+func validateTokenTypePattern(pattern string) error {
+	if len(pattern) == 0 {
+		return errors.New("empty token type")
+	}
+	if i := strings.Index(pattern, "*"); i >= 0 && i != len(pattern)-1 {
+		return errors.Errorf("invalid token type pattern [%s], '*' is allowed only at the end", pattern)
+	}
+	return nil
+}
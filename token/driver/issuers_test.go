@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssuerPolicies_Lookup(t *testing.T) {
+	policies := IssuerPolicies{
+		{TokenType: "*", Issuers: []Identity{[]byte("any")}},
+		{TokenType: "EUR*", Issuers: []Identity{[]byte("ecb")}},
+		{TokenType: "EUR.bond*", Issuers: []Identity{[]byte("bonds")}},
+		{TokenType: "EUR.bond.2030", Issuers: []Identity{[]byte("treasury")}},
+	}
+	assert.NoError(t, policies.Validate())
+
+	assert.Equal(t, "any", string(policies.Lookup("USD").Issuers[0]))
+	assert.Equal(t, "ecb", string(policies.Lookup("EUR").Issuers[0]))
+	assert.Equal(t, "bonds", string(policies.Lookup("EUR.bond.2040").Issuers[0]))
+	assert.Equal(t, "treasury", string(policies.Lookup("EUR.bond.2030").Issuers[0]))
+
+	defaults := []Identity{[]byte("default")}
+	assert.Equal(t, defaults, policies[1:].AuthorizedIssuers("USD", defaults))
+	assert.Equal(t, []Identity{[]byte("ecb")}, policies[1:].AuthorizedIssuers("EUR", defaults))
+	assert.Nil(t, IssuerPolicies(nil).Lookup("USD"))
+}
+
+func TestIssuerPolicies_Validate(t *testing.T) {
+	issuers := []Identity{[]byte("issuer")}
+	assert.EqualError(t, IssuerPolicies{nil}.Validate(), "nil issuer policy")
+	assert.EqualError(t, IssuerPolicies{{TokenType: "", Issuers: issuers}}.Validate(), "invalid issuer policy: empty token type")
+	assert.EqualError(t, IssuerPolicies{{TokenType: "E*R", Issuers: issuers}}.Validate(), "invalid issuer policy: invalid token type pattern [E*R], '*' is allowed only at the end")
+	assert.EqualError(t, IssuerPolicies{{TokenType: "EUR"}}.Validate(), "invalid issuer policy: no issuers for token type [EUR]")
+	assert.EqualError(t, IssuerPolicies{{TokenType: "EUR", Issuers: []Identity{nil}}}.Validate(), "invalid issuer policy: empty issuer for token type [EUR]")
+	assert.EqualError(t, IssuerPolicies{{TokenType: "EUR", Issuers: issuers}, {TokenType: "EUR", Issuers: issuers}}.Validate(), "duplicate issuer policy for token type [EUR]")
+}
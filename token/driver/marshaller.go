@@ -51,3 +51,12 @@ func ToIdentity(id *request.Identity) Identity {
 	}
 	return id.Raw
 }
+
+func toProtoIdentity(id Identity) *request.Identity {
+	if id.IsNone() {
+		return nil
+	}
+	return &request.Identity{
+		Raw: id,
+	}
+}
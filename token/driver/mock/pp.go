@@ -29,6 +29,26 @@ type PublicParameters struct {
 	certificationDriverReturnsOnCall map[int]struct {
 		result1 string
 	}
+	EnforcersStub        func() []identity.Identity
+	enforcersMutex       sync.RWMutex
+	enforcersArgsForCall []struct {
+	}
+	enforcersReturns struct {
+		result1 []identity.Identity
+	}
+	enforcersReturnsOnCall map[int]struct {
+		result1 []identity.Identity
+	}
+	FeePoliciesStub        func() driver.FeePolicies
+	feePoliciesMutex       sync.RWMutex
+	feePoliciesArgsForCall []struct {
+	}
+	feePoliciesReturns struct {
+		result1 driver.FeePolicies
+	}
+	feePoliciesReturnsOnCall map[int]struct {
+		result1 driver.FeePolicies
+	}
 	GraphHidingStub        func() bool
 	graphHidingMutex       sync.RWMutex
 	graphHidingArgsForCall []struct {
@@ -79,6 +99,16 @@ type PublicParameters struct {
 	precisionReturnsOnCall map[int]struct {
 		result1 uint64
 	}
+	RequiredAuditorSignaturesStub        func() int
+	requiredAuditorSignaturesMutex       sync.RWMutex
+	requiredAuditorSignaturesArgsForCall []struct {
+	}
+	requiredAuditorSignaturesReturns struct {
+		result1 int
+	}
+	requiredAuditorSignaturesReturnsOnCall map[int]struct {
+		result1 int
+	}
 	SerializeStub        func() ([]byte, error)
 	serializeMutex       sync.RWMutex
 	serializeArgsForCall []struct {
@@ -241,6 +271,112 @@ func (fake *PublicParameters) CertificationDriverReturnsOnCall(i int, result1 st
 	}{result1}
 }
 
+func (fake *PublicParameters) Enforcers() []identity.Identity {
+	fake.enforcersMutex.Lock()
+	ret, specificReturn := fake.enforcersReturnsOnCall[len(fake.enforcersArgsForCall)]
+	fake.enforcersArgsForCall = append(fake.enforcersArgsForCall, struct {
+	}{})
+	stub := fake.EnforcersStub
+	fakeReturns := fake.enforcersReturns
+	fake.recordInvocation("Enforcers", []interface{}{})
+	fake.enforcersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PublicParameters) EnforcersCallCount() int {
+	fake.enforcersMutex.RLock()
+	defer fake.enforcersMutex.RUnlock()
+	return len(fake.enforcersArgsForCall)
+}
+
+func (fake *PublicParameters) EnforcersCalls(stub func() []identity.Identity) {
+	fake.enforcersMutex.Lock()
+	defer fake.enforcersMutex.Unlock()
+	fake.EnforcersStub = stub
+}
+
+func (fake *PublicParameters) EnforcersReturns(result1 []identity.Identity) {
+	fake.enforcersMutex.Lock()
+	defer fake.enforcersMutex.Unlock()
+	fake.EnforcersStub = nil
+	fake.enforcersReturns = struct {
+		result1 []identity.Identity
+	}{result1}
+}
+
+func (fake *PublicParameters) EnforcersReturnsOnCall(i int, result1 []identity.Identity) {
+	fake.enforcersMutex.Lock()
+	defer fake.enforcersMutex.Unlock()
+	fake.EnforcersStub = nil
+	if fake.enforcersReturnsOnCall == nil {
+		fake.enforcersReturnsOnCall = make(map[int]struct {
+			result1 []identity.Identity
+		})
+	}
+	fake.enforcersReturnsOnCall[i] = struct {
+		result1 []identity.Identity
+	}{result1}
+}
+
+func (fake *PublicParameters) FeePolicies() driver.FeePolicies {
+	fake.feePoliciesMutex.Lock()
+	ret, specificReturn := fake.feePoliciesReturnsOnCall[len(fake.feePoliciesArgsForCall)]
+	fake.feePoliciesArgsForCall = append(fake.feePoliciesArgsForCall, struct {
+	}{})
+	stub := fake.FeePoliciesStub
+	fakeReturns := fake.feePoliciesReturns
+	fake.recordInvocation("FeePolicies", []interface{}{})
+	fake.feePoliciesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PublicParameters) FeePoliciesCallCount() int {
+	fake.feePoliciesMutex.RLock()
+	defer fake.feePoliciesMutex.RUnlock()
+	return len(fake.feePoliciesArgsForCall)
+}
+
+func (fake *PublicParameters) FeePoliciesCalls(stub func() driver.FeePolicies) {
+	fake.feePoliciesMutex.Lock()
+	defer fake.feePoliciesMutex.Unlock()
+	fake.FeePoliciesStub = stub
+}
+
+func (fake *PublicParameters) FeePoliciesReturns(result1 driver.FeePolicies) {
+	fake.feePoliciesMutex.Lock()
+	defer fake.feePoliciesMutex.Unlock()
+	fake.FeePoliciesStub = nil
+	fake.feePoliciesReturns = struct {
+		result1 driver.FeePolicies
+	}{result1}
+}
+
+func (fake *PublicParameters) FeePoliciesReturnsOnCall(i int, result1 driver.FeePolicies) {
+	fake.feePoliciesMutex.Lock()
+	defer fake.feePoliciesMutex.Unlock()
+	fake.FeePoliciesStub = nil
+	if fake.feePoliciesReturnsOnCall == nil {
+		fake.feePoliciesReturnsOnCall = make(map[int]struct {
+			result1 driver.FeePolicies
+		})
+	}
+	fake.feePoliciesReturnsOnCall[i] = struct {
+		result1 driver.FeePolicies
+	}{result1}
+}
+
 func (fake *PublicParameters) GraphHiding() bool {
 	fake.graphHidingMutex.Lock()
 	ret, specificReturn := fake.graphHidingReturnsOnCall[len(fake.graphHidingArgsForCall)]
@@ -506,6 +642,59 @@ func (fake *PublicParameters) PrecisionReturnsOnCall(i int, result1 uint64) {
 	}{result1}
 }
 
+func (fake *PublicParameters) RequiredAuditorSignatures() int {
+	fake.requiredAuditorSignaturesMutex.Lock()
+	ret, specificReturn := fake.requiredAuditorSignaturesReturnsOnCall[len(fake.requiredAuditorSignaturesArgsForCall)]
+	fake.requiredAuditorSignaturesArgsForCall = append(fake.requiredAuditorSignaturesArgsForCall, struct {
+	}{})
+	stub := fake.RequiredAuditorSignaturesStub
+	fakeReturns := fake.requiredAuditorSignaturesReturns
+	fake.recordInvocation("RequiredAuditorSignatures", []interface{}{})
+	fake.requiredAuditorSignaturesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PublicParameters) RequiredAuditorSignaturesCallCount() int {
+	fake.requiredAuditorSignaturesMutex.RLock()
+	defer fake.requiredAuditorSignaturesMutex.RUnlock()
+	return len(fake.requiredAuditorSignaturesArgsForCall)
+}
+
+func (fake *PublicParameters) RequiredAuditorSignaturesCalls(stub func() int) {
+	fake.requiredAuditorSignaturesMutex.Lock()
+	defer fake.requiredAuditorSignaturesMutex.Unlock()
+	fake.RequiredAuditorSignaturesStub = stub
+}
+
+func (fake *PublicParameters) RequiredAuditorSignaturesReturns(result1 int) {
+	fake.requiredAuditorSignaturesMutex.Lock()
+	defer fake.requiredAuditorSignaturesMutex.Unlock()
+	fake.RequiredAuditorSignaturesStub = nil
+	fake.requiredAuditorSignaturesReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *PublicParameters) RequiredAuditorSignaturesReturnsOnCall(i int, result1 int) {
+	fake.requiredAuditorSignaturesMutex.Lock()
+	defer fake.requiredAuditorSignaturesMutex.Unlock()
+	fake.RequiredAuditorSignaturesStub = nil
+	if fake.requiredAuditorSignaturesReturnsOnCall == nil {
+		fake.requiredAuditorSignaturesReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.requiredAuditorSignaturesReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *PublicParameters) Serialize() ([]byte, error) {
 	fake.serializeMutex.Lock()
 	ret, specificReturn := fake.serializeReturnsOnCall[len(fake.serializeArgsForCall)]
@@ -781,6 +970,10 @@ func (fake *PublicParameters) Invocations() map[string][][]interface{} {
 	defer fake.auditorsMutex.RUnlock()
 	fake.certificationDriverMutex.RLock()
 	defer fake.certificationDriverMutex.RUnlock()
+	fake.enforcersMutex.RLock()
+	defer fake.enforcersMutex.RUnlock()
+	fake.feePoliciesMutex.RLock()
+	defer fake.feePoliciesMutex.RUnlock()
 	fake.graphHidingMutex.RLock()
 	defer fake.graphHidingMutex.RUnlock()
 	fake.identifierMutex.RLock()
@@ -791,6 +984,8 @@ func (fake *PublicParameters) Invocations() map[string][][]interface{} {
 	defer fake.maxTokenValueMutex.RUnlock()
 	fake.precisionMutex.RLock()
 	defer fake.precisionMutex.RUnlock()
+	fake.requiredAuditorSignaturesMutex.RLock()
+	defer fake.requiredAuditorSignaturesMutex.RUnlock()
 	fake.serializeMutex.RLock()
 	defer fake.serializeMutex.RUnlock()
 	fake.stringMutex.RLock()
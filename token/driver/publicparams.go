@@ -48,8 +48,15 @@ type PublicParameters interface {
 	CertificationDriver() string
 	// Auditors returns the list of auditors.
 	Auditors() []Identity
+	// RequiredAuditorSignatures returns the number of auditors that must sign a token request.
+	// It is zero if there are no auditors.
+	RequiredAuditorSignatures() int
 	// Issuers returns the list of issuers.
 	Issuers() []Identity
+	// FeePolicies returns the fees charged on transfers, if any.
+	FeePolicies() FeePolicies
+	// Enforcers returns the list of enforcers, the entities that can freeze tokens and owners, and force transfers.
+	Enforcers() []Identity
 	// Precision returns the precision used to represent the token value.
 	Precision() uint64
 	// String returns a readable version of the public parameters
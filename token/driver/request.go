@@ -25,6 +25,7 @@ const (
 // TokenRequest is a collection of Token Action:
 // Issues, to create new Tokens;
 // Transfers, to manipulate Tokens (e.g., transfer ownership or redeem)
+// Freezes, to freeze or unfreeze tokens and owners (see FreezeAction)
 // The actions in the collection are independent. An action cannot spend tokens created by another action
 // in the same Token Request.
 // In addition, actions comes with a set of Witnesses to verify the right to spend or the right to issue a given token
@@ -33,6 +34,8 @@ type TokenRequest struct {
 	Transfers         [][]byte
 	Signatures        [][]byte
 	AuditorSignatures [][]byte
+	// Freezes is marshalled, for signing, only if not empty. This keeps the message to sign of the other requests unchanged.
+	Freezes [][]byte `asn1:"optional,omitempty"`
 }
 
 func (r *TokenRequest) Bytes() ([]byte, error) {
@@ -60,6 +63,7 @@ func (r *TokenRequest) ToProtos() (*request.TokenRequest, error) {
 		utils.ToActionSlice(request.ActionType_ISSUE, r.Issues),
 		utils.ToActionSlice(request.ActionType_TRANSFER, r.Transfers)...,
 	)
+	tr.Actions = append(tr.Actions, utils.ToActionSlice(request.ActionType_FREEZE, r.Freezes)...)
 	tr.Signatures = utils.ToSignatureSlice(r.Signatures)
 	tr.AuditorSignatures = utils.ToSignatureSlice(r.AuditorSignatures)
 	return tr, nil
@@ -80,6 +84,8 @@ func (r *TokenRequest) FromProtos(tr *request.TokenRequest) error {
 			r.Issues = append(r.Issues, action.Raw)
 		case request.ActionType_TRANSFER:
 			r.Transfers = append(r.Transfers, action.Raw)
+		case request.ActionType_FREEZE:
+			r.Freezes = append(r.Freezes, action.Raw)
 		default:
 			return errors.Errorf("unknown action type [%s]", action.Type)
 		}
@@ -90,8 +96,9 @@ func (r *TokenRequest) FromProtos(tr *request.TokenRequest) error {
 		}
 		r.Signatures = append(r.Signatures, signature.Raw)
 	}
+	// auditor signatures are positional, an empty signature marks an auditor that did not sign
 	for _, signature := range tr.AuditorSignatures {
-		if signature == nil || len(signature.Raw) == 0 {
+		if signature == nil {
 			return errors.New("nil auditor signature found")
 		}
 		r.AuditorSignatures = append(r.AuditorSignatures, signature.Raw)
@@ -100,7 +107,7 @@ func (r *TokenRequest) FromProtos(tr *request.TokenRequest) error {
 }
 
 func (r *TokenRequest) MarshalToMessageToSign(anchor []byte) ([]byte, error) {
-	bytes, err := asn1.Marshal(TokenRequest{Issues: r.Issues, Transfers: r.Transfers})
+	bytes, err := asn1.Marshal(TokenRequest{Issues: r.Issues, Transfers: r.Transfers, Freezes: r.Freezes})
 	if err != nil {
 		return nil, errors.Wrapf(err, "audit of tx [%s] failed: error marshal token request for signature", string(anchor))
 	}
@@ -337,6 +344,9 @@ type TransferMetadata struct {
 	// ExtraSigners is the list of extra identities that are not part of the transfer action per se
 	// but needs to sign the request
 	ExtraSigners []Identity
+	// Enforcer is the enforcer that forces the transfer, if any.
+	// It signs the request in place of the senders, once per input.
+	Enforcer Identity
 }
 
 // TokenIDAt returns the TokenID at the given index.
@@ -361,6 +371,7 @@ func (t *TransferMetadata) ToProtos() (*request.TransferMetadata, error) {
 		Inputs:       inputs,
 		Outputs:      outputs,
 		ExtraSigners: ToProtoIdentitySlice(t.ExtraSigners),
+		Enforcer:     toProtoIdentity(t.Enforcer),
 	}, nil
 }
 
@@ -374,6 +385,7 @@ func (t *TransferMetadata) FromProtos(transferMetadata *request.TransferMetadata
 		return errors.Wrap(err, "failed unmarshalling outputs")
 	}
 	t.ExtraSigners = FromProtoIdentitySlice(transferMetadata.ExtraSigners)
+	t.Enforcer = ToIdentity(transferMetadata.Enforcer)
 	return nil
 }
 
@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 
 package driver
 
+import "time"
+
 type FullIdentity interface {
 	SigningIdentity
 	Verifier
@@ -28,6 +30,32 @@ type Verifier interface {
 	Verify(message, sigma []byte) error
 }
 
+// TimeDependentVerifier is a Verifier whose outcome depends on the time the signature is verified at,
+// like the verifier of a timelock script.
+type TimeDependentVerifier interface {
+	Verifier
+	// VerifyAt is like Verify but at the passed time
+	VerifyAt(message, sigma []byte, now time.Time) error
+}
+
+// VerifierAt returns a Verifier that verifies the signatures at the passed time, if the passed verifier is a TimeDependentVerifier.
+// Otherwise, it returns the passed verifier.
+func VerifierAt(verifier Verifier, now time.Time) Verifier {
+	if v, ok := verifier.(TimeDependentVerifier); ok {
+		return &verifierAt{verifier: v, now: now}
+	}
+	return verifier
+}
+
+type verifierAt struct {
+	verifier TimeDependentVerifier
+	now      time.Time
+}
+
+func (v *verifierAt) Verify(message, sigma []byte) error {
+	return v.verifier.VerifyAt(message, sigma, v.now)
+}
+
 //go:generate counterfeiter -o mock/signer.go -fake-name Signer . Signer
 
 // Signer is an interface which wraps the Sign method.
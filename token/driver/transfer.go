@@ -16,6 +16,9 @@ import (
 type TransferOptions struct {
 	// Attributes is a container of generic options that might be driver specific
 	Attributes map[interface{}]interface{}
+	// Enforcer, if set, is the enforcer that forces the transfer.
+	// The enforcer signs in place of the owners of the inputs.
+	Enforcer Identity
 }
 
 //go:generate counterfeiter -o mock/ts.go -fake-name TransferService . TransferService
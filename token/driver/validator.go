@@ -8,6 +8,7 @@ package driver
 
 import (
 	"context"
+	"time"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 )
@@ -18,6 +19,52 @@ type ValidationAttributeID = string
 // ValidationAttributes is a map containing attributes generated during validation
 type ValidationAttributes = map[ValidationAttributeID][]byte
 
+type validationTimeKey struct{}
+
+// WithValidationTime returns a copy of the passed context that carries the time the token request is validated against,
+// for instance the timestamp of the transaction carrying the token request.
+func WithValidationTime(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, validationTimeKey{}, t)
+}
+
+// ValidationTime returns the time carried by the passed context, if any. Otherwise, it returns the current time.
+func ValidationTime(ctx context.Context) time.Time {
+	if ctx != nil {
+		if t, ok := ctx.Value(validationTimeKey{}).(time.Time); ok {
+			return t
+		}
+	}
+	return time.Now()
+}
+
+// FreezeLedger models a read-only view of the freezes recorded on the ledger
+type FreezeLedger interface {
+	// IsTokenFrozen returns true if the token with the passed id is frozen
+	IsTokenFrozen(id token.ID) (bool, error)
+	// IsOwnerFrozen returns true if the passed owner is frozen.
+	// The owner is matched by its raw identity, therefore freezing an owner has no effect on the tokens
+	// owned by other identities of the same user, for instance, idemix pseudonyms.
+	IsOwnerFrozen(owner Identity) (bool, error)
+}
+
+type freezeLedgerKey struct{}
+
+// WithFreezeLedger returns a copy of the passed context that carries the ledger the freezes are checked against
+func WithFreezeLedger(ctx context.Context, l FreezeLedger) context.Context {
+	return context.WithValue(ctx, freezeLedgerKey{}, l)
+}
+
+// GetFreezeLedger returns the freeze ledger carried by the passed context, nil if there is none.
+// Only the validators that have access to the ledger, such as the endorsers, carry one.
+func GetFreezeLedger(ctx context.Context) FreezeLedger {
+	if ctx != nil {
+		if l, ok := ctx.Value(freezeLedgerKey{}).(FreezeLedger); ok {
+			return l
+		}
+	}
+	return nil
+}
+
 // GetStateFnc models a function that returns the value for the given key from the ledger
 type GetStateFnc = func(id token.ID) ([]byte, error)
 
@@ -10,6 +10,7 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 )
 
@@ -53,6 +54,18 @@ type CertificationClientProvider interface {
 	New(tms *ManagementService) (driver.CertificationClient, error)
 }
 
+// SpendingPolicy decides whether an owner wallet is allowed to send tokens
+type SpendingPolicy interface {
+	// CheckSpending returns an error if the passed wallet is not allowed to send the passed value of the passed token type
+	CheckSpending(wallet *OwnerWallet, tokenType token.Type, value uint64) error
+}
+
+// SpendingPolicyProvider provides instances of SpendingPolicy
+type SpendingPolicyProvider interface {
+	// SpendingPolicy returns the SpendingPolicy of the passed management service, nil if there is none.
+	SpendingPolicy(tms *ManagementService) (SpendingPolicy, error)
+}
+
 // ManagementServiceProvider provides instances of the management service
 type ManagementServiceProvider struct {
 	logger                      logging.Logger
@@ -61,6 +74,7 @@ type ManagementServiceProvider struct {
 	certificationClientProvider CertificationClientProvider
 	selectorManagerProvider     SelectorManagerProvider
 	vaultProvider               VaultProvider
+	spendingPolicyProvider      SpendingPolicyProvider
 }
 
 // NewManagementServiceProvider returns a new instance of ManagementServiceProvider
@@ -82,6 +96,12 @@ func NewManagementServiceProvider(
 	}
 }
 
+// SetSpendingPolicyProvider sets the provider of the spending policies consulted by Request.Transfer and Request.Redeem.
+// If no provider is set, no spending policy is consulted.
+func (p *ManagementServiceProvider) SetSpendingPolicyProvider(spendingPolicyProvider SpendingPolicyProvider) {
+	p.spendingPolicyProvider = spendingPolicyProvider
+}
+
 // GetManagementService returns an instance of the management service for the passed options.
 // If the management service has not been created yet, it will be created.
 func (p *ManagementServiceProvider) GetManagementService(opts ...ServiceOption) (*ManagementService, error) {
@@ -134,6 +154,7 @@ func (p *ManagementServiceProvider) managementService(aNew bool, opts ...Service
 		vaultProvider:               p.vaultProvider,
 		certificationClientProvider: p.certificationClientProvider,
 		selectorManagerProvider:     p.selectorManagerProvider,
+		spendingPolicyProvider:      p.spendingPolicyProvider,
 		signatureService: &SignatureService{
 			deserializer:     tokenService.Deserializer(),
 			identityProvider: tokenService.IdentityProvider(),
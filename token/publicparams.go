@@ -57,6 +57,21 @@ func (c *PublicParameters) Auditors() []Identity {
 	return c.PublicParameters.Auditors()
 }
 
+// RequiredAuditorSignatures returns the number of auditors that must sign a token request
+func (c *PublicParameters) RequiredAuditorSignatures() int {
+	return c.PublicParameters.RequiredAuditorSignatures()
+}
+
+// FeePolicies returns the fees charged on transfers, by token type
+func (c *PublicParameters) FeePolicies() driver.FeePolicies {
+	return c.PublicParameters.FeePolicies()
+}
+
+// Enforcers returns the list of enforcers, the entities that can freeze tokens and owners, and force transfers
+func (c *PublicParameters) Enforcers() []Identity {
+	return c.PublicParameters.Enforcers()
+}
+
 // PublicParamsFetcher models the public parameters fetcher
 type PublicParamsFetcher interface {
 	// Fetch fetches the public parameters from the backend
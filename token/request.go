@@ -8,6 +8,8 @@ package token
 
 import (
 	"context"
+	"math/bits"
+	"slices"
 
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/proto"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/hash"
@@ -78,6 +80,8 @@ type TransferOptions struct {
 	TokenIDs []*token.ID
 	// RestRecipientIdentity TODO:
 	RestRecipientIdentity *RecipientData
+	// NoFee, if true, tells Transfer not to add the fee output required by the public parameters
+	NoFee bool
 }
 
 func compileTransferOptions(opts ...TransferOption) (*TransferOptions, error) {
@@ -133,6 +137,15 @@ func WithRestRecipientIdentity(recipientData *RecipientData) TransferOption {
 	}
 }
 
+// WithoutFee tells Transfer not to add the fee output required by the public parameters.
+// It is meant for the transfers the validators exempt from fees, like the spending of an htlc script.
+func WithoutFee() TransferOption {
+	return func(o *TransferOptions) error {
+		o.NoFee = true
+		return nil
+	}
+}
+
 // AuditRecord models the audit record returned by the audit command
 // It contains the token request's anchor, inputs (with Type and Quantity), and outputs
 type AuditRecord struct {
@@ -165,6 +178,9 @@ type Transfer struct {
 	// This field is to be used by the token drivers to list any additional identities that must
 	// sign the token request.
 	ExtraSigners []Identity
+	// Enforcer is the enforcer that forces the transfer, if any.
+	// A forced transfer is signed by the enforcer in place of the senders.
+	Enforcer Identity
 }
 
 // Request aggregates token operations that must be performed atomically.
@@ -179,6 +195,20 @@ type Request struct {
 	Metadata *driver.TokenRequestMetadata
 	// TokenService this request refers to
 	TokenService *ManagementService `json:"-"`
+
+	// sent is the value sent by the local wallets in the actions appended so far, by enrollment ID and token type
+	sent map[spendingKey]uint64
+}
+
+type spendingKey struct {
+	enrollmentID string
+	tokenType    token.Type
+}
+
+// spending is the value of a token type sent by an enrollment ID in an action
+type spending struct {
+	key   spendingKey
+	value uint64
 }
 
 // NewRequest creates a new empty request for the given token service and anchor
@@ -290,6 +320,12 @@ func (r *Request) Issue(ctx context.Context, wallet *IssuerWallet, receiver Iden
 // Transfer appends a transfer action to the request. The action will be prepared using the provided owner wallet.
 // The action transfers tokens of the passed types to the receivers for the passed quantities.
 // In other words, owners[0] will receives values[0], and so on.
+// If the public parameters charge a fee on the passed type, an output paying the fee to the collector is added,
+// unless the WithoutFee option is passed. The fee is computed as the validators do, once the inputs are known.
+// The rest goes to a fresh identity of the wallet, unless a fee is charged. The validators recognize the change by its owner,
+// then, in this case, the rest goes back to the owner of an input of the wallet, and it is not charged.
+// If the management service has a spending policy, the policy is consulted on the value sent to the other owners, fee included,
+// summed to the value of the same type sent by the enrollment ID of the wallet in the actions already in the request.
 // Additional options can be passed to customize the action.
 func (r *Request) Transfer(ctx context.Context, wallet *OwnerWallet, typ token.Type, values []uint64, owners []Identity, opts ...TransferOption) (*TransferAction, error) {
 	for _, v := range values {
@@ -301,7 +337,7 @@ func (r *Request) Transfer(ctx context.Context, wallet *OwnerWallet, typ token.T
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed compiling options [%v]", opts)
 	}
-	tokenIDs, outputTokens, err := r.prepareTransfer(false, wallet, typ, values, owners, opt)
+	tokenIDs, outputTokens, sent, err := r.prepareTransfer(false, wallet, typ, values, owners, opt)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed preparing transfer")
 	}
@@ -338,19 +374,22 @@ func (r *Request) Transfer(ctx context.Context, wallet *OwnerWallet, typ token.T
 	}
 	r.Actions.Transfers = append(r.Actions.Transfers, raw)
 	r.Metadata.Transfers = append(r.Metadata.Transfers, transferMetadata)
+	r.addSent(sent)
 
 	return &TransferAction{a: transfer}, nil
 }
 
 // Redeem appends a redeem action to the request. The action will be prepared using the provided owner wallet.
 // The action redeems tokens of the passed type for a total amount matching the passed value.
+// If the management service has a spending policy, the policy is consulted on the redeemed value,
+// summed to the value of the same type sent by the enrollment ID of the wallet in the actions already in the request.
 // Additional options can be passed to customize the action.
 func (r *Request) Redeem(ctx context.Context, wallet *OwnerWallet, typ token.Type, value uint64, opts ...TransferOption) error {
 	opt, err := compileTransferOptions(opts...)
 	if err != nil {
 		return errors.WithMessagef(err, "failed compiling options [%v]", opts)
 	}
-	tokenIDs, outputTokens, err := r.prepareTransfer(true, wallet, typ, []uint64{value}, []Identity{nil}, opt)
+	tokenIDs, outputTokens, sent, err := r.prepareTransfer(true, wallet, typ, []uint64{value}, []Identity{nil}, opt)
 	if err != nil {
 		return errors.Wrap(err, "failed preparing transfer")
 	}
@@ -389,10 +428,130 @@ func (r *Request) Redeem(ctx context.Context, wallet *OwnerWallet, typ token.Typ
 
 	r.Actions.Transfers = append(r.Actions.Transfers, raw)
 	r.Metadata.Transfers = append(r.Metadata.Transfers, transferMetadata)
+	r.addSent(sent)
 
 	return nil
 }
 
+// ForcedTransfer appends a transfer action, forced by the passed enforcer, that moves the tokens with the passed ids
+// to the passed recipient, whatever their owners and even if they are frozen.
+// The tokens must have the same type, and must be available in the vault of this node together with the audit info of their owners.
+// The enforcer must be listed in the public parameters. It signs the action in place of the owners.
+// Forced transfers do not pay fees and are not subject to the spending policy.
+func (r *Request) ForcedTransfer(ctx context.Context, enforcer Identity, ids []*token.ID, recipient Identity, opts ...TransferOption) (*TransferAction, error) {
+	if enforcer.IsNone() {
+		return nil, errors.New("enforcer must be defined")
+	}
+	return r.transferTokens(ctx, enforcer, ids, recipient, opts...)
+}
+
+// Reclaim appends a transfer action that moves the tokens with the passed ids to the passed recipient.
+// The action has no sender wallet, the tokens are spent by the party designated by their owners, for instance
+// the issuer of expired tokens bound to a validity window.
+func (r *Request) Reclaim(ctx context.Context, ids []*token.ID, recipient Identity, opts ...TransferOption) (*TransferAction, error) {
+	return r.transferTokens(ctx, nil, ids, recipient, opts...)
+}
+
+// transferTokens appends a transfer action that moves the tokens with the passed ids to the passed recipient,
+// with a single output. If the enforcer is defined, the transfer is forced by the enforcer.
+func (r *Request) transferTokens(ctx context.Context, enforcer Identity, ids []*token.ID, recipient Identity, opts ...TransferOption) (*TransferAction, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("no token to transfer")
+	}
+	if recipient.IsNone() {
+		return nil, errors.New("recipient must be defined")
+	}
+	opt, err := compileTransferOptions(opts...)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed compiling options [%v]", opts)
+	}
+	tokenIDs, sum, typ, err := r.parseInputIDs(ids)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed parsing token ids")
+	}
+	outputTokens := []*token.Token{{
+		Owner:    recipient,
+		Type:     typ,
+		Quantity: sum.Hex(),
+	}}
+
+	r.TokenService.logger.Debugf("Prepare Transfer Action of Tokens [id:%s,ins:%d,outs:%d,forced:%v]", r.Anchor, len(tokenIDs), len(outputTokens), !enforcer.IsNone())
+
+	ts := r.TokenService.tms.TransferService()
+	transfer, transferMetadata, err := ts.Transfer(
+		ctx,
+		r.Anchor,
+		nil,
+		tokenIDs,
+		outputTokens,
+		&driver.TransferOptions{
+			Attributes: opt.Attributes,
+			Enforcer:   enforcer,
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating transfer action")
+	}
+	if r.TokenService.logger.IsEnabledFor(zapcore.DebugLevel) {
+		// double check
+		if err := ts.VerifyTransfer(transfer, transferMetadata.Outputs); err != nil {
+			return nil, errors.Wrap(err, "failed checking generated proof")
+		}
+	}
+
+	// Append
+	raw, err := transfer.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed serializing transfer action")
+	}
+	r.Actions.Transfers = append(r.Actions.Transfers, raw)
+	r.Metadata.Transfers = append(r.Metadata.Transfers, transferMetadata)
+
+	return &TransferAction{a: transfer}, nil
+}
+
+// Freeze appends a freeze action, signed by the passed enforcer, that freezes the tokens with the passed ids and the passed owners.
+// A frozen token, or a token owned by a frozen owner, can only be spent by a forced transfer.
+// Owners are matched by their raw identity, therefore freezing an anonymous owner, such as an idemix pseudonym,
+// does not freeze the tokens of the same user held under other pseudonyms: freeze those tokens by id instead.
+// The enforcer must be listed in the public parameters.
+func (r *Request) Freeze(enforcer Identity, ids []*token.ID, owners []Identity) error {
+	return r.appendFreeze(&driver.FreezeAction{Enforcer: enforcer, Tokens: ids, Owners: owners})
+}
+
+// Unfreeze appends a freeze action, signed by the passed enforcer, that unfreezes the tokens with the passed ids and the passed owners.
+// The enforcer must be listed in the public parameters.
+func (r *Request) Unfreeze(enforcer Identity, ids []*token.ID, owners []Identity) error {
+	return r.appendFreeze(&driver.FreezeAction{Enforcer: enforcer, Unfreeze: true, Tokens: ids, Owners: owners})
+}
+
+func (r *Request) appendFreeze(action *driver.FreezeAction) error {
+	if err := action.Validate(); err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(r.TokenService.PublicParametersManager().PublicParameters().Enforcers(), action.Enforcer.Equal) {
+		return errors.Errorf("[%s] is not an enforcer", action.Enforcer)
+	}
+	raw, err := action.Serialize()
+	if err != nil {
+		return errors.Wrap(err, "failed serializing freeze action")
+	}
+	r.Actions.Freezes = append(r.Actions.Freezes, raw)
+	return nil
+}
+
+// Freezes returns the freeze actions of the request
+func (r *Request) Freezes() ([]*driver.FreezeAction, error) {
+	freezes := make([]*driver.FreezeAction, len(r.Actions.Freezes))
+	for i, raw := range r.Actions.Freezes {
+		freezes[i] = &driver.FreezeAction{}
+		if err := freezes[i].Deserialize(raw); err != nil {
+			return nil, errors.Wrapf(err, "failed deserializing freeze action [%d]", i)
+		}
+	}
+	return freezes, nil
+}
+
 // Upgrade performs an upgrade operation of the passed ledger tokens.
 // A proof and its challenge will be used to verify that the request of upgrade is legit.
 // If the proof verifies then the passed wallet will be used to issue a new amount of tokens
@@ -1043,8 +1202,29 @@ func (r *Request) AddAuditorSignature(sigma []byte) {
 	r.Actions.AuditorSignatures = append(r.Actions.AuditorSignatures, sigma)
 }
 
+// SetAuditorSignature sets the signature of the auditor at the passed index in the list of auditors of the public parameters.
+// Auditor signatures are positional, the entries of the auditors that did not sign are left empty.
+func (r *Request) SetAuditorSignature(index int, sigma []byte) {
+	for len(r.Actions.AuditorSignatures) <= index {
+		r.Actions.AuditorSignatures = append(r.Actions.AuditorSignatures, nil)
+	}
+	r.Actions.AuditorSignatures[index] = sigma
+}
+
+// AuditorSignaturesCount returns the number of auditors that signed the request
+func (r *Request) AuditorSignaturesCount() int {
+	count := 0
+	for _, sigma := range r.Actions.AuditorSignatures {
+		if len(sigma) != 0 {
+			count++
+		}
+	}
+	return count
+}
+
 func (r *Request) SetSignatures(sigmas map[string][]byte) bool {
 	signers := append(r.IssueSigners(), r.TransferSigners()...)
+	signers = append(signers, r.FreezeSigners()...)
 	signatures := make([][]byte, len(signers))
 	all := true
 	for i, signer := range signers {
@@ -1063,12 +1243,43 @@ func (r *Request) SetSignatures(sigmas map[string][]byte) bool {
 func (r *Request) TransferSigners() []Identity {
 	signers := make([]Identity, 0)
 	for _, transfer := range r.Transfers() {
-		signers = append(signers, transfer.Senders...)
+		if transfer.Enforcer.IsNone() {
+			signers = append(signers, transfer.Senders...)
+		} else {
+			// the enforcer signs in place of each sender
+			for range transfer.Senders {
+				signers = append(signers, transfer.Enforcer)
+			}
+		}
 		signers = append(signers, transfer.ExtraSigners...)
 	}
 	return signers
 }
 
+// HasForcedTransfers returns true if the request contains a transfer forced by an enforcer
+func (r *Request) HasForcedTransfers() bool {
+	for _, transfer := range r.Metadata.Transfers {
+		if !transfer.Enforcer.IsNone() {
+			return true
+		}
+	}
+	return false
+}
+
+// FreezeSigners returns the enforcers that must sign the freeze actions of the request, one for each action
+func (r *Request) FreezeSigners() []Identity {
+	signers := make([]Identity, 0, len(r.Actions.Freezes))
+	freezes, err := r.Freezes()
+	if err != nil {
+		r.TokenService.logger.Errorf("failed getting freeze actions: [%s]", err)
+		return signers
+	}
+	for _, freeze := range freezes {
+		signers = append(signers, freeze.Enforcer)
+	}
+	return signers
+}
+
 func (r *Request) IssueSigners() []Identity {
 	signers := make([]Identity, 0)
 	for _, issue := range r.Issues() {
@@ -1153,6 +1364,7 @@ func (r *Request) Transfers() []*Transfer {
 			Senders:      transfer.Senders(),
 			Receivers:    transfer.Receivers(),
 			ExtraSigners: transfer.ExtraSigners,
+			Enforcer:     transfer.Enforcer,
 		})
 	}
 	return transfers
@@ -1311,15 +1523,15 @@ func (r *Request) parseInputIDs(inputs []*token.ID) ([]*token.ID, token.Quantity
 	return inputs, sum, typ, nil
 }
 
-func (r *Request) prepareTransfer(redeem bool, wallet *OwnerWallet, tokenType token.Type, values []uint64, owners []Identity, transferOpts *TransferOptions) ([]*token.ID, []*token.Token, error) {
+func (r *Request) prepareTransfer(redeem bool, wallet *OwnerWallet, tokenType token.Type, values []uint64, owners []Identity, transferOpts *TransferOptions) ([]*token.ID, []*token.Token, *spending, error) {
 	for _, owner := range owners {
 		if redeem {
 			if !owner.IsNone() {
-				return nil, nil, errors.Errorf("all recipients must be nil")
+				return nil, nil, nil, errors.Errorf("all recipients must be nil")
 			}
 		} else {
 			if owner.IsNone() {
-				return nil, nil, errors.Errorf("all recipients should be defined")
+				return nil, nil, nil, errors.Errorf("all recipients should be defined")
 			}
 		}
 	}
@@ -1333,18 +1545,39 @@ func (r *Request) prepareTransfer(redeem bool, wallet *OwnerWallet, tokenType to
 	if len(transferOpts.TokenIDs) != 0 {
 		tokenIDs, inputSum, tokenType, err = r.parseInputIDs(transferOpts.TokenIDs)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "failed parsing passed input tokens")
+			return nil, nil, nil, errors.Wrap(err, "failed parsing passed input tokens")
 		}
 	}
 
 	if tokenType == "" {
-		return nil, nil, errors.Errorf("type is empty")
+		return nil, nil, nil, errors.Errorf("type is empty")
 	}
 
 	// Compute output tokens
 	outputTokens, outputSum, err := r.genOutputs(values, owners, tokenType)
 	if err != nil {
-		return nil, nil, errors.WithMessagef(err, "failed to generate outputs")
+		return nil, nil, nil, errors.WithMessagef(err, "failed to generate outputs")
+	}
+	precision := r.TokenService.PublicParametersManager().PublicParameters().Precision()
+
+	// Is there a fee?
+	// The owners of the inputs are not known before the selection, then the selection covers the fee charged on all the outputs,
+	// which is at least the fee charged once the owners of the inputs are known.
+	feePolicy := r.TokenService.PublicParametersManager().PublicParameters().FeePolicies().Lookup(tokenType)
+	if transferOpts.NoFee {
+		feePolicy = nil
+	}
+	selectSum := outputSum
+	if feePolicy != nil {
+		maxFee, err := feeDue(feePolicy, nil, values, owners)
+		if err != nil {
+			return nil, nil, nil, errors.WithMessagef(err, "failed computing fee")
+		}
+		maxFeeQ, err := token.UInt64ToQuantity(maxFee, precision)
+		if err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "failed to convert fee [%d] to quantity of precision [%d]", maxFee, precision)
+		}
+		selectSum = token.NewZeroQuantity(precision).Add(outputSum).Add(maxFeeQ)
 	}
 
 	// Select input tokens, if not passed as opt
@@ -1354,63 +1587,237 @@ func (r *Request) prepareTransfer(redeem bool, wallet *OwnerWallet, tokenType to
 			// resort to default strategy
 			sm, err := r.TokenService.SelectorManager()
 			if err != nil {
-				return nil, nil, errors.Wrapf(err, "failed to get selector manager")
+				return nil, nil, nil, errors.Wrapf(err, "failed to get selector manager")
 			}
 			selector, err = sm.NewSelector(r.Anchor)
 			defer sm.Close(r.Anchor)
 			if err != nil {
-				return nil, nil, errors.Wrapf(err, "failed getting default selector")
+				return nil, nil, nil, errors.Wrapf(err, "failed getting default selector")
 			}
 		}
-		tokenIDs, inputSum, err = selector.Select(wallet, outputSum.Decimal(), tokenType)
+		tokenIDs, inputSum, err = selector.Select(wallet, selectSum.Decimal(), tokenType)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "failed selecting tokens")
+			return nil, nil, nil, errors.Wrap(err, "failed selecting tokens")
 		}
 	}
 
 	// Is there a rest?
-	cmp := inputSum.Cmp(outputSum)
-	switch cmp {
-	case 1:
-		diff := inputSum.Sub(outputSum)
-		r.TokenService.logger.Debugf("reassign rest [%s] to sender", diff.Decimal())
-
-		var restIdentity []byte
-		if transferOpts.RestRecipientIdentity != nil {
-			// register it and us it
-			if err := wallet.RegisterRecipient(transferOpts.RestRecipientIdentity); err != nil {
-				return nil, nil, errors.WithMessagef(err, "failed to register recipient identity [%s] for the rest, wallet [%s]", transferOpts.RestRecipientIdentity.Identity, wallet.ID())
-			}
-			restIdentity = transferOpts.RestRecipientIdentity.Identity
-		} else {
-			restIdentity, err = wallet.GetRecipientIdentity()
+	if inputSum.Cmp(outputSum) < 0 {
+		return nil, nil, nil, errors.Errorf("the sum of the outputs is larger then the sum of the inputs [%s][%s]", inputSum.Decimal(), outputSum.Decimal())
+	}
+	rest := inputSum.Sub(outputSum)
+	var restIdentity Identity
+	if feePolicy != nil {
+		// the rest pays the fee first
+		budget := rest.ToBigInt()
+		if !budget.IsUint64() {
+			return nil, nil, nil, errors.Errorf("rest [%s] overflows", rest.Decimal())
+		}
+		var fee, change uint64
+		fee, change, restIdentity, err = r.payFee(feePolicy, wallet, tokenIDs, values, owners, budget.Uint64(), transferOpts)
+		if err != nil {
+			return nil, nil, nil, errors.WithMessagef(err, "failed computing fee")
+		}
+		if fee != 0 {
+			feeQ, err := token.UInt64ToQuantity(fee, precision)
 			if err != nil {
-				return nil, nil, errors.WithMessagef(err, "failed getting recipient identity for the rest, wallet [%s]", wallet.ID())
+				return nil, nil, nil, errors.Wrapf(err, "failed to convert fee [%d] to quantity of precision [%d]", fee, precision)
+			}
+			outputTokens = append(outputTokens, &token.Token{
+				Owner:    feePolicy.Collector,
+				Type:     tokenType,
+				Quantity: feeQ.Hex(),
+			})
+			values = append(append([]uint64{}, values...), fee)
+			owners = append(append([]Identity{}, owners...), feePolicy.Collector)
+		}
+		if rest, err = token.UInt64ToQuantity(change, precision); err != nil {
+			return nil, nil, nil, errors.Wrapf(err, "failed to convert rest [%d] to quantity of precision [%d]", change, precision)
+		}
+	}
+	if rest.Cmp(token.NewZeroQuantity(precision)) == 1 {
+		r.TokenService.logger.Debugf("reassign rest [%s] to sender", rest.Decimal())
+		if restIdentity == nil {
+			if restIdentity, err = r.restIdentity(wallet, transferOpts); err != nil {
+				return nil, nil, nil, err
 			}
 		}
-
 		outputTokens = append(outputTokens, &token.Token{
 			Owner:    restIdentity,
 			Type:     tokenType,
-			Quantity: diff.Hex(),
+			Quantity: rest.Hex(),
 		})
-	case -1:
-		return nil, nil, errors.Errorf("the sum of the outputs is larger then the sum of the inputs [%s][%s]", inputSum.Decimal(), outputSum.Decimal())
 	}
 
-	if r.TokenService.PublicParametersManager().PublicParameters().GraphHiding() {
+	// Check the spending policy, if any, the fee included
+	sent, err := r.checkSpendingPolicy(wallet, tokenType, values, owners)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if pp := r.TokenService.PublicParametersManager().PublicParameters(); pp.GraphHiding() && len(pp.CertificationDriver()) != 0 {
 		r.TokenService.logger.Debugf("graph hiding enabled, request certification")
 		// Check token certification
 		cc, err := r.TokenService.CertificationClient()
 		if err != nil {
-			return nil, nil, errors.WithMessagef(err, "cannot get certification client")
+			return nil, nil, nil, errors.WithMessagef(err, "cannot get certification client")
 		}
 		if err := cc.RequestCertification(tokenIDs...); err != nil {
-			return nil, nil, errors.WithMessagef(err, "failed certifiying inputs")
+			return nil, nil, nil, errors.WithMessagef(err, "failed certifiying inputs")
 		}
 	}
 
-	return tokenIDs, outputTokens, nil
+	return tokenIDs, outputTokens, sent, nil
+}
+
+// payFee returns the fee due by a transfer of the passed inputs and outputs, what is left of the passed rest after paying it,
+// and the identity that receives what is left.
+// The validators charge the outputs owned by neither the collector nor the owner of an input, then what is left goes to
+// the owner of an input, and it is never charged.
+func (r *Request) payFee(policy *driver.FeePolicy, wallet *OwnerWallet, tokenIDs []*token.ID, values []uint64, owners []Identity, rest uint64, transferOpts *TransferOptions) (uint64, uint64, Identity, error) {
+	inputTokens, err := r.TokenService.Vault().NewQueryEngine().GetTokens(tokenIDs...)
+	if err != nil {
+		return 0, 0, nil, errors.WithMessagef(err, "failed getting the owners of the inputs")
+	}
+	inputOwners := make([]Identity, len(inputTokens))
+	for i, tok := range inputTokens {
+		inputOwners[i] = tok.Owner
+	}
+	payments, err := paymentsValue(policy, inputOwners, values, owners)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	fee, err := policy.Fee(payments)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	if rest < fee {
+		return 0, 0, nil, errors.Errorf("the inputs do not cover the fee [%d], [%d] left after the outputs", fee, rest)
+	}
+	if rest == fee {
+		return fee, 0, nil, nil
+	}
+	changeIdentity, err := changeIdentity(wallet, inputOwners, transferOpts)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	return fee, rest - fee, changeIdentity, nil
+}
+
+// changeIdentity returns the identity that receives the change of a transfer charged by a fee policy.
+// This is either the one passed in the options, or the owner of the first input that belongs to the wallet.
+// Either way, it must be the owner of an input, otherwise the validators would charge the change as a payment.
+func changeIdentity(wallet *OwnerWallet, inputOwners []Identity, transferOpts *TransferOptions) (Identity, error) {
+	if transferOpts.RestRecipientIdentity != nil {
+		id := transferOpts.RestRecipientIdentity.Identity
+		if !driver.ContainsIssuer(inputOwners, id) {
+			return nil, errors.Errorf("the identity [%s] for the rest does not own any input, the change would be charged", id)
+		}
+		return id, nil
+	}
+	for _, owner := range inputOwners {
+		if wallet.Contains(owner) {
+			return owner, nil
+		}
+	}
+	return nil, errors.Errorf("no input is owned by wallet [%s], cannot assign the change", wallet.ID())
+}
+
+// restIdentity returns the identity that receives the rest of a transfer: either the one passed in the options, or a fresh one of the wallet
+func (r *Request) restIdentity(wallet *OwnerWallet, transferOpts *TransferOptions) (Identity, error) {
+	if transferOpts.RestRecipientIdentity != nil {
+		// register it and us it
+		if err := wallet.RegisterRecipient(transferOpts.RestRecipientIdentity); err != nil {
+			return nil, errors.WithMessagef(err, "failed to register recipient identity [%s] for the rest, wallet [%s]", transferOpts.RestRecipientIdentity.Identity, wallet.ID())
+		}
+		return transferOpts.RestRecipientIdentity.Identity, nil
+	}
+	restIdentity, err := wallet.GetRecipientIdentity()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting recipient identity for the rest, wallet [%s]", wallet.ID())
+	}
+	return restIdentity, nil
+}
+
+// feeDue returns the fee due on the passed outputs according to the passed policy, given the owners of the inputs, if known
+func feeDue(policy *driver.FeePolicy, inputOwners []Identity, values []uint64, owners []Identity) (uint64, error) {
+	payments, err := paymentsValue(policy, inputOwners, values, owners)
+	if err != nil {
+		return 0, err
+	}
+	return policy.Fee(payments)
+}
+
+// paymentsValue returns the sum of the passed outputs charged by the passed policy, given the owners of the inputs.
+// The outputs are split as the validators do.
+func paymentsValue(policy *driver.FeePolicy, inputOwners []Identity, values []uint64, owners []Identity) (uint64, error) {
+	_, payments := policy.SplitOutputs(inputOwners, owners)
+	var sum uint64
+	for _, i := range payments {
+		var carry uint64
+		sum, carry = bits.Add64(sum, values[i], 0)
+		if carry != 0 {
+			return 0, errors.New("the sum of the payments overflows")
+		}
+	}
+	return sum, nil
+}
+
+// checkSpendingPolicy asks the spending policy of the management service, if any, whether the passed wallet
+// is allowed to send the passed outputs together with what its enrollment ID already sends in the request.
+// It returns what the outputs send, nil if nothing is sent or there is no policy.
+func (r *Request) checkSpendingPolicy(wallet *OwnerWallet, tokenType token.Type, values []uint64, owners []Identity) (*spending, error) {
+	policy, err := r.TokenService.SpendingPolicy()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting spending policy")
+	}
+	if policy == nil {
+		return nil, nil
+	}
+	sent, err := sentValue(values, owners, wallet.Contains)
+	if err != nil {
+		return nil, err
+	}
+	if sent == 0 {
+		return nil, nil
+	}
+	key := spendingKey{enrollmentID: wallet.EnrollmentID(), tokenType: tokenType}
+	total, carry := bits.Add64(r.sent[key], sent, 0)
+	if carry != 0 {
+		return nil, errors.New("the sum of the sent values overflows")
+	}
+	if err := policy.CheckSpending(wallet, tokenType, total); err != nil {
+		return nil, errors.WithMessagef(err, "spending policy violated by wallet [%s]", wallet.ID())
+	}
+	return &spending{key: key, value: sent}, nil
+}
+
+// addSent adds the passed spending to the value sent in the request
+func (r *Request) addSent(s *spending) {
+	if s == nil {
+		return
+	}
+	if r.sent == nil {
+		r.sent = map[spendingKey]uint64{}
+	}
+	r.sent[s.key] += s.value
+}
+
+// sentValue returns the sum of the passed values not owned by the sender, as told by isSender.
+// Redeemed values are sent.
+func sentValue(values []uint64, owners []Identity, isSender func(Identity) bool) (uint64, error) {
+	var sent uint64
+	for i, value := range values {
+		if !owners[i].IsNone() && isSender(owners[i]) {
+			continue
+		}
+		var carry uint64
+		sent, carry = bits.Add64(sent, value, 0)
+		if carry != 0 {
+			return 0, errors.New("the sum of the sent values overflows")
+		}
+	}
+	return sent, nil
 }
 
 func (r *Request) genOutputs(values []uint64, owners []Identity, tokenType token.Type) ([]*token.Token, token.Quantity, error) {
@@ -7,9 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package token
 
 import (
+	"math"
 	"testing"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,6 +46,29 @@ func TestRequestSerialization(t *testing.T) {
 	assert.Equal(t, mRaw, mRaw2)
 }
 
+func TestRequest_SetAuditorSignature(t *testing.T) {
+	r := NewRequest(nil, "hello world")
+	r.Actions = &driver.TokenRequest{}
+	assert.Equal(t, 0, r.AuditorSignaturesCount())
+
+	// the second auditor signs, the first slot stays empty
+	r.SetAuditorSignature(1, []byte("compliance"))
+	assert.Equal(t, [][]byte{nil, []byte("compliance")}, r.Actions.AuditorSignatures)
+	assert.Equal(t, 1, r.AuditorSignaturesCount())
+	r.SetAuditorSignature(0, []byte("regulator"))
+	assert.Equal(t, 2, r.AuditorSignaturesCount())
+
+	// empty slots survive serialization
+	r.Actions.AuditorSignatures[0] = nil
+	raw, err := r.Bytes()
+	assert.NoError(t, err)
+	r2 := NewRequest(nil, "")
+	assert.NoError(t, r2.FromBytes(raw))
+	assert.Len(t, r2.Actions.AuditorSignatures, 2)
+	assert.Empty(t, r2.Actions.AuditorSignatures[0])
+	assert.Equal(t, []byte("compliance"), r2.Actions.AuditorSignatures[1])
+}
+
 func TestRequest_ApplicationMetadata(t *testing.T) {
 	// Test case: No application metadata set
 	request := &Request{
@@ -104,3 +130,124 @@ func TestRequest_SetApplicationMetadata(t *testing.T) {
 	assert.Equal(t, []byte("value1"), request.Metadata.Application["key1"])
 	assert.Equal(t, []byte("value2"), request.Metadata.Application["key2"])
 }
+
+func TestFeeDue(t *testing.T) {
+	policy := &driver.FeePolicy{TokenType: "USD", Flat: 1, Rate: 100, Collector: []byte("collector")}
+	bob, charlie := Identity("bob"), Identity("charlie")
+
+	// before the selection, all the outputs but the fees are charged
+	fee, err := feeDue(policy, nil, []uint64{100, 50, 7}, []Identity{bob, charlie, policy.Collector})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), fee)
+
+	// the outputs to the owner of an input are not charged, as the validators do
+	fee, err = feeDue(policy, []Identity{charlie}, []uint64{100, 50}, []Identity{bob, charlie})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), fee)
+	fee, err = feeDue(policy, []Identity{bob}, []uint64{100}, []Identity{bob})
+	assert.NoError(t, err)
+	assert.Zero(t, fee)
+
+	_, err = feeDue(policy, nil, []uint64{math.MaxUint64, 1}, []Identity{bob, charlie})
+	assert.Error(t, err)
+}
+
+func TestSentValue(t *testing.T) {
+	isAlice := func(id Identity) bool { return string(id) == "alice" }
+
+	// the change to alice is not sent, the redeemed value is
+	sent, err := sentValue([]uint64{100, 20, 5}, []Identity{[]byte("bob"), []byte("alice"), nil}, isAlice)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(105), sent)
+
+	sent, err = sentValue([]uint64{20}, []Identity{[]byte("alice")}, isAlice)
+	assert.NoError(t, err)
+	assert.Zero(t, sent)
+
+	_, err = sentValue([]uint64{math.MaxUint64, 1}, []Identity{[]byte("bob"), []byte("charlie")}, isAlice)
+	assert.Error(t, err)
+}
+
+type ownerWallet struct {
+	driver.OwnerWallet
+	id           string
+	enrollmentID string
+}
+
+func (w *ownerWallet) ID() string { return w.id }
+
+func (w *ownerWallet) EnrollmentID() string { return w.enrollmentID }
+
+func (w *ownerWallet) Contains(identity driver.Identity) bool { return string(identity) == w.id }
+
+// maxPerTransaction is a spending policy capping the value sent by a single transaction
+type maxPerTransaction uint64
+
+func (m maxPerTransaction) CheckSpending(_ *OwnerWallet, _ token.Type, value uint64) error {
+	if value > uint64(m) {
+		return errors.Errorf("[%d] exceeds [%d]", value, m)
+	}
+	return nil
+}
+
+type spendingPolicyProvider struct {
+	policy SpendingPolicy
+}
+
+func (p *spendingPolicyProvider) SpendingPolicy(*ManagementService) (SpendingPolicy, error) {
+	return p.policy, nil
+}
+
+func TestCheckSpendingPolicy(t *testing.T) {
+	r := NewRequest(&ManagementService{spendingPolicyProvider: &spendingPolicyProvider{policy: maxPerTransaction(100)}}, "tx")
+	newWallet := func(id string) *OwnerWallet {
+		w := &ownerWallet{id: id, enrollmentID: id}
+		return &OwnerWallet{Wallet: &Wallet{w: w}, w: w}
+	}
+	alice, charlie := newWallet("alice"), newWallet("charlie")
+	bob := []Identity{[]byte("bob")}
+
+	// the value sent by the previous actions of the request counts
+	sent, err := r.checkSpendingPolicy(alice, "USD", []uint64{60}, bob)
+	assert.NoError(t, err)
+	r.addSent(sent)
+	_, err = r.checkSpendingPolicy(alice, "USD", []uint64{50}, bob)
+	assert.ErrorContains(t, err, "[110] exceeds [100]")
+	sent, err = r.checkSpendingPolicy(alice, "USD", []uint64{40}, bob)
+	assert.NoError(t, err)
+	r.addSent(sent)
+	_, err = r.checkSpendingPolicy(alice, "USD", []uint64{1}, []Identity{nil})
+	assert.ErrorContains(t, err, "[101] exceeds [100]")
+
+	// the change does not count
+	sent, err = r.checkSpendingPolicy(alice, "USD", []uint64{10}, []Identity{[]byte("alice")})
+	assert.NoError(t, err)
+	assert.Nil(t, sent)
+
+	// other token types and enrollment IDs are counted apart
+	_, err = r.checkSpendingPolicy(alice, "EUR", []uint64{100}, bob)
+	assert.NoError(t, err)
+	_, err = r.checkSpendingPolicy(charlie, "USD", []uint64{100}, bob)
+	assert.NoError(t, err)
+}
+
+func TestChangeIdentity(t *testing.T) {
+	w := &ownerWallet{id: "alice", enrollmentID: "alice"}
+	alice := &OwnerWallet{Wallet: &Wallet{w: w}, w: w}
+	inputOwners := []Identity{[]byte("bob"), []byte("alice")}
+
+	// the change goes back to the owner of an input of the wallet
+	change, err := changeIdentity(alice, inputOwners, &TransferOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, Identity("alice"), change)
+
+	// an identity passed in the options must own an input
+	change, err = changeIdentity(alice, inputOwners, &TransferOptions{RestRecipientIdentity: &RecipientData{Identity: []byte("bob")}})
+	assert.NoError(t, err)
+	assert.Equal(t, Identity("bob"), change)
+	_, err = changeIdentity(alice, inputOwners, &TransferOptions{RestRecipientIdentity: &RecipientData{Identity: []byte("alice2")}})
+	assert.ErrorContains(t, err, "does not own any input")
+
+	_, err = changeIdentity(alice, []Identity{[]byte("bob")}, &TransferOptions{})
+	assert.ErrorContains(t, err, "no input is owned by wallet [alice]")
+}
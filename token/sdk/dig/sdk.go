@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger-labs/fabric-smart-client/platform/fabric/core/generic/committer"
 	fabricsdk "github.com/hyperledger-labs/fabric-smart-client/platform/fabric/sdk/dig"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/driver"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/events"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/kvs"
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	core2 "github.com/hyperledger-labs/fabric-token-sdk/token/core"
@@ -35,18 +36,22 @@ import (
 	config2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/config"
 	db2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/db"
 	common2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/db/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/redis"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/driver/memory"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/driver/sql"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/driver/unity"
 	identity2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identitydb"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/limits"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common"
 	driver3 "github.com/hyperledger-labs/fabric-token-sdk/token/services/network/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/rebuild"
 	sdriver "github.com/hyperledger-labs/fabric-token-sdk/token/services/selector/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/selector/sherdlock"
 	selector "github.com/hyperledger-labs/fabric-token-sdk/token/services/selector/simple"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/streaming"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokendb"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokenlockdb"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokens"
@@ -126,12 +131,13 @@ func (p *SDK) Install() error {
 				new(tokens.TMSProvider),
 				new(auditor.TokenManagementServiceProvider),
 				new(common2.TokenManagementServiceProvider),
+				new(limits.TMSProvider),
 			),
 		),
 		p.Container().Provide(func(dh *db2.DriverHolder) *ttxdb.Manager {
 			return ttxdb.NewManager(dh, "ttxdb.persistence", "db.persistence")
 		}),
-		p.Container().Provide(digutils.Identity[*ttxdb.Manager](), dig.As(new(ttx.DBProvider), new(network2.TTXDBProvider))),
+		p.Container().Provide(digutils.Identity[*ttxdb.Manager](), dig.As(new(ttx.DBProvider), new(network2.TTXDBProvider), new(limits.TTXDBProvider))),
 		p.Container().Provide(func(dh *db2.DriverHolder) *tokendb.Manager {
 			return tokendb.NewManager(dh, "tokendb.persistence", "db.persistence")
 		}),
@@ -155,11 +161,19 @@ func (p *SDK) Install() error {
 		p.Container().Provide(digutils.Identity[*identity.DBStorageProvider](), dig.As(new(identity2.StorageProvider))),
 		p.Container().Provide(NewAuditorCheckServiceProvider),
 		p.Container().Provide(digutils.Identity[*db.AuditorCheckServiceProvider](), dig.As(new(auditor.CheckServiceProvider))),
+		p.Container().Provide(limits.NewProvider),
+		p.Container().Provide(digutils.Identity[*limits.Provider](), dig.As(new(auditor.SpendingPolicyProvider), new(token.SpendingPolicyProvider))),
 		p.Container().Provide(auditor.NewManager),
 		p.Container().Provide(NewOwnerCheckServiceProvider),
 		p.Container().Provide(digutils.Identity[*db.OwnerCheckServiceProvider](), dig.As(new(ttx.CheckServiceProvider))),
 		p.Container().Provide(ttx.NewManager),
+		p.Container().Provide(func(configService *config2.Service, subscriber events.Subscriber, ttxdbManager *ttxdb.Manager) *streaming.Service {
+			return streaming.NewService(configService, subscriber, ttxdbManager)
+		}),
 		p.Container().Provide(tokens.NewManager),
+		p.Container().Provide(func(tmsProvider *token.ManagementServiceProvider, networkProvider *network.Provider, tokensManager *tokens.Manager, ttxdbManager *ttxdb.Manager, auditdbManager *auditdb.Manager) *rebuild.Service {
+			return rebuild.NewService(tmsProvider, networkProvider, tokensManager, ttxdbManager, auditdbManager)
+		}),
 		p.Container().Provide(digutils.Identity[*tokens.Manager](), dig.As(new(ttx.TokensProvider), new(auditor.TokenDBProvider))),
 		p.Container().Provide(vault.NewVaultProvider),
 		p.Container().Provide(digutils.Identity[*vault.Provider](), dig.As(new(token.VaultProvider))),
@@ -171,6 +185,7 @@ func (p *SDK) Install() error {
 		p.Container().Provide(unity.NewUnityDriver, dig.Group("token-db-drivers")),
 		p.Container().Provide(sql.NewDriver, dig.Group("token-db-drivers")),
 		p.Container().Provide(memory.NewDriver, dig.Group("token-db-drivers")),
+		p.Container().Provide(redis.NewDriver, dig.Group("token-db-drivers")),
 		p.Container().Provide(func(dbManager *tokendb.Manager, notifierManager *tokendb.NotifierManager, metricsProvider metrics.Provider) sherdlock.FetcherProvider {
 			return sherdlock.NewFetcherProvider(dbManager, notifierManager, metricsProvider, sherdlock.Mixed)
 		}),
@@ -201,6 +216,7 @@ func (p *SDK) Install() error {
 		digutils.Register[*tokendb.Manager](p.Container()),
 		digutils.Register[*auditdb.Manager](p.Container()),
 		digutils.Register[*identitydb.Manager](p.Container()),
+		digutils.Register[*tokenlockdb.Manager](p.Container()),
 		digutils.Register[*vault.Provider](p.Container()),
 		digutils.Register[driver.ConfigService](p.Container()),
 		digutils.Register[*identity.DBStorageProvider](p.Container()),
@@ -209,6 +225,7 @@ func (p *SDK) Install() error {
 		digutils.Register[*config2.Service](p.Container()),
 		digutils.Register[*ttx.Manager](p.Container()),
 		digutils.Register[*tokens.Manager](p.Container()),
+		digutils.Register[*rebuild.Service](p.Container()),
 		digutils.Register[trace.TracerProvider](p.Container()),
 		digutils.Register[metrics.Provider](p.Container()),
 	)
@@ -219,6 +236,10 @@ func (p *SDK) Install() error {
 	err = errors2.Join(
 		p.Container().Invoke(func(tmsProvider *core2.TMSProvider, postInitializer *tms.PostInitializer) {
 			tmsProvider.SetCallback(postInitializer.PostInit)
+			tmsProvider.SetRegisteredCallback(postInitializer.Registered)
+		}),
+		p.Container().Invoke(func(managementServiceProvider *token.ManagementServiceProvider, spendingPolicyProvider token.SpendingPolicyProvider) {
+			managementServiceProvider.SetSpendingPolicyProvider(spendingPolicyProvider)
 		}),
 	)
 	if err != nil {
@@ -240,6 +261,7 @@ func (p *SDK) Start(ctx context.Context) error {
 	return errors2.Join(
 		p.Container().Invoke(registerNetworkDrivers),
 		p.Container().Invoke(connectNetworks),
+		p.Container().Invoke(func(streamingService *streaming.Service) error { return streamingService.Start(ctx) }),
 	)
 }
 
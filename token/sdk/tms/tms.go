@@ -62,3 +62,17 @@ func (p *PostInitializer) PostInit(tms driver.TokenManagerService, networkID, ch
 
 	return nil
 }
+
+// Registered recovers the transactions left unfinished by a stop of the node.
+// It runs once the TMS has been registered, because the recovery needs the TMS, see ttx.Manager.RecoverTMS.
+func (p *PostInitializer) Registered(_ driver.TokenManagerService, networkID, channel, namespace string) error {
+	tmsID := token3.TMSID{
+		Network:   networkID,
+		Channel:   channel,
+		Namespace: namespace,
+	}
+	if err := p.ownerManager.RecoverTMS(tmsID); err != nil {
+		return errors.WithMessagef(err, "failed to recover transactions for [%s]", tmsID)
+	}
+	return nil
+}
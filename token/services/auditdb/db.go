@@ -122,6 +122,9 @@ func (t *TransactionIterator) Next() (*TransactionRecord, error) {
 // QueryTransactionsParams defines the parameters for querying movements
 type QueryTransactionsParams = driver.QueryTransactionsParams
 
+// Pagination selects a page of the results of a query
+type Pagination = driver.Pagination
+
 // QueryTokenRequestsParams defines the parameters for querying token requests
 type QueryTokenRequestsParams = driver.QueryTokenRequestsParams
 
@@ -215,6 +218,12 @@ func (d *DB) Transactions(params QueryTransactionsParams) (driver.TransactionIte
 	return d.db.QueryTransactions(params)
 }
 
+// TransactionsPage returns a page of the transaction records filtered by the given params.
+// To get the next page, pass the returned cursor in the pagination of the next call.
+func (d *DB) TransactionsPage(params QueryTransactionsParams, pagination Pagination) (*driver.Page[*TransactionRecord], error) {
+	return d.db.QueryTransactionsPage(params, pagination)
+}
+
 // TokenRequests returns an iterator over the token requests matching the passed params
 func (d *DB) TokenRequests(params QueryTokenRequestsParams) (driver.TokenRequestIterator, error) {
 	return d.db.QueryTokenRequests(params)
@@ -8,6 +8,7 @@ package auditdb
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
@@ -36,6 +37,12 @@ func (f *PaymentsFilter) Last(num int) *PaymentsFilter {
 	return f
 }
 
+// Since restricts the filter to the payments stored from the passed time on.
+func (f *PaymentsFilter) Since(from time.Time) *PaymentsFilter {
+	f.params.From = &from
+	return f
+}
+
 func (f *PaymentsFilter) Execute() (*PaymentsFilter, error) {
 	f.params.TxStatuses = []driver.TxStatus{driver.Pending, driver.Confirmed}
 	f.params.MovementDirection = driver.Sent
@@ -58,6 +58,12 @@ type CheckService interface {
 	Check(context context.Context) ([]string, error)
 }
 
+// SpendingPolicy decides whether the senders of an audited transaction are allowed to send what they send
+type SpendingPolicy interface {
+	// CheckRecord returns an error if a sender in the passed audit record is not allowed to send what it sends
+	CheckRecord(record *token.AuditRecord) error
+}
+
 // Auditor is the interface for the auditor service
 type Auditor struct {
 	networkProvider NetworkProvider
@@ -67,6 +73,7 @@ type Auditor struct {
 	tmsProvider     TokenManagementServiceProvider
 	finalityTracer  trace.Tracer
 	checkService    CheckService
+	spendingPolicy  SpendingPolicy
 }
 
 // Validate validates the passed token request
@@ -75,7 +82,7 @@ func (a *Auditor) Validate(context context.Context, request *token.Request) erro
 }
 
 // Audit extracts the list of inputs and outputs from the passed transaction.
-// In addition, the Audit locks the enrollment named ids.
+// In addition, the Audit locks the enrollment named ids and enforces the spending policy, if any.
 // Release must be invoked in case
 func (a *Auditor) Audit(tx Transaction) (*token.InputStream, *token.OutputStream, error) {
 	logger.Debugf("audit transaction [%s]....", tx.ID())
@@ -94,6 +101,15 @@ func (a *Auditor) Audit(tx Transaction) (*token.InputStream, *token.OutputStream
 	}
 	logger.Debugf("audit transaction [%s], acquire locks done", tx.ID())
 
+	// the locks guarantee that no other transaction of the same enrollment IDs is audited concurrently.
+	// The transactions forced by an enforcer are not subject to the spending policy.
+	if a.spendingPolicy != nil && !request.HasForcedTransfers() {
+		if err := a.spendingPolicy.CheckRecord(record); err != nil {
+			a.auditDB.ReleaseLocks(request.Anchor)
+			return nil, nil, errors.WithMessagef(err, "spending policy violated by transaction [%s]", tx.ID())
+		}
+	}
+
 	return record.Inputs, record.Outputs, nil
 }
 
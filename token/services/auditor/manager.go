@@ -36,14 +36,22 @@ type CheckServiceProvider interface {
 	CheckService(id token.TMSID, adb *auditdb.DB, tdb *tokens.Tokens) (CheckService, error)
 }
 
+// SpendingPolicyProvider provides the spending policies enforced by the auditors
+type SpendingPolicyProvider interface {
+	// AuditorSpendingPolicy returns the spending policy to enforce on the passed TMS, nil if there is none.
+	// The policy can use the passed auditdb to look up the past payments.
+	AuditorSpendingPolicy(id token.TMSID, adb *auditdb.DB) (SpendingPolicy, error)
+}
+
 // Manager handles the databases
 type Manager struct {
-	networkProvider      NetworkProvider
-	auditDBProvider      AuditDBProvider
-	tokenDBProvider      TokenDBProvider
-	tmsProvider          TokenManagementServiceProvider
-	tracerProvider       trace.TracerProvider
-	checkServiceProvider CheckServiceProvider
+	networkProvider        NetworkProvider
+	auditDBProvider        AuditDBProvider
+	tokenDBProvider        TokenDBProvider
+	tmsProvider            TokenManagementServiceProvider
+	tracerProvider         trace.TracerProvider
+	checkServiceProvider   CheckServiceProvider
+	spendingPolicyProvider SpendingPolicyProvider
 
 	mutex    sync.Mutex
 	auditors map[string]*Auditor
@@ -57,15 +65,17 @@ func NewManager(
 	tmsProvider TokenManagementServiceProvider,
 	tracerProvider trace.TracerProvider,
 	checkServiceProvider CheckServiceProvider,
+	spendingPolicyProvider SpendingPolicyProvider,
 ) *Manager {
 	return &Manager{
-		networkProvider:      networkProvider,
-		auditDBProvider:      auditDBProvider,
-		tokenDBProvider:      tokenDBProvider,
-		tmsProvider:          tmsProvider,
-		tracerProvider:       tracerProvider,
-		auditors:             map[string]*Auditor{},
-		checkServiceProvider: checkServiceProvider,
+		networkProvider:        networkProvider,
+		auditDBProvider:        auditDBProvider,
+		tokenDBProvider:        tokenDBProvider,
+		tmsProvider:            tmsProvider,
+		tracerProvider:         tracerProvider,
+		auditors:               map[string]*Auditor{},
+		checkServiceProvider:   checkServiceProvider,
+		spendingPolicyProvider: spendingPolicyProvider,
 	}
 }
 
@@ -119,6 +129,10 @@ func (cm *Manager) newAuditor(tmsID token.TMSID) (*Auditor, error) {
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to get checkservice for [%s]", tmsID)
 	}
+	spendingPolicy, err := cm.spendingPolicyProvider.AuditorSpendingPolicy(tmsID, auditDB)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get spending policy for [%s]", tmsID)
+	}
 
 	auditor := &Auditor{
 		networkProvider: cm.networkProvider,
@@ -130,7 +144,8 @@ func (cm *Manager) newAuditor(tmsID token.TMSID) (*Auditor, error) {
 			Namespace:  "tokensdk",
 			LabelNames: []tracing.LabelName{txIdLabel},
 		})),
-		checkService: checkService,
+		checkService:   checkService,
+		spendingPolicy: spendingPolicy,
 	}
 	return auditor, nil
 }
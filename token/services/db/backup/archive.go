@@ -0,0 +1,346 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/pkg/errors"
+)
+
+// FormatVersion is the version of the archive format written by Export
+const FormatVersion = 1
+
+// ErrCorruptedArchive is returned, wrapped, when an archive fails the integrity checks
+var ErrCorruptedArchive = errors.New("corrupted archive")
+
+// Record types. An archive is a gzip compressed sequence of JSON records, one per line:
+// a header, then, for each table, a table record, its rows and an end record, and finally a footer.
+const (
+	headerRecord = "header"
+	tableRecord  = "table"
+	rowRecord    = "row"
+	endRecord    = "end"
+	footerRecord = "footer"
+)
+
+// Kind is the portable type of a column
+type Kind string
+
+const (
+	BoolKind      Kind = "bool"
+	IntKind       Kind = "int"
+	TextKind      Kind = "text"
+	BytesKind     Kind = "bytes"
+	TimestampKind Kind = "timestamp"
+)
+
+// Header describes the content of an archive
+type Header struct {
+	Format    int         `json:"format"`
+	TMSID     token.TMSID `json:"tms_id"`
+	CreatedAt time.Time   `json:"created_at"`
+	Stores    []StoreInfo `json:"stores"`
+}
+
+// StoreInfo describes a store in an archive
+type StoreInfo struct {
+	Name          string   `json:"name"`
+	SchemaVersion int      `json:"schema_version"`
+	Tables        []string `json:"tables"`
+}
+
+// Column is a column of an archived table
+type Column struct {
+	Name string `json:"name"`
+	Kind Kind   `json:"kind"`
+}
+
+type record struct {
+	Type string `json:"type"`
+
+	// header
+	*Header `json:",omitempty"`
+
+	// table and end
+	Store   string   `json:"store,omitempty"`
+	Table   string   `json:"table,omitempty"`
+	Columns []Column `json:"columns,omitempty"`
+
+	// row
+	Values []json.RawMessage `json:"values,omitempty"`
+
+	// end and footer
+	Rows   int64  `json:"rows,omitempty"`
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// writer writes the records of an archive, keeping the hash of the whole archive and of the rows of the current table
+type writer struct {
+	gz    *gzip.Writer
+	w     *bufio.Writer
+	total hash.Hash
+	table hash.Hash
+	rows  int64
+}
+
+func newWriter(w io.Writer) *writer {
+	gz := gzip.NewWriter(w)
+	return &writer{gz: gz, w: bufio.NewWriter(gz), total: sha256.New()}
+}
+
+func (a *writer) write(r *record) error {
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrapf(err, "failed marshalling [%s] record", r.Type)
+	}
+	raw = append(raw, '\n')
+	a.total.Write(raw)
+	if r.Type == rowRecord {
+		a.table.Write(raw)
+		a.rows++
+	}
+	_, err = a.w.Write(raw)
+	return errors.Wrapf(err, "failed writing [%s] record", r.Type)
+}
+
+func (a *writer) startTable(store, table string, columns []Column) error {
+	a.table = sha256.New()
+	a.rows = 0
+	return a.write(&record{Type: tableRecord, Store: store, Table: table, Columns: columns})
+}
+
+func (a *writer) endTable(store, table string) error {
+	return a.write(&record{Type: endRecord, Store: store, Table: table, Rows: a.rows, SHA256: hex.EncodeToString(a.table.Sum(nil))})
+}
+
+func (a *writer) close() error {
+	if err := a.write(&record{Type: footerRecord, SHA256: hex.EncodeToString(a.total.Sum(nil))}); err != nil {
+		return err
+	}
+	if err := a.w.Flush(); err != nil {
+		return errors.Wrapf(err, "failed flushing archive")
+	}
+	return errors.Wrapf(a.gz.Close(), "failed closing archive")
+}
+
+// reader reads the records of an archive and checks the hashes
+type reader struct {
+	r     *bufio.Reader
+	total hash.Hash
+	table hash.Hash
+	rows  int64
+	done  bool
+}
+
+func newReader(r io.Reader) (*reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrapf(ErrCorruptedArchive, "not a gzip stream: %s", err)
+	}
+	return &reader{r: bufio.NewReader(gz), total: sha256.New()}, nil
+}
+
+// next returns the next record, or nil after the footer
+func (a *reader) next() (*record, error) {
+	if a.done {
+		return nil, nil
+	}
+	raw, err := a.r.ReadBytes('\n')
+	if err == io.EOF {
+		return nil, errors.Wrapf(ErrCorruptedArchive, "archive truncated")
+	}
+	if err != nil {
+		return nil, errors.Wrapf(ErrCorruptedArchive, "failed reading archive: %s", err)
+	}
+	r := &record{}
+	if err := json.Unmarshal(raw, r); err != nil {
+		return nil, errors.Wrapf(ErrCorruptedArchive, "failed unmarshalling record: %s", err)
+	}
+	switch r.Type {
+	case tableRecord:
+		a.table = sha256.New()
+		a.rows = 0
+	case rowRecord:
+		if a.table == nil {
+			return nil, errors.Wrapf(ErrCorruptedArchive, "row outside a table")
+		}
+		a.table.Write(raw)
+		a.rows++
+	case endRecord:
+		if a.table == nil {
+			return nil, errors.Wrapf(ErrCorruptedArchive, "end outside a table")
+		}
+		if r.Rows != a.rows || r.SHA256 != hex.EncodeToString(a.table.Sum(nil)) {
+			return nil, errors.Wrapf(ErrCorruptedArchive, "hash mismatch for table [%s] of store [%s]", r.Table, r.Store)
+		}
+		a.table = nil
+	case footerRecord:
+		if r.SHA256 != hex.EncodeToString(a.total.Sum(nil)) {
+			return nil, errors.Wrapf(ErrCorruptedArchive, "archive hash mismatch")
+		}
+		a.done = true
+		return nil, nil
+	}
+	a.total.Write(raw)
+	return r, nil
+}
+
+// kindOf maps the database type of a column to its portable kind
+func kindOf(databaseType string) Kind {
+	t := strings.ToUpper(databaseType)
+	switch {
+	case strings.Contains(t, "BOOL"):
+		return BoolKind
+	case strings.Contains(t, "TIMESTAMP") || strings.Contains(t, "DATE"):
+		return TimestampKind
+	case strings.Contains(t, "BYTEA") || strings.Contains(t, "BLOB"):
+		return BytesKind
+	case strings.Contains(t, "INT") || strings.Contains(t, "SERIAL"):
+		return IntKind
+	default:
+		return TextKind
+	}
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// encodeValue encodes a value read from a database as JSON
+func encodeValue(kind Kind, v any) (json.RawMessage, error) {
+	if v == nil {
+		return json.RawMessage("null"), nil
+	}
+	var out any
+	switch kind {
+	case BoolKind:
+		switch b := v.(type) {
+		case bool:
+			out = b
+		case int64:
+			out = b != 0
+		default:
+			return nil, errors.Errorf("unexpected [%T] for a bool", v)
+		}
+	case IntKind:
+		switch i := v.(type) {
+		case int64:
+			out = i
+		case int32:
+			out = int64(i)
+		case int:
+			out = int64(i)
+		case float64:
+			out = int64(i)
+		case []byte:
+			n, err := strconv.ParseInt(string(i), 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid int")
+			}
+			out = n
+		default:
+			return nil, errors.Errorf("unexpected [%T] for an int", v)
+		}
+	case TextKind:
+		switch s := v.(type) {
+		case string:
+			out = s
+		case []byte:
+			out = string(s)
+		default:
+			return nil, errors.Errorf("unexpected [%T] for a text", v)
+		}
+	case BytesKind:
+		switch b := v.(type) {
+		case []byte:
+			out = base64.StdEncoding.EncodeToString(b)
+		case string:
+			out = base64.StdEncoding.EncodeToString([]byte(b))
+		default:
+			return nil, errors.Errorf("unexpected [%T] for bytes", v)
+		}
+	case TimestampKind:
+		t, err := toTime(v)
+		if err != nil {
+			return nil, err
+		}
+		out = t.UTC().Format(time.RFC3339Nano)
+	default:
+		return nil, errors.Errorf("unknown kind [%s]", kind)
+	}
+	return json.Marshal(out)
+}
+
+// decodeValue decodes a JSON value into the value to insert into a database
+func decodeValue(kind Kind, raw json.RawMessage) (any, error) {
+	if string(raw) == "null" {
+		return nil, nil
+	}
+	switch kind {
+	case BoolKind:
+		var b bool
+		return b, json.Unmarshal(raw, &b)
+	case IntKind:
+		var i int64
+		return i, json.Unmarshal(raw, &i)
+	case TextKind:
+		var s string
+		return s, json.Unmarshal(raw, &s)
+	case BytesKind:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.DecodeString(s)
+	case TimestampKind:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	default:
+		return nil, errors.Errorf("unknown kind [%s]", kind)
+	}
+}
+
+func toTime(v any) (time.Time, error) {
+	var s string
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case string:
+		s = t
+	case []byte:
+		s = string(t)
+	default:
+		return time.Time{}, errors.Errorf("unexpected [%T] for a timestamp", v)
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.Errorf("invalid timestamp [%s]", s)
+}
@@ -0,0 +1,452 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.MustGetLogger("token-sdk.db.backup")
+
+// Export writes to the passed writer an archive with the content of the passed stores of the passed TMS.
+// The stores on the same database are read in a single read-only transaction, then the archive is a consistent snapshot
+// even if the node is running.
+func Export(ctx context.Context, w io.Writer, tmsID token.TMSID, stores ...*Store) (*Header, error) {
+	header := &Header{Format: FormatVersion, TMSID: tmsID, CreatedAt: time.Now().UTC()}
+	var exported []*Store
+	for _, store := range stores {
+		sp, err := store.spec()
+		if err != nil {
+			return nil, err
+		}
+		prefix, err := store.prefix()
+		if err != nil {
+			return nil, err
+		}
+		version, err := common.SchemaVersion(store.DB, prefix, sp.migrationStore)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed getting schema version of store [%s]", store.Name)
+		}
+		tables, physical, err := sp.tables(prefix)
+		if err != nil {
+			return nil, err
+		}
+		// a store might have never been used, like the audit store of a node that is not an auditor
+		info := StoreInfo{Name: store.Name, SchemaVersion: version}
+		for i, table := range tables {
+			exists, err := common.TableExists(store.DB, physical[i])
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				info.Tables = append(info.Tables, table)
+			}
+		}
+		if len(info.Tables) == 0 {
+			logger.Infof("skipping store [%s]: no tables", store.Name)
+			continue
+		}
+		header.Stores = append(header.Stores, info)
+		exported = append(exported, store)
+	}
+
+	txs := map[*sql.DB]*sql.Tx{}
+	defer func() {
+		for _, tx := range txs {
+			if err := tx.Rollback(); err != nil {
+				logger.Warnf("failed closing read transaction: [%s]", err)
+			}
+		}
+	}()
+	for _, store := range exported {
+		if _, ok := txs[store.DB]; ok {
+			continue
+		}
+		tx, err := beginSnapshot(ctx, store.DB)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed reading store [%s]", store.Name)
+		}
+		txs[store.DB] = tx
+	}
+
+	a := newWriter(w)
+	if err := a.write(&record{Type: headerRecord, Header: header}); err != nil {
+		return nil, err
+	}
+	for i, store := range exported {
+		sp, _ := store.spec()
+		prefix, _ := store.prefix()
+		tables, physical, err := sp.tables(prefix)
+		if err != nil {
+			return nil, err
+		}
+		for j, table := range tables {
+			if !slices.Contains(header.Stores[i].Tables, table) {
+				continue
+			}
+			if err := exportTable(ctx, a, txs[store.DB], store.Name, table, physical[j]); err != nil {
+				return nil, errors.WithMessagef(err, "failed exporting table [%s] of store [%s]", table, store.Name)
+			}
+		}
+	}
+	if err := a.close(); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// beginSnapshot starts a read-only transaction whose reads see the same state of the passed database
+func beginSnapshot(ctx context.Context, db *sql.DB) (*sql.Tx, error) {
+	// sqlite transactions are serializable and its driver does not support the options
+	var opts *sql.TxOptions
+	if common.DetectDialect(db) == common.Postgres {
+		opts = &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	}
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed starting read transaction")
+	}
+	return tx, nil
+}
+
+func exportTable(ctx context.Context, a *writer, tx *sql.Tx, store, table, physical string) error {
+	query := "SELECT * FROM " + physical
+	logger.Debug(query)
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return errors.Wrapf(err, "failed querying table")
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return errors.Wrapf(err, "failed getting column types")
+	}
+	columns := make([]Column, len(types))
+	for i, t := range types {
+		columns[i] = Column{Name: strings.ToLower(t.Name()), Kind: kindOf(t.DatabaseTypeName())}
+	}
+	if err := a.startTable(store, table, columns); err != nil {
+		return err
+	}
+
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return errors.Wrapf(err, "failed scanning row")
+		}
+		r := &record{Type: rowRecord, Values: make([]json.RawMessage, len(columns))}
+		for i, column := range columns {
+			if r.Values[i], err = encodeValue(column.Kind, values[i]); err != nil {
+				return errors.WithMessagef(err, "failed encoding column [%s]", column.Name)
+			}
+		}
+		if err := a.write(r); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrapf(err, "failed iterating rows")
+	}
+	return a.endTable(store, table)
+}
+
+// Verify reads the whole archive and checks its integrity
+func Verify(r io.Reader) (*Header, error) {
+	a, err := newReader(r)
+	if err != nil {
+		return nil, err
+	}
+	header, err := readHeader(a)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		rec, err := a.next()
+		if err != nil {
+			return nil, err
+		}
+		if rec == nil {
+			return header, nil
+		}
+	}
+}
+
+func readHeader(a *reader) (*Header, error) {
+	rec, err := a.next()
+	if err != nil {
+		return nil, err
+	}
+	if rec == nil || rec.Type != headerRecord || rec.Header == nil {
+		return nil, errors.Wrapf(ErrCorruptedArchive, "missing header")
+	}
+	if rec.Header.Format != FormatVersion {
+		return nil, errors.Errorf("unsupported archive format [%d], expected [%d]", rec.Header.Format, FormatVersion)
+	}
+	return rec.Header, nil
+}
+
+// Import restores into the passed stores the content of the archive.
+// The archive is verified in full before anything is written, then the stores on the same database are restored
+// in a single transaction, committed once all the stores are restored. Then, a failure leaves the tables empty.
+// Only if the stores are on different databases and a commit fails, the stores of the databases committed before
+// keep their content, and their tables must be emptied before a new import.
+// The tables of the target stores are created if needed and must be empty.
+// Stores in the archive with no matching target store are skipped.
+func Import(ctx context.Context, r io.ReadSeeker, stores ...*Store) (*Header, error) {
+	header, err := Verify(r)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.Wrapf(err, "failed rewinding archive")
+	}
+
+	targets := map[string]*Store{}
+	for _, store := range stores {
+		targets[store.Name] = store
+	}
+	for _, info := range header.Stores {
+		store, ok := targets[info.Name]
+		if !ok {
+			logger.Warnf("skipping store [%s]: no target", info.Name)
+			continue
+		}
+		if err := prepare(store, info); err != nil {
+			return nil, errors.WithMessagef(err, "failed preparing store [%s]", info.Name)
+		}
+	}
+
+	// one transaction per database, rolled back if any store fails
+	var dbs []*sql.DB
+	txs := map[*sql.DB]*sql.Tx{}
+	defer func() {
+		for _, tx := range txs {
+			if err := tx.Rollback(); err != nil {
+				logger.Warnf("failed rolling back import: [%s]", err)
+			}
+		}
+	}()
+	for _, info := range header.Stores {
+		store, ok := targets[info.Name]
+		if !ok {
+			continue
+		}
+		if _, ok := txs[store.DB]; ok {
+			continue
+		}
+		tx, err := store.DB.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed starting transaction for store [%s]", store.Name)
+		}
+		txs[store.DB] = tx
+		dbs = append(dbs, store.DB)
+	}
+
+	a, err := newReader(r)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := readHeader(a); err != nil {
+		return nil, err
+	}
+	rec, err := a.next()
+	if err != nil {
+		return nil, err
+	}
+	for rec != nil {
+		if rec.Type != tableRecord {
+			return nil, errors.Wrapf(ErrCorruptedArchive, "unexpected [%s] record", rec.Type)
+		}
+		store, ok := targets[rec.Store]
+		if !ok {
+			if rec, err = skipStore(a, rec.Store); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if rec, err = importStore(ctx, a, txs[store.DB], store, rec); err != nil {
+			return nil, errors.WithMessagef(err, "failed importing store [%s]", store.Name)
+		}
+	}
+	for _, db := range dbs {
+		err := txs[db].Commit()
+		delete(txs, db)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed committing import")
+		}
+	}
+	return header, nil
+}
+
+// prepare creates the schema of the store, if needed, and checks it can receive the archived content
+func prepare(store *Store, info StoreInfo) error {
+	sp, err := store.spec()
+	if err != nil {
+		return err
+	}
+	prefix, err := store.prefix()
+	if err != nil {
+		return err
+	}
+	dialect := common.DetectDialect(store.DB)
+	create, ok := sp.creators[dialect]
+	if !ok {
+		return errors.Errorf("unsupported dialect [%s]", dialect)
+	}
+	// the constructors also migrate the schema to the latest version, the stores are not used afterward
+	if err := create(store.DB, common.NewDBOpts{TablePrefix: store.TablePrefix, CreateSchema: true}); err != nil {
+		return errors.WithMessagef(err, "failed creating schema")
+	}
+	version, err := common.SchemaVersion(store.DB, prefix, sp.migrationStore)
+	if err != nil {
+		return err
+	}
+	if info.SchemaVersion > version {
+		return errors.Wrapf(common.ErrNewerSchema, "archive has schema version [%d], target has [%d]", info.SchemaVersion, version)
+	}
+	_, physical, err := sp.tables(prefix)
+	if err != nil {
+		return err
+	}
+	for _, table := range physical {
+		var n int
+		if err := store.DB.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n); err != nil {
+			return errors.Wrapf(err, "failed counting rows of [%s]", table)
+		}
+		if n != 0 {
+			return errors.Errorf("table [%s] is not empty", table)
+		}
+	}
+	return nil
+}
+
+// skipStore reads past the tables of the passed store and returns the first record of the next store
+func skipStore(a *reader, store string) (*record, error) {
+	for {
+		rec, err := a.next()
+		if err != nil || rec == nil {
+			return nil, err
+		}
+		if rec.Type == tableRecord && rec.Store != store {
+			return rec, nil
+		}
+	}
+}
+
+// importStore inserts the tables of a store, starting at the passed table record, and returns the first record of the next store
+func importStore(ctx context.Context, a *reader, tx *sql.Tx, store *Store, rec *record) (*record, error) {
+	sp, _ := store.spec()
+	prefix, _ := store.prefix()
+	tables, physical, err := sp.tables(prefix)
+	if err != nil {
+		return nil, err
+	}
+	physicalOf := map[string]string{}
+	for i, table := range tables {
+		physicalOf[table] = physical[i]
+	}
+
+	for rec != nil && rec.Type == tableRecord && rec.Store == store.Name {
+		table, ok := physicalOf[rec.Table]
+		if !ok {
+			return nil, errors.Errorf("unknown table [%s]", rec.Table)
+		}
+		if rec, err = importTable(ctx, a, tx, table, rec.Columns); err != nil {
+			return nil, errors.WithMessagef(err, "failed importing table [%s]", table)
+		}
+	}
+	return rec, nil
+}
+
+// importTable inserts the rows following a table record and returns the record after the end of the table
+func importTable(ctx context.Context, a *reader, tx *sql.Tx, table string, columns []Column) (*record, error) {
+	targetColumns, err := columnsOf(ctx, tx, table)
+	if err != nil {
+		return nil, err
+	}
+	// columns dropped by the target schema are ignored, added ones get their default
+	var names []string
+	var indexes []int
+	for i, column := range columns {
+		if targetColumns[column.Name] {
+			names = append(names, column.Name)
+			indexes = append(indexes, i)
+		} else {
+			logger.Warnf("ignoring column [%s] of table [%s]: not in the target", column.Name, table)
+		}
+	}
+	query, err := common.NewInsertInto(table).Rows(strings.Join(names, ", ")).Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed compiling query")
+	}
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed preparing [%s]", query)
+	}
+	defer stmt.Close()
+
+	var n int
+	for {
+		rec, err := a.next()
+		if err != nil {
+			return nil, err
+		}
+		if rec == nil {
+			return nil, errors.Wrapf(ErrCorruptedArchive, "table not terminated")
+		}
+		if rec.Type == endRecord {
+			logger.Debugf("imported [%d] rows into [%s]", n, table)
+			return a.next()
+		}
+		if len(rec.Values) != len(columns) {
+			return nil, errors.Wrapf(ErrCorruptedArchive, "row with [%d] values, expected [%d]", len(rec.Values), len(columns))
+		}
+		args := make([]any, len(indexes))
+		for i, index := range indexes {
+			if args[i], err = decodeValue(columns[index].Kind, rec.Values[index]); err != nil {
+				return nil, errors.Wrapf(ErrCorruptedArchive, "invalid value for column [%s]: %s", columns[index].Name, err)
+			}
+		}
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return nil, errors.Wrapf(err, "failed inserting row")
+		}
+		n++
+	}
+}
+
+func columnsOf(ctx context.Context, tx *sql.Tx, table string) (map[string]bool, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT * FROM "+table+" WHERE 1 = 0")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed querying columns of [%s]", table)
+	}
+	defer rows.Close()
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting columns of [%s]", table)
+	}
+	columns := map[string]bool{}
+	for _, name := range names {
+		columns[strings.ToLower(name)] = true
+	}
+	return columns, nil
+}
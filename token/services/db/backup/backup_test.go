@@ -0,0 +1,257 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"path"
+	"testing"
+	"time"
+
+	sql2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql"
+	common2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	driver2 "github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/sqlite"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+var tmsID = token.TMSID{Network: "pineapple", Channel: "ch", Namespace: "zkat"}
+
+func openSqlite(t *testing.T, dataSource string) Stores {
+	stores, err := OpenStoresOn(common2.Opts{Driver: sql2.SQLite, DataSource: dataSource, MaxOpenConns: 10}, tmsID)
+	require.NoError(t, err)
+	t.Cleanup(func() { stores.Close() })
+	return stores
+}
+
+func fileDataSource(t *testing.T) string {
+	return fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)", path.Join(t.TempDir(), "db.sqlite"))
+}
+
+func storeOf(stores Stores, name string) *Store {
+	for _, s := range stores {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func populate(t *testing.T, stores Stores) {
+	tokens := storeOf(stores, TokensStore)
+	tokenDB, err := sqlite.NewTokenDB(tokens.DB, tokens.DB, common.NewDBOpts{TablePrefix: tokens.TablePrefix, CreateSchema: true})
+	require.NoError(t, err)
+	require.NoError(t, tokenDB.StorePublicParams([]byte("public params")))
+	tx, err := tokenDB.NewTokenDBTransaction()
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, tx.StoreToken(context.Background(), driver.TokenRecord{
+			TxID:           "tx1",
+			Index:          uint64(i),
+			OwnerRaw:       []byte{1, 2, 3},
+			OwnerType:      "idemix",
+			OwnerIdentity:  []byte{4, 5, 6},
+			OwnerWalletID:  "alice",
+			Ledger:         []byte("ledger"),
+			LedgerMetadata: []byte{},
+			Quantity:       "0x0a",
+			Type:           "USD",
+			Amount:         10,
+			Owner:          true,
+			NotAfter:       time.Now().Add(time.Hour),
+		}, []string{"alice"}))
+	}
+	require.NoError(t, tx.Delete(context.Background(), token2.ID{TxId: "tx1", Index: 2}, "tx2"))
+	require.NoError(t, tx.Commit())
+
+	transactions := storeOf(stores, TransactionsStore)
+	ttxDB, err := sqlite.NewTransactionDB(transactions.DB, transactions.DB, common.NewDBOpts{TablePrefix: transactions.TablePrefix, CreateSchema: true})
+	require.NoError(t, err)
+	w, err := ttxDB.BeginAtomicWrite()
+	require.NoError(t, err)
+	require.NoError(t, w.AddTokenRequest("tx1", []byte("request"), map[string][]byte{"key": []byte("value")}, driver2.PPHash("pp")))
+	require.NoError(t, w.AddTransaction(&driver.TransactionRecord{
+		TxID:         "tx1",
+		ActionType:   driver.Issue,
+		RecipientEID: "alice",
+		TokenType:    "USD",
+		Amount:       big.NewInt(30),
+		Timestamp:    time.Now(),
+		Status:       driver.Pending,
+	}))
+	require.NoError(t, w.Commit())
+	require.NoError(t, ttxDB.SetStatus(context.Background(), "tx1", driver.Confirmed, "all good"))
+
+	wallets := storeOf(stores, WalletsStore)
+	walletDB, err := sqlite.NewWalletDB(wallets.DB, wallets.DB, common.NewDBOpts{TablePrefix: wallets.TablePrefix, CreateSchema: true})
+	require.NoError(t, err)
+	require.NoError(t, walletDB.StoreIdentity([]byte("alice"), "alice_eid", "alice", 0, []byte("meta")))
+}
+
+func check(t *testing.T, stores Stores) {
+	tokens := storeOf(stores, TokensStore)
+	tokenDB, err := sqlite.NewTokenDB(tokens.DB, tokens.DB, common.NewDBOpts{TablePrefix: tokens.TablePrefix})
+	require.NoError(t, err)
+	pp, err := tokenDB.PublicParams()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("public params"), pp)
+	unspent, err := tokenDB.ListUnspentTokens()
+	require.NoError(t, err)
+	assert.Equal(t, 2, unspent.Count())
+	balance, err := tokenDB.Balance("alice", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), balance)
+	spentBy, deleted, err := tokenDB.WhoDeletedTokens(&token2.ID{TxId: "tx1", Index: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tx2"}, spentBy)
+	assert.Equal(t, []bool{true}, deleted)
+
+	transactions := storeOf(stores, TransactionsStore)
+	ttxDB, err := sqlite.NewTransactionDB(transactions.DB, transactions.DB, common.NewDBOpts{TablePrefix: transactions.TablePrefix})
+	require.NoError(t, err)
+	status, message, err := ttxDB.GetStatus("tx1")
+	require.NoError(t, err)
+	assert.Equal(t, driver.Confirmed, status)
+	assert.Equal(t, "all good", message)
+	request, err := ttxDB.GetTokenRequest("tx1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("request"), request)
+
+	wallets := storeOf(stores, WalletsStore)
+	walletDB, err := sqlite.NewWalletDB(wallets.DB, wallets.DB, common.NewDBOpts{TablePrefix: wallets.TablePrefix})
+	require.NoError(t, err)
+	walletID, err := walletDB.GetWalletID([]byte("alice"), 0)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", walletID)
+}
+
+func export(t *testing.T) []byte {
+	source := openSqlite(t, fileDataSource(t))
+	populate(t, source)
+	buf := &bytes.Buffer{}
+	header, err := Export(context.Background(), buf, tmsID, source...)
+	require.NoError(t, err)
+	assert.Equal(t, FormatVersion, header.Format)
+	assert.Equal(t, tmsID, header.TMSID)
+	// the audit and identity stores are not used
+	var names []string
+	for _, info := range header.Stores {
+		names = append(names, info.Name)
+	}
+	assert.Equal(t, []string{TokensStore, TransactionsStore, WalletsStore}, names)
+	return buf.Bytes()
+}
+
+func TestRoundTrip(t *testing.T) {
+	archive := export(t)
+
+	header, err := Verify(bytes.NewReader(archive))
+	require.NoError(t, err)
+	assert.Equal(t, 1, header.Stores[0].SchemaVersion)
+
+	for name, dataSource := range map[string]string{
+		"sqlite": fileDataSource(t),
+		"memory": "file:backup?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)&mode=memory&cache=shared",
+	} {
+		t.Run(name, func(t *testing.T) {
+			target := openSqlite(t, dataSource)
+			_, err := Import(context.Background(), bytes.NewReader(archive), target...)
+			require.NoError(t, err)
+			check(t, target)
+
+			// a second import is refused
+			_, err = Import(context.Background(), bytes.NewReader(archive), target...)
+			assert.ErrorContains(t, err, "is not empty")
+		})
+	}
+}
+
+func TestImportRollback(t *testing.T) {
+	archive := export(t)
+
+	// the wallets, imported last, cannot be written
+	target := openSqlite(t, fileDataSource(t))
+	wallets := storeOf(target, WalletsStore)
+	_, err := sqlite.NewWalletDB(wallets.DB, wallets.DB, common.NewDBOpts{TablePrefix: wallets.TablePrefix, CreateSchema: true})
+	require.NoError(t, err)
+	tables, err := common.GetTableNames(wallets.TablePrefix)
+	require.NoError(t, err)
+	_, err = wallets.DB.Exec(fmt.Sprintf("CREATE TRIGGER no_wallets BEFORE INSERT ON %s BEGIN SELECT RAISE(ABORT, 'no wallets'); END;", tables.Wallets))
+	require.NoError(t, err)
+	_, err = Import(context.Background(), bytes.NewReader(archive), target...)
+	assert.ErrorContains(t, err, "no wallets")
+
+	// the stores imported before are rolled back, the import can be run again
+	tokens := storeOf(target, TokensStore)
+	tables, err = common.GetTableNames(tokens.TablePrefix)
+	require.NoError(t, err)
+	var n int
+	require.NoError(t, tokens.DB.QueryRow("SELECT COUNT(*) FROM "+tables.Tokens).Scan(&n))
+	assert.Zero(t, n)
+	_, err = wallets.DB.Exec("DROP TRIGGER no_wallets")
+	require.NoError(t, err)
+	_, err = Import(context.Background(), bytes.NewReader(archive), target...)
+	require.NoError(t, err)
+	check(t, target)
+}
+
+func TestCorruptedArchive(t *testing.T) {
+	archive := export(t)
+
+	// truncated
+	_, err := Verify(bytes.NewReader(archive[:len(archive)/2]))
+	assert.True(t, errors.Is(err, ErrCorruptedArchive))
+
+	// not an archive
+	_, err = Verify(bytes.NewReader([]byte("hello")))
+	assert.True(t, errors.Is(err, ErrCorruptedArchive))
+
+	// tampered
+	a, err := newReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	w := newWriter(buf)
+	for {
+		rec, err := a.next()
+		require.NoError(t, err)
+		if rec == nil {
+			break
+		}
+		if rec.Type == rowRecord && rec.Values[0] != nil && string(rec.Values[0]) == `"tx1"` {
+			rec.Values[0] = []byte(`"tx3"`)
+		}
+		// the end record carries the original table hash
+		if rec.Type == tableRecord {
+			require.NoError(t, w.startTable(rec.Store, rec.Table, rec.Columns))
+			continue
+		}
+		require.NoError(t, w.write(rec))
+	}
+	require.NoError(t, w.close())
+	_, err = Verify(bytes.NewReader(buf.Bytes()))
+	assert.True(t, errors.Is(err, ErrCorruptedArchive))
+
+	// nothing is written when the archive is corrupted
+	target := openSqlite(t, fileDataSource(t))
+	_, err = Import(context.Background(), bytes.NewReader(buf.Bytes()), target...)
+	assert.True(t, errors.Is(err, ErrCorruptedArchive))
+	for _, s := range target {
+		exists := 0
+		require.NoError(t, s.DB.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table'").Scan(&exists))
+		assert.Zero(t, exists)
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package backup
+
+import (
+	"database/sql"
+	errors2 "errors"
+
+	sql2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql"
+	common2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql/common"
+	postgres2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql/postgres"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql/sqlite"
+	db2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/storage/db"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/postgres"
+	sqlite2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/sqlite"
+	"github.com/pkg/errors"
+)
+
+// Store names
+const (
+	TokensStore       = "tokens"
+	TransactionsStore = "transactions"
+	AuditStore        = "audit"
+	IdentityStore     = "identity"
+	WalletsStore      = "wallets"
+)
+
+// auditSuffix is the suffix appended to the table prefix of the audit transactions, see common.NewAuditTransactionDB
+const auditSuffix = "_aud"
+
+type creator func(db *sql.DB, opts common.NewDBOpts) error
+
+func newCreator[T any](newDB common.NewDBFunc[T]) creator {
+	return func(db *sql.DB, opts common.NewDBOpts) error {
+		_, err := newDB(db, db, opts)
+		return err
+	}
+}
+
+// spec describes how to find, read and create a store
+type spec struct {
+	name string
+	// keys are the configuration keys of the persistence of the store, in order of precedence
+	keys []string
+	// migrationStore is the name of the store in the schema versions table
+	migrationStore string
+	prefixSuffix   string
+	// tables returns the tables of the store, parents first
+	tables   func(prefix string) ([]string, []string, error)
+	creators map[common.Dialect]creator
+}
+
+var specs = []spec{
+	{
+		name:           TokensStore,
+		keys:           []string{"tokendb.persistence", "db.persistence"},
+		migrationStore: common.TokensStore,
+		tables: func(prefix string) ([]string, []string, error) {
+			t, err := common.GetTableNames(prefix)
+			return []string{"tokens", "token_ownership", "public_params", "token_certifications", "transfer_orders", "transfer_order_executions"},
+				[]string{t.Tokens, t.Ownership, t.PublicParams, t.Certifications, t.TransferOrders, t.TransferOrderRuns}, err
+		},
+		creators: map[common.Dialect]creator{
+			common.SQLite:   newCreator(sqlite2.NewTokenDB),
+			common.Postgres: newCreator(postgres.NewTokenDB),
+		},
+	},
+	{
+		name:           TransactionsStore,
+		keys:           []string{"ttxdb.persistence", "db.persistence"},
+		migrationStore: common.TransactionsStore,
+		tables:         transactionTables,
+		creators: map[common.Dialect]creator{
+			common.SQLite:   newCreator(sqlite2.NewTransactionDB),
+			common.Postgres: newCreator(postgres.NewTransactionDB),
+		},
+	},
+	{
+		name:           AuditStore,
+		keys:           []string{"auditdb.persistence", "db.persistence"},
+		migrationStore: common.TransactionsStore,
+		prefixSuffix:   auditSuffix,
+		tables:         transactionTables,
+		creators: map[common.Dialect]creator{
+			common.SQLite:   newCreator(sqlite2.NewAuditTransactionDB),
+			common.Postgres: newCreator(postgres.NewAuditTransactionDB),
+		},
+	},
+	{
+		name:           IdentityStore,
+		keys:           []string{"identitydb.persistence", "db.persistence"},
+		migrationStore: common.IdentityStore,
+		tables: func(prefix string) ([]string, []string, error) {
+			t, err := common.GetTableNames(prefix)
+			return []string{"identity_configurations", "identity_information", "identity_signers"},
+				[]string{t.IdentityConfigurations, t.IdentityInfo, t.Signers}, err
+		},
+		creators: map[common.Dialect]creator{
+			common.SQLite:   newCreator(sqlite2.NewIdentityDB),
+			common.Postgres: newCreator(postgres.NewIdentityDB),
+		},
+	},
+	{
+		name:           WalletsStore,
+		keys:           []string{"identitydb.persistence", "db.persistence"},
+		migrationStore: common.WalletsStore,
+		tables: func(prefix string) ([]string, []string, error) {
+			t, err := common.GetTableNames(prefix)
+			return []string{"wallets"}, []string{t.Wallets}, err
+		},
+		creators: map[common.Dialect]creator{
+			common.SQLite:   newCreator(sqlite2.NewWalletDB),
+			common.Postgres: newCreator(postgres.NewWalletDB),
+		},
+	},
+}
+
+func transactionTables(prefix string) ([]string, []string, error) {
+	t, err := common.GetTableNames(prefix)
+	return []string{"requests", "transactions", "movements", "request_validations", "transaction_endorsements", "parked_transactions"},
+		[]string{t.Requests, t.Transactions, t.Movements, t.Validations, t.TransactionEndorseAck, t.ParkedTransactions}, err
+}
+
+func specOf(name string) (*spec, error) {
+	for i := range specs {
+		if specs[i].name == name {
+			return &specs[i], nil
+		}
+	}
+	return nil, errors.Errorf("unknown store [%s]", name)
+}
+
+// Store is a SQL store of a TMS, the unit of export and import.
+// Token locks are not part of a backup: they only matter while a transaction is assembled.
+type Store struct {
+	Name        string
+	DB          *sql.DB
+	TablePrefix string
+}
+
+func (s *Store) spec() (*spec, error) {
+	return specOf(s.Name)
+}
+
+// prefix returns the table prefix of the store, including the store suffix
+func (s *Store) prefix() (string, error) {
+	sp, err := s.spec()
+	if err != nil {
+		return "", err
+	}
+	return s.TablePrefix + sp.prefixSuffix, nil
+}
+
+// Stores is a set of stores
+type Stores []*Store
+
+// Close closes the databases of the stores
+func (s Stores) Close() error {
+	closed := map[*sql.DB]bool{}
+	var errs []error
+	for _, store := range s {
+		if closed[store.DB] {
+			continue
+		}
+		closed[store.DB] = true
+		errs = append(errs, store.DB.Close())
+	}
+	return errors2.Join(errs...)
+}
+
+// OpenStores opens the stores of the passed TMS as configured for the node
+func OpenStores(cp driver.ConfigProvider, tmsID token.TMSID) (Stores, error) {
+	var stores Stores
+	dbs := map[string]*sql.DB{}
+	for _, sp := range specs {
+		opts, _, err := db.CompileOpts(cp, tmsID, sp.keys...)
+		if err != nil {
+			stores.Close()
+			return nil, errors.WithMessagef(err, "failed to get options of store [%s]", sp.name)
+		}
+		key := string(opts.Driver) + opts.DataSource
+		sqlDB, ok := dbs[key]
+		if !ok {
+			sqlDB, err = OpenDB(opts)
+			if err != nil {
+				stores.Close()
+				return nil, errors.WithMessagef(err, "failed to open store [%s]", sp.name)
+			}
+			dbs[key] = sqlDB
+		}
+		stores = append(stores, &Store{Name: sp.name, DB: sqlDB, TablePrefix: opts.TablePrefix})
+	}
+	return stores, nil
+}
+
+// OpenStoresOn opens all the stores of the passed TMS on the database described by the passed options.
+// If the options have no table prefix, the one the SDK derives from the TMS ID is used.
+func OpenStoresOn(opts common2.Opts, tmsID token.TMSID) (Stores, error) {
+	sqlDB, err := OpenDB(opts)
+	if err != nil {
+		return nil, err
+	}
+	prefix := opts.TablePrefix
+	if len(prefix) == 0 {
+		prefix = db2.EscapeForTableName(tmsID.Network, tmsID.Channel, tmsID.Namespace)
+	}
+	var stores Stores
+	for _, sp := range specs {
+		stores = append(stores, &Store{Name: sp.name, DB: sqlDB, TablePrefix: prefix})
+	}
+	return stores, nil
+}
+
+// OpenDB opens the database described by the passed options. The memory persistence uses sqlite.
+func OpenDB(opts common2.Opts) (*sql.DB, error) {
+	switch opts.Driver {
+	case sql2.SQLite:
+		return sqlite.OpenDB(opts.DataSource, opts.MaxOpenConns, opts.MaxIdleConns, opts.MaxIdleTime, opts.SkipPragmas)
+	case sql2.Postgres:
+		return postgres2.OpenDB(opts.DataSource, opts.MaxOpenConns, opts.MaxIdleConns, opts.MaxIdleTime)
+	default:
+		return nil, errors.Errorf("unsupported driver [%s]", opts.Driver)
+	}
+}
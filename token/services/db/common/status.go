@@ -24,6 +24,7 @@ type StatusEvent struct {
 
 type StatusSupport struct {
 	listeners      map[string][]chan StatusEvent
+	globals        []chan StatusEvent
 	mutex          sync.RWMutex
 	pollingTimeout time.Duration
 }
@@ -64,22 +65,54 @@ func (c *StatusSupport) DeleteStatusListener(txID string, ch chan StatusEvent) {
 	}
 }
 
+// AddGlobalStatusListener registers a listener for the status changes of all transactions.
+// The events are sent without blocking, a listener whose channel is full misses them. Then, the channel must be buffered.
+func (c *StatusSupport) AddGlobalStatusListener(ch chan StatusEvent) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.globals = append(c.globals, ch)
+}
+
+// DeleteGlobalStatusListener removes a listener registered with AddGlobalStatusListener
+func (c *StatusSupport) DeleteGlobalStatusListener(ch chan StatusEvent) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, l := range c.globals {
+		if l == ch {
+			c.globals = append(c.globals[:i], c.globals[i+1:]...)
+			return
+		}
+	}
+}
+
 func (c *StatusSupport) Notify(event StatusEvent) {
 	span := trace.SpanFromContext(event.Ctx)
 	span.AddEvent("start_notify")
 	defer span.AddEvent("end_notify")
 	c.mutex.RLock()
 	listeners := c.listeners[event.TxID]
-	if len(listeners) == 0 {
+	if len(listeners) == 0 && len(c.globals) == 0 {
 		c.mutex.RUnlock()
 		return
 	}
 	// clone listeners and release lock
 	clone := make([]chan StatusEvent, len(listeners))
 	copy(clone, listeners)
+	globals := make([]chan StatusEvent, len(c.globals))
+	copy(globals, c.globals)
 	c.mutex.RUnlock()
 
 	for _, listener := range clone {
 		listener <- event
 	}
+	// a slow global listener must not stall the status updates
+	for _, listener := range globals {
+		select {
+		case listener <- event:
+		default:
+			logger.Warnf("dropping status event of [%s]: listener is full", event.TxID)
+		}
+	}
 }
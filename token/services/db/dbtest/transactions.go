@@ -35,8 +35,11 @@ var TokenTransactionDBCases = []struct {
 	{"AllowsSameTxID", TAllowsSameTxID},
 	{"Rollback", TRollback},
 	{"TransactionQueries", TTransactionQueries},
+	{"TransactionsPagination", TTransactionsPagination},
+	{"MovementsPagination", TMovementsPagination},
 	{"ValidationRecordQueries", TValidationRecordQueries},
 	{"TEndorserAcks", TEndorserAcks},
+	{"ParkedTransactions", TParkedTransactions},
 }
 
 func TFailsIfRequestDoesNotExist(t *testing.T, db driver.TokenTransactionDB) {
@@ -226,6 +229,16 @@ func TMovements(t *testing.T, db driver.TokenTransactionDB) {
 	records, err = db.QueryMovements(driver.QueryMovementsParams{TxStatuses: []driver.TxStatus{driver.Confirmed}})
 	assert.NoError(t, err)
 	assert.Len(t, records, 1)
+
+	// From
+	lastHour := time.Now().Add(-time.Hour)
+	records, err = db.QueryMovements(driver.QueryMovementsParams{MovementDirection: driver.Sent, From: &lastHour})
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	nextHour := time.Now().Add(time.Hour)
+	records, err = db.QueryMovements(driver.QueryMovementsParams{MovementDirection: driver.All, From: &nextHour})
+	assert.NoError(t, err)
+	assert.Len(t, records, 0)
 }
 
 func TTransaction(t *testing.T, db driver.TokenTransactionDB) {
@@ -775,6 +788,117 @@ func TTransactionQueries(t *testing.T, db driver.TokenTransactionDB) {
 	}
 }
 
+func TTransactionsPagination(t *testing.T, db driver.TokenTransactionDB) {
+	// seven records, the last three share the same timestamp
+	t0 := time.Now().UTC().Add(-time.Hour)
+	w, err := db.BeginAtomicWrite()
+	assert.NoError(t, err)
+	for i := 0; i < 7; i++ {
+		txID := fmt.Sprintf("tx%d", i)
+		assert.NoError(t, w.AddTokenRequest(txID, []byte{}, map[string][]byte{}, driver2.PPHash("tr")))
+		assert.NoError(t, w.AddTransaction(&driver.TransactionRecord{
+			TxID:         txID,
+			ActionType:   driver.Transfer,
+			SenderEID:    "alice",
+			RecipientEID: "bob",
+			TokenType:    "magic",
+			Amount:       big.NewInt(int64(i + 1)),
+			Timestamp:    t0.Add(time.Duration(min(i, 4)) * time.Minute),
+		}))
+	}
+	assert.NoError(t, w.Commit())
+
+	_, err = db.QueryTransactionsPage(driver.QueryTransactionsParams{}, driver.Pagination{})
+	assert.Error(t, err)
+	_, err = db.QueryTransactionsPage(driver.QueryTransactionsParams{}, driver.Pagination{PageSize: 1, Cursor: "not a cursor"})
+	assert.Error(t, err)
+
+	var all []*driver.TransactionRecord
+	pagination := driver.Pagination{PageSize: 3}
+	for pages := 1; ; pages++ {
+		page, err := db.QueryTransactionsPage(driver.QueryTransactionsParams{}, pagination)
+		assert.NoError(t, err)
+		assert.True(t, len(page.Items) <= 3)
+		all = append(all, page.Items...)
+		if len(page.NextCursor) == 0 {
+			assert.Equal(t, 3, pages)
+			break
+		}
+		// records stored after the first page do not change the following ones
+		if pages == 1 {
+			w, err := db.BeginAtomicWrite()
+			assert.NoError(t, err)
+			assert.NoError(t, w.AddTokenRequest("old", []byte{}, map[string][]byte{}, driver2.PPHash("tr")))
+			assert.NoError(t, w.AddTransaction(&driver.TransactionRecord{TxID: "old", TokenType: "magic", Amount: big.NewInt(1), Timestamp: t0.Add(-time.Minute)}))
+			assert.NoError(t, w.Commit())
+		}
+		pagination.Cursor = page.NextCursor
+	}
+	assert.Len(t, all, 7)
+	seen := map[string]bool{}
+	for i, r := range all {
+		assert.False(t, seen[r.TxID], "duplicate record [%s]", r.TxID)
+		seen[r.TxID] = true
+		if i > 0 {
+			assert.False(t, r.Timestamp.Before(all[i-1].Timestamp), "records out of order")
+		}
+	}
+
+	// filters apply to all pages
+	page, err := db.QueryTransactionsPage(driver.QueryTransactionsParams{IDs: []string{"tx1", "tx5"}}, driver.Pagination{PageSize: 1})
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, "tx1", page.Items[0].TxID)
+	page, err = db.QueryTransactionsPage(driver.QueryTransactionsParams{IDs: []string{"tx1", "tx5"}}, driver.Pagination{PageSize: 1, Cursor: page.NextCursor})
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, "tx5", page.Items[0].TxID)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TMovementsPagination(t *testing.T, db driver.TokenTransactionDB) {
+	w, err := db.BeginAtomicWrite()
+	assert.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		txID := fmt.Sprintf("tx%d", i)
+		assert.NoError(t, w.AddTokenRequest(txID, []byte{}, map[string][]byte{}, driver2.PPHash("tr")))
+		assert.NoError(t, w.AddMovement(&driver.MovementRecord{
+			TxID:         txID,
+			EnrollmentID: "alice",
+			TokenType:    "magic",
+			Amount:       big.NewInt(int64(i + 1)),
+		}))
+	}
+	assert.NoError(t, w.Commit())
+
+	for _, direction := range []driver.SearchDirection{driver.FromBeginning, driver.FromLast} {
+		params := driver.QueryMovementsParams{EnrollmentIDs: []string{"alice"}, MovementDirection: driver.All, SearchDirection: direction, NumRecords: 1}
+		var all []*driver.MovementRecord
+		pagination := driver.Pagination{PageSize: 2}
+		for {
+			page, err := db.QueryMovementsPage(params, pagination)
+			assert.NoError(t, err)
+			all = append(all, page.Items...)
+			if len(page.NextCursor) == 0 {
+				break
+			}
+			pagination.Cursor = page.NextCursor
+		}
+		assert.Len(t, all, 5)
+		seen := map[string]bool{}
+		for i, r := range all {
+			assert.False(t, seen[r.TxID], "duplicate record [%s]", r.TxID)
+			seen[r.TxID] = true
+			if i > 0 && direction == driver.FromBeginning {
+				assert.False(t, r.Timestamp.Before(all[i-1].Timestamp), "records out of order")
+			}
+			if i > 0 && direction == driver.FromLast {
+				assert.False(t, r.Timestamp.After(all[i-1].Timestamp), "records out of order")
+			}
+		}
+	}
+}
+
 func getTransactions(t *testing.T, db driver.TokenTransactionDB, params driver.QueryTransactionsParams) []*driver.TransactionRecord {
 	records, err := db.QueryTransactions(params)
 	assert.NoError(t, err)
@@ -909,6 +1033,47 @@ func TEndorserAcks(t *testing.T, db driver.TokenTransactionDB) {
 	}
 }
 
+func TParkedTransactions(t *testing.T, db driver.TokenTransactionDB) {
+	stage, raw, err := db.GetParkedTransaction("1")
+	assert.NoError(t, err)
+	assert.Empty(t, stage)
+	assert.Nil(t, raw)
+
+	assert.NoError(t, db.ParkTransaction("1", "stage_1", []byte("tx_1")))
+	assert.NoError(t, db.ParkTransaction("2", "stage_1", []byte("tx_2")))
+	stage, raw, err = db.GetParkedTransaction("1")
+	assert.NoError(t, err)
+	assert.Equal(t, "stage_1", stage)
+	assert.Equal(t, []byte("tx_1"), raw)
+	records, err := db.QueryParkedTransactions()
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "1", records[0].TxID)
+	assert.Equal(t, "stage_1", records[0].Stage)
+	assert.False(t, records[0].StoredAt.IsZero())
+	assert.Equal(t, "2", records[1].TxID)
+
+	// parking again replaces the previous transaction
+	assert.NoError(t, db.ParkTransaction("1", "stage_2", []byte("tx_1'")))
+	stage, raw, err = db.GetParkedTransaction("1")
+	assert.NoError(t, err)
+	assert.Equal(t, "stage_2", stage)
+	assert.Equal(t, []byte("tx_1'"), raw)
+
+	assert.NoError(t, db.UnparkTransaction("1"))
+	_, raw, err = db.GetParkedTransaction("1")
+	assert.NoError(t, err)
+	assert.Nil(t, raw)
+	_, raw, err = db.GetParkedTransaction("2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("tx_2"), raw)
+	assert.NoError(t, db.UnparkTransaction("1"))
+	records, err = db.QueryParkedTransactions()
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "2", records[0].TxID)
+}
+
 func createTestTransaction(t *testing.T, db driver.TokenTransactionDB, txID string) {
 	w, err := db.BeginAtomicWrite()
 	if err != nil {
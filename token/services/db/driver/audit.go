@@ -34,6 +34,13 @@ type AuditTransactionDB interface {
 	// QueryMovements returns a list of movement records
 	QueryMovements(params QueryMovementsParams) ([]*MovementRecord, error)
 
+	// QueryTransactionsPage returns a page of the transactions that match the passed params, ordered by time of storage
+	QueryTransactionsPage(params QueryTransactionsParams, pagination Pagination) (*Page[*TransactionRecord], error)
+
+	// QueryMovementsPage returns a page of the movement records that match the passed params, ordered by time of storage
+	// following params.SearchDirection. params.NumRecords is ignored, the page size is given by the pagination
+	QueryMovementsPage(params QueryMovementsParams, pagination Pagination) (*Page[*MovementRecord], error)
+
 	// QueryValidations returns an iterator over the validation records matching the passed params
 	QueryValidations(params QueryValidationRecordsParams) (ValidationRecordsIterator, error)
 
@@ -177,7 +177,7 @@ type ValidationRecordsIterator = collections.Iterator[*ValidationRecord]
 type TokenRequestIterator = collections.Iterator[*TokenRequestRecord]
 
 // QueryMovementsParams defines the parameters for querying movements.
-// Movement records will be filtered by EnrollmentID, TokenFormat, and Status.
+// Movement records will be filtered by EnrollmentID, TokenFormat, Status, and time of storage.
 // SearchDirection tells if the search should start from the oldest to the newest records or vice versa.
 // MovementDirection which amounts to consider. Sent correspond to a negative amount,
 // Received to a positive amount, and All to both.
@@ -195,6 +195,9 @@ type QueryMovementsParams struct {
 	// NumRecords is the number of records to return
 	// If 0, all records are returned
 	NumRecords int
+	// From is the start time of the query
+	// If nil, the query starts from the first movement
+	From *time.Time
 }
 
 // QueryTransactionsParams defines the parameters for querying transactions.
@@ -227,6 +230,26 @@ type QueryTransactionsParams struct {
 	Statuses []TxStatus
 }
 
+// Pagination selects a page of the results of a query.
+// Pages are ordered and stable: records stored after the first page has been returned
+// do not shift the following pages.
+type Pagination struct {
+	// PageSize is the maximum number of records in the page. It must be positive
+	PageSize int
+	// Cursor is the opaque continuation token returned with the previous page.
+	// If empty, the first page is returned
+	Cursor string
+}
+
+// Page is a page of the results of a query
+type Page[T any] struct {
+	// Items are the records in the page, in order
+	Items []T
+	// NextCursor is the continuation token to pass in the Pagination of the next query to get the next page.
+	// It is empty if there are no more records
+	NextCursor string
+}
+
 // QueryValidationRecordsParams defines the parameters for querying validation records.
 type QueryValidationRecordsParams struct {
 	// From is the start time of the query
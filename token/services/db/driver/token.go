@@ -53,6 +53,10 @@ type TokenRecord struct {
 	Auditor bool
 	// Issuer issued to mark this token as issued by this node
 	Issuer bool
+	// NotBefore is the time before which the token cannot be spent, if the token is bound to a validity window
+	NotBefore time.Time
+	// NotAfter is the time from which the token is expired, if the token is bound to a validity window
+	NotAfter time.Time
 }
 
 // TokenDetails provides details about an owned (spent or unspent) token
@@ -97,6 +101,8 @@ type QueryTokenDetailsParams struct {
 	Spendable SpendableFilter
 	// LedgerTokenFormats selects tokens whose output on the ledger has a format in the list
 	LedgerTokenFormats []token.Format
+	// ValidAt (optional) selects tokens whose validity window, if any, contains the passed time
+	ValidAt *time.Time
 }
 
 type SpendableFilter int
@@ -140,9 +146,97 @@ type TokenDBTransaction interface {
 	Rollback() error
 }
 
+// TransferOrderStatus is the status of a scheduled transfer order
+type TransferOrderStatus int
+
+const (
+	// TransferOrderActive is the status of an order waiting for its next execution
+	TransferOrderActive TransferOrderStatus = iota
+	// TransferOrderCompleted is the status of a one-shot order executed successfully
+	TransferOrderCompleted
+	// TransferOrderFailed is the status of a one-shot order whose retries are exhausted
+	TransferOrderFailed
+	// TransferOrderCancelled is the status of an order cancelled before its completion
+	TransferOrderCancelled
+)
+
+// TransferOrderRecord is a transfer to be executed at a given time, once or periodically
+type TransferOrderRecord struct {
+	// ID identifies the order
+	ID string
+	// Wallet is the identifier of the owner wallet to spend from
+	Wallet string
+	// Type is the type of the tokens to transfer
+	Type token.Type
+	// Amount is the quantity to transfer at each execution
+	Amount uint64
+	// Recipient is the identity of the node the recipient identity is requested to at each execution
+	Recipient []byte
+	// Auditor is the identity of the auditor, if required by the TMS
+	Auditor []byte
+	// Schedule is the cron-like schedule of a recurring order, empty for a one-shot order
+	Schedule string
+	// NextRun is the time of the next execution
+	NextRun time.Time
+	// Attempts is the number of failed attempts of the current execution
+	Attempts int
+	// MaxRetries is the number of times a failed execution is retried
+	MaxRetries int
+	// RetryDelay is the time to wait before retrying a failed execution
+	RetryDelay time.Duration
+	// Status is the status of the order
+	Status TransferOrderStatus
+	// PendingTxID is the id of the transaction of the current execution whose finality is not known yet, if any.
+	// The execution is not retried until the transaction is known to be committed or rejected.
+	PendingTxID string
+	// CreatedAt is the time the order was stored the first time
+	CreatedAt time.Time
+}
+
+// TransferOrderExecutionRecord is the outcome of an attempt to execute a transfer order
+type TransferOrderExecutionRecord struct {
+	// OrderID identifies the order
+	OrderID string
+	// TxID is the id of the transaction generated by the attempt, empty if the transaction could not be assembled
+	TxID string
+	// Attempt is the number of the attempt of the current execution, starting from 1
+	Attempt int
+	// ScheduledAt is the time the attempt was due
+	ScheduledAt time.Time
+	// Committed is true if the transaction has been committed
+	Committed bool
+	// Error describes the failure, if any
+	Error string
+	// ExecutedAt is the time the attempt completed
+	ExecutedAt time.Time
+}
+
+// QueryTransferOrdersParams defines the parameters for querying transfer orders
+type QueryTransferOrdersParams struct {
+	// Statuses selects the orders with one of the passed statuses, all if empty
+	Statuses []TransferOrderStatus
+	// DueBefore, if not zero, selects the orders whose next run is not after it
+	DueBefore time.Time
+}
+
+// TransferOrderDB stores the scheduled transfer orders and the outcomes of their executions
+type TransferOrderDB interface {
+	// StoreTransferOrder stores the passed order, replacing any order with the same id
+	StoreTransferOrder(order TransferOrderRecord) error
+	// GetTransferOrder returns the order with the passed id, nil if it does not exist
+	GetTransferOrder(id string) (*TransferOrderRecord, error)
+	// QueryTransferOrders returns the orders matching the passed params, ordered by next run
+	QueryTransferOrders(params QueryTransferOrdersParams) ([]TransferOrderRecord, error)
+	// AddTransferOrderExecution records the outcome of an attempt to execute an order
+	AddTransferOrderExecution(record TransferOrderExecutionRecord) error
+	// QueryTransferOrderExecutions returns the outcomes of the attempts to execute the passed order, oldest first
+	QueryTransferOrderExecutions(orderID string) ([]TransferOrderExecutionRecord, error)
+}
+
 // TokenDB defines a database to store token related info
 type TokenDB interface {
 	CertificationDB
+	TransferOrderDB
 	// DeleteTokens marks the passsed tokens as deleted
 	DeleteTokens(deletedBy string, toDelete ...*token.ID) error
 	// IsMine return true if the passed token was stored before
@@ -191,6 +285,8 @@ type TokenDB interface {
 	NewTokenDBTransaction() (TokenDBTransaction, error)
 	// QueryTokenDetails provides detailed information about tokens
 	QueryTokenDetails(params QueryTokenDetailsParams) ([]TokenDetails, error)
+	// QueryTokenDetailsPage returns a page of the token details matching the passed params, ordered by token id and owner wallet
+	QueryTokenDetailsPage(params QueryTokenDetailsParams, pagination Pagination) (*Page[TokenDetails], error)
 	// Balance returns the sun of the amounts of the tokens with type and EID equal to those passed as arguments.
 	Balance(ownerEID string, typ token.Type) (uint64, error)
 	// SetSupportedTokenFormats sets the supported token formats
@@ -9,6 +9,7 @@ package driver
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
@@ -19,6 +20,7 @@ import (
 type TokenTransactionDB interface {
 	TransactionDB
 	TransactionEndorsementAckDB
+	ParkedTransactionDB
 }
 
 type AtomicWrite interface {
@@ -68,6 +70,13 @@ type TransactionDB interface {
 	// QueryMovements returns a list of movement records
 	QueryMovements(params QueryMovementsParams) ([]*MovementRecord, error)
 
+	// QueryTransactionsPage returns a page of the transactions that match the given criteria, ordered by time of storage
+	QueryTransactionsPage(params QueryTransactionsParams, pagination Pagination) (*Page[*TransactionRecord], error)
+
+	// QueryMovementsPage returns a page of the movement records that match the given criteria, ordered by time of storage
+	// following params.SearchDirection. params.NumRecords is ignored, the page size is given by the pagination
+	QueryMovementsPage(params QueryMovementsParams, pagination Pagination) (*Page[*MovementRecord], error)
+
 	// QueryValidations returns a list of validation  records
 	QueryValidations(params QueryValidationRecordsParams) (ValidationRecordsIterator, error)
 
@@ -87,6 +96,32 @@ type TransactionEndorsementAckDB interface {
 	GetTransactionEndorsementAcks(txID string) (map[string][]byte, error)
 }
 
+type ParkedTransactionDB interface {
+	// ParkTransaction stores the serialized transaction with the passed id, together with the stage it is parked at.
+	// It replaces any transaction already parked with the same id.
+	ParkTransaction(txID string, stage string, raw []byte) error
+
+	// GetParkedTransaction returns the serialized transaction parked with the passed id and its stage.
+	// It returns nil without error if no transaction is parked with the passed id.
+	GetParkedTransaction(txID string) (string, []byte, error)
+
+	// UnparkTransaction removes the transaction parked with the passed id, if any
+	UnparkTransaction(txID string) error
+
+	// QueryParkedTransactions returns the records of all the parked transactions, oldest first
+	QueryParkedTransactions() ([]ParkedTransactionRecord, error)
+}
+
+// ParkedTransactionRecord describes a parked transaction
+type ParkedTransactionRecord struct {
+	// TxID is the transaction id
+	TxID string
+	// Stage is the stage the transaction is parked at
+	Stage string
+	// StoredAt is the time the transaction was parked at its current stage
+	StoredAt time.Time
+}
+
 // TTXDBDriver is the interface for a token transaction db driver
 type TTXDBDriver interface {
 	// Open opens a token transaction database
@@ -32,7 +32,7 @@ type Manager[S any] struct{ lazy.Provider[token.TMSID, S] }
 func NewManager[S any](cp driver2.ConfigProvider, drivers map[driver.PersistenceType]sql.Opener[S], configKeys ...string) *Manager[S] {
 	return &Manager[S]{
 		Provider: lazy.NewProviderWithKeyMapper(key, func(tmsID token.TMSID) (S, error) {
-			opts, persistenceType, err := compileOpts(cp, tmsID, configKeys...)
+			opts, persistenceType, err := CompileOpts(cp, tmsID, configKeys...)
 			if err != nil {
 				return utils.Zero[S](), errors.Wrapf(err, "failed to compile opts")
 			}
@@ -47,7 +47,9 @@ func NewManager[S any](cp driver2.ConfigProvider, drivers map[driver.Persistence
 	}
 }
 
-func compileOpts(cp driver2.ConfigProvider, tmsID token.TMSID, keys ...string) (common2.Opts, driver.PersistenceType, error) {
+// CompileOpts returns the options of the database of the passed TMS, configured under the first of the passed keys that is set.
+// It defaults to memory.
+func CompileOpts(cp driver2.ConfigProvider, tmsID token.TMSID, keys ...string) (common2.Opts, driver.PersistenceType, error) {
 	tmsConfig, err := config2.NewService(cp).ConfigurationFor(tmsID.Network, tmsID.Channel, tmsID.Namespace)
 	if err != nil {
 		return common2.Opts{}, "", errors.WithMessagef(err, "failed to load configuration for tms [%s]", tmsID)
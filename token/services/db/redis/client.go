@@ -0,0 +1,228 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Error is an error reply of the server
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// client is a minimal client of the RESP2 protocol spoken by Redis and Valkey, with a pool of connections
+type client struct {
+	addr     string
+	username string
+	password string
+	db       int
+	timeout  time.Duration
+	idle     chan *conn
+}
+
+func newClient(cfg *Config) *client {
+	return &client{
+		addr:     cfg.Addr,
+		username: cfg.Username,
+		password: cfg.Password,
+		db:       cfg.DB,
+		timeout:  cfg.Timeout,
+		idle:     make(chan *conn, cfg.PoolSize),
+	}
+}
+
+// do runs a command on a connection of the pool
+func (c *client) do(args ...string) (any, error) {
+	var reply any
+	err := c.use(func(cn *conn) error {
+		var err error
+		reply, err = cn.do(args...)
+		return err
+	}, true)
+	return reply, err
+}
+
+// withConn runs the passed function on a connection of the pool, for commands that need the same connection, like transactions.
+// If the function fails, the connection is closed, since it might be left in a transaction.
+func (c *client) withConn(f func(cn *conn) error) error {
+	return c.use(f, false)
+}
+
+func (c *client) use(f func(cn *conn) error, keepOnServerError bool) error {
+	var cn *conn
+	select {
+	case cn = <-c.idle:
+	default:
+		var err error
+		if cn, err = c.dial(); err != nil {
+			return err
+		}
+	}
+	err := f(cn)
+	var serverErr Error
+	if err != nil && (!keepOnServerError || !errors.As(err, &serverErr)) {
+		cn.close()
+		return err
+	}
+	select {
+	case c.idle <- cn:
+	default:
+		cn.close()
+	}
+	return err
+}
+
+func (c *client) dial() (*conn, error) {
+	nc, err := net.DialTimeout("tcp", c.addr, c.timeout)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed connecting to [%s]", c.addr)
+	}
+	cn := &conn{c: nc, r: bufio.NewReader(nc), w: bufio.NewWriter(nc), timeout: c.timeout}
+	if len(c.password) != 0 {
+		args := []string{"AUTH", c.password}
+		if len(c.username) != 0 {
+			args = []string{"AUTH", c.username, c.password}
+		}
+		if _, err := cn.do(args...); err != nil {
+			cn.close()
+			return nil, errors.WithMessagef(err, "failed authenticating to [%s]", c.addr)
+		}
+	}
+	if c.db != 0 {
+		if _, err := cn.do("SELECT", strconv.Itoa(c.db)); err != nil {
+			cn.close()
+			return nil, errors.WithMessagef(err, "failed selecting db [%d]", c.db)
+		}
+	}
+	return cn, nil
+}
+
+func (c *client) close() {
+	for {
+		select {
+		case cn := <-c.idle:
+			cn.close()
+		default:
+			return
+		}
+	}
+}
+
+type conn struct {
+	c       net.Conn
+	r       *bufio.Reader
+	w       *bufio.Writer
+	timeout time.Duration
+}
+
+// do sends a command and reads its reply.
+// A reply is either a string, an int64, a []byte (nil if null), or a []any (nil if null).
+// An error reply is returned as an Error.
+func (cn *conn) do(args ...string) (any, error) {
+	if cn.timeout > 0 {
+		if err := cn.c.SetDeadline(time.Now().Add(cn.timeout)); err != nil {
+			return nil, errors.Wrapf(err, "failed setting deadline")
+		}
+	}
+	if err := writeCommand(cn.w, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed sending [%s]", args[0])
+	}
+	if err := cn.w.Flush(); err != nil {
+		return nil, errors.Wrapf(err, "failed sending [%s]", args[0])
+	}
+	reply, err := readReply(cn.r)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed reading reply to [%s]", args[0])
+	}
+	if e, ok := reply.(Error); ok {
+		return nil, e
+	}
+	return reply, nil
+}
+
+func (cn *conn) close() {
+	if err := cn.c.Close(); err != nil {
+		logger.Debugf("failed closing connection: %s", err)
+	}
+}
+
+// writeCommand writes the passed command as an array of bulk strings
+func writeCommand(w *bufio.Writer, args ...string) error {
+	if _, err := fmt.Fprintf(w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+	for _, arg := range args {
+		if _, err := fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readReply reads a RESP2 value
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, errors.Errorf("invalid line [%q]", line)
+	}
+	kind, payload := line[0], line[1:len(line)-2]
+	switch kind {
+	case '+':
+		return payload, nil
+	case '-':
+		return Error(payload), nil
+	case ':':
+		n, err := strconv.ParseInt(payload, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid integer [%s]", payload)
+		}
+		return n, nil
+	case '$':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid bulk length [%s]", payload)
+		}
+		if n < 0 {
+			return []byte(nil), nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid array length [%s]", payload)
+		}
+		if n < 0 {
+			return []any(nil), nil
+		}
+		values := make([]any, n)
+		for i := range values {
+			if values[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, errors.Errorf("unknown reply type [%c]", kind)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redis
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	driver2 "github.com/hyperledger-labs/fabric-smart-client/platform/common/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	common2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Persistence is the persistence type of the key-value stores speaking the Redis protocol, like Redis and Valkey
+	Persistence driver2.PersistenceType = "redis"
+
+	defaultLease    = 3 * time.Minute
+	defaultTimeout  = 5 * time.Second
+	defaultPoolSize = 10
+)
+
+// ErrNotSupported is returned when opening a store other than the token lock store
+var ErrNotSupported = errors.New("the redis persistence supports only the token lock store")
+
+// Config is the configuration of the connection to the server, parsed from a data source of the form
+// redis://[[username]:password@]host:port[/db][?lease=3m&timeout=5s]
+type Config struct {
+	Addr     string
+	Username string
+	Password string
+	DB       int
+	// Lease is the time to live of a lock. If zero, the lease expiry of the selector is used
+	Lease time.Duration
+	// Timeout bounds the time to connect and to run a command
+	Timeout time.Duration
+	// PoolSize is the maximum number of idle connections
+	PoolSize int
+	// Prefix is prepended to all the keys
+	Prefix string
+}
+
+// ParseConfig returns the configuration for the passed options
+func ParseConfig(opts common2.Opts) (*Config, error) {
+	u, err := url.Parse(opts.DataSource)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid data source")
+	}
+	if u.Scheme != "redis" && u.Scheme != "valkey" {
+		return nil, errors.Errorf("invalid data source scheme [%s], expected redis or valkey", u.Scheme)
+	}
+	if len(u.Host) == 0 {
+		return nil, errors.New("invalid data source, missing host")
+	}
+	cfg := &Config{
+		Addr:     u.Host,
+		Timeout:  defaultTimeout,
+		PoolSize: defaultPoolSize,
+		Prefix:   opts.TablePrefix,
+	}
+	if len(u.Port()) == 0 {
+		cfg.Addr = u.Host + ":6379"
+	}
+	if u.User != nil {
+		cfg.Username = u.User.Username()
+		cfg.Password, _ = u.User.Password()
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); len(db) != 0 {
+		if cfg.DB, err = strconv.Atoi(db); err != nil {
+			return nil, errors.Wrapf(err, "invalid db [%s]", db)
+		}
+	}
+	query := u.Query()
+	if lease := query.Get("lease"); len(lease) != 0 {
+		if cfg.Lease, err = time.ParseDuration(lease); err != nil || cfg.Lease <= 0 {
+			return nil, errors.Errorf("invalid lease [%s]", lease)
+		}
+	}
+	if timeout := query.Get("timeout"); len(timeout) != 0 {
+		if cfg.Timeout, err = time.ParseDuration(timeout); err != nil {
+			return nil, errors.Wrapf(err, "invalid timeout [%s]", timeout)
+		}
+	}
+	if opts.MaxOpenConns > 0 {
+		cfg.PoolSize = opts.MaxOpenConns
+	}
+	return cfg, nil
+}
+
+type Driver struct{}
+
+// NewDriver returns the driver of the redis persistence, which supports only the token lock store
+func NewDriver() driver.NamedDriver {
+	return driver.NamedDriver{
+		Name:   Persistence,
+		Driver: &Driver{},
+	}
+}
+
+func (d *Driver) NewTokenLock(opts common2.Opts) (driver.TokenLockDB, error) {
+	cfg, err := ParseConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenLockDB(cfg)
+}
+
+func (d *Driver) NewWallet(common2.Opts) (driver.WalletDB, error) {
+	return nil, ErrNotSupported
+}
+
+func (d *Driver) NewIdentity(common2.Opts) (driver.IdentityDB, error) {
+	return nil, ErrNotSupported
+}
+
+func (d *Driver) NewToken(common2.Opts) (driver.TokenDB, error) {
+	return nil, ErrNotSupported
+}
+
+func (d *Driver) NewTokenNotifier(common2.Opts) (driver.TokenNotifier, error) {
+	return nil, ErrNotSupported
+}
+
+func (d *Driver) NewAuditTransaction(common2.Opts) (driver.AuditTransactionDB, error) {
+	return nil, ErrNotSupported
+}
+
+func (d *Driver) NewOwnerTransaction(common2.Opts) (driver.TokenTransactionDB, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,614 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package redistest provides an in-process stand-in of a Redis server, for testing.
+// It speaks RESP2 and supports the subset of commands used by the token lock store: strings, sets,
+// expiration, and optimistic transactions. All the data lives in memory, in a single database.
+package redistest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type status string
+
+type errReply string
+
+type nullArray struct{}
+
+var ok = status("OK")
+
+type entry struct {
+	value    []byte
+	set      map[string]struct{}
+	expireAt time.Time
+}
+
+// Server is an in-process stand-in of a Redis server
+type Server struct {
+	listener net.Listener
+	password string
+
+	mutex    sync.Mutex
+	offset   time.Duration
+	entries  map[string]*entry
+	versions map[string]uint64
+	conns    map[net.Conn]struct{}
+	wg       sync.WaitGroup
+}
+
+// NewServer starts a server on a random local port
+func NewServer() (*Server, error) {
+	return NewServerWithPassword("")
+}
+
+// NewServerWithPassword starts a server on a random local port that requires the passed password, if not empty
+func NewServerWithPassword(password string) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed listening")
+	}
+	s := &Server{
+		listener: listener,
+		password: password,
+		entries:  map[string]*entry{},
+		versions: map[string]uint64{},
+		conns:    map[net.Conn]struct{}{},
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Addr returns the address the server listens to
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// DataSource returns a data source pointing to the server
+func (s *Server) DataSource() string {
+	if len(s.password) != 0 {
+		return fmt.Sprintf("redis://:%s@%s", s.password, s.Addr())
+	}
+	return "redis://" + s.Addr()
+}
+
+// FastForward moves the clock of the server forward, expiring the keys whose time to live is over
+func (s *Server) FastForward(d time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.offset += d
+}
+
+// Keys returns the sorted keys that are not expired
+func (s *Server) Keys() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var keys []string
+	for key := range s.entries {
+		if s.lookup(key) != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Close stops the server and closes the open connections
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.mutex.Lock()
+	for c := range s.conns {
+		_ = c.Close()
+	}
+	s.mutex.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mutex.Lock()
+		s.conns[c] = struct{}{}
+		s.mutex.Unlock()
+		s.wg.Add(1)
+		go s.handle(c)
+	}
+}
+
+// session is the state of a connection
+type session struct {
+	authenticated bool
+	watched       map[string]uint64
+	multi         bool
+	queued        [][]string
+	aborted       bool
+}
+
+func (s *Server) handle(c net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mutex.Lock()
+		delete(s.conns, c)
+		s.mutex.Unlock()
+		_ = c.Close()
+	}()
+	r := bufio.NewReader(c)
+	w := bufio.NewWriter(c)
+	sess := &session{authenticated: len(s.password) == 0}
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			if err != io.EOF {
+				writeReply(w, errReply("ERR Protocol error: "+err.Error()))
+				_ = w.Flush()
+			}
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		reply := s.dispatch(sess, args)
+		writeReply(w, reply)
+		if err := w.Flush(); err != nil {
+			return
+		}
+		if strings.ToUpper(args[0]) == "QUIT" {
+			return
+		}
+	}
+}
+
+func (s *Server) dispatch(sess *session, args []string) any {
+	name := strings.ToUpper(args[0])
+	switch name {
+	case "AUTH":
+		if len(args) < 2 || len(args) > 3 {
+			return wrongArgs(name)
+		}
+		if args[len(args)-1] != s.password || len(s.password) == 0 {
+			return errReply("WRONGPASS invalid username-password pair or user is disabled.")
+		}
+		sess.authenticated = true
+		return ok
+	case "QUIT":
+		return ok
+	}
+	if !sess.authenticated {
+		return errReply("NOAUTH Authentication required.")
+	}
+
+	switch name {
+	case "MULTI":
+		if sess.multi {
+			return errReply("ERR MULTI calls can not be nested")
+		}
+		sess.multi = true
+		return ok
+	case "DISCARD":
+		if !sess.multi {
+			return errReply("ERR DISCARD without MULTI")
+		}
+		sess.reset()
+		return ok
+	case "EXEC":
+		if !sess.multi {
+			return errReply("ERR EXEC without MULTI")
+		}
+		return s.exec(sess)
+	case "WATCH":
+		if sess.multi {
+			return errReply("ERR WATCH inside MULTI is not allowed")
+		}
+		if len(args) < 2 {
+			return wrongArgs(name)
+		}
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if sess.watched == nil {
+			sess.watched = map[string]uint64{}
+		}
+		for _, key := range args[1:] {
+			s.lookup(key)
+			sess.watched[key] = s.versions[key]
+		}
+		return ok
+	case "UNWATCH":
+		sess.watched = nil
+		return ok
+	}
+
+	if sess.multi {
+		if _, known := commands[name]; !known {
+			sess.aborted = true
+			return errReply(fmt.Sprintf("ERR unknown command '%s'", args[0]))
+		}
+		sess.queued = append(sess.queued, args)
+		return status("QUEUED")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.run(args)
+}
+
+func (s *Server) exec(sess *session) any {
+	defer sess.reset()
+	if sess.aborted {
+		return errReply("EXECABORT Transaction discarded because of previous errors.")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for key, version := range sess.watched {
+		s.lookup(key)
+		if s.versions[key] != version {
+			return nullArray{}
+		}
+	}
+	replies := make([]any, len(sess.queued))
+	for i, args := range sess.queued {
+		replies[i] = s.run(args)
+	}
+	return replies
+}
+
+func (sess *session) reset() {
+	sess.multi = false
+	sess.queued = nil
+	sess.aborted = false
+	sess.watched = nil
+}
+
+var commands = map[string]func(s *Server, args []string) any{
+	"PING":     (*Server).ping,
+	"SELECT":   (*Server).selectDB,
+	"GET":      (*Server).get,
+	"SET":      (*Server).set,
+	"DEL":      (*Server).del,
+	"EXISTS":   (*Server).exists,
+	"SADD":     (*Server).sadd,
+	"SREM":     (*Server).srem,
+	"SMEMBERS": (*Server).smembers,
+	"PEXPIRE":  (*Server).pexpire,
+	"PTTL":     (*Server).pttl,
+}
+
+// run executes a command, the mutex must be held
+func (s *Server) run(args []string) any {
+	name := strings.ToUpper(args[0])
+	command, known := commands[name]
+	if !known {
+		return errReply(fmt.Sprintf("ERR unknown command '%s'", args[0]))
+	}
+	return command(s, args)
+}
+
+func (s *Server) now() time.Time {
+	return time.Now().Add(s.offset)
+}
+
+// lookup returns the entry of the passed key, removing it if expired
+func (s *Server) lookup(key string) *entry {
+	e, found := s.entries[key]
+	if !found {
+		return nil
+	}
+	if !e.expireAt.IsZero() && !s.now().Before(e.expireAt) {
+		s.remove(key)
+		return nil
+	}
+	return e
+}
+
+func (s *Server) remove(key string) {
+	delete(s.entries, key)
+	s.touch(key)
+}
+
+func (s *Server) touch(key string) {
+	s.versions[key]++
+}
+
+func (s *Server) ping(args []string) any {
+	if len(args) > 1 {
+		return []byte(args[1])
+	}
+	return status("PONG")
+}
+
+func (s *Server) selectDB(args []string) any {
+	if len(args) != 2 {
+		return wrongArgs(args[0])
+	}
+	if _, err := strconv.Atoi(args[1]); err != nil {
+		return errReply("ERR value is not an integer or out of range")
+	}
+	return ok
+}
+
+func (s *Server) get(args []string) any {
+	if len(args) != 2 {
+		return wrongArgs(args[0])
+	}
+	e := s.lookup(args[1])
+	if e == nil {
+		return []byte(nil)
+	}
+	if e.set != nil {
+		return wrongType()
+	}
+	return e.value
+}
+
+func (s *Server) set(args []string) any {
+	if len(args) < 3 {
+		return wrongArgs(args[0])
+	}
+	key, value := args[1], args[2]
+	var nx, xx bool
+	var ttl time.Duration
+	for i := 3; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "NX":
+			nx = true
+		case "XX":
+			xx = true
+		case "PX", "EX":
+			if i+1 == len(args) {
+				return errReply("ERR syntax error")
+			}
+			n, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil || n <= 0 {
+				return errReply("ERR invalid expire time in 'set' command")
+			}
+			ttl = time.Duration(n) * time.Millisecond
+			if strings.ToUpper(args[i]) == "EX" {
+				ttl = time.Duration(n) * time.Second
+			}
+			i++
+		default:
+			return errReply("ERR syntax error")
+		}
+	}
+	exists := s.lookup(key) != nil
+	if nx && exists || xx && !exists {
+		return []byte(nil)
+	}
+	e := &entry{value: []byte(value)}
+	if ttl > 0 {
+		e.expireAt = s.now().Add(ttl)
+	}
+	s.entries[key] = e
+	s.touch(key)
+	return ok
+}
+
+func (s *Server) del(args []string) any {
+	if len(args) < 2 {
+		return wrongArgs(args[0])
+	}
+	var n int64
+	for _, key := range args[1:] {
+		if s.lookup(key) != nil {
+			s.remove(key)
+			n++
+		}
+	}
+	return n
+}
+
+func (s *Server) exists(args []string) any {
+	if len(args) < 2 {
+		return wrongArgs(args[0])
+	}
+	var n int64
+	for _, key := range args[1:] {
+		if s.lookup(key) != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *Server) sadd(args []string) any {
+	if len(args) < 3 {
+		return wrongArgs(args[0])
+	}
+	key := args[1]
+	e := s.lookup(key)
+	if e == nil {
+		e = &entry{set: map[string]struct{}{}}
+		s.entries[key] = e
+	}
+	if e.set == nil {
+		return wrongType()
+	}
+	var n int64
+	for _, member := range args[2:] {
+		if _, found := e.set[member]; !found {
+			e.set[member] = struct{}{}
+			n++
+		}
+	}
+	s.touch(key)
+	return n
+}
+
+func (s *Server) srem(args []string) any {
+	if len(args) < 3 {
+		return wrongArgs(args[0])
+	}
+	key := args[1]
+	e := s.lookup(key)
+	if e == nil {
+		return int64(0)
+	}
+	if e.set == nil {
+		return wrongType()
+	}
+	var n int64
+	for _, member := range args[2:] {
+		if _, found := e.set[member]; found {
+			delete(e.set, member)
+			n++
+		}
+	}
+	if len(e.set) == 0 {
+		s.remove(key)
+	} else {
+		s.touch(key)
+	}
+	return n
+}
+
+func (s *Server) smembers(args []string) any {
+	if len(args) != 2 {
+		return wrongArgs(args[0])
+	}
+	e := s.lookup(args[1])
+	if e == nil {
+		return []any{}
+	}
+	if e.set == nil {
+		return wrongType()
+	}
+	members := make([]string, 0, len(e.set))
+	for member := range e.set {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	replies := make([]any, len(members))
+	for i, member := range members {
+		replies[i] = []byte(member)
+	}
+	return replies
+}
+
+func (s *Server) pexpire(args []string) any {
+	if len(args) != 3 {
+		return wrongArgs(args[0])
+	}
+	n, err := strconv.ParseInt(args[2], 10, 64)
+	if err != nil {
+		return errReply("ERR value is not an integer or out of range")
+	}
+	e := s.lookup(args[1])
+	if e == nil {
+		return int64(0)
+	}
+	if n <= 0 {
+		s.remove(args[1])
+		return int64(1)
+	}
+	e.expireAt = s.now().Add(time.Duration(n) * time.Millisecond)
+	s.touch(args[1])
+	return int64(1)
+}
+
+func (s *Server) pttl(args []string) any {
+	if len(args) != 2 {
+		return wrongArgs(args[0])
+	}
+	e := s.lookup(args[1])
+	if e == nil {
+		return int64(-2)
+	}
+	if e.expireAt.IsZero() {
+		return int64(-1)
+	}
+	return e.expireAt.Sub(s.now()).Milliseconds()
+}
+
+func wrongArgs(name string) errReply {
+	return errReply(fmt.Sprintf("ERR wrong number of arguments for '%s' command", strings.ToLower(name)))
+}
+
+func wrongType() errReply {
+	return errReply("WRONGTYPE Operation against a key holding the wrong kind of value")
+}
+
+// readCommand reads a command sent as an array of bulk strings
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '*' {
+		return nil, errors.Errorf("expected array, got [%q]", line)
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n < 0 {
+		return nil, errors.Errorf("invalid array length [%s]", line[1:])
+	}
+	args := make([]string, n)
+	for i := range args {
+		line, err := readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 || line[0] != '$' {
+			return nil, errors.Errorf("expected bulk string, got [%q]", line)
+		}
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 {
+			return nil, errors.Errorf("invalid bulk length [%s]", line[1:])
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil
+}
+
+func writeReply(w *bufio.Writer, reply any) {
+	switch v := reply.(type) {
+	case status:
+		fmt.Fprintf(w, "+%s\r\n", v)
+	case errReply:
+		fmt.Fprintf(w, "-%s\r\n", v)
+	case int64:
+		fmt.Fprintf(w, ":%d\r\n", v)
+	case []byte:
+		if v == nil {
+			w.WriteString("$-1\r\n")
+			return
+		}
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(v), v)
+	case nullArray:
+		w.WriteString("*-1\r\n")
+	case []any:
+		fmt.Fprintf(w, "*%d\r\n", len(v))
+		for _, item := range v {
+			writeReply(w, item)
+		}
+	default:
+		panic(fmt.Sprintf("unexpected reply type %T", reply))
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/types/transaction"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.MustGetLogger("token-sdk.db.redis")
+
+// TokenLockDB stores the token locks in a key-value store speaking the Redis protocol.
+// Each lock is a key, holding the consumer transaction id, that expires natively when its lease ends.
+// The keys of the locks of a consumer transaction are tracked in a set, so that they can be released together.
+type TokenLockDB struct {
+	client *client
+	prefix string
+
+	mutex      sync.RWMutex
+	lease      time.Duration
+	fixedLease bool
+}
+
+// NewTokenLockDB returns a TokenLockDB connected to the server of the passed configuration
+func NewTokenLockDB(cfg *Config) (*TokenLockDB, error) {
+	db := &TokenLockDB{
+		client:     newClient(cfg),
+		prefix:     cfg.Prefix,
+		lease:      cfg.Lease,
+		fixedLease: cfg.Lease > 0,
+	}
+	if !db.fixedLease {
+		db.lease = defaultLease
+	}
+	if _, err := db.client.do("PING"); err != nil {
+		db.client.close()
+		return nil, errors.WithMessagef(err, "failed reaching [%s]", cfg.Addr)
+	}
+	return db, nil
+}
+
+func (db *TokenLockDB) Lock(tokenID *token.ID, consumerTxID transaction.ID) error {
+	lockKey := db.lockKey(tokenID)
+	lease := strconv.FormatInt(db.getLease().Milliseconds(), 10)
+	reply, err := db.client.do("SET", lockKey, consumerTxID, "NX", "PX", lease)
+	if err != nil {
+		return errors.WithMessagef(err, "failed locking [%s]", tokenID)
+	}
+	if reply != "OK" {
+		return errors.Errorf("token [%s] already locked", tokenID)
+	}
+	// the set of the locks of the consumer lives at least as long as its locks
+	txKey := db.txKey(consumerTxID)
+	err = db.client.withConn(func(cn *conn) error {
+		for _, args := range [][]string{{"MULTI"}, {"SADD", txKey, lockKey}, {"PEXPIRE", txKey, lease}} {
+			if _, err := cn.do(args...); err != nil {
+				return err
+			}
+		}
+		_, err := cn.do("EXEC")
+		return err
+	})
+	if err != nil {
+		if _, err2 := db.client.do("DEL", lockKey); err2 != nil {
+			logger.Warnf("failed releasing lock [%s]: [%s]", lockKey, err2)
+		}
+		return errors.WithMessagef(err, "failed tracking lock [%s] of [%s]", tokenID, consumerTxID)
+	}
+	logger.Debugf("locked [%s] for [%s]", tokenID, consumerTxID)
+	return nil
+}
+
+func (db *TokenLockDB) UnlockByTxID(consumerTxID transaction.ID) error {
+	txKey := db.txKey(consumerTxID)
+	reply, err := db.client.do("SMEMBERS", txKey)
+	if err != nil {
+		return errors.WithMessagef(err, "failed getting locks of [%s]", consumerTxID)
+	}
+	members, _ := reply.([]any)
+	for _, member := range members {
+		lockKey, _ := member.([]byte)
+		if err := db.release(string(lockKey), consumerTxID); err != nil {
+			return errors.WithMessagef(err, "failed releasing lock [%s] of [%s]", lockKey, consumerTxID)
+		}
+	}
+	if _, err := db.client.do("DEL", txKey); err != nil {
+		return errors.WithMessagef(err, "failed deleting locks of [%s]", consumerTxID)
+	}
+	return nil
+}
+
+// release deletes the passed lock if still held by the passed consumer.
+// The lock might have expired and been taken by another consumer in the meantime.
+func (db *TokenLockDB) release(lockKey string, consumerTxID transaction.ID) error {
+	return db.client.withConn(func(cn *conn) error {
+		if _, err := cn.do("WATCH", lockKey); err != nil {
+			return err
+		}
+		holder, err := cn.do("GET", lockKey)
+		if err != nil {
+			return err
+		}
+		if h, _ := holder.([]byte); string(h) != consumerTxID {
+			_, err := cn.do("UNWATCH")
+			return err
+		}
+		if _, err := cn.do("MULTI"); err != nil {
+			return err
+		}
+		if _, err := cn.do("DEL", lockKey); err != nil {
+			return err
+		}
+		// a nil reply means that the lock changed after the WATCH, therefore it is not ours anymore
+		_, err = cn.do("EXEC")
+		return err
+	})
+}
+
+// Cleanup does not need to remove expired locks, they expire natively.
+// If no lease is configured, the passed lease expiry becomes the lease of the next locks.
+func (db *TokenLockDB) Cleanup(leaseExpiry time.Duration) error {
+	if leaseExpiry <= 0 {
+		return nil
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if !db.fixedLease && db.lease != leaseExpiry {
+		logger.Debugf("lease of token locks set to [%s]", leaseExpiry)
+		db.lease = leaseExpiry
+	}
+	return nil
+}
+
+func (db *TokenLockDB) Close() error {
+	logger.Info("closing database")
+	db.client.close()
+	return nil
+}
+
+func (db *TokenLockDB) getLease() time.Duration {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+	return db.lease
+}
+
+func (db *TokenLockDB) lockKey(tokenID *token.ID) string {
+	return fmt.Sprintf("%s:lock:%s:%d", db.prefix, tokenID.TxId, tokenID.Index)
+}
+
+func (db *TokenLockDB) txKey(consumerTxID transaction.ID) string {
+	return fmt.Sprintf("%s:tx:%s", db.prefix, consumerTxID)
+}
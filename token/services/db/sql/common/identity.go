@@ -89,10 +89,8 @@ func NewIdentityDB(readDB, writeDB *sql.DB, tablePrefix string, createSchema boo
 		auditInfoCache,
 		ci,
 	)
-	if createSchema {
-		if err = common.InitSchema(writeDB, []string{identityDB.GetSchema()}...); err != nil {
-			return nil, err
-		}
+	if err = InitSchema(writeDB, tablePrefix, createSchema, IdentityStore, tables.IdentityConfigurations, identityDB.GetSchema()); err != nil {
+		return nil, err
 	}
 	return identityDB, nil
 }
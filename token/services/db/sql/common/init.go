@@ -19,6 +19,7 @@ type tableNames struct {
 	Requests               string
 	Validations            string
 	TransactionEndorseAck  string
+	ParkedTransactions     string
 	Certifications         string
 	Tokens                 string
 	Ownership              string
@@ -28,6 +29,9 @@ type tableNames struct {
 	IdentityInfo           string
 	Signers                string
 	TokenLocks             string
+	TransferOrders         string
+	TransferOrderRuns      string
+	SchemaVersions         string
 }
 
 func GetTableNames(prefix string) (tableNames, error) {
@@ -40,16 +44,20 @@ func GetTableNames(prefix string) (tableNames, error) {
 		Movements:              nc.MustGetTableName("movements"),
 		Transactions:           nc.MustGetTableName("transactions"),
 		TransactionEndorseAck:  nc.MustGetTableName("transaction_endorsements"),
+		ParkedTransactions:     nc.MustGetTableName("parked_transactions"),
 		Requests:               nc.MustGetTableName("requests"),
 		Validations:            nc.MustGetTableName("request_validations"),
 		Tokens:                 nc.MustGetTableName("tokens"),
 		Ownership:              nc.MustGetTableName("token_ownership"),
 		Certifications:         nc.MustGetTableName("token_certifications"),
 		TokenLocks:             nc.MustGetTableName("token_locks"),
+		TransferOrders:         nc.MustGetTableName("transfer_orders"),
+		TransferOrderRuns:      nc.MustGetTableName("transfer_order_executions"),
 		PublicParams:           nc.MustGetTableName("public_params"),
 		Wallets:                nc.MustGetTableName("wallets"),
 		IdentityConfigurations: nc.MustGetTableName("identity_configurations"),
 		IdentityInfo:           nc.MustGetTableName("identity_information"),
 		Signers:                nc.MustGetTableName("identity_signers"),
+		SchemaVersions:         nc.MustGetTableName("schema_versions"),
 	}, nil
 }
@@ -24,6 +24,7 @@ func TestGetTableNames(t *testing.T) {
 		Requests:               "requests",
 		Validations:            "request_validations",
 		TransactionEndorseAck:  "transaction_endorsements",
+		ParkedTransactions:     "parked_transactions",
 		Certifications:         "token_certifications",
 		Tokens:                 "tokens",
 		Ownership:              "token_ownership",
@@ -33,6 +34,9 @@ func TestGetTableNames(t *testing.T) {
 		IdentityInfo:           "identity_information",
 		Signers:                "identity_signers",
 		TokenLocks:             "token_locks",
+		TransferOrders:         "transfer_orders",
+		TransferOrderRuns:      "transfer_order_executions",
+		SchemaVersions:         "schema_versions",
 	}, names)
 
 	names, err = GetTableNames("valid_prefix")
@@ -0,0 +1,411 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"database/sql"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Dialect is the SQL dialect of a database
+type Dialect string
+
+const (
+	SQLite   Dialect = "sqlite"
+	Postgres Dialect = "postgres"
+)
+
+// Store names, as recorded in the schema versions table
+const (
+	TokensStore       = "tokens"
+	TransactionsStore = "transactions"
+	IdentityStore     = "identity"
+	WalletsStore      = "wallets"
+	TokenLocksStore   = "token_locks"
+)
+
+var (
+	// ErrNewerSchema is returned, wrapped, when a database has a schema version this SDK does not know
+	ErrNewerSchema = errors.New("database schema is newer than supported")
+	// ErrPendingMigrations is returned, wrapped, when a database needs migrations that the SDK is not allowed to apply
+	ErrPendingMigrations = errors.New("database schema needs migrations")
+)
+
+// Migration upgrades the schema of a store from Version-1 to Version
+type Migration struct {
+	Version     int
+	Description string
+	Steps       []MigrationStep
+}
+
+// MigrationStep is a step of a migration
+type MigrationStep interface {
+	// Statements returns the statements that apply the step in the passed dialect.
+	// It returns no statement if the step is already applied.
+	Statements(q Querier, dialect Dialect) ([]string, error)
+}
+
+// Querier runs read queries
+type Querier interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// Exec is a step running the given statements, one per dialect
+type Exec struct {
+	SQLite   string
+	Postgres string
+}
+
+func (e Exec) Statements(_ Querier, dialect Dialect) ([]string, error) {
+	switch dialect {
+	case SQLite:
+		return []string{e.SQLite}, nil
+	case Postgres:
+		return []string{e.Postgres}, nil
+	default:
+		return nil, errors.Errorf("unknown dialect [%s]", dialect)
+	}
+}
+
+// AddColumn is a step adding a column to a table, unless the column already exists
+type AddColumn struct {
+	Table      string
+	Column     string
+	Definition string
+}
+
+func (a AddColumn) Statements(q Querier, dialect Dialect) ([]string, error) {
+	exists, err := columnExists(q, dialect, a.Table, a.Column)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, nil
+	}
+	return []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", a.Table, a.Column, a.Definition)}, nil
+}
+
+// MigrationReport describes the schema version of a store and the migrations that bring it to the latest version
+type MigrationReport struct {
+	Store string
+	// Versioned is false for databases created before the introduction of schema versions
+	Versioned bool
+	// Current is the version of the schema, zero if not versioned
+	Current int
+	// Target is the latest version known to this SDK
+	Target  int
+	Pending []PendingMigration
+}
+
+// PendingMigration is a migration not applied yet, with the statements that would apply it
+type PendingMigration struct {
+	Version     int
+	Description string
+	Statements  []string
+}
+
+func (r *MigrationReport) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("store [%s]: version [%d], target [%d], versioned [%v]", r.Store, r.Current, r.Target, r.Versioned))
+	for _, m := range r.Pending {
+		sb.WriteString(fmt.Sprintf("\n  [%d] %s", m.Version, m.Description))
+		for _, s := range m.Statements {
+			sb.WriteString("\n    " + s)
+		}
+	}
+	return sb.String()
+}
+
+// Migrator brings the schema of a store to the latest version.
+// The versions applied are recorded, per store, in the schema versions table of the table prefix.
+// A database created from scratch gets the latest schema directly and is recorded at the latest version.
+// A database created before the introduction of schema versions has all the migrations applied,
+// therefore these tolerate finding their changes already in place.
+type Migrator struct {
+	db           *sql.DB
+	store        string
+	versionTable string
+	mainTable    string
+	schema       string
+	migrations   []Migration
+}
+
+// NewMigrator returns a Migrator for the passed store.
+// mainTable is a table of the store used to tell new databases from unversioned ones,
+// schema creates the latest version of the store's tables and must be idempotent,
+// migrations are ordered and numbered from 1.
+func NewMigrator(db *sql.DB, tablePrefix, store, mainTable, schema string, migrations []Migration) (*Migrator, error) {
+	tables, err := GetTableNames(tablePrefix)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get table names")
+	}
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, errors.Errorf("migration [%s] of store [%s] has version [%d], expected [%d]", m.Description, store, m.Version, i+1)
+		}
+	}
+	return &Migrator{
+		db:           db,
+		store:        store,
+		versionTable: tables.SchemaVersions,
+		mainTable:    mainTable,
+		schema:       schema,
+		migrations:   migrations,
+	}, nil
+}
+
+// Target returns the latest schema version known to this SDK
+func (m *Migrator) Target() int {
+	return len(m.migrations)
+}
+
+// Report returns the migrations that Migrate would apply, without touching the database (dry run).
+// It returns an error wrapping ErrNewerSchema if the database has a schema newer than the SDK.
+func (m *Migrator) Report() (*MigrationReport, error) {
+	return m.report(m.db, DetectDialect(m.db))
+}
+
+func (m *Migrator) report(q Querier, dialect Dialect) (*MigrationReport, error) {
+	report := &MigrationReport{Store: m.store, Target: m.Target()}
+	exists, err := tableExists(q, dialect, m.versionTable)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		report.Current, report.Versioned, err = m.currentVersion(q)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if report.Current > report.Target {
+		return nil, errors.Wrapf(ErrNewerSchema, "store [%s] has version [%d], latest supported is [%d]", m.store, report.Current, report.Target)
+	}
+	if !report.Versioned {
+		mainExists, err := tableExists(q, dialect, m.mainTable)
+		if err != nil {
+			return nil, err
+		}
+		if !mainExists {
+			// a new database, the schema is created at the latest version
+			return report, nil
+		}
+	}
+	for _, migration := range m.migrations[report.Current:] {
+		pending := PendingMigration{Version: migration.Version, Description: migration.Description}
+		for _, step := range migration.Steps {
+			statements, err := step.Statements(q, dialect)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "failed preparing migration [%d] of store [%s]", migration.Version, m.store)
+			}
+			pending.Statements = append(pending.Statements, statements...)
+		}
+		report.Pending = append(report.Pending, pending)
+	}
+	return report, nil
+}
+
+// Migrate applies the pending migrations and creates the missing tables, in a single transaction.
+// On postgres, the transaction holds an advisory lock, then replicas starting together apply the migrations once.
+// It returns the report of what was applied.
+func (m *Migrator) Migrate() (report *MigrationReport, err error) {
+	dialect := DetectDialect(m.db)
+	tx, err := m.db.Begin()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed starting a db transaction")
+	}
+	defer func() {
+		if err != nil {
+			if err1 := tx.Rollback(); err1 != nil {
+				logger.Errorf("failed to rollback [%s][%s]", err1, debug.Stack())
+			}
+		}
+	}()
+	if dialect == Postgres {
+		// released at the end of the transaction
+		query := "SELECT pg_advisory_xact_lock(hashtext($1));"
+		logger.Debug(query, m.versionTable)
+		if _, err = tx.Exec(query, m.versionTable); err != nil {
+			return nil, errors.Wrapf(err, "failed locking schema of store [%s]", m.store)
+		}
+	}
+	if err = m.createVersionTable(tx); err != nil {
+		return nil, err
+	}
+	// the version is read under the lock, another replica might have migrated the store in the meantime
+	report, err = m.report(tx, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	for _, pending := range report.Pending {
+		logger.Infof("applying migration [%d] of store [%s]: %s", pending.Version, m.store, pending.Description)
+		for _, statement := range pending.Statements {
+			logger.Debug(statement)
+			if _, err = tx.Exec(statement); err != nil {
+				return nil, errors.Wrapf(err, "failed applying migration [%d] of store [%s]: %s", pending.Version, m.store, statement)
+			}
+		}
+		if err = m.recordVersion(tx, pending.Version, pending.Description, now); err != nil {
+			return nil, err
+		}
+	}
+	logger.Debug(m.schema)
+	if _, err = tx.Exec(m.schema); err != nil {
+		return nil, errors.Wrapf(err, "error creating schema of store [%s]", m.store)
+	}
+	if !report.Versioned && len(report.Pending) == 0 {
+		if err = m.recordVersion(tx, report.Target, "baseline", now); err != nil {
+			return nil, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed committing migrations of store [%s]", m.store)
+	}
+	return report, nil
+}
+
+// Check returns an error if the schema of the database is newer than the SDK,
+// or if it is older and the pending migrations have statements to execute, wrapping ErrPendingMigrations.
+// It is used when the SDK is not allowed to create or change the schema.
+func (m *Migrator) Check() error {
+	report, err := m.Report()
+	if err != nil {
+		return err
+	}
+	for _, pending := range report.Pending {
+		if len(pending.Statements) != 0 {
+			return errors.Wrapf(ErrPendingMigrations, "apply the migrations or enable the table creation, %s", report)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) createVersionTable(tx *sql.Tx) error {
+	query := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			store TEXT NOT NULL,
+			version INT NOT NULL,
+			description TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (store, version)
+		);`, m.versionTable)
+	logger.Debug(query)
+	_, err := tx.Exec(query)
+	return errors.Wrapf(err, "failed creating table [%s]", m.versionTable)
+}
+
+func (m *Migrator) currentVersion(q Querier) (int, bool, error) {
+	query, err := NewSelect("MAX(version)").From(m.versionTable).Where("store = $1").Compile()
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "failed compiling query")
+	}
+	logger.Debug(query, m.store)
+	var version sql.NullInt64
+	if err := q.QueryRow(query, m.store).Scan(&version); err != nil {
+		return 0, false, errors.Wrapf(err, "failed reading schema version of store [%s]", m.store)
+	}
+	return int(version.Int64), version.Valid, nil
+}
+
+func (m *Migrator) recordVersion(tx *sql.Tx, version int, description string, appliedAt time.Time) error {
+	query, err := NewInsertInto(m.versionTable).Rows("store, version, description, applied_at").Compile()
+	if err != nil {
+		return errors.Wrapf(err, "failed compiling query")
+	}
+	logger.Debug(query, m.store, version, description, appliedAt)
+	_, err = tx.Exec(query, m.store, version, description, appliedAt)
+	return errors.Wrapf(err, "failed recording version [%d] of store [%s]", version, m.store)
+}
+
+// InitSchema migrates the schema of the passed store to the latest version if createSchema is true,
+// otherwise it only checks that the schema is neither newer nor older than the SDK.
+func InitSchema(db *sql.DB, tablePrefix string, createSchema bool, store, mainTable, schema string, migrations ...Migration) error {
+	m, err := NewMigrator(db, tablePrefix, store, mainTable, schema, migrations)
+	if err != nil {
+		return err
+	}
+	if !createSchema {
+		return m.Check()
+	}
+	report, err := m.Migrate()
+	if err != nil {
+		return err
+	}
+	if len(report.Pending) != 0 {
+		logger.Infof("migrated schema of store [%s] from version [%d] to [%d]", store, report.Current, report.Target)
+	}
+	return nil
+}
+
+// SchemaVersion returns the schema version of the passed store, zero if not versioned
+func SchemaVersion(db *sql.DB, tablePrefix, store string) (int, error) {
+	m, err := NewMigrator(db, tablePrefix, store, "", "", nil)
+	if err != nil {
+		return 0, err
+	}
+	exists, err := tableExists(db, DetectDialect(db), m.versionTable)
+	if err != nil || !exists {
+		return 0, err
+	}
+	version, _, err := m.currentVersion(db)
+	return version, err
+}
+
+// TableExists returns true if the passed table exists in the passed database
+func TableExists(db *sql.DB, table string) (bool, error) {
+	return tableExists(db, DetectDialect(db), table)
+}
+
+// DetectDialect returns the dialect of the passed database
+func DetectDialect(db *sql.DB) Dialect {
+	var version string
+	if err := db.QueryRow("SELECT sqlite_version()").Scan(&version); err == nil {
+		return SQLite
+	}
+	return Postgres
+}
+
+func tableExists(q Querier, dialect Dialect, table string) (bool, error) {
+	var query string
+	switch dialect {
+	case SQLite:
+		query = "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = $1"
+	case Postgres:
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1"
+	default:
+		return false, errors.Errorf("unknown dialect [%s]", dialect)
+	}
+	return count(q, query, table)
+}
+
+func columnExists(q Querier, dialect Dialect, table, column string) (bool, error) {
+	var query string
+	switch dialect {
+	case SQLite:
+		query = "SELECT COUNT(*) FROM pragma_table_info($1) WHERE name = $2"
+	case Postgres:
+		query = "SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1 AND column_name = $2"
+	default:
+		return false, errors.Errorf("unknown dialect [%s]", dialect)
+	}
+	return count(q, query, table, column)
+}
+
+func count(q Querier, query string, args ...any) (bool, error) {
+	logger.Debug(query, args)
+	var n int
+	if err := q.QueryRow(query, args...).Scan(&n); err != nil {
+		return false, errors.Wrapf(err, "failed querying schema")
+	}
+	return n > 0, nil
+}
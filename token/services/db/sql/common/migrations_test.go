@@ -0,0 +1,159 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"database/sql"
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql/sqlite"
+	"github.com/pkg/errors"
+	"github.com/test-go/testify/assert"
+)
+
+// legacyTokensSchema is the tokens table as created before the validity windows
+const legacyTokensSchema = `
+	CREATE TABLE %s (
+		tx_id TEXT NOT NULL,
+		idx INT NOT NULL,
+		amount BIGINT NOT NULL,
+		token_type TEXT NOT NULL,
+		quantity TEXT NOT NULL,
+		issuer_raw BYTEA,
+		owner_raw BYTEA NOT NULL,
+		owner_type TEXT NOT NULL,
+		owner_identity BYTEA NOT NULL,
+		owner_wallet_id TEXT,
+		ledger BYTEA NOT NULL,
+		ledger_type TEXT DEFAULT '',
+		ledger_metadata BYTEA NOT NULL,
+		stored_at TIMESTAMP NOT NULL,
+		is_deleted BOOL NOT NULL DEFAULT false,
+		spent_by TEXT NOT NULL DEFAULT '',
+		spent_at TIMESTAMP,
+		owner BOOL NOT NULL DEFAULT false,
+		auditor BOOL NOT NULL DEFAULT false,
+		issuer BOOL NOT NULL DEFAULT false,
+		spendable BOOL NOT NULL DEFAULT true,
+		PRIMARY KEY (tx_id, idx)
+	);`
+
+func openSqlite(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)", path.Join(t.TempDir(), "db.sqlite")))
+	assert.NoError(t, err)
+	t.Cleanup(func() { Close(db) })
+	return db
+}
+
+func openTokenDB(db *sql.DB, createSchema bool) (*TokenDB, error) {
+	tokenDB, err := NewTokenDB(db, db, NewDBOpts{TablePrefix: "test", CreateSchema: createSchema}, NewTokenInterpreter(sqlite.NewInterpreter()))
+	if err != nil {
+		return nil, err
+	}
+	return tokenDB.(*TokenDB), nil
+}
+
+func TestMigrationsNewDatabase(t *testing.T) {
+	db := openSqlite(t)
+	assert.Equal(t, SQLite, DetectDialect(db))
+
+	tokenDB, err := openTokenDB(db, true)
+	assert.NoError(t, err)
+	m, err := NewMigrator(db, "test", TokensStore, tokenDB.table.Tokens, tokenDB.GetSchema(), tokenDB.Migrations())
+	assert.NoError(t, err)
+	report, err := m.Report()
+	assert.NoError(t, err)
+	assert.True(t, report.Versioned)
+	assert.Equal(t, 1, report.Current)
+	assert.Equal(t, 1, report.Target)
+	assert.Empty(t, report.Pending)
+
+	// reopening is a no-op
+	_, err = openTokenDB(db, true)
+	assert.NoError(t, err)
+	var n int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM test_schema_versions").Scan(&n))
+	assert.Equal(t, 1, n)
+}
+
+func TestMigrationsLegacyDatabase(t *testing.T) {
+	db := openSqlite(t)
+	_, err := db.Exec(fmt.Sprintf(legacyTokensSchema, "test_tokens"))
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO test_tokens (tx_id, idx, amount, token_type, quantity, owner_raw, owner_type, owner_identity, ledger, ledger_metadata, stored_at) VALUES ('tx1', 0, 10, 'USD', '0x0a', 'o', 'x509', 'i', 'l', 'm', '2024-01-01')")
+	assert.NoError(t, err)
+
+	// the dry run reports the migration without applying it
+	m, err := NewMigrator(db, "test", TokensStore, "test_tokens", "", (&TokenDB{table: tokenTables{Tokens: "test_tokens"}}).Migrations())
+	assert.NoError(t, err)
+	report, err := m.Report()
+	assert.NoError(t, err)
+	assert.False(t, report.Versioned)
+	assert.Len(t, report.Pending, 1)
+	assert.Equal(t, []string{
+		"ALTER TABLE test_tokens ADD COLUMN not_before TIMESTAMP;",
+		"ALTER TABLE test_tokens ADD COLUMN not_after TIMESTAMP;",
+	}, report.Pending[0].Statements)
+	exists, err := columnExists(db, SQLite, "test_tokens", "not_before")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// without the permission to change the schema, the database is not opened until migrated
+	_, err = openTokenDB(db, false)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrPendingMigrations))
+	assert.Contains(t, err.Error(), "ALTER TABLE test_tokens ADD COLUMN not_before TIMESTAMP;")
+
+	_, err = openTokenDB(db, true)
+	assert.NoError(t, err)
+	for _, column := range []string{"not_before", "not_after"} {
+		exists, err := columnExists(db, SQLite, "test_tokens", column)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	}
+	var description string
+	assert.NoError(t, db.QueryRow("SELECT description FROM test_schema_versions WHERE store = 'tokens' AND version = 1").Scan(&description))
+	assert.Equal(t, "add the validity window columns to the tokens", description)
+	var n int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM test_tokens WHERE not_before IS NULL").Scan(&n))
+	assert.Equal(t, 1, n)
+
+	// a database with the validity columns but unversioned is migrated as well
+	db2 := openSqlite(t)
+	_, err = db2.Exec(fmt.Sprintf(legacyTokensSchema, "test_tokens") + "ALTER TABLE test_tokens ADD COLUMN not_before TIMESTAMP;")
+	assert.NoError(t, err)
+	_, err = openTokenDB(db2, true)
+	assert.NoError(t, err)
+	exists, err = columnExists(db2, SQLite, "test_tokens", "not_after")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	// an unversioned database whose migrations are already in place is opened without the permission to change the schema
+	db3 := openSqlite(t)
+	_, err = db3.Exec(fmt.Sprintf(legacyTokensSchema, "test_tokens") + "ALTER TABLE test_tokens ADD COLUMN not_before TIMESTAMP; ALTER TABLE test_tokens ADD COLUMN not_after TIMESTAMP;")
+	assert.NoError(t, err)
+	_, err = openTokenDB(db3, false)
+	assert.NoError(t, err)
+}
+
+func TestMigrationsNewerSchema(t *testing.T) {
+	db := openSqlite(t)
+	_, err := openTokenDB(db, true)
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO test_schema_versions (store, version, description, applied_at) VALUES ('tokens', 2, 'from the future', '2030-01-01')")
+	assert.NoError(t, err)
+
+	for _, createSchema := range []bool{true, false} {
+		_, err = openTokenDB(db, createSchema)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, ErrNewerSchema))
+	}
+	_, err = NewMigrator(db, "test", TokensStore, "test_tokens", "", []Migration{{Version: 2}})
+	assert.EqualError(t, err, "migration [] of store [tokens] has version [2], expected [1]")
+}
@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/pkg/errors"
+)
+
+// cursor is the position of the last record of a page.
+// It is serialized into the opaque continuation token handed to the caller.
+type cursor struct {
+	StoredAt time.Time `json:"t,omitempty"`
+	ID       string    `json:"id,omitempty"`
+	TxID     string    `json:"tx,omitempty"`
+	Index    uint64    `json:"idx,omitempty"`
+	Wallet   string    `json:"w,omitempty"`
+}
+
+func (c *cursor) Encode() (string, error) {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal cursor")
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeCursor returns the cursor encoded in the passed continuation token, nil if the token is empty
+func decodeCursor(token string) (*cursor, error) {
+	if len(token) == 0 {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cursor")
+	}
+	c := &cursor{}
+	if err := json.Unmarshal(raw, c); err != nil {
+		return nil, errors.Wrapf(err, "invalid cursor")
+	}
+	return c, nil
+}
+
+func checkPagination(pagination driver.Pagination) (*cursor, error) {
+	if pagination.PageSize <= 0 {
+		return nil, errors.Errorf("invalid page size [%d], it must be positive", pagination.PageSize)
+	}
+	return decodeCursor(pagination.Cursor)
+}
+
+// keysetCondition returns the condition that selects the records following, in the order given by the passed fields,
+// the record whose fields have the passed values.
+// For fields (a, b), it returns (a > va) OR (a = va AND b > vb), with < in place of > if desc is true.
+func keysetCondition(ci common.Interpreter, fields []common.FieldName, values []any, desc bool) common.Condition {
+	symbol := ">"
+	if desc {
+		symbol = "<"
+	}
+	conds := make([]common.Condition, len(fields))
+	for i := range fields {
+		terms := make([]common.Condition, 0, i+1)
+		for j := 0; j < i; j++ {
+			terms = append(terms, cmp(ci, fields[j], "=", values[j]))
+		}
+		terms = append(terms, cmp(ci, fields[i], symbol, values[i]))
+		conds[i] = ci.And(terms...)
+	}
+	return ci.Or(conds...)
+}
+
+// cmp is like common.Interpreter.Cmp, but it does not skip empty strings
+func cmp(ci common.Interpreter, field common.FieldName, symbol string, value any) common.Condition {
+	if s, ok := value.(string); ok && len(s) == 0 {
+		return common.ConstCondition(fmt.Sprintf("%s %s ''", field, symbol))
+	}
+	return ci.Cmp(field, symbol, value)
+}
+
+// keysetOrderBy orders by the passed fields and fetches one record more than the page size,
+// to tell whether there is a next page
+func keysetOrderBy(fields []common.FieldName, desc bool, pageSize int) string {
+	dir := " ASC"
+	if desc {
+		dir = " DESC"
+	}
+	sb := strings.Builder{}
+	sb.WriteString(" ORDER BY ")
+	for i, field := range fields {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(field)
+		sb.WriteString(dir)
+	}
+	sb.WriteString(" LIMIT ")
+	sb.WriteString(strconv.Itoa(pageSize + 1))
+	return sb.String()
+}
+
+// newPage trims the passed items, fetched with keysetOrderBy, to the page size,
+// and sets the next cursor to the position of the last item, if there are more.
+// positions[i] is the position of items[i].
+func newPage[T any](items []T, positions []*cursor, pageSize int) (*driver.Page[T], error) {
+	if len(items) <= pageSize {
+		return &driver.Page[T]{Items: items}, nil
+	}
+	next, err := positions[pageSize-1].Encode()
+	if err != nil {
+		return nil, err
+	}
+	return &driver.Page[T]{Items: items[:pageSize], NextCursor: next}, nil
+}
@@ -119,6 +119,7 @@ func TestTransactionSql(t *testing.T) {
 }
 
 func TestMovementConditions(t *testing.T) {
+	yesterday := time.Now().UTC().AddDate(0, 0, -1)
 	testCases := []struct {
 		name         string
 		params       driver.QueryMovementsParams
@@ -223,6 +224,19 @@ func TestMovementConditions(t *testing.T) {
 			expectedSql:  "WHERE (status = $1 AND amount > 0) ORDER BY stored_at DESC LIMIT 2",
 			expectedArgs: []interface{}{driver.Pending},
 		},
+		{
+			name: "Sent XYZ from alice since yesterday",
+			params: driver.QueryMovementsParams{
+				EnrollmentIDs:     []string{"alice"},
+				TokenTypes:        []token.Type{"XYZ"},
+				TxStatuses:        []driver.TxStatus{driver.Pending, driver.Confirmed},
+				MovementDirection: driver.Sent,
+				SearchDirection:   driver.FromLast,
+				From:              &yesterday,
+			},
+			expectedSql:  "WHERE (enrollment_id = $1 AND token_type = $2 AND (status) IN (($3), ($4)) AND amount < 0 AND stored_at >= $5) ORDER BY stored_at DESC",
+			expectedArgs: []interface{}{"alice", "XYZ", driver.Pending, driver.Confirmed, &yesterday},
+		},
 	}
 
 	for _, tc := range testCases {
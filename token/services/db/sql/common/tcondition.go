@@ -91,6 +91,13 @@ func (c *tokenInterpreter) HasTokenDetails(params driver.QueryTokenDetailsParams
 	if len(params.LedgerTokenFormats) > 0 {
 		conds = append(conds, c.HasTokenFormats("ledger_type", params.LedgerTokenFormats...))
 	}
+	if params.ValidAt != nil {
+		t := params.ValidAt.UTC()
+		conds = append(conds,
+			c.Or(common.ConstCondition("not_before IS NULL"), c.Cmp("not_before", "<=", t)),
+			c.Or(common.ConstCondition("not_after IS NULL"), c.Cmp("not_after", ">", t)),
+		)
+	}
 	return c.And(conds...)
 }
 
@@ -110,6 +117,9 @@ func (c *tokenInterpreter) HasMovementsParams(params driver.QueryMovementsParams
 	} else if params.MovementDirection == driver.Received {
 		conds = append(conds, common.ConstCondition("amount > 0"))
 	}
+	if params.From != nil && !params.From.IsZero() {
+		conds = append(conds, c.Cmp("stored_at", ">=", params.From.UTC()))
+	}
 	return c.And(conds...)
 }
 
@@ -130,7 +130,10 @@ var TokensCases = []struct {
 	{"PublicParams", TPublicParams},
 	{"Certification", TCertification},
 	{"QueryTokenDetails", TQueryTokenDetails},
+	{"QueryTokenDetailsPage", TQueryTokenDetailsPage},
 	{"TTokenTypes", TTokenTypes},
+	{"TransferOrders", TTransferOrders},
+	{"ValidityWindows", TValidityWindows},
 }
 
 func TTransaction(t *testing.T, db TestTokenDB) {
@@ -989,6 +992,63 @@ func TQueryTokenDetails(t *testing.T, db TestTokenDB) {
 	assertEqual(t, tx2, res[1])
 }
 
+func TQueryTokenDetailsPage(t *testing.T, db TestTokenDB) {
+	tx, err := db.NewTokenDBTransaction()
+	assert.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		owners := []string{"alice"}
+		if i == 2 {
+			// a token owned by two wallets is returned once per wallet
+			owners = append(owners, "bob")
+		}
+		assert.NoError(t, tx.StoreToken(context.TODO(), driver.TokenRecord{
+			TxID:           fmt.Sprintf("tx%d", i/2),
+			Index:          uint64(i % 2),
+			IssuerRaw:      []byte{},
+			OwnerRaw:       []byte{1, 2, 3},
+			OwnerType:      "idemix",
+			OwnerIdentity:  []byte{},
+			Ledger:         []byte("ledger"),
+			LedgerMetadata: []byte{},
+			Quantity:       "0x01",
+			Type:           TST,
+			Amount:         1,
+			Owner:          true,
+		}, owners))
+	}
+	assert.NoError(t, tx.Commit())
+
+	var all []driver.TokenDetails
+	pagination := driver.Pagination{PageSize: 2}
+	for {
+		page, err := db.QueryTokenDetailsPage(driver.QueryTokenDetailsParams{}, pagination)
+		assert.NoError(t, err)
+		all = append(all, page.Items...)
+		if len(page.NextCursor) == 0 {
+			break
+		}
+		pagination.Cursor = page.NextCursor
+	}
+	res, err := db.QueryTokenDetails(driver.QueryTokenDetailsParams{})
+	assert.NoError(t, err)
+	assert.Len(t, all, 6)
+	assert2.ElementsMatch(t, res, all)
+	for i := 1; i < len(all); i++ {
+		prev, cur := all[i-1], all[i]
+		assert.True(t, prev.TxID < cur.TxID || (prev.TxID == cur.TxID && (prev.Index < cur.Index || (prev.Index == cur.Index && prev.OwnerEnrollment < cur.OwnerEnrollment))), "records out of order")
+	}
+
+	// filters apply to all pages
+	page, err := db.QueryTokenDetailsPage(driver.QueryTokenDetailsParams{WalletID: "bob"}, driver.Pagination{PageSize: 1})
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, "tx1", page.Items[0].TxID)
+	assert.Empty(t, page.NextCursor)
+
+	_, err = db.QueryTokenDetailsPage(driver.QueryTokenDetailsParams{}, driver.Pagination{PageSize: -1})
+	assert.Error(t, err)
+}
+
 func TTokenTypes(t *testing.T, db TestTokenDB) {
 	tx, err := db.NewTokenDBTransaction()
 	assert.NoError(t, err)
@@ -1108,3 +1168,159 @@ func assertEqual(t *testing.T, r driver.TokenRecord, d driver.TokenDetails) {
 	assert.Equal(t, r.Amount, d.Amount)
 	assert.Equal(t, r.OwnerType, d.OwnerType)
 }
+
+func TTransferOrders(t *testing.T, db TestTokenDB) {
+	order, err := db.GetTransferOrder("order1")
+	assert.NoError(t, err)
+	assert.Nil(t, order)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	assert.NoError(t, db.StoreTransferOrder(driver.TransferOrderRecord{
+		ID:         "order1",
+		Wallet:     "alice",
+		Type:       TST,
+		Amount:     10,
+		Recipient:  []byte("bob"),
+		Schedule:   "@every 1h",
+		NextRun:    now.Add(time.Hour),
+		MaxRetries: 3,
+		RetryDelay: time.Minute,
+		Status:     driver.TransferOrderActive,
+	}))
+	assert.NoError(t, db.StoreTransferOrder(driver.TransferOrderRecord{
+		ID:          "order2",
+		Wallet:      "alice",
+		Type:        ABC,
+		Amount:      5,
+		Recipient:   []byte("charlie"),
+		Auditor:     []byte("auditor"),
+		NextRun:     now.Add(-time.Minute),
+		Status:      driver.TransferOrderActive,
+		PendingTxID: "tx0",
+	}))
+
+	order, err = db.GetTransferOrder("order1")
+	assert.NoError(t, err)
+	assert.NotNil(t, order)
+	assert.Equal(t, "alice", order.Wallet)
+	assert.Equal(t, TST, order.Type)
+	assert.Equal(t, uint64(10), order.Amount)
+	assert.Equal(t, []byte("bob"), order.Recipient)
+	assert.Equal(t, "@every 1h", order.Schedule)
+	assert.True(t, now.Add(time.Hour).Equal(order.NextRun))
+	assert.Equal(t, 3, order.MaxRetries)
+	assert.Equal(t, time.Minute, order.RetryDelay)
+	assert.False(t, order.CreatedAt.IsZero())
+	assert.Empty(t, order.PendingTxID)
+
+	// only the second order is due
+	orders, err := db.QueryTransferOrders(driver.QueryTransferOrdersParams{
+		Statuses:  []driver.TransferOrderStatus{driver.TransferOrderActive},
+		DueBefore: now,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, orders, 1)
+	assert.Equal(t, "order2", orders[0].ID)
+	assert.Equal(t, []byte("auditor"), orders[0].Auditor)
+	assert.Equal(t, "tx0", orders[0].PendingTxID)
+
+	// storing again replaces the order
+	order.Status = driver.TransferOrderCancelled
+	assert.NoError(t, db.StoreTransferOrder(*order))
+	orders, err = db.QueryTransferOrders(driver.QueryTransferOrdersParams{Statuses: []driver.TransferOrderStatus{driver.TransferOrderActive}})
+	assert.NoError(t, err)
+	assert.Len(t, orders, 1)
+	orders, err = db.QueryTransferOrders(driver.QueryTransferOrdersParams{})
+	assert.NoError(t, err)
+	assert.Len(t, orders, 2)
+	assert.Equal(t, "order2", orders[0].ID)
+	assert.Equal(t, driver.TransferOrderCancelled, orders[1].Status)
+
+	// executions
+	assert.NoError(t, db.AddTransferOrderExecution(driver.TransferOrderExecutionRecord{
+		OrderID:     "order2",
+		Attempt:     1,
+		ScheduledAt: now,
+		Error:       "failed",
+		ExecutedAt:  now,
+	}))
+	assert.NoError(t, db.AddTransferOrderExecution(driver.TransferOrderExecutionRecord{
+		OrderID:     "order2",
+		TxID:        "tx1",
+		Attempt:     2,
+		ScheduledAt: now,
+		Committed:   true,
+		ExecutedAt:  now.Add(time.Second),
+	}))
+	executions, err := db.QueryTransferOrderExecutions("order2")
+	assert.NoError(t, err)
+	assert.Len(t, executions, 2)
+	assert.Equal(t, "failed", executions[0].Error)
+	assert.False(t, executions[0].Committed)
+	assert.Equal(t, "tx1", executions[1].TxID)
+	assert.True(t, executions[1].Committed)
+	executions, err = db.QueryTransferOrderExecutions("order1")
+	assert.NoError(t, err)
+	assert.Empty(t, executions)
+}
+
+func TValidityWindows(t *testing.T, db TestTokenDB) {
+	now := time.Now()
+	windows := map[string][2]time.Time{
+		"unbound":  {},
+		"valid":    {now.Add(-time.Hour), now.Add(time.Hour)},
+		"open":     {now.Add(-time.Hour), time.Time{}},
+		"expired":  {now.Add(-2 * time.Hour), now.Add(-time.Hour)},
+		"notyet":   {now.Add(time.Hour), now.Add(2 * time.Hour)},
+		"deadline": {time.Time{}, now.Add(-time.Minute)},
+	}
+	tx, err := db.NewTokenDBTransaction()
+	assert.NoError(t, err)
+	for txID, w := range windows {
+		assert.NoError(t, tx.StoreToken(context.TODO(), driver.TokenRecord{
+			TxID:           txID,
+			Index:          0,
+			IssuerRaw:      []byte{},
+			OwnerRaw:       []byte{1, 2, 3},
+			OwnerType:      "vld",
+			OwnerIdentity:  []byte{},
+			OwnerWalletID:  "alice",
+			Ledger:         []byte("ledger"),
+			LedgerMetadata: []byte{},
+			Quantity:       "0x01",
+			Type:           TST,
+			Amount:         1,
+			Owner:          true,
+			NotBefore:      w[0],
+			NotAfter:       w[1],
+		}, []string{"alice"}))
+	}
+	assert.NoError(t, tx.Commit())
+
+	// only the tokens valid now can be spent
+	it, err := db.SpendableTokensIteratorBy(context.TODO(), "alice", TST)
+	assert.NoError(t, err)
+	consumeSpendableTokensIterator(t, it, TST, 3)
+
+	tds, err := db.QueryTokenDetails(driver.QueryTokenDetailsParams{WalletID: "alice", ValidAt: &now})
+	assert.NoError(t, err)
+	var ids []string
+	for _, td := range tds {
+		ids = append(ids, td.TxID)
+	}
+	assert2.ElementsMatch(t, []string{"unbound", "valid", "open"}, ids)
+
+	later := now.Add(90 * time.Minute)
+	tds, err = db.QueryTokenDetails(driver.QueryTokenDetailsParams{WalletID: "alice", ValidAt: &later})
+	assert.NoError(t, err)
+	ids = nil
+	for _, td := range tds {
+		ids = append(ids, td.TxID)
+	}
+	assert2.ElementsMatch(t, []string{"unbound", "open", "notyet"}, ids)
+
+	// without ValidAt, all tokens are returned
+	tds, err = db.QueryTokenDetails(driver.QueryTokenDetailsParams{WalletID: "alice"})
+	assert.NoError(t, err)
+	assert.Len(t, tds, len(windows))
+}
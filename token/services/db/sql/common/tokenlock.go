@@ -12,7 +12,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/types/transaction"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
@@ -54,10 +53,8 @@ func NewTokenLockDB(readDB, writeDB *sql.DB, opts NewDBOpts) (*TokenLockDB, erro
 			Requests:   tables.Requests,
 		},
 	)
-	if opts.CreateSchema {
-		if err = common.InitSchema(writeDB, []string{tokenLockDB.GetSchema()}...); err != nil {
-			return nil, err
-		}
+	if err = InitSchema(writeDB, opts.TablePrefix, opts.CreateSchema, TokenLocksStore, tables.TokenLocks, tokenLockDB.GetSchema()); err != nil {
+		return nil, err
 	}
 	return tokenLockDB, nil
 }
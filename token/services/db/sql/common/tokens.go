@@ -29,10 +29,12 @@ import (
 )
 
 type tokenTables struct {
-	Tokens         string
-	Ownership      string
-	PublicParams   string
-	Certifications string
+	Tokens            string
+	Ownership         string
+	PublicParams      string
+	Certifications    string
+	TransferOrders    string
+	TransferOrderRuns string
 }
 
 func NewTokenDB(readDB, writeDB *sql.DB, opts NewDBOpts, ci TokenInterpreter) (driver.TokenDB, error) {
@@ -42,15 +44,15 @@ func NewTokenDB(readDB, writeDB *sql.DB, opts NewDBOpts, ci TokenInterpreter) (d
 	}
 
 	tokenDB := newTokenDB(readDB, writeDB, tokenTables{
-		Tokens:         tables.Tokens,
-		Ownership:      tables.Ownership,
-		PublicParams:   tables.PublicParams,
-		Certifications: tables.Certifications,
+		Tokens:            tables.Tokens,
+		Ownership:         tables.Ownership,
+		PublicParams:      tables.PublicParams,
+		Certifications:    tables.Certifications,
+		TransferOrders:    tables.TransferOrders,
+		TransferOrderRuns: tables.TransferOrderRuns,
 	}, ci)
-	if opts.CreateSchema {
-		if err = common.InitSchema(writeDB, tokenDB.GetSchema()); err != nil {
-			return nil, err
-		}
+	if err = InitSchema(writeDB, opts.TablePrefix, opts.CreateSchema, TokensStore, tables.Tokens, tokenDB.GetSchema(), tokenDB.Migrations()...); err != nil {
+		return nil, err
 	}
 	return tokenDB, nil
 }
@@ -168,11 +170,13 @@ func (db *TokenDB) UnspentTokensIteratorBy(ctx context.Context, walletID string,
 // SpendableTokensIteratorBy returns the minimum information about the tokens needed for the selector
 func (db *TokenDB) SpendableTokensIteratorBy(ctx context.Context, walletID string, typ token.Type) (tdriver.SpendableTokensIterator, error) {
 	span := trace.SpanFromContext(ctx)
+	now := time.Now()
 	where, args := common.Where(db.ci.HasTokenDetails(driver.QueryTokenDetailsParams{
 		WalletID:           walletID,
 		TokenType:          typ,
 		Spendable:          driver.SpendableOnly,
 		LedgerTokenFormats: db.getSupportedTokenFormats(),
+		ValidAt:            &now,
 	}, ""))
 
 	query, err := NewSelect("tx_id, idx, token_type, quantity, owner_wallet_id").From(db.table.Tokens).Where(where).Compile()
@@ -677,6 +681,61 @@ func (db *TokenDB) QueryTokenDetails(params driver.QueryTokenDetailsParams) ([]d
 	return tokenDetails, nil
 }
 
+// QueryTokenDetailsPage returns a page of the token details matching the passed params.
+// Tokens are ordered by token id and then by owner wallet, therefore a token owned by more wallets
+// is returned once per wallet as in QueryTokenDetails.
+func (db *TokenDB) QueryTokenDetailsPage(params driver.QueryTokenDetailsParams, pagination driver.Pagination) (*driver.Page[driver.TokenDetails], error) {
+	after, err := checkPagination(pagination)
+	if err != nil {
+		return nil, err
+	}
+	keys := []common.FieldName{common.JoinCol(db.table.Tokens, "tx_id"), common.JoinCol(db.table.Tokens, "idx"), "wallet_id"}
+	cond := db.ci.HasTokenDetails(params, db.table.Tokens)
+	if after != nil {
+		cond = db.ci.And(cond, keysetCondition(db.ci, keys, []any{after.TxID, after.Index, after.Wallet}, false))
+	}
+	where, args := common.Where(cond)
+	join := joinOnTokenID(db.table.Tokens, db.table.Ownership)
+
+	query, err := NewSelect(fmt.Sprintf("%s.tx_id, %s.idx, owner_identity, owner_type, wallet_id, token_type, amount, is_deleted, spent_by, stored_at", db.table.Tokens, db.table.Tokens)).
+		From(db.table.Tokens, join).Where(where).OrderBy(keysetOrderBy(keys, false, pagination.PageSize)).Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile query")
+	}
+	logger.Debug(query, args)
+	rows, err := db.readDB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer Close(rows)
+
+	var tokenDetails []driver.TokenDetails
+	var positions []*cursor
+	for rows.Next() {
+		td := driver.TokenDetails{}
+		if err := rows.Scan(
+			&td.TxID,
+			&td.Index,
+			&td.OwnerIdentity,
+			&td.OwnerType,
+			&td.OwnerEnrollment,
+			&td.Type,
+			&td.Amount,
+			&td.IsSpent,
+			&td.SpentBy,
+			&td.StoredAt,
+		); err != nil {
+			return nil, err
+		}
+		tokenDetails = append(tokenDetails, td)
+		positions = append(positions, &cursor{TxID: td.TxID, Index: td.Index, Wallet: td.OwnerEnrollment})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return newPage(tokenDetails, positions, pagination.PageSize)
+}
+
 // WhoDeletedTokens returns information about which transaction deleted the passed tokens.
 // The bool array is an indicator used to tell if the token at a given position has been deleted or not
 func (db *TokenDB) WhoDeletedTokens(inputs ...*token.ID) ([]string, []bool, error) {
@@ -945,6 +1004,8 @@ func (db *TokenDB) GetSchema() string {
 			auditor BOOL NOT NULL DEFAULT false,
 			issuer BOOL NOT NULL DEFAULT false,
 			spendable BOOL NOT NULL DEFAULT true,
+			not_before TIMESTAMP,
+			not_after TIMESTAMP,
 			PRIMARY KEY (tx_id, idx)
 		);
 		CREATE INDEX IF NOT EXISTS idx_spent_%s ON %s ( is_deleted, owner );
@@ -976,6 +1037,37 @@ func (db *TokenDB) GetSchema() string {
 			PRIMARY KEY (tx_id, idx),
 			FOREIGN KEY (tx_id, idx) REFERENCES %s
 		);
+
+		-- Transfer Orders
+		CREATE TABLE IF NOT EXISTS %s (
+			id TEXT PRIMARY KEY,
+			wallet_id TEXT NOT NULL,
+			token_type TEXT NOT NULL,
+			amount BIGINT NOT NULL,
+			recipient BYTEA NOT NULL,
+			auditor BYTEA,
+			schedule TEXT NOT NULL DEFAULT '',
+			next_run TIMESTAMP NOT NULL,
+			attempts INT NOT NULL DEFAULT 0,
+			max_retries INT NOT NULL DEFAULT 0,
+			retry_delay BIGINT NOT NULL DEFAULT 0,
+			status INT NOT NULL,
+			pending_tx_id TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS next_run_%s ON %s ( status, next_run );
+
+		-- Transfer Order Executions
+		CREATE TABLE IF NOT EXISTS %s (
+			order_id TEXT NOT NULL,
+			tx_id TEXT NOT NULL DEFAULT '',
+			attempt INT NOT NULL,
+			scheduled_at TIMESTAMP NOT NULL,
+			committed BOOL NOT NULL,
+			error TEXT NOT NULL DEFAULT '',
+			executed_at TIMESTAMP NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS order_id_%s ON %s ( order_id );
 		`,
 		db.table.Tokens,
 		db.table.Tokens, db.table.Tokens,
@@ -983,9 +1075,25 @@ func (db *TokenDB) GetSchema() string {
 		db.table.Ownership, db.table.Tokens,
 		db.table.PublicParams, db.table.PublicParams, db.table.PublicParams,
 		db.table.Certifications, db.table.Tokens,
+		db.table.TransferOrders, db.table.TransferOrders, db.table.TransferOrders,
+		db.table.TransferOrderRuns, db.table.TransferOrderRuns, db.table.TransferOrderRuns,
 	)
 }
 
+// Migrations returns the schema migrations of the token store, in order
+func (db *TokenDB) Migrations() []Migration {
+	return []Migration{
+		{
+			Version:     1,
+			Description: "add the validity window columns to the tokens",
+			Steps: []MigrationStep{
+				AddColumn{Table: db.table.Tokens, Column: "not_before", Definition: "TIMESTAMP"},
+				AddColumn{Table: db.table.Tokens, Column: "not_after", Definition: "TIMESTAMP"},
+			},
+		},
+	}
+}
+
 func (db *TokenDB) Close() {
 	if db.readDB != db.writeDB {
 		Close(db.writeDB)
@@ -1157,7 +1265,7 @@ func (t *TokenTransaction) StoreToken(ctx context.Context, tr driver.TokenRecord
 	// Store token
 	now := time.Now().UTC()
 	query, err := NewInsertInto(t.table.Tokens).Rows(
-		"tx_id, idx, issuer_raw, owner_raw, owner_type, owner_identity, owner_wallet_id, ledger, ledger_type, ledger_metadata, token_type, quantity, amount, stored_at, owner, auditor, issuer").Compile()
+		"tx_id, idx, issuer_raw, owner_raw, owner_type, owner_identity, owner_wallet_id, ledger, ledger_type, ledger_metadata, token_type, quantity, amount, stored_at, owner, auditor, issuer, not_before, not_after").Compile()
 	if err != nil {
 		return errors.Wrapf(err, "failed building insert")
 	}
@@ -1178,7 +1286,9 @@ func (t *TokenTransaction) StoreToken(ctx context.Context, tr driver.TokenRecord
 		now,
 		tr.Owner,
 		tr.Auditor,
-		tr.Issuer)
+		tr.Issuer,
+		tr.NotBefore,
+		tr.NotAfter)
 	span.AddEvent("query", tracing.WithAttributes(tracing.String(QueryLabel, query)))
 	if _, err := t.tx.Exec(query,
 		tr.TxID,
@@ -1197,7 +1307,9 @@ func (t *TokenTransaction) StoreToken(ctx context.Context, tr driver.TokenRecord
 		now,
 		tr.Owner,
 		tr.Auditor,
-		tr.Issuer); err != nil {
+		tr.Issuer,
+		nullTime(tr.NotBefore),
+		nullTime(tr.NotAfter)); err != nil {
 		logger.Errorf("error storing token [%s] in table [%s]: [%s][%s]", tr.TxID, t.table.Tokens, err, string(debug.Stack()))
 		return errors.Wrapf(err, "error storing token [%s] in table [%s]", tr.TxID, t.table.Tokens)
 	}
@@ -1350,6 +1462,11 @@ func tokenDBError(err error) error {
 	return err
 }
 
+// nullTime maps the zero time to NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t.UTC(), Valid: !t.IsZero()}
+}
+
 type LedgerTokensIterator struct {
 	txs *sql.Rows
 }
@@ -31,6 +31,7 @@ type transactionTables struct {
 	Requests              string
 	Validations           string
 	TransactionEndorseAck string
+	ParkedTransactions    string
 }
 
 type TransactionDB struct {
@@ -68,11 +69,10 @@ func NewTransactionDB(readDB, writeDB *sql.DB, opts NewDBOpts, ci TokenInterpret
 		Requests:              tables.Requests,
 		Validations:           tables.Validations,
 		TransactionEndorseAck: tables.TransactionEndorseAck,
+		ParkedTransactions:    tables.ParkedTransactions,
 	}, ci)
-	if opts.CreateSchema {
-		if err = common.InitSchema(writeDB, []string{transactionsDB.GetSchema()}...); err != nil {
-			return nil, err
-		}
+	if err = InitSchema(writeDB, opts.TablePrefix, opts.CreateSchema, TransactionsStore, tables.Requests, transactionsDB.GetSchema()); err != nil {
+		return nil, err
 	}
 	return transactionsDB, nil
 }
@@ -159,6 +159,97 @@ func (db *TransactionDB) QueryTransactions(params driver.QueryTransactionsParams
 	return &TransactionIterator{txs: rows}, nil
 }
 
+// QueryTransactionsPage returns a page of the transactions matching the passed params.
+// Transactions are ordered by time of storage, and then by record id to break ties.
+func (db *TransactionDB) QueryTransactionsPage(params driver.QueryTransactionsParams, pagination driver.Pagination) (*driver.Page[*driver.TransactionRecord], error) {
+	after, err := checkPagination(pagination)
+	if err != nil {
+		return nil, err
+	}
+	keys := []common.FieldName{common.JoinCol(db.table.Transactions, "stored_at"), common.JoinCol(db.table.Transactions, "id")}
+	cond := db.ci.HasTransactionParams(params, db.table.Transactions)
+	if after != nil {
+		cond = db.ci.And(cond, keysetCondition(db.ci, keys, []any{after.StoredAt.UTC(), after.ID}, false))
+	}
+	where, args := common.Where(cond)
+	query, err := NewSelect(
+		fmt.Sprintf("%s.tx_id, action_type, sender_eid, recipient_eid, token_type, amount, %s.status, %s.application_metadata, stored_at, %s.id", db.table.Transactions, db.table.Requests, db.table.Requests, db.table.Transactions),
+	).From(db.table.Transactions, joinOnTxID(db.table.Transactions, db.table.Requests)).Where(where).OrderBy(keysetOrderBy(keys, false, pagination.PageSize)).Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile query")
+	}
+	logger.Debug(query, args)
+	rows, err := db.readDB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer Close(rows)
+
+	var records []*driver.TransactionRecord
+	var positions []*cursor
+	for rows.Next() {
+		var id string
+		r, err := scanTransactionRecord(rows, &id)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+		positions = append(positions, &cursor{StoredAt: r.Timestamp, ID: id})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return newPage(records, positions, pagination.PageSize)
+}
+
+// QueryMovementsPage returns a page of the movements matching the passed params.
+// Movements are ordered by time of storage, following params.SearchDirection, and then by record id to break ties.
+func (db *TransactionDB) QueryMovementsPage(params driver.QueryMovementsParams, pagination driver.Pagination) (*driver.Page[*driver.MovementRecord], error) {
+	after, err := checkPagination(pagination)
+	if err != nil {
+		return nil, err
+	}
+	desc := params.SearchDirection == driver.FromLast
+	keys := []common.FieldName{common.JoinCol(db.table.Movements, "stored_at"), common.JoinCol(db.table.Movements, "id")}
+	cond := db.ci.HasMovementsParams(params)
+	if after != nil {
+		cond = db.ci.And(cond, keysetCondition(db.ci, keys, []any{after.StoredAt.UTC(), after.ID}, desc))
+	}
+	where, args := common.Where(cond)
+	query, err := NewSelect(
+		fmt.Sprintf("%s.tx_id, enrollment_id, token_type, amount, %s.status, %s.stored_at, %s.id", db.table.Movements, db.table.Requests, db.table.Movements, db.table.Movements),
+	).From(db.table.Movements, joinOnTxID(db.table.Movements, db.table.Requests)).Where(where).OrderBy(keysetOrderBy(keys, desc, pagination.PageSize)).Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile query")
+	}
+	logger.Debug(query, args)
+	rows, err := db.readDB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer Close(rows)
+
+	var records []*driver.MovementRecord
+	var positions []*cursor
+	for rows.Next() {
+		var r driver.MovementRecord
+		var amount int64
+		var status int
+		var id string
+		if err := rows.Scan(&r.TxID, &r.EnrollmentID, &r.TokenType, &amount, &status, &r.Timestamp, &id); err != nil {
+			return nil, err
+		}
+		r.Amount = big.NewInt(amount)
+		r.Status = driver.TxStatus(status)
+		records = append(records, &r)
+		positions = append(positions, &cursor{StoredAt: r.Timestamp, ID: id})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return newPage(records, positions, pagination.PageSize)
+}
+
 func (db *TransactionDB) GetStatus(txID string) (driver.TxStatus, string, error) {
 	var status driver.TxStatus
 	var statusMessage string
@@ -264,6 +355,91 @@ func (db *TransactionDB) GetTransactionEndorsementAcks(txID string) (map[string]
 	return acks, nil
 }
 
+func (db *TransactionDB) ParkTransaction(txID string, stage string, raw []byte) (err error) {
+	logger.Debugf("parking transaction [%s] at stage [%s]", txID, stage)
+
+	tx, err := db.writeDB.Begin()
+	if err != nil {
+		return errors.Wrapf(err, "failed starting a db transaction")
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				logger.Errorf("failed to rollback [%s]", rollbackErr)
+			}
+		}
+	}()
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE tx_id = $1;", db.table.ParkedTransactions)
+	logger.Debug(query, txID)
+	if _, err = tx.Exec(query, txID); err != nil {
+		return errors.Wrapf(err, "failed removing previously parked transaction [%s]", txID)
+	}
+	query, err = NewInsertInto(db.table.ParkedTransactions).Rows("tx_id, stage, tx, stored_at").Compile()
+	if err != nil {
+		return errors.Wrapf(err, "error compiling query")
+	}
+	now := time.Now().UTC()
+	logger.Debug(query, txID, stage, fmt.Sprintf("(%d bytes)", len(raw)), now)
+	if _, err = tx.Exec(query, txID, stage, raw, now); err != nil {
+		return ttxDBError(err)
+	}
+	return tx.Commit()
+}
+
+func (db *TransactionDB) GetParkedTransaction(txID string) (string, []byte, error) {
+	query, err := NewSelect("stage, tx").From(db.table.ParkedTransactions).Where("tx_id=$1").Compile()
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed compiling query")
+	}
+	logger.Debug(query, txID)
+
+	var stage string
+	var raw []byte
+	if err := db.readDB.QueryRow(query, txID).Scan(&stage, &raw); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil, nil
+		}
+		return "", nil, errors.Wrapf(err, "error querying db")
+	}
+	return stage, raw, nil
+}
+
+func (db *TransactionDB) UnparkTransaction(txID string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE tx_id = $1;", db.table.ParkedTransactions)
+	logger.Debug(query, txID)
+	if _, err := db.writeDB.Exec(query, txID); err != nil {
+		return errors.Wrapf(err, "failed unparking transaction [%s]", txID)
+	}
+	return nil
+}
+
+func (db *TransactionDB) QueryParkedTransactions() ([]driver.ParkedTransactionRecord, error) {
+	query, err := NewSelect("tx_id, stage, stored_at").From(db.table.ParkedTransactions).OrderBy("stored_at ASC").Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed compiling query")
+	}
+	logger.Debug(query)
+
+	rows, err := db.readDB.Query(query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query")
+	}
+	defer Close(rows)
+	var records []driver.ParkedTransactionRecord
+	for rows.Next() {
+		var record driver.ParkedTransactionRecord
+		if err := rows.Scan(&record.TxID, &record.Stage, &record.StoredAt); err != nil {
+			return nil, errors.Wrapf(err, "error querying db")
+		}
+		records = append(records, record)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
 func (db *TransactionDB) Close() error {
 	logger.Info("closing database")
 	if db.readDB != db.writeDB {
@@ -349,12 +525,21 @@ func (db *TransactionDB) GetSchema() string {
 			stored_at TIMESTAMP NOT NULL
 		);
 		CREATE INDEX IF NOT EXISTS idx_tx_id_%s ON %s ( tx_id );
+
+		-- parked transactions
+		CREATE TABLE IF NOT EXISTS %s (
+			tx_id TEXT NOT NULL PRIMARY KEY,
+			stage TEXT NOT NULL,
+			tx BYTEA NOT NULL,
+			stored_at TIMESTAMP NOT NULL
+		);
 		`,
 		db.table.Requests,
 		db.table.Transactions, db.table.Requests, db.table.Transactions, db.table.Transactions,
 		db.table.Movements, db.table.Requests, db.table.Movements, db.table.Movements,
 		db.table.Validations, db.table.Requests,
 		db.table.TransactionEndorseAck, db.table.TransactionEndorseAck, db.table.TransactionEndorseAck,
+		db.table.ParkedTransactions,
 	)
 }
 
@@ -379,16 +564,22 @@ func (t *TransactionIterator) Close() {
 }
 
 func (t *TransactionIterator) Next() (*driver.TransactionRecord, error) {
-	var r driver.TransactionRecord
 	if !t.txs.Next() {
 		return nil, nil
 	}
+	return scanTransactionRecord(t.txs)
+}
+
+// scanTransactionRecord scans the current row into a transaction record.
+// The passed destinations receive the columns following the record ones.
+func scanTransactionRecord(rows *sql.Rows, dest ...any) (*driver.TransactionRecord, error) {
+	var r driver.TransactionRecord
 	var actionType int
 	var amount int64
 	var status int
 	var metadata []byte
-	// tx_id, action_type, sender_eid, recipient_eid, token_type, amount, status, stored_at
-	err := t.txs.Scan(
+	// tx_id, action_type, sender_eid, recipient_eid, token_type, amount, status, application_metadata, stored_at
+	if err := rows.Scan(append([]any{
 		&r.TxID,
 		&actionType,
 		&r.SenderEID,
@@ -398,7 +589,9 @@ func (t *TransactionIterator) Next() (*driver.TransactionRecord, error) {
 		&status,
 		&metadata,
 		&r.Timestamp,
-	)
+	}, dest...)...); err != nil {
+		return &r, err
+	}
 	if err := unmarshal(metadata, &r.ApplicationMetadata); err != nil {
 		logger.Errorf("error unmarshaling application metadata: %v", metadata)
 		return &r, errors.New("error umarshaling application metadata")
@@ -408,7 +601,7 @@ func (t *TransactionIterator) Next() (*driver.TransactionRecord, error) {
 	r.Amount = big.NewInt(amount)
 	r.Status = driver.TxStatus(status)
 
-	return &r, err
+	return &r, nil
 }
 
 type ValidationRecordsIterator struct {
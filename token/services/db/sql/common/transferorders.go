@@ -0,0 +1,216 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"database/sql"
+	errors2 "errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/pkg/errors"
+)
+
+const transferOrderColumns = "id, wallet_id, token_type, amount, recipient, auditor, schedule, next_run, attempts, max_retries, retry_delay, status, pending_tx_id, created_at"
+
+func (db *TokenDB) StoreTransferOrder(order driver.TransferOrderRecord) (err error) {
+	logger.Debugf("store transfer order [%s]", order.ID)
+	if len(order.ID) == 0 {
+		return errors.New("transfer order id is empty")
+	}
+	if order.CreatedAt.IsZero() {
+		order.CreatedAt = time.Now()
+	}
+
+	tx, err := db.writeDB.Begin()
+	if err != nil {
+		return errors.Wrapf(err, "failed starting a db transaction")
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				logger.Errorf("failed to rollback [%s]", rollbackErr)
+			}
+		}
+	}()
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1;", db.table.TransferOrders)
+	logger.Debug(query, order.ID)
+	if _, err = tx.Exec(query, order.ID); err != nil {
+		return errors.Wrapf(err, "failed removing previous transfer order [%s]", order.ID)
+	}
+	query, err = NewInsertInto(db.table.TransferOrders).Rows(transferOrderColumns).Compile()
+	if err != nil {
+		return errors.Wrapf(err, "error compiling query")
+	}
+	logger.Debug(query, order.ID, order.Wallet, order.Type, order.Amount, order.Schedule, order.NextRun, order.Status)
+	if _, err = tx.Exec(query,
+		order.ID,
+		order.Wallet,
+		order.Type,
+		order.Amount,
+		order.Recipient,
+		order.Auditor,
+		order.Schedule,
+		order.NextRun.UTC(),
+		order.Attempts,
+		order.MaxRetries,
+		int64(order.RetryDelay),
+		int(order.Status),
+		order.PendingTxID,
+		order.CreatedAt.UTC(),
+	); err != nil {
+		return errors.Wrapf(err, "failed storing transfer order [%s]", order.ID)
+	}
+	return tx.Commit()
+}
+
+func (db *TokenDB) GetTransferOrder(id string) (*driver.TransferOrderRecord, error) {
+	query, err := NewSelect(transferOrderColumns).From(db.table.TransferOrders).Where("id = $1").Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed compiling query")
+	}
+	logger.Debug(query, id)
+
+	order, err := scanTransferOrder(db.readDB.QueryRow(query, id))
+	if err != nil {
+		if errors2.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "error querying db")
+	}
+	return order, nil
+}
+
+func (db *TokenDB) QueryTransferOrders(params driver.QueryTransferOrdersParams) ([]driver.TransferOrderRecord, error) {
+	var conds []common.Condition
+	if len(params.Statuses) > 0 {
+		conds = append(conds, db.ci.InInts("status", common.ToInts(params.Statuses)))
+	}
+	if !params.DueBefore.IsZero() {
+		conds = append(conds, db.ci.Cmp("next_run", "<=", params.DueBefore.UTC()))
+	}
+	where, args := common.Where(db.ci.And(conds...))
+	query, err := NewSelect(transferOrderColumns).From(db.table.TransferOrders).Where(where).OrderBy("next_run ASC").Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed compiling query")
+	}
+	logger.Debug(query, args)
+
+	rows, err := db.readDB.Query(query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query")
+	}
+	defer Close(rows)
+	var orders []driver.TransferOrderRecord
+	for rows.Next() {
+		order, err := scanTransferOrder(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error querying db")
+		}
+		orders = append(orders, *order)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (db *TokenDB) AddTransferOrderExecution(record driver.TransferOrderExecutionRecord) error {
+	logger.Debugf("add execution of transfer order [%s], attempt [%d]", record.OrderID, record.Attempt)
+	if record.ExecutedAt.IsZero() {
+		record.ExecutedAt = time.Now()
+	}
+	query, err := NewInsertInto(db.table.TransferOrderRuns).Rows("order_id, tx_id, attempt, scheduled_at, committed, error, executed_at").Compile()
+	if err != nil {
+		return errors.Wrapf(err, "error compiling query")
+	}
+	logger.Debug(query, record.OrderID, record.TxID, record.Attempt, record.ScheduledAt, record.Committed, record.Error, record.ExecutedAt)
+	if _, err := db.writeDB.Exec(query,
+		record.OrderID,
+		record.TxID,
+		record.Attempt,
+		record.ScheduledAt.UTC(),
+		record.Committed,
+		record.Error,
+		record.ExecutedAt.UTC(),
+	); err != nil {
+		return errors.Wrapf(err, "failed storing execution of transfer order [%s]", record.OrderID)
+	}
+	return nil
+}
+
+func (db *TokenDB) QueryTransferOrderExecutions(orderID string) ([]driver.TransferOrderExecutionRecord, error) {
+	query, err := NewSelect("order_id, tx_id, attempt, scheduled_at, committed, error, executed_at").
+		From(db.table.TransferOrderRuns).
+		Where("order_id = $1").
+		OrderBy("executed_at ASC").
+		Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed compiling query")
+	}
+	logger.Debug(query, orderID)
+
+	rows, err := db.readDB.Query(query, orderID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query")
+	}
+	defer Close(rows)
+	var records []driver.TransferOrderExecutionRecord
+	for rows.Next() {
+		var record driver.TransferOrderExecutionRecord
+		if err := rows.Scan(
+			&record.OrderID,
+			&record.TxID,
+			&record.Attempt,
+			&record.ScheduledAt,
+			&record.Committed,
+			&record.Error,
+			&record.ExecutedAt,
+		); err != nil {
+			return nil, errors.Wrapf(err, "error querying db")
+		}
+		records = append(records, record)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTransferOrder(s scanner) (*driver.TransferOrderRecord, error) {
+	var order driver.TransferOrderRecord
+	var retryDelay int64
+	var status int
+	if err := s.Scan(
+		&order.ID,
+		&order.Wallet,
+		&order.Type,
+		&order.Amount,
+		&order.Recipient,
+		&order.Auditor,
+		&order.Schedule,
+		&order.NextRun,
+		&order.Attempts,
+		&order.MaxRetries,
+		&retryDelay,
+		&status,
+		&order.PendingTxID,
+		&order.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	order.RetryDelay = time.Duration(retryDelay)
+	order.Status = driver.TransferOrderStatus(status)
+	return &order, nil
+}
@@ -42,10 +42,8 @@ func NewWalletDB(readDB, writeDB *sql.DB, opts NewDBOpts) (driver.WalletDB, erro
 	}
 
 	walletDB := newWalletDB(readDB, writeDB, walletTables{Wallets: tables.Wallets})
-	if opts.CreateSchema {
-		if err = common.InitSchema(writeDB, []string{walletDB.GetSchema()}...); err != nil {
-			return nil, errors.Wrapf(err, "failed to create schema")
-		}
+	if err = InitSchema(writeDB, opts.TablePrefix, opts.CreateSchema, WalletsStore, tables.Wallets, walletDB.GetSchema()); err != nil {
+		return nil, errors.Wrapf(err, "failed to create schema")
 	}
 	return walletDB, nil
 }
@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/json"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/htlc"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
+	"github.com/pkg/errors"
+)
+
+type deserializer interface {
+	DeserializeVerifier(id driver.Identity) (driver.Verifier, error)
+	MatchIdentity(id driver.Identity, ai []byte) error
+}
+
+// TypedIdentityDeserializer deserializes timelock scripts.
+// The audit info of a timelock script has the format of the audit info of an htlc script,
+// therefore the EIDRH deserializer of htlc scripts applies to timelock scripts as well.
+type TypedIdentityDeserializer struct {
+	deserializer deserializer
+}
+
+func NewTypedIdentityDeserializer(deserializer deserializer) *TypedIdentityDeserializer {
+	return &TypedIdentityDeserializer{deserializer: deserializer}
+}
+
+func (t *TypedIdentityDeserializer) DeserializeVerifier(typ identity.Type, raw []byte) (driver.Verifier, error) {
+	if typ != timelock.ScriptType {
+		return nil, errors.Errorf("cannot deserializer type [%s], expected [%s]", typ, timelock.ScriptType)
+	}
+	script := &timelock.Script{}
+	if err := script.FromBytes(raw); err != nil {
+		return nil, errors.Errorf("failed to unmarshal TypedIdentity as a timelock script")
+	}
+	v := &timelock.Verifier{UnlockAt: script.UnlockAt, Revocable: script.Revocable}
+	var err error
+	v.Sender, err = t.deserializer.DeserializeVerifier(script.Sender)
+	if err != nil {
+		return nil, errors.Errorf("failed to unmarshal the identity of the sender in the timelock script")
+	}
+	v.Recipient, err = t.deserializer.DeserializeVerifier(script.Recipient)
+	if err != nil {
+		return nil, errors.Errorf("failed to unmarshal the identity of the recipient in the timelock script")
+	}
+	return v, nil
+}
+
+func (t *TypedIdentityDeserializer) Recipients(id driver.Identity, typ identity.Type, raw []byte) ([]driver.Identity, error) {
+	if typ != timelock.ScriptType {
+		return nil, errors.New("unknown identity type")
+	}
+	script := &timelock.Script{}
+	if err := script.FromBytes(raw); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal timelock script")
+	}
+	return []driver.Identity{script.Recipient}, nil
+}
+
+func (t *TypedIdentityDeserializer) GetAuditInfo(id driver.Identity, typ identity.Type, raw []byte, p driver.AuditInfoProvider) ([]byte, error) {
+	if typ != timelock.ScriptType {
+		return nil, errors.Errorf("invalid type, got [%s], expected [%s]", typ, timelock.ScriptType)
+	}
+	script := &timelock.Script{}
+	if err := script.FromBytes(raw); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal timelock script")
+	}
+
+	auditInfo := &htlc.ScriptInfo{}
+	var err error
+	auditInfo.Sender, err = p.GetAuditInfo(script.Sender)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting audit info for timelock script [%s]", id.String())
+	}
+	auditInfo.Recipient, err = p.GetAuditInfo(script.Recipient)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting audit info for timelock script [%s]", id.String())
+	}
+	auditInfoRaw, err := json.Marshal(auditInfo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed marshaling audit info for timelock script")
+	}
+	return auditInfoRaw, nil
+}
+
+func (t *TypedIdentityDeserializer) GetAuditInfoMatcher(owner driver.Identity, auditInfo []byte) (driver.Matcher, error) {
+	return &AuditInfoMatcher{
+		auditInfo:    auditInfo,
+		deserializer: t.deserializer,
+	}, nil
+}
+
+// AuditInfoMatcher matches a timelock script to the audit info of its sender and recipient
+type AuditInfoMatcher struct {
+	auditInfo    []byte
+	deserializer deserializer
+}
+
+func (a *AuditInfoMatcher) Match(id []byte) error {
+	scriptInf := &htlc.ScriptInfo{}
+	if err := json.Unmarshal(a.auditInfo, scriptInf); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal script info")
+	}
+	script := &timelock.Script{}
+	if err := script.FromBytes(id); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal timelock script")
+	}
+	if err := a.deserializer.MatchIdentity(script.Sender, scriptInf.Sender); err != nil {
+		return errors.Wrapf(err, "failed matching sender identity [%s]", script.Sender.String())
+	}
+	if err := a.deserializer.MatchIdentity(script.Recipient, scriptInf.Recipient); err != nil {
+		return errors.Wrapf(err, "failed matching recipient identity [%s]", script.Recipient.String())
+	}
+	return nil
+}
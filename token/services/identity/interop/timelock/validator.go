@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
+	"github.com/pkg/errors"
+)
+
+type OperationType int
+
+const (
+	None OperationType = iota
+	Release
+	Revoke
+)
+
+// VerifyOwner validates the owner of the output of a transfer that spends a timelock script.
+// From the unlock time on, the output must be owned by the recipient of the script (release).
+// Before, the output must be owned by the sender of the script, and the script must be revocable (revocation).
+func VerifyOwner(senderRawOwner []byte, outRawOwner []byte, now time.Time) (*timelock.Script, OperationType, error) {
+	sender, err := identity.UnmarshalTypedIdentity(senderRawOwner)
+	if err != nil {
+		return nil, None, err
+	}
+	if sender.Type != timelock.ScriptType {
+		return nil, None, errors.Errorf("invalid identity type, expected [%s], got [%s]", timelock.ScriptType, sender.Type)
+	}
+	script := &timelock.Script{}
+	if err := script.FromBytes(sender.Identity); err != nil {
+		return nil, None, err
+	}
+
+	if script.IsUnlocked(now) {
+		// this should be a release
+		if !script.Recipient.Equal(outRawOwner) {
+			return nil, None, errors.New("owner of output token does not correspond to recipient in timelock script")
+		}
+		return script, Release, nil
+	}
+	// this should be a revocation
+	if !script.Revocable {
+		return nil, None, errors.Errorf("timelock script locked until [%s]", script.UnlockAt)
+	}
+	if !script.Sender.Equal(outRawOwner) {
+		return nil, None, errors.New("owner of output token does not correspond to sender in timelock script")
+	}
+	return script, Revoke, nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyOwner(t *testing.T) {
+	sender := view.Identity("sender")
+	recipient := view.Identity("recipient")
+	unlockAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	locked := func(revocable bool) []byte {
+		id, err := (&timelock.Script{Sender: sender, Recipient: recipient, UnlockAt: unlockAt, Revocable: revocable}).Identity()
+		require.NoError(t, err)
+		return id
+	}
+
+	// release, from the unlock time on, to the recipient only
+	_, op, err := VerifyOwner(locked(false), recipient, unlockAt)
+	require.NoError(t, err)
+	assert.Equal(t, Release, op)
+	_, _, err = VerifyOwner(locked(true), sender, unlockAt.Add(time.Hour))
+	assert.EqualError(t, err, "owner of output token does not correspond to recipient in timelock script")
+
+	// before the unlock time, nothing can be spent, unless revocable
+	_, _, err = VerifyOwner(locked(false), recipient, unlockAt.Add(-time.Second))
+	assert.EqualError(t, err, "timelock script locked until [2025-01-01 00:00:00 +0000 UTC]")
+	_, _, err = VerifyOwner(locked(false), sender, unlockAt.Add(-time.Second))
+	assert.Error(t, err)
+
+	// revocation, before the unlock time, to the sender only
+	_, op, err = VerifyOwner(locked(true), sender, unlockAt.Add(-time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, Revoke, op)
+	_, _, err = VerifyOwner(locked(true), recipient, unlockAt.Add(-time.Second))
+	assert.EqualError(t, err, "owner of output token does not correspond to sender in timelock script")
+}
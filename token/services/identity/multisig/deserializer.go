@@ -96,7 +96,7 @@ func (d *TypedIdentityDeserializer) DeserializeVerifier(typ identity.Type, id []
 	if err != nil {
 		return nil, errors.New("failed to unmarshal multisig identity")
 	}
-	verifier := &Verifier{}
+	verifier := &Verifier{Threshold: multisigIdentity.EffectiveThreshold()}
 	verifier.Verifiers = make([]driver.Verifier, len(multisigIdentity.Identities))
 	for k, i := range multisigIdentity.Identities {
 		verifier.Verifiers[k], err = d.VerifierDeserializer.DeserializeVerifier(i)
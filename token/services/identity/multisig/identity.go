@@ -20,29 +20,89 @@ import (
 // It is used to identify a multisig identity in a typed identity (identity.TypedIdentity).
 const Multisig = "ms"
 
+// MultiIdentity is a set of identities that jointly own a token.
+// Threshold is the minimum number of identities that must sign to spend the token.
+// A zero threshold means that all the identities must sign (N-of-N).
+// When zero, the threshold is omitted from the serialization, keeping N-of-N identities backward compatible.
 type MultiIdentity struct {
 	Identities []token.Identity
+	Threshold  int `asn1:"optional,default:0"`
+}
+
+// EffectiveThreshold returns the number of signatures required to satisfy this multisig identity
+func (m *MultiIdentity) EffectiveThreshold() int {
+	if m.Threshold == 0 {
+		return len(m.Identities)
+	}
+	return m.Threshold
+}
+
+// Validate checks that the identities are distinct and that the threshold is consistent with their number.
+// Signatures are matched to identities by their unique id, therefore a repeated identity would count more than once towards the threshold.
+func (m *MultiIdentity) Validate() error {
+	if err := m.validateThreshold(); err != nil {
+		return err
+	}
+	seen := make(map[string]struct{}, len(m.Identities))
+	for k, id := range m.Identities {
+		if id.IsNone() {
+			return errors.Errorf("identity at index [%d] is empty", k)
+		}
+		uid := id.UniqueID()
+		if _, ok := seen[uid]; ok {
+			return errors.Errorf("identity at index [%d] is a duplicate", k)
+		}
+		seen[uid] = struct{}{}
+	}
+	return nil
+}
+
+// validateThreshold checks that the threshold is consistent with the number of identities
+func (m *MultiIdentity) validateThreshold() error {
+	if len(m.Identities) == 0 {
+		return errors.New("no identities provided")
+	}
+	if m.Threshold < 0 || m.Threshold > len(m.Identities) {
+		return errors.Errorf("invalid threshold [%d], expected a value between 1 and [%d]", m.Threshold, len(m.Identities))
+	}
+	return nil
 }
 
 func (m *MultiIdentity) Serialize() ([]byte, error) {
 	return asn1.Marshal(*m)
 }
 
+// Deserialize does not require the identities to be distinct, this is checked only when a multisig identity is built.
+// Otherwise, the tokens owned by the identities built before the check was introduced could not be spent anymore.
 func (m *MultiIdentity) Deserialize(raw []byte) error {
 	_, err := asn1.Unmarshal(raw, m)
-	return err
+	if err != nil {
+		return err
+	}
+	return m.validateThreshold()
 }
 
 func (m *MultiIdentity) Bytes() ([]byte, error) {
 	return asn1.Marshal(*m)
 }
 
-// WrapIdentities wraps the given identities into a multisig identity
+// WrapIdentities wraps the given identities into a multisig identity.
+// All the identities must sign to spend.
 func WrapIdentities(ids ...token.Identity) (token.Identity, error) {
-	if len(ids) == 0 {
-		return nil, errors.New("no identities provided")
+	return WrapIdentitiesWithThreshold(0, ids...)
+}
+
+// WrapIdentitiesWithThreshold wraps the given identities into a multisig identity
+// that requires at least threshold signatures to spend (M-of-N).
+// A threshold equal to zero or to the number of identities means that all the identities must sign.
+func WrapIdentitiesWithThreshold(threshold int, ids ...token.Identity) (token.Identity, error) {
+	if threshold == len(ids) {
+		threshold = 0
+	}
+	mi := &MultiIdentity{Identities: ids, Threshold: threshold}
+	if err := mi.Validate(); err != nil {
+		return nil, err
 	}
-	mi := &MultiIdentity{Identities: ids}
 	raw, err := mi.Bytes()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed marshalling multi identity")
@@ -57,6 +117,16 @@ func WrapIdentities(ids ...token.Identity) (token.Identity, error) {
 // Unwrap returns the identities wrapped in the given multisig identity
 // It returns the identities and a boolean indicating whether the given identity is a multisig identity
 func Unwrap(raw []byte) (bool, []token.Identity, error) {
+	ok, mi, err := UnwrapMultiIdentity(raw)
+	if err != nil || !ok {
+		return ok, nil, err
+	}
+	return true, mi.Identities, nil
+}
+
+// UnwrapMultiIdentity returns the multisig identity, threshold included, wrapped in the given identity.
+// It returns the multisig identity and a boolean indicating whether the given identity is a multisig identity
+func UnwrapMultiIdentity(raw []byte) (bool, *MultiIdentity, error) {
 	ti, err := identity.UnmarshalTypedIdentity(raw)
 	if err != nil {
 		return false, nil, errors.Wrap(err, "failed unmarshalling typed identity")
@@ -69,7 +139,7 @@ func Unwrap(raw []byte) (bool, []token.Identity, error) {
 	if err != nil {
 		return false, nil, errors.Wrap(err, "failed unmarshalling multi identity")
 	}
-	return true, mi.Identities, nil
+	return true, mi, nil
 }
 
 // InfoMatcher matches a multisig identity to its own audit info.
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package multisig
 
 import (
+	"encoding/asn1"
 	"testing"
 
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
@@ -41,6 +42,80 @@ func TestWrapIdentities(t *testing.T) {
 	assert.Equal(t, identities, unwrapped)
 }
 
+func TestWrapIdentitiesWithThreshold(t *testing.T) {
+	identities := identities(t, "id1", "id2", "id3")
+	wrapped, err := WrapIdentitiesWithThreshold(2, identities...)
+	assert.NoError(t, err)
+
+	isMultisig, mi, err := UnwrapMultiIdentity(wrapped)
+	assert.NoError(t, err)
+	assert.True(t, isMultisig)
+	assert.Equal(t, identities, mi.Identities)
+	assert.Equal(t, 2, mi.Threshold)
+	assert.Equal(t, 2, mi.EffectiveThreshold())
+
+	// a threshold equal to the number of identities is encoded as N-of-N
+	wrapped, err = WrapIdentitiesWithThreshold(3, identities...)
+	assert.NoError(t, err)
+	nOfN, err := WrapIdentities(identities...)
+	assert.NoError(t, err)
+	assert.Equal(t, nOfN, wrapped)
+
+	_, err = WrapIdentitiesWithThreshold(4, identities...)
+	assert.Error(t, err)
+	_, err = WrapIdentitiesWithThreshold(-1, identities...)
+	assert.Error(t, err)
+}
+
+func TestMultiIdentity_Duplicates(t *testing.T) {
+	ids := identities(t, "id1", "id2")
+	dup := []token.Identity{ids[0], ids[0], ids[1]}
+
+	// duplicates are rejected when building the identity
+	_, err := WrapIdentitiesWithThreshold(2, dup...)
+	assert.ErrorContains(t, err, "duplicate")
+	_, err = WrapIdentities(dup...)
+	assert.ErrorContains(t, err, "duplicate")
+
+	// but an identity built before the check was introduced can still be deserialized, its tokens remain spendable
+	raw, err := asn1.Marshal(MultiIdentity{Identities: dup})
+	assert.NoError(t, err)
+	mi := &MultiIdentity{}
+	assert.NoError(t, mi.Deserialize(raw))
+	assert.Equal(t, dup, mi.Identities)
+	assert.ErrorContains(t, mi.Validate(), "duplicate")
+	typed, err := (&identity.TypedIdentity{Type: Multisig, Identity: raw}).Bytes()
+	assert.NoError(t, err)
+	ok, ids, err := Unwrap(typed)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, dup, ids)
+
+	// the threshold must still be consistent with the number of identities
+	raw, err = asn1.Marshal(MultiIdentity{Identities: dup, Threshold: 4})
+	assert.NoError(t, err)
+	assert.ErrorContains(t, (&MultiIdentity{}).Deserialize(raw), "invalid threshold [4]")
+}
+
+func TestMultiIdentity_BackwardCompatibility(t *testing.T) {
+	// identities serialized without threshold must decode as N-of-N
+	type legacyMultiIdentity struct {
+		Identities []token.Identity
+	}
+	identities := identities(t, "id1", "id2")
+	raw, err := asn1.Marshal(legacyMultiIdentity{Identities: identities})
+	assert.NoError(t, err)
+
+	mi := &MultiIdentity{}
+	assert.NoError(t, mi.Deserialize(raw))
+	assert.Equal(t, 0, mi.Threshold)
+	assert.Equal(t, 2, mi.EffectiveThreshold())
+
+	serialized, err := mi.Serialize()
+	assert.NoError(t, err)
+	assert.Equal(t, raw, serialized)
+}
+
 func TestUnwrap_InvalidIdentity(t *testing.T) {
 	invalidIdentity := []byte("invalid")
 	isMultisig, unwrapped, err := Unwrap(invalidIdentity)
@@ -18,6 +18,7 @@ import (
 // MultiSignature represents a multi-signature
 // It is a sequence of signatures from different identities on the same message.
 // The order of the signatures is the same as the order of the identities.
+// For threshold multisig identities, the signature of an identity that did not sign is empty.
 type MultiSignature struct {
 	Signatures [][]byte
 }
@@ -49,11 +50,37 @@ func JoinSignatures(identities []token.Identity, sigmas map[string][]byte) ([]by
 	return sig.Bytes()
 }
 
+// JoinThresholdSignatures joins the signatures of the given identities into a single signature.
+// Identities without a signature get an empty placeholder.
+// It fails if fewer than threshold signatures are available.
+// The order of the signatures is the same as the order of the identities.
+func JoinThresholdSignatures(identities []token.Identity, threshold int, sigmas map[string][]byte) ([]byte, error) {
+	signatures := make([][]byte, len(identities))
+	count := 0
+	for k, identity := range identities {
+		sigma, ok := sigmas[identity.UniqueID()]
+		if !ok || len(sigma) == 0 {
+			continue
+		}
+		signatures[k] = sigma
+		count++
+	}
+	if count < threshold {
+		return nil, errors.Errorf("not enough signatures, expected at least [%d], got [%d]", threshold, count)
+	}
+	sig := &MultiSignature{
+		Signatures: signatures,
+	}
+	return sig.Bytes()
+}
+
 // Verifier is a multi-signature verifier that verifies a multi-signature.
 // It is composed of a list of verifiers, one for each identity that signed the message.
 // The order of the verifiers is the same as the order of the identities.
+// Threshold is the minimum number of valid signatures required, zero means all.
 type Verifier struct {
 	Verifiers []driver.Verifier
+	Threshold int
 }
 
 func (v *Verifier) Verify(msg, raw []byte) error {
@@ -65,10 +92,23 @@ func (v *Verifier) Verify(msg, raw []byte) error {
 	if len(v.Verifiers) != len(sig.Signatures) {
 		return errors.Errorf("invalid multisig: expect [%d] signatures, but received [%d]", len(v.Verifiers), len(sig.Signatures))
 	}
+	threshold := v.Threshold
+	if threshold == 0 {
+		threshold = len(v.Verifiers)
+	}
+	valid := 0
 	for k, ver := range v.Verifiers {
+		if len(sig.Signatures[k]) == 0 && threshold < len(v.Verifiers) {
+			// this identity did not sign, this is fine as long as the threshold is met
+			continue
+		}
 		if err = ver.Verify(msg, sig.Signatures[k]); err != nil {
 			return errors.Errorf("invalid multisig: signature at index [%d] does not verify", k)
 		}
+		valid++
+	}
+	if valid < threshold {
+		return errors.Errorf("invalid multisig: expect at least [%d] valid signatures, but received [%d]", threshold, valid)
 	}
 	return nil
 }
@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package multisig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifier_AllOfN(t *testing.T) {
+	ids := identities(t, "id1", "id2", "id3")
+	verifier := &Verifier{Verifiers: sigVerifiers("id1", "id2", "id3")}
+
+	sigma, err := JoinSignatures(ids, sigmas(ids, 0, 1, 2))
+	assert.NoError(t, err)
+	assert.NoError(t, verifier.Verify([]byte("msg"), sigma))
+
+	_, err = JoinSignatures(ids, sigmas(ids, 0, 1))
+	assert.Error(t, err)
+
+	// an empty signature is not accepted when all parties must sign
+	sigma, err = JoinThresholdSignatures(ids, 2, sigmas(ids, 0, 1))
+	assert.NoError(t, err)
+	assert.Error(t, verifier.Verify([]byte("msg"), sigma))
+}
+
+func TestVerifier_Threshold(t *testing.T) {
+	ids := identities(t, "id1", "id2", "id3")
+	verifier := &Verifier{Verifiers: sigVerifiers("id1", "id2", "id3"), Threshold: 2}
+
+	for _, signers := range [][]int{{0, 1}, {0, 2}, {1, 2}, {0, 1, 2}} {
+		sigma, err := JoinThresholdSignatures(ids, 2, sigmas(ids, signers...))
+		assert.NoError(t, err)
+		assert.NoError(t, verifier.Verify([]byte("msg"), sigma), "signers %v", signers)
+	}
+
+	// not enough signatures
+	_, err := JoinThresholdSignatures(ids, 2, sigmas(ids, 1))
+	assert.Error(t, err)
+	sigma, err := JoinThresholdSignatures(ids, 1, sigmas(ids, 1))
+	assert.NoError(t, err)
+	assert.Error(t, verifier.Verify([]byte("msg"), sigma))
+
+	// an invalid signature invalidates the multisig even if the threshold is met
+	s := sigmas(ids, 0, 1)
+	s[ids[2].UniqueID()] = []byte("invalid")
+	sigma, err = JoinThresholdSignatures(ids, 2, s)
+	assert.NoError(t, err)
+	assert.Error(t, verifier.Verify([]byte("msg"), sigma))
+}
+
+func sigVerifiers(names ...string) []driver.Verifier {
+	verifiers := make([]driver.Verifier, len(names))
+	for i, name := range names {
+		verifiers[i] = &mockSigVerifier{expected: []byte(name)}
+	}
+	return verifiers
+}
+
+func sigmas(ids []driver.Identity, signers ...int) map[string][]byte {
+	names := []string{"id1", "id2", "id3"}
+	res := map[string][]byte{}
+	for _, k := range signers {
+		res[ids[k].UniqueID()] = []byte(names[k])
+	}
+	return res
+}
+
+type mockSigVerifier struct {
+	expected []byte
+}
+
+func (m *mockSigVerifier) Verify(message, signature []byte) error {
+	if !bytes.Equal(signature, m.expected) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validity
+
+import (
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/pkg/errors"
+)
+
+type deserializer interface {
+	DeserializeVerifier(id driver.Identity) (driver.Verifier, error)
+	MatchIdentity(id driver.Identity, ai []byte) error
+}
+
+// TypedIdentityDeserializer deserializes validity identities.
+// The audit info of a validity identity is the audit info of its owner.
+type TypedIdentityDeserializer struct {
+	deserializer deserializer
+}
+
+func NewTypedIdentityDeserializer(deserializer deserializer) *TypedIdentityDeserializer {
+	return &TypedIdentityDeserializer{deserializer: deserializer}
+}
+
+func (t *TypedIdentityDeserializer) DeserializeVerifier(typ identity.Type, raw []byte) (driver.Verifier, error) {
+	if typ != Validity {
+		return nil, errors.Errorf("cannot deserializer type [%s], expected [%s]", typ, Validity)
+	}
+	w := &Window{}
+	if err := w.FromBytes(raw); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal validity window")
+	}
+	v := &Verifier{}
+	var err error
+	v.Owner, err = t.deserializer.DeserializeVerifier(w.Owner)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the identity of the owner in the validity window")
+	}
+	v.Issuer, err = t.deserializer.DeserializeVerifier(w.Issuer)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the identity of the issuer in the validity window")
+	}
+	return v, nil
+}
+
+func (t *TypedIdentityDeserializer) Recipients(id driver.Identity, typ identity.Type, raw []byte) ([]driver.Identity, error) {
+	if typ != Validity {
+		return nil, errors.New("unknown identity type")
+	}
+	w := &Window{}
+	if err := w.FromBytes(raw); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal validity window")
+	}
+	return []driver.Identity{w.Owner}, nil
+}
+
+func (t *TypedIdentityDeserializer) GetAuditInfo(id driver.Identity, typ identity.Type, raw []byte, p driver.AuditInfoProvider) ([]byte, error) {
+	if typ != Validity {
+		return nil, errors.Errorf("invalid type, got [%s], expected [%s]", typ, Validity)
+	}
+	w := &Window{}
+	if err := w.FromBytes(raw); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal validity window")
+	}
+	auditInfo, err := p.GetAuditInfo(w.Owner)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting audit info for the owner of [%s]", id.String())
+	}
+	return auditInfo, nil
+}
+
+func (t *TypedIdentityDeserializer) GetAuditInfoMatcher(owner driver.Identity, auditInfo []byte) (driver.Matcher, error) {
+	return &AuditInfoMatcher{
+		auditInfo:    auditInfo,
+		deserializer: t.deserializer,
+	}, nil
+}
+
+// AuditInfoMatcher matches a validity window to the audit info of its owner
+type AuditInfoMatcher struct {
+	auditInfo    []byte
+	deserializer deserializer
+}
+
+func (a *AuditInfoMatcher) Match(raw []byte) error {
+	w := &Window{}
+	if err := w.FromBytes(raw); err != nil {
+		return errors.Wrap(err, "failed to unmarshal validity window")
+	}
+	if err := a.deserializer.MatchIdentity(w.Owner, a.auditInfo); err != nil {
+		return errors.Wrapf(err, "failed matching owner identity [%s]", w.Owner.String())
+	}
+	return nil
+}
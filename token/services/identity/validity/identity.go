@@ -0,0 +1,198 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validity
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/json"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/pkg/errors"
+)
+
+// Validity is the type of an identity that binds an owner to a validity window.
+// It is used to identify such an identity in a typed identity (identity.TypedIdentity).
+const Validity = "vld"
+
+// Window binds the owner of a token to the time window in which the owner can spend the token.
+// Before NotBefore, the token cannot be spent. From NotAfter on, the token is expired and only the issuer can reclaim it.
+// A zero NotBefore means that the token can be spent as soon as it is issued.
+// A zero NotAfter means that the token never expires.
+type Window struct {
+	Owner     driver.Identity
+	Issuer    driver.Identity
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// Validate checks that the owner and the issuer are set, and that the window is well-formed
+func (w *Window) Validate() error {
+	if w.Owner.IsNone() {
+		return errors.New("owner not set")
+	}
+	if w.Issuer.IsNone() {
+		return errors.New("issuer not set")
+	}
+	if w.NotBefore.IsZero() && w.NotAfter.IsZero() {
+		return errors.New("neither not-before nor not-after is set")
+	}
+	if !w.NotBefore.IsZero() && !w.NotAfter.IsZero() && !w.NotBefore.Before(w.NotAfter) {
+		return errors.Errorf("not-before [%s] must precede not-after [%s]", w.NotBefore, w.NotAfter)
+	}
+	return nil
+}
+
+// ValidAt returns nil if the owner can spend the token at the passed time, an error otherwise
+func (w *Window) ValidAt(t time.Time) error {
+	if !w.NotBefore.IsZero() && t.Before(w.NotBefore) {
+		return errors.Errorf("token not valid before [%s]", w.NotBefore)
+	}
+	if w.ExpiredAt(t) {
+		return errors.Errorf("token expired at [%s]", w.NotAfter)
+	}
+	return nil
+}
+
+// ExpiredAt returns true if the token is expired at the passed time
+func (w *Window) ExpiredAt(t time.Time) bool {
+	return !w.NotAfter.IsZero() && !t.Before(w.NotAfter)
+}
+
+// Contains returns true if the passed window has the same issuer of this window and is not wider than this window
+func (w *Window) Contains(o *Window) bool {
+	if !w.Issuer.Equal(o.Issuer) {
+		return false
+	}
+	if !w.NotBefore.IsZero() && (o.NotBefore.IsZero() || o.NotBefore.Before(w.NotBefore)) {
+		return false
+	}
+	if !w.NotAfter.IsZero() && (o.NotAfter.IsZero() || o.NotAfter.After(w.NotAfter)) {
+		return false
+	}
+	return true
+}
+
+func (w *Window) Bytes() ([]byte, error) {
+	return json.Marshal(w)
+}
+
+func (w *Window) FromBytes(raw []byte) error {
+	if err := json.Unmarshal(raw, w); err != nil {
+		return err
+	}
+	return w.Validate()
+}
+
+// Wrap binds the passed owner to the passed issuer and validity window.
+// A zero notBefore or notAfter leaves the corresponding side of the window open.
+func Wrap(owner driver.Identity, issuer driver.Identity, notBefore, notAfter time.Time) (driver.Identity, error) {
+	w := &Window{Owner: owner, Issuer: issuer, NotBefore: notBefore.UTC(), NotAfter: notAfter.UTC()}
+	return w.Identity()
+}
+
+// Identity returns the typed identity wrapping this window
+func (w *Window) Identity() (driver.Identity, error) {
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	raw, err := w.Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshalling validity window")
+	}
+	return identity.WrapWithType(Validity, raw)
+}
+
+// Unwrap returns the validity window wrapped in the passed identity.
+// It returns the window and a boolean indicating whether the passed identity is a validity identity.
+func Unwrap(raw []byte) (bool, *Window, error) {
+	ti, err := identity.UnmarshalTypedIdentity(raw)
+	if err != nil {
+		return false, nil, errors.Wrap(err, "failed unmarshalling typed identity")
+	}
+	if ti.Type != Validity {
+		return false, nil, nil
+	}
+	w := &Window{}
+	if err := w.FromBytes(ti.Identity); err != nil {
+		return false, nil, errors.Wrap(err, "failed unmarshalling validity window")
+	}
+	return true, w, nil
+}
+
+// windowOf returns the validity window wrapped in the passed owner, or nil if the owner is not a validity identity
+func windowOf(owner driver.Identity) (*Window, error) {
+	ti, err := identity.UnmarshalTypedIdentity(owner)
+	if err != nil || ti.Type != Validity {
+		return nil, nil
+	}
+	w := &Window{}
+	if err := w.FromBytes(ti.Identity); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshalling validity window")
+	}
+	return w, nil
+}
+
+// Intersect returns the window of the tokens obtained by spending tokens owned by the passed owners.
+// The window has no owner, it is the intersection of the windows of the owners that have one.
+// It returns nil, if none of the owners has a validity window.
+// It fails, if the windows have different issuers.
+func Intersect(owners ...driver.Identity) (*Window, error) {
+	var res *Window
+	for i, owner := range owners {
+		w, err := windowOf(owner)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid owner at index [%d]", i)
+		}
+		if w == nil {
+			continue
+		}
+		if res == nil {
+			res = &Window{Issuer: w.Issuer, NotBefore: w.NotBefore, NotAfter: w.NotAfter}
+			continue
+		}
+		if !res.Issuer.Equal(w.Issuer) {
+			return nil, errors.Errorf("owner at index [%d] has a validity window with a different issuer", i)
+		}
+		if w.NotBefore.After(res.NotBefore) {
+			res.NotBefore = w.NotBefore
+		}
+		if !w.NotAfter.IsZero() && (res.NotAfter.IsZero() || w.NotAfter.Before(res.NotAfter)) {
+			res.NotAfter = w.NotAfter
+		}
+	}
+	return res, nil
+}
+
+// CarryOver returns the owners of the outputs of a transfer that spends tokens owned by the passed input owners.
+// If the inputs have a validity window that has not expired at the passed time, the non-empty output owners
+// get bound to the intersection of the windows of the inputs. Otherwise, the output owners are returned unchanged,
+// the expired tokens can only be reclaimed by their issuer.
+func CarryOver(inputOwners []driver.Identity, outputOwners []driver.Identity, now time.Time) ([]driver.Identity, error) {
+	window, err := Intersect(inputOwners...)
+	if err != nil {
+		return nil, err
+	}
+	if window == nil || window.ExpiredAt(now) {
+		return outputOwners, nil
+	}
+	res := make([]driver.Identity, len(outputOwners))
+	for i, owner := range outputOwners {
+		if owner.IsNone() {
+			continue
+		}
+		if w, err := windowOf(owner); err == nil && w != nil {
+			res[i] = owner
+			continue
+		}
+		res[i], err = Wrap(owner, window.Issuer, window.NotBefore, window.NotAfter)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed binding output owner at index [%d] to validity window", i)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	alice  = driver.Identity("alice")
+	bob    = driver.Identity("bob")
+	issuer = driver.Identity("issuer")
+	now    = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func TestWrapUnwrap(t *testing.T) {
+	id, err := Wrap(alice, issuer, now.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+
+	ok, w, err := Unwrap(id)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, alice, w.Owner)
+	assert.Equal(t, issuer, w.Issuer)
+	assert.True(t, w.NotBefore.Equal(now.Add(-time.Hour)))
+	assert.True(t, w.NotAfter.Equal(now.Add(time.Hour)))
+
+	// other typed identities are not validity identities
+	other, err := identity.WrapWithType("htlc", []byte("script"))
+	require.NoError(t, err)
+	ok, w, err = Unwrap(other)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, w)
+
+	_, _, err = Unwrap([]byte("not a typed identity"))
+	assert.Error(t, err)
+}
+
+func TestWrapInvalid(t *testing.T) {
+	_, err := Wrap(nil, issuer, now, time.Time{})
+	assert.EqualError(t, err, "owner not set")
+	_, err = Wrap(alice, nil, now, time.Time{})
+	assert.EqualError(t, err, "issuer not set")
+	_, err = Wrap(alice, issuer, time.Time{}, time.Time{})
+	assert.EqualError(t, err, "neither not-before nor not-after is set")
+	_, err = Wrap(alice, issuer, now, now)
+	assert.Error(t, err)
+}
+
+func TestValidAt(t *testing.T) {
+	w := &Window{Owner: alice, Issuer: issuer, NotBefore: now, NotAfter: now.Add(time.Hour)}
+	assert.Error(t, w.ValidAt(now.Add(-time.Second)))
+	assert.NoError(t, w.ValidAt(now))
+	assert.NoError(t, w.ValidAt(now.Add(time.Hour-time.Second)))
+	assert.Error(t, w.ValidAt(now.Add(time.Hour)))
+	assert.False(t, w.ExpiredAt(now))
+	assert.True(t, w.ExpiredAt(now.Add(time.Hour)))
+
+	open := &Window{Owner: alice, Issuer: issuer, NotBefore: now}
+	assert.NoError(t, open.ValidAt(now.Add(100*365*24*time.Hour)))
+	assert.False(t, open.ExpiredAt(now.Add(100*365*24*time.Hour)))
+}
+
+func TestIntersect(t *testing.T) {
+	a, err := Wrap(alice, issuer, now.Add(-2*time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+	b, err := Wrap(bob, issuer, now.Add(-time.Hour), now.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	w, err := Intersect(a, b, driver.Identity("plain"))
+	require.NoError(t, err)
+	assert.Equal(t, issuer, w.Issuer)
+	assert.True(t, w.NotBefore.Equal(now.Add(-time.Hour)))
+	assert.True(t, w.NotAfter.Equal(now.Add(time.Hour)))
+
+	w, err = Intersect(alice, bob)
+	require.NoError(t, err)
+	assert.Nil(t, w)
+
+	c, err := Wrap(bob, driver.Identity("another issuer"), now.Add(-time.Hour), now.Add(2*time.Hour))
+	require.NoError(t, err)
+	_, err = Intersect(a, c)
+	assert.EqualError(t, err, "owner at index [1] has a validity window with a different issuer")
+}
+
+func TestCarryOver(t *testing.T) {
+	a, err := Wrap(alice, issuer, now.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, err)
+
+	// outputs inherit the window of the inputs, redeems stay empty
+	outs, err := CarryOver([]driver.Identity{a}, []driver.Identity{bob, nil}, now)
+	require.NoError(t, err)
+	require.Len(t, outs, 2)
+	assert.Nil(t, outs[1])
+	ok, w, err := Unwrap(outs[0])
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, bob, w.Owner)
+	assert.Equal(t, issuer, w.Issuer)
+	assert.True(t, w.NotAfter.Equal(now.Add(time.Hour)))
+
+	// no window, nothing to carry over
+	outs, err = CarryOver([]driver.Identity{alice}, []driver.Identity{bob}, now)
+	require.NoError(t, err)
+	assert.Equal(t, []driver.Identity{bob}, outs)
+
+	// expired inputs are reclaimed, the outputs are free
+	outs, err = CarryOver([]driver.Identity{a}, []driver.Identity{bob}, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []driver.Identity{bob}, outs)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validity
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/json"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/pkg/errors"
+)
+
+// Signature is the signature on a token owned by a validity identity.
+// It is either the signature of the owner or, when Reclaim is true, the signature of the issuer.
+type Signature struct {
+	Reclaim   bool
+	Signature []byte
+}
+
+func (s *Signature) Bytes() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+func (s *Signature) FromBytes(raw []byte) error {
+	if err := json.Unmarshal(raw, s); err != nil {
+		return err
+	}
+	if len(s.Signature) == 0 {
+		return errors.New("empty signature")
+	}
+	return nil
+}
+
+// WrapSignature wraps the passed signature of the owner, or of the issuer if reclaim is true
+func WrapSignature(sigma []byte, reclaim bool) ([]byte, error) {
+	return (&Signature{Reclaim: reclaim, Signature: sigma}).Bytes()
+}
+
+// IsReclaim returns true if the passed signature has been generated by the issuer to reclaim an expired token
+func IsReclaim(sigma []byte) (bool, error) {
+	s := &Signature{}
+	if err := s.FromBytes(sigma); err != nil {
+		return false, errors.Wrap(err, "failed unmarshalling validity signature")
+	}
+	return s.Reclaim, nil
+}
+
+// Verifier verifies the signatures on a token owned by a validity identity.
+// The signature of the owner is verified against the owner's verifier, the signature of the issuer
+// against the issuer's verifier. The validity window is enforced by the validators.
+type Verifier struct {
+	Owner  driver.Verifier
+	Issuer driver.Verifier
+}
+
+func (v *Verifier) Verify(msg []byte, raw []byte) error {
+	s := &Signature{}
+	if err := s.FromBytes(raw); err != nil {
+		return errors.Wrap(err, "failed unmarshalling validity signature")
+	}
+	if s.Reclaim {
+		return v.Issuer.Verify(msg, s.Signature)
+	}
+	return v.Owner.Verify(msg, s.Signature)
+}
+
+// SignerOf returns the identity whose signature is needed, at the passed time, to spend a token owned by the passed window.
+// This is the issuer, if the window is expired, the owner otherwise. The returned boolean is true in the first case.
+func SignerOf(w *Window, now time.Time) (driver.Identity, bool) {
+	if w.ExpiredAt(now) {
+		return w.Issuer, true
+	}
+	return w.Owner, false
+}
@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validity
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/pkg/errors"
+)
+
+// VerifyIssue checks that the issued tokens bound to a validity window name the passed issuer
+// and are not expired at the passed time.
+func VerifyIssue(issuer driver.Identity, outputOwners []driver.Identity, now time.Time) error {
+	for i, owner := range outputOwners {
+		w, err := windowOf(owner)
+		if err != nil {
+			return errors.WithMessagef(err, "invalid owner of output at index [%d]", i)
+		}
+		if w == nil {
+			continue
+		}
+		if !w.Issuer.Equal(issuer) {
+			return errors.Errorf("the validity window of the output at index [%d] does not name the issuer of the action", i)
+		}
+		if w.ExpiredAt(now) {
+			return errors.Errorf("the output at index [%d] is already expired", i)
+		}
+	}
+	return nil
+}
+
+// VerifyTransfer checks that a transfer respects the validity windows of the tokens it spends.
+// The signatures are those of the owners of the inputs, one per input.
+// - The owners spend the tokens within their windows. The outputs, redeems aside, must be bound to windows contained
+// in the intersection of the windows of the inputs.
+// - The issuer reclaims the tokens once expired. The outputs are not bound to any window.
+// - The outputs of a transfer that spends no token bound to a window are not bound to any window.
+func VerifyTransfer(inputOwners []driver.Identity, signatures [][]byte, outputOwners []driver.Identity, now time.Time) error {
+	if len(inputOwners) != len(signatures) {
+		return errors.Errorf("invalid number of signatures, expected [%d], got [%d]", len(inputOwners), len(signatures))
+	}
+	window, err := Intersect(inputOwners...)
+	if err != nil {
+		return errors.WithMessage(err, "invalid inputs")
+	}
+	if window == nil {
+		for i, owner := range outputOwners {
+			if w, _ := windowOf(owner); w != nil {
+				return errors.Errorf("the output at index [%d] is bound to a validity window, validity windows are set at issuance", i)
+			}
+		}
+		return nil
+	}
+
+	// the inputs are either all reclaimed by the issuer or all spent by their owners
+	reclaims := 0
+	bound := 0
+	for i, owner := range inputOwners {
+		w, _ := windowOf(owner)
+		if w == nil {
+			continue
+		}
+		bound++
+		reclaim, err := IsReclaim(signatures[i])
+		if err != nil {
+			return errors.WithMessagef(err, "invalid signature for input at index [%d]", i)
+		}
+		if reclaim {
+			reclaims++
+			if !w.ExpiredAt(now) {
+				return errors.Errorf("the input at index [%d] cannot be reclaimed before its expiry at [%s]", i, w.NotAfter)
+			}
+			continue
+		}
+		if err := w.ValidAt(now); err != nil {
+			return errors.WithMessagef(err, "the input at index [%d] cannot be spent", i)
+		}
+	}
+	if reclaims != 0 && reclaims != bound {
+		return errors.New("expired inputs must be reclaimed together")
+	}
+
+	for i, owner := range outputOwners {
+		if owner.IsNone() {
+			// redeem
+			continue
+		}
+		w, err := windowOf(owner)
+		if err != nil {
+			return errors.WithMessagef(err, "invalid owner of output at index [%d]", i)
+		}
+		if reclaims != 0 {
+			if w != nil {
+				return errors.Errorf("the output at index [%d] of a reclaim is bound to a validity window", i)
+			}
+			continue
+		}
+		if w == nil || !window.Contains(w) {
+			return errors.Errorf("the output at index [%d] must be bound to the validity window of the inputs", i)
+		}
+	}
+	return nil
+}
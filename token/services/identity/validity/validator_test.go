@@ -0,0 +1,63 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func wrap(t *testing.T, owner driver.Identity, notBefore, notAfter time.Time) driver.Identity {
+	id, err := Wrap(owner, issuer, notBefore, notAfter)
+	require.NoError(t, err)
+	return id
+}
+
+func sig(t *testing.T, reclaim bool) []byte {
+	raw, err := WrapSignature([]byte("sigma"), reclaim)
+	require.NoError(t, err)
+	return raw
+}
+
+func TestVerifyIssue(t *testing.T) {
+	valid := wrap(t, alice, time.Time{}, now.Add(time.Hour))
+	assert.NoError(t, VerifyIssue(issuer, []driver.Identity{valid, bob}, now))
+	assert.EqualError(t, VerifyIssue(driver.Identity("another issuer"), []driver.Identity{valid}, now), "the validity window of the output at index [0] does not name the issuer of the action")
+	assert.EqualError(t, VerifyIssue(issuer, []driver.Identity{valid}, now.Add(time.Hour)), "the output at index [0] is already expired")
+}
+
+func TestVerifyTransfer(t *testing.T) {
+	in := wrap(t, alice, now.Add(-time.Hour), now.Add(time.Hour))
+	inherited := wrap(t, bob, now.Add(-time.Hour), now.Add(time.Hour))
+	wider := wrap(t, bob, now.Add(-time.Hour), now.Add(2*time.Hour))
+
+	// owner spend within the window
+	assert.NoError(t, VerifyTransfer([]driver.Identity{in}, [][]byte{sig(t, false)}, []driver.Identity{inherited, nil}, now))
+	// the window cannot be widened or dropped
+	assert.EqualError(t, VerifyTransfer([]driver.Identity{in}, [][]byte{sig(t, false)}, []driver.Identity{wider}, now), "the output at index [0] must be bound to the validity window of the inputs")
+	assert.EqualError(t, VerifyTransfer([]driver.Identity{in}, [][]byte{sig(t, false)}, []driver.Identity{bob}, now), "the output at index [0] must be bound to the validity window of the inputs")
+	// the owner cannot spend before or after the window
+	assert.Error(t, VerifyTransfer([]driver.Identity{in}, [][]byte{sig(t, false)}, []driver.Identity{inherited}, now.Add(-2*time.Hour)))
+	assert.Error(t, VerifyTransfer([]driver.Identity{in}, [][]byte{sig(t, false)}, []driver.Identity{inherited}, now.Add(time.Hour)))
+
+	// the issuer reclaims after expiry only
+	assert.NoError(t, VerifyTransfer([]driver.Identity{in}, [][]byte{sig(t, true)}, []driver.Identity{bob}, now.Add(time.Hour)))
+	assert.EqualError(t, VerifyTransfer([]driver.Identity{in}, [][]byte{sig(t, true)}, []driver.Identity{bob}, now), "the input at index [0] cannot be reclaimed before its expiry at [2025-01-01 13:00:00 +0000 UTC]")
+	assert.EqualError(t, VerifyTransfer([]driver.Identity{in}, [][]byte{sig(t, true)}, []driver.Identity{inherited}, now.Add(time.Hour)), "the output at index [0] of a reclaim is bound to a validity window")
+
+	// reclaims and owner spends cannot be mixed
+	open := wrap(t, bob, now.Add(-time.Hour), time.Time{})
+	assert.EqualError(t, VerifyTransfer([]driver.Identity{in, open}, [][]byte{sig(t, true), sig(t, false)}, []driver.Identity{bob}, now.Add(time.Hour)), "expired inputs must be reclaimed together")
+
+	// windows are set at issuance only
+	assert.NoError(t, VerifyTransfer([]driver.Identity{alice}, [][]byte{[]byte("sigma")}, []driver.Identity{bob}, now))
+	assert.EqualError(t, VerifyTransfer([]driver.Identity{alice}, [][]byte{[]byte("sigma")}, []driver.Identity{inherited}, now), "the output at index [0] is bound to a validity window, validity windows are set at issuance")
+}
@@ -0,0 +1,176 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	token3 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+const ScriptType = "timelock" // timelock script
+
+// Script contains the details of a timelock.
+// The recipient can spend the token from UnlockAt on.
+// Before UnlockAt, the token can be spent only by the sender, and only if the script is revocable.
+type Script struct {
+	Sender    view.Identity
+	Recipient view.Identity
+	UnlockAt  time.Time
+	Revocable bool
+}
+
+// Validate performs the following checks:
+// - The sender must be set
+// - The recipient must be set
+// - The unlock time must be after the passed time reference
+func (s *Script) Validate(timeReference time.Time) error {
+	if s.Sender.IsNone() {
+		return errors.New("sender not set")
+	}
+	if s.Recipient.IsNone() {
+		return errors.New("recipient not set")
+	}
+	if !s.UnlockAt.After(timeReference) {
+		return errors.New("unlock time has already passed")
+	}
+	return nil
+}
+
+// IsUnlocked returns true if the recipient can spend the token at the passed time
+func (s *Script) IsUnlocked(now time.Time) bool {
+	return !now.Before(s.UnlockAt)
+}
+
+func (s *Script) FromBytes(raw []byte) error {
+	return json.Unmarshal(raw, s)
+}
+
+// Identity returns the typed identity wrapping this script
+func (s *Script) Identity() (view.Identity, error) {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal timelock script")
+	}
+	return identity.WrapWithType(ScriptType, raw)
+}
+
+// Verifier checks if a timelock script can be spent
+type Verifier struct {
+	Recipient driver.Verifier
+	Sender    driver.Verifier
+	UnlockAt  time.Time
+	Revocable bool
+}
+
+// Verify verifies the signature of the recipient, once the script is unlocked, or the signature of the sender,
+// before the unlock time, if the script is revocable. The script is evaluated at the current time.
+func (v *Verifier) Verify(msg []byte, sigma []byte) error {
+	return v.VerifyAt(msg, sigma, time.Now())
+}
+
+// VerifyAt is like Verify but evaluates the script at the passed time.
+// The validators pass the time the token request is validated against, the same time used to check the outputs of the transfer.
+func (v *Verifier) VerifyAt(msg []byte, sigma []byte, now time.Time) error {
+	if !now.Before(v.UnlockAt) {
+		if err := v.Recipient.Verify(msg, sigma); err != nil {
+			return errors.WithMessagef(err, "failed verifying timelock release signature")
+		}
+		return nil
+	}
+	if !v.Revocable {
+		return errors.Errorf("timelock script locked until [%s]", v.UnlockAt)
+	}
+	if err := v.Sender.Verify(msg, sigma); err != nil {
+		return errors.WithMessagef(err, "timelock script locked, failed verifying revocation signature")
+	}
+	return nil
+}
+
+// ScriptAuth implements the Authorization interface for this script
+type ScriptAuth struct {
+	WalletService driver.WalletService
+}
+
+func NewScriptAuth(walletService driver.WalletService) *ScriptAuth {
+	return &ScriptAuth{WalletService: walletService}
+}
+
+// AmIAnAuditor returns false for script ownership
+func (s *ScriptAuth) AmIAnAuditor() bool {
+	return false
+}
+
+// IsMine returns true if either the sender or the recipient is in one of the owner wallets.
+// It returns an empty wallet id.
+func (s *ScriptAuth) IsMine(tok *token3.Token) (string, []string, bool) {
+	owner, err := identity.UnmarshalTypedIdentity(tok.Owner)
+	if err != nil {
+		logger.Debugf("Is Mine [%s,%s,%s]? No, failed unmarshalling [%s]", view.Identity(tok.Owner), tok.Type, tok.Quantity, err)
+		return "", nil, false
+	}
+	if owner.Type != ScriptType {
+		logger.Debugf("Is Mine [%s,%s,%s]? No, owner type is [%s] instead of [%s]", view.Identity(tok.Owner), tok.Type, tok.Quantity, owner.Type, ScriptType)
+		return "", nil, false
+	}
+	script := &Script{}
+	if err := script.FromBytes(owner.Identity); err != nil {
+		logger.Debugf("Is Mine [%s,%s,%s]? No, failed unmarshalling [%s]", view.Identity(tok.Owner), tok.Type, tok.Quantity, err)
+		return "", nil, false
+	}
+	if script.Sender.IsNone() || script.Recipient.IsNone() {
+		logger.Debugf("Is Mine [%s,%s,%s]? No, invalid content [%v]", view.Identity(tok.Owner), tok.Type, tok.Quantity, script)
+		return "", nil, false
+	}
+
+	var ids []string
+	// I'm either the sender
+	logger.Debugf("Is Mine [%s,%s,%s] as a sender?", view.Identity(tok.Owner), tok.Type, tok.Quantity)
+	if wallet, err := s.WalletService.OwnerWallet(script.Sender); err == nil {
+		logger.Debugf("Is Mine [%s,%s,%s] as a sender? Yes", view.Identity(tok.Owner), tok.Type, tok.Quantity)
+		ids = append(ids, senderWallet(wallet))
+	}
+
+	// or the recipient
+	logger.Debugf("Is Mine [%s,%s,%s] as a recipient?", view.Identity(tok.Owner), tok.Type, tok.Quantity)
+	if wallet, err := s.WalletService.OwnerWallet(script.Recipient); err == nil {
+		logger.Debugf("Is Mine [%s,%s,%s] as a recipient? Yes", view.Identity(tok.Owner), tok.Type, tok.Quantity)
+		ids = append(ids, recipientWallet(wallet))
+	}
+
+	logger.Debugf("Is Mine [%s,%s,%s]? %v", view.Identity(tok.Owner), tok.Type, tok.Quantity, len(ids) != 0)
+	return "", ids, len(ids) != 0
+}
+
+func (s *ScriptAuth) Issued(issuer driver.Identity, tok *token3.Token) bool {
+	return false
+}
+
+func (s *ScriptAuth) OwnerType(raw []byte) (string, []byte, error) {
+	owner, err := identity.UnmarshalTypedIdentity(raw)
+	if err != nil {
+		return "", nil, err
+	}
+	return owner.Type, owner.Identity, nil
+}
+
+type ownerWallet interface {
+	ID() string
+}
+
+func senderWallet(w ownerWallet) string {
+	return "timelock.sender" + w.ID()
+}
+
+func recipientWallet(w ownerWallet) string {
+	return "timelock.recipient" + w.ID()
+}
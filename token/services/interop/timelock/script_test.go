@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type verifier []byte
+
+func (v verifier) Verify(_, sigma []byte) error {
+	if !bytes.Equal(v, sigma) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func TestScriptIdentity(t *testing.T) {
+	script := &Script{Sender: []byte("sender"), Recipient: []byte("recipient"), UnlockAt: time.Now().Add(time.Hour).UTC(), Revocable: true}
+	id, err := script.Identity()
+	require.NoError(t, err)
+	ti, err := identity.UnmarshalTypedIdentity(id)
+	require.NoError(t, err)
+	assert.Equal(t, ScriptType, ti.Type)
+	decoded := &Script{}
+	require.NoError(t, decoded.FromBytes(ti.Identity))
+	assert.Equal(t, script.Sender, decoded.Sender)
+	assert.Equal(t, script.Recipient, decoded.Recipient)
+	assert.True(t, script.UnlockAt.Equal(decoded.UnlockAt))
+	assert.True(t, decoded.Revocable)
+
+	assert.NoError(t, script.Validate(time.Now()))
+	assert.EqualError(t, script.Validate(script.UnlockAt), "unlock time has already passed")
+	assert.EqualError(t, (&Script{Recipient: []byte("recipient")}).Validate(time.Now()), "sender not set")
+}
+
+func TestVerifier(t *testing.T) {
+	v := &Verifier{Sender: verifier("sender"), Recipient: verifier("recipient"), UnlockAt: time.Now().Add(-time.Minute)}
+	assert.NoError(t, v.Verify(nil, []byte("recipient")))
+	assert.Error(t, v.Verify(nil, []byte("sender")))
+
+	v.UnlockAt = time.Now().Add(time.Hour)
+	assert.Error(t, v.Verify(nil, []byte("recipient")))
+	assert.Error(t, v.Verify(nil, []byte("sender")))
+	v.Revocable = true
+	assert.NoError(t, v.Verify(nil, []byte("sender")))
+	assert.Error(t, v.Verify(nil, []byte("recipient")))
+
+	// the script is evaluated at the passed time, not at the current one
+	assert.NoError(t, v.VerifyAt(nil, []byte("recipient"), v.UnlockAt))
+	assert.Error(t, v.VerifyAt(nil, []byte("sender"), v.UnlockAt))
+	atUnlock := driver.VerifierAt(v, v.UnlockAt)
+	assert.NoError(t, atUnlock.Verify(nil, []byte("recipient")))
+	assert.Error(t, atUnlock.Verify(nil, []byte("sender")))
+	v.UnlockAt = time.Now().Add(-time.Minute)
+	assert.NoError(t, v.VerifyAt(nil, []byte("sender"), v.UnlockAt.Add(-time.Second)))
+	assert.Error(t, v.VerifyAt(nil, []byte("recipient"), v.UnlockAt.Add(-time.Second)))
+}
+
+func TestLinearSchedule(t *testing.T) {
+	first := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedule, err := LinearSchedule(100, first, 30*24*time.Hour, 3)
+	require.NoError(t, err)
+	require.Len(t, schedule, 3)
+	assert.Equal(t, []uint64{33, 33, 34}, []uint64{schedule[0].Value, schedule[1].Value, schedule[2].Value})
+	assert.True(t, schedule[0].UnlockAt.Equal(first))
+	assert.True(t, schedule[2].UnlockAt.Equal(first.Add(60*24*time.Hour)))
+
+	schedule, err = LinearSchedule(100, first, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []Tranche{{UnlockAt: first, Value: 100}}, schedule)
+
+	_, err = LinearSchedule(100, first, time.Hour, 0)
+	assert.EqualError(t, err, "invalid number of tranches [0]")
+	_, err = LinearSchedule(100, first, 0, 2)
+	assert.EqualError(t, err, "invalid period [0s]")
+	_, err = LinearSchedule(1, first, time.Hour, 2)
+	assert.EqualError(t, err, "cannot split [1] in [2] tranches")
+}
@@ -0,0 +1,211 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"time"
+
+	view2 "github.com/hyperledger-labs/fabric-smart-client/platform/view"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// WithRevocable makes the timelock scripts created by the transfer command revocable by their sender before their unlock time
+func WithRevocable() token.TransferOption {
+	return func(o *token.TransferOptions) error {
+		if o.Attributes == nil {
+			o.Attributes = map[interface{}]interface{}{}
+		}
+		o.Attributes["timelock.revocable"] = true
+		return nil
+	}
+}
+
+func compileTransferOptions(opts ...token.TransferOption) (*token.TransferOptions, error) {
+	txOptions := &token.TransferOptions{}
+	for _, opt := range opts {
+		if err := opt(txOptions); err != nil {
+			return nil, err
+		}
+	}
+	return txOptions, nil
+}
+
+type Binder interface {
+	Bind(longTerm view.Identity, ephemeral view.Identity) error
+}
+
+// Transaction holds a ttx transaction
+type Transaction struct {
+	*ttx.Transaction
+	Binder Binder
+}
+
+// NewTransaction returns a new token transaction customized with the passed opts that will be signed by the passed signer
+func NewTransaction(sp view.Context, signer view.Identity, opts ...ttx.TxOption) (*Transaction, error) {
+	tx, err := ttx.NewTransaction(sp, signer, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Transaction{
+		Transaction: tx,
+		Binder:      view2.GetEndpointService(sp),
+	}, nil
+}
+
+// NewAnonymousTransaction returns a new anonymous token transaction customized with the passed opts
+func NewAnonymousTransaction(sp view.Context, opts ...ttx.TxOption) (*Transaction, error) {
+	tx, err := ttx.NewAnonymousTransaction(sp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Transaction{
+		Transaction: tx,
+		Binder:      view2.GetEndpointService(sp),
+	}, nil
+}
+
+// Lock appends a transfer action that locks the passed value in a timelock script that the recipient can spend from unlockAt on.
+// If the sender is nil, a recipient identity of the passed wallet is used.
+// Use WithRevocable to let the sender revoke the lock before unlockAt.
+func (t *Transaction) Lock(wallet *token.OwnerWallet, sender view.Identity, typ token2.Type, value uint64, recipient view.Identity, unlockAt time.Time, opts ...token.TransferOption) error {
+	return t.Vest(wallet, sender, typ, []Tranche{{UnlockAt: unlockAt, Value: value}}, recipient, opts...)
+}
+
+// Vest appends a transfer action that locks each tranche of the passed schedule in its own timelock script,
+// that the recipient can spend from the unlock time of the tranche on.
+// If the sender is nil, a recipient identity of the passed wallet is used.
+// Use WithRevocable to let the sender revoke the tranches still locked.
+func (t *Transaction) Vest(wallet *token.OwnerWallet, sender view.Identity, typ token2.Type, schedule []Tranche, recipient view.Identity, opts ...token.TransferOption) error {
+	options, err := compileTransferOptions(opts...)
+	if err != nil {
+		return err
+	}
+	if recipient.IsNone() {
+		return errors.Errorf("must specify a recipient")
+	}
+	if len(schedule) == 0 {
+		return errors.Errorf("must specify at least a tranche")
+	}
+	if sender == nil {
+		sender, err = wallet.GetRecipientIdentity()
+		if err != nil {
+			return errors.WithMessagef(err, "failed getting sender identity")
+		}
+	}
+	revocable := false
+	if options.Attributes != nil {
+		if boxed, ok := options.Attributes["timelock.revocable"]; ok {
+			revocable, ok = boxed.(bool)
+			if !ok {
+				return errors.Errorf("expected timelock.revocable attribute to be bool, got [%T]", boxed)
+			}
+		}
+	}
+
+	now := time.Now()
+	values := make([]uint64, len(schedule))
+	owners := make([]view.Identity, len(schedule))
+	for i, tranche := range schedule {
+		script := &Script{
+			Sender:    sender,
+			Recipient: recipient,
+			UnlockAt:  tranche.UnlockAt,
+			Revocable: revocable,
+		}
+		if err := script.Validate(now); err != nil {
+			return errors.WithMessagef(err, "invalid tranche at index [%d]", i)
+		}
+		owners[i], err = script.Identity()
+		if err != nil {
+			return err
+		}
+		values[i] = tranche.Value
+	}
+	_, err = t.TokenRequest.Transfer(t.Transaction.Context, wallet, typ, values, owners, opts...)
+	return err
+}
+
+// Release appends a transfer action that moves the passed unlocked token to the recipient of its timelock script
+func (t *Transaction) Release(wallet *token.OwnerWallet, tok *token2.UnspentToken, opts ...token.TransferOption) error {
+	script, err := scriptOf(tok)
+	if err != nil {
+		return err
+	}
+	if !script.IsUnlocked(time.Now()) {
+		return errors.Errorf("token locked until [%s]", script.UnlockAt)
+	}
+	return t.spend(wallet, tok, script.Recipient, opts...)
+}
+
+// Revoke appends a transfer action that moves the passed locked token back to the sender of its timelock script.
+// The script must be revocable.
+func (t *Transaction) Revoke(wallet *token.OwnerWallet, tok *token2.UnspentToken, opts ...token.TransferOption) error {
+	script, err := scriptOf(tok)
+	if err != nil {
+		return err
+	}
+	if !script.Revocable {
+		return errors.New("timelock script not revocable")
+	}
+	if script.IsUnlocked(time.Now()) {
+		return errors.Errorf("token unlocked since [%s], it cannot be revoked", script.UnlockAt)
+	}
+	return t.spend(wallet, tok, script.Sender, opts...)
+}
+
+// spend registers the signer of the passed beneficiary for the passed token and transfers the token to the beneficiary
+func (t *Transaction) spend(wallet *token.OwnerWallet, tok *token2.UnspentToken, beneficiary view.Identity, opts ...token.TransferOption) error {
+	q, err := token2.ToQuantity(tok.Quantity, t.TokenRequest.TokenService.PublicParametersManager().PublicParameters().Precision())
+	if err != nil {
+		return errors.Wrapf(err, "failed to convert quantity [%s]", tok.Quantity)
+	}
+
+	sigService := t.TokenService().SigService()
+	signer, err := sigService.GetSigner(beneficiary)
+	if err != nil {
+		return err
+	}
+	verifier, err := sigService.OwnerVerifier(beneficiary)
+	if err != nil {
+		return err
+	}
+	logger.Debugf("registering signer for timelock script...")
+	if err := sigService.RegisterSigner(tok.Owner, signer, verifier); err != nil {
+		return err
+	}
+	if err := t.Binder.Bind(beneficiary, tok.Owner); err != nil {
+		return err
+	}
+
+	return t.Transfer(
+		wallet,
+		tok.Type,
+		[]uint64{q.ToBigInt().Uint64()},
+		[]view.Identity{beneficiary},
+		append(opts, token.WithTokenIDs(tok.Id), token.WithoutFee())...,
+	)
+}
+
+func scriptOf(tok *token2.UnspentToken) (*Script, error) {
+	owner, err := identity.UnmarshalTypedIdentity(tok.Owner)
+	if err != nil {
+		return nil, err
+	}
+	if owner.Type != ScriptType {
+		return nil, errors.New("invalid owner type, expected timelock script")
+	}
+	script := &Script{}
+	if err := script.FromBytes(owner.Identity); err != nil {
+		return nil, errors.New("failed to unmarshal TypedIdentity as a timelock script")
+	}
+	return script, nil
+}
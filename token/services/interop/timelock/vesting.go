@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Tranche is a portion of a vesting grant that unlocks at a given time
+type Tranche struct {
+	UnlockAt time.Time
+	Value    uint64
+}
+
+// LinearSchedule splits the passed total value into the passed number of tranches of equal value,
+// the first unlocking at the passed time, the others one period after the previous one.
+// The remainder of the division is assigned to the last tranche.
+func LinearSchedule(total uint64, first time.Time, period time.Duration, tranches int) ([]Tranche, error) {
+	if tranches <= 0 {
+		return nil, errors.Errorf("invalid number of tranches [%d]", tranches)
+	}
+	if tranches > 1 && period <= 0 {
+		return nil, errors.Errorf("invalid period [%s]", period)
+	}
+	if total < uint64(tranches) {
+		return nil, errors.Errorf("cannot split [%d] in [%d] tranches", total, tranches)
+	}
+	value := total / uint64(tranches)
+	res := make([]Tranche, tranches)
+	for i := range res {
+		res[i] = Tranche{UnlockAt: first.Add(time.Duration(i) * period), Value: value}
+	}
+	res[tranches-1].Value += total % uint64(tranches)
+	return res, nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"context"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+type QueryEngine interface {
+	// UnspentTokensIteratorBy returns an iterator over all unspent tokens by type and id. Type can be empty
+	UnspentTokensIteratorBy(ctx context.Context, id string, tokenType token2.Type) (driver.UnspentTokensIterator, error)
+}
+
+// OwnerWallet is a combination of a wallet and a query service
+type OwnerWallet struct {
+	wallet      *token.OwnerWallet
+	queryEngine QueryEngine
+}
+
+// ListLockedTokens returns a list of timelock-tokens, still locked, whose recipient belongs to this wallet
+func (w *OwnerWallet) ListLockedTokens(opts ...token.ListTokensOption) (*token2.UnspentTokens, error) {
+	compiledOpts, err := token.CompileListTokensOption(opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile options")
+	}
+	return w.filter(compiledOpts.TokenType, false, SelectLocked)
+}
+
+// ListLockedTokensIterator returns an iterator of timelock-tokens, still locked, whose recipient belongs to this wallet
+func (w *OwnerWallet) ListLockedTokensIterator(opts ...token.ListTokensOption) (*FilteredIterator, error) {
+	compiledOpts, err := token.CompileListTokensOption(opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile options")
+	}
+	return w.filterIterator(compiledOpts.TokenType, false, SelectLocked)
+}
+
+// ListUnlockedTokens returns a list of unlocked timelock-tokens whose recipient belongs to this wallet.
+// These tokens can be released with Transaction.Release.
+func (w *OwnerWallet) ListUnlockedTokens(opts ...token.ListTokensOption) (*token2.UnspentTokens, error) {
+	compiledOpts, err := token.CompileListTokensOption(opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile options")
+	}
+	return w.filter(compiledOpts.TokenType, false, SelectUnlocked)
+}
+
+// ListUnlockedTokensIterator returns an iterator of unlocked timelock-tokens whose recipient belongs to this wallet
+func (w *OwnerWallet) ListUnlockedTokensIterator(opts ...token.ListTokensOption) (*FilteredIterator, error) {
+	compiledOpts, err := token.CompileListTokensOption(opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile options")
+	}
+	return w.filterIterator(compiledOpts.TokenType, false, SelectUnlocked)
+}
+
+// ListRevocableTokens returns a list of revocable timelock-tokens, still locked, whose sender belongs to this wallet.
+// These tokens can be revoked with Transaction.Revoke.
+func (w *OwnerWallet) ListRevocableTokens(opts ...token.ListTokensOption) (*token2.UnspentTokens, error) {
+	compiledOpts, err := token.CompileListTokensOption(opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile options")
+	}
+	return w.filter(compiledOpts.TokenType, true, SelectRevocable)
+}
+
+// ListTokensAsSender returns an iterator of timelock-tokens, still locked, whose sender belongs to this wallet
+func (w *OwnerWallet) ListTokensAsSender(opts ...token.ListTokensOption) (*FilteredIterator, error) {
+	compiledOpts, err := token.CompileListTokensOption(opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile options")
+	}
+	return w.filterIterator(compiledOpts.TokenType, true, SelectLocked)
+}
+
+func (w *OwnerWallet) filter(tokenType token2.Type, sender bool, selector SelectFunction) (*token2.UnspentTokens, error) {
+	it, err := w.filterIterator(tokenType, sender, selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "token selection failed")
+	}
+	defer it.Close()
+	var tokens []*token2.UnspentToken
+	for {
+		tok, err := it.Next()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get next unspent token from iterator")
+		}
+		if tok == nil {
+			break
+		}
+		logger.Debugf("filtered token [%s]", tok.Id)
+
+		tokens = append(tokens, tok)
+	}
+	return &token2.UnspentTokens{Tokens: tokens}, nil
+}
+
+func (w *OwnerWallet) filterIterator(tokenType token2.Type, sender bool, selector SelectFunction) (*FilteredIterator, error) {
+	var walletID string
+	if sender {
+		walletID = senderWallet(w.wallet)
+	} else {
+		walletID = recipientWallet(w.wallet)
+	}
+	it, err := w.queryEngine.UnspentTokensIteratorBy(context.TODO(), walletID, tokenType)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get iterator over unspent tokens")
+	}
+	return &FilteredIterator{
+		it:       it,
+		selector: selector,
+	}, nil
+}
+
+// GetWallet returns the wallet whose id is the passed id
+func GetWallet(sp token.ServiceProvider, id string, opts ...token.ServiceOption) *token.OwnerWallet {
+	return ttx.GetWallet(sp, id, opts...)
+}
+
+// Wallet returns an OwnerWallet which contains a wallet and a query service
+func Wallet(sp token.ServiceProvider, wallet *token.OwnerWallet) *OwnerWallet {
+	if wallet == nil {
+		return nil
+	}
+	return &OwnerWallet{
+		wallet:      wallet,
+		queryEngine: wallet.TMS().Vault().NewQueryEngine(),
+	}
+}
+
+type FilteredIterator struct {
+	it       driver.UnspentTokensIterator
+	selector SelectFunction
+}
+
+func (f *FilteredIterator) Close() {
+	f.it.Close()
+}
+
+func (f *FilteredIterator) Next() (*token2.UnspentToken, error) {
+	for {
+		tok, err := f.it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if tok == nil {
+			logger.Debugf("no more tokens!")
+			return nil, nil
+		}
+		owner, err := identity.UnmarshalTypedIdentity(tok.Owner)
+		if err != nil {
+			logger.Debugf("Is Mine [%s,%s,%s]? No, failed unmarshalling [%s]", view.Identity(tok.Owner), tok.Type, tok.Quantity, err)
+			continue
+		}
+		if owner.Type != ScriptType {
+			continue
+		}
+		script := &Script{}
+		if err := script.FromBytes(owner.Identity); err != nil || script.Sender.IsNone() {
+			logger.Debugf("token [%s,%s,%s,%s] contains a script? No", tok.Id, view.Identity(tok.Owner).UniqueID(), tok.Type, tok.Quantity)
+			continue
+		}
+		pickItem, err := f.selector(tok, script)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to select (token,script)[%v:%v] pair", tok, script)
+		}
+		if pickItem {
+			return tok, nil
+		}
+	}
+}
+
+// Sum computes the sum of the quantities of the tokens in the iterator.
+// Sum closes the iterator at the end of the execution.
+func (f *FilteredIterator) Sum(precision uint64) (token2.Quantity, error) {
+	defer f.Close()
+	sum := token2.NewZeroQuantity(precision)
+	for {
+		tok, err := f.Next()
+		if err != nil {
+			return nil, err
+		}
+		if tok == nil {
+			break
+		}
+
+		q, err := token2.ToQuantity(tok.Quantity, precision)
+		if err != nil {
+			return nil, err
+		}
+		sum = sum.Add(q)
+	}
+
+	return sum, nil
+}
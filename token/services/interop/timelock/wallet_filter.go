@@ -0,0 +1,39 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+)
+
+// SelectFunction is the prototype of a function to select pairs (token,script)
+type SelectFunction = func(*token.UnspentToken, *Script) (bool, error)
+
+// SelectLocked selects timelock-tokens that are still locked
+func SelectLocked(tok *token.UnspentToken, script *Script) (bool, error) {
+	now := time.Now()
+	logger.Debugf("token [%s,%s,%s,%s] locked, [%v]>[%v]?", tok.Id, view.Identity(tok.Owner).UniqueID(), tok.Type, tok.Quantity, script.UnlockAt, now)
+	return !script.IsUnlocked(now), nil
+}
+
+// SelectUnlocked selects timelock-tokens that are unlocked
+func SelectUnlocked(tok *token.UnspentToken, script *Script) (bool, error) {
+	now := time.Now()
+	logger.Debugf("token [%s,%s,%s,%s] unlocked, [%v]<=[%v]?", tok.Id, view.Identity(tok.Owner).UniqueID(), tok.Type, tok.Quantity, script.UnlockAt, now)
+	return script.IsUnlocked(now), nil
+}
+
+// SelectRevocable selects timelock-tokens that are still locked and can be revoked by their sender
+func SelectRevocable(tok *token.UnspentToken, script *Script) (bool, error) {
+	if !script.Revocable {
+		return false, nil
+	}
+	return SelectLocked(tok, script)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package limits
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/auditdb"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// TransactionDB is the subset of ttxdb.DB used by TransactionHistory
+type TransactionDB interface {
+	Transactions(params ttxdb.QueryTransactionsParams) (driver.TransactionIterator, error)
+}
+
+// TransactionHistory computes the value sent from the transaction records of a ttxdb.
+// It is meant for the owners, whose ttxdb stores the transactions of their wallets.
+type TransactionHistory struct {
+	db TransactionDB
+}
+
+// NewTransactionHistory returns a new TransactionHistory over the passed db
+func NewTransactionHistory(db TransactionDB) *TransactionHistory {
+	return &TransactionHistory{db: db}
+}
+
+// Sent sums the transfers to other enrollment IDs and the redeems of the passed enrollment ID
+func (h *TransactionHistory) Sent(enrollmentID string, tokenType token.Type, since time.Time) (*big.Int, error) {
+	it, err := h.db.Transactions(ttxdb.QueryTransactionsParams{
+		SenderWallet:    enrollmentID,
+		RecipientWallet: enrollmentID,
+		ExcludeToSelf:   true,
+		From:            &since,
+		ActionTypes:     []driver.ActionType{driver.Transfer, driver.Redeem},
+		Statuses:        []driver.TxStatus{driver.Pending, driver.Confirmed},
+	})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed querying transactions")
+	}
+	defer it.Close()
+
+	sum := big.NewInt(0)
+	for {
+		record, err := it.Next()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed iterating over transactions")
+		}
+		if record == nil {
+			return sum, nil
+		}
+		if record.SenderEID == enrollmentID && record.TokenType == tokenType {
+			sum.Add(sum, record.Amount)
+		}
+	}
+}
+
+// PaymentsDB is the subset of auditdb.DB used by PaymentsHistory
+type PaymentsDB interface {
+	NewPaymentsFilter() *auditdb.PaymentsFilter
+}
+
+// PaymentsHistory computes the value sent from the movements of an auditdb.
+// It is meant for the auditors, whose auditdb stores the movements of all the enrollment IDs they audit.
+type PaymentsHistory struct {
+	db PaymentsDB
+}
+
+// NewPaymentsHistory returns a new PaymentsHistory over the passed db
+func NewPaymentsHistory(db PaymentsDB) *PaymentsHistory {
+	return &PaymentsHistory{db: db}
+}
+
+// Sent sums the outgoing movements of the passed enrollment ID
+func (h *PaymentsHistory) Sent(enrollmentID string, tokenType token.Type, since time.Time) (*big.Int, error) {
+	filter, err := h.db.NewPaymentsFilter().ByEnrollmentId(enrollmentID).ByType(tokenType).Since(since).Execute()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed querying payments")
+	}
+	return filter.Sum(), nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package limits
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// Any matches any enrollment ID or token type
+const Any = "*"
+
+// ErrLimitExceeded is returned, wrapped, when a limit would be exceeded
+var ErrLimitExceeded = errors.New("spending limit exceeded")
+
+// Limit caps the value of a token type an enrollment ID can send per transaction and per rolling window.
+// A value is sent when it is transferred to another enrollment ID or redeemed.
+type Limit struct {
+	// EnrollmentID is the enrollment ID the limit applies to. Empty or Any matches all enrollment IDs
+	EnrollmentID string `yaml:"enrollmentID,omitempty"`
+	// TokenType is the token type the limit applies to. Empty or Any matches all token types
+	TokenType token2.Type `yaml:"tokenType,omitempty"`
+	// MaxPerTransaction is the maximum value sent by a single transaction. Zero means no limit
+	MaxPerTransaction uint64 `yaml:"maxPerTransaction,omitempty"`
+	// MaxPerWindow is the maximum value sent in any time window of length Window. Zero means no limit
+	MaxPerWindow uint64 `yaml:"maxPerWindow,omitempty"`
+	// Window is the length of the rolling window of MaxPerWindow
+	Window time.Duration `yaml:"window,omitempty"`
+}
+
+// Matches returns true if the limit applies to the passed enrollment ID and token type
+func (l *Limit) Matches(enrollmentID string, tokenType token2.Type) bool {
+	return (len(l.EnrollmentID) == 0 || l.EnrollmentID == Any || l.EnrollmentID == enrollmentID) &&
+		(len(l.TokenType) == 0 || l.TokenType == Any || l.TokenType == tokenType)
+}
+
+// Validate checks that the limit caps something, and that a window is set when needed
+func (l *Limit) Validate() error {
+	if l.MaxPerTransaction == 0 && l.MaxPerWindow == 0 {
+		return errors.Errorf("no limit for enrollment ID [%s] and token type [%s]", l.EnrollmentID, l.TokenType)
+	}
+	if l.MaxPerWindow != 0 && l.Window <= 0 {
+		return errors.Errorf("invalid window [%s] for enrollment ID [%s] and token type [%s]", l.Window, l.EnrollmentID, l.TokenType)
+	}
+	return nil
+}
+
+// History tells how much an enrollment ID has sent
+type History interface {
+	// Sent returns the value of the passed token type sent by the passed enrollment ID
+	// in the pending and confirmed transactions stored from the passed time on
+	Sent(enrollmentID string, tokenType token2.Type, since time.Time) (*big.Int, error)
+}
+
+// Engine enforces a list of limits. All the limits matching an enrollment ID and a token type apply.
+type Engine struct {
+	limits  []Limit
+	history History
+	now     func() time.Time
+}
+
+// NewEngine returns a new Engine for the passed limits.
+// The passed history is used to compute the value sent in the rolling windows.
+func NewEngine(history History, limits ...Limit) (*Engine, error) {
+	for i := range limits {
+		if err := limits[i].Validate(); err != nil {
+			return nil, errors.WithMessagef(err, "invalid limit at index [%d]", i)
+		}
+	}
+	return &Engine{
+		limits:  limits,
+		history: history,
+		now:     time.Now,
+	}, nil
+}
+
+// Check returns an error wrapping ErrLimitExceeded if the passed enrollment ID
+// cannot send the passed value of the passed token type
+func (e *Engine) Check(enrollmentID string, tokenType token2.Type, value *big.Int) error {
+	for _, l := range e.limits {
+		if !l.Matches(enrollmentID, tokenType) {
+			continue
+		}
+		if l.MaxPerTransaction != 0 && value.Cmp(new(big.Int).SetUint64(l.MaxPerTransaction)) > 0 {
+			return errors.Wrapf(ErrLimitExceeded, "[%s] of type [%s] sent by [%s] exceeds the limit [%d] per transaction", value, tokenType, enrollmentID, l.MaxPerTransaction)
+		}
+		if l.MaxPerWindow == 0 {
+			continue
+		}
+		sent, err := e.history.Sent(enrollmentID, tokenType, e.now().Add(-l.Window))
+		if err != nil {
+			return errors.WithMessagef(err, "failed getting the value of type [%s] sent by [%s]", tokenType, enrollmentID)
+		}
+		if total := new(big.Int).Add(sent, value); total.Cmp(new(big.Int).SetUint64(l.MaxPerWindow)) > 0 {
+			return errors.Wrapf(ErrLimitExceeded, "[%s] of type [%s] sent by [%s] in the last [%s] exceeds the limit [%d]", total, tokenType, enrollmentID, l.Window, l.MaxPerWindow)
+		}
+	}
+	return nil
+}
+
+// CheckSpending checks the passed value of the passed token type against the limits of the enrollment ID of the passed wallet
+func (e *Engine) CheckSpending(wallet *token.OwnerWallet, tokenType token2.Type, value uint64) error {
+	return e.Check(wallet.EnrollmentID(), tokenType, new(big.Int).SetUint64(value))
+}
+
+// CheckRecord checks the value sent by each enrollment ID in the passed audit record against its limits
+func (e *Engine) CheckRecord(record *token.AuditRecord) error {
+	movements, err := ttxdb.Movements(record, e.now())
+	if err != nil {
+		return errors.WithMessagef(err, "failed computing the movements of [%s]", record.Anchor)
+	}
+	for _, mv := range movements {
+		if mv.Amount.Sign() >= 0 || len(mv.EnrollmentID) == 0 {
+			continue
+		}
+		if err := e.Check(mv.EnrollmentID, mv.TokenType, new(big.Int).Neg(mv.Amount)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
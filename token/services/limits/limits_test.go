@@ -0,0 +1,139 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package limits
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/common/utils/collections"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimit_Validate(t *testing.T) {
+	assert.NoError(t, (&Limit{MaxPerTransaction: 10}).Validate())
+	assert.NoError(t, (&Limit{MaxPerWindow: 10, Window: time.Hour}).Validate())
+	assert.EqualError(t, (&Limit{EnrollmentID: "alice", TokenType: "USD"}).Validate(), "no limit for enrollment ID [alice] and token type [USD]")
+	assert.EqualError(t, (&Limit{EnrollmentID: "alice", TokenType: "USD", MaxPerWindow: 10}).Validate(), "invalid window [0s] for enrollment ID [alice] and token type [USD]")
+
+	_, err := NewEngine(&history{}, Limit{MaxPerTransaction: 10}, Limit{})
+	assert.EqualError(t, err, "invalid limit at index [1]: no limit for enrollment ID [] and token type []")
+}
+
+func TestLimit_Matches(t *testing.T) {
+	assert.True(t, (&Limit{}).Matches("alice", "USD"))
+	assert.True(t, (&Limit{EnrollmentID: Any, TokenType: Any}).Matches("alice", "USD"))
+	assert.True(t, (&Limit{EnrollmentID: "alice", TokenType: "USD"}).Matches("alice", "USD"))
+	assert.False(t, (&Limit{EnrollmentID: "alice"}).Matches("bob", "USD"))
+	assert.False(t, (&Limit{TokenType: "USD"}).Matches("alice", "EUR"))
+}
+
+func TestEngine_Check(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+	h := &history{sent: map[string]int64{"alice/USD": 800}}
+	e, err := NewEngine(h,
+		Limit{TokenType: "USD", MaxPerTransaction: 500},
+		Limit{EnrollmentID: "alice", TokenType: "USD", MaxPerWindow: 1000, Window: 24 * time.Hour},
+	)
+	assert.NoError(t, err)
+	e.now = func() time.Time { return now }
+
+	assert.NoError(t, e.Check("alice", "USD", big.NewInt(200)))
+	assert.Equal(t, now.Add(-24*time.Hour), h.since)
+
+	err = e.Check("alice", "USD", big.NewInt(201))
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	assert.EqualError(t, err, "[1001] of type [USD] sent by [alice] in the last [24h0m0s] exceeds the limit [1000]: spending limit exceeded")
+
+	err = e.Check("bob", "USD", big.NewInt(501))
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	assert.EqualError(t, err, "[501] of type [USD] sent by [bob] exceeds the limit [500] per transaction: spending limit exceeded")
+
+	// no limit on EUR
+	assert.NoError(t, e.Check("alice", "EUR", big.NewInt(10000)))
+
+	h.err = errors.New("db is down")
+	assert.EqualError(t, e.Check("alice", "USD", big.NewInt(1)), "failed getting the value of type [USD] sent by [alice]: db is down")
+}
+
+func TestEngine_CheckRecord(t *testing.T) {
+	h := &history{sent: map[string]int64{"alice/USD": 80}}
+	e, err := NewEngine(h, Limit{EnrollmentID: "alice", MaxPerWindow: 100, Window: time.Hour})
+	assert.NoError(t, err)
+
+	// alice sends 10 to bob and gets 90 back as change, bob's receipt is not limited
+	record := &token.AuditRecord{
+		Anchor: "tx1",
+		Inputs: token.NewInputStream(qs{}, []*token.Input{
+			{EnrollmentID: "alice", Type: "USD", Quantity: token2.NewQuantityFromUInt64(100)},
+		}, 64),
+		Outputs: token.NewOutputStream([]*token.Output{
+			{EnrollmentID: "bob", Type: "USD", Quantity: token2.NewQuantityFromUInt64(10)},
+			{EnrollmentID: "alice", Type: "USD", Quantity: token2.NewQuantityFromUInt64(90)},
+		}, 64),
+	}
+	assert.NoError(t, e.CheckRecord(record))
+
+	h.sent["alice/USD"] = 91
+	assert.True(t, errors.Is(e.CheckRecord(record), ErrLimitExceeded))
+}
+
+func TestTransactionHistory_Sent(t *testing.T) {
+	since := time.Now().Add(-time.Hour)
+	db := &transactionDB{records: []*driver.TransactionRecord{
+		{SenderEID: "alice", RecipientEID: "bob", TokenType: "USD", Amount: big.NewInt(10)},
+		{SenderEID: "alice", RecipientEID: "", TokenType: "USD", Amount: big.NewInt(5)},
+		{SenderEID: "alice", RecipientEID: "bob", TokenType: "EUR", Amount: big.NewInt(7)},
+		{SenderEID: "bob", RecipientEID: "alice", TokenType: "USD", Amount: big.NewInt(20)},
+	}}
+	sent, err := NewTransactionHistory(db).Sent("alice", "USD", since)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(15), sent)
+
+	assert.Equal(t, "alice", db.params.SenderWallet)
+	assert.Equal(t, "alice", db.params.RecipientWallet)
+	assert.True(t, db.params.ExcludeToSelf)
+	assert.Equal(t, &since, db.params.From)
+	assert.Equal(t, []driver.ActionType{driver.Transfer, driver.Redeem}, db.params.ActionTypes)
+	assert.Equal(t, []driver.TxStatus{driver.Pending, driver.Confirmed}, db.params.Statuses)
+}
+
+type history struct {
+	sent  map[string]int64
+	since time.Time
+	err   error
+}
+
+func (h *history) Sent(enrollmentID string, tokenType token2.Type, since time.Time) (*big.Int, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	h.since = since
+	return big.NewInt(h.sent[enrollmentID+"/"+string(tokenType)]), nil
+}
+
+type transactionDB struct {
+	records []*driver.TransactionRecord
+	params  ttxdb.QueryTransactionsParams
+}
+
+func (db *transactionDB) Transactions(params ttxdb.QueryTransactionsParams) (driver.TransactionIterator, error) {
+	db.params = params
+	return collections.NewSliceIterator(db.records), nil
+}
+
+type qs struct{}
+
+func (qs) IsMine(*token2.ID) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package limits
+
+import (
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/auditdb"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/auditor"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	"github.com/pkg/errors"
+)
+
+// ConfigKey is the key, relative to the configuration of a TMS, of the list of limits
+const ConfigKey = "limits"
+
+type TMSProvider interface {
+	GetManagementService(opts ...token.ServiceOption) (*token.ManagementService, error)
+}
+
+type TTXDBProvider interface {
+	DBByTMSId(id token.TMSID) (*ttxdb.DB, error)
+}
+
+// Provider provides the spending policies of the TMSs, built from the limits in their configuration.
+// The owners compute the value sent from their ttxdb, the auditors from their auditdb.
+type Provider struct {
+	tmsProvider   TMSProvider
+	ttxDBProvider TTXDBProvider
+}
+
+// NewProvider returns a new Provider
+func NewProvider(tmsProvider TMSProvider, ttxDBProvider TTXDBProvider) *Provider {
+	return &Provider{tmsProvider: tmsProvider, ttxDBProvider: ttxDBProvider}
+}
+
+// SpendingPolicy returns the spending policy consulted by the owner wallets of the passed TMS, nil if the TMS has no limits
+func (p *Provider) SpendingPolicy(tms *token.ManagementService) (token.SpendingPolicy, error) {
+	limits, err := Limits(tms.Configuration())
+	if err != nil || len(limits) == 0 {
+		return nil, err
+	}
+	db, err := p.ttxDBProvider.DBByTMSId(tms.ID())
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get ttxdb for [%s]", tms.ID())
+	}
+	engine, err := NewEngine(NewTransactionHistory(db), limits...)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid limits for [%s]", tms.ID())
+	}
+	return engine, nil
+}
+
+// AuditorSpendingPolicy returns the spending policy enforced by the auditor of the passed TMS, nil if the TMS has no limits
+func (p *Provider) AuditorSpendingPolicy(id token.TMSID, adb *auditdb.DB) (auditor.SpendingPolicy, error) {
+	tms, err := p.tmsProvider.GetManagementService(token.WithTMSID(id))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get tms for [%s]", id)
+	}
+	limits, err := Limits(tms.Configuration())
+	if err != nil || len(limits) == 0 {
+		return nil, err
+	}
+	engine, err := NewEngine(NewPaymentsHistory(adb), limits...)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid limits for [%s]", id)
+	}
+	return engine, nil
+}
+
+// Limits returns the limits in the passed TMS configuration
+func Limits(configuration *token.Configuration) ([]Limit, error) {
+	if !configuration.IsSet(ConfigKey) {
+		return nil, nil
+	}
+	var limits []Limit
+	if err := configuration.UnmarshalKey(ConfigKey, &limits); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshalling limits")
+	}
+	return limits, nil
+}
@@ -30,6 +30,8 @@ const (
 	InputSerialNumberPrefix      = "sn"
 	IssueActionMetadataPrefix    = "iam"
 	TransferActionMetadataPrefix = "tam"
+	FrozenTokenPrefix            = "fzt"
+	FrozenOwnerPrefix            = "fzo"
 )
 
 type Translator struct {
@@ -88,6 +90,15 @@ func (t *Translator) CreateTransferActionMetadataKey(key string) (translator.Key
 	return createCompositeKey(TransferActionMetadataPrefix, []string{key})
 }
 
+func (t *Translator) CreateFrozenTokenKey(id string, index uint64) (translator.Key, error) {
+	return createCompositeKey(FrozenTokenPrefix, []string{id, strconv.FormatUint(index, 10)})
+}
+
+func (t *Translator) CreateFrozenOwnerKey(owner []byte) (translator.Key, error) {
+	h := sha256.Sum256(owner)
+	return createCompositeKey(FrozenOwnerPrefix, []string{hex.EncodeToString(h[:])})
+}
+
 func (t *Translator) TransferActionMetadataKeyPrefix() (translator.Key, error) {
 	return createCompositeKey(TransferActionMetadataPrefix, nil)
 }
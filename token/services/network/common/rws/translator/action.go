@@ -47,6 +47,16 @@ type TransferAction interface {
 	GetMetadata() map[string][]byte
 }
 
+// FreezeAction is the action used by an enforcer to freeze, or unfreeze, tokens and owners
+type FreezeAction interface {
+	// IsUnfreeze returns true if the action unfreezes its tokens and owners
+	IsUnfreeze() bool
+	// GetTokens returns the identifiers of the tokens to freeze or unfreeze
+	GetTokens() []*token.ID
+	// GetOwners returns the owners to freeze or unfreeze
+	GetOwners() [][]byte
+}
+
 //go:generate counterfeiter -o mock/action_with_inputs.go -fake-name ActionWithInputs . ActionWithInputs
 
 type ActionWithInputs interface {
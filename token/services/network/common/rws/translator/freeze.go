@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package translator
+
+import (
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// GetStateFnc returns the value bound to the passed key
+type GetStateFnc = func(key Key) ([]byte, error)
+
+// FreezeLedger implements driver.FreezeLedger on top of the keys written by the freeze actions.
+// When the passed function reads from a rwset, the reads become dependencies of the transaction,
+// therefore a freeze committed concurrently invalidates the transaction.
+type FreezeLedger struct {
+	KeyTranslator KeyTranslator
+	GetState      GetStateFnc
+}
+
+func NewFreezeLedger(keyTranslator KeyTranslator, getState GetStateFnc) *FreezeLedger {
+	return &FreezeLedger{KeyTranslator: keyTranslator, GetState: getState}
+}
+
+func (l *FreezeLedger) IsTokenFrozen(id token.ID) (bool, error) {
+	key, err := l.KeyTranslator.CreateFrozenTokenKey(id.TxId, id.Index)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to generate frozen key for token [%s]", id)
+	}
+	return l.exists(key)
+}
+
+func (l *FreezeLedger) IsOwnerFrozen(owner driver.Identity) (bool, error) {
+	key, err := l.KeyTranslator.CreateFrozenOwnerKey(owner)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to generate frozen key for owner")
+	}
+	return l.exists(key)
+}
+
+func (l *FreezeLedger) exists(key Key) (bool, error) {
+	v, err := l.GetState(key)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read state [%s]", key)
+	}
+	return len(v) != 0, nil
+}
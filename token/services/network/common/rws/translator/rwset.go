@@ -44,6 +44,10 @@ type KeyTranslator interface {
 	GetTransferMetadataSubKey(k string) (Key, error)
 	// TransferActionMetadataKeyPrefix TODO
 	TransferActionMetadataKeyPrefix() (Key, error)
+	// CreateFrozenTokenKey creates the key that marks as frozen the token with the passed id and index
+	CreateFrozenTokenKey(id string, index uint64) (Key, error)
+	// CreateFrozenOwnerKey creates the key that marks as frozen the passed owner
+	CreateFrozenOwnerKey(owner []byte) (Key, error)
 }
 
 // RWSet interface, used to read from, and write to, a rwset.
@@ -201,6 +205,22 @@ func (h *HashedKeyTranslator) CreateTransferActionMetadataKey(key Key) (Key, err
 	return h.hash(8, k)
 }
 
+func (h *HashedKeyTranslator) CreateFrozenTokenKey(id string, index uint64) (Key, error) {
+	k, err := h.KT.CreateFrozenTokenKey(id, index)
+	if err != nil {
+		return "", err
+	}
+	return h.hash(9, k)
+}
+
+func (h *HashedKeyTranslator) CreateFrozenOwnerKey(owner []byte) (Key, error) {
+	k, err := h.KT.CreateFrozenOwnerKey(owner)
+	if err != nil {
+		return "", err
+	}
+	return h.hash(10, k)
+}
+
 func (h *HashedKeyTranslator) TransferActionMetadataKeyPrefix() (Key, error) {
 	// TODO:
 	return "", nil
@@ -198,6 +198,8 @@ func (t *Translator) checkAction(tokenAction interface{}) error {
 		return t.checkTransfer(action)
 	case SetupAction:
 		return nil
+	case FreezeAction:
+		return nil
 	default:
 		return errors.Errorf("unknown token action: %T", action)
 	}
@@ -247,10 +249,42 @@ func (t *Translator) commitAction(tokenAction interface{}) (err error) {
 		err = t.commitTransferAction(action)
 	case SetupAction:
 		err = t.commitSetupAction(action)
+	case FreezeAction:
+		err = t.commitFreezeAction(action)
 	}
 	return
 }
 
+func (t *Translator) commitFreezeAction(freezeAction FreezeAction) error {
+	var keys []string
+	for _, id := range freezeAction.GetTokens() {
+		key, err := t.KeyTranslator.CreateFrozenTokenKey(id.TxId, id.Index)
+		if err != nil {
+			return errors.Wrapf(err, "failed to generate frozen key for token [%s]", id)
+		}
+		keys = append(keys, key)
+	}
+	for _, owner := range freezeAction.GetOwners() {
+		key, err := t.KeyTranslator.CreateFrozenOwnerKey(owner)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate frozen key for owner")
+		}
+		keys = append(keys, key)
+	}
+	for _, key := range keys {
+		if freezeAction.IsUnfreeze() {
+			if err := t.RWSet.DeleteState(key); err != nil {
+				return errors.Wrapf(err, "failed to unfreeze [%s]", key)
+			}
+			continue
+		}
+		if err := t.RWSet.SetState(key, NotEmpty); err != nil {
+			return errors.Wrapf(err, "failed to freeze [%s]", key)
+		}
+	}
+	return nil
+}
+
 func (t *Translator) commitSetupAction(setup SetupAction) error {
 	raw, err := setup.GetSetupParameters()
 	if err != nil {
@@ -406,7 +440,11 @@ func (t *Translator) commitTransferAction(transferAction TransferAction) error {
 
 func (t *Translator) checkInputs(action ActionWithInputs) error {
 	// we must check that the serial number does not exist, if any are in the action
-	for _, key := range action.GetSerialNumbers() {
+	for _, id := range action.GetSerialNumbers() {
+		key, err := t.KeyTranslator.CreateInputSNKey(id)
+		if err != nil {
+			return errors.Wrapf(err, "failed to generate key for id [%s]", id)
+		}
 		if err := t.RWSet.StateMustNotExist(key); err != nil {
 			return errors.Wrapf(err, "invalid transfer: serial number must not exist")
 		}
@@ -9,6 +9,7 @@ package translator_test
 import (
 	"strconv"
 
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/keys"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator/mock"
@@ -244,11 +245,13 @@ var _ = Describe("Translator", func() {
 			It("transfer fails", func() {
 				err := writer.Write(faketransfer)
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("invalid transfer: serial number must not exist: state [tns:sn2] already exists for [0]"))
+				Expect(err.Error()).To(ContainSubstring("invalid transfer: serial number must not exist"))
+				key, err := keyTranslator.CreateInputSNKey(sn[2])
+				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeRWSet.GetStateCallCount()).To(Equal(3))
 				ns, snkey := fakeRWSet.GetStateArgsForCall(2)
 				Expect(ns).To(Equal(tokenNameSpace))
-				Expect(snkey).To(Equal(sn[2]))
+				Expect(snkey).To(Equal(key))
 			})
 		})
 		When("serial numbers cannot be added", func() {
@@ -317,4 +320,100 @@ var _ = Describe("Translator", func() {
 			})
 		})
 	})
+
+	Describe("Freeze", func() {
+		var freeze *driver.FreezeAction
+
+		BeforeEach(func() {
+			freeze = &driver.FreezeAction{
+				Enforcer: []byte("enforcer"),
+				Tokens:   []*token.ID{{TxId: "key1", Index: 1}},
+				Owners:   []driver.Identity{[]byte("owner1")},
+			}
+		})
+		When("tokens and owners are frozen", func() {
+			It("marks them as frozen", func() {
+				Expect(writer.Write(freeze)).To(Succeed())
+				Expect(fakeRWSet.SetStateCallCount()).To(Equal(2))
+
+				tokenKey, err := keyTranslator.CreateFrozenTokenKey("key1", 1)
+				Expect(err).NotTo(HaveOccurred())
+				ns, id, value := fakeRWSet.SetStateArgsForCall(0)
+				Expect(ns).To(Equal(tokenNameSpace))
+				Expect(id).To(Equal(tokenKey))
+				Expect(value).To(Equal([]byte{1}))
+
+				ownerKey, err := keyTranslator.CreateFrozenOwnerKey([]byte("owner1"))
+				Expect(err).NotTo(HaveOccurred())
+				_, id, _ = fakeRWSet.SetStateArgsForCall(1)
+				Expect(id).To(Equal(ownerKey))
+			})
+		})
+		When("tokens and owners are unfrozen", func() {
+			It("removes them from the freeze list", func() {
+				freeze.Unfreeze = true
+				Expect(writer.Write(freeze)).To(Succeed())
+				Expect(fakeRWSet.SetStateCallCount()).To(Equal(0))
+				Expect(fakeRWSet.DeleteStateCallCount()).To(Equal(2))
+
+				tokenKey, err := keyTranslator.CreateFrozenTokenKey("key1", 1)
+				Expect(err).NotTo(HaveOccurred())
+				_, id := fakeRWSet.DeleteStateArgsForCall(0)
+				Expect(id).To(Equal(tokenKey))
+			})
+		})
+	})
+
+	Describe("FreezeLedger", func() {
+		var (
+			frozenKey string
+			ledger    *translator.FreezeLedger
+		)
+
+		BeforeEach(func() {
+			var err error
+			frozenKey, err = keyTranslator.CreateFrozenTokenKey("key1", 0)
+			Expect(err).NotTo(HaveOccurred())
+			rws := translator.NewRWSetWrapper(fakeRWSet, tokenNameSpace, "0")
+			ledger = translator.NewFreezeLedger(keyTranslator, rws.GetState)
+			fakeRWSet.GetStateStub = func(_ string, key string) ([]byte, error) {
+				if key == frozenKey {
+					return translator.NotEmpty, nil
+				}
+				return nil, nil
+			}
+		})
+		When("the token is frozen", func() {
+			It("reports it, reading the frozen key", func() {
+				frozen, err := ledger.IsTokenFrozen(token.ID{TxId: "key1", Index: 0})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(frozen).To(BeTrue())
+				frozen, err = ledger.IsTokenFrozen(token.ID{TxId: "key1", Index: 1})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(frozen).To(BeFalse())
+				_, key := fakeRWSet.GetStateArgsForCall(0)
+				Expect(key).To(Equal(frozenKey))
+			})
+		})
+		When("the owner is frozen", func() {
+			It("reports it", func() {
+				frozenKey, _ = keyTranslator.CreateFrozenOwnerKey([]byte("owner1"))
+				frozen, err := ledger.IsOwnerFrozen([]byte("owner1"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(frozen).To(BeTrue())
+				frozen, err = ledger.IsOwnerFrozen([]byte("owner2"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(frozen).To(BeFalse())
+			})
+		})
+		When("the state cannot be read", func() {
+			It("fails", func() {
+				fakeRWSet.GetStateStub = nil
+				fakeRWSet.GetStateReturns(nil, errors.New("boom"))
+				_, err := ledger.IsTokenFrozen(token.ID{TxId: "key1"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("boom"))
+			})
+		})
+	})
 })
@@ -6,8 +6,41 @@ SPDX-License-Identifier: Apache-2.0
 
 package driver
 
+import "context"
+
 // Ledger models the ledger service
 type Ledger interface {
 	// Status returns the status of the transaction
 	Status(id string) (ValidationCode, error)
 }
+
+// LedgerTransaction is a transaction committed on the ledger that carries a token request
+type LedgerTransaction struct {
+	// Block is the number of the block containing the transaction, if known
+	Block uint64
+	// Index is the position of the transaction in its block, if known
+	Index uint64
+	// TxID is the transaction id
+	TxID string
+	// Status is the validation code of the transaction
+	Status ValidationCode
+	// Message is the validation message, if any
+	Message string
+	// RequestHash is the hash of the token request as committed
+	RequestHash []byte
+}
+
+// TransactionScanner is implemented by the ledgers that can enumerate the committed transactions
+type TransactionScanner interface {
+	// ScanTransactions invokes the callback, in commit order, on the transactions carrying a token request for the passed namespace,
+	// starting from the passed block and up to the height of the ledger when the scan starts.
+	// The scan stops early if the callback returns true.
+	ScanTransactions(ctx context.Context, namespace string, fromBlock uint64, callback func(tx *LedgerTransaction) (bool, error)) error
+}
+
+// TransactionFetcher is implemented by the ledgers that can fetch a committed transaction by id
+type TransactionFetcher interface {
+	// GetTransaction returns the transaction with the passed id and its token request for the passed namespace.
+	// If the transaction is not on the ledger, the status is Unknown.
+	GetTransaction(ctx context.Context, namespace string, txID string) (*LedgerTransaction, error)
+}
@@ -34,6 +34,14 @@ func (t *TxID) String() string {
 	return fmt.Sprintf("[%s:%s]", base64.StdEncoding.EncodeToString(t.Nonce), base64.StdEncoding.EncodeToString(t.Creator))
 }
 
+// ApprovalRequest is a token request to be approved for a given TMS
+type ApprovalRequest struct {
+	// TMS is the token management service the request belongs to
+	TMS *token2.ManagementService
+	// RequestRaw is the serialized token request
+	RequestRaw []byte
+}
+
 // Network models a backend that stores tokens
 type Network interface {
 	// Name returns the name of the network
@@ -55,6 +63,10 @@ type Network interface {
 	// RequestApproval requests approval for the passed request and returns the returned envelope
 	RequestApproval(context view.Context, tms *token2.ManagementService, requestRaw []byte, signer view.Identity, txID TxID) (Envelope, error)
 
+	// RequestApprovals requests approval for the passed requests, each for a different TMS of this network,
+	// and returns the envelope of the single transaction committing all of them atomically
+	RequestApprovals(context view.Context, requests []*ApprovalRequest, signer view.Identity, txID TxID) (Envelope, error)
+
 	// ComputeTxID computes the network transaction id from the passed abstract transaction id
 	ComputeTxID(id *TxID) string
 
@@ -20,13 +20,23 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
 
+// TokenRequest is a serialized token request for a given TMS
+type TokenRequest struct {
+	TMSID      token2.TMSID
+	RequestRaw []byte
+}
+
 type RequestApprovalView struct {
 	TMSID      token2.TMSID
 	TxID       driver.TxID
 	RequestRaw []byte
+	// OtherRequests, if not empty, are the token requests for other TMSs on the same channel
+	// to be validated and committed in the same transaction as RequestRaw.
+	OtherRequests []*TokenRequest
 	// RequestAnchor, if not nil it will instruct the approver to verify the token request using this anchor and not the transaction it.
 	// This is to be used only for testing.
 	RequestAnchor string
@@ -61,6 +71,11 @@ func (r *RequestApprovalView) Call(context view.Context) (interface{}, error) {
 	if err := tx.SetTransient("token_request", r.RequestRaw); err != nil {
 		return nil, errors.WithMessagef(err, "failed to set token request transient")
 	}
+	if len(r.OtherRequests) != 0 {
+		if err := tx.SetTransientState("other_token_requests", r.OtherRequests); err != nil {
+			return nil, errors.WithMessagef(err, "failed to set other token requests transient")
+		}
+	}
 	if len(r.RequestAnchor) != 0 {
 		if err := tx.SetTransient("RequestAnchor", []byte(r.RequestAnchor)); err != nil {
 			return nil, errors.WithMessagef(err, "failed to set token request transient")
@@ -140,10 +155,13 @@ func (r *RequestApprovalResponderView) Call(context view.Context) (interface{},
 		requestAnchor = tx.ID()
 	}
 
-	logger.Debugf("evaluate token request on TMS [%s]", tmsID)
-	tms := token2.GetManagementService(context, token2.WithTMSID(tmsID))
-	if tms == nil {
-		return nil, errors.Errorf("cannot find TMS for [%s]", tmsID)
+	requests := []*TokenRequest{{TMSID: tmsID, RequestRaw: requestRaw}}
+	if len(tx.GetTransient("other_token_requests")) != 0 {
+		var others []*TokenRequest
+		if err := tx.GetTransientState("other_token_requests", &others); err != nil {
+			return nil, errors.WithMessagef(err, "failed to get other token requests from transient [%s]", tx.ID())
+		}
+		requests = append(requests, others...)
 	}
 
 	rws, err := tx.RWSet()
@@ -152,37 +170,67 @@ func (r *RequestApprovalResponderView) Call(context view.Context) (interface{},
 	}
 	defer rws.Done()
 
-	fns, err := fabric2.GetFabricNetworkService(context, tms.Network())
+	// validate against the timestamp of the proposal, so that all endorsers agree on time-dependent checks.
+	// The timestamp is set by the client, it is checked against the clock of this node for each TMS.
+	now, err := proposalTimestamp(tx)
 	if err != nil {
-		return nil, errors.WithMessagef(err, "cannot find fabric network for [%s]", tms.Network())
+		return nil, err
 	}
 
-	// validate token request
-	logger.Debugf("Validate TX [%s]", tx.ID())
-	actions, validationMetadata, err := r.validate(context, tms, tx, requestAnchor, requestRaw, func(id token.ID) ([]byte, error) {
-		key, err := r.keyTranslator.CreateOutputKey(id.TxId, id.Index)
-		if err != nil {
-			return nil, errors.WithMessagef(err, "failed to create token key for id [%s]", id)
+	// validate each token request and write its actions into its own namespace
+	var endorserID view.Identity
+	namespaces := map[string]struct{}{}
+	for i, request := range requests {
+		logger.Debugf("evaluate token request on TMS [%s]", request.TMSID)
+		tms := token2.GetManagementService(context, token2.WithTMSID(request.TMSID))
+		if tms == nil {
+			return nil, errors.Errorf("cannot find TMS for [%s]", request.TMSID)
+		}
+		if len(request.RequestRaw) == 0 {
+			return nil, errors.Errorf("token request for [%s] is empty [%s]", request.TMSID, tx.ID())
+		}
+		if _, ok := namespaces[tms.Namespace()]; ok {
+			return nil, errors.Errorf("more than one token request for namespace [%s] in tx [%s]", tms.Namespace(), tx.ID())
+		}
+		namespaces[tms.Namespace()] = struct{}{}
+		if err := checkProposalTimestamp(tms, now, time.Now()); err != nil {
+			return nil, errors.WithMessagef(err, "invalid proposal timestamp for tx [%s]", tx.ID())
 		}
-		return rws.GetDirectState(tms.Namespace(), key)
-	})
 
-	if err != nil {
-		return nil, err
-	}
+		// validate token request
+		logger.Debugf("Validate TX [%s] for [%s]", tx.ID(), tms.ID())
+		actions, validationMetadata, err := r.validate(context, tms, tx, now, requestAnchor, request.RequestRaw, func(id token.ID) ([]byte, error) {
+			key, err := r.keyTranslator.CreateOutputKey(id.TxId, id.Index)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "failed to create token key for id [%s]", id)
+			}
+			return rws.GetDirectState(tms.Namespace(), key)
+		}, translator.NewFreezeLedger(r.keyTranslator, func(key translator.Key) ([]byte, error) {
+			// read through the rwset to make the freezes a dependency of the transaction
+			return rws.GetState(tms.Namespace(), key)
+		}))
+		if err != nil {
+			return nil, err
+		}
 
-	// endorse
-	logger.Debugf("Endorse TX [%s]", tx.ID())
-	endorserID, err := r.endorserID(tms, fns)
-	if err != nil {
-		return nil, err
-	}
+		// the endorser identity is the one of the TMS the approval was requested for
+		if i == 0 {
+			fns, err := fabric2.GetFabricNetworkService(context, tms.Network())
+			if err != nil {
+				return nil, errors.WithMessagef(err, "cannot find fabric network for [%s]", tms.Network())
+			}
+			logger.Debugf("Endorse TX [%s]", tx.ID())
+			endorserID, err = r.endorserID(tms, fns)
+			if err != nil {
+				return nil, err
+			}
+		}
 
-	// write actions into the transaction
-	logger.Debugf("Translate TX [%s]", tx.ID())
-	err = r.translate(tms, tx, validationMetadata, rws, actions...)
-	if err != nil {
-		return nil, err
+		// write actions into the transaction
+		logger.Debugf("Translate TX [%s] for [%s]", tx.ID(), tms.ID())
+		if err := r.translate(tms, tx, validationMetadata, rws, actions...); err != nil {
+			return nil, err
+		}
 	}
 
 	logger.Debugf("Endorse proposal for TX [%s]", tx.ID())
@@ -227,9 +275,11 @@ func (r *RequestApprovalResponderView) validate(
 	context view.Context,
 	tms *token2.ManagementService,
 	tx *endorser.Transaction,
+	now time.Time,
 	anchor string,
 	requestRaw []byte,
 	getState driver2.GetStateFnc,
+	freezes driver2.FreezeLedger,
 ) ([]any, map[string][]byte, error) {
 	defer logger.Debugf("Finished validation of TX [%s]", tx.ID())
 	logger.Debugf("Get validator for TX [%s]", tx.ID())
@@ -238,7 +288,8 @@ func (r *RequestApprovalResponderView) validate(
 		return nil, nil, errors.WithMessagef(err, "failed to get validator [%s:%s]", tms.Network(), tms.Channel())
 	}
 	logger.Debugf("Unmarshal and verify with metadata for TX [%s]", tx.ID())
-	actions, meta, err := validator.UnmarshallAndVerifyWithMetadata(context.Context(), token2.NewLedgerFromGetter(getState), anchor, requestRaw)
+	ctx := driver2.WithFreezeLedger(driver2.WithValidationTime(context.Context(), now), freezes)
+	actions, meta, err := validator.UnmarshallAndVerifyWithMetadata(ctx, token2.NewLedgerFromGetter(getState), anchor, requestRaw)
 	if err != nil {
 		return nil, nil, errors.WithMessagef(err, "failed to verify token request for [%s]", tx.ID())
 	}
@@ -258,6 +309,40 @@ func (r *RequestApprovalResponderView) validate(
 	return actions, meta, nil
 }
 
+// proposalTimestamp returns the timestamp in the channel header of the proposal of the passed transaction
+func proposalTimestamp(tx *endorser.Transaction) (time.Time, error) {
+	ts, err := headerTimestamp(tx.Transaction.Proposal().Header())
+	if err != nil {
+		return time.Time{}, errors.WithMessagef(err, "invalid proposal for tx [%s]", tx.ID())
+	}
+	return ts, nil
+}
+
+// headerTimestamp returns the timestamp in the channel header of the passed marshalled header
+func headerTimestamp(header []byte) (time.Time, error) {
+	hdr, err := protoutil.UnmarshalHeader(header)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to unmarshal header")
+	}
+	chdr, err := protoutil.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to unmarshal channel header")
+	}
+	if chdr.Timestamp == nil {
+		return time.Time{}, errors.New("missing timestamp")
+	}
+	return chdr.Timestamp.AsTime(), nil
+}
+
+// checkProposalTimestamp checks that the passed timestamp is within the maximum clock skew configured for the passed TMS
+func checkProposalTimestamp(tms *token2.ManagementService, timestamp time.Time, now time.Time) error {
+	maxSkew, err := common.ValidatorMaxClockSkew(tms.Configuration())
+	if err != nil {
+		return err
+	}
+	return common.CheckClockSkew(timestamp, now, maxSkew)
+}
+
 func (r *RequestApprovalResponderView) endorserID(tms *token2.ManagementService, fns *fabric2.NetworkService) (view.Identity, error) {
 	var endorserIDLabel string
 	if err := tms.Configuration().UnmarshalKey("services.network.fabric.fsc_endorsement.id", &endorserIDLabel); err != nil {
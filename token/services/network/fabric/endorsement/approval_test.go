@@ -0,0 +1,46 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorsement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func header(ts *timestamppb.Timestamp) []byte {
+	return protoutil.MarshalOrPanic(&cb.Header{
+		ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{TxId: "tx1", Timestamp: ts}),
+	})
+}
+
+func TestProposalTimestamp(t *testing.T) {
+	now := time.Now()
+
+	ts, err := headerTimestamp(header(timestamppb.New(now)))
+	assert.NoError(t, err)
+	assert.True(t, ts.Equal(now))
+	assert.NoError(t, common.CheckClockSkew(ts, now.Add(time.Second), common.DefaultMaxClockSkew))
+
+	// a backdated proposal, for instance to spend an expired token, is rejected
+	ts, err = headerTimestamp(header(timestamppb.New(now.Add(-time.Hour))))
+	assert.NoError(t, err)
+	assert.ErrorContains(t, common.CheckClockSkew(ts, now, common.DefaultMaxClockSkew), "is too far from the local time")
+
+	// as a post-dated one, for instance to release a timelock early
+	ts, err = headerTimestamp(header(timestamppb.New(now.Add(time.Hour))))
+	assert.NoError(t, err)
+	assert.ErrorContains(t, common.CheckClockSkew(ts, now, common.DefaultMaxClockSkew), "is too far from the local time")
+
+	_, err = headerTimestamp(header(nil))
+	assert.EqualError(t, err, "missing timestamp")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
 	token2 "github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/driver"
+	"github.com/pkg/errors"
 )
 
 const InvokeFunction = "invoke"
@@ -47,3 +48,11 @@ func (e *ChaincodeEndorsementService) Endorse(context view.Context, requestRaw [
 	}
 	return env, nil
 }
+
+// EndorseAll is not supported, the token chaincode endorses the token request of its own namespace only
+func (e *ChaincodeEndorsementService) EndorseAll(context view.Context, requests []*driver.ApprovalRequest, signer view.Identity, txID driver.TxID) (driver.Envelope, error) {
+	if len(requests) != 1 {
+		return nil, errors.Errorf("chaincode endorsement supports one token request per transaction, got [%d]", len(requests))
+	}
+	return e.Endorse(context, requests[0].RequestRaw, signer, txID)
+}
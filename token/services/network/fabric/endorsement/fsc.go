@@ -100,6 +100,32 @@ func NewFSCService(
 }
 
 func (e *FSCService) Endorse(context view.Context, requestRaw []byte, signer view.Identity, txID driver.TxID) (driver.Envelope, error) {
+	return e.requestApproval(context, &RequestApprovalView{
+		TMSID:      e.TmsID,
+		RequestRaw: requestRaw,
+		TxID:       txID,
+	})
+}
+
+// EndorseAll requests the approval of the passed requests to the endorsers of this TMS, that must be endorsers of all the TMSs involved.
+// The first request must be the one of this TMS.
+func (e *FSCService) EndorseAll(context view.Context, requests []*driver.ApprovalRequest, signer view.Identity, txID driver.TxID) (driver.Envelope, error) {
+	if len(requests) == 0 || !requests[0].TMS.ID().Equal(e.TmsID) {
+		return nil, errors.Errorf("the first token request must be for [%s]", e.TmsID)
+	}
+	others := make([]*TokenRequest, 0, len(requests)-1)
+	for _, request := range requests[1:] {
+		others = append(others, &TokenRequest{TMSID: request.TMS.ID(), RequestRaw: request.RequestRaw})
+	}
+	return e.requestApproval(context, &RequestApprovalView{
+		TMSID:         e.TmsID,
+		RequestRaw:    requests[0].RequestRaw,
+		OtherRequests: others,
+		TxID:          txID,
+	})
+}
+
+func (e *FSCService) requestApproval(context view.Context, approvalView *RequestApprovalView) (driver.Envelope, error) {
 	var endorsers []view.Identity
 	switch e.PolicyType {
 	case OneOutNPolicy:
@@ -111,12 +137,8 @@ func (e *FSCService) Endorse(context view.Context, requestRaw []byte, signer vie
 	}
 	logger.Debugf("request approval via fts endrosers with policy [%s]: [%d]...", e.PolicyType, len(endorsers))
 
-	envBoxed, err := e.ViewManager.InitiateView(&RequestApprovalView{
-		TMSID:      e.TmsID,
-		RequestRaw: requestRaw,
-		TxID:       txID,
-		Endorsers:  endorsers,
-	}, context.Context())
+	approvalView.Endorsers = endorsers
+	envBoxed, err := e.ViewManager.InitiateView(approvalView, context.Context())
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to request approval")
 	}
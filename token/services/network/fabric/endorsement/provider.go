@@ -65,6 +65,8 @@ func NewServiceProvider(
 
 type Service interface {
 	Endorse(context view.Context, requestRaw []byte, signer view.Identity, txID driver.TxID) (driver.Envelope, error)
+	// EndorseAll endorses the passed requests, for distinct TMSs on the same channel, in a single transaction
+	EndorseAll(context view.Context, requests []*driver.ApprovalRequest, signer view.Identity, txID driver.TxID) (driver.Envelope, error)
 }
 
 type loader struct {
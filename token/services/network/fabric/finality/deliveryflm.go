@@ -88,13 +88,18 @@ func NewDeliveryBasedFLMProvider(fnsp *fabric.NetworkServiceProvider, tracerProv
 
 func newEndorserDeliveryBasedFLMProvider(fnsp *fabric.NetworkServiceProvider, tracerProvider trace.TracerProvider, keyTranslator translator.KeyTranslator, config events.DeliveryListenerManagerConfig) *deliveryBasedFLMProvider {
 	return NewDeliveryBasedFLMProvider(fnsp, tracerProvider, config, func(network, _ string) events.EventInfoMapper[TxInfo] {
-		return &endorserTxInfoMapper{
-			network:       network,
-			keyTranslator: keyTranslator,
-		}
+		return NewEndorserTxInfoMapper(network, keyTranslator)
 	})
 }
 
+// NewEndorserTxInfoMapper returns a mapper that extracts, for each namespace, the token request hash committed by an endorser transaction
+func NewEndorserTxInfoMapper(network string, keyTranslator translator.KeyTranslator) events.EventInfoMapper[TxInfo] {
+	return &endorserTxInfoMapper{
+		network:       network,
+		keyTranslator: keyTranslator,
+	}
+}
+
 func (p *deliveryBasedFLMProvider) NewManager(network, channel string) (ListenerManager, error) {
 	net, err := p.fnsp.FabricNetworkService(network)
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 
 	"github.com/hyperledger-labs/fabric-smart-client/platform/common/utils/lazy"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/fabric"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/fabric/core/generic/events"
 	view2 "github.com/hyperledger-labs/fabric-smart-client/platform/view"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/tracing"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
@@ -28,6 +29,7 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel/trace"
@@ -54,7 +56,9 @@ func (n *lm) AnonymousIdentity() (view.Identity, error) {
 }
 
 type ledger struct {
-	l *fabric.Ledger
+	l        *fabric.Ledger
+	delivery *fabric.Delivery
+	mapper   events.EventInfoMapper[finality.TxInfo]
 }
 
 func (l *ledger) Status(id string) (driver.ValidationCode, error) {
@@ -71,6 +75,44 @@ func (l *ledger) Status(id string) (driver.ValidationCode, error) {
 	}
 }
 
+// ScanTransactions scans the blocks from the passed one up to the current height of the ledger,
+// and invokes the callback on the transactions carrying a token request for the passed namespace
+func (l *ledger) ScanTransactions(ctx context.Context, namespace string, fromBlock uint64, callback func(tx *driver.LedgerTransaction) (bool, error)) error {
+	info, err := l.l.GetLedgerInfo()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get ledger info")
+	}
+	if fromBlock >= info.Height {
+		return nil
+	}
+	last := info.Height - 1
+	logger.Debugf("scan blocks [%d:%d] for namespace [%s]", fromBlock, last, namespace)
+	return l.delivery.ScanBlockFrom(ctx, fromBlock, func(ctx context.Context, block *common.Block) (bool, error) {
+		for i, data := range block.Data.Data {
+			infos, err := l.mapper.MapTxData(ctx, data, block.Metadata, block.Header.Number, uint64(i))
+			if err != nil {
+				return true, errors.WithMessagef(err, "failed mapping transaction [%d:%d]", block.Header.Number, i)
+			}
+			info, ok := infos[namespace]
+			if !ok {
+				continue
+			}
+			stop, err := callback(&driver.LedgerTransaction{
+				Block:       block.Header.Number,
+				Index:       uint64(i),
+				TxID:        info.TxId,
+				Status:      info.Status,
+				Message:     info.Message,
+				RequestHash: info.RequestHash,
+			})
+			if err != nil || stop {
+				return true, err
+			}
+		}
+		return block.Header.Number >= last, nil
+	})
+}
+
 type ViewManager interface {
 	InitiateView(view view2.View, ctx context.Context) (interface{}, error)
 }
@@ -125,7 +167,7 @@ func NewNetwork(
 		ch:                         ch,
 		tmsProvider:                tmsProvider,
 		viewManager:                viewManager,
-		ledger:                     &ledger{l: ch.Ledger()},
+		ledger:                     &ledger{l: ch.Ledger(), delivery: ch.Delivery(), mapper: finality.NewEndorserTxInfoMapper(n.Name(), keyTranslator)},
 		configuration:              configuration,
 		filterProvider:             filterProvider,
 		tokensProvider:             tokensProvider,
@@ -257,6 +299,32 @@ func (n *Network) RequestApproval(context view.Context, tms *token2.ManagementSe
 	return endorsement.Endorse(context, requestRaw, signer, txID)
 }
 
+// RequestApprovals requests the approval of the passed requests in a single transaction.
+// All the TMSs must belong to this network and channel, and the endorsement service of the first TMS is used.
+func (n *Network) RequestApprovals(context view.Context, requests []*driver.ApprovalRequest, signer view.Identity, txID driver.TxID) (driver.Envelope, error) {
+	if len(requests) == 0 {
+		return nil, errors.New("no token request to approve")
+	}
+	if len(requests) == 1 {
+		return n.RequestApproval(context, requests[0].TMS, requests[0].RequestRaw, signer, txID)
+	}
+	namespaces := map[string]struct{}{}
+	for _, request := range requests {
+		if request.TMS.Network() != n.Name() || request.TMS.Channel() != n.Channel() {
+			return nil, errors.Errorf("tms [%s] does not belong to network [%s:%s]", request.TMS.ID(), n.Name(), n.Channel())
+		}
+		if _, ok := namespaces[request.TMS.Namespace()]; ok {
+			return nil, errors.Errorf("more than one token request for namespace [%s]", request.TMS.Namespace())
+		}
+		namespaces[request.TMS.Namespace()] = struct{}{}
+	}
+	endorsement, err := n.endorsementServiceProvider.Get(requests[0].TMS.ID())
+	if err != nil {
+		return nil, errors.Wrapf(err, "network not connected [%s]", requests[0].TMS.ID())
+	}
+	return endorsement.EndorseAll(context, requests, signer, txID)
+}
+
 func (n *Network) ComputeTxID(id *driver.TxID) string {
 	logger.Debugf("compute tx id for [%s]", id.String())
 	temp := &fabric.TxID{
@@ -14,9 +14,11 @@ import (
 	"os"
 	"runtime/debug"
 	"sync"
+	"time"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/keys"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator"
@@ -36,6 +38,9 @@ const (
 	QueryStates               = "queryStates"
 
 	PublicParamsPathVarEnv = "PUBLIC_PARAMS_FILE_PATH"
+	// MaxClockSkewVarEnv is the environment variable with the maximum distance, for instance `30s`,
+	// between the timestamp of a transaction and the clock of the peer. Defaults to common.DefaultMaxClockSkew.
+	MaxClockSkewVarEnv = "MAX_CLOCK_SKEW"
 )
 
 type Agent interface {
@@ -198,6 +203,23 @@ func (cc *TokenChaincode) Initialize(builtInParams string) error {
 	return nil
 }
 
+// MaxClockSkew returns the maximum clock skew set by the environment variable MaxClockSkewVarEnv,
+// common.DefaultMaxClockSkew if the variable is not set
+func (cc *TokenChaincode) MaxClockSkew() (time.Duration, error) {
+	v := os.Getenv(MaxClockSkewVarEnv)
+	if len(v) == 0 {
+		return common.DefaultMaxClockSkew, nil
+	}
+	skew, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s [%s]", MaxClockSkewVarEnv, v)
+	}
+	if skew <= 0 {
+		return 0, errors.Errorf("invalid %s [%s], it must be positive", MaxClockSkewVarEnv, v)
+	}
+	return skew, nil
+}
+
 func (cc *TokenChaincode) ReadParamsFromFile() string {
 	publicParamsPath := os.Getenv(PublicParamsPathVarEnv)
 	if publicParamsPath == "" {
@@ -223,9 +245,29 @@ func (cc *TokenChaincode) ProcessRequest(raw []byte, stub shim.ChaincodeStubInte
 		return shim.Error(err.Error())
 	}
 
+	// Validate against the timestamp of the transaction, so that all endorsers agree on time-dependent checks.
+	// The timestamp is set by the client, check that it is close to the clock of the peer.
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return shim.Error("failed to get transaction timestamp: " + err.Error())
+	}
+	if ts == nil {
+		return shim.Error("missing transaction timestamp")
+	}
+	maxSkew, err := cc.MaxClockSkew()
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if err := common.CheckClockSkew(ts.AsTime(), time.Now(), maxSkew); err != nil {
+		return shim.Error("invalid transaction timestamp: " + err.Error())
+	}
+	ctx := driver.WithValidationTime(context.Background(), ts.AsTime())
+	// Check the freezes through the stub, so that they become dependencies of the transaction
+	ctx = driver.WithFreezeLedger(ctx, translator.NewFreezeLedger(&keys.Translator{}, stub.GetState))
+
 	// Verify
 	actions, attributes, err := validator.UnmarshallAndVerifyWithMetadata(
-		context.Background(),
+		ctx,
 		&ledger{stub: stub, keyTranslator: &keys.Translator{}},
 		stub.GetTxID(),
 		raw,
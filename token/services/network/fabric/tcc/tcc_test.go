@@ -8,12 +8,14 @@ package tcc_test
 import (
 	"encoding/base64"
 	"os"
+	"time"
 
 	chaincode2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/network/fabric/tcc"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/fabric/tcc/mock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var _ = Describe("ccvalidator", func() {
@@ -45,6 +47,7 @@ var _ = Describe("ccvalidator", func() {
 		Expect(err).NotTo(HaveOccurred())
 		fakestub = &mock.ChaincodeStubInterface{}
 		fakestub.GetTxIDReturns("txid")
+		fakestub.GetTxTimestampReturns(timestamppb.Now(), nil)
 		err = os.Setenv(chaincode2.PublicParamsPathVarEnv, ppFile.Name())
 		Expect(err).NotTo(HaveOccurred())
 	})
@@ -83,6 +86,36 @@ var _ = Describe("ccvalidator", func() {
 			})
 		})
 
+		Context("Invoke is called with a timestamp too far from the clock of the peer", func() {
+			BeforeEach(func() {
+				fakestub.GetArgsReturns([][]byte{[]byte("invoke")})
+				fakestub.GetTransientReturns(map[string][]byte{"token_request": []byte("token request")}, nil)
+				fakestub.GetStateReturnsOnCall(0, []byte("pp"), nil)
+				fakestub.GetStateReturnsOnCall(1, nil, nil)
+				fakeValidator.UnmarshallAndVerifyWithMetadataReturns([]interface{}{}, nil, nil)
+			})
+			It("fails when the transaction is backdated", func() {
+				fakestub.GetTxTimestampReturns(timestamppb.New(time.Now().Add(-time.Hour)), nil)
+				response := chaincode.Invoke(fakestub)
+				Expect(response.Status).To(Equal(int32(500)))
+				Expect(response.Message).To(ContainSubstring("invalid transaction timestamp"))
+				Expect(fakeValidator.UnmarshallAndVerifyWithMetadataCallCount()).To(Equal(0))
+			})
+			It("fails when the transaction is post-dated", func() {
+				fakestub.GetTxTimestampReturns(timestamppb.New(time.Now().Add(time.Hour)), nil)
+				response := chaincode.Invoke(fakestub)
+				Expect(response.Status).To(Equal(int32(500)))
+				Expect(response.Message).To(ContainSubstring("invalid transaction timestamp"))
+			})
+			It("succeeds when the skew is within the configured bound", func() {
+				Expect(os.Setenv(chaincode2.MaxClockSkewVarEnv, "2h")).To(Succeed())
+				defer os.Unsetenv(chaincode2.MaxClockSkewVarEnv)
+				fakestub.GetTxTimestampReturns(timestamppb.New(time.Now().Add(-time.Hour)), nil)
+				response := chaincode.Invoke(fakestub)
+				Expect(response.Status).To(Equal(int32(200)))
+			})
+		})
+
 		Context("When VerifyTokenRequest fails", func() {
 			BeforeEach(func() {
 				var err error
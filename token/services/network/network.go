@@ -11,6 +11,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/hyperledger-labs/fabric-smart-client/platform/common/utils/lazy"
@@ -31,8 +32,24 @@ const (
 	Unknown = driver.Unknown // Transaction is unknown
 )
 
+// LedgerTransaction is a transaction committed on the ledger that carries a token request
+type LedgerTransaction = driver.LedgerTransaction
+
+// ErrScanNotSupported is returned when the ledger cannot enumerate the committed transactions
+var ErrScanNotSupported = errors.New("ledger does not support scanning transactions")
+
 var logger = logging.MustGetLogger("token-sdk.network")
 
+// tokenNotFound matches the errors returned by QueryTokens when a queried output does not exist on the ledger
+var tokenNotFound = regexp.MustCompile(`output for key \[[^\]]*\] does not exist`)
+
+// IsTokenNotFound returns true if the passed error, returned by QueryTokens for a single token, reports that the token
+// does not exist on the ledger. Other errors, for instance network failures, say nothing about the existence of the token.
+// The ledgers report a missing output only in the message of the error, because the error crosses process boundaries.
+func IsTokenNotFound(err error) bool {
+	return err != nil && tokenNotFound.MatchString(err.Error())
+}
+
 // FinalityListener is the interface that must be implemented to receive transaction status change notifications
 type FinalityListener interface {
 	// OnStatus is called when the status of a transaction changes
@@ -41,6 +58,9 @@ type FinalityListener interface {
 
 type GetFunc func() (view.Identity, []byte, error)
 
+// ApprovalRequest is a token request to be approved for a given TMS
+type ApprovalRequest = driver.ApprovalRequest
+
 type TxID struct {
 	Nonce   []byte
 	Creator []byte
@@ -154,6 +174,32 @@ func (l *Ledger) Status(id string) (ValidationCode, string, error) {
 	return ValidationCode(vc), "", nil
 }
 
+// CanScan returns true if the ledger can enumerate the committed transactions, in commit order
+func (l *Ledger) CanScan() bool {
+	_, ok := l.l.(driver.TransactionScanner)
+	return ok
+}
+
+// ScanTransactions invokes the callback, in commit order, on the transactions carrying a token request for the passed namespace,
+// starting from the passed block and up to the height of the ledger when the scan starts.
+// It returns ErrScanNotSupported if the ledger cannot enumerate the committed transactions.
+func (l *Ledger) ScanTransactions(ctx context.Context, namespace string, fromBlock uint64, callback func(tx *LedgerTransaction) (bool, error)) error {
+	scanner, ok := l.l.(driver.TransactionScanner)
+	if !ok {
+		return ErrScanNotSupported
+	}
+	return scanner.ScanTransactions(ctx, namespace, fromBlock, callback)
+}
+
+// GetTransaction returns the transaction with the passed id and its token request for the passed namespace
+func (l *Ledger) GetTransaction(ctx context.Context, namespace string, txID string) (*LedgerTransaction, error) {
+	fetcher, ok := l.l.(driver.TransactionFetcher)
+	if !ok {
+		return nil, errors.Errorf("ledger does not support fetching transactions")
+	}
+	return fetcher.GetTransaction(ctx, namespace, txID)
+}
+
 // Network provides access to the remote network
 type Network struct {
 	n driver.Network
@@ -201,6 +247,20 @@ func (n *Network) RequestApproval(context view.Context, tms *token.ManagementSer
 	return &Envelope{e: env}, nil
 }
 
+// RequestApprovals requests approval for the given token requests, each for a different TMS of this network.
+// The requests are committed atomically by the transaction in the returned envelope.
+// The approval is requested to the endorsers of the TMS of the first request, they must be endorsers of all the TMSs.
+func (n *Network) RequestApprovals(context view.Context, requests []*ApprovalRequest, signer view.Identity, txID TxID) (*Envelope, error) {
+	env, err := n.n.RequestApprovals(context, requests, signer, driver.TxID{
+		Nonce:   txID.Nonce,
+		Creator: txID.Creator,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{e: env}, nil
+}
+
 // ComputeTxID computes the transaction ID in the target network format for the given tx id
 func (n *Network) ComputeTxID(id *TxID) string {
 	temp := &driver.TxID{
@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	common2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/db/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/keys"
@@ -154,13 +155,17 @@ func (r *RequestApprovalResponderView) process(context view.Context, request *Ap
 		keyTranslator: r.keyTranslator,
 	}
 
+	// orion transactions carry no timestamp, the custodian, the only validator, fixes the time to validate against
+	// when it receives the request, so that all the attempts validate against the same time
+	now := time.Now()
+
 	runner := common2.NewRetryRunner(5, time.Second, true)
 
 	var envelopeRaw []byte
 	validateErr := runner.RunWithErrors(func() (bool, error) {
 		span.AddEvent("try_validate")
 		var retry bool
-		envelopeRaw, retry, err = r.validate(context, request, validator)
+		envelopeRaw, retry, err = r.validate(context, now, request, validator)
 		if err == nil {
 			return true, nil
 		}
@@ -198,7 +203,7 @@ func (r *RequestApprovalResponderView) process(context view.Context, request *Ap
 	return envelopeRaw, nil
 }
 
-func (r *RequestApprovalResponderView) validate(context view.Context, request *ApprovalRequest, validator *token.Validator) ([]byte, bool, error) {
+func (r *RequestApprovalResponderView) validate(context view.Context, now time.Time, request *ApprovalRequest, validator *token.Validator) ([]byte, bool, error) {
 	span := trace.SpanFromContext(context.Context())
 
 	sm, err := r.dbManager.GetSessionManager(request.Network)
@@ -213,10 +218,24 @@ func (r *RequestApprovalResponderView) validate(context view.Context, request *A
 	if err != nil {
 		return nil, true, errors.Wrapf(err, "failed to get query executor for orion network [%s]", request.Network)
 	}
+	tx, err := sm.Orion.TransactionManager().NewTransactionFromSession(oSession, request.TxID)
+	if err != nil {
+		return nil, true, errors.Wrapf(err, "failed to create transaction [%s]", request.TxID)
+	}
+	rws := &TxRWSWrapper{
+		me: sm.CustodianID,
+		db: request.Namespace,
+		tx: tx,
+	}
+	keyTranslator := &translator.HashedKeyTranslator{KT: &keys.Translator{}}
+
 	span.AddEvent("validate_request")
+	// the freezes are read through the transaction to make them a dependency of it
+	freezes := translator.NewFreezeLedger(keyTranslator, translator.NewRWSetWrapper(rws, "", request.TxID).GetState)
+	ctx := driver.WithFreezeLedger(driver.WithValidationTime(context.Context(), now), freezes)
 	actions, attributes, err := validator.UnmarshallAndVerifyWithMetadata(
-		context.Context(),
-		&LedgerWrapper{qe: qe, keyTranslator: &translator.HashedKeyTranslator{KT: &keys.Translator{}}},
+		ctx,
+		&LedgerWrapper{qe: qe, keyTranslator: keyTranslator},
 		request.TxID,
 		request.Request,
 	)
@@ -225,16 +244,7 @@ func (r *RequestApprovalResponderView) validate(context view.Context, request *A
 	}
 
 	// Write
-	tx, err := sm.Orion.TransactionManager().NewTransactionFromSession(oSession, request.TxID)
-	if err != nil {
-		return nil, true, errors.Wrapf(err, "failed to create transaction [%s]", request.TxID)
-	}
-	rws := &TxRWSWrapper{
-		me: sm.CustodianID,
-		db: request.Namespace,
-		tx: tx,
-	}
-	t := translator.New(request.TxID, translator.NewRWSetWrapper(rws, "", request.TxID), &translator.HashedKeyTranslator{KT: &keys.Translator{}})
+	t := translator.New(request.TxID, translator.NewRWSetWrapper(rws, "", request.TxID), keyTranslator)
 	for _, action := range actions {
 		err = t.Write(action)
 		if err != nil {
@@ -173,6 +173,14 @@ func (n *Network) RequestApproval(context view.Context, tms *token2.ManagementSe
 	return envBoxed.(driver.Envelope), nil
 }
 
+// RequestApprovals supports a single request only, orion transactions carry the token request of one namespace
+func (n *Network) RequestApprovals(context view.Context, requests []*driver.ApprovalRequest, signer view.Identity, txID driver.TxID) (driver.Envelope, error) {
+	if len(requests) != 1 {
+		return nil, errors.Errorf("orion network supports the approval of one token request per transaction, got [%d]", len(requests))
+	}
+	return n.RequestApproval(context, requests[0].TMS, requests[0].RequestRaw, signer, txID)
+}
+
 func (n *Network) ComputeTxID(id *driver.TxID) string {
 	logger.Debugf("compute tx id for [%s]", id.String())
 	temp := &orion.TxID{
@@ -254,6 +262,20 @@ func (l *ledger) Status(id string) (driver.ValidationCode, error) {
 	return boxed.(*TxStatusResponse).Status, nil
 }
 
+// GetTransaction asks the custodian for the status of the passed transaction and its token request reference in the passed namespace
+func (l *ledger) GetTransaction(ctx context.Context, namespace string, txID string) (*driver.LedgerTransaction, error) {
+	boxed, err := l.viewManager.InitiateView(NewRequestTxStatusView(l.network, namespace, txID, l.dbManager), ctx)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get status for [%s]", txID)
+	}
+	response := boxed.(*TxStatusResponse)
+	return &driver.LedgerTransaction{
+		TxID:        txID,
+		Status:      response.Status,
+		RequestHash: response.TokenRequestReference,
+	}, nil
+}
+
 type FinalityListener struct {
 	root        driver.FinalityListener
 	network     string
@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rebuild
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Checkpoint records the progress of a rebuild
+type Checkpoint struct {
+	// Position is the position of the last processed transaction, nil if none
+	Position *Position `json:"position,omitempty"`
+	// Scanned is the number of committed transactions scanned
+	Scanned uint64 `json:"scanned"`
+	// Appended is the number of valid transactions whose token request was appended to the token store
+	Appended uint64 `json:"appended"`
+	// Invalid is the number of transactions skipped because not valid
+	Invalid uint64 `json:"invalid"`
+	// Missing is the number of valid transactions skipped because their token request is not in the local stores
+	Missing uint64 `json:"missing"`
+	// Mismatched is the number of valid transactions skipped because the local token request does not match the committed one
+	Mismatched uint64 `json:"mismatched"`
+	// Pruned is the number of unspent tokens removed at the end because spent on the ledger
+	Pruned uint64 `json:"pruned"`
+	// Done is true when the last rebuild reached the end of the ledger
+	Done bool `json:"done"`
+	// UpdatedAt is the time of the checkpoint
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CheckpointStore stores the checkpoint of a rebuild
+type CheckpointStore interface {
+	// Load returns the stored checkpoint, or an empty one if none
+	Load() (*Checkpoint, error)
+	// Store durably stores the passed checkpoint
+	Store(cp *Checkpoint) error
+}
+
+// FileCheckpoints stores the checkpoint in a JSON file
+type FileCheckpoints struct {
+	path string
+}
+
+// NewFileCheckpoints returns a CheckpointStore backed by the file at the passed path
+func NewFileCheckpoints(path string) (*FileCheckpoints, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, errors.Wrapf(err, "failed creating directory of [%s]", path)
+	}
+	return &FileCheckpoints{path: path}, nil
+}
+
+func (f *FileCheckpoints) Load() (*Checkpoint, error) {
+	raw, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return &Checkpoint{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading checkpoint [%s]", f.path)
+	}
+	cp := &Checkpoint{}
+	if err := json.Unmarshal(raw, cp); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshalling checkpoint [%s]", f.path)
+	}
+	return cp, nil
+}
+
+func (f *FileCheckpoints) Store(cp *Checkpoint) error {
+	raw, err := json.Marshal(cp)
+	if err != nil {
+		return errors.Wrapf(err, "failed marshalling checkpoint")
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return errors.Wrapf(err, "failed writing checkpoint [%s]", tmp)
+	}
+	return errors.Wrapf(os.Rename(tmp, f.path), "failed replacing checkpoint [%s]", f.path)
+}
+
+// MemoryCheckpoints keeps the checkpoint in memory
+type MemoryCheckpoints struct {
+	mutex sync.Mutex
+	cp    Checkpoint
+}
+
+func (m *MemoryCheckpoints) Load() (*Checkpoint, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	cp := m.cp
+	return &cp, nil
+}
+
+func (m *MemoryCheckpoints) Store(cp *Checkpoint) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.cp = *cp
+	return nil
+}
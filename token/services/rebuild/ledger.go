@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rebuild
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	"github.com/pkg/errors"
+)
+
+// Position locates a transaction in the scan of a ledger
+type Position struct {
+	Block uint64 `json:"block"`
+	Index uint64 `json:"index"`
+	TxID  string `json:"tx_id"`
+}
+
+// ScanFunc is invoked on each committed transaction, together with its position
+type ScanFunc = func(pos Position, tx *network.LedgerTransaction) error
+
+// Ledger gives access to the transactions committed for a namespace
+type Ledger interface {
+	// Scan invokes the callback on the committed transactions following the passed position, or from the beginning if nil,
+	// up to the current height of the ledger
+	Scan(ctx context.Context, after *Position, callback ScanFunc) error
+	// Ordered returns true if Scan follows the commit order.
+	// If not, a spend might be processed before the token it spends.
+	Ordered() bool
+}
+
+// NetworkLedger returns the ledger of the passed network for the passed namespace.
+// If the network cannot enumerate the committed transactions, like Orion, the transactions
+// whose token request is known to the passed stores are fetched one by one.
+func NetworkLedger(ledger *network.Ledger, namespace string, stores ...RequestStore) Ledger {
+	if ledger.CanScan() {
+		return &scanningLedger{ledger: ledger, namespace: namespace}
+	}
+	return &fetchingLedger{ledger: ledger, namespace: namespace, stores: stores}
+}
+
+// scanningLedger scans the blocks of the ledger
+type scanningLedger struct {
+	ledger    *network.Ledger
+	namespace string
+}
+
+func (l *scanningLedger) Scan(ctx context.Context, after *Position, callback ScanFunc) error {
+	var from uint64
+	if after != nil {
+		from = after.Block
+	}
+	return l.ledger.ScanTransactions(ctx, l.namespace, from, func(tx *network.LedgerTransaction) (bool, error) {
+		if after != nil && (tx.Block < after.Block || tx.Block == after.Block && tx.Index <= after.Index) {
+			return false, nil
+		}
+		return false, callback(Position{Block: tx.Block, Index: tx.Index, TxID: tx.TxID}, tx)
+	})
+}
+
+func (l *scanningLedger) Ordered() bool {
+	return true
+}
+
+// fetchingLedger fetches, in transaction id order, the transactions whose token request is in the local stores
+type fetchingLedger struct {
+	ledger    *network.Ledger
+	namespace string
+	stores    []RequestStore
+}
+
+func (l *fetchingLedger) Scan(ctx context.Context, after *Position, callback ScanFunc) error {
+	txIDs, err := candidates(l.stores)
+	if err != nil {
+		return err
+	}
+	for i, txID := range txIDs {
+		if after != nil && txID <= after.TxID {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		tx, err := l.ledger.GetTransaction(ctx, l.namespace, txID)
+		if err != nil {
+			return errors.WithMessagef(err, "failed fetching transaction [%s]", txID)
+		}
+		if err := callback(Position{Index: uint64(i), TxID: txID}, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *fetchingLedger) Ordered() bool {
+	return false
+}
+
+// candidates returns the sorted ids of the transactions whose token request is in the passed stores
+func candidates(stores []RequestStore) ([]string, error) {
+	set := map[string]struct{}{}
+	for _, store := range stores {
+		it, err := store.TokenRequests(ttxdb.QueryTokenRequestsParams{})
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed querying token requests")
+		}
+		for {
+			record, err := it.Next()
+			if err != nil {
+				it.Close()
+				return nil, errors.WithMessagef(err, "failed iterating token requests")
+			}
+			if record == nil {
+				break
+			}
+			set[record.TxID] = struct{}{}
+		}
+		it.Close()
+	}
+	txIDs := make([]string, 0, len(set))
+	for txID := range set {
+		txIDs = append(txIDs, txID)
+	}
+	sort.Strings(txIDs)
+	return txIDs, nil
+}
+
+// MemoryLedger is a local stand-in for a ledger, with one transaction per block.
+// It is meant for testing.
+type MemoryLedger struct {
+	mutex sync.RWMutex
+	txs   []*network.LedgerTransaction
+}
+
+// NewMemoryLedger returns a new empty MemoryLedger
+func NewMemoryLedger() *MemoryLedger {
+	return &MemoryLedger{}
+}
+
+// Commit appends to the ledger a transaction with the passed status and token request hash
+func (l *MemoryLedger) Commit(txID string, status network.ValidationCode, requestHash []byte) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.txs = append(l.txs, &network.LedgerTransaction{
+		Block:       uint64(len(l.txs)),
+		TxID:        txID,
+		Status:      status,
+		RequestHash: requestHash,
+	})
+}
+
+func (l *MemoryLedger) Scan(ctx context.Context, after *Position, callback ScanFunc) error {
+	l.mutex.RLock()
+	txs := l.txs
+	l.mutex.RUnlock()
+
+	from := 0
+	if after != nil {
+		from = int(after.Block) + 1
+	}
+	for i := from; i < len(txs); i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := callback(Position{Block: txs[i].Block, TxID: txs[i].TxID}, txs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *MemoryLedger) Ordered() bool {
+	return true
+}
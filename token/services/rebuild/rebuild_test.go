@@ -0,0 +1,197 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rebuild
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tmsID = token.TMSID{Network: "pineapple", Channel: "ch", Namespace: "zkat"}
+
+type requestStore map[string][]byte
+
+func (s requestStore) GetTokenRequest(txID string) ([]byte, error) {
+	return s[txID], nil
+}
+
+func (s requestStore) TokenRequests(ttxdb.QueryTokenRequestsParams) (driver.TokenRequestIterator, error) {
+	var records []*driver.TokenRequestRecord
+	for txID, raw := range s {
+		records = append(records, &driver.TokenRequestRecord{TxID: txID, TokenRequest: raw})
+	}
+	return &iterator{records: records}, nil
+}
+
+type iterator struct {
+	records []*driver.TokenRequestRecord
+}
+
+func (it *iterator) Close() {}
+
+func (it *iterator) Next() (*driver.TokenRequestRecord, error) {
+	if len(it.records) == 0 {
+		return nil, nil
+	}
+	r := it.records[0]
+	it.records = it.records[1:]
+	return r, nil
+}
+
+type tokenStore struct {
+	appended []string
+	failOn   string
+	pruned   int
+}
+
+func (t *tokenStore) AppendRaw(_ context.Context, _ token.TMSID, txID string, _ []byte) error {
+	if txID == t.failOn {
+		return errors.New("boom")
+	}
+	t.appended = append(t.appended, txID)
+	return nil
+}
+
+func (t *tokenStore) PruneInvalidUnspentTokens(context.Context) ([]*token2.ID, error) {
+	t.pruned++
+	return []*token2.ID{{TxId: "tx1", Index: 0}}, nil
+}
+
+// checker accepts a request whose hash is the request itself
+func checker(_ string, raw []byte, hash []byte) error {
+	if string(raw) != string(hash) {
+		return errors.New("mismatch")
+	}
+	return nil
+}
+
+func TestRebuild(t *testing.T) {
+	ledger := NewMemoryLedger()
+	ledger.Commit("tx1", network.Valid, []byte("r1"))
+	ledger.Commit("tx2", network.Invalid, []byte("r2"))
+	ledger.Commit("tx3", network.Valid, []byte("r3"))
+	ledger.Commit("tx4", network.Valid, []byte("other"))
+	ledger.Commit("tx5", network.Valid, []byte("r5"))
+	requests := requestStore{"tx1": []byte("r1"), "tx2": []byte("r2"), "tx4": []byte("r4"), "tx5": []byte("r5")}
+
+	checkpoints, err := NewFileCheckpoints(path.Join(t.TempDir(), "rebuild", "checkpoint.json"))
+	require.NoError(t, err)
+	toks := &tokenStore{failOn: "tx5"}
+	var progress []uint64
+	r := &Rebuilder{
+		TMSID:           tmsID,
+		Ledger:          ledger,
+		Tokens:          toks,
+		Requests:        []RequestStore{requestStore{}, requests},
+		Checker:         checker,
+		Checkpoints:     checkpoints,
+		Pruner:          toks,
+		CheckpointEvery: 2,
+		Progress:        func(cp Checkpoint) { progress = append(progress, cp.Scanned) },
+	}
+
+	// the failure on tx5 stops the rebuild, the checkpoint points to tx4
+	cp, err := r.Run(context.Background())
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "boom")
+	assert.Equal(t, "tx4", cp.Position.TxID)
+	assert.False(t, cp.Done)
+	assert.Equal(t, []string{"tx1"}, toks.appended)
+	assert.Equal(t, []uint64{2, 4, 4}, progress)
+	stored, err := checkpoints.Load()
+	require.NoError(t, err)
+	assert.Equal(t, cp.Position, stored.Position)
+
+	// the second run resumes from tx5
+	toks.failOn = ""
+	ledger.Commit("tx6", network.Valid, []byte("r6"))
+	cp, err = r.Run(context.Background())
+	require.NoError(t, err)
+	assert.True(t, cp.Done)
+	assert.Equal(t, []string{"tx1", "tx5"}, toks.appended)
+	assert.Equal(t, uint64(6), cp.Scanned)
+	assert.Equal(t, uint64(2), cp.Appended)
+	assert.Equal(t, uint64(1), cp.Invalid)
+	assert.Equal(t, uint64(2), cp.Missing)
+	assert.Equal(t, uint64(1), cp.Mismatched)
+	assert.Equal(t, "tx6", cp.Position.TxID)
+	// the tokens spent in transactions without a local token request are pruned at the end of each completed run
+	assert.Equal(t, 1, toks.pruned)
+	assert.Equal(t, uint64(1), cp.Pruned)
+
+	// nothing new, nothing to do
+	cp, err = r.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), cp.Scanned)
+	assert.Equal(t, []string{"tx1", "tx5"}, toks.appended)
+	assert.Equal(t, 2, toks.pruned)
+}
+
+// unorderedLedger scans the memory ledger in reverse order
+type unorderedLedger struct {
+	*MemoryLedger
+}
+
+func (l *unorderedLedger) Scan(ctx context.Context, after *Position, callback ScanFunc) error {
+	var txs []*network.LedgerTransaction
+	if err := l.MemoryLedger.Scan(ctx, after, func(_ Position, tx *network.LedgerTransaction) error {
+		txs = append([]*network.LedgerTransaction{tx}, txs...)
+		return nil
+	}); err != nil {
+		return err
+	}
+	for i, tx := range txs {
+		if err := callback(Position{Index: uint64(i), TxID: tx.TxID}, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *unorderedLedger) Ordered() bool {
+	return false
+}
+
+func TestRebuildUnordered(t *testing.T) {
+	ledger := NewMemoryLedger()
+	ledger.Commit("tx1", network.Valid, []byte("r1"))
+	ledger.Commit("tx2", network.Valid, []byte("r2"))
+	toks := &tokenStore{}
+	r := &Rebuilder{
+		TMSID:       tmsID,
+		Ledger:      &unorderedLedger{MemoryLedger: ledger},
+		Tokens:      toks,
+		Requests:    []RequestStore{requestStore{"tx1": []byte("r1"), "tx2": []byte("r2")}},
+		Checkpoints: &MemoryCheckpoints{},
+		Pruner:      toks,
+	}
+	cp, err := r.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tx2", "tx1"}, toks.appended)
+	assert.Equal(t, 1, toks.pruned)
+	assert.Equal(t, uint64(1), cp.Pruned)
+	assert.True(t, cp.Done)
+}
+
+func TestCandidates(t *testing.T) {
+	txIDs, err := candidates([]RequestStore{
+		requestStore{"tx3": nil, "tx1": nil},
+		requestStore{"tx2": nil, "tx1": nil},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tx1", "tx2", "tx3"}, txIDs)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rebuild
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/hash"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.MustGetLogger("token-sdk.rebuild")
+
+const defaultCheckpointEvery = 100
+
+// RequestStore gives access to the token requests known to the node, like the transaction and audit stores
+type RequestStore interface {
+	// GetTokenRequest returns the token request bound to the passed transaction id, nil if not available
+	GetTokenRequest(txID string) ([]byte, error)
+	// TokenRequests returns an iterator over the token requests matching the passed params
+	TokenRequests(params ttxdb.QueryTokenRequestsParams) (driver.TokenRequestIterator, error)
+}
+
+// Tokens appends the content of a token request to the token store
+type Tokens interface {
+	// AppendRaw parses the passed token request and stores the tokens it creates and deletes
+	AppendRaw(ctx context.Context, tmsID token.TMSID, txID string, requestRaw []byte) error
+}
+
+// Pruner removes the unspent tokens that are spent on the ledger
+type Pruner interface {
+	PruneInvalidUnspentTokens(ctx context.Context) ([]*token2.ID, error)
+}
+
+// RequestChecker returns an error if the passed token request does not match the passed hash committed on the ledger
+type RequestChecker = func(txID string, raw []byte, hash []byte) error
+
+// NewRequestChecker returns a RequestChecker that parses the token requests with the passed TMS
+func NewRequestChecker(tms *token.ManagementService) RequestChecker {
+	return func(txID string, raw []byte, reference []byte) error {
+		request, err := token.NewFullRequestFromBytes(tms, raw)
+		if err != nil {
+			return errors.WithMessagef(err, "failed unmarshalling token request [%s]", txID)
+		}
+		trToSign, err := request.MarshalToSign()
+		if err != nil {
+			return errors.WithMessagef(err, "can't get request hash [%s]", txID)
+		}
+		if base64.StdEncoding.EncodeToString(reference) != hash.Hashable(trToSign).String() {
+			return errors.Errorf(
+				"token requests do not match, tr hashes [%s][%s]",
+				base64.StdEncoding.EncodeToString(reference),
+				hash.Hashable(trToSign),
+			)
+		}
+		return nil
+	}
+}
+
+// Rebuilder re-derives the token store of a TMS from the transactions committed on the ledger.
+// The ledger tells which transactions are valid, and in which order, while the token requests
+// come from the local stores. Progress is checkpointed, a new run continues from the last checkpoint.
+type Rebuilder struct {
+	TMSID       token.TMSID
+	Ledger      Ledger
+	Tokens      Tokens
+	Requests    []RequestStore
+	Checker     RequestChecker
+	Checkpoints CheckpointStore
+	// Pruner is used at the end of the run, can be nil
+	Pruner Pruner
+	// CheckpointEvery is the number of scanned transactions between two checkpoints, defaults to 100
+	CheckpointEvery int
+	// Progress is invoked at each checkpoint, can be nil
+	Progress func(cp Checkpoint)
+}
+
+// Run rebuilds the token store and returns the last checkpoint.
+// On error, the checkpoint points to the last transaction processed successfully.
+func (r *Rebuilder) Run(ctx context.Context) (*Checkpoint, error) {
+	if r.Ledger == nil || r.Tokens == nil || r.Checkpoints == nil {
+		return nil, errors.New("ledger, tokens, and checkpoints must be set")
+	}
+	every := r.CheckpointEvery
+	if every <= 0 {
+		every = defaultCheckpointEvery
+	}
+	cp, err := r.Checkpoints.Load()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed loading checkpoint")
+	}
+	cp.Done = false
+	if cp.Position != nil {
+		logger.Infof("rebuild of [%s] resumes after [%s]", r.TMSID, cp.Position.TxID)
+	}
+
+	pending := 0
+	err = r.Ledger.Scan(ctx, cp.Position, func(pos Position, tx *network.LedgerTransaction) error {
+		if err := r.process(ctx, cp, tx); err != nil {
+			return err
+		}
+		cp.Scanned++
+		cp.Position = &pos
+		pending++
+		if pending < every {
+			return nil
+		}
+		pending = 0
+		return r.checkpoint(cp)
+	})
+	if err != nil {
+		if err2 := r.checkpoint(cp); err2 != nil {
+			logger.Errorf("failed storing checkpoint of [%s]: [%s]", r.TMSID, err2)
+		}
+		return cp, errors.WithMessagef(err, "failed rebuilding [%s]", r.TMSID)
+	}
+
+	// the tokens of the node might have been spent in transactions whose token request is not in the local stores,
+	// like forced transfers, htlc claims, or spends of a co-owner. Moreover, when the ledger is not ordered,
+	// a spend might have been processed before the token it spends
+	if r.Pruner != nil {
+		pruned, err := r.Pruner.PruneInvalidUnspentTokens(ctx)
+		if err != nil {
+			return cp, errors.WithMessagef(err, "failed pruning unspent tokens of [%s]", r.TMSID)
+		}
+		cp.Pruned += uint64(len(pruned))
+	}
+	cp.Done = true
+	if err := r.checkpoint(cp); err != nil {
+		return cp, err
+	}
+	logger.Infof("rebuild of [%s] done: [%d] scanned, [%d] appended", r.TMSID, cp.Scanned, cp.Appended)
+	return cp, nil
+}
+
+func (r *Rebuilder) process(ctx context.Context, cp *Checkpoint, tx *network.LedgerTransaction) error {
+	if tx.Status != network.Valid {
+		logger.Debugf("skipping tx [%s] with status [%d]", tx.TxID, tx.Status)
+		cp.Invalid++
+		return nil
+	}
+	raw, err := r.request(tx.TxID)
+	if err != nil {
+		return err
+	}
+	if len(raw) == 0 {
+		// not a transaction this node took part in
+		logger.Debugf("skipping tx [%s]: no token request", tx.TxID)
+		cp.Missing++
+		return nil
+	}
+	if r.Checker != nil {
+		if err := r.Checker(tx.TxID, raw, tx.RequestHash); err != nil {
+			logger.Warnf("skipping tx [%s]: %s", tx.TxID, err)
+			cp.Mismatched++
+			return nil
+		}
+	}
+	if err := r.Tokens.AppendRaw(ctx, r.TMSID, tx.TxID, raw); err != nil {
+		return errors.WithMessagef(err, "failed appending token request [%s]", tx.TxID)
+	}
+	cp.Appended++
+	return nil
+}
+
+func (r *Rebuilder) request(txID string) ([]byte, error) {
+	for _, store := range r.Requests {
+		raw, err := store.GetTokenRequest(txID)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed getting token request [%s]", txID)
+		}
+		if len(raw) != 0 {
+			return raw, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *Rebuilder) checkpoint(cp *Checkpoint) error {
+	cp.UpdatedAt = time.Now().UTC()
+	if err := r.Checkpoints.Store(cp); err != nil {
+		return errors.WithMessagef(err, "failed storing checkpoint")
+	}
+	if r.Progress != nil {
+		r.Progress(*cp)
+	}
+	return nil
+}
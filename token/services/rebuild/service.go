@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rebuild
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/auditdb"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokens"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	"github.com/pkg/errors"
+)
+
+type TMSProvider interface {
+	GetManagementService(opts ...token.ServiceOption) (*token.ManagementService, error)
+}
+
+type NetworkProvider interface {
+	GetNetwork(network string, channel string) (*network.Network, error)
+}
+
+type TokensProvider interface {
+	Tokens(tmsID token.TMSID) (*tokens.Tokens, error)
+}
+
+type TTXDBProvider interface {
+	DBByTMSId(id token.TMSID) (*ttxdb.DB, error)
+}
+
+type AuditDBProvider interface {
+	DBByTMSId(id token.TMSID) (*auditdb.DB, error)
+}
+
+// Service rebuilds the token store of a TMS from the ledger
+type Service struct {
+	tmsProvider     TMSProvider
+	networkProvider NetworkProvider
+	tokensProvider  TokensProvider
+	ttxDBProvider   TTXDBProvider
+	auditDBProvider AuditDBProvider
+}
+
+// NewService returns a new rebuild Service
+func NewService(
+	tmsProvider TMSProvider,
+	networkProvider NetworkProvider,
+	tokensProvider TokensProvider,
+	ttxDBProvider TTXDBProvider,
+	auditDBProvider AuditDBProvider,
+) *Service {
+	return &Service{
+		tmsProvider:     tmsProvider,
+		networkProvider: networkProvider,
+		tokensProvider:  tokensProvider,
+		ttxDBProvider:   ttxDBProvider,
+		auditDBProvider: auditDBProvider,
+	}
+}
+
+// Rebuild re-derives the token store of the passed TMS from the transactions committed on the ledger,
+// storing the progress in the file at the passed path. If the file exists, the rebuild continues from there.
+func (s *Service) Rebuild(ctx context.Context, tmsID token.TMSID, checkpointPath string) (*Checkpoint, error) {
+	checkpoints, err := NewFileCheckpoints(checkpointPath)
+	if err != nil {
+		return nil, err
+	}
+	rebuilder, err := s.NewRebuilder(tmsID, checkpoints)
+	if err != nil {
+		return nil, err
+	}
+	return rebuilder.Run(ctx)
+}
+
+// NewRebuilder returns a Rebuilder for the passed TMS, backed by the network ledger and the local stores
+func (s *Service) NewRebuilder(tmsID token.TMSID, checkpoints CheckpointStore) (*Rebuilder, error) {
+	tms, err := s.tmsProvider.GetManagementService(token.WithTMSID(tmsID))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting tms [%s]", tmsID)
+	}
+	tmsID = tms.ID()
+	ttxDB, err := s.ttxDBProvider.DBByTMSId(tmsID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting ttxdb for [%s]", tmsID)
+	}
+	requests := []RequestStore{ttxDB}
+	if tms.Authorization().AmIAnAuditor() {
+		auditDB, err := s.auditDBProvider.DBByTMSId(tmsID)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed getting auditdb for [%s]", tmsID)
+		}
+		requests = append(requests, auditDB)
+	}
+	tokens, err := s.tokensProvider.Tokens(tmsID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting tokens for [%s]", tmsID)
+	}
+	net, err := s.networkProvider.GetNetwork(tmsID.Network, tmsID.Channel)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting network [%s:%s]", tmsID.Network, tmsID.Channel)
+	}
+	ledger, err := net.Ledger()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting ledger of [%s:%s]", tmsID.Network, tmsID.Channel)
+	}
+	return &Rebuilder{
+		TMSID:       tmsID,
+		Ledger:      NetworkLedger(ledger, tmsID.Namespace, requests...),
+		Tokens:      tokens,
+		Requests:    requests,
+		Checker:     NewRequestChecker(tms),
+		Checkpoints: checkpoints,
+		Pruner:      tokens,
+	}, nil
+}
+
+var serviceType = reflect.TypeOf((*Service)(nil))
+
+// GetService returns the rebuild Service registered in the passed service provider
+func GetService(sp token.ServiceProvider) (*Service, error) {
+	s, err := sp.GetService(serviceType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get rebuild service")
+	}
+	return s.(*Service), nil
+}
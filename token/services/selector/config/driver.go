@@ -31,6 +31,9 @@ type Config struct {
 	NumRetries             int           `yaml:"numRetries,omitempty"`
 	LeaseExpiry            time.Duration `yaml:"leaseExpiry,omitempty"`
 	LeaseCleanupTickPeriod time.Duration `yaml:"leaseCleanupTickPeriod,omitempty"`
+	// Strategy is the coin-selection strategy used by the selector, if supported.
+	// It can be overridden per TMS with the key token.tms.<id>.selector.strategy.
+	Strategy string `yaml:"strategy,omitempty"`
 }
 
 // New returns a SelectorConfig with the values from the token.selector key
@@ -77,3 +80,7 @@ func (c *Config) GetLeaseCleanupTickPeriod() time.Duration {
 	}
 	return defaultLeaseCleanupTickPeriod
 }
+
+func (c *Config) GetStrategy() string {
+	return c.Strategy
+}
@@ -21,6 +21,7 @@ type SelectorConfig interface {
 	GetRetryInterval() time.Duration
 	GetLeaseExpiry() time.Duration
 	GetLeaseCleanupTickPeriod() time.Duration
+	GetStrategy() string
 }
 
 type Driver string
@@ -52,13 +52,14 @@ func NewManager(
 	maxRetriesAfterBackOff int,
 	leaseExpiry time.Duration,
 	leaseCleanupTickPeriod time.Duration,
+	strategy Strategy,
 ) *manager {
 	m := &manager{
 		locker:                 locker,
 		leaseExpiry:            leaseExpiry,
 		leaseCleanupTickPeriod: leaseCleanupTickPeriod,
 		selectorCache: lazy2.NewProvider(func(txID transaction.ID) (tokenSelectorUnlocker, error) {
-			return NewSherdSelector(txID, fetcher, locker, precision, backoff, maxRetriesAfterBackOff), nil
+			return NewSherdSelector(txID, fetcher, locker, precision, backoff, maxRetriesAfterBackOff, strategy), nil
 		}),
 	}
 	if leaseCleanupTickPeriod > 0 && leaseExpiry > 0 {
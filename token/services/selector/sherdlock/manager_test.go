@@ -93,7 +93,7 @@ func createManager(pgConnStr string, backoff time.Duration, maxRetries int) (tes
 	}
 
 	fetcher := newMixedFetcher(tokenDB.(common2.TestTokenDB), newMetrics(&disabled.Provider{}))
-	manager := NewManager(fetcher, lockDB, testutils.TokenQuantityPrecision, backoff, maxRetries, 0, 0)
+	manager := NewManager(fetcher, lockDB, testutils.TokenQuantityPrecision, backoff, maxRetries, 0, 0, nil)
 
 	return testutils.NewEnhancedManager(manager, tokenDB.(common2.TestTokenDB)), nil
 }
@@ -8,6 +8,7 @@ package sherdlock
 
 import (
 	"fmt"
+	"math/big"
 	"math/rand"
 	"time"
 
@@ -45,6 +46,8 @@ type selector struct {
 	fetcher   tokenFetcher
 	locker    tokenLocker
 	precision uint64
+	// strategy decides the order in which tokens are locked, nil means first-fit
+	strategy Strategy
 }
 
 type stubbornSelector struct {
@@ -71,21 +74,22 @@ func (m *stubbornSelector) Select(owner token.OwnerFilter, q string, currency to
 	return nil, nil, errors.Wrapf(token.SelectorInsufficientFunds, "aborted too many times and no other process unlocked or added tokens")
 }
 
-func NewStubbornSelector(logger logging.Logger, tokenDB tokenFetcher, lockDB tokenLocker, precision uint64, backoff time.Duration, retries int) *stubbornSelector {
+func NewStubbornSelector(logger logging.Logger, tokenDB tokenFetcher, lockDB tokenLocker, precision uint64, backoff time.Duration, retries int, strategy Strategy) *stubbornSelector {
 	return &stubbornSelector{
-		selector:               NewSelector(logger, tokenDB, lockDB, precision),
+		selector:               NewSelector(logger, tokenDB, lockDB, precision, strategy),
 		backoffInterval:        backoff,
 		maxRetriesAfterBackoff: retries,
 	}
 }
 
-func NewSelector(logger logging.Logger, tokenDB tokenFetcher, lockDB tokenLocker, precision uint64) *selector {
+func NewSelector(logger logging.Logger, tokenDB tokenFetcher, lockDB tokenLocker, precision uint64, strategy Strategy) *selector {
 	return &selector{
 		logger:    logger,
 		cache:     collections.NewEmptyIterator[*token2.UnspentTokenInWallet](),
 		fetcher:   tokenDB,
 		locker:    lockDB,
 		precision: precision,
+		strategy:  strategy,
 	}
 }
 
@@ -132,6 +136,12 @@ func (s *selector) Select(owner token.OwnerFilter, q string, currency token2.Typ
 				err2 := s.locker.UnlockAll()
 				return nil, nil, errors.Wrapf(err, "failed to reload tokens for retry %d [%s:%s] - unlock: %v", immediateRetries, owner.ID(), currency, err2)
 			}
+			if s.strategy != nil {
+				if s.cache, err = s.order(s.cache, selected, new(big.Int).Sub(quantity.ToBigInt(), sum.ToBigInt())); err != nil {
+					err2 := s.locker.UnlockAll()
+					return nil, nil, errors.Wrapf(err, "failed to order tokens for retry %d [%s:%s] - unlock: %v", immediateRetries, owner.ID(), currency, err2)
+				}
+			}
 
 			immediateRetries++
 			tokensLockedByOthersExist = false
@@ -155,6 +165,40 @@ func (s *selector) Select(owner token.OwnerFilter, q string, currency token2.Typ
 	}
 }
 
+// order drains the passed iterator and returns an iterator over the same tokens, minus those already selected,
+// in the order given by the selection strategy
+func (s *selector) order(it iterator[*token2.UnspentTokenInWallet], selected collections.Set[*token2.ID], target *big.Int) (iterator[*token2.UnspentTokenInWallet], error) {
+	defer it.Close()
+	alreadySelected := make(map[token2.ID]struct{}, selected.Length())
+	for _, id := range selected.ToSlice() {
+		alreadySelected[*id] = struct{}{}
+	}
+	var candidates []Candidate
+	for {
+		t, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if t == nil {
+			break
+		}
+		if _, ok := alreadySelected[*t.Id]; ok {
+			continue
+		}
+		q, err := token2.ToQuantity(t.Quantity, s.precision)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid token [%s] found", t.Id)
+		}
+		candidates = append(candidates, Candidate{Token: t, Quantity: q.ToBigInt()})
+	}
+	candidates = s.strategy.Order(candidates, target)
+	tokens := make([]*token2.UnspentTokenInWallet, len(candidates))
+	for i, c := range candidates {
+		tokens[i] = c.Token
+	}
+	return collections.NewSliceIterator(tokens), nil
+}
+
 func (s *selector) Close() error {
 	if s.isClosed() {
 		return errors.New("selector is already closed")
@@ -193,12 +237,12 @@ func (l *locker) UnlockAll() error {
 	return l.Locker.UnlockByTxID(l.txID)
 }
 
-func NewSherdSelector(txID transaction.ID, fetcher tokenFetcher, lockDB Locker, precision uint64, backoff time.Duration, maxRetriesAfterBackoff int) tokenSelectorUnlocker {
+func NewSherdSelector(txID transaction.ID, fetcher tokenFetcher, lockDB Locker, precision uint64, backoff time.Duration, maxRetriesAfterBackoff int, strategy Strategy) tokenSelectorUnlocker {
 	logger := logger.Named(fmt.Sprintf("selector-%s", txID))
 	locker := &locker{txID: txID, Locker: lockDB}
 	if backoff < 0 {
-		return NewSelector(logger, fetcher, locker, precision)
+		return NewSelector(logger, fetcher, locker, precision, strategy)
 	} else {
-		return NewStubbornSelector(logger, fetcher, locker, precision, backoff, maxRetriesAfterBackoff)
+		return NewStubbornSelector(logger, fetcher, locker, precision, backoff, maxRetriesAfterBackoff, strategy)
 	}
 }
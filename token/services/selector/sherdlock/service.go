@@ -17,6 +17,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// strategyKey is the TMS configuration key that overrides the selection strategy of token.selector.strategy
+const strategyKey = "selector.strategy"
+
 type SelectorService struct {
 	managerLazyCache lazy2.Provider[*token.ManagementService, token.SelectorManager]
 }
@@ -34,6 +37,7 @@ func NewService(fetcherProvider FetcherProvider, tokenLockDBManager *tokenlockdb
 		numRetries:             cfg.GetNumRetries(),
 		leaseExpiry:            cfg.GetLeaseExpiry(),
 		leaseCleanupTickPeriod: cfg.GetLeaseCleanupTickPeriod(),
+		strategy:               SelectionStrategy(cfg.GetStrategy()),
 	}
 	return &SelectorService{
 		managerLazyCache: lazy2.NewProviderWithKeyMapper(key, loader.load),
@@ -55,6 +59,7 @@ type loader struct {
 	retryInterval          time.Duration
 	leaseExpiry            time.Duration
 	leaseCleanupTickPeriod time.Duration
+	strategy               SelectionStrategy
 }
 
 func (s *loader) load(tms *token.ManagementService) (token.SelectorManager, error) {
@@ -70,6 +75,17 @@ func (s *loader) load(tms *token.ManagementService) (token.SelectorManager, erro
 	if err != nil {
 		return nil, errors.Errorf("failed to create token fetcher: %v", err)
 	}
+	strategyName := s.strategy
+	if tms.Configuration().IsSet(strategyKey) {
+		if err := tms.Configuration().UnmarshalKey(strategyKey, &strategyName); err != nil {
+			return nil, errors.Wrapf(err, "failed to load selection strategy for TMS [%s]", tms.ID())
+		}
+	}
+	strategy, err := GetStrategy(strategyName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid selection strategy for TMS [%s]", tms.ID())
+	}
+	logger.Debugf("selection strategy for TMS [%s] is [%s]", tms.ID(), strategyName)
 	return NewManager(
 		fetcher,
 		tokenLockDB,
@@ -78,6 +94,7 @@ func (s *loader) load(tms *token.ManagementService) (token.SelectorManager, erro
 		s.numRetries,
 		s.leaseExpiry,
 		s.leaseCleanupTickPeriod,
+		strategy,
 	), nil
 }
 
@@ -0,0 +1,192 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sherdlock
+
+import (
+	"math/big"
+	"slices"
+
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// SelectionStrategy is the name of a coin-selection strategy
+type SelectionStrategy string
+
+const (
+	// FirstFit selects tokens in the order they are returned by the token DB
+	FirstFit SelectionStrategy = "first-fit"
+	// LargestFirst selects the tokens with the highest quantity first
+	LargestFirst SelectionStrategy = "largest-first"
+	// SmallestFirst selects the tokens with the lowest quantity first, consolidating dust
+	SmallestFirst SelectionStrategy = "smallest-first"
+	// ExactMatch looks, via branch-and-bound, for a set of tokens whose sum is exactly the requested amount,
+	// avoiding change outputs. If no such set exists, it behaves like LargestFirst.
+	ExactMatch SelectionStrategy = "exact-match"
+	// MinimizeInputs selects the smallest number of tokens covering the requested amount,
+	// and among those, the set with the lowest change.
+	MinimizeInputs SelectionStrategy = "minimize-inputs"
+
+	// maxBranchAndBoundSteps bounds the search of ExactMatch
+	maxBranchAndBoundSteps = 100000
+)
+
+// Candidate is an unspent token together with its parsed quantity
+type Candidate struct {
+	Token    *token2.UnspentTokenInWallet
+	Quantity *big.Int
+}
+
+// Strategy decides in which order the selector tries to lock the available tokens.
+// The selector locks the candidates in the returned order until the requested amount is covered,
+// therefore, a strategy that found a good set of tokens returns it first, followed by the rest as fallback,
+// in case some tokens of the set are locked by other processes.
+type Strategy interface {
+	Order(candidates []Candidate, target *big.Int) []Candidate
+}
+
+var strategies = map[SelectionStrategy]Strategy{
+	LargestFirst:   &largestFirst{},
+	SmallestFirst:  &smallestFirst{},
+	ExactMatch:     &exactMatch{maxSteps: maxBranchAndBoundSteps},
+	MinimizeInputs: &minimizeInputs{},
+}
+
+// GetStrategy returns the strategy with the given name.
+// It returns nil for FirstFit, meaning that the tokens are selected in the order returned by the token DB.
+func GetStrategy(name SelectionStrategy) (Strategy, error) {
+	if len(name) == 0 || name == FirstFit {
+		return nil, nil
+	}
+	s, ok := strategies[name]
+	if !ok {
+		return nil, errors.Errorf("undefined selection strategy [%s]", name)
+	}
+	return s, nil
+}
+
+type largestFirst struct{}
+
+func (s *largestFirst) Order(candidates []Candidate, _ *big.Int) []Candidate {
+	slices.SortStableFunc(candidates, descending)
+	return candidates
+}
+
+type smallestFirst struct{}
+
+func (s *smallestFirst) Order(candidates []Candidate, _ *big.Int) []Candidate {
+	slices.SortStableFunc(candidates, func(a, b Candidate) int { return a.Quantity.Cmp(b.Quantity) })
+	return candidates
+}
+
+type exactMatch struct {
+	maxSteps int
+}
+
+func (s *exactMatch) Order(candidates []Candidate, target *big.Int) []Candidate {
+	slices.SortStableFunc(candidates, descending)
+
+	// suffix[i] is the sum of the quantities of candidates[i:]
+	suffix := make([]*big.Int, len(candidates)+1)
+	suffix[len(candidates)] = big.NewInt(0)
+	for i := len(candidates) - 1; i >= 0; i-- {
+		suffix[i] = new(big.Int).Add(suffix[i+1], candidates[i].Quantity)
+	}
+
+	steps := 0
+	var chosen []int
+	var search func(i int, remaining *big.Int) bool
+	search = func(i int, remaining *big.Int) bool {
+		if remaining.Sign() == 0 {
+			return true
+		}
+		steps++
+		if i >= len(candidates) || steps > s.maxSteps || suffix[i].Cmp(remaining) < 0 {
+			return false
+		}
+		// include candidates[i], if it does not exceed the remaining amount
+		if candidates[i].Quantity.Cmp(remaining) <= 0 {
+			chosen = append(chosen, i)
+			if search(i+1, new(big.Int).Sub(remaining, candidates[i].Quantity)) {
+				return true
+			}
+			chosen = chosen[:len(chosen)-1]
+		}
+		// exclude candidates[i]
+		return search(i+1, remaining)
+	}
+	if !search(0, target) {
+		logger.Debugf("no exact match found for [%s] after [%d] steps, fallback to largest-first", target, steps)
+		return candidates
+	}
+	return moveToFront(candidates, chosen)
+}
+
+type minimizeInputs struct{}
+
+func (s *minimizeInputs) Order(candidates []Candidate, target *big.Int) []Candidate {
+	slices.SortStableFunc(candidates, descending)
+
+	// the k largest tokens give the minimum number of inputs k
+	sum := big.NewInt(0)
+	k := 0
+	for k < len(candidates) && sum.Cmp(target) < 0 {
+		sum.Add(sum, candidates[k].Quantity)
+		k++
+	}
+	if sum.Cmp(target) < 0 {
+		return candidates
+	}
+
+	// reduce the change by replacing, from the smallest, each of the k tokens with the smallest unused token
+	// that keeps the sum above the target
+	chosen := make([]int, k)
+	used := make([]bool, len(candidates))
+	for i := 0; i < k; i++ {
+		chosen[i] = i
+		used[i] = true
+	}
+	for i := k - 1; i >= 0; i-- {
+		current := candidates[chosen[i]].Quantity
+		for j := len(candidates) - 1; j >= k; j-- {
+			if used[j] {
+				continue
+			}
+			// candidates are sorted by descending quantity, the first eligible from the end is the smallest one
+			newSum := new(big.Int).Sub(sum, current)
+			newSum.Add(newSum, candidates[j].Quantity)
+			if newSum.Cmp(target) >= 0 && candidates[j].Quantity.Cmp(current) < 0 {
+				used[chosen[i]] = false
+				used[j] = true
+				chosen[i] = j
+				sum = newSum
+				break
+			}
+		}
+	}
+	return moveToFront(candidates, chosen)
+}
+
+func descending(a, b Candidate) int {
+	return b.Quantity.Cmp(a.Quantity)
+}
+
+// moveToFront returns the candidates at the passed indexes first, followed by the others in their original order
+func moveToFront(candidates []Candidate, indexes []int) []Candidate {
+	res := make([]Candidate, 0, len(candidates))
+	front := make(map[int]struct{}, len(indexes))
+	for _, i := range indexes {
+		front[i] = struct{}{}
+		res = append(res, candidates[i])
+	}
+	for i, c := range candidates {
+		if _, ok := front[i]; !ok {
+			res = append(res, c)
+		}
+	}
+	return res
+}
@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sherdlock
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/common/utils/collections"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStrategy(t *testing.T) {
+	s, err := GetStrategy("")
+	assert.NoError(t, err)
+	assert.Nil(t, s)
+	s, err = GetStrategy(FirstFit)
+	assert.NoError(t, err)
+	assert.Nil(t, s)
+	for _, name := range []SelectionStrategy{LargestFirst, SmallestFirst, ExactMatch, MinimizeInputs} {
+		s, err = GetStrategy(name)
+		assert.NoError(t, err)
+		assert.NotNil(t, s)
+	}
+	_, err = GetStrategy("unknown")
+	assert.Error(t, err)
+}
+
+func TestStrategyOrder(t *testing.T) {
+	testCases := []struct {
+		strategy SelectionStrategy
+		values   []int64
+		target   int64
+		expected []int64
+	}{
+		{strategy: LargestFirst, values: []int64{3, 10, 1, 7}, target: 5, expected: []int64{10, 7, 3, 1}},
+		{strategy: SmallestFirst, values: []int64{3, 10, 1, 7}, target: 5, expected: []int64{1, 3, 7, 10}},
+		// 7 + 3 = 10 avoids the change
+		{strategy: ExactMatch, values: []int64{8, 7, 4, 3}, target: 10, expected: []int64{7, 3, 8, 4}},
+		// no exact match, fallback to largest-first
+		{strategy: ExactMatch, values: []int64{8, 4}, target: 10, expected: []int64{8, 4}},
+		// two inputs are needed, 8 + 3 has the lowest change
+		{strategy: MinimizeInputs, values: []int64{8, 7, 3, 1}, target: 10, expected: []int64{8, 3, 7, 1}},
+		// a single token is enough
+		{strategy: MinimizeInputs, values: []int64{20, 12, 3, 1}, target: 10, expected: []int64{12, 20, 3, 1}},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s_%v_%d", tc.strategy, tc.values, tc.target), func(t *testing.T) {
+			s, err := GetStrategy(tc.strategy)
+			assert.NoError(t, err)
+			ordered := s.Order(candidates(tc.values...), big.NewInt(tc.target))
+			assert.Equal(t, tc.expected, quantities(ordered))
+		})
+	}
+}
+
+func TestSelectorWithStrategy(t *testing.T) {
+	tokens := []*token2.UnspentTokenInWallet{
+		unspentToken("a", 8), unspentToken("b", 7), unspentToken("c", 4), unspentToken("d", 3),
+	}
+	fetcher := &sliceFetcher{tokens: tokens}
+
+	// first-fit selects in DB order
+	s := NewSelector(logger, fetcher, &allowLocker{}, 64, nil)
+	ids, sum, err := s.Select(&ownerFilter{}, "10", "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, "15", sum.Decimal())
+	assert.Len(t, ids, 2)
+
+	// exact-match selects 7 + 3
+	strategy, err := GetStrategy(ExactMatch)
+	assert.NoError(t, err)
+	s = NewSelector(logger, fetcher, &allowLocker{}, 64, strategy)
+	ids, sum, err = s.Select(&ownerFilter{}, "10", "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, "10", sum.Decimal())
+	assert.ElementsMatch(t, []string{"b", "d"}, []string{ids[0].TxId, ids[1].TxId})
+
+	// exact-match falls back to the rest when a token of the match is locked by others
+	s = NewSelector(logger, fetcher, &allowLocker{denied: "d"}, 64, strategy)
+	ids, sum, err = s.Select(&ownerFilter{}, "10", "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, "15", sum.Decimal())
+	assert.ElementsMatch(t, []string{"a", "b"}, []string{ids[0].TxId, ids[1].TxId})
+}
+
+func candidates(values ...int64) []Candidate {
+	res := make([]Candidate, len(values))
+	for i, v := range values {
+		res[i] = Candidate{Token: unspentToken(fmt.Sprintf("%d", i), v), Quantity: big.NewInt(v)}
+	}
+	return res
+}
+
+func quantities(candidates []Candidate) []int64 {
+	res := make([]int64, len(candidates))
+	for i, c := range candidates {
+		res[i] = c.Quantity.Int64()
+	}
+	return res
+}
+
+func unspentToken(id string, q int64) *token2.UnspentTokenInWallet {
+	return &token2.UnspentTokenInWallet{
+		Id:       &token2.ID{TxId: id},
+		Type:     "USD",
+		Quantity: fmt.Sprintf("%d", q),
+	}
+}
+
+type sliceFetcher struct {
+	tokens []*token2.UnspentTokenInWallet
+}
+
+func (f *sliceFetcher) UnspentTokensIteratorBy(string, token2.Type) (iterator[*token2.UnspentTokenInWallet], error) {
+	return collections.NewSliceIterator(f.tokens), nil
+}
+
+type allowLocker struct {
+	denied string
+}
+
+func (l *allowLocker) TryLock(id *token2.ID) bool {
+	return id.TxId != l.denied
+}
+
+func (l *allowLocker) UnlockAll() error {
+	return nil
+}
+
+type ownerFilter struct{}
+
+func (o *ownerFilter) ID() string {
+	return "alice"
+}
+
+var _ token.OwnerFilter = &ownerFilter{}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/validity"
 	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 	"go.uber.org/zap/zapcore"
@@ -103,6 +104,10 @@ func (s *selector) selectByID(ownerFilter token.OwnerFilter, q string, tokenType
 			if t == nil {
 				break
 			}
+			if ok, w, err := validity.Unwrap(t.Owner); err == nil && ok && w.ValidAt(time.Now()) != nil {
+				logger.Debugf("token [%s] outside its validity window, skip it", t.Id)
+				continue
+			}
 
 			q, err := token2.ToQuantity(t.Quantity, s.precision)
 			if err != nil {
@@ -0,0 +1,140 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package streaming
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/pkg/errors"
+)
+
+// ConfigKey is the key, relative to the configuration of a TMS, of the streaming configuration
+const ConfigKey = "streaming"
+
+const (
+	// WebhookSinkType is the type of the sinks posting the events to an HTTP endpoint
+	WebhookSinkType = "webhook"
+	// FileSinkType is the type of the sinks appending the events to a local NDJSON file
+	FileSinkType = "file"
+
+	defaultBatchSize    = 100
+	defaultMaxEvents    = 100000
+	defaultRetryBackoff = time.Second
+)
+
+// Config is the streaming configuration of a TMS
+type Config struct {
+	// Dir is the directory where the event journal and the delivery offsets are stored
+	Dir string `yaml:"dir,omitempty"`
+	// BatchSize is the maximum number of events delivered to a sink at once
+	BatchSize int `yaml:"batchSize,omitempty"`
+	// MaxEvents is the maximum number of undelivered events kept in the journal, the oldest are dropped beyond it
+	MaxEvents int `yaml:"maxEvents,omitempty"`
+	// Sinks are the destinations of the events
+	Sinks []SinkConfig `yaml:"sinks,omitempty"`
+}
+
+// SinkConfig is the configuration of a sink
+type SinkConfig struct {
+	// Name identifies the sink and its delivery offset. It must be unique per TMS
+	Name string `yaml:"name,omitempty"`
+	// Type is either WebhookSinkType or FileSinkType
+	Type string `yaml:"type,omitempty"`
+	// Events are the event types delivered to the sink. Empty means all
+	Events []EventType `yaml:"events,omitempty"`
+
+	// URL is the endpoint of a webhook sink
+	URL string `yaml:"url,omitempty"`
+	// Secret is the HMAC-SHA256 key used to sign the payloads of a webhook sink. Empty means unsigned
+	Secret string `yaml:"secret,omitempty"`
+	// Headers are additional HTTP headers sent by a webhook sink
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Timeout is the timeout of each request of a webhook sink
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// MaxRetries is the number of times a webhook sink retries a failed request before giving up on the batch
+	MaxRetries int `yaml:"maxRetries,omitempty"`
+	// RetryBackoff is the initial delay between retries, doubled at each attempt
+	RetryBackoff time.Duration `yaml:"retryBackoff,omitempty"`
+
+	// Path is the file a file sink appends to
+	Path string `yaml:"path,omitempty"`
+}
+
+// Accepts returns true if the sink is interested in events of the passed type
+func (c *SinkConfig) Accepts(eventType EventType) bool {
+	if len(c.Events) == 0 {
+		return true
+	}
+	for _, t := range c.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that the configuration is complete
+func (c *Config) Validate() error {
+	if len(c.Dir) == 0 {
+		return errors.New("no directory set")
+	}
+	names := map[string]bool{}
+	for i, sink := range c.Sinks {
+		if len(sink.Name) == 0 {
+			return errors.Errorf("no name for sink at index [%d]", i)
+		}
+		if names[sink.Name] {
+			return errors.Errorf("duplicate sink [%s]", sink.Name)
+		}
+		names[sink.Name] = true
+		switch sink.Type {
+		case WebhookSinkType:
+			if len(sink.URL) == 0 {
+				return errors.Errorf("no url for sink [%s]", sink.Name)
+			}
+		case FileSinkType:
+			if len(sink.Path) == 0 {
+				return errors.Errorf("no path for sink [%s]", sink.Name)
+			}
+		default:
+			return errors.Errorf("unknown type [%s] for sink [%s]", sink.Type, sink.Name)
+		}
+	}
+	return nil
+}
+
+// LoadConfig returns the streaming configuration of the passed TMS configuration, nil if not set.
+// Relative paths are translated with respect to the configuration path.
+func LoadConfig(configuration driver.Configuration) (*Config, error) {
+	if !configuration.IsSet(ConfigKey) {
+		return nil, nil
+	}
+	c := &Config{}
+	if err := configuration.UnmarshalKey(ConfigKey, c); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshalling streaming configuration")
+	}
+	if len(c.Sinks) == 0 {
+		return nil, nil
+	}
+	if err := c.Validate(); err != nil {
+		return nil, errors.WithMessagef(err, "invalid streaming configuration")
+	}
+	c.Dir = configuration.TranslatePath(c.Dir)
+	for i := range c.Sinks {
+		if len(c.Sinks[i].Path) != 0 {
+			c.Sinks[i].Path = configuration.TranslatePath(c.Sinks[i].Path)
+		}
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultBatchSize
+	}
+	if c.MaxEvents <= 0 {
+		c.MaxEvents = defaultMaxEvents
+	}
+	return c, nil
+}
@@ -0,0 +1,42 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package streaming
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+)
+
+// EventType is the type of streamed event
+type EventType string
+
+const (
+	// TokenAdded is the type of the events emitted when a token is added to the vault
+	TokenAdded EventType = "token.added"
+	// TokenSpent is the type of the events emitted when a token is deleted from the vault because spent
+	TokenSpent EventType = "token.spent"
+	// TxStatusChanged is the type of the events emitted when the status of a token transaction changes
+	TxStatusChanged EventType = "tx.status"
+)
+
+// Event is a token or transaction event as delivered to the sinks.
+// Offset grows monotonically per TMS and identifies the event: consumers can use it to discard duplicates,
+// since the delivery is at-least-once.
+type Event struct {
+	Offset    uint64      `json:"offset"`
+	Type      EventType   `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+	TMSID     token.TMSID `json:"tms_id"`
+	TxID      string      `json:"tx_id"`
+	WalletID  string      `json:"wallet_id,omitempty"`
+	TokenType token2.Type `json:"token_type,omitempty"`
+	Index     uint64      `json:"index"`
+	Status    string      `json:"status,omitempty"`
+	Message   string      `json:"message,omitempty"`
+}
@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package streaming
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// FileSink appends the events to a local file, one JSON object per line (NDJSON)
+type FileSink struct {
+	path string
+	file *os.File
+}
+
+// NewFileSink returns a new FileSink appending to the passed file
+func NewFileSink(path string) (*FileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, errors.Wrapf(err, "failed creating directory for [%s]", path)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed opening [%s]", path)
+	}
+	return &FileSink{path: path, file: f}, nil
+}
+
+func (s *FileSink) Deliver(_ context.Context, events []Event) error {
+	w := bufio.NewWriter(s.file)
+	for _, e := range events {
+		if err := writeLine(w, e); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return errors.Wrapf(err, "failed writing to [%s]", s.path)
+	}
+	return errors.Wrapf(s.file.Sync(), "failed syncing [%s]", s.path)
+}
+
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,286 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package streaming
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	journalFile = "journal.ndjson"
+	offsetsFile = "offsets.json"
+
+	// compactThreshold is the number of delivered events after which the journal file is rewritten
+	compactThreshold = 1000
+)
+
+// Journal is an append-only log of events persisted as NDJSON.
+// Events are kept until all the sinks have acknowledged them, so that the delivery survives restarts.
+// The journal keeps at most maxEvents events, when full, the oldest events are dropped even if not delivered.
+type Journal struct {
+	path      string
+	maxEvents int
+
+	mutex      sync.RWMutex
+	file       *os.File
+	events     []Event
+	last       uint64
+	delivered  int
+	appendedCh chan struct{}
+}
+
+// OpenJournal opens the journal in the passed directory, dropping the events up to the passed offset.
+// The journal keeps at most maxEvents events, zero means no limit.
+func OpenJournal(dir string, delivered uint64, maxEvents int) (*Journal, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrapf(err, "failed creating directory [%s]", dir)
+	}
+	j := &Journal{path: filepath.Join(dir, journalFile), maxEvents: maxEvents, appendedCh: make(chan struct{})}
+	if err := j.load(delivered); err != nil {
+		return nil, err
+	}
+	j.bound()
+	if err := j.rewrite(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (j *Journal) load(delivered uint64) error {
+	f, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		j.last = delivered
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed opening journal [%s]", j.path)
+	}
+	defer f.Close()
+
+	j.last = delivered
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// a torn write at the end of the journal, the event was never acknowledged to the publisher
+			logger.Warnf("skipping corrupted journal entry in [%s]: %s", j.path, err)
+			continue
+		}
+		if e.Offset > j.last {
+			j.last = e.Offset
+		}
+		if e.Offset > delivered {
+			j.events = append(j.events, e)
+		}
+	}
+	return errors.Wrapf(scanner.Err(), "failed reading journal [%s]", j.path)
+}
+
+// rewrite replaces the journal file with the pending events only
+func (j *Journal) rewrite() error {
+	tmp := j.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Wrapf(err, "failed creating journal [%s]", tmp)
+	}
+	w := bufio.NewWriter(f)
+	for _, e := range j.events {
+		if err := writeLine(w, e); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed writing journal [%s]", tmp)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed syncing journal [%s]", tmp)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed closing journal [%s]", tmp)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return errors.Wrapf(err, "failed replacing journal [%s]", j.path)
+	}
+	if j.file != nil {
+		j.file.Close()
+	}
+	j.file, err = os.OpenFile(j.path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Wrapf(err, "failed opening journal [%s]", j.path)
+	}
+	j.delivered = 0
+	return nil
+}
+
+// Append assigns the next offset to the passed event and persists it
+func (j *Journal) Append(e Event) (uint64, error) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	e.Offset = j.last + 1
+	if err := writeLine(j.file, e); err != nil {
+		return 0, err
+	}
+	j.last = e.Offset
+	j.events = append(j.events, e)
+
+	// wake up the readers waiting for new events
+	close(j.appendedCh)
+	j.appendedCh = make(chan struct{})
+
+	if dropped := j.bound(); dropped != 0 && j.delivered >= compactThreshold {
+		if err := j.rewrite(); err != nil {
+			logger.Warnf("failed compacting journal [%s]: %s", j.path, err)
+		}
+	}
+	return e.Offset, nil
+}
+
+// bound drops the oldest events beyond maxEvents and returns how many were dropped.
+// The sinks that did not receive them skip to the oldest event left.
+func (j *Journal) bound() int {
+	if j.maxEvents <= 0 || len(j.events) <= j.maxEvents {
+		return 0
+	}
+	n := len(j.events) - j.maxEvents
+	logger.Warnf("journal [%s] is full, dropping [%d] undelivered events up to offset [%d]", j.path, n, j.events[n-1].Offset)
+	j.events = append([]Event(nil), j.events[n:]...)
+	j.delivered += n
+	return n
+}
+
+// Read returns up to max events with offset greater than the passed one
+func (j *Journal) Read(from uint64, max int) []Event {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+
+	var res []Event
+	for _, e := range j.events {
+		if e.Offset <= from {
+			continue
+		}
+		res = append(res, e)
+		if len(res) == max {
+			break
+		}
+	}
+	return res
+}
+
+// Appended returns a channel closed when the next event is appended
+func (j *Journal) Appended() <-chan struct{} {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.appendedCh
+}
+
+// Last returns the offset of the last event appended
+func (j *Journal) Last() uint64 {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.last
+}
+
+// Trim drops the events up to the passed offset, delivered to all the sinks
+func (j *Journal) Trim(offset uint64) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	i := 0
+	for i < len(j.events) && j.events[i].Offset <= offset {
+		i++
+	}
+	if i == 0 {
+		return nil
+	}
+	j.events = append([]Event(nil), j.events[i:]...)
+	j.delivered += i
+	if j.delivered < compactThreshold {
+		return nil
+	}
+	return j.rewrite()
+}
+
+// Close closes the journal file
+func (j *Journal) Close() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	return j.file.Close()
+}
+
+func writeLine(w interface{ Write([]byte) (int, error) }, e Event) error {
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrapf(err, "failed marshalling event")
+	}
+	if _, err := w.Write(append(raw, '\n')); err != nil {
+		return errors.Wrapf(err, "failed writing event [%d]", e.Offset)
+	}
+	return nil
+}
+
+// Offsets stores the offset of the last event delivered to each sink
+type Offsets struct {
+	path string
+
+	mutex   sync.Mutex
+	offsets map[string]uint64
+}
+
+// OpenOffsets loads the delivery offsets stored in the passed directory
+func OpenOffsets(dir string) (*Offsets, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrapf(err, "failed creating directory [%s]", dir)
+	}
+	o := &Offsets{path: filepath.Join(dir, offsetsFile), offsets: map[string]uint64{}}
+	raw, err := os.ReadFile(o.path)
+	if os.IsNotExist(err) {
+		return o, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading offsets [%s]", o.path)
+	}
+	if err := json.Unmarshal(raw, &o.offsets); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshalling offsets [%s]", o.path)
+	}
+	return o, nil
+}
+
+// Get returns the offset of the last event delivered to the passed sink, and whether the sink is known
+func (o *Offsets) Get(sink string) (uint64, bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	offset, ok := o.offsets[sink]
+	return offset, ok
+}
+
+// Set durably stores the offset of the last event delivered to the passed sink
+func (o *Offsets) Set(sink string, offset uint64) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.offsets[sink] = offset
+	raw, err := json.Marshal(o.offsets)
+	if err != nil {
+		return errors.Wrapf(err, "failed marshalling offsets")
+	}
+	tmp := o.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return errors.Wrapf(err, "failed writing offsets [%s]", tmp)
+	}
+	return errors.Wrapf(os.Rename(tmp, o.path), "failed replacing offsets [%s]", o.path)
+}
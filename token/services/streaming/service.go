@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package streaming
+
+import (
+	"context"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/events"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	"github.com/pkg/errors"
+)
+
+type ConfigService interface {
+	Configurations() ([]driver.Configuration, error)
+}
+
+type TTXDBProvider interface {
+	DBByTMSId(id token.TMSID) (*ttxdb.DB, error)
+}
+
+// Service starts a Streamer for each TMS whose configuration has sinks
+type Service struct {
+	configService ConfigService
+	subscriber    events.Subscriber
+	ttxDBProvider TTXDBProvider
+}
+
+// NewService returns a new streaming Service
+func NewService(configService ConfigService, subscriber events.Subscriber, ttxDBProvider TTXDBProvider) *Service {
+	return &Service{
+		configService: configService,
+		subscriber:    subscriber,
+		ttxDBProvider: ttxDBProvider,
+	}
+}
+
+// Start starts the streamers, they stop when the passed context is done
+func (s *Service) Start(ctx context.Context) error {
+	configurations, err := s.configService.Configurations()
+	if err != nil {
+		return errors.WithMessagef(err, "failed to get tms configurations")
+	}
+	for _, configuration := range configurations {
+		tmsID := configuration.ID()
+		config, err := LoadConfig(configuration)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to load streaming configuration for [%s]", tmsID)
+		}
+		if config == nil {
+			continue
+		}
+		db, err := s.ttxDBProvider.DBByTMSId(tmsID)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to get ttxdb for [%s]", tmsID)
+		}
+		streamer, err := NewStreamer(tmsID, config)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to create streamer for [%s]", tmsID)
+		}
+		logger.Infof("start streaming events of [%s] to [%d] sinks", tmsID, len(config.Sinks))
+		streamer.Start(ctx, s.subscriber, db)
+	}
+	return nil
+}
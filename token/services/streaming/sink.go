@@ -0,0 +1,34 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package streaming
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// Sink delivers events to an external consumer
+type Sink interface {
+	// Deliver delivers the passed events, in order.
+	// When it returns nil, the events are considered delivered and are never passed again.
+	Deliver(ctx context.Context, events []Event) error
+	// Close releases the resources held by the sink
+	Close() error
+}
+
+// NewSink returns the sink described by the passed configuration
+func NewSink(c SinkConfig) (Sink, error) {
+	switch c.Type {
+	case WebhookSinkType:
+		return NewWebhookSink(c), nil
+	case FileSinkType:
+		return NewFileSink(c.Path)
+	default:
+		return nil, errors.Errorf("unknown sink type [%s]", c.Type)
+	}
+}
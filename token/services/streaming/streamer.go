@@ -0,0 +1,250 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package streaming
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/events"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokens"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.MustGetLogger("token-sdk.streaming")
+
+const maxRetryBackoff = time.Minute
+
+// StatusNotifier notifies the status changes of the transactions of a TMS
+type StatusNotifier interface {
+	AddGlobalStatusListener(ch chan common.StatusEvent)
+	DeleteGlobalStatusListener(ch chan common.StatusEvent)
+}
+
+// Streamer journals the token and transaction events of a TMS and delivers them to the configured sinks.
+// Each sink has its own delivery offset: a sink that is down does not hold back the others,
+// and resumes from its offset once back, also across restarts.
+type Streamer struct {
+	tmsID     token.TMSID
+	batchSize int
+	journal   *Journal
+	offsets   *Offsets
+	workers   []*worker
+	statusCh  chan common.StatusEvent
+	trimMutex sync.Mutex
+}
+
+type worker struct {
+	name   string
+	config SinkConfig
+	sink   Sink
+	offset uint64
+}
+
+// NewStreamer returns a new Streamer for the passed TMS and configuration.
+// Sinks without a stored offset receive only the events journaled from now on.
+func NewStreamer(tmsID token.TMSID, config *Config) (*Streamer, error) {
+	offsets, err := OpenOffsets(config.Dir)
+	if err != nil {
+		return nil, err
+	}
+	delivered, known := uint64(0), false
+	for _, c := range config.Sinks {
+		if offset, ok := offsets.Get(c.Name); ok && (!known || offset < delivered) {
+			delivered, known = offset, true
+		}
+	}
+	journal, err := OpenJournal(config.Dir, delivered, config.MaxEvents)
+	if err != nil {
+		return nil, err
+	}
+	s := &Streamer{
+		tmsID:     tmsID,
+		batchSize: config.BatchSize,
+		journal:   journal,
+		offsets:   offsets,
+		statusCh:  make(chan common.StatusEvent, 100),
+	}
+	for _, c := range config.Sinks {
+		sink, err := NewSink(c)
+		if err != nil {
+			s.closeSinks()
+			return nil, errors.WithMessagef(err, "failed creating sink [%s]", c.Name)
+		}
+		offset, ok := offsets.Get(c.Name)
+		if !ok {
+			offset = journal.Last()
+			if err := offsets.Set(c.Name, offset); err != nil {
+				s.closeSinks()
+				return nil, errors.WithMessagef(err, "failed initializing offset of sink [%s]", c.Name)
+			}
+		}
+		s.workers = append(s.workers, &worker{name: c.Name, config: c, sink: sink, offset: offset})
+	}
+	return s, nil
+}
+
+// OnReceive journals the token events of the TMS published by the tokens service
+func (s *Streamer) OnReceive(event events.Event) {
+	msg, ok := event.Message().(tokens.TokenMessage)
+	if !ok || !msg.TMSID.Equal(s.tmsID) {
+		return
+	}
+	e := Event{
+		TMSID:     s.tmsID,
+		Timestamp: time.Now(),
+		TxID:      msg.TxID,
+		WalletID:  msg.WalletID,
+		TokenType: msg.TokenType,
+		Index:     msg.Index,
+	}
+	switch event.Topic() {
+	case tokens.AddToken:
+		e.Type = TokenAdded
+	case tokens.DeleteToken:
+		e.Type = TokenSpent
+	default:
+		return
+	}
+	s.append(e)
+}
+
+// Start subscribes the streamer to the passed sources and starts delivering to the sinks until the context is done
+func (s *Streamer) Start(ctx context.Context, subscriber events.Subscriber, statusNotifier StatusNotifier) {
+	subscriber.Subscribe(tokens.AddToken, s)
+	subscriber.Subscribe(tokens.DeleteToken, s)
+	statusNotifier.AddGlobalStatusListener(s.statusCh)
+
+	var wg sync.WaitGroup
+	for _, w := range s.workers {
+		wg.Add(1)
+		go func(w *worker) {
+			defer wg.Done()
+			s.deliver(ctx, w)
+		}(w)
+	}
+	go s.listenStatus(ctx)
+	go func() {
+		<-ctx.Done()
+		subscriber.Unsubscribe(tokens.AddToken, s)
+		subscriber.Unsubscribe(tokens.DeleteToken, s)
+		statusNotifier.DeleteGlobalStatusListener(s.statusCh)
+		wg.Wait()
+		s.closeSinks()
+		if err := s.journal.Close(); err != nil {
+			logger.Warnf("failed closing journal of [%s]: %s", s.tmsID, err)
+		}
+	}()
+}
+
+func (s *Streamer) listenStatus(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			// drain the notifications sent before the listener was removed
+			for {
+				select {
+				case <-s.statusCh:
+				case <-time.After(time.Second):
+					return
+				}
+			}
+		case event := <-s.statusCh:
+			s.append(Event{
+				Type:      TxStatusChanged,
+				TMSID:     s.tmsID,
+				Timestamp: time.Now(),
+				TxID:      event.TxID,
+				Status:    driver.TxStatusMessage[event.ValidationCode],
+				Message:   event.ValidationMessage,
+			})
+		}
+	}
+}
+
+func (s *Streamer) append(e Event) {
+	offset, err := s.journal.Append(e)
+	if err != nil {
+		logger.Errorf("failed journaling event [%s] of [%s] for [%s]: %s", e.Type, e.TxID, s.tmsID, err)
+		return
+	}
+	logger.Debugf("journaled event [%d][%s] of [%s] for [%s]", offset, e.Type, e.TxID, s.tmsID)
+}
+
+func (s *Streamer) deliver(ctx context.Context, w *worker) {
+	backoff := defaultRetryBackoff
+	for {
+		// take the channel before reading, not to miss an append in between
+		appended := s.journal.Appended()
+		batch := s.journal.Read(w.offset, s.batchSize)
+		if len(batch) == 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-appended:
+				continue
+			}
+		}
+
+		var accepted []Event
+		for _, e := range batch {
+			if w.config.Accepts(e.Type) {
+				accepted = append(accepted, e)
+			}
+		}
+		if len(accepted) != 0 {
+			if err := w.sink.Deliver(ctx, accepted); err != nil {
+				logger.Warnf("failed delivering events to sink [%s] of [%s], retry in [%s]: %s", w.name, s.tmsID, backoff, err)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(backoff):
+				}
+				backoff = min(2*backoff, maxRetryBackoff)
+				continue
+			}
+		}
+		backoff = defaultRetryBackoff
+
+		last := batch[len(batch)-1].Offset
+		if err := s.offsets.Set(w.name, last); err != nil {
+			logger.Errorf("failed storing offset [%d] of sink [%s] of [%s]: %s", last, w.name, s.tmsID, err)
+		}
+		w.offset = last
+		s.trim()
+	}
+}
+
+// trim drops from the journal the events delivered to all the sinks
+func (s *Streamer) trim() {
+	s.trimMutex.Lock()
+	defer s.trimMutex.Unlock()
+
+	var delivered uint64
+	for i, w := range s.workers {
+		offset, _ := s.offsets.Get(w.name)
+		if i == 0 || offset < delivered {
+			delivered = offset
+		}
+	}
+	if err := s.journal.Trim(delivered); err != nil {
+		logger.Warnf("failed trimming journal of [%s]: %s", s.tmsID, err)
+	}
+}
+
+func (s *Streamer) closeSinks() {
+	for _, w := range s.workers {
+		if err := w.sink.Close(); err != nil {
+			logger.Warnf("failed closing sink [%s] of [%s]: %s", w.name, s.tmsID, err)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package streaming
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/events"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokens"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tmsID = token.TMSID{Network: "n", Channel: "c", Namespace: "ns"}
+
+func TestWebhookSink(t *testing.T) {
+	secret := []byte("secret")
+	var calls atomic.Int32
+	var payload WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.True(t, VerifySignature(secret, body, r.Header.Get(SignatureHeader)))
+		assert.Equal(t, "2", r.Header.Get(OffsetHeader))
+		assert.Equal(t, "v", r.Header.Get("X-Custom"))
+		assert.NoError(t, json.Unmarshal(body, &payload))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(SinkConfig{
+		URL:          server.URL,
+		Secret:       string(secret),
+		Headers:      map[string]string{"X-Custom": "v"},
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	})
+	defer sink.Close()
+	evs := []Event{{Offset: 1, Type: TokenAdded, TxID: "tx1"}, {Offset: 2, Type: TokenSpent, TxID: "tx1"}}
+	require.NoError(t, sink.Deliver(context.Background(), evs))
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Len(t, payload.Events, 2)
+	assert.Equal(t, TokenSpent, payload.Events[1].Type)
+
+	// the retries are exhausted
+	calls.Store(0)
+	sink.maxRetries = 1
+	err := sink.Deliver(context.Background(), evs)
+	assert.EqualError(t, err, "failed delivering events to ["+server.URL+"] after [2] attempts: unexpected status code [503]")
+	assert.False(t, VerifySignature([]byte("other"), []byte("body"), Sign(secret, []byte("body"))))
+}
+
+func TestJournal(t *testing.T) {
+	dir := t.TempDir()
+	j, err := OpenJournal(dir, 0, 0)
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		offset, err := j.Append(Event{Type: TokenAdded})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i+1), offset)
+	}
+	assert.Len(t, j.Read(2, 10), 3)
+	assert.Len(t, j.Read(0, 2), 2)
+	require.NoError(t, j.Trim(3))
+	assert.Len(t, j.Read(0, 10), 2)
+	require.NoError(t, j.Close())
+
+	// reopening keeps the offsets and drops the delivered events
+	j, err = OpenJournal(dir, 4, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), j.Last())
+	evs := j.Read(0, 10)
+	require.Len(t, evs, 1)
+	assert.Equal(t, uint64(5), evs[0].Offset)
+	offset, err := j.Append(Event{Type: TokenSpent})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), offset)
+	require.NoError(t, j.Close())
+
+	// a full journal drops the oldest events, also when reopened with a lower limit
+	j, err = OpenJournal(dir, 4, 3)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err := j.Append(Event{Type: TokenAdded})
+		require.NoError(t, err)
+	}
+	evs = j.Read(0, 10)
+	require.Len(t, evs, 3)
+	assert.Equal(t, uint64(6), evs[0].Offset)
+	assert.Equal(t, uint64(8), j.Last())
+	require.NoError(t, j.Close())
+	j, err = OpenJournal(dir, 4, 2)
+	require.NoError(t, err)
+	evs = j.Read(0, 10)
+	require.Len(t, evs, 2)
+	assert.Equal(t, uint64(7), evs[0].Offset)
+	require.NoError(t, j.Close())
+
+	o, err := OpenOffsets(dir)
+	require.NoError(t, err)
+	_, ok := o.Get("a")
+	assert.False(t, ok)
+	require.NoError(t, o.Set("a", 5))
+	o, err = OpenOffsets(dir)
+	require.NoError(t, err)
+	offset, ok = o.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), offset)
+}
+
+func TestStreamer(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out", "events.ndjson")
+	config := &Config{
+		Dir:       filepath.Join(dir, "journal"),
+		BatchSize: 2,
+		Sinks: []SinkConfig{
+			{Name: "all", Type: FileSinkType, Path: out},
+			{Name: "status", Type: FileSinkType, Path: out + ".status", Events: []EventType{TxStatusChanged}},
+		},
+	}
+	require.NoError(t, config.Validate())
+
+	bus := &bus{}
+	status := &statusNotifier{}
+	s, err := NewStreamer(tmsID, config)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx, bus, status)
+
+	bus.publish(tokens.AddToken, tokens.TokenMessage{TMSID: tmsID, WalletID: "alice", TokenType: "USD", TxID: "tx1", Index: 0})
+	bus.publish(tokens.AddToken, tokens.TokenMessage{TMSID: token.TMSID{Network: "other"}, TxID: "tx1"})
+	bus.publish(tokens.DeleteToken, tokens.TokenMessage{TMSID: tmsID, WalletID: "alice", TokenType: "USD", TxID: "tx0", Index: 1})
+	status.notify(common.StatusEvent{TxID: "tx1", ValidationCode: driver.Confirmed})
+
+	assert.Eventually(t, func() bool { return len(readEvents(t, out)) == 3 }, 5*time.Second, 10*time.Millisecond)
+	evs := readEvents(t, out)
+	assert.Equal(t, []EventType{TokenAdded, TokenSpent, TxStatusChanged}, []EventType{evs[0].Type, evs[1].Type, evs[2].Type})
+	assert.Equal(t, []uint64{1, 2, 3}, []uint64{evs[0].Offset, evs[1].Offset, evs[2].Offset})
+	assert.Equal(t, "alice", evs[0].WalletID)
+	assert.Equal(t, "Confirmed", evs[2].Status)
+	assert.Eventually(t, func() bool { return len(readEvents(t, out+".status")) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return len(s.journal.Read(0, 10)) == 0 }, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	assert.Eventually(t, func() bool { return bus.empty() }, 5*time.Second, 10*time.Millisecond)
+
+	// a restarted streamer resumes from the stored offsets
+	s, err = NewStreamer(tmsID, config)
+	require.NoError(t, err)
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx, bus, status)
+	bus.publish(tokens.AddToken, tokens.TokenMessage{TMSID: tmsID, TxID: "tx2"})
+	assert.Eventually(t, func() bool { return len(readEvents(t, out)) == 4 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint64(4), readEvents(t, out)[3].Offset)
+}
+
+func readEvents(t *testing.T, path string) []Event {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.NoError(t, err)
+	defer f.Close()
+	var res []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		res = append(res, e)
+	}
+	return res
+}
+
+type event struct {
+	topic   string
+	message interface{}
+}
+
+func (e *event) Topic() string        { return e.topic }
+func (e *event) Message() interface{} { return e.message }
+
+type bus struct {
+	mutex     sync.Mutex
+	listeners map[string][]events.Listener
+}
+
+func (b *bus) Subscribe(topic string, receiver events.Listener) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.listeners == nil {
+		b.listeners = map[string][]events.Listener{}
+	}
+	b.listeners[topic] = append(b.listeners[topic], receiver)
+}
+
+func (b *bus) Unsubscribe(topic string, receiver events.Listener) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	ls := b.listeners[topic]
+	for i, l := range ls {
+		if l == receiver {
+			b.listeners[topic] = append(ls[:i], ls[i+1:]...)
+			return
+		}
+	}
+}
+
+func (b *bus) publish(topic string, message tokens.TokenMessage) {
+	b.mutex.Lock()
+	ls := append([]events.Listener(nil), b.listeners[topic]...)
+	b.mutex.Unlock()
+	for _, l := range ls {
+		l.OnReceive(&event{topic: topic, message: message})
+	}
+}
+
+func (b *bus) empty() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, ls := range b.listeners {
+		if len(ls) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type statusNotifier struct {
+	common.StatusSupport
+}
+
+func (s *statusNotifier) notify(e common.StatusEvent) {
+	e.Ctx = context.Background()
+	s.Notify(e)
+}
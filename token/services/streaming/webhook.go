@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package streaming
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// SignatureHeader carries the hex encoded HMAC-SHA256 of the request body, prefixed by "sha256="
+	SignatureHeader = "X-Token-SDK-Signature"
+	// OffsetHeader carries the offset of the last event in the request body
+	OffsetHeader = "X-Token-SDK-Offset"
+
+	defaultWebhookTimeout = 10 * time.Second
+)
+
+// WebhookPayload is the body posted by a WebhookSink
+type WebhookPayload struct {
+	Events []Event `json:"events"`
+}
+
+// WebhookSink posts the events, in batches, to an HTTP endpoint.
+// A delivery succeeds when the endpoint answers with a 2xx status code.
+type WebhookSink struct {
+	url          string
+	secret       []byte
+	headers      map[string]string
+	maxRetries   int
+	retryBackoff time.Duration
+	client       *http.Client
+}
+
+// NewWebhookSink returns a new WebhookSink for the passed configuration
+func NewWebhookSink(c SinkConfig) *WebhookSink {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	backoff := c.RetryBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	return &WebhookSink{
+		url:          c.URL,
+		secret:       []byte(c.Secret),
+		headers:      c.Headers,
+		maxRetries:   c.MaxRetries,
+		retryBackoff: backoff,
+		client:       &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *WebhookSink) Deliver(ctx context.Context, events []Event) error {
+	body, err := json.Marshal(&WebhookPayload{Events: events})
+	if err != nil {
+		return errors.Wrapf(err, "failed marshalling events")
+	}
+	backoff := s.retryBackoff
+	for attempt := 0; ; attempt++ {
+		err = s.post(ctx, body, events[len(events)-1].Offset)
+		if err == nil {
+			return nil
+		}
+		if attempt >= s.maxRetries {
+			return errors.WithMessagef(err, "failed delivering events to [%s] after [%d] attempts", s.url, attempt+1)
+		}
+		logger.Debugf("failed delivering events to [%s], retry in [%s]: %s", s.url, backoff, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (s *WebhookSink) post(ctx context.Context, body []byte, offset uint64) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed creating request")
+	}
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(OffsetHeader, strconv.FormatUint(offset, 10))
+	if len(s.secret) != 0 {
+		req.Header.Set(SignatureHeader, Sign(s.secret, body))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed posting events")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected status code [%d]", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *WebhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// Sign returns the value of the SignatureHeader for the passed body
+func Sign(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks, in constant time, the value of the SignatureHeader received with the passed body.
+// Consumers can use it to authenticate the payloads of a WebhookSink.
+func VerifySignature(secret []byte, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
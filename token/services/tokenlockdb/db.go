@@ -7,16 +7,35 @@ SPDX-License-Identifier: Apache-2.0
 package tokenlockdb
 
 import (
+	"reflect"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/pkg/errors"
 )
 
 type Manager = db.Manager[*DB]
 
+var managerType = reflect.TypeOf((*Manager)(nil))
+
 func NewManager(dh *db.DriverHolder, keys ...string) *Manager {
 	return db.MappedManager[driver.TokenLockDB, *DB](dh.NewTokenLockManager(keys...), newDB)
 }
 
+// GetByTMSId returns the token lock db of the passed TMS, the same used by the token selector
+func GetByTMSId(sp token.ServiceProvider, tmsID token.TMSID) (*DB, error) {
+	s, err := sp.GetService(managerType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get manager service")
+	}
+	c, err := s.(*Manager).DBByTMSId(tmsID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get db for tms [%s]", tmsID)
+	}
+	return c, nil
+}
+
 type DB struct{ driver.TokenLockDB }
 
 func newDB(p driver.TokenLockDB) (*DB, error) { return &DB{TokenLockDB: p}, nil }
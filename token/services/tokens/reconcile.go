@@ -0,0 +1,256 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tokens
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/hash"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+const defaultReconcileBatchSize = 50
+
+// DriftType is the kind of inconsistency between the token store and the ledger
+type DriftType string
+
+const (
+	// SpentOnLedger marks a token that is unspent in the token store but spent on the ledger
+	SpentOnLedger DriftType = "spent_on_ledger"
+	// MissingOnLedger marks a token that is unspent in the token store but whose output is not on the ledger
+	MissingOnLedger DriftType = "missing_on_ledger"
+	// OutputMismatch marks a token whose output in the token store differs from the one on the ledger
+	OutputMismatch DriftType = "output_mismatch"
+)
+
+// Drift is an inconsistency between the token store and the ledger
+type Drift struct {
+	Type    DriftType
+	ID      token2.ID
+	Message string
+}
+
+func (d Drift) String() string {
+	if len(d.Message) == 0 {
+		return fmt.Sprintf("[%s] token [%s]", d.Type, d.ID)
+	}
+	return fmt.Sprintf("[%s] token [%s]: %s", d.Type, d.ID, d.Message)
+}
+
+// ReconcileOptions configures a reconciliation
+type ReconcileOptions struct {
+	// SampleSize is the number of unspent tokens, chosen at random, to check.
+	// If zero or negative, all the unspent tokens are checked.
+	SampleSize int
+	// BatchSize is the number of tokens per query to the network, defaults to 50
+	BatchSize int
+	// Repair removes from the token store the unspent tokens that are spent on the ledger.
+	// The other drifts are only reported.
+	Repair bool
+}
+
+// ReconcileReport is the outcome of a reconciliation
+type ReconcileReport struct {
+	// Unspent is the number of unspent tokens in the token store
+	Unspent int
+	// Checked is the number of unspent tokens checked against the ledger
+	Checked int
+	// Drifts are the inconsistencies found
+	Drifts []Drift
+	// Repaired are the ids of the tokens removed from the token store
+	Repaired []*token2.ID
+}
+
+// Messages returns a message per drift, in the format of the checks of the ttx and auditor services
+func (r *ReconcileReport) Messages() []string {
+	messages := make([]string, len(r.Drifts))
+	for i, drift := range r.Drifts {
+		messages[i] = drift.String()
+	}
+	return messages
+}
+
+// TokenLedger gives the authoritative state of tokens
+type TokenLedger interface {
+	AreTokensSpent(ctx context.Context, namespace string, tokenIDs []*token2.ID, meta []string) ([]bool, error)
+	QueryTokens(ctx context.Context, namespace string, IDs []*token2.ID) ([][]byte, error)
+}
+
+// Reconcile checks the unspent tokens of the token store against the ledger and reports
+// the tokens that are spent on the ledger, missing from the ledger, or whose output does not match the ledger's.
+// If requested, the tokens spent on the ledger are removed from the token store.
+func (t *Tokens) Reconcile(ctx context.Context, opts ReconcileOptions) (*ReconcileReport, error) {
+	tmsID := t.Storage.tmsID
+	tms, err := t.TMSProvider.GetManagementService(token.WithTMSID(tmsID))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting token management service [%s]", tmsID)
+	}
+	tmsID = tms.ID()
+	net, err := t.NetworkProvider.GetNetwork(tmsID.Network, tms.Channel())
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting network [%s:%s]", tmsID.Network, tmsID.Channel)
+	}
+	r := &reconciler{
+		namespace: tms.Namespace(),
+		unspent:   tms.Vault().NewQueryEngine().UnspentLedgerTokensIteratorBy,
+		ledger:    net,
+		spentIDs:  tms.WalletManager().SpentIDs,
+		deleter:   t.DeleteTokens,
+	}
+	return r.reconcile(ctx, opts)
+}
+
+type reconciler struct {
+	namespace string
+	unspent   func(ctx context.Context) (driver.LedgerTokensIterator, error)
+	ledger    TokenLedger
+	spentIDs  func(ids []*token2.ID) ([]string, error)
+	deleter   func(ids ...*token2.ID) error
+}
+
+func (r *reconciler) reconcile(ctx context.Context, opts ReconcileOptions) (*ReconcileReport, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultReconcileBatchSize
+	}
+	it, err := r.unspent(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get an iterator of unspent tokens")
+	}
+	defer it.Close()
+
+	report := &ReconcileReport{}
+	var buffer []*token2.LedgerToken
+	for {
+		tok, err := it.Next()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get next unspent token")
+		}
+		if tok == nil {
+			break
+		}
+		report.Unspent++
+		if opts.SampleSize > 0 {
+			// reservoir sampling, the sample is checked at the end
+			if len(buffer) < opts.SampleSize {
+				buffer = append(buffer, tok)
+			} else if j := rand.Intn(report.Unspent); j < opts.SampleSize {
+				buffer[j] = tok
+			}
+			continue
+		}
+		buffer = append(buffer, tok)
+		if len(buffer) >= batchSize {
+			if err := r.check(ctx, report, buffer, opts.Repair); err != nil {
+				return nil, err
+			}
+			buffer = nil
+		}
+	}
+	for len(buffer) > 0 {
+		n := min(batchSize, len(buffer))
+		if err := r.check(ctx, report, buffer[:n], opts.Repair); err != nil {
+			return nil, err
+		}
+		buffer = buffer[n:]
+	}
+	logger.Debugf("reconciliation done: [%d] unspent, [%d] checked, [%d] drifts, [%d] repaired", report.Unspent, report.Checked, len(report.Drifts), len(report.Repaired))
+	return report, nil
+}
+
+func (r *reconciler) check(ctx context.Context, report *ReconcileReport, toks []*token2.LedgerToken, repair bool) error {
+	ids := make([]*token2.ID, len(toks))
+	for i, tok := range toks {
+		ids[i] = &tok.ID
+	}
+	meta, err := r.spentIDs(ids)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to compute spent ids for [%v]", ids)
+	}
+	spent, err := r.ledger.AreTokensSpent(ctx, r.namespace, ids, meta)
+	if err != nil {
+		return errors.WithMessagef(err, "cannot fetch spent flags for ids [%v]", ids)
+	}
+	if len(spent) != len(ids) {
+		return errors.Errorf("expected [%d] spent flags, got [%d]", len(ids), len(spent))
+	}
+	report.Checked += len(ids)
+
+	var toDelete []*token2.ID
+	var unspent []*token2.LedgerToken
+	for i, tok := range toks {
+		if spent[i] {
+			report.Drifts = append(report.Drifts, Drift{Type: SpentOnLedger, ID: tok.ID})
+			toDelete = append(toDelete, ids[i])
+			continue
+		}
+		unspent = append(unspent, tok)
+	}
+	if err := r.compareOutputs(ctx, report, unspent); err != nil {
+		return err
+	}
+
+	if repair && len(toDelete) != 0 {
+		if err := r.deleter(toDelete...); err != nil {
+			return errors.WithMessagef(err, "failed to remove token ids [%v]", toDelete)
+		}
+		report.Repaired = append(report.Repaired, toDelete...)
+	}
+	return nil
+}
+
+// compareOutputs compares the outputs of the passed tokens with those on the ledger.
+// A query fails if any of the outputs is missing, therefore, on failure, the tokens are queried one by one.
+// Only a token that the ledger reports as not existing is missing, any other failure is returned.
+func (r *reconciler) compareOutputs(ctx context.Context, report *ReconcileReport, toks []*token2.LedgerToken) error {
+	if len(toks) == 0 {
+		return nil
+	}
+	ids := make([]*token2.ID, len(toks))
+	for i, tok := range toks {
+		ids[i] = &tok.ID
+	}
+	outputs, err := r.ledger.QueryTokens(ctx, r.namespace, ids)
+	if err != nil || len(outputs) != len(ids) {
+		if len(toks) > 1 {
+			for _, tok := range toks {
+				if err := r.compareOutputs(ctx, report, []*token2.LedgerToken{tok}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if network.IsTokenNotFound(err) {
+			report.Drifts = append(report.Drifts, Drift{Type: MissingOnLedger, ID: toks[0].ID, Message: err.Error()})
+			return nil
+		}
+		if err != nil {
+			return errors.WithMessagef(err, "cannot fetch output for id [%s]", toks[0].ID)
+		}
+		return errors.Errorf("expected 1 output for id [%s], got [%d]", toks[0].ID, len(outputs))
+	}
+	for i, tok := range toks {
+		switch {
+		case len(outputs[i]) == 0:
+			report.Drifts = append(report.Drifts, Drift{Type: MissingOnLedger, ID: tok.ID})
+		case !bytes.Equal(outputs[i], tok.Token):
+			report.Drifts = append(report.Drifts, Drift{
+				Type:    OutputMismatch,
+				ID:      tok.ID,
+				Message: fmt.Sprintf("local [%s], ledger [%s]", hash.Hashable(tok.Token), hash.Hashable(outputs[i])),
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tokens
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/test-go/testify/assert"
+)
+
+type ledgerTokensIterator struct {
+	toks []*token2.LedgerToken
+}
+
+func (it *ledgerTokensIterator) Close() {}
+
+func (it *ledgerTokensIterator) Next() (*token2.LedgerToken, error) {
+	if len(it.toks) == 0 {
+		return nil, nil
+	}
+	tok := it.toks[0]
+	it.toks = it.toks[1:]
+	return tok, nil
+}
+
+// tokenLedgerMock holds the outputs on the ledger, a token missing from outputs is spent
+type tokenLedgerMock struct {
+	outputs map[string][]byte
+	queries int
+	// err, if set, is returned by QueryTokens
+	err error
+}
+
+func (l *tokenLedgerMock) AreTokensSpent(_ context.Context, _ string, ids []*token2.ID, meta []string) ([]bool, error) {
+	spent := make([]bool, len(ids))
+	for i, id := range ids {
+		_, ok := l.outputs[id.TxId]
+		spent[i] = !ok && meta[i] != "missing"
+	}
+	return spent, nil
+}
+
+func (l *tokenLedgerMock) QueryTokens(_ context.Context, _ string, ids []*token2.ID) ([][]byte, error) {
+	l.queries++
+	if l.err != nil {
+		return nil, l.err
+	}
+	var res [][]byte
+	for _, id := range ids {
+		output, ok := l.outputs[id.TxId]
+		if !ok {
+			return nil, errors.Errorf("output for key [%s:%d] does not exist", id.TxId, id.Index)
+		}
+		res = append(res, output)
+	}
+	return res, nil
+}
+
+func newReconciler(ledger *tokenLedgerMock, toks []*token2.LedgerToken, deleted *[]*token2.ID) *reconciler {
+	return &reconciler{
+		namespace: "zkat",
+		unspent: func(ctx context.Context) (driver.LedgerTokensIterator, error) {
+			return &ledgerTokensIterator{toks: toks}, nil
+		},
+		ledger: ledger,
+		spentIDs: func(ids []*token2.ID) ([]string, error) {
+			meta := make([]string, len(ids))
+			for i, id := range ids {
+				meta[i] = id.TxId
+			}
+			return meta, nil
+		},
+		deleter: func(ids ...*token2.ID) error {
+			*deleted = append(*deleted, ids...)
+			return nil
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	toks := []*token2.LedgerToken{
+		{ID: token2.ID{TxId: "ok1"}, Token: []byte("ok1")},
+		{ID: token2.ID{TxId: "spent"}, Token: []byte("spent")},
+		{ID: token2.ID{TxId: "missing"}, Token: []byte("missing")},
+		{ID: token2.ID{TxId: "ok2"}, Token: []byte("ok2")},
+		{ID: token2.ID{TxId: "changed"}, Token: []byte("changed")},
+	}
+	ledger := &tokenLedgerMock{outputs: map[string][]byte{
+		"ok1":     []byte("ok1"),
+		"ok2":     []byte("ok2"),
+		"changed": []byte("something else"),
+	}}
+
+	// report only
+	var deleted []*token2.ID
+	report, err := newReconciler(ledger, toks, &deleted).reconcile(context.Background(), ReconcileOptions{BatchSize: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, report.Unspent)
+	assert.Equal(t, 5, report.Checked)
+	assert.Equal(t, []Drift{
+		{Type: SpentOnLedger, ID: token2.ID{TxId: "spent"}},
+		{Type: MissingOnLedger, ID: token2.ID{TxId: "missing"}, Message: "output for key [missing:0] does not exist"},
+		{Type: OutputMismatch, ID: token2.ID{TxId: "changed"}, Message: report.Drifts[2].Message},
+	}, report.Drifts)
+	assert.Len(t, report.Messages(), 3)
+	assert.Empty(t, report.Repaired)
+	assert.Empty(t, deleted)
+
+	// repair
+	report, err = newReconciler(ledger, toks, &deleted).reconcile(context.Background(), ReconcileOptions{Repair: true})
+	assert.NoError(t, err)
+	assert.Len(t, report.Drifts, 3)
+	assert.Equal(t, []*token2.ID{{TxId: "spent"}}, report.Repaired)
+	assert.Equal(t, []*token2.ID{{TxId: "spent"}}, deleted)
+
+	// a failure to query the ledger does not tell that the outputs are missing
+	ledger.err = errors.New("connection refused")
+	deleted = nil
+	_, err = newReconciler(ledger, toks, &deleted).reconcile(context.Background(), ReconcileOptions{Repair: true})
+	assert.EqualError(t, err, "cannot fetch output for id [[ok1:0]]: connection refused")
+	assert.Empty(t, deleted)
+}
+
+func TestReconcileSample(t *testing.T) {
+	var toks []*token2.LedgerToken
+	outputs := map[string][]byte{}
+	for i := 0; i < 100; i++ {
+		id := token2.ID{TxId: "tx", Index: uint64(i)}
+		toks = append(toks, &token2.LedgerToken{ID: id, Token: []byte("tx")})
+		outputs["tx"] = []byte("tx")
+	}
+	ledger := &tokenLedgerMock{outputs: outputs}
+	var deleted []*token2.ID
+	report, err := newReconciler(ledger, toks, &deleted).reconcile(context.Background(), ReconcileOptions{SampleSize: 10, BatchSize: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, 100, report.Unspent)
+	assert.Equal(t, 10, report.Checked)
+	assert.Empty(t, report.Drifts)
+	assert.Equal(t, 3, ledger.queries)
+}
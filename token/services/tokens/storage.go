@@ -8,6 +8,7 @@ package tokens
 
 import (
 	"context"
+	"time"
 
 	errors2 "github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver"
@@ -74,6 +75,8 @@ type TokenToAppend struct {
 	issuer                token.Identity
 	precision             uint64
 	flags                 Flags
+	notBefore             time.Time
+	notAfter              time.Time
 }
 
 type transaction struct {
@@ -152,6 +155,8 @@ func (t *transaction) AppendToken(ctx context.Context, tta TokenToAppend) error
 		Owner:          tta.flags.Mine,
 		Auditor:        tta.flags.Auditor,
 		Issuer:         tta.flags.Issuer,
+		NotBefore:      tta.notBefore,
+		NotAfter:       tta.notAfter,
 	}, tta.owners)
 	if err != nil && !errors2.HasCause(err, driver.UniqueKeyViolation) {
 		return errors.Wrapf(err, "cannot store token in db")
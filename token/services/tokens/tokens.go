@@ -9,10 +9,12 @@ package tokens
 import (
 	"context"
 	"runtime/debug"
+	"time"
 
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/hash"
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/validity"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
 	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
@@ -425,6 +427,16 @@ func (t *Tokens) parse(
 			continue
 		}
 
+		var notBefore, notAfter time.Time
+		if ownerType == validity.Validity {
+			w := &validity.Window{}
+			if err := w.FromBytes(ownerIdentity); err != nil {
+				logger.Errorf("could not unmarshal validity window when storing token: %s", err.Error())
+				continue
+			}
+			notBefore, notAfter = w.NotBefore, w.NotAfter
+		}
+
 		tta := TokenToAppend{
 			txID:                  txID,
 			index:                 output.Index,
@@ -443,6 +455,8 @@ func (t *Tokens) parse(
 				Auditor: auditorFlag,
 				Issuer:  issuerFlag,
 			},
+			notBefore: notBefore,
+			notAfter:  notAfter,
 		}
 		toAppend = append(toAppend, tta)
 
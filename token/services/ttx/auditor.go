@@ -74,6 +74,12 @@ func (a *TxAuditor) Transactions(params QueryTransactionsParams) (driver.Transac
 	return a.auditDB.Transactions(params)
 }
 
+// TransactionsPage returns a page of the transaction records filtered by the given params.
+// To get the next page, pass the returned cursor in the pagination of the next call.
+func (a *TxAuditor) TransactionsPage(params QueryTransactionsParams, pagination Pagination) (*driver.Page[*driver.TransactionRecord], error) {
+	return a.auditDB.TransactionsPage(params, pagination)
+}
+
 // NewPaymentsFilter returns a programmable filter over the payments sent or received by enrollment IDs.
 func (a *TxAuditor) NewPaymentsFilter() *auditdb.PaymentsFilter {
 	return a.auditDB.NewPaymentsFilter()
@@ -122,12 +128,13 @@ func (r *RegisterAuditorView) Call(context view.Context) (interface{}, error) {
 }
 
 type AuditingViewInitiator struct {
-	tx    *Transaction
-	local bool
+	tx      *Transaction
+	auditor view.Identity
+	local   bool
 }
 
-func newAuditingViewInitiator(tx *Transaction, local bool) *AuditingViewInitiator {
-	return &AuditingViewInitiator{tx: tx, local: local}
+func newAuditingViewInitiator(tx *Transaction, auditor view.Identity, local bool) *AuditingViewInitiator {
+	return &AuditingViewInitiator{tx: tx, auditor: auditor, local: local}
 }
 
 func (a *AuditingViewInitiator) Call(context view.Context) (interface{}, error) {
@@ -154,7 +161,7 @@ func (a *AuditingViewInitiator) Call(context view.Context) (interface{}, error)
 		return nil, errors.WithMessage(err, "failed to read audit event")
 	}
 	span.AddEvent("received_message")
-	logger.Debugf("reply received from %s", a.tx.Opts.Auditor)
+	logger.Debugf("reply received from %s", a.auditor)
 
 	// Check signature
 	signed, err := a.tx.MarshallToAudit()
@@ -162,12 +169,13 @@ func (a *AuditingViewInitiator) Call(context view.Context) (interface{}, error)
 		return nil, errors.Wrapf(err, "failed marshalling message to sign")
 	}
 	if logger.IsEnabledFor(zapcore.DebugLevel) {
-		logger.Debugf("Verifying auditor signature on [%s][%s][%s]", a.tx.Opts.Auditor.UniqueID(), hash.Hashable(signed).String(), a.tx.ID())
+		logger.Debugf("Verifying auditor signature on [%s][%s][%s]", a.auditor.UniqueID(), hash.Hashable(signed).String(), a.tx.ID())
 	}
 
-	validAuditing := false
+	// the signature is stored at the position of the auditor in the public parameters
+	auditorIndex := -1
 	span.AddEvent("validate_auditing")
-	for _, auditorID := range a.tx.TokenService().PublicParametersManager().PublicParameters().Auditors() {
+	for i, auditorID := range a.tx.TokenService().PublicParametersManager().PublicParameters().Auditors() {
 		v, err := a.tx.TokenService().SigService().AuditorVerifier(auditorID)
 		if err != nil {
 			logger.Debugf("failed to get auditor verifier for [%s]", auditorID)
@@ -180,23 +188,23 @@ func (a *AuditingViewInitiator) Call(context view.Context) (interface{}, error)
 			if logger.IsEnabledFor(zapcore.DebugLevel) {
 				logger.Debugf("auditor signature verified [%s][%s][%s]", auditorID, base64.StdEncoding.EncodeToString(signature), hash.Hashable(signed))
 			}
-			validAuditing = true
+			auditorIndex = i
 			break
 		}
 	}
-	if !validAuditing {
+	if auditorIndex < 0 {
 		return nil, errors.Errorf("failed verifying auditor signature [%s][%s]", hash.Hashable(signed).String(), a.tx.TokenRequest.Anchor)
 	}
 	span.AddEvent("append_auditor_signature")
-	a.tx.TokenRequest.AddAuditorSignature(signature)
+	a.tx.TokenRequest.SetAuditorSignature(auditorIndex, signature)
 
 	logger.Debug("auditor signature verified")
 	return session, nil
 }
 
 func (a *AuditingViewInitiator) startRemote(context view.Context) (view.Session, error) {
-	logger.Debugf("Starting remote auditing session with [%s] for [%s]", a.tx.Opts.Auditor.UniqueID(), a.tx.ID())
-	session, err := context.GetSession(a, a.tx.Opts.Auditor)
+	logger.Debugf("Starting remote auditing session with [%s] for [%s]", a.auditor.UniqueID(), a.tx.ID())
+	session, err := context.GetSession(a, a.auditor)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed getting session")
 	}
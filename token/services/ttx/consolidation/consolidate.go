@@ -0,0 +1,342 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consolidation
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokenlockdb"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultMaxInputsPerAction is the default maximum number of tokens merged by a single transfer action
+	DefaultMaxInputsPerAction = 10
+)
+
+// Request describes a consolidation of the unspent tokens of a given type owned by a wallet
+type Request struct {
+	// TMSID identifies the TMS the wallet belongs to
+	TMSID token.TMSID
+	// Wallet is the identifier of the owner wallet whose tokens must be merged
+	Wallet string
+	// Type is the type of the tokens to merge
+	Type token2.Type
+	// MaxInputsPerAction bounds the number of tokens spent by each self-transfer
+	MaxInputsPerAction int
+	// MaxTransactions bounds the number of transactions generated by a single run, zero means no bound
+	MaxTransactions int
+	// MinTokens is the number of unspent tokens below which the wallet is not consolidated
+	MinTokens int
+	// Auditor is the identity of the auditor, if required by the TMS
+	Auditor view.Identity
+	// Anonymous is true if the transactions must be anonymous
+	Anonymous bool
+	// FinalityTimeout is the time to wait for the finality of each transaction, zero means the ttx default
+	FinalityTimeout time.Duration
+}
+
+// BatchResult is the outcome of the consolidation of a batch of tokens
+type BatchResult struct {
+	// TxID is the identifier of the transaction that merged the batch, empty if the transaction could not be assembled
+	TxID string
+	// Inputs are the tokens spent by the transaction
+	Inputs []*token2.ID
+	// Quantity is the sum of the inputs
+	Quantity uint64
+	// Fee is the part of Quantity paid to the fee collector, the output holds the rest
+	Fee uint64
+	// Err is the reason of the failure, nil if the batch has been committed
+	Err error
+}
+
+// Report summarizes a consolidation run
+type Report struct {
+	// TokensBefore is the number of unspent tokens found before the run
+	TokensBefore int
+	// Batches contains the outcome of each batch, in order of execution
+	Batches []BatchResult
+}
+
+// Merged returns the number of tokens spent by the committed batches
+func (r *Report) Merged() int {
+	merged := 0
+	for _, b := range r.Batches {
+		if b.Err == nil {
+			merged += len(b.Inputs)
+		}
+	}
+	return merged
+}
+
+// TokensAfter returns the expected number of unspent tokens after the run
+func (r *Report) TokensAfter() int {
+	after := r.TokensBefore
+	for _, b := range r.Batches {
+		if b.Err == nil {
+			after -= len(b.Inputs) - 1
+		}
+	}
+	return after
+}
+
+// ProgressListener is notified every time a batch completes
+type ProgressListener interface {
+	OnBatch(request *Request, batch *BatchResult, done, total int)
+}
+
+// Locker locks tokens on behalf of a transaction, as the token selector does
+type Locker interface {
+	// Lock locks the passed token for the passed transaction, it fails if the token is already locked
+	Lock(tokenID *token2.ID, consumerTxID string) error
+	// UnlockByTxID unlocks all the tokens locked by the passed transaction
+	UnlockByTxID(consumerTxID string) error
+}
+
+// View merges the unspent tokens of a given type owned by a wallet into fewer tokens.
+// It splits the tokens into batches of at most MaxInputsPerAction tokens, and, for each batch, it
+// generates a self-transfer that goes through endorsement, ordering, and finality.
+// The tokens of a batch are locked in the token lock db of the TMS before the transfer is assembled,
+// so that the selector does not pick them for a concurrent transfer.
+// If a fee policy applies to the type, the output of a batch is the sum of its inputs minus the fee.
+// A failing batch does not stop the run, its error is recorded in the returned Report.
+type View struct {
+	Request  *Request
+	Listener ProgressListener
+}
+
+// NewView returns a new instance of the consolidation view for the passed request
+func NewView(request *Request) *View {
+	return &View{Request: request}
+}
+
+// WithListener sets the listener to notify about the progress of the consolidation
+func (v *View) WithListener(listener ProgressListener) *View {
+	v.Listener = listener
+	return v
+}
+
+// Consolidate runs the consolidation view for the passed request and returns its report
+func Consolidate(context view.Context, request *Request) (*Report, error) {
+	boxed, err := context.RunView(NewView(request))
+	if err != nil {
+		return nil, err
+	}
+	return boxed.(*Report), nil
+}
+
+func (v *View) Call(context view.Context) (interface{}, error) {
+	if v.Request == nil {
+		return nil, errors.New("no consolidation request provided")
+	}
+	req := v.Request
+	tms := token.GetManagementService(context, token.WithTMSID(req.TMSID))
+	if tms == nil {
+		return nil, errors.Errorf("failed getting token management service [%s]", req.TMSID)
+	}
+	wallet := tms.WalletManager().OwnerWallet(req.Wallet)
+	if wallet == nil {
+		return nil, errors.Errorf("owner wallet [%s] not found", req.Wallet)
+	}
+	unspent, err := wallet.ListUnspentTokens(ttx.WithType(req.Type))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed listing unspent tokens of type [%s] for wallet [%s]", req.Type, req.Wallet)
+	}
+	report := &Report{TokensBefore: len(unspent.Tokens)}
+	if len(unspent.Tokens) < max(req.MinTokens, 2) {
+		logger.Debugf("wallet [%s] has [%d] tokens of type [%s], nothing to consolidate", req.Wallet, len(unspent.Tokens), req.Type)
+		return report, nil
+	}
+
+	batches, err := Batches(unspent.Tokens, req.maxInputsPerAction(), tms.PublicParametersManager().PublicParameters().Precision())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed splitting tokens into batches")
+	}
+	if req.MaxTransactions > 0 && len(batches) > req.MaxTransactions {
+		batches = batches[:req.MaxTransactions]
+	}
+	logger.Debugf("consolidate [%d] tokens of type [%s] for wallet [%s] in [%d] batches", len(unspent.Tokens), req.Type, req.Wallet, len(batches))
+
+	locker, err := tokenlockdb.GetByTMSId(context, req.TMSID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting token lock db")
+	}
+	feePolicy := tms.PublicParametersManager().PublicParameters().FeePolicies().Lookup(req.Type)
+	for i, batch := range batches {
+		result := v.merge(context, wallet, locker, feePolicy, batch)
+		if result.Err != nil {
+			logger.Errorf("failed consolidating batch [%d] of wallet [%s]: [%s]", i, req.Wallet, result.Err)
+		}
+		report.Batches = append(report.Batches, *result)
+		if v.Listener != nil {
+			v.Listener.OnBatch(req, result, i+1, len(batches))
+		}
+	}
+	return report, nil
+}
+
+func (v *View) merge(context view.Context, wallet *token.OwnerWallet, locker Locker, feePolicy *driver.FeePolicy, batch *Batch) *BatchResult {
+	req := v.Request
+	result := &BatchResult{Inputs: batch.IDs, Quantity: batch.Quantity}
+
+	recipient, err := batch.Recipient(feePolicy, wallet)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	value, fee, err := batch.Split(feePolicy, recipient)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Fee = fee
+	tx, err := ttx.NewTransaction(
+		context,
+		nil,
+		ttx.WithTMSID(req.TMSID),
+		ttx.WithAuditor(req.Auditor),
+		ttx.WithAnonymousTransaction(req.Anonymous),
+	)
+	if err != nil {
+		result.Err = errors.Wrapf(err, "failed creating transaction")
+		return result
+	}
+	result.TxID = tx.ID()
+	release := func() {
+		tx.Release()
+		if err := locker.UnlockByTxID(tx.ID()); err != nil {
+			logger.Warnf("failed releasing the tokens locked by [%s]: [%s]", tx.ID(), err)
+		}
+	}
+	if err := Lock(locker, tx.ID(), batch.IDs); err != nil {
+		result.Err = err
+		return result
+	}
+	if err := tx.Transfer(wallet, req.Type, []uint64{value}, []view.Identity{recipient}, token.WithTokenIDs(batch.IDs...)); err != nil {
+		release()
+		result.Err = errors.Wrapf(err, "failed adding self-transfer")
+		return result
+	}
+	if _, err := context.RunView(ttx.NewCollectEndorsementsView(tx)); err != nil {
+		release()
+		result.Err = errors.Wrapf(err, "failed collecting endorsements")
+		return result
+	}
+	var orderingView view.View
+	if req.FinalityTimeout > 0 {
+		orderingView = ttx.NewOrderingAndFinalityWithTimeoutView(tx, req.FinalityTimeout)
+	} else {
+		orderingView = ttx.NewOrderingAndFinalityView(tx)
+	}
+	if _, err := context.RunView(orderingView); err != nil {
+		result.Err = errors.Wrapf(err, "failed ordering or waiting for finality")
+		return result
+	}
+	return result
+}
+
+// Lock locks the passed tokens for the passed transaction.
+// If a token is already locked, for instance by a concurrent transfer, the tokens locked so far are released.
+func Lock(locker Locker, txID string, ids []*token2.ID) error {
+	for _, id := range ids {
+		if err := locker.Lock(id, txID); err != nil {
+			if err2 := locker.UnlockByTxID(txID); err2 != nil {
+				logger.Warnf("failed releasing the tokens locked by [%s]: [%s]", txID, err2)
+			}
+			return errors.WithMessagef(err, "failed locking token [%s]", id)
+		}
+	}
+	return nil
+}
+
+func (r *Request) maxInputsPerAction() int {
+	if r.MaxInputsPerAction < 2 {
+		return DefaultMaxInputsPerAction
+	}
+	return r.MaxInputsPerAction
+}
+
+// Batch is a set of tokens to be merged into a single output
+type Batch struct {
+	IDs      []*token2.ID
+	Owners   []token.Identity
+	Quantity uint64
+}
+
+// Recipient returns the owner of the output merging the batch. If the passed policy charges a fee, this is the owner
+// of the first token of the batch, so that the validators recognize the output as change, otherwise a fresh identity of the wallet.
+func (b *Batch) Recipient(policy *driver.FeePolicy, wallet *token.OwnerWallet) (token.Identity, error) {
+	if policy != nil && len(b.Owners) != 0 {
+		return b.Owners[0], nil
+	}
+	recipient, err := wallet.GetRecipientIdentity()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting recipient identity from wallet [%s]", wallet.ID())
+	}
+	return recipient, nil
+}
+
+// Split returns the value of the output merging the batch into the passed recipient, and the fee charged by the passed policy, if any.
+// The validators do not charge an output owned by the owner of an input, otherwise the output pays the fee.
+func (b *Batch) Split(policy *driver.FeePolicy, recipient token.Identity) (uint64, uint64, error) {
+	if policy == nil {
+		return b.Quantity, 0, nil
+	}
+	if _, payments := policy.SplitOutputs(b.Owners, []token.Identity{recipient}); len(payments) == 0 {
+		return b.Quantity, 0, nil
+	}
+	value, fee, err := policy.SplitBudget(0, b.Quantity)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "failed computing fee")
+	}
+	if value == 0 {
+		return 0, 0, errors.Errorf("batch of value [%d] does not cover the fee", b.Quantity)
+	}
+	return value, fee, nil
+}
+
+// Batches splits the passed tokens into batches of at most maxInputs tokens each.
+// The sum of each batch must fit in 64 bits, if adding a token would overflow, a new batch is started.
+// Batches with a single token are discarded, there is nothing to merge.
+func Batches(tokens []*token2.UnspentToken, maxInputs int, precision uint64) ([]*Batch, error) {
+	var batches []*Batch
+	current := &Batch{}
+	sum := big.NewInt(0)
+	flush := func() {
+		if len(current.IDs) > 1 {
+			current.Quantity = sum.Uint64()
+			batches = append(batches, current)
+		}
+		current = &Batch{}
+		sum = big.NewInt(0)
+	}
+	for _, tok := range tokens {
+		q, err := token2.ToQuantity(tok.Quantity, precision)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid quantity for token [%s]", tok.Id)
+		}
+		next := new(big.Int).Add(sum, q.ToBigInt())
+		if !next.IsUint64() {
+			flush()
+			next = q.ToBigInt()
+		}
+		current.IDs = append(current.IDs, tok.Id)
+		current.Owners = append(current.Owners, tok.Owner)
+		sum = next
+		if len(current.IDs) == maxInputs {
+			flush()
+		}
+	}
+	flush()
+	return batches, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consolidation
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatches(t *testing.T) {
+	tokens := unspentTokens(1, 2, 3, 4, 5, 6, 7)
+
+	batches, err := Batches(tokens, 3, 64)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+	assert.Len(t, batches[0].IDs, 3)
+	assert.Equal(t, uint64(6), batches[0].Quantity)
+	assert.Len(t, batches[1].IDs, 3)
+	assert.Equal(t, uint64(15), batches[1].Quantity)
+
+	// the last token is left alone, there is nothing to merge it with
+	batches, err = Batches(tokens, 7, 64)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, uint64(28), batches[0].Quantity)
+}
+
+func TestBatches_Overflow(t *testing.T) {
+	tokens := unspentTokens(math.MaxUint64-1, 1, 1, 2)
+	batches, err := Batches(tokens, 10, 64)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+	assert.Equal(t, uint64(math.MaxUint64), batches[0].Quantity)
+	assert.Equal(t, uint64(3), batches[1].Quantity)
+}
+
+func TestBatches_InvalidQuantity(t *testing.T) {
+	tokens := unspentTokens(1, 2)
+	tokens[1].Quantity = "invalid"
+	_, err := Batches(tokens, 10, 64)
+	assert.Error(t, err)
+}
+
+func TestReport(t *testing.T) {
+	report := &Report{
+		TokensBefore: 10,
+		Batches: []BatchResult{
+			{Inputs: make([]*token2.ID, 4)},
+			{Inputs: make([]*token2.ID, 4), Err: fmt.Errorf("failed")},
+			{Inputs: make([]*token2.ID, 2)},
+		},
+	}
+	assert.Equal(t, 6, report.Merged())
+	assert.Equal(t, 6, report.TokensAfter())
+}
+
+func TestBatch_Split(t *testing.T) {
+	policy := &driver.FeePolicy{TokenType: "USD", Flat: 5, Rate: 100, Collector: []byte("collector")}
+	batch := &Batch{Owners: []token.Identity{[]byte("alice1"), []byte("alice2")}, Quantity: 1000}
+
+	// without a policy, the output holds the whole batch
+	value, fee, err := batch.Split(nil, []byte("alice3"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), value)
+	assert.Zero(t, fee)
+
+	// an output to the owner of an input is not charged
+	value, fee, err = batch.Split(policy, []byte("alice2"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), value)
+	assert.Zero(t, fee)
+
+	// an output to a fresh identity pays the fee
+	value, fee, err = batch.Split(policy, []byte("alice3"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(985), value)
+	assert.Equal(t, uint64(15), fee)
+
+	_, _, err = (&Batch{Quantity: 5}).Split(policy, []byte("alice3"))
+	assert.Error(t, err)
+}
+
+func TestBatch_Recipient(t *testing.T) {
+	policy := &driver.FeePolicy{TokenType: "USD", Flat: 5, Rate: 100, Collector: []byte("collector")}
+	batch := &Batch{Owners: []token.Identity{[]byte("alice1"), []byte("alice2")}, Quantity: 1000}
+
+	// with a policy, the batch is merged into the owner of its first token, and nothing is charged
+	recipient, err := batch.Recipient(policy, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, token.Identity("alice1"), recipient)
+	value, fee, err := batch.Split(policy, recipient)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), value)
+	assert.Zero(t, fee)
+}
+
+type locker struct {
+	locks map[string]string
+}
+
+func (l *locker) Lock(id *token2.ID, txID string) error {
+	if _, ok := l.locks[id.String()]; ok {
+		return errors.Errorf("token [%s] already locked", id)
+	}
+	l.locks[id.String()] = txID
+	return nil
+}
+
+func (l *locker) UnlockByTxID(txID string) error {
+	for id, holder := range l.locks {
+		if holder == txID {
+			delete(l.locks, id)
+		}
+	}
+	return nil
+}
+
+func TestLock(t *testing.T) {
+	l := &locker{locks: map[string]string{}}
+	ids := []*token2.ID{{TxId: "a"}, {TxId: "b"}, {TxId: "c"}}
+
+	// a concurrent transfer holds b
+	assert.NoError(t, l.Lock(ids[1], "transfer"))
+	assert.Error(t, Lock(l, "consolidation", ids))
+	assert.Equal(t, map[string]string{ids[1].String(): "transfer"}, l.locks)
+
+	assert.NoError(t, l.UnlockByTxID("transfer"))
+	assert.NoError(t, Lock(l, "consolidation", ids))
+	assert.Len(t, l.locks, 3)
+}
+
+func unspentTokens(values ...uint64) []*token2.UnspentToken {
+	tokens := make([]*token2.UnspentToken, len(values))
+	for i, v := range values {
+		tokens[i] = &token2.UnspentToken{
+			Id:       &token2.ID{TxId: fmt.Sprintf("tx%d", i)},
+			Type:     "USD",
+			Quantity: token2.NewQuantityFromUInt64(v).Decimal(),
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consolidation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/pkg/errors"
+)
+
+type ViewManager interface {
+	InitiateView(view view.View, ctx context.Context) (interface{}, error)
+}
+
+// Service runs consolidations on demand or periodically, outside the scope of an application view
+type Service struct {
+	viewManager ViewManager
+	listener    ProgressListener
+
+	mutex     sync.Mutex
+	schedules map[string]context.CancelFunc
+}
+
+// NewService returns a new consolidation service.
+// The passed listener, if not nil, is notified about the progress of every run.
+func NewService(viewManager ViewManager, listener ProgressListener) *Service {
+	return &Service{
+		viewManager: viewManager,
+		listener:    listener,
+		schedules:   map[string]context.CancelFunc{},
+	}
+}
+
+// Consolidate runs a consolidation now and returns its report
+func (s *Service) Consolidate(ctx context.Context, request *Request) (*Report, error) {
+	boxed, err := s.viewManager.InitiateView(NewView(request).WithListener(s.listener), ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed consolidating wallet [%s] for type [%s]", request.Wallet, request.Type)
+	}
+	return boxed.(*Report), nil
+}
+
+// Schedule runs a consolidation for the passed request every interval, until Unschedule is called or the passed context is done.
+// Only one schedule per (TMS, wallet, type) is allowed.
+func (s *Service) Schedule(ctx context.Context, request *Request, interval time.Duration) error {
+	if interval <= 0 {
+		return errors.Errorf("invalid interval [%s]", interval)
+	}
+	key := scheduleKey(request)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.schedules[key]; ok {
+		return errors.Errorf("consolidation already scheduled for [%s]", key)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	s.schedules[key] = cancel
+	go s.run(ctx, request, interval)
+	return nil
+}
+
+// Unschedule stops the periodic consolidation for the passed request, if any
+func (s *Service) Unschedule(request *Request) {
+	key := scheduleKey(request)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if cancel, ok := s.schedules[key]; ok {
+		cancel()
+		delete(s.schedules, key)
+	}
+}
+
+func (s *Service) run(ctx context.Context, request *Request, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Debugf("consolidation for [%s] stopped", scheduleKey(request))
+			return
+		case <-ticker.C:
+			report, err := s.Consolidate(ctx, request)
+			if err != nil {
+				logger.Errorf("scheduled consolidation for [%s] failed: [%s]", scheduleKey(request), err)
+				continue
+			}
+			logger.Debugf("scheduled consolidation for [%s] done: [%d] tokens before, [%d] after", scheduleKey(request), report.TokensBefore, report.TokensAfter())
+		}
+	}
+}
+
+func scheduleKey(request *Request) string {
+	return request.TMSID.String() + ":" + request.Wallet + ":" + string(request.Type)
+}
@@ -25,6 +25,8 @@ const txIdLabel tracing.LabelName = "tx_id"
 
 type QueryTransactionsParams = ttxdb.QueryTransactionsParams
 
+type Pagination = ttxdb.Pagination
+
 type NetworkProvider interface {
 	GetNetwork(network string, channel string) (*network.Network, error)
 }
@@ -93,6 +95,26 @@ func (a *DB) GetTransactionEndorsementAcks(id string) (map[string][]byte, error)
 	return a.ttxDB.GetTransactionEndorsementAcks(id)
 }
 
+// ParkTransaction stores the serialized transaction with the passed id at the passed stage
+func (a *DB) ParkTransaction(txID string, stage string, raw []byte) error {
+	return a.ttxDB.ParkTransaction(txID, stage, raw)
+}
+
+// GetParkedTransaction returns the serialized transaction parked with the passed id and its stage, nil if none exists
+func (a *DB) GetParkedTransaction(txID string) (string, []byte, error) {
+	return a.ttxDB.GetParkedTransaction(txID)
+}
+
+// UnparkTransaction removes the transaction parked with the passed id
+func (a *DB) UnparkTransaction(txID string) error {
+	return a.ttxDB.UnparkTransaction(txID)
+}
+
+// QueryParkedTransactions returns the records of all the parked transactions, oldest first
+func (a *DB) QueryParkedTransactions() ([]ttxdb.ParkedTransactionRecord, error) {
+	return a.ttxDB.QueryParkedTransactions()
+}
+
 func (a *DB) Check(context context.Context) ([]string, error) {
 	return a.checkService.Check(context)
 }
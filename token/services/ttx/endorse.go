@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"reflect"
 	"runtime/debug"
+	"slices"
 	"time"
 
 	"github.com/hyperledger-labs/fabric-smart-client/platform/common/utils/collections"
@@ -21,8 +22,10 @@ import (
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/multisig"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/validity"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokens"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx/offline"
 	session2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/json/session"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel/trace"
@@ -59,6 +62,8 @@ type CollectEndorsementsView struct {
 	tx       *Transaction
 	Opts     *EndorsementsOpts
 	sessions map[string]view.Session
+	// offlineRequests collects the requests of signature for the offline wallets
+	offlineRequests *offline.RequestEnvelope
 }
 
 // NewCollectEndorsementsView returns an instance of the CollectEndorsementsView struct.
@@ -85,7 +90,30 @@ func NewCollectEndorsementsView(tx *Transaction, opts ...EndorsementsOpt) *Colle
 // 3. Before completing, all recipients receive the approved transaction.
 // Depending on the token driver implementation, the recipient's signature might or might not be needed to make
 // the token transaction valid.
+// If some signatures must be produced offline, see WithOfflineWallet, the view parks the transaction in the ttx db
+// and returns, as result, the *offline.RequestEnvelope to be signed. The endorsement can then be completed by passing
+// the parked transaction, see LoadParkedTransaction, and the signatures, see WithOfflineSignatures, to a new instance of this view.
+// The transaction is parked at EndorsingStage while the endorsements are collected, and at EndorsedStage when done,
+// so that it can be recovered if the node stops, see Manager.RecoverTMS.
 func (c *CollectEndorsementsView) Call(context view.Context) (interface{}, error) {
+	// a transaction waiting for offline signatures stays parked at its stage until endorsed
+	resuming := len(c.Opts.OfflineSignatures) != 0
+	if !resuming {
+		if err := parkAt(context, c.tx, EndorsingStage); err != nil {
+			return nil, err
+		}
+	}
+	res, err := c.collect(context)
+	if err != nil {
+		if !resuming {
+			unpark(context, c.tx)
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *CollectEndorsementsView) collect(context view.Context) (interface{}, error) {
 	span := trace.SpanFromContext(context.Context())
 	metrics := GetMetrics(context)
 
@@ -103,6 +131,12 @@ func (c *CollectEndorsementsView) Call(context view.Context) (interface{}, error
 		return nil, errors.WithMessage(err, "failed requesting signatures on transfers")
 	}
 
+	span.AddEvent("Request signatures on freezes")
+	freezeSigmas, err := c.requestSignaturesOnFreezes(context, externalWallets)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed requesting signatures on freezes")
+	}
+
 	// signal the external wallets that the process is completed
 	span.AddEvent("Inform external wallets that endorsement is complete")
 	for id, signer := range externalWallets {
@@ -112,9 +146,15 @@ func (c *CollectEndorsementsView) Call(context view.Context) (interface{}, error
 		}
 	}
 
+	// park the transaction, if some signatures must be produced offline
+	if c.offlineRequests != nil {
+		span.AddEvent("Park transaction for offline signatures")
+		return c.parkForOfflineSignatures(context)
+	}
+
 	// Add the signatures to the token request
 	span.AddEvent("Add the signatures to the token request")
-	if !c.tx.TokenRequest.SetSignatures(mergeSigmas(issueSigmas, transferSigmas)) {
+	if !c.tx.TokenRequest.SetSignatures(mergeSigmas(issueSigmas, transferSigmas, freezeSigmas)) {
 		return nil, errors.New("failed setting signatures on token request, some signatures are missing")
 	}
 
@@ -146,7 +186,7 @@ func (c *CollectEndorsementsView) Call(context view.Context) (interface{}, error
 
 	// Cleanup audit
 	span.AddEvent("Cleanup audit")
-	if err := c.cleanupAudit(context); err != nil {
+	if err := c.cleanupAudit(context, auditors); err != nil {
 		span.RecordError(err)
 		return nil, errors.WithMessage(err, "failed cleaning up audit")
 	}
@@ -155,6 +195,11 @@ func (c *CollectEndorsementsView) Call(context view.Context) (interface{}, error
 		logger.Debugf("CollectEndorsementsView done.")
 	}
 
+	span.AddEvent("Park endorsed transaction")
+	if err := parkAt(context, c.tx, EndorsedStage); err != nil {
+		return nil, err
+	}
+
 	labels := []string{
 		"network", c.tx.Network(),
 		"channel", c.tx.Channel(),
@@ -181,9 +226,29 @@ func (c *CollectEndorsementsView) requestSignaturesOnTransfers(context view.Cont
 		logger.Debugf("collecting signature on [%d] request transfer", len(c.tx.TokenRequest.Metadata.Transfers))
 	}
 
+	// the enforcers of the forced transfers sign with their long-term identities
+	sigService := c.tx.TokenService().SigService()
+	enforcers := c.tx.TokenService().PublicParametersManager().PublicParameters().Enforcers()
 	return c.requestSignatures(
 		c.tx.TokenRequest.TransferSigners(),
-		c.tx.TokenService().SigService().OwnerVerifier,
+		func(identity view.Identity) (token.Verifier, error) {
+			if slices.ContainsFunc(enforcers, identity.Equal) {
+				return sigService.AuditorVerifier(identity)
+			}
+			return sigService.OwnerVerifier(identity)
+		},
+		context,
+		externalWallets,
+	)
+}
+
+func (c *CollectEndorsementsView) requestSignaturesOnFreezes(context view.Context, externalWallets map[string]ExternalWalletSigner) (map[string][]byte, error) {
+	if logger.IsEnabledFor(zapcore.DebugLevel) {
+		logger.Debugf("collecting signature on [%d] request freeze", len(c.tx.TokenRequest.Actions.Freezes))
+	}
+	return c.requestSignatures(
+		c.tx.TokenRequest.FreezeSigners(),
+		c.tx.TokenService().SigService().AuditorVerifier,
 		context,
 		externalWallets,
 	)
@@ -209,6 +274,7 @@ func (c *CollectEndorsementsView) requestSignatures(signers []view.Identity, ver
 		// - there is a signer locally bound to the party, use it to generate the signature
 		// - there is a wallet bound to the party but the signer is not local, the signature is generated externally
 		// - the identity is a multi-sig identity
+		// - the identity binds an owner to a validity window
 		// - the signature must be generated by a remote party
 
 		signatureRequest := &SignatureRequest{
@@ -220,25 +286,84 @@ func (c *CollectEndorsementsView) requestSignatures(signers []view.Identity, ver
 		logger.Debugf("collecting signature on request from [%s]", signerIdentity)
 
 		// Case: the identity is a multi-sig identity
-		ok, multiSigners, err := multisig.Unwrap(signerIdentity)
+		ok, multiIdentity, err := multisig.UnwrapMultiIdentity(signerIdentity)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed unwrapping multi-sig identity [%s]", signerIdentity)
 		}
 		if ok {
 			span.AddEvent(fmt.Sprintf("%d. Multi-sig signer", i))
-			logger.Debugf("found multi-sig identity [%s], request multi-sig signature to [%d] parties", signerIdentity, len(multiSigners))
-			// collect the signatures from multiSigners
-			multiSignersSigmas, err := c.requestSignatures(multiSigners, verifierGetter, context, externalWallets)
+			multiSigners := multiIdentity.Identities
+			threshold := multiIdentity.EffectiveThreshold()
+			logger.Debugf("found multi-sig identity [%s], request multi-sig signature to [%d] parties, threshold [%d]", signerIdentity, len(multiSigners), threshold)
+			var sigma []byte
+			if threshold == len(multiSigners) {
+				// collect the signatures from all multiSigners
+				multiSignersSigmas, err := c.requestSignatures(multiSigners, verifierGetter, context, externalWallets)
+				if err != nil {
+					return nil, errors.WithMessage(err, "failed requesting signatures")
+				}
+				if len(multiSignersSigmas) < len(multiSigners) {
+					// some signatures must be produced offline, the multi-sig signature is assembled once they are available
+					logger.Debugf("multi-sig identity [%s] waiting for offline signatures", signerIdentity)
+					continue
+				}
+				logger.Debugf("collected [%d] signatures for multi-sig identity [%s]", len(multiSignersSigmas), signerIdentity)
+				sigma, err = multisig.JoinSignatures(multiSigners, multiSignersSigmas)
+				if err != nil {
+					return nil, errors.WithMessage(err, "failed joining multi-sig signatures")
+				}
+			} else {
+				// collect the signatures from multiSigners until the threshold is reached
+				multiSignersSigmas, err := c.requestThresholdSignatures(multiSigners, threshold, verifierGetter, context, externalWallets)
+				if err != nil {
+					return nil, errors.WithMessage(err, "failed requesting threshold signatures")
+				}
+				if len(multiSignersSigmas) < threshold {
+					// some signatures must be produced offline, the multi-sig signature is assembled once they are available
+					logger.Debugf("multi-sig identity [%s] waiting for offline signatures", signerIdentity)
+					continue
+				}
+				logger.Debugf("collected [%d] signatures for multi-sig identity [%s]", len(multiSignersSigmas), signerIdentity)
+				sigma, err = multisig.JoinThresholdSignatures(multiSigners, threshold, multiSignersSigmas)
+				if err != nil {
+					return nil, errors.WithMessage(err, "failed joining multi-sig signatures")
+				}
+			}
+			sigmas[signerIdentity.UniqueID()] = sigma
+			span.AddEvent("Done requesting multi-sig")
+			continue
+		}
+
+		// Case: the identity binds an owner to a validity window.
+		// The owner signs while the window is open, the issuer once it has expired
+		ok, window, err := validity.Unwrap(signerIdentity)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed unwrapping validity identity [%s]", signerIdentity)
+		}
+		if ok {
+			span.AddEvent(fmt.Sprintf("%d. Validity signer", i))
+			signer, reclaim := validity.SignerOf(window, time.Now())
+			getter := verifierGetter
+			if reclaim {
+				getter = c.tx.TokenService().SigService().IssuerVerifier
+			}
+			logger.Debugf("found validity identity [%s], request signature to [%s], reclaim [%v]", signerIdentity, signer, reclaim)
+			signerSigmas, err := c.requestSignatures([]view.Identity{signer}, getter, context, externalWallets)
 			if err != nil {
-				return nil, errors.WithMessage(err, "failed requesting signatures")
+				return nil, errors.WithMessage(err, "failed requesting signature")
+			}
+			signerSigma, ok := signerSigmas[signer.UniqueID()]
+			if !ok {
+				// the signature must be produced offline, the validity signature is assembled once it is available
+				logger.Debugf("validity identity [%s] waiting for offline signature", signerIdentity)
+				continue
 			}
-			logger.Debugf("collected [%d] signatures for multi-sig identity [%s]", len(multiSignersSigmas), signerIdentity)
-			sigma, err := multisig.JoinSignatures(multiSigners, multiSignersSigmas)
+			sigma, err := validity.WrapSignature(signerSigma, reclaim)
 			if err != nil {
-				return nil, errors.WithMessage(err, "failed joining multi-sig signatures")
+				return nil, errors.WithMessage(err, "failed wrapping validity signature")
 			}
 			sigmas[signerIdentity.UniqueID()] = sigma
-			span.AddEvent("Done requesting multi-sig")
+			span.AddEvent("Done requesting validity signature")
 			continue
 		}
 
@@ -263,6 +388,18 @@ func (c *CollectEndorsementsView) requestSignatures(signers []view.Identity, ver
 
 		// Case: there is a wallet bound to the party but the signer is not local, the signature is generated externally
 		if w := c.tx.TokenService().WalletManager().OwnerWallet(signerIdentity); w != nil {
+			if c.Opts.IsOfflineWallet(w.ID()) {
+				span.AddEvent(fmt.Sprintf("%d. Offline signer", i))
+				sigma, ok, err := c.signOffline(signerIdentity, w.ID(), signatureRequest, verifierGetter)
+				if err != nil {
+					return nil, errors.WithMessagef(err, "failed signing offline for party [%s]", signerIdentity)
+				}
+				if ok {
+					sigmas[signerIdentity.UniqueID()] = sigma
+				}
+				span.AddEvent("Done offline signing")
+				continue
+			}
 			span.AddEvent(fmt.Sprintf("%d. External signer", i))
 			if logger.IsEnabledFor(zapcore.DebugLevel) {
 				logger.Debugf("found wallet for party [%s], request external signature", signerIdentity)
@@ -297,6 +434,40 @@ func (c *CollectEndorsementsView) requestSignatures(signers []view.Identity, ver
 	return sigmas, nil
 }
 
+// requestThresholdSignatures requests the signatures of the passed signers one by one until threshold signatures have been collected.
+// A signer that fails to sign is skipped, an error is returned only if the threshold cannot be reached anymore.
+// Signatures to be produced offline count towards the threshold, in that case fewer than threshold signatures are returned.
+func (c *CollectEndorsementsView) requestThresholdSignatures(signers []view.Identity, threshold int, verifierGetter verifierGetterFunc, context view.Context, externalWallets map[string]ExternalWalletSigner) (map[string][]byte, error) {
+	sigmas := make(map[string][]byte, threshold)
+	pending := 0
+	var errs []error
+	for i, signer := range signers {
+		if len(sigmas)+pending >= threshold {
+			break
+		}
+		if len(sigmas)+pending+len(signers)-i < threshold {
+			break
+		}
+		sigma, err := c.requestSignatures([]view.Identity{signer}, verifierGetter, context, externalWallets)
+		if err != nil {
+			logger.Warnf("failed requesting signature from multi-sig party [%s], try next: [%s]", signer, err)
+			errs = append(errs, err)
+			continue
+		}
+		s, ok := sigma[signer.UniqueID()]
+		if !ok {
+			// the signature must be produced offline
+			pending++
+			continue
+		}
+		sigmas[signer.UniqueID()] = s
+	}
+	if len(sigmas)+pending < threshold {
+		return nil, errors.Errorf("failed collecting [%d] signatures, got [%d]: %v", threshold, len(sigmas), errs)
+	}
+	return sigmas, nil
+}
+
 func (c *CollectEndorsementsView) signLocal(party view.Identity, signer token.Signer, signatureRequest *SignatureRequest) ([]byte, error) {
 	if logger.IsEnabledFor(zapcore.DebugLevel) {
 		logger.Debugf("signing [%s][%s]", hash.Hashable(signatureRequest.Request).String(), c.tx.ID())
@@ -409,32 +580,51 @@ func (c *CollectEndorsementsView) requestApproval(context view.Context) (*networ
 }
 
 func (c *CollectEndorsementsView) requestAudit(context view.Context) ([]view.Identity, error) {
-	auditors := c.tx.TokenService().PublicParametersManager().PublicParameters().Auditors()
+	pp := c.tx.TokenService().PublicParametersManager().PublicParameters()
+	auditors := pp.Auditors()
 	logger.Debugf("# auditors in public parameters [%d]", len(auditors))
-	if len(c.tx.TokenService().PublicParametersManager().PublicParameters().Auditors()) == 0 {
+	if len(auditors) == 0 {
+		return nil, nil
+	}
+
+	nodes := c.tx.Opts.AuditorNodes()
+	if len(nodes) == 0 {
+		logger.Warnf("no auditor specified, skip auditing, but # auditors in public parameters is [%d]", len(auditors))
 		return nil, nil
 	}
 
-	if !c.tx.Opts.Auditor.IsNone() {
+	// ask auditing until the number of auditors required by the public parameters is reached.
+	// If all auditors are required, any failure is fatal.
+	required := pp.RequiredAuditorSignatures()
+	var audited []view.Identity
+	for _, node := range nodes {
+		if c.tx.TokenRequest.AuditorSignaturesCount() >= required {
+			break
+		}
 		if logger.IsEnabledFor(zapcore.DebugLevel) {
-			logger.Debugf("ask auditing to [%s]", c.tx.Opts.Auditor)
+			logger.Debugf("ask auditing to [%s]", node)
 		}
-		local := view2.GetSigService(context).IsMe(c.tx.Opts.Auditor)
-		sessionBoxed, err := context.RunView(newAuditingViewInitiator(c.tx, local))
+		local := view2.GetSigService(context).IsMe(node)
+		sessionBoxed, err := context.RunView(newAuditingViewInitiator(c.tx, node, local))
 		if err != nil {
-			return nil, errors.WithMessagef(err, "failed requesting auditing from [%s]", c.tx.Opts.Auditor.String())
+			if required == len(auditors) {
+				return nil, errors.WithMessagef(err, "failed requesting auditing from [%s]", node.String())
+			}
+			logger.Warnf("failed requesting auditing from [%s], try the next auditor: [%s]", node, err)
+			continue
 		}
-		c.sessions[c.tx.Opts.Auditor.String()] = sessionBoxed.(view.Session)
-		return []view.Identity{c.tx.Opts.Auditor}, nil
-	} else {
-		logger.Warnf("no auditor specified, skip auditing, but # auditors in public parameters is [%d]", len(auditors))
+		c.sessions[node.String()] = sessionBoxed.(view.Session)
+		audited = append(audited, node)
 	}
-	return nil, nil
+	if signed := c.tx.TokenRequest.AuditorSignaturesCount(); signed < required {
+		return nil, errors.Errorf("insufficient number of auditor signatures, expected [%d], got [%d]", required, signed)
+	}
+	return audited, nil
 }
 
-func (c *CollectEndorsementsView) cleanupAudit(context view.Context) error {
-	if !c.tx.Opts.Auditor.IsNone() {
-		session, err := c.getSession(context, c.tx.Opts.Auditor)
+func (c *CollectEndorsementsView) cleanupAudit(context view.Context, auditors []view.Identity) error {
+	for _, auditor := range auditors {
+		session, err := c.getSession(context, auditor)
 		if err != nil {
 			return errors.Wrap(err, "failed getting auditor's session")
 		}
@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 
 package ttx
 
+import "github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx/offline"
+
 // EndorsementsOpts is used to configure the CollectEndorsementsView
 type EndorsementsOpts struct {
 	// SkipAuditing set it to true to skip the auditing phase
@@ -16,6 +18,10 @@ type EndorsementsOpts struct {
 	SkipDistributeEnv bool
 	// External Signers
 	ExternalWalletSigners map[string]ExternalWalletSigner
+	// OfflineWallets are the identifiers of the wallets whose signatures are produced offline
+	OfflineWallets map[string]struct{}
+	// OfflineSignatures are the signatures produced offline
+	OfflineSignatures []*offline.ResponseEnvelope
 }
 
 func (o *EndorsementsOpts) ExternalWalletSigner(id string) ExternalWalletSigner {
@@ -25,6 +31,11 @@ func (o *EndorsementsOpts) ExternalWalletSigner(id string) ExternalWalletSigner
 	return o.ExternalWalletSigners[id]
 }
 
+func (o *EndorsementsOpts) IsOfflineWallet(id string) bool {
+	_, ok := o.OfflineWallets[id]
+	return ok
+}
+
 // EndorsementsOpt is a function that configures a EndorsementsOpts
 type EndorsementsOpt func(*EndorsementsOpts) error
 
@@ -72,3 +83,23 @@ func WithExternalWalletSigner(walletID string, ews ExternalWalletSigner) Endorse
 		return nil
 	}
 }
+
+// WithOfflineWallet marks the passed wallet as offline.
+// The signatures of its parties are not requested to a signer, they are exported instead to be produced offline.
+func WithOfflineWallet(walletID string) EndorsementsOpt {
+	return func(o *EndorsementsOpts) error {
+		if o.OfflineWallets == nil {
+			o.OfflineWallets = map[string]struct{}{}
+		}
+		o.OfflineWallets[walletID] = struct{}{}
+		return nil
+	}
+}
+
+// WithOfflineSignatures imports the signatures produced offline
+func WithOfflineSignatures(envelopes ...*offline.ResponseEnvelope) EndorsementsOpt {
+	return func(o *EndorsementsOpts) error {
+		o.OfflineSignatures = append(o.OfflineSignatures, envelopes...)
+		return nil
+	}
+}
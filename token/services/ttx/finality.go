@@ -112,6 +112,7 @@ func (f *finalityView) call(ctx view.Context, txID string, tmsID token.TMSID, ti
 	if statusTTXDB != ttxdb.Unknown {
 		span.AddEvent("request_ttxdb_finality")
 		index, err = f.dbFinality(c, txID, transactionDB, index, iterations)
+		f.unparkFinal(txID, transactionDB)
 		if err != nil {
 			return nil, err
 		}
@@ -126,6 +127,17 @@ func (f *finalityView) call(ctx view.Context, txID string, tmsID token.TMSID, ti
 	return nil, nil
 }
 
+// unparkFinal removes the passed transaction from the parked ones, if final, see Manager.RecoverTMS
+func (f *finalityView) unparkFinal(txID string, transactionDB *ttxdb.DB) {
+	status, _, err := transactionDB.GetStatus(txID)
+	if err != nil || (status != ttxdb.Confirmed && status != ttxdb.Deleted) {
+		return
+	}
+	if err := transactionDB.UnparkTransaction(txID); err != nil {
+		logger.Errorf("failed unparking transaction [%s]: [%s]", txID, err)
+	}
+}
+
 func (f *finalityView) dbFinality(c context.Context, txID string, finalityDB finalityDB, startCounter, iterations int) (int, error) {
 	span := trace.SpanFromContext(c)
 	// notice that adding the listener can happen after the event we are looking for has already happened
@@ -0,0 +1,378 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ttx
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx/offline"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MultiTransactionAbortedMessage is the status message of the records of a multi transaction whose endorsement failed
+	MultiTransactionAbortedMessage = "aborted, the endorsement of the multi transaction failed"
+	// MultiTransactionRejectedMessage is the status message of the records of a multi transaction
+	// found pending in a TMS while the ledger rejected the network transaction
+	MultiTransactionRejectedMessage = "rejected, the network transaction of the multi transaction is not valid"
+)
+
+// ErrPartiallyApplied is returned, wrapped, when the transactions of a multi transaction have not reached
+// the same status in all their TMSs
+var ErrPartiallyApplied = errors.New("multi transaction partially applied")
+
+// MultiTransaction is a set of token transactions, one for each TMS of the same network and channel, sharing the same transaction id.
+// The token requests of all the transactions are approved together and committed atomically by a single network transaction:
+// either all of them are committed or none is.
+// Each transaction is assembled, signed, audited and stored as any other token transaction of its TMS.
+// The approval is requested to the endorsers of the first TMS, that must then be endorsers of every TMS involved.
+// Notice that the ledger commits all the transactions or none, but each TMS updates its own vault and ttx DB
+// when it processes the network transaction, see NewMultiOrderingAndFinalityView for how the partial updates are handled.
+type MultiTransaction struct {
+	transactions []*Transaction
+}
+
+// NewMultiTransaction returns a new multi transaction whose first token transaction, customized with the passed opts,
+// will be signed by the passed signer. Use Add to append the token transactions for the other TMSs.
+func NewMultiTransaction(context view.Context, signer view.Identity, opts ...TxOption) (*MultiTransaction, error) {
+	tx, err := NewTransaction(context, signer, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &MultiTransaction{transactions: []*Transaction{tx}}, nil
+}
+
+// Add appends, and returns, a new token transaction customized with the passed opts.
+// The TMS of the new transaction must belong to the network and channel of the first transaction,
+// and no other transaction for the same TMS must exist.
+func (m *MultiTransaction) Add(context view.Context, opts ...TxOption) (*Transaction, error) {
+	first := m.transactions[0]
+	tx, err := NewTransaction(context, nil, append(opts, WithNetworkTxID(first.TxID))...)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Network() != first.Network() || tx.Channel() != first.Channel() {
+		tx.Release()
+		return nil, errors.Errorf("tms [%s] does not belong to network [%s:%s]", tx.TMSID(), first.Network(), first.Channel())
+	}
+	for _, other := range m.transactions {
+		if other.Namespace() == tx.Namespace() {
+			tx.Release()
+			return nil, errors.Errorf("a transaction for tms [%s] already exists", tx.TMSID())
+		}
+	}
+	if tx.ID() != first.ID() {
+		tx.Release()
+		return nil, errors.Errorf("transaction ids do not match [%s][%s]", tx.ID(), first.ID())
+	}
+	m.transactions = append(m.transactions, tx)
+	return tx, nil
+}
+
+// ID returns the id shared by all the transactions
+func (m *MultiTransaction) ID() string {
+	return m.transactions[0].ID()
+}
+
+// Transactions returns the token transactions, in the order they have been added
+func (m *MultiTransaction) Transactions() []*Transaction {
+	return m.transactions
+}
+
+// Release releases the resources of all the transactions
+func (m *MultiTransaction) Release() {
+	for _, tx := range m.transactions {
+		tx.Release()
+	}
+}
+
+type collectMultiEndorsementsView struct {
+	mtx  *MultiTransaction
+	opts []EndorsementsOpt
+}
+
+// NewCollectMultiEndorsementsView returns a view that collects the endorsements of all the transactions of the passed multi transaction.
+// The view does the following:
+// 1. For each transaction, in order, it runs the CollectEndorsementsView without approval.
+// Therefore, the parties of a transaction sign and receive it before the parties of the next one are contacted.
+// A party involved in more than one transaction receives them, on the same session, one after the other.
+// 2. It requests the approval of all the token requests at once to the endorsers of the first TMS,
+// that must be endorsers of all the TMSs involved. The resulting envelope is set on all the transactions.
+// The transactions stay parked at EndorsingStage until approved, then they are parked at EndorsedStage.
+// Offline signatures are not supported.
+func NewCollectMultiEndorsementsView(mtx *MultiTransaction, opts ...EndorsementsOpt) *collectMultiEndorsementsView {
+	return &collectMultiEndorsementsView{mtx: mtx, opts: opts}
+}
+
+func (c *collectMultiEndorsementsView) Call(context view.Context) (interface{}, error) {
+	txs := c.mtx.Transactions()
+	opts := append(append([]EndorsementsOpt{}, c.opts...), WithSkipApproval())
+	for i, tx := range txs {
+		res, err := context.RunView(NewCollectEndorsementsView(tx, opts...))
+		if err == nil {
+			if _, ok := res.(*offline.RequestEnvelope); ok {
+				err = errors.New("offline signatures are not supported")
+			}
+		}
+		if err == nil {
+			// not approved yet
+			err = parkAt(context, tx, EndorsingStage)
+		}
+		if err != nil {
+			c.abort(context, txs[:i+1])
+			return nil, errors.WithMessagef(err, "failed collecting endorsements for [%s]", tx.TMSID())
+		}
+	}
+
+	env, err := c.requestApproval(context, txs)
+	if err != nil {
+		c.abort(context, txs)
+		return nil, errors.WithMessage(err, "failed requesting approval")
+	}
+	for _, tx := range txs {
+		tx.Envelope = env
+		if err := parkAt(context, tx, EndorsedStage); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (c *collectMultiEndorsementsView) requestApproval(context view.Context, txs []*Transaction) (*network.Envelope, error) {
+	requests := make([]*network.ApprovalRequest, len(txs))
+	for i, tx := range txs {
+		raw, err := tx.TokenRequest.RequestToBytes()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed marshalling request for [%s]", tx.TMSID())
+		}
+		requests[i] = &network.ApprovalRequest{TMS: tx.TokenService(), RequestRaw: raw}
+	}
+	first := txs[0]
+	return network.GetInstance(context, first.Network(), first.Channel()).RequestApprovals(
+		context,
+		requests,
+		first.Signer,
+		first.Payload.TxID,
+	)
+}
+
+// abort unparks the passed transactions and deletes their pending records, they will never reach the ledger
+func (c *collectMultiEndorsementsView) abort(context view.Context, txs []*Transaction) {
+	for _, tx := range txs {
+		unpark(context, tx)
+		db := Get(context, tx.TokenService())
+		if db == nil {
+			continue
+		}
+		if status, _, err := db.GetStatus(tx.ID()); err != nil || status != Pending {
+			continue
+		}
+		if err := db.SetStatus(context.Context(), tx.ID(), Deleted, MultiTransactionAbortedMessage); err != nil {
+			logger.Errorf("failed deleting records of transaction [%s] in [%s]: [%s]", tx.ID(), tx.TMSID(), err)
+		}
+	}
+}
+
+type multiOrderingView struct {
+	mtx  *MultiTransaction
+	opts []TxOption
+}
+
+// NewMultiOrderingView returns a view that broadcasts the network transaction shared by the transactions of the passed multi transaction.
+// Once broadcast, the transactions are parked at OrderedStage until their finality.
+func NewMultiOrderingView(mtx *MultiTransaction, opts ...TxOption) *multiOrderingView {
+	return &multiOrderingView{mtx: mtx, opts: opts}
+}
+
+func (o *multiOrderingView) Call(context view.Context) (interface{}, error) {
+	txs := o.mtx.Transactions()
+	// the envelope is shared, broadcast it once
+	if _, err := context.RunView(NewOrderingView(txs[0], o.opts...)); err != nil {
+		for _, tx := range txs[1:] {
+			unpark(context, tx)
+		}
+		return nil, err
+	}
+	options, err := CompileOpts(o.opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile options")
+	}
+	for _, tx := range txs[1:] {
+		if err := ordered(context, tx, options.NoCachingRequest); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+type multiOrderingAndFinalityView struct {
+	mtx     *MultiTransaction
+	timeout time.Duration
+}
+
+// NewMultiOrderingAndFinalityView returns a view that broadcasts the network transaction shared by the transactions
+// of the passed multi transaction, and waits for the finality of each of them in its TMS.
+// If the transactions do not reach the same status in all the TMSs, the view reconciles them:
+//   - If the ledger rejected the network transaction, the records still pending are marked as deleted and their tokens unlocked.
+//   - If the ledger committed the network transaction, the transactions still pending stay parked at OrderedStage,
+//     their TMSs apply them when they process the network transaction, at the latest when the node restarts, see Manager.RecoverTMS.
+//
+// In the latter case, or if the TMSs disagree with the ledger, the view returns an error wrapping ErrPartiallyApplied
+// that lists the status of the transaction in each TMS.
+func NewMultiOrderingAndFinalityView(mtx *MultiTransaction) *multiOrderingAndFinalityView {
+	return NewMultiOrderingAndFinalityWithTimeoutView(mtx, finalityTimeout)
+}
+
+// NewMultiOrderingAndFinalityWithTimeoutView is like NewMultiOrderingAndFinalityView,
+// it returns in case the finality of a transaction is not reached before the passed timeout.
+func NewMultiOrderingAndFinalityWithTimeoutView(mtx *MultiTransaction, timeout time.Duration) *multiOrderingAndFinalityView {
+	return &multiOrderingAndFinalityView{mtx: mtx, timeout: timeout}
+}
+
+func (o *multiOrderingAndFinalityView) Call(ctx view.Context) (interface{}, error) {
+	if _, err := ctx.RunView(NewMultiOrderingView(o.mtx)); err != nil {
+		return nil, err
+	}
+	// wait for all the transactions, even if one fails, to know the status of each of them
+	failed := false
+	for _, tx := range o.mtx.Transactions() {
+		if _, err := ctx.RunView(NewFinalityView(tx, WithTimeout(o.timeout))); err != nil {
+			logger.Warnf("failed waiting for finality of [%s] in [%s]: [%s]", tx.ID(), tx.TMSID(), err)
+			failed = true
+		}
+	}
+	if !failed {
+		return nil, nil
+	}
+	r, err := newMultiReconciliation(ctx, o.mtx)
+	if err != nil {
+		return nil, err
+	}
+	return nil, r.reconcile(ctx.Context())
+}
+
+// multiMemberDB stores the records of a transaction of a multi transaction in its TMS
+type multiMemberDB interface {
+	GetStatus(txID string) (TxStatus, string, error)
+	SetStatus(ctx context.Context, txID string, status TxStatus, message string) error
+	UnparkTransaction(txID string) error
+}
+
+// multiMember is a transaction of a multi transaction, in its TMS
+type multiMember struct {
+	tmsID token.TMSID
+	db    multiMemberDB
+	// unlock releases the tokens locked by the transaction
+	unlock func(txID string) error
+}
+
+// multiReconciliation brings the transactions of a multi transaction to the status of the network transaction on the ledger
+type multiReconciliation struct {
+	txID    string
+	members []*multiMember
+	ledger  interface {
+		Status(txID string) (network.ValidationCode, string, error)
+	}
+}
+
+func newMultiReconciliation(context view.Context, mtx *MultiTransaction) (*multiReconciliation, error) {
+	first := mtx.Transactions()[0]
+	net := network.GetInstance(context, first.Network(), first.Channel())
+	if net == nil {
+		return nil, errors.Errorf("network [%s:%s] not found", first.Network(), first.Channel())
+	}
+	r := &multiReconciliation{txID: mtx.ID(), ledger: &networkLedger{net: net}}
+	for _, tx := range mtx.Transactions() {
+		db := Get(context, tx.TokenService())
+		if db == nil {
+			return nil, errors.Errorf("failed to get ttx db for [%s]", tx.TMSID())
+		}
+		tms := tx.TokenService()
+		r.members = append(r.members, &multiMember{
+			tmsID: tx.TMSID(),
+			db:    db,
+			unlock: func(txID string) error {
+				sm, err := tms.SelectorManager()
+				if err != nil {
+					return errors.WithMessagef(err, "failed to get selector manager")
+				}
+				return sm.Unlock(txID)
+			},
+		})
+	}
+	return r, nil
+}
+
+// reconcile returns nil if the transaction is confirmed in all the TMSs, a plain error if, once reconciled,
+// the transaction is deleted, or pending, in all the TMSs, and an error wrapping ErrPartiallyApplied otherwise
+func (r *multiReconciliation) reconcile(ctx context.Context) error {
+	var confirmed, deleted, pending []*multiMember
+	for _, m := range r.members {
+		status, _, err := m.db.GetStatus(r.txID)
+		if err != nil {
+			return errors.WithMessagef(err, "failed getting status of [%s] in [%s]", r.txID, m.tmsID)
+		}
+		switch status {
+		case ttxdb.Confirmed:
+			confirmed = append(confirmed, m)
+		case ttxdb.Deleted:
+			deleted = append(deleted, m)
+		default:
+			pending = append(pending, m)
+		}
+	}
+	if len(pending) != 0 {
+		vc, _, err := r.ledger.Status(r.txID)
+		if err != nil {
+			return errors.WithMessagef(err, "failed getting ledger status of [%s]", r.txID)
+		}
+		if vc == network.Invalid {
+			// the ledger rejected the transaction for all the TMSs
+			for _, m := range pending {
+				if err := r.reject(ctx, m); err != nil {
+					return errors.WithMessagef(err, "failed rejecting [%s] in [%s]", r.txID, m.tmsID)
+				}
+			}
+			deleted = append(deleted, pending...)
+			pending = nil
+		}
+	}
+	switch {
+	case len(pending) == 0 && len(deleted) == 0:
+		return nil
+	case len(pending) == 0 && len(confirmed) == 0:
+		return errors.Errorf("transaction [%s] is not valid", r.txID)
+	case len(confirmed) == 0 && len(deleted) == 0:
+		return errors.Errorf("transaction [%s] is still pending in all the TMSs", r.txID)
+	}
+	logger.Errorf("transaction [%s] partially applied: confirmed in %v, deleted in %v, pending in %v", r.txID, tmsIDs(confirmed), tmsIDs(deleted), tmsIDs(pending))
+	return errors.Wrapf(ErrPartiallyApplied, "transaction [%s] confirmed in %v, deleted in %v, pending in %v", r.txID, tmsIDs(confirmed), tmsIDs(deleted), tmsIDs(pending))
+}
+
+// reject marks as deleted the pending records of the passed member, unlocks its tokens, and unparks it
+func (r *multiReconciliation) reject(ctx context.Context, m *multiMember) error {
+	if err := m.unlock(r.txID); err != nil {
+		return errors.WithMessagef(err, "failed unlocking tokens")
+	}
+	if err := m.db.SetStatus(ctx, r.txID, ttxdb.Deleted, MultiTransactionRejectedMessage); err != nil {
+		return errors.WithMessagef(err, "failed setting status")
+	}
+	return m.db.UnparkTransaction(r.txID)
+}
+
+func tmsIDs(members []*multiMember) []string {
+	ids := make([]string, len(members))
+	for i, m := range members {
+		ids[i] = m.tmsID.String()
+	}
+	return ids
+}
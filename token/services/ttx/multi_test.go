@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ttx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type multiFixture struct {
+	ledger   *recoveryLedgerMock
+	dbs      map[string]*recoveryDBMock
+	unlocked map[string][]string
+	r        *multiReconciliation
+}
+
+func newMultiFixture(namespaces ...string) *multiFixture {
+	f := &multiFixture{
+		ledger:   &recoveryLedgerMock{status: map[string]network.ValidationCode{}},
+		dbs:      map[string]*recoveryDBMock{},
+		unlocked: map[string][]string{},
+	}
+	f.r = &multiReconciliation{txID: "tx1", ledger: f.ledger}
+	for _, ns := range namespaces {
+		db := newRecoveryDBMock()
+		f.dbs[ns] = db
+		f.r.members = append(f.r.members, &multiMember{
+			tmsID: token.TMSID{Network: "n", Channel: "c", Namespace: ns},
+			db:    db,
+			unlock: func(txID string) error {
+				f.unlocked[ns] = append(f.unlocked[ns], txID)
+				return nil
+			},
+		})
+	}
+	return f
+}
+
+// set sets the status of the transaction in the passed namespaces and parks it at OrderedStage
+func (f *multiFixture) set(status TxStatus, namespaces ...string) {
+	for _, ns := range namespaces {
+		f.dbs[ns].status["tx1"] = status
+		f.dbs[ns].parked["tx1"] = parkedTx{stage: OrderedStage, raw: []byte("tx1")}
+	}
+}
+
+func TestMultiReconciliationConsistent(t *testing.T) {
+	f := newMultiFixture("cash", "securities")
+	f.set(ttxdb.Confirmed, "cash", "securities")
+	assert.NoError(t, f.r.reconcile(context.Background()))
+
+	f.set(ttxdb.Deleted, "cash", "securities")
+	assert.EqualError(t, f.r.reconcile(context.Background()), "transaction [tx1] is not valid")
+
+	// a timeout in all the TMSs is not a partial application
+	f.set(ttxdb.Pending, "cash", "securities")
+	f.ledger.status["tx1"] = network.Busy
+	err := f.r.reconcile(context.Background())
+	assert.EqualError(t, err, "transaction [tx1] is still pending in all the TMSs")
+	assert.False(t, errors.Is(err, ErrPartiallyApplied))
+	assert.Empty(t, f.unlocked)
+}
+
+func TestMultiReconciliationRejected(t *testing.T) {
+	f := newMultiFixture("cash", "securities")
+	// the securities TMS has not processed the rejection yet
+	f.set(ttxdb.Deleted, "cash")
+	f.set(ttxdb.Pending, "securities")
+	f.ledger.status["tx1"] = network.Invalid
+
+	err := f.r.reconcile(context.Background())
+	assert.EqualError(t, err, "transaction [tx1] is not valid")
+	assert.Equal(t, ttxdb.Deleted, f.dbs["securities"].status["tx1"])
+	assert.Equal(t, MultiTransactionRejectedMessage, f.dbs["securities"].messages["tx1"])
+	assert.NotContains(t, f.dbs["securities"].parked, "tx1")
+	assert.Equal(t, map[string][]string{"securities": {"tx1"}}, f.unlocked)
+}
+
+func TestMultiReconciliationPartial(t *testing.T) {
+	f := newMultiFixture("cash", "securities", "bonds")
+	// the ledger committed the transaction, the securities TMS has not applied it yet
+	f.set(ttxdb.Confirmed, "cash", "bonds")
+	f.set(ttxdb.Pending, "securities")
+	f.ledger.status["tx1"] = network.Valid
+
+	err := f.r.reconcile(context.Background())
+	assert.True(t, errors.Is(err, ErrPartiallyApplied))
+	assert.ErrorContains(t, err, "transaction [tx1] confirmed in [n,c,cash n,c,bonds], deleted in [], pending in [n,c,securities]")
+	// the pending transaction stays parked, to be applied by its TMS
+	assert.Equal(t, ttxdb.Pending, f.dbs["securities"].status["tx1"])
+	assert.Equal(t, OrderedStage, f.dbs["securities"].parked["tx1"].stage)
+	assert.Empty(t, f.unlocked)
+
+	// the TMSs disagree
+	f.set(ttxdb.Deleted, "securities")
+	err = f.r.reconcile(context.Background())
+	assert.True(t, errors.Is(err, ErrPartiallyApplied))
+	assert.ErrorContains(t, err, "deleted in [n,c,securities]")
+}
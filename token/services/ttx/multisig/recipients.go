@@ -14,6 +14,7 @@ import (
 
 // RequestRecipientIdentity requests the recipient identity for the given parties.
 // It returns a multisig identity. All the parties are notified about the participants in the multisig identity.
+// By default, all the parties must sign to spend. Pass ttx.WithMultisigThreshold to require only M of them.
 func RequestRecipientIdentity(context view.Context, parties []token.Identity, opts ...token.ServiceOption) (token.Identity, error) {
 	return ttx.RequestMultisigIdentity(context, parties, opts...)
 }
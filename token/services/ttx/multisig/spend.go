@@ -76,9 +76,12 @@ type answer struct {
 }
 
 // RequestSpendView sends a SpendRequest to all parties and waits for their responses
+// until enough parties, as required by the threshold of the multisig identity, have approved.
+// It returns the identities of the parties that approved the request.
 type RequestSpendView struct {
 	unspentToken *token.UnspentToken
 	parties      []view.Identity
+	threshold    int
 	options      *token2.ServiceOptions
 
 	err     error
@@ -95,7 +98,7 @@ func NewRequestSpendView(unspentToken *token.UnspentToken, opts ...token2.Servic
 		return &RequestSpendView{err: errors.Wrap(err, "failed to compile service options")}
 	}
 
-	ok, identities, err := multisig.Unwrap(unspentToken.Owner)
+	ok, multiIdentity, err := multisig.UnwrapMultiIdentity(unspentToken.Owner)
 	if err != nil {
 		return &RequestSpendView{err: errors.Wrap(err, "failed to unwrap identities")}
 	}
@@ -105,7 +108,8 @@ func NewRequestSpendView(unspentToken *token.UnspentToken, opts ...token2.Servic
 
 	return &RequestSpendView{
 		unspentToken: unspentToken,
-		parties:      identities,
+		parties:      multiIdentity.Identities,
+		threshold:    multiIdentity.EffectiveThreshold(),
 		options:      serviceOptions,
 	}
 }
@@ -132,30 +136,48 @@ func (c *RequestSpendView) Call(context view.Context) (interface{}, error) {
 		return nil, errors.Errorf("failed getting TMS for [%s]", c.options.TMSID())
 	}
 	areMe := tms.SigService().AreMe(c.parties...)
+	approvers := make([]view.Identity, 0, len(c.parties))
 	for _, party := range c.parties {
 		logger.Debugf("notify party [%s] about request...", party)
 		if slices.Contains(areMe, party.UniqueID()) {
 			// it is me, skip
 			logger.Debugf("notify party [%s] about request, it is me, skipping...", party)
+			approvers = append(approvers, party)
 			continue
 		}
 		go c.collectSpendRequestAnswers(context, party, requestRaw, answerChannel)
 		counter++
 	}
 
-	for i := 0; i < counter; i++ {
+	allRequired := c.threshold >= len(c.parties)
+	var errs []error
+	for i := 0; i < counter && len(approvers) < c.threshold; i++ {
 		span.AddEvent("wait_for_answer")
 		// TODO: put a timeout
 		a := <-answerChannel
 		span.AddEvent("received_answer")
-		if a.err != nil {
-			return nil, errors.Wrapf(a.err, "got failure [%s] from [%s]", a.party.String(), a.err)
+		err := a.err
+		if err == nil && a.response.Err != nil {
+			err = a.response.Err
 		}
-		if a.response.Err != nil {
-			return nil, errors.Wrapf(a.response.Err, "got failure [%s] from [%s]", a.party.String(), a.response.Err)
+		if err != nil {
+			if allRequired {
+				return nil, errors.Wrapf(err, "got failure [%s] from [%s]", a.party.String(), err)
+			}
+			logger.Debugf("party [%s] did not approve the spend request: [%s]", a.party, err)
+			errs = append(errs, errors.Wrapf(err, "got failure from [%s]", a.party.String()))
+			if len(approvers)+counter-i-1 < c.threshold {
+				return nil, errors.Errorf("threshold [%d] cannot be reached anymore: %v", c.threshold, errs)
+			}
+			continue
 		}
+		approvers = append(approvers, a.party)
+	}
+	if len(approvers) < c.threshold {
+		return nil, errors.Errorf("not enough approvals, expected [%d], got [%d]: %v", c.threshold, len(approvers), errs)
 	}
-	return nil, nil
+	logger.Debugf("spend request approved by [%d] of [%d] parties, threshold [%d]", len(approvers), len(c.parties), c.threshold)
+	return approvers, nil
 }
 
 func (c *RequestSpendView) WithTimeout(timeout time.Duration) *RequestSpendView {
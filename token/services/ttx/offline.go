@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ttx
+
+import (
+	"crypto/sha256"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx/offline"
+	"github.com/pkg/errors"
+)
+
+// OfflineSignaturesStage is the stage of a transaction parked while waiting for signatures produced offline
+const OfflineSignaturesStage = "offline-signatures"
+
+// signOffline returns the signature of the passed party imported from the offline signatures, if any.
+// An imported signature must have been produced by the party, see offline.Signature.Matches, and verify against its verifier.
+// Otherwise, it adds the request of signature to the envelope to be exported.
+func (c *CollectEndorsementsView) signOffline(party view.Identity, walletID string, signatureRequest *SignatureRequest, verifierGetter verifierGetterFunc) ([]byte, bool, error) {
+	message := signatureRequest.MessageToSign()
+	hash := sha256.Sum256(message)
+	for _, envelope := range c.Opts.OfflineSignatures {
+		if envelope.TxID != c.tx.ID() {
+			continue
+		}
+		sigma, ok := envelope.Signature(party, hash[:])
+		if !ok {
+			continue
+		}
+		verifier, err := verifierGetter(party)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "failed getting verifier for [%s]", party)
+		}
+		if err := verifier.Verify(message, sigma); err != nil {
+			return nil, false, errors.Wrapf(err, "failed verifying offline signature from [%s]", party)
+		}
+		return sigma, true, nil
+	}
+
+	logger.Debugf("no offline signature found for party [%s], export request", party)
+	if c.offlineRequests == nil {
+		envelope, err := c.newOfflineRequests()
+		if err != nil {
+			return nil, false, err
+		}
+		c.offlineRequests = envelope
+	}
+	var identityType string
+	if typed, err := identity.UnmarshalTypedIdentity(party); err == nil {
+		identityType = typed.Type
+	}
+	c.offlineRequests.Add(party, walletID, identityType, message)
+	return nil, false, nil
+}
+
+// newOfflineRequests returns an envelope describing the outputs of the transaction, so that the offline signer can review them
+func (c *CollectEndorsementsView) newOfflineRequests() (*offline.RequestEnvelope, error) {
+	envelope := offline.NewRequestEnvelope(c.tx.TokenService().ID(), c.tx.ID())
+	outputs, err := c.tx.Outputs()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting the outputs of transaction [%s]", c.tx.ID())
+	}
+	for _, output := range outputs.Outputs() {
+		envelope.AddOutput(&offline.Output{
+			ActionIndex:  output.ActionIndex,
+			Recipient:    output.Owner,
+			EnrollmentID: output.EnrollmentID,
+			Type:         output.Type,
+			Quantity:     output.Quantity.Decimal(),
+		})
+	}
+	return envelope, nil
+}
+
+// parkForOfflineSignatures stores the transaction in the ttx db and returns the envelope of the requests of signature to be signed offline
+func (c *CollectEndorsementsView) parkForOfflineSignatures(context view.Context) (*offline.RequestEnvelope, error) {
+	if err := parkAt(context, c.tx, OfflineSignaturesStage); err != nil {
+		return nil, err
+	}
+	logger.Infof("transaction [%s] parked, waiting for [%d] offline signatures", c.tx.ID(), len(c.offlineRequests.Requests))
+	return c.offlineRequests, nil
+}
+
+// LoadParkedTransaction returns the transaction parked with the passed id to wait for signatures produced offline.
+// The returned transaction can be passed to CollectEndorsementsView together with the signatures, see WithOfflineSignatures.
+func LoadParkedTransaction(context view.Context, tms *token.ManagementService, txID string) (*Transaction, error) {
+	db := Get(context, tms)
+	if db == nil {
+		return nil, errors.Errorf("failed to get ttx db for [%s]", tms.ID())
+	}
+	stage, raw, err := db.GetParkedTransaction(txID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed loading parked transaction [%s]", txID)
+	}
+	if len(raw) == 0 {
+		return nil, errors.Errorf("no transaction parked with id [%s]", txID)
+	}
+	if stage != OfflineSignaturesStage {
+		return nil, errors.Errorf("transaction [%s] is parked at stage [%s], expected [%s]", txID, stage, OfflineSignaturesStage)
+	}
+	return NewTransactionFromBytes(context, raw)
+}
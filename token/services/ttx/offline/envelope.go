@@ -0,0 +1,314 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package offline
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+const (
+	// RequestEnvelopeType identifies an envelope carrying signature requests
+	RequestEnvelopeType = "token-sdk/offline-signature-request"
+	// ResponseEnvelopeType identifies an envelope carrying signatures
+	ResponseEnvelopeType = "token-sdk/offline-signature-response"
+	// Version is the version of the envelope format
+	Version = 1
+)
+
+// SignatureRequest asks a party to sign a token request
+type SignatureRequest struct {
+	// Party is the identity whose signature is requested
+	Party token.Identity
+	// WalletID is the identifier of the wallet the party belongs to
+	WalletID string
+	// IdentityType is the type of the identity of the party, for example x509 or idemix
+	IdentityType string
+	// Message is the message to sign, the serialized token request
+	Message []byte
+	// MessageHash is the SHA-256 hash of Message, to be checked by the signer
+	MessageHash []byte
+}
+
+// Output describes an output of the transaction, so that the signer knows what it is signing
+type Output struct {
+	// ActionIndex is the index of the action that creates the output
+	ActionIndex int
+	// Recipient is the owner of the output, empty if the output is redeemed
+	Recipient token.Identity
+	// EnrollmentID is the enrollment ID of the recipient, if known
+	EnrollmentID string
+	// Type is the token type
+	Type token2.Type
+	// Quantity is the quantity, in decimal
+	Quantity string
+}
+
+// RequestEnvelope carries the signature requests of a transaction to an offline signer.
+// It is self-describing, the signer needs nothing else to produce the signatures.
+type RequestEnvelope struct {
+	// Type is always RequestEnvelopeType
+	Type string
+	// Version of the envelope format
+	Version int
+	// Network, Channel and Namespace identify the token management service
+	Network   string
+	Channel   string
+	Namespace string
+	// TxID is the anchor of the transaction
+	TxID string
+	// CreatedAt is the time the envelope was created
+	CreatedAt time.Time
+	// Outputs are the outputs of the transaction, as reported by the node.
+	// The offline signer cannot open the actions of the token request, then it must trust the node on them.
+	Outputs []*Output
+	// Requests are the signatures to produce
+	Requests []*SignatureRequest
+}
+
+// NewRequestEnvelope returns an empty RequestEnvelope for the passed transaction
+func NewRequestEnvelope(tmsID token.TMSID, txID string) *RequestEnvelope {
+	return &RequestEnvelope{
+		Type:      RequestEnvelopeType,
+		Version:   Version,
+		Network:   tmsID.Network,
+		Channel:   tmsID.Channel,
+		Namespace: tmsID.Namespace,
+		TxID:      txID,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// Add appends a request for the passed party to sign the passed message
+func (e *RequestEnvelope) Add(party token.Identity, walletID string, identityType string, message []byte) {
+	hash := sha256.Sum256(message)
+	e.Requests = append(e.Requests, &SignatureRequest{
+		Party:        party,
+		WalletID:     walletID,
+		IdentityType: identityType,
+		Message:      message,
+		MessageHash:  hash[:],
+	})
+}
+
+// AddOutput appends the description of an output of the transaction
+func (e *RequestEnvelope) AddOutput(output *Output) {
+	e.Outputs = append(e.Outputs, output)
+}
+
+// TMSID returns the identifier of the token management service the envelope refers to
+func (e *RequestEnvelope) TMSID() token.TMSID {
+	return token.TMSID{Network: e.Network, Channel: e.Channel, Namespace: e.Namespace}
+}
+
+// Bytes marshals the envelope
+func (e *RequestEnvelope) Bytes() ([]byte, error) {
+	return json.MarshalIndent(e, "", "  ")
+}
+
+// Validate checks that the envelope is well-formed and that each message matches its hash
+func (e *RequestEnvelope) Validate() error {
+	if e.Type != RequestEnvelopeType {
+		return errors.Errorf("invalid envelope type, expected [%s], got [%s]", RequestEnvelopeType, e.Type)
+	}
+	if e.Version != Version {
+		return errors.Errorf("unsupported envelope version, expected [%d], got [%d]", Version, e.Version)
+	}
+	if len(e.TxID) == 0 {
+		return errors.New("invalid envelope, empty transaction id")
+	}
+	if len(e.Requests) == 0 {
+		return errors.New("invalid envelope, no signature requests")
+	}
+	for i, request := range e.Requests {
+		if request == nil || request.Party.IsNone() {
+			return errors.Errorf("invalid signature request at index [%d], empty party", i)
+		}
+		hash := sha256.Sum256(request.Message)
+		if !bytes.Equal(hash[:], request.MessageHash) {
+			return errors.Errorf("invalid signature request at index [%d], message does not match its hash", i)
+		}
+		tr, anchor, err := DecodeMessage(request.Message)
+		if err != nil {
+			return errors.WithMessagef(err, "invalid signature request at index [%d]", i)
+		}
+		if anchor != e.TxID {
+			return errors.Errorf("invalid signature request at index [%d], the message is bound to transaction [%s], expected [%s]", i, anchor, e.TxID)
+		}
+		if len(tr.Issues)+len(tr.Transfers) == 0 {
+			return errors.Errorf("invalid signature request at index [%d], the token request has no actions", i)
+		}
+	}
+	return nil
+}
+
+// DecodeMessage returns the token request and the anchor a message to sign consists of.
+// Recall that the message to sign is the asn1 encoding of the actions of the token request followed by the anchor.
+func DecodeMessage(message []byte) (*driver.TokenRequest, string, error) {
+	tr := &driver.TokenRequest{}
+	anchor, err := asn1.Unmarshal(message, tr)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed decoding the token request to sign")
+	}
+	return tr, string(anchor), nil
+}
+
+// UnmarshalRequestEnvelope un-marshals and validates a RequestEnvelope
+func UnmarshalRequestEnvelope(raw []byte) (*RequestEnvelope, error) {
+	e := &RequestEnvelope{}
+	if err := json.Unmarshal(raw, e); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshalling request envelope")
+	}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Signature is the signature of a party on the message of a SignatureRequest
+type Signature struct {
+	// Party is the signer
+	Party token.Identity
+	// MessageHash is the SHA-256 hash of the signed message
+	MessageHash []byte
+	// Sigma is the signature
+	Sigma []byte
+	// Verifier is the serialized identity whose secret key produced the signature.
+	// It lets the node check that the signature comes from the expected identity, see Matches.
+	Verifier []byte
+}
+
+// Matches returns true if the signature has been produced by the passed party.
+// A typed party, an owner for instance, matches its identity.
+func (s *Signature) Matches(party token.Identity) bool {
+	if bytes.Equal(s.Verifier, party) {
+		return true
+	}
+	typed, err := identity.UnmarshalTypedIdentity(party)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(s.Verifier, typed.Identity)
+}
+
+// ResponseEnvelope carries the signatures produced offline back to the node
+type ResponseEnvelope struct {
+	// Type is always ResponseEnvelopeType
+	Type string
+	// Version of the envelope format
+	Version int
+	// TxID is the anchor of the transaction
+	TxID string
+	// Signatures produced by the offline signer
+	Signatures []*Signature
+}
+
+// Bytes marshals the envelope
+func (e *ResponseEnvelope) Bytes() ([]byte, error) {
+	return json.MarshalIndent(e, "", "  ")
+}
+
+// Validate checks that the envelope is well-formed
+func (e *ResponseEnvelope) Validate() error {
+	if e.Type != ResponseEnvelopeType {
+		return errors.Errorf("invalid envelope type, expected [%s], got [%s]", ResponseEnvelopeType, e.Type)
+	}
+	if e.Version != Version {
+		return errors.Errorf("unsupported envelope version, expected [%d], got [%d]", Version, e.Version)
+	}
+	if len(e.TxID) == 0 {
+		return errors.New("invalid envelope, empty transaction id")
+	}
+	for i, signature := range e.Signatures {
+		if signature == nil || signature.Party.IsNone() || len(signature.Sigma) == 0 {
+			return errors.Errorf("invalid signature at index [%d]", i)
+		}
+		if !signature.Matches(signature.Party) {
+			return errors.Errorf("invalid signature at index [%d], the verifier does not match the party", i)
+		}
+	}
+	return nil
+}
+
+// Signature returns the signature of the passed party on the message with the passed hash, if any
+func (e *ResponseEnvelope) Signature(party token.Identity, messageHash []byte) ([]byte, bool) {
+	for _, signature := range e.Signatures {
+		if signature.Party.Equal(party) && signature.Matches(party) && bytes.Equal(signature.MessageHash, messageHash) {
+			return signature.Sigma, true
+		}
+	}
+	return nil, false
+}
+
+// UnmarshalResponseEnvelope un-marshals and validates a ResponseEnvelope
+func UnmarshalResponseEnvelope(raw []byte) (*ResponseEnvelope, error) {
+	e := &ResponseEnvelope{}
+	if err := json.Unmarshal(raw, e); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshalling response envelope")
+	}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// SignerProvider returns the signer bound to a party, if available
+type SignerProvider interface {
+	GetSigner(party token.Identity) (driver.SigningIdentity, error)
+}
+
+// Sign produces the signatures requested by the passed envelope for which the passed provider has a signer.
+// Requests for other parties are skipped, so that an envelope can be signed on more than one offline machine.
+// Each message is checked to be a token request bound to the transaction of the envelope, see Validate.
+// It returns an error if no signature could be produced.
+func Sign(request *RequestEnvelope, sp SignerProvider) (*ResponseEnvelope, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	response := &ResponseEnvelope{
+		Type:    ResponseEnvelopeType,
+		Version: Version,
+		TxID:    request.TxID,
+	}
+	for _, req := range request.Requests {
+		signer, err := sp.GetSigner(req.Party)
+		if err != nil {
+			logger.Debugf("no signer for party [%s], skip: [%s]", req.Party, err)
+			continue
+		}
+		verifier, err := signer.Serialize()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed serializing signer of party [%s]", req.Party)
+		}
+		signature := &Signature{
+			Party:       req.Party,
+			MessageHash: req.MessageHash,
+			Verifier:    verifier,
+		}
+		if !signature.Matches(req.Party) {
+			return nil, errors.Errorf("the signer of party [%s] has another identity", req.Party)
+		}
+		signature.Sigma, err = signer.Sign(req.Message)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed signing for party [%s]", req.Party)
+		}
+		response.Signatures = append(response.Signatures, signature)
+	}
+	if len(response.Signatures) == 0 {
+		return nil, errors.Errorf("no signer available for any of the [%d] requests of transaction [%s]", len(request.Requests), request.TxID)
+	}
+	return response, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package offline
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/storage/kvs"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/x509"
+	"github.com/stretchr/testify/assert"
+)
+
+// message returns a message to sign for the passed transaction
+func message(t *testing.T, txID string) []byte {
+	raw, err := (&driver.TokenRequest{Transfers: [][]byte{[]byte("transfer")}}).MarshalToMessageToSign([]byte(txID))
+	assert.NoError(t, err)
+	return raw
+}
+
+func TestSign(t *testing.T) {
+	sp, err := NewX509SignerProvider("../../identity/x509/testdata/msp")
+	assert.NoError(t, err)
+	km, _, err := x509.NewKeyManager("../../identity/x509/testdata/msp1", nil, nil, x509.NewKeyStore(kvs.NewTrackedMemory()))
+	assert.NoError(t, err)
+	party, _, err := km.Identity(nil)
+	assert.NoError(t, err)
+	typed, err := identity.WrapWithType(x509.IdentityType, party)
+	assert.NoError(t, err)
+
+	request := NewRequestEnvelope(token.TMSID{Network: "n", Channel: "c", Namespace: "ns"}, "tx1")
+	request.AddOutput(&Output{Recipient: typed, EnrollmentID: "alice", Type: "USD", Quantity: "10"})
+	request.Add(typed, "treasury", string(x509.IdentityType), message(t, "tx1"))
+	request.Add(token.Identity("someone else"), "other", "", message(t, "tx1"))
+	raw, err := request.Bytes()
+	assert.NoError(t, err)
+
+	// the offline machine
+	request2, err := UnmarshalRequestEnvelope(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, token.TMSID{Network: "n", Channel: "c", Namespace: "ns"}, request2.TMSID())
+	assert.Equal(t, request.Outputs, request2.Outputs)
+	response, err := Sign(request2, sp)
+	assert.NoError(t, err)
+	assert.Len(t, response.Signatures, 1)
+	raw, err = response.Bytes()
+	assert.NoError(t, err)
+
+	// back to the node
+	response2, err := UnmarshalResponseEnvelope(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, "tx1", response2.TxID)
+	sigma, ok := response2.Signature(typed, request.Requests[0].MessageHash)
+	assert.True(t, ok)
+	verifier, err := (&x509.IdentityDeserializer{}).DeserializeVerifier(party)
+	assert.NoError(t, err)
+	assert.NoError(t, verifier.Verify(message(t, "tx1"), sigma))
+	_, ok = response2.Signature(typed, request.Requests[1].MessageHash[:1])
+	assert.False(t, ok)
+
+	// a signature produced by another key is rejected
+	response2.Signatures[0].Verifier = []byte("another key")
+	_, ok = response2.Signature(typed, request.Requests[0].MessageHash)
+	assert.False(t, ok)
+	raw, err = response2.Bytes()
+	assert.NoError(t, err)
+	_, err = UnmarshalResponseEnvelope(raw)
+	assert.EqualError(t, err, "invalid signature at index [0], the verifier does not match the party")
+
+	// nothing to sign
+	_, err = Sign(&RequestEnvelope{Type: RequestEnvelopeType, Version: Version, TxID: "tx1", Requests: request.Requests[1:]}, sp)
+	assert.EqualError(t, err, "no signer available for any of the [1] requests of transaction [tx1]")
+}
+
+func TestValidate(t *testing.T) {
+	request := NewRequestEnvelope(token.TMSID{}, "tx1")
+	assert.EqualError(t, request.Validate(), "invalid envelope, no signature requests")
+	request.Add(token.Identity("alice"), "alice", "", message(t, "tx1"))
+	assert.NoError(t, request.Validate())
+
+	// the message must be a token request bound to the transaction
+	request.Requests = request.Requests[:0]
+	request.Add(token.Identity("alice"), "alice", "", message(t, "tx2"))
+	assert.EqualError(t, request.Validate(), "invalid signature request at index [0], the message is bound to transaction [tx2], expected [tx1]")
+	request.Requests = request.Requests[:0]
+	request.Add(token.Identity("alice"), "alice", "", []byte("request"))
+	assert.ErrorContains(t, request.Validate(), "invalid signature request at index [0]: failed decoding the token request to sign")
+	request.Requests = request.Requests[:0]
+	raw, err := (&driver.TokenRequest{}).MarshalToMessageToSign([]byte("tx1"))
+	assert.NoError(t, err)
+	request.Add(token.Identity("alice"), "alice", "", raw)
+	assert.EqualError(t, request.Validate(), "invalid signature request at index [0], the token request has no actions")
+
+	request.Requests[0].Message = []byte("tampered")
+	assert.EqualError(t, request.Validate(), "invalid signature request at index [0], message does not match its hash")
+	request.Type = ResponseEnvelopeType
+	assert.EqualError(t, request.Validate(), "invalid envelope type, expected [token-sdk/offline-signature-request], got [token-sdk/offline-signature-response]")
+
+	_, err = UnmarshalResponseEnvelope([]byte(`{"Type":"token-sdk/offline-signature-response","Version":2,"TxID":"tx1"}`))
+	assert.EqualError(t, err, "unsupported envelope version, expected [1], got [2]")
+	_, err = UnmarshalResponseEnvelope([]byte(`{"Type":"token-sdk/offline-signature-response","Version":1,"TxID":"tx1","Signatures":[{"Party":"YWxpY2U="}]}`))
+	assert.EqualError(t, err, "invalid signature at index [0]")
+}
@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package offline
+
+import (
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/storage/kvs"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/x509"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.MustGetLogger("token-sdk.ttx.offline")
+
+// X509SignerProvider provides the signers of x509 identities loaded from MSP folders.
+// It is meant to run on the offline machine that holds the secret keys.
+type X509SignerProvider struct {
+	signers map[string]driver.SigningIdentity
+}
+
+// NewX509SignerProvider loads the x509 identities, with their secret keys, stored in the passed MSP folders
+func NewX509SignerProvider(mspDirs ...string) (*X509SignerProvider, error) {
+	backend, err := kvs.NewInMemory()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create key store")
+	}
+	keyStore := x509.NewKeyStore(backend)
+	p := &X509SignerProvider{signers: map[string]driver.SigningIdentity{}}
+	for _, dir := range mspDirs {
+		km, _, err := x509.NewKeyManager(dir, nil, nil, keyStore)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to load x509 identity from [%s]", dir)
+		}
+		if km.IsRemote() {
+			return nil, errors.Errorf("no secret key found in [%s]", dir)
+		}
+		id, _, err := km.Identity(nil)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get x509 identity from [%s]", dir)
+		}
+		// owners are typed identities, issuers are not
+		typed, err := identity.WrapWithType(x509.IdentityType, id)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to wrap x509 identity from [%s]", dir)
+		}
+		p.signers[id.UniqueID()] = km.SigningIdentity()
+		p.signers[typed.UniqueID()] = km.SigningIdentity()
+	}
+	return p, nil
+}
+
+// GetSigner returns the signer of the passed party
+func (p *X509SignerProvider) GetSigner(party token.Identity) (driver.SigningIdentity, error) {
+	signer, ok := p.signers[party.UniqueID()]
+	if !ok {
+		return nil, errors.Errorf("no signer found for party [%s]", party)
+	}
+	return signer, nil
+}
@@ -12,10 +12,12 @@ import (
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/pkg/errors"
 )
 
 type TxOptions struct {
 	Auditor                   view.Identity
+	Auditors                  []view.Identity
 	TMSID                     token.TMSID
 	NoTransactionVerification bool
 	Timeout                   time.Duration
@@ -36,6 +38,23 @@ func CompileOpts(opts ...TxOption) (*TxOptions, error) {
 	return txOptions, nil
 }
 
+// AuditorNodes returns the FSC nodes of the auditors to ask for auditing, without duplicates
+func (o *TxOptions) AuditorNodes() []view.Identity {
+	var nodes []view.Identity
+	seen := map[string]struct{}{}
+	for _, auditor := range append([]view.Identity{o.Auditor}, o.Auditors...) {
+		if auditor.IsNone() {
+			continue
+		}
+		if _, ok := seen[auditor.UniqueID()]; ok {
+			continue
+		}
+		seen[auditor.UniqueID()] = struct{}{}
+		nodes = append(nodes, auditor)
+	}
+	return nodes
+}
+
 type TxOption func(*TxOptions) error
 
 func WithAuditor(auditor view.Identity) TxOption {
@@ -45,6 +64,15 @@ func WithAuditor(auditor view.Identity) TxOption {
 	}
 }
 
+// WithAuditors sets the FSC nodes of the auditors to ask for auditing.
+// Use it when the public parameters list more than one auditor.
+func WithAuditors(auditors ...view.Identity) TxOption {
+	return func(o *TxOptions) error {
+		o.Auditors = append(o.Auditors, auditors...)
+		return nil
+	}
+}
+
 func WithNetwork(network string) TxOption {
 	return func(o *TxOptions) error {
 		o.TMSID.Network = network
@@ -182,6 +210,29 @@ func WithRecipientWalletID(walletID string) token.ServiceOption {
 	}
 }
 
+// WithMultisigThreshold is used to set the number of signatures required to spend
+// tokens owned by a multisig identity (M-of-N). Zero means that all the parties must sign.
+func WithMultisigThreshold(threshold int) token.ServiceOption {
+	return func(options *token.ServiceOptions) error {
+		if threshold < 0 {
+			return errors.Errorf("invalid multisig threshold [%d]", threshold)
+		}
+		if options.Params == nil {
+			options.Params = map[string]interface{}{}
+		}
+		options.Params["MultisigThreshold"] = threshold
+		return nil
+	}
+}
+
+func getMultisigThreshold(opts *token.ServiceOptions) int {
+	tBoxed, ok := opts.Params["MultisigThreshold"]
+	if !ok {
+		return 0
+	}
+	return tBoxed.(int)
+}
+
 func getRecipientWalletID(opts *token.ServiceOptions) string {
 	wBoxed, ok := opts.Params["RecipientWalletID"]
 	if !ok {
@@ -33,27 +33,45 @@ func NewOrderingViewWithOpts(opts ...TxOption) *orderingView {
 // Call execute the view.
 // The view does the following:
 // 1. It broadcasts the token transaction to the proper backend.
+// Once broadcast, the transaction is parked at OrderedStage until its finality, see Manager.RecoverTMS.
 func (o *orderingView) Call(context view.Context) (interface{}, error) {
 	// Compile options
 	options, err := CompileOpts(o.opts...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to compile options")
 	}
+	if options.Transaction == nil {
+		return nil, errors.Errorf("transaction is nil")
+	}
 	if err := o.broadcast(context, options.Transaction); err != nil {
+		// the application is in charge of the failure, nothing to recover
+		unpark(context, options.Transaction)
+		return nil, err
+	}
+	if err := ordered(context, options.Transaction, options.NoCachingRequest); err != nil {
 		return nil, err
 	}
+	return nil, nil
+}
+
+// ordered parks the passed broadcast transaction until its finality and caches its token request, unless noCachingRequest is set
+func ordered(context view.Context, tx *Transaction, noCachingRequest bool) error {
+	// the transaction is now waiting for finality
+	if err := parkAt(context, tx, OrderedStage); err != nil {
+		logger.Warnf("failed parking transaction [%s] at stage [%s], it will not be recovered if the node stops: [%s]", tx.ID(), OrderedStage, err)
+	}
 
 	// cache the token request into the tokens db
-	t, err := tokens.GetService(context, options.Transaction.TMSID())
+	t, err := tokens.GetService(context, tx.TMSID())
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get tokens db for [%s]", options.Transaction.TMSID())
+		return errors.Wrapf(err, "failed to get tokens db for [%s]", tx.TMSID())
 	}
-	if !options.NoCachingRequest {
-		if err := t.CacheRequest(options.Transaction.TMSID(), options.Transaction.TokenRequest); err != nil {
-			logger.Warnf("failed to cache token request [%s], this might cause delay, investigate when possible: [%s]", options.Transaction.TokenRequest.Anchor, err)
+	if !noCachingRequest {
+		if err := t.CacheRequest(tx.TMSID(), tx.TokenRequest); err != nil {
+			logger.Warnf("failed to cache token request [%s], this might cause delay, investigate when possible: [%s]", tx.TokenRequest.Anchor, err)
 		}
 	}
-	return nil, nil
+	return nil
 }
 
 func (o *orderingView) broadcast(context view.Context, transaction *Transaction) error {
@@ -44,6 +44,12 @@ func (a *TxOwner) Transactions(params QueryTransactionsParams) (driver.Transacti
 	return a.owner.ttxDB.Transactions(params)
 }
 
+// TransactionsPage returns a page of the transaction records filtered by the given params.
+// To get the next page, pass the returned cursor in the pagination of the next call.
+func (a *TxOwner) TransactionsPage(params QueryTransactionsParams, pagination Pagination) (*driver.Page[*driver.TransactionRecord], error) {
+	return a.owner.ttxDB.TransactionsPage(params, pagination)
+}
+
 // TransactionInfo returns the transaction info for the given transaction ID.
 func (a *TxOwner) TransactionInfo(txID string) (*TransactionInfo, error) {
 	return a.transactionInfoProvider.TransactionInfo(txID)
@@ -77,6 +77,9 @@ func (r Recipients) Identities() []view.Identity {
 type RequestRecipientIdentityView struct {
 	TMSID      token.TMSID
 	Recipients Recipients
+	// Threshold is the number of signatures required to spend with the resulting multisig identity.
+	// Zero means that all the recipients must sign.
+	Threshold int
 }
 
 // RequestRecipientIdentity executes the RequestRecipientIdentityView.
@@ -109,6 +112,7 @@ func RequestRecipientIdentity(context view.Context, recipient view.Identity, opt
 
 // RequestMultisigIdentity collects the recipient identities from all the passed identities.
 // It merges them into a single multisig identity and distributes it to all the participants.
+// Use WithMultisigThreshold to require only a subset of the participants to sign (M-of-N).
 func RequestMultisigIdentity(context view.Context, ids []view.Identity, opts ...token.ServiceOption) (token.Identity, error) {
 	options, err := CompileServiceOptions(opts...)
 	if err != nil {
@@ -126,6 +130,7 @@ func RequestMultisigIdentity(context view.Context, ids []view.Identity, opts ...
 		&RequestRecipientIdentityView{
 			TMSID:      options.TMSID(),
 			Recipients: recipients,
+			Threshold:  getMultisigThreshold(options),
 		},
 		options.Duration,
 	)
@@ -229,7 +234,7 @@ func (f *RequestRecipientIdentityView) callWithRecipientData(context view.Contex
 
 func (f *RequestRecipientIdentityView) aggregateAndDistribute(context view.Context, tms *token.ManagementService, recipients []token.Identity, local []bool) (token.Identity, error) {
 	// prepare identity
-	multisigIdentity, err := multisig.WrapIdentities(recipients...)
+	multisigIdentity, err := multisig.WrapIdentitiesWithThreshold(f.Threshold, recipients...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed wrapping identities")
 	}
@@ -0,0 +1,247 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ttx
+
+import (
+	"context"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	"github.com/pkg/errors"
+)
+
+const (
+	// EndorsingStage is the stage of a transaction whose endorsements are being collected
+	EndorsingStage = "endorsing"
+	// EndorsedStage is the stage of a transaction endorsed and distributed to all parties, but not yet sent to the ordering service
+	EndorsedStage = "endorsed"
+	// OrderedStage is the stage of a transaction sent to the ordering service and waiting for finality
+	OrderedStage = "ordered"
+)
+
+// AbortedByRecoveryMessage is the status message of the transactions aborted by the recovery at startup
+const AbortedByRecoveryMessage = "aborted by recovery, the node stopped while collecting endorsements"
+
+// parkAt stores the passed transaction at the passed stage, so that it can be recovered if the node stops
+func parkAt(context view.Context, tx *Transaction, stage string) error {
+	db := Get(context, tx.TokenService())
+	if db == nil {
+		return errors.Errorf("failed to get ttx db for [%s]", tx.TokenService().ID())
+	}
+	raw, err := tx.Bytes()
+	if err != nil {
+		return errors.WithMessagef(err, "failed marshalling transaction [%s]", tx.ID())
+	}
+	if err := db.ParkTransaction(tx.ID(), stage, raw); err != nil {
+		return errors.WithMessagef(err, "failed parking transaction [%s] at stage [%s]", tx.ID(), stage)
+	}
+	return nil
+}
+
+// unpark removes the passed transaction from the parked ones, logging any failure
+func unpark(context view.Context, tx *Transaction) {
+	db := Get(context, tx.TokenService())
+	if db == nil {
+		logger.Errorf("failed to get ttx db for [%s]", tx.TokenService().ID())
+		return
+	}
+	if err := db.UnparkTransaction(tx.ID()); err != nil {
+		logger.Errorf("failed unparking transaction [%s]: [%s]", tx.ID(), err)
+	}
+}
+
+// RecoverTMS resumes or aborts the transactions of the passed TMS left unfinished by a stop of the node.
+// Depending on the stage a transaction was parked at:
+//   - EndorsingStage: the transaction is aborted. Its tokens are unlocked and its records, if any, are marked as deleted.
+//   - EndorsedStage, OrderedStage: if the ledger does not know the transaction, it is sent again to the ordering service.
+//     The finality listeners installed by RestoreTMS take care of the rest.
+//   - OfflineSignaturesStage: the transaction is left untouched, waiting for its signatures.
+//
+// RecoverTMS must be invoked after RestoreTMS and when the TMS is available.
+func (m *Manager) RecoverTMS(tmsID token.TMSID) error {
+	db, err := m.DB(tmsID)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to get db for [%s]", tmsID)
+	}
+	records, err := db.QueryParkedTransactions()
+	if err != nil {
+		return errors.WithMessagef(err, "failed to get parked transactions for [%s]", tmsID)
+	}
+	if len(records) == 0 {
+		logger.Debugf("no parked transactions to recover for [%s]", tmsID)
+		return nil
+	}
+	net, err := m.networkProvider.GetNetwork(tmsID.Network, tmsID.Channel)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to get network instance for [%s:%s]", tmsID.Network, tmsID.Channel)
+	}
+	tms, err := m.tmsProvider.GetManagementService(token.WithTMSID(tmsID))
+	if err != nil {
+		return errors.WithMessagef(err, "failed to get tms for [%s]", tmsID)
+	}
+
+	r := &recovery{
+		db:     db,
+		ledger: &networkLedger{net: net},
+		unlock: func(txID string) error {
+			sm, err := tms.SelectorManager()
+			if err != nil {
+				return errors.WithMessagef(err, "failed to get selector manager")
+			}
+			return sm.Unlock(txID)
+		},
+		envelope: func(raw []byte) (interface{}, error) {
+			payload := &Payload{
+				Transient:    map[string][]byte{},
+				TokenRequest: token.NewRequest(nil, ""),
+			}
+			if err := unmarshal(m.networkProvider.GetNetwork, payload, raw); err != nil {
+				return nil, err
+			}
+			return payload.Envelope, nil
+		},
+	}
+	counter := 0
+	for _, record := range records {
+		if err := r.recover(record); err != nil {
+			// do not stop, the other transactions can still be recovered
+			logger.Errorf("failed recovering transaction [%s] parked at stage [%s]: [%s]", record.TxID, record.Stage, err)
+			continue
+		}
+		counter++
+	}
+	logger.Infof("recovered [%d] of [%d] parked transactions for [%s]", counter, len(records), tmsID)
+	return nil
+}
+
+// recoveryDB stores the parked transactions and their status
+type recoveryDB interface {
+	GetStatus(txID string) (TxStatus, string, error)
+	SetStatus(ctx context.Context, txID string, status TxStatus, message string) error
+	ParkTransaction(txID string, stage string, raw []byte) error
+	GetParkedTransaction(txID string) (string, []byte, error)
+	UnparkTransaction(txID string) error
+}
+
+// recoveryLedger tells if a transaction is known to the ledger and sends transactions to the ordering service
+type recoveryLedger interface {
+	Status(txID string) (network.ValidationCode, string, error)
+	Broadcast(ctx context.Context, blob interface{}) error
+}
+
+// networkLedger is the recoveryLedger of a network
+type networkLedger struct {
+	net *network.Network
+}
+
+func (l *networkLedger) Status(txID string) (network.ValidationCode, string, error) {
+	ledger, err := l.net.Ledger()
+	if err != nil {
+		return network.Unknown, "", errors.WithMessagef(err, "failed getting ledger")
+	}
+	return ledger.Status(txID)
+}
+
+func (l *networkLedger) Broadcast(ctx context.Context, blob interface{}) error {
+	return l.net.Broadcast(ctx, blob)
+}
+
+type recovery struct {
+	db     recoveryDB
+	ledger recoveryLedger
+	// unlock releases the tokens locked by a transaction
+	unlock func(txID string) error
+	// envelope returns the envelope of a serialized transaction
+	envelope func(raw []byte) (interface{}, error)
+}
+
+func (r *recovery) recover(record ttxdb.ParkedTransactionRecord) error {
+	logger.Debugf("recover transaction [%s] parked at stage [%s] since [%s]", record.TxID, record.Stage, record.StoredAt)
+	switch record.Stage {
+	case OfflineSignaturesStage:
+		logger.Infof("transaction [%s] is waiting for offline signatures, leave it parked", record.TxID)
+		return nil
+	case EndorsingStage:
+		return r.abort(record.TxID)
+	case EndorsedStage, OrderedStage:
+		return r.resume(record.TxID)
+	default:
+		return errors.Errorf("unknown stage [%s]", record.Stage)
+	}
+}
+
+// abort unlocks the tokens of the passed transaction, marks its records as deleted, and unparks it
+func (r *recovery) abort(txID string) error {
+	logger.Infof("abort transaction [%s]", txID)
+	if err := r.unlock(txID); err != nil {
+		return errors.WithMessagef(err, "failed unlocking tokens")
+	}
+	status, _, err := r.db.GetStatus(txID)
+	if err != nil {
+		return errors.WithMessagef(err, "failed getting status")
+	}
+	if status == ttxdb.Pending {
+		if err := r.db.SetStatus(context.Background(), txID, ttxdb.Deleted, AbortedByRecoveryMessage); err != nil {
+			return errors.WithMessagef(err, "failed setting status")
+		}
+	}
+	return r.db.UnparkTransaction(txID)
+}
+
+// resume sends again the passed transaction to the ordering service, if the ledger does not know it yet.
+// Transactions already final are unparked.
+func (r *recovery) resume(txID string) error {
+	status, _, err := r.db.GetStatus(txID)
+	if err != nil {
+		return errors.WithMessagef(err, "failed getting status")
+	}
+	if status == ttxdb.Confirmed || status == ttxdb.Deleted {
+		logger.Debugf("transaction [%s] is already final [%s]", txID, TxStatusMessage[status])
+		return r.db.UnparkTransaction(txID)
+	}
+
+	vc, _, err := r.ledger.Status(txID)
+	if err != nil {
+		return errors.WithMessagef(err, "failed getting ledger status")
+	}
+	if vc != network.Unknown {
+		logger.Infof("transaction [%s] is known to the ledger, wait for its finality", txID)
+		return r.park(txID, OrderedStage, nil)
+	}
+
+	stage, raw, err := r.db.GetParkedTransaction(txID)
+	if err != nil {
+		return errors.WithMessagef(err, "failed loading parked transaction")
+	}
+	envelope, err := r.envelope(raw)
+	if err != nil {
+		return errors.WithMessagef(err, "failed unmarshalling transaction at stage [%s]", stage)
+	}
+	logger.Infof("transaction [%s] is unknown to the ledger, broadcast it again", txID)
+	if err := r.ledger.Broadcast(context.Background(), envelope); err != nil {
+		return errors.WithMessagef(err, "failed broadcasting transaction")
+	}
+	return r.park(txID, OrderedStage, raw)
+}
+
+// park moves the passed transaction to the passed stage.
+// If raw is nil, the transaction currently parked is kept.
+func (r *recovery) park(txID string, stage string, raw []byte) error {
+	if raw == nil {
+		current, parked, err := r.db.GetParkedTransaction(txID)
+		if err != nil {
+			return errors.WithMessagef(err, "failed loading parked transaction")
+		}
+		if current == stage {
+			return nil
+		}
+		raw = parked
+	}
+	return r.db.ParkTransaction(txID, stage, raw)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ttx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type parkedTx struct {
+	stage string
+	raw   []byte
+}
+
+type recoveryDBMock struct {
+	status   map[string]TxStatus
+	messages map[string]string
+	parked   map[string]parkedTx
+}
+
+func newRecoveryDBMock() *recoveryDBMock {
+	return &recoveryDBMock{
+		status:   map[string]TxStatus{},
+		messages: map[string]string{},
+		parked:   map[string]parkedTx{},
+	}
+}
+
+func (d *recoveryDBMock) GetStatus(txID string) (TxStatus, string, error) {
+	return d.status[txID], d.messages[txID], nil
+}
+
+func (d *recoveryDBMock) SetStatus(_ context.Context, txID string, status TxStatus, message string) error {
+	d.status[txID] = status
+	d.messages[txID] = message
+	return nil
+}
+
+func (d *recoveryDBMock) ParkTransaction(txID string, stage string, raw []byte) error {
+	d.parked[txID] = parkedTx{stage: stage, raw: raw}
+	return nil
+}
+
+func (d *recoveryDBMock) GetParkedTransaction(txID string) (string, []byte, error) {
+	p := d.parked[txID]
+	return p.stage, p.raw, nil
+}
+
+func (d *recoveryDBMock) UnparkTransaction(txID string) error {
+	delete(d.parked, txID)
+	return nil
+}
+
+type recoveryLedgerMock struct {
+	status       map[string]network.ValidationCode
+	broadcasted  []interface{}
+	broadcastErr error
+}
+
+func (l *recoveryLedgerMock) Status(txID string) (network.ValidationCode, string, error) {
+	vc, ok := l.status[txID]
+	if !ok {
+		return network.Unknown, "", nil
+	}
+	return vc, "", nil
+}
+
+func (l *recoveryLedgerMock) Broadcast(_ context.Context, blob interface{}) error {
+	if l.broadcastErr != nil {
+		return l.broadcastErr
+	}
+	l.broadcasted = append(l.broadcasted, blob)
+	return nil
+}
+
+func newRecovery(db *recoveryDBMock, ledger *recoveryLedgerMock, unlocked *[]string) *recovery {
+	return &recovery{
+		db:     db,
+		ledger: ledger,
+		unlock: func(txID string) error {
+			*unlocked = append(*unlocked, txID)
+			return nil
+		},
+		envelope: func(raw []byte) (interface{}, error) {
+			return "envelope of " + string(raw), nil
+		},
+	}
+}
+
+func TestRecoveryGiveUp(t *testing.T) {
+	db := newRecoveryDBMock()
+	ledger := &recoveryLedgerMock{status: map[string]network.ValidationCode{}}
+	var unlocked []string
+	r := newRecovery(db, ledger, &unlocked)
+
+	// the node stopped while collecting endorsements, the transaction is aborted
+	db.status["tx1"] = ttxdb.Pending
+	assert.NoError(t, db.ParkTransaction("tx1", EndorsingStage, []byte("tx1")))
+	assert.NoError(t, r.recover(ttxdb.ParkedTransactionRecord{TxID: "tx1", Stage: EndorsingStage}))
+	assert.Equal(t, []string{"tx1"}, unlocked)
+	assert.Equal(t, ttxdb.Deleted, db.status["tx1"])
+	assert.Equal(t, AbortedByRecoveryMessage, db.messages["tx1"])
+	assert.NotContains(t, db.parked, "tx1")
+	assert.Empty(t, ledger.broadcasted)
+
+	// a transaction without records is only unlocked and unparked
+	assert.NoError(t, db.ParkTransaction("tx2", EndorsingStage, []byte("tx2")))
+	assert.NoError(t, r.recover(ttxdb.ParkedTransactionRecord{TxID: "tx2", Stage: EndorsingStage}))
+	assert.Equal(t, []string{"tx1", "tx2"}, unlocked)
+	assert.NotContains(t, db.status, "tx2")
+	assert.NotContains(t, db.parked, "tx2")
+}
+
+func TestRecoveryResume(t *testing.T) {
+	db := newRecoveryDBMock()
+	ledger := &recoveryLedgerMock{status: map[string]network.ValidationCode{}}
+	var unlocked []string
+	r := newRecovery(db, ledger, &unlocked)
+
+	// already final, nothing to do
+	for _, status := range []TxStatus{ttxdb.Confirmed, ttxdb.Deleted} {
+		db.status["tx1"] = status
+		assert.NoError(t, db.ParkTransaction("tx1", OrderedStage, []byte("tx1")))
+		assert.NoError(t, r.recover(ttxdb.ParkedTransactionRecord{TxID: "tx1", Stage: OrderedStage}))
+		assert.NotContains(t, db.parked, "tx1")
+	}
+
+	// known to the ledger, wait for finality
+	db.status["tx2"] = ttxdb.Pending
+	ledger.status["tx2"] = network.Busy
+	assert.NoError(t, db.ParkTransaction("tx2", EndorsedStage, []byte("tx2")))
+	assert.NoError(t, r.recover(ttxdb.ParkedTransactionRecord{TxID: "tx2", Stage: EndorsedStage}))
+	assert.Equal(t, parkedTx{stage: OrderedStage, raw: []byte("tx2")}, db.parked["tx2"])
+
+	assert.Empty(t, ledger.broadcasted)
+	assert.Empty(t, unlocked)
+}
+
+func TestRecoveryRebroadcast(t *testing.T) {
+	db := newRecoveryDBMock()
+	ledger := &recoveryLedgerMock{status: map[string]network.ValidationCode{}}
+	var unlocked []string
+	r := newRecovery(db, ledger, &unlocked)
+
+	// unknown to the ledger, broadcast again
+	db.status["tx1"] = ttxdb.Pending
+	assert.NoError(t, db.ParkTransaction("tx1", EndorsedStage, []byte("tx1")))
+	assert.NoError(t, r.recover(ttxdb.ParkedTransactionRecord{TxID: "tx1", Stage: EndorsedStage}))
+	assert.Equal(t, []interface{}{"envelope of tx1"}, ledger.broadcasted)
+	assert.Equal(t, parkedTx{stage: OrderedStage, raw: []byte("tx1")}, db.parked["tx1"])
+
+	// the broadcast fails, the transaction stays at its stage to be recovered at the next start
+	ledger.broadcastErr = errors.New("ordering service unavailable")
+	db.status["tx2"] = ttxdb.Pending
+	assert.NoError(t, db.ParkTransaction("tx2", EndorsedStage, []byte("tx2")))
+	err := r.recover(ttxdb.ParkedTransactionRecord{TxID: "tx2", Stage: EndorsedStage})
+	assert.ErrorContains(t, err, "ordering service unavailable")
+	assert.Equal(t, EndorsedStage, db.parked["tx2"].stage)
+	assert.Empty(t, unlocked)
+}
+
+func TestRecoveryOtherStages(t *testing.T) {
+	db := newRecoveryDBMock()
+	ledger := &recoveryLedgerMock{status: map[string]network.ValidationCode{}}
+	var unlocked []string
+	r := newRecovery(db, ledger, &unlocked)
+
+	// waiting for offline signatures, untouched
+	assert.NoError(t, db.ParkTransaction("tx1", OfflineSignaturesStage, []byte("tx1")))
+	assert.NoError(t, r.recover(ttxdb.ParkedTransactionRecord{TxID: "tx1", Stage: OfflineSignaturesStage}))
+	assert.Equal(t, OfflineSignaturesStage, db.parked["tx1"].stage)
+
+	err := r.recover(ttxdb.ParkedTransactionRecord{TxID: "tx2", Stage: "unknown"})
+	assert.EqualError(t, err, "unknown stage [unknown]")
+	assert.Empty(t, ledger.broadcasted)
+	assert.Empty(t, unlocked)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scheduler
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Schedule computes the execution times of a recurring order
+type Schedule interface {
+	// Next returns the first execution time strictly after the passed time, zero if there is none
+	Next(after time.Time) time.Time
+}
+
+// ParseSchedule parses a cron-like schedule. The supported forms are:
+//   - "@every <duration>", where the duration is parsed by time.ParseDuration, for example "@every 1h30m".
+//   - "@yearly" (or "@annually"), "@monthly", "@weekly", "@daily" (or "@midnight"), "@hourly".
+//   - A standard cron expression with five fields: minute, hour, day of month, month, and day of week.
+//     Each field accepts "*", single values, ranges "a-b", lists "a,b", and steps "*/n" or "a-b/n".
+//     Days of the week go from 0 (Sunday) to 6, 7 is also Sunday.
+//
+// Cron expressions are evaluated in UTC.
+func ParseSchedule(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(expr, "@every ")))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid schedule [%s]", expr)
+		}
+		if d < time.Second {
+			return nil, errors.Errorf("invalid schedule [%s], the period must be at least one second", expr)
+		}
+		return every(d), nil
+	}
+	switch expr {
+	case "@yearly", "@annually":
+		expr = "0 0 1 1 *"
+	case "@monthly":
+		expr = "0 0 1 * *"
+	case "@weekly":
+		expr = "0 0 * * 0"
+	case "@daily", "@midnight":
+		expr = "0 0 * * *"
+	case "@hourly":
+		expr = "0 * * * *"
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("invalid schedule [%s], expected five fields", expr)
+	}
+	s := &cronSchedule{}
+	var err error
+	if s.minute, err = parseField(fields[0], 0, 59); err != nil {
+		return nil, errors.WithMessagef(err, "invalid minute in schedule [%s]", expr)
+	}
+	if s.hour, err = parseField(fields[1], 0, 23); err != nil {
+		return nil, errors.WithMessagef(err, "invalid hour in schedule [%s]", expr)
+	}
+	if s.dom, err = parseField(fields[2], 1, 31); err != nil {
+		return nil, errors.WithMessagef(err, "invalid day of month in schedule [%s]", expr)
+	}
+	if s.month, err = parseField(fields[3], 1, 12); err != nil {
+		return nil, errors.WithMessagef(err, "invalid month in schedule [%s]", expr)
+	}
+	if s.dow, err = parseField(fields[4], 0, 7); err != nil {
+		return nil, errors.WithMessagef(err, "invalid day of week in schedule [%s]", expr)
+	}
+	// 7 is Sunday as well
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.anyDom = fields[2] == "*"
+	s.anyDow = fields[4] == "*"
+	return s, nil
+}
+
+type every time.Duration
+
+func (e every) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(e))
+}
+
+// cronSchedule stores, for each field, the bitset of the allowed values
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	anyDom, anyDow                bool
+}
+
+// maxLookahead bounds the search of the next execution time, for expressions that never match, like "0 0 30 2 *"
+const maxLookahead = 5 * 366 * 24 * time.Hour
+
+func (s *cronSchedule) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxLookahead)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchDay follows the cron convention: when both the day of month and the day of week are restricted,
+// a day matches if either of them matches
+func (s *cronSchedule) matchDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.anyDom || s.anyDow {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func parseField(field string, first, last int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.Errorf("invalid step in [%s]", part)
+			}
+			part = part[:i]
+		}
+		lo, hi := first, last
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, errors.Errorf("invalid range [%s]", part)
+			}
+			if hi, err = strconv.Atoi(bounds[1]); err != nil {
+				return 0, errors.Errorf("invalid range [%s]", part)
+			}
+		default:
+			v, err := strconv.Atoi(part)
+			if err != nil {
+				return 0, errors.Errorf("invalid value [%s]", part)
+			}
+			lo, hi = v, v
+			if step > 1 {
+				// "a/n" means from a to the maximum, every n
+				hi = last
+			}
+		}
+		if lo < first || hi > last || lo > hi {
+			return 0, errors.Errorf("[%s] out of range [%d-%d]", part, first, last)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchedule(t *testing.T) {
+	// Monday, 15 January 2024, 10:30:45 UTC
+	now := time.Date(2024, time.January, 15, 10, 30, 45, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"@every 90m", now.Add(90 * time.Minute)},
+		{"@hourly", time.Date(2024, time.January, 15, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, time.January, 16, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, time.January, 21, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"* * * * *", time.Date(2024, time.January, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.January, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2024, time.January, 15, 13, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 8 * * 1-5", time.Date(2024, time.January, 16, 8, 30, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2024, time.January, 21, 12, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// both day of month and day of week restricted: either matches
+		{"0 0 31 * 3", time.Date(2024, time.January, 17, 0, 0, 0, 0, time.UTC)},
+		// payroll on the 27th
+		{"0 6 27 * *", time.Date(2024, time.January, 27, 6, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := ParseSchedule(tt.expr)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, s.Next(now))
+		})
+	}
+
+	// an expression that never fires
+	s, err := ParseSchedule("0 0 30 2 *")
+	assert.NoError(t, err)
+	assert.True(t, s.Next(now).IsZero())
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"@every",
+		"@every 1ms",
+		"@every tomorrow",
+		"@never",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseSchedule(expr)
+			assert.Error(t, err)
+		})
+	}
+}
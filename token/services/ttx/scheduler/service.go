@@ -0,0 +1,407 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultRetryDelay is the time to wait before retrying a failed execution, if the order does not specify it
+	DefaultRetryDelay = time.Minute
+	// DefaultPollingInterval is the default interval between two checks for due orders
+	DefaultPollingInterval = 10 * time.Second
+)
+
+type (
+	// Order is a transfer to be executed at a given time, once or periodically
+	Order = driver.TransferOrderRecord
+	// Execution is the outcome of an attempt to execute an order
+	Execution = driver.TransferOrderExecutionRecord
+	// Status is the status of an order
+	Status = driver.TransferOrderStatus
+)
+
+const (
+	// Active is the status of an order waiting for its next execution
+	Active = driver.TransferOrderActive
+	// Completed is the status of a one-shot order executed successfully
+	Completed = driver.TransferOrderCompleted
+	// Failed is the status of a one-shot order whose retries are exhausted
+	Failed = driver.TransferOrderFailed
+	// Cancelled is the status of an order cancelled before its completion
+	Cancelled = driver.TransferOrderCancelled
+)
+
+type ViewManager interface {
+	InitiateView(view view.View, ctx context.Context) (interface{}, error)
+}
+
+// DB stores the orders and their executions, usually the token db of the TMS
+type DB interface {
+	driver.TransferOrderDB
+}
+
+// TxStatusProvider returns the status of the transactions of the TMS, usually the ttx DB, see ttx.Get
+type TxStatusProvider interface {
+	GetStatus(txID string) (ttx.TxStatus, string, error)
+}
+
+// Service executes the transfer orders of a TMS when they are due, through the usual transfer,
+// endorsement, and finality pipeline. Orders and the outcome of each execution are stored in the passed DB.
+// A failed execution is retried up to Order.MaxRetries times, every Order.RetryDelay.
+// If the transaction of an execution has been broadcast, but its finality is not known, for instance because
+// waiting for it timed out, the execution is pending: the order is not executed again until the transaction is known
+// to be committed, then the execution succeeded, or rejected, then the execution failed and can be retried.
+// Then, a one-shot order fails, while a recurring order moves to its next execution.
+// Executions missed while the service was not running are not replayed: a due order is executed once,
+// and its next execution is computed from the current time.
+// Only one service per TMS must run against the same DB.
+type Service struct {
+	tmsID           token.TMSID
+	db              DB
+	txStatus        TxStatusProvider
+	viewManager     ViewManager
+	finalityTimeout time.Duration
+	now             func() time.Time
+
+	// runMutex serializes the executions of the due orders
+	runMutex sync.Mutex
+	mutex    sync.Mutex
+	cancel   context.CancelFunc
+}
+
+// NewService returns a new scheduler service for the passed TMS.
+// The status of the transactions is read from the passed provider.
+func NewService(tmsID token.TMSID, db DB, txStatus TxStatusProvider, viewManager ViewManager) *Service {
+	return &Service{
+		tmsID:       tmsID,
+		db:          db,
+		txStatus:    txStatus,
+		viewManager: viewManager,
+		now:         time.Now,
+	}
+}
+
+// WithFinalityTimeout sets the time to wait for the finality of each transaction, zero means the default of the finality view
+func (s *Service) WithFinalityTimeout(timeout time.Duration) *Service {
+	s.finalityTimeout = timeout
+	return s
+}
+
+// Submit validates and stores the passed order, and returns its id.
+// If the order has no id, a new one is generated.
+// If the order has no next run, a one-shot order is due immediately, and a recurring order at the first time
+// of its schedule.
+func (s *Service) Submit(order Order) (string, error) {
+	if len(order.Wallet) == 0 {
+		return "", errors.New("no wallet specified")
+	}
+	if len(order.Type) == 0 {
+		return "", errors.New("no token type specified")
+	}
+	if order.Amount == 0 {
+		return "", errors.New("amount must be greater than zero")
+	}
+	if len(order.Recipient) == 0 {
+		return "", errors.New("no recipient specified")
+	}
+	if order.MaxRetries < 0 {
+		return "", errors.Errorf("invalid max retries [%d]", order.MaxRetries)
+	}
+	if len(order.ID) == 0 {
+		id, err := uuid.GenerateUUID()
+		if err != nil {
+			return "", errors.Wrapf(err, "failed generating order id")
+		}
+		order.ID = id
+	}
+	if order.NextRun.IsZero() {
+		order.NextRun = s.now()
+		if len(order.Schedule) != 0 {
+			schedule, err := ParseSchedule(order.Schedule)
+			if err != nil {
+				return "", err
+			}
+			order.NextRun = schedule.Next(order.NextRun)
+			if order.NextRun.IsZero() {
+				return "", errors.Errorf("schedule [%s] never fires", order.Schedule)
+			}
+		}
+	} else if len(order.Schedule) != 0 {
+		if _, err := ParseSchedule(order.Schedule); err != nil {
+			return "", err
+		}
+	}
+	order.Status = Active
+	order.Attempts = 0
+	order.PendingTxID = ""
+	order.CreatedAt = s.now()
+	if err := s.db.StoreTransferOrder(order); err != nil {
+		return "", errors.WithMessagef(err, "failed storing order [%s]", order.ID)
+	}
+	logger.Debugf("order [%s] submitted, next run at [%s]", order.ID, order.NextRun)
+	return order.ID, nil
+}
+
+// Cancel cancels the passed active order
+func (s *Service) Cancel(id string) error {
+	order, err := s.Order(id)
+	if err != nil {
+		return err
+	}
+	if order.Status != Active {
+		return errors.Errorf("order [%s] is not active", id)
+	}
+	order.Status = Cancelled
+	if err := s.db.StoreTransferOrder(*order); err != nil {
+		return errors.WithMessagef(err, "failed cancelling order [%s]", id)
+	}
+	return nil
+}
+
+// Order returns the order with the passed id
+func (s *Service) Order(id string) (*Order, error) {
+	order, err := s.db.GetTransferOrder(id)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed loading order [%s]", id)
+	}
+	if order == nil {
+		return nil, errors.Errorf("order [%s] not found", id)
+	}
+	return order, nil
+}
+
+// Orders returns the orders with one of the passed statuses, all if none is passed
+func (s *Service) Orders(statuses ...Status) ([]Order, error) {
+	return s.db.QueryTransferOrders(driver.QueryTransferOrdersParams{Statuses: statuses})
+}
+
+// Executions returns the outcomes of the attempts to execute the passed order, oldest first
+func (s *Service) Executions(id string) ([]Execution, error) {
+	return s.db.QueryTransferOrderExecutions(id)
+}
+
+// Start checks for due orders every interval, until Stop is called or the passed context is done
+func (s *Service) Start(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultPollingInterval
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.cancel != nil {
+		return errors.New("scheduler already started")
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+	go s.run(ctx, interval)
+	return nil
+}
+
+// Stop stops checking for due orders. Executions in progress are not interrupted.
+func (s *Service) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+}
+
+func (s *Service) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Debugf("scheduler for [%s] stopped", s.tmsID)
+			return
+		case <-ticker.C:
+			if _, err := s.RunDue(ctx); err != nil {
+				logger.Errorf("failed running due orders for [%s]: [%s]", s.tmsID, err)
+			}
+		}
+	}
+}
+
+// RunDue executes, one after the other, the active orders whose next run is due, and returns how many were executed
+func (s *Service) RunDue(ctx context.Context) (int, error) {
+	s.runMutex.Lock()
+	defer s.runMutex.Unlock()
+
+	orders, err := s.db.QueryTransferOrders(driver.QueryTransferOrdersParams{
+		Statuses:  []Status{Active},
+		DueBefore: s.now(),
+	})
+	if err != nil {
+		return 0, errors.WithMessagef(err, "failed querying due orders")
+	}
+	for i := range orders {
+		if ctx.Err() != nil {
+			return i, ctx.Err()
+		}
+		if err := s.execute(ctx, &orders[i]); err != nil {
+			logger.Errorf("failed executing order [%s]: [%s]", orders[i].ID, err)
+		}
+	}
+	return len(orders), nil
+}
+
+func (s *Service) execute(ctx context.Context, order *Order) error {
+	if cancelled, err := s.isCancelled(order.ID); err != nil || cancelled {
+		return err
+	}
+	if len(order.PendingTxID) != 0 {
+		return s.checkPending(order)
+	}
+	logger.Debugf("execute order [%s], attempt [%d]", order.ID, order.Attempts+1)
+	v := NewExecuteView(s.tmsID, order)
+	v.FinalityTimeout = s.finalityTimeout
+	result := &Result{}
+	boxed, err := s.viewManager.InitiateView(v, ctx)
+	if err != nil {
+		result.Err = err
+	} else if r, ok := boxed.(*Result); ok {
+		result = r
+	} else {
+		return errors.Errorf("invalid result of the execution of order [%s], got [%T]", order.ID, boxed)
+	}
+
+	execution := Execution{
+		OrderID:     order.ID,
+		TxID:        result.TxID,
+		Attempt:     order.Attempts + 1,
+		ScheduledAt: order.NextRun,
+		Committed:   result.Err == nil,
+		ExecutedAt:  s.now(),
+	}
+	if result.Err != nil {
+		execution.Error = result.Err.Error()
+	}
+	if err := s.db.AddTransferOrderExecution(execution); err != nil {
+		logger.Errorf("failed recording execution of order [%s]: [%s]", order.ID, err)
+	}
+
+	if result.Err != nil && result.Submitted {
+		// the transaction might still be committed, wait for its finality before executing the order again
+		logger.Warnf("finality of transaction [%s] of order [%s] not known, wait for it: [%s]", result.TxID, order.ID, result.Err)
+		order.PendingTxID = result.TxID
+	} else {
+		s.advance(order, result.Err)
+	}
+	return s.store(order)
+}
+
+// checkPending checks the finality of the pending transaction of the passed order.
+// If the transaction is committed or rejected, the order advances as if the execution just completed.
+// Otherwise, the order is left as it is and checked again at the next run.
+func (s *Service) checkPending(order *Order) error {
+	status, message, err := s.txStatus.GetStatus(order.PendingTxID)
+	if err != nil {
+		return errors.WithMessagef(err, "failed getting status of transaction [%s] of order [%s]", order.PendingTxID, order.ID)
+	}
+	var failure error
+	switch status {
+	case ttxdb.Confirmed:
+	case ttxdb.Deleted:
+		failure = errors.Errorf("transaction [%s] is not valid [%s]", order.PendingTxID, message)
+	default:
+		logger.Debugf("transaction [%s] of order [%s] still pending", order.PendingTxID, order.ID)
+		return nil
+	}
+
+	execution := Execution{
+		OrderID:     order.ID,
+		TxID:        order.PendingTxID,
+		Attempt:     order.Attempts + 1,
+		ScheduledAt: order.NextRun,
+		Committed:   failure == nil,
+		ExecutedAt:  s.now(),
+	}
+	if failure != nil {
+		execution.Error = failure.Error()
+	}
+	if err := s.db.AddTransferOrderExecution(execution); err != nil {
+		logger.Errorf("failed recording execution of order [%s]: [%s]", order.ID, err)
+	}
+
+	order.PendingTxID = ""
+	s.advance(order, failure)
+	return s.store(order)
+}
+
+// store stores the passed order, unless it was cancelled in the meantime
+func (s *Service) store(order *Order) error {
+	if cancelled, err := s.isCancelled(order.ID); err != nil {
+		return err
+	} else if cancelled {
+		// the order was cancelled during the execution
+		order.Status = Cancelled
+	}
+	return s.db.StoreTransferOrder(*order)
+}
+
+func (s *Service) isCancelled(id string) (bool, error) {
+	current, err := s.db.GetTransferOrder(id)
+	if err != nil {
+		return false, errors.WithMessagef(err, "failed loading order [%s]", id)
+	}
+	return current == nil || current.Status == Cancelled, nil
+}
+
+// advance updates the passed order according to the outcome of its last execution
+func (s *Service) advance(order *Order, failure error) {
+	now := s.now()
+	if failure != nil {
+		order.Attempts++
+		if order.Attempts <= order.MaxRetries {
+			delay := order.RetryDelay
+			if delay <= 0 {
+				delay = DefaultRetryDelay
+			}
+			order.NextRun = now.Add(delay)
+			logger.Warnf("execution of order [%s] failed, retry [%d/%d] at [%s]: [%s]", order.ID, order.Attempts, order.MaxRetries, order.NextRun, failure)
+			return
+		}
+		logger.Errorf("execution of order [%s] failed, no retries left: [%s]", order.ID, failure)
+	}
+	order.Attempts = 0
+
+	if len(order.Schedule) == 0 {
+		if failure != nil {
+			order.Status = Failed
+		} else {
+			order.Status = Completed
+		}
+		return
+	}
+	schedule, err := ParseSchedule(order.Schedule)
+	if err != nil {
+		logger.Errorf("invalid schedule for order [%s], mark it as failed: [%s]", order.ID, err)
+		order.Status = Failed
+		return
+	}
+	next := schedule.Next(order.NextRun)
+	if !next.After(now) {
+		// skip the executions missed
+		next = schedule.Next(now)
+	}
+	if next.IsZero() {
+		order.Status = Completed
+		return
+	}
+	order.NextRun = next
+}
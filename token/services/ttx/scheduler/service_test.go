@@ -0,0 +1,332 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_OneShot(t *testing.T) {
+	s, db, vm, clock := newTestService()
+
+	id, err := s.Submit(Order{Wallet: "alice", Type: "USD", Amount: 10, Recipient: []byte("bob")})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	vm.results = []*Result{{TxID: "tx1"}}
+	n, err := s.RunDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	order, err := s.Order(id)
+	assert.NoError(t, err)
+	assert.Equal(t, Completed, order.Status)
+	executions, err := s.Executions(id)
+	assert.NoError(t, err)
+	assert.Len(t, executions, 1)
+	assert.Equal(t, "tx1", executions[0].TxID)
+	assert.True(t, executions[0].Committed)
+
+	// nothing left to do
+	*clock = clock.Add(time.Hour)
+	n, err = s.RunDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Len(t, db.executions, 1)
+}
+
+func TestService_Retries(t *testing.T) {
+	s, _, vm, clock := newTestService()
+
+	id, err := s.Submit(Order{Wallet: "alice", Type: "USD", Amount: 10, Recipient: []byte("bob"), MaxRetries: 1, RetryDelay: time.Minute})
+	assert.NoError(t, err)
+
+	vm.results = []*Result{{TxID: "tx1", Err: errors.New("no funds")}, {Err: errors.New("still no funds")}}
+	_, err = s.RunDue(context.Background())
+	assert.NoError(t, err)
+	order, err := s.Order(id)
+	assert.NoError(t, err)
+	assert.Equal(t, Active, order.Status)
+	assert.Equal(t, 1, order.Attempts)
+	assert.Equal(t, clock.Add(time.Minute), order.NextRun)
+
+	// the retry is not due yet
+	n, err := s.RunDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	*clock = clock.Add(time.Minute)
+	_, err = s.RunDue(context.Background())
+	assert.NoError(t, err)
+	order, err = s.Order(id)
+	assert.NoError(t, err)
+	assert.Equal(t, Failed, order.Status)
+
+	executions, err := s.Executions(id)
+	assert.NoError(t, err)
+	assert.Len(t, executions, 2)
+	assert.Equal(t, 1, executions[0].Attempt)
+	assert.Equal(t, "no funds", executions[0].Error)
+	assert.Equal(t, 2, executions[1].Attempt)
+	assert.False(t, executions[1].Committed)
+}
+
+func TestService_Recurring(t *testing.T) {
+	s, _, vm, clock := newTestService()
+
+	id, err := s.Submit(Order{Wallet: "alice", Type: "USD", Amount: 10, Recipient: []byte("bob"), Schedule: "0 0 * * *"})
+	assert.NoError(t, err)
+	order, err := s.Order(id)
+	assert.NoError(t, err)
+	midnight := time.Date(2024, time.January, 16, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, midnight, order.NextRun)
+
+	// the node was down for two days and a half, the missed executions are not replayed
+	*clock = midnight.Add(60 * time.Hour)
+	vm.results = []*Result{{TxID: "tx1"}}
+	n, err := s.RunDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	order, err = s.Order(id)
+	assert.NoError(t, err)
+	assert.Equal(t, Active, order.Status)
+	assert.Equal(t, midnight.Add(72*time.Hour), order.NextRun)
+
+	// a failure without retries moves to the next execution
+	*clock = order.NextRun
+	vm.results = []*Result{{Err: errors.New("failed")}}
+	_, err = s.RunDue(context.Background())
+	assert.NoError(t, err)
+	order, err = s.Order(id)
+	assert.NoError(t, err)
+	assert.Equal(t, Active, order.Status)
+	assert.Equal(t, 0, order.Attempts)
+	assert.Equal(t, midnight.Add(96*time.Hour), order.NextRun)
+
+	assert.NoError(t, s.Cancel(id))
+	*clock = order.NextRun
+	n, err = s.RunDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Error(t, s.Cancel(id))
+}
+
+func TestService_FinalityTimeout(t *testing.T) {
+	s, db, vm, clock := newTestService()
+
+	id, err := s.Submit(Order{Wallet: "alice", Type: "USD", Amount: 10, Recipient: []byte("bob"), MaxRetries: 3, RetryDelay: time.Minute})
+	assert.NoError(t, err)
+
+	// the transaction is broadcast, but its finality is not known before the timeout
+	vm.results = []*Result{{TxID: "tx1", Err: errors.New("timeout"), Submitted: true}}
+	db.statuses["tx1"] = ttxdb.Pending
+	_, err = s.RunDue(context.Background())
+	assert.NoError(t, err)
+	order, err := s.Order(id)
+	assert.NoError(t, err)
+	assert.Equal(t, Active, order.Status)
+	assert.Equal(t, "tx1", order.PendingTxID)
+	assert.Equal(t, 0, order.Attempts)
+
+	// while the transaction is pending, the order is not executed again, even after the retry delay
+	*clock = clock.Add(time.Hour)
+	_, err = s.RunDue(context.Background())
+	assert.NoError(t, err)
+	order, err = s.Order(id)
+	assert.NoError(t, err)
+	assert.Equal(t, "tx1", order.PendingTxID)
+	assert.Len(t, db.executions, 1)
+
+	// the transaction gets committed, the order completes without a new transaction
+	db.statuses["tx1"] = ttxdb.Confirmed
+	_, err = s.RunDue(context.Background())
+	assert.NoError(t, err)
+	order, err = s.Order(id)
+	assert.NoError(t, err)
+	assert.Equal(t, Completed, order.Status)
+	assert.Empty(t, order.PendingTxID)
+	assert.Empty(t, vm.results)
+
+	executions, err := s.Executions(id)
+	assert.NoError(t, err)
+	assert.Len(t, executions, 2)
+	assert.Equal(t, "tx1", executions[0].TxID)
+	assert.False(t, executions[0].Committed)
+	assert.Equal(t, "tx1", executions[1].TxID)
+	assert.Equal(t, 1, executions[1].Attempt)
+	assert.True(t, executions[1].Committed)
+}
+
+func TestService_FinalityTimeoutRejected(t *testing.T) {
+	s, db, vm, clock := newTestService()
+
+	id, err := s.Submit(Order{Wallet: "alice", Type: "USD", Amount: 10, Recipient: []byte("bob"), MaxRetries: 1, RetryDelay: time.Minute})
+	assert.NoError(t, err)
+
+	vm.results = []*Result{{TxID: "tx1", Err: errors.New("timeout"), Submitted: true}}
+	_, err = s.RunDue(context.Background())
+	assert.NoError(t, err)
+
+	// the transaction is rejected, the order is retried with a new transaction
+	db.statuses["tx1"] = ttxdb.Deleted
+	_, err = s.RunDue(context.Background())
+	assert.NoError(t, err)
+	order, err := s.Order(id)
+	assert.NoError(t, err)
+	assert.Equal(t, Active, order.Status)
+	assert.Empty(t, order.PendingTxID)
+	assert.Equal(t, 1, order.Attempts)
+	assert.Equal(t, clock.Add(time.Minute), order.NextRun)
+
+	*clock = clock.Add(time.Minute)
+	vm.results = []*Result{{TxID: "tx2"}}
+	_, err = s.RunDue(context.Background())
+	assert.NoError(t, err)
+	order, err = s.Order(id)
+	assert.NoError(t, err)
+	assert.Equal(t, Completed, order.Status)
+
+	executions, err := s.Executions(id)
+	assert.NoError(t, err)
+	assert.Len(t, executions, 3)
+	assert.Contains(t, executions[1].Error, "transaction [tx1] is not valid")
+	assert.Equal(t, "tx2", executions[2].TxID)
+	assert.True(t, executions[2].Committed)
+}
+
+func TestService_InvalidResult(t *testing.T) {
+	s, db, vm, _ := newTestService()
+
+	id, err := s.Submit(Order{Wallet: "alice", Type: "USD", Amount: 10, Recipient: []byte("bob")})
+	assert.NoError(t, err)
+
+	// the order is left as it is
+	vm.invalid = "tx1"
+	_, err = s.RunDue(context.Background())
+	assert.NoError(t, err)
+	order, err := s.Order(id)
+	assert.NoError(t, err)
+	assert.Equal(t, Active, order.Status)
+	assert.Equal(t, 0, order.Attempts)
+	assert.Empty(t, db.executions)
+}
+
+func TestService_Submit_Invalid(t *testing.T) {
+	s, _, _, _ := newTestService()
+	for _, order := range []Order{
+		{Type: "USD", Amount: 10, Recipient: []byte("bob")},
+		{Wallet: "alice", Amount: 10, Recipient: []byte("bob")},
+		{Wallet: "alice", Type: "USD", Recipient: []byte("bob")},
+		{Wallet: "alice", Type: "USD", Amount: 10},
+		{Wallet: "alice", Type: "USD", Amount: 10, Recipient: []byte("bob"), MaxRetries: -1},
+		{Wallet: "alice", Type: "USD", Amount: 10, Recipient: []byte("bob"), Schedule: "every day"},
+		{Wallet: "alice", Type: "USD", Amount: 10, Recipient: []byte("bob"), Schedule: "0 0 30 2 *"},
+	} {
+		_, err := s.Submit(order)
+		assert.Error(t, err)
+	}
+}
+
+func newTestService() (*Service, *memDB, *viewManager, *time.Time) {
+	// Monday, 15 January 2024, 10:30:00 UTC
+	clock := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+	db := &memDB{orders: map[string]Order{}, statuses: map[string]ttx.TxStatus{}}
+	vm := &viewManager{}
+	s := NewService(token.TMSID{Network: "n", Channel: "c", Namespace: "ns"}, db, db, vm)
+	s.now = func() time.Time { return clock }
+	return s, db, vm, &clock
+}
+
+type viewManager struct {
+	results []*Result
+	// invalid, if set, is returned in place of the results
+	invalid interface{}
+}
+
+func (v *viewManager) InitiateView(view view.View, ctx context.Context) (interface{}, error) {
+	if _, ok := view.(*ExecuteView); !ok {
+		return nil, errors.Errorf("unexpected view [%T]", view)
+	}
+	if v.invalid != nil {
+		return v.invalid, nil
+	}
+	if len(v.results) == 0 {
+		return nil, errors.New("no result available")
+	}
+	r := v.results[0]
+	v.results = v.results[1:]
+	return r, nil
+}
+
+type memDB struct {
+	orders     map[string]Order
+	executions []Execution
+	statuses   map[string]ttx.TxStatus
+}
+
+func (m *memDB) GetStatus(txID string) (ttx.TxStatus, string, error) {
+	status, ok := m.statuses[txID]
+	if !ok {
+		return ttxdb.Unknown, "", nil
+	}
+	return status, "", nil
+}
+
+func (m *memDB) StoreTransferOrder(order driver.TransferOrderRecord) error {
+	m.orders[order.ID] = order
+	return nil
+}
+
+func (m *memDB) GetTransferOrder(id string) (*driver.TransferOrderRecord, error) {
+	order, ok := m.orders[id]
+	if !ok {
+		return nil, nil
+	}
+	return &order, nil
+}
+
+func (m *memDB) QueryTransferOrders(params driver.QueryTransferOrdersParams) ([]driver.TransferOrderRecord, error) {
+	var res []driver.TransferOrderRecord
+	for _, order := range m.orders {
+		if len(params.Statuses) != 0 && !slices.Contains(params.Statuses, order.Status) {
+			continue
+		}
+		if !params.DueBefore.IsZero() && order.NextRun.After(params.DueBefore) {
+			continue
+		}
+		res = append(res, order)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].NextRun.Before(res[j].NextRun) })
+	return res, nil
+}
+
+func (m *memDB) AddTransferOrderExecution(record driver.TransferOrderExecutionRecord) error {
+	m.executions = append(m.executions, record)
+	return nil
+}
+
+func (m *memDB) QueryTransferOrderExecutions(orderID string) ([]driver.TransferOrderExecutionRecord, error) {
+	var res []driver.TransferOrderExecutionRecord
+	for _, e := range m.executions {
+		if e.OrderID == orderID {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
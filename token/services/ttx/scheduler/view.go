@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scheduler
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx"
+	"github.com/pkg/errors"
+)
+
+// Result is the outcome of an execution of a transfer order
+type Result struct {
+	// TxID is the identifier of the transaction, empty if the transaction could not be assembled
+	TxID string
+	// Err is the reason of the failure, nil if the transaction has been committed
+	Err error
+	// Submitted is true if the transaction might have been broadcast.
+	// Then, a failure does not mean that the transaction has not been committed,
+	// for instance when the ordering fails after the broadcast or when the finality times out.
+	Submitted bool
+}
+
+// ExecuteView executes a transfer order once.
+// It requests a fresh identity to the recipient node, and generates a transfer that goes through endorsement,
+// ordering, and finality. The view returns a *Result, the failures are reported in the result and not as error.
+type ExecuteView struct {
+	TMSID           token.TMSID
+	Order           *Order
+	FinalityTimeout time.Duration
+}
+
+// NewExecuteView returns a new instance of the view executing the passed order for the passed TMS
+func NewExecuteView(tmsID token.TMSID, order *Order) *ExecuteView {
+	return &ExecuteView{TMSID: tmsID, Order: order}
+}
+
+func (v *ExecuteView) Call(context view.Context) (interface{}, error) {
+	if v.Order == nil {
+		return nil, errors.New("no transfer order provided")
+	}
+	return v.execute(context), nil
+}
+
+func (v *ExecuteView) execute(context view.Context) *Result {
+	order := v.Order
+	result := &Result{}
+
+	tms := token.GetManagementService(context, token.WithTMSID(v.TMSID))
+	if tms == nil {
+		result.Err = errors.Errorf("failed getting token management service [%s]", v.TMSID)
+		return result
+	}
+	wallet := tms.WalletManager().OwnerWallet(order.Wallet)
+	if wallet == nil {
+		result.Err = errors.Errorf("owner wallet [%s] not found", order.Wallet)
+		return result
+	}
+	recipient, err := ttx.RequestRecipientIdentity(context, order.Recipient, token.WithTMSID(v.TMSID))
+	if err != nil {
+		result.Err = errors.Wrapf(err, "failed getting recipient identity from [%s]", view.Identity(order.Recipient))
+		return result
+	}
+	tx, err := ttx.NewTransaction(
+		context,
+		nil,
+		ttx.WithTMSID(v.TMSID),
+		ttx.WithAuditor(order.Auditor),
+	)
+	if err != nil {
+		result.Err = errors.Wrapf(err, "failed creating transaction")
+		return result
+	}
+	result.TxID = tx.ID()
+	if err := tx.Transfer(wallet, order.Type, []uint64{order.Amount}, []view.Identity{recipient}); err != nil {
+		tx.Release()
+		result.Err = errors.Wrapf(err, "failed adding transfer")
+		return result
+	}
+	if _, err := context.RunView(ttx.NewCollectEndorsementsView(tx)); err != nil {
+		tx.Release()
+		result.Err = errors.Wrapf(err, "failed collecting endorsements")
+		return result
+	}
+	// from now on, the transaction might reach the ledger
+	result.Submitted = true
+	if _, err := context.RunView(ttx.NewOrderingView(tx)); err != nil {
+		// the inputs are released, if the transaction reaches the ledger anyway, they are spent there
+		tx.Release()
+		result.Err = errors.Wrapf(err, "failed ordering")
+		return result
+	}
+	if _, err := context.RunView(ttx.NewFinalityView(tx, ttx.WithTimeout(v.FinalityTimeout))); err != nil {
+		result.Err = errors.Wrapf(err, "failed waiting for finality")
+		return result
+	}
+	return result
+}
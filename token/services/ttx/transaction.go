@@ -248,6 +248,23 @@ func (t *Transaction) Redeem(wallet *token.OwnerWallet, typ token2.Type, value u
 	return t.TokenRequest.Redeem(t.Context, wallet, typ, value, opts...)
 }
 
+// ForcedTransfer appends a new Transfer operation, forced by the passed enforcer, that moves the tokens with the passed ids
+// to the passed recipient. The enforcer signs in place of the owners of the tokens.
+func (t *Transaction) ForcedTransfer(enforcer view.Identity, ids []*token2.ID, recipient view.Identity, opts ...token.TransferOption) error {
+	_, err := t.TokenRequest.ForcedTransfer(t.Context, enforcer, ids, recipient, opts...)
+	return err
+}
+
+// Freeze appends a new Freeze operation, signed by the passed enforcer, for the passed tokens and owners
+func (t *Transaction) Freeze(enforcer view.Identity, ids []*token2.ID, owners []view.Identity) error {
+	return t.TokenRequest.Freeze(enforcer, ids, owners)
+}
+
+// Unfreeze appends a new Unfreeze operation, signed by the passed enforcer, for the passed tokens and owners
+func (t *Transaction) Unfreeze(enforcer view.Identity, ids []*token2.ID, owners []view.Identity) error {
+	return t.TokenRequest.Unfreeze(enforcer, ids, owners)
+}
+
 // Upgrade performs an upgrade operation of the passed ledger tokens.
 // A proof and its challenge will be used to verify that the request of upgrade is legit.
 // If the proof verifies then the passed wallet will be used to issue a new amount of tokens
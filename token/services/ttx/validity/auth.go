@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validity
+
+import (
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/validity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	token3 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+)
+
+var logger = logging.MustGetLogger("token-sdk.services.validity")
+
+// WindowAuth implements the Authorization interface for tokens bound to a validity window
+type WindowAuth struct {
+	WalletService driver.WalletService
+}
+
+func NewWindowAuth(walletService driver.WalletService) *WindowAuth {
+	return &WindowAuth{WalletService: walletService}
+}
+
+// AmIAnAuditor returns false for validity windows
+func (s *WindowAuth) AmIAnAuditor() bool {
+	return false
+}
+
+// IsMine returns true if either the owner is in one of the owner wallets or the issuer is in one of the issuer wallets.
+// The token is assigned to the wallet of the owner, if any, so that the owner can spend it like any other token.
+// The issuer is assigned an additional identifier to list the tokens it might reclaim.
+func (s *WindowAuth) IsMine(tok *token3.Token) (string, []string, bool) {
+	ok, w, err := validity.Unwrap(tok.Owner)
+	if err != nil || !ok {
+		logger.Debugf("Is Mine [%s,%s,%s]? No, not a validity window [%v]", view.Identity(tok.Owner), tok.Type, tok.Quantity, err)
+		return "", nil, false
+	}
+
+	var walletID string
+	logger.Debugf("Is Mine [%s,%s,%s] as an owner?", view.Identity(tok.Owner), tok.Type, tok.Quantity)
+	if wallet, err := s.WalletService.OwnerWallet(w.Owner); err == nil {
+		logger.Debugf("Is Mine [%s,%s,%s] as an owner? Yes", view.Identity(tok.Owner), tok.Type, tok.Quantity)
+		walletID = wallet.ID()
+	}
+
+	var ids []string
+	logger.Debugf("Is Mine [%s,%s,%s] as an issuer?", view.Identity(tok.Owner), tok.Type, tok.Quantity)
+	if wallet, err := s.WalletService.IssuerWallet(w.Issuer); err == nil {
+		logger.Debugf("Is Mine [%s,%s,%s] as an issuer? Yes", view.Identity(tok.Owner), tok.Type, tok.Quantity)
+		ids = append(ids, issuerWallet(wallet))
+	}
+
+	mine := len(walletID) != 0 || len(ids) != 0
+	logger.Debugf("Is Mine [%s,%s,%s]? %v", view.Identity(tok.Owner), tok.Type, tok.Quantity, mine)
+	return walletID, ids, mine
+}
+
+func (s *WindowAuth) Issued(issuer driver.Identity, tok *token3.Token) bool {
+	return false
+}
+
+func (s *WindowAuth) OwnerType(raw []byte) (string, []byte, error) {
+	owner, err := identity.UnmarshalTypedIdentity(raw)
+	if err != nil {
+		return "", nil, err
+	}
+	return owner.Type, owner.Identity, nil
+}
+
+type wallet interface {
+	ID() string
+}
+
+func issuerWallet(w wallet) string {
+	return "validity.issuer" + w.ID()
+}
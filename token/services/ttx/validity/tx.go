@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validity
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/validity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// Transaction wraps a ttx.Transaction to provide a more user-friendly API for tokens bound to a validity window.
+type Transaction struct {
+	*ttx.Transaction
+}
+
+// Wrap wraps a ttx.Transaction to provide a more user-friendly API for tokens bound to a validity window.
+func Wrap(tx *ttx.Transaction) *Transaction {
+	return &Transaction{Transaction: tx}
+}
+
+// Issue issues tokens to the receiver that can be spent only in the passed validity window.
+// A zero notBefore or notAfter leaves the corresponding side of the window open.
+// Once expired, the tokens can be reclaimed by the issuer identity of the passed wallet for the given type.
+func (t *Transaction) Issue(wallet *token.IssuerWallet, receiver token.Identity, typ token2.Type, q uint64, notBefore, notAfter time.Time, opts ...token.IssueOption) error {
+	issuer, err := wallet.GetIssuerIdentity(typ)
+	if err != nil {
+		return errors.WithMessagef(err, "failed getting issuer identity for type [%s]", typ)
+	}
+	owner, err := validity.Wrap(receiver, issuer, notBefore, notAfter)
+	if err != nil {
+		return errors.WithMessage(err, "failed binding receiver to validity window")
+	}
+	return t.Transaction.Issue(wallet, owner, typ, q, opts...)
+}
+
+// Reclaim moves the expired tokens with the passed ids to the passed recipient.
+// The issuer named in the validity windows of the tokens signs in place of the owners.
+func (t *Transaction) Reclaim(ids []*token2.ID, recipient token.Identity, opts ...token.TransferOption) error {
+	_, err := t.TokenRequest.Reclaim(t.Context, ids, recipient, opts...)
+	return err
+}
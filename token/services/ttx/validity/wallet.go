@@ -0,0 +1,114 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validity
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/validity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+type QueryEngine interface {
+	// UnspentTokensIteratorBy returns an iterator over all unspent tokens by type and id. Type can be empty
+	UnspentTokensIteratorBy(ctx context.Context, id string, tokenType token2.Type) (driver.UnspentTokensIterator, error)
+}
+
+// IssuerWallet is a combination of an issuer wallet and a query service
+type IssuerWallet struct {
+	wallet      *token.IssuerWallet
+	queryEngine QueryEngine
+}
+
+// ListExpiredTokens returns the tokens bound to a validity window naming this wallet as issuer,
+// that are expired at the passed time and can therefore be reclaimed.
+func (w *IssuerWallet) ListExpiredTokens(now time.Time, opts ...token.ListTokensOption) (*token2.UnspentTokens, error) {
+	it, err := w.ListExpiredTokensIterator(now, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "token selection failed")
+	}
+	defer it.Close()
+	var tokens []*token2.UnspentToken
+	for {
+		tok, err := it.Next()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get next unspent token from iterator")
+		}
+		if tok == nil {
+			break
+		}
+		tokens = append(tokens, tok)
+	}
+	return &token2.UnspentTokens{Tokens: tokens}, nil
+}
+
+// ListExpiredTokensIterator returns an iterator over the tokens returned by ListExpiredTokens
+func (w *IssuerWallet) ListExpiredTokensIterator(now time.Time, opts ...token.ListTokensOption) (*ExpiredIterator, error) {
+	compiledOpts, err := token.CompileListTokensOption(opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile options")
+	}
+	it, err := w.queryEngine.UnspentTokensIteratorBy(context.TODO(), issuerWallet(w.wallet), compiledOpts.TokenType)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get iterator over unspent tokens")
+	}
+	return &ExpiredIterator{it: it, now: now}, nil
+}
+
+// GetIssuerWallet returns the issuer wallet whose id is the passed id
+func GetIssuerWallet(sp token.ServiceProvider, id string, opts ...token.ServiceOption) *token.IssuerWallet {
+	return ttx.GetIssuerWallet(sp, id, opts...)
+}
+
+// Wallet returns an IssuerWallet which contains a wallet and a query service
+func Wallet(sp token.ServiceProvider, wallet *token.IssuerWallet) *IssuerWallet {
+	if wallet == nil {
+		return nil
+	}
+	return &IssuerWallet{
+		wallet:      wallet,
+		queryEngine: wallet.TMS().Vault().NewQueryEngine(),
+	}
+}
+
+// ExpiredIterator filters the tokens bound to a validity window that are expired
+type ExpiredIterator struct {
+	it  driver.UnspentTokensIterator
+	now time.Time
+}
+
+func (f *ExpiredIterator) Close() {
+	f.it.Close()
+}
+
+func (f *ExpiredIterator) Next() (*token2.UnspentToken, error) {
+	for {
+		tok, err := f.it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if tok == nil {
+			return nil, nil
+		}
+		ok, w, err := validity.Unwrap(tok.Owner)
+		if err != nil || !ok {
+			logger.Debugf("token [%s] bound to a validity window? No [%v]", tok.Id, err)
+			continue
+		}
+		if !w.ExpiredAt(f.now) {
+			logger.Debugf("token [%s,%s] expired? No, expires at [%s]", tok.Id, view.Identity(tok.Owner).UniqueID(), w.NotAfter)
+			continue
+		}
+		return tok, nil
+	}
+}
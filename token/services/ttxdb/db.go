@@ -95,6 +95,9 @@ type MovementRecord = driver.MovementRecord
 // in that action.
 type ValidationRecord = driver.ValidationRecord
 
+// ParkedTransactionRecord describes a parked transaction
+type ParkedTransactionRecord = driver.ParkedTransactionRecord
+
 // TransactionIterator is an iterator over transaction records
 type TransactionIterator struct {
 	it driver.TransactionIterator
@@ -173,6 +176,9 @@ func newDB(p driver.TokenTransactionDB) (*DB, error) {
 // QueryTransactionsParams defines the parameters for querying movements
 type QueryTransactionsParams = driver.QueryTransactionsParams
 
+// Pagination selects a page of the results of a query
+type Pagination = driver.Pagination
+
 // QueryTokenRequestsParams defines the parameters for querying token requests
 type QueryTokenRequestsParams = driver.QueryTokenRequestsParams
 
@@ -184,6 +190,12 @@ func (d *DB) Transactions(params QueryTransactionsParams) (driver.TransactionIte
 	return d.db.QueryTransactions(params)
 }
 
+// TransactionsPage returns a page of the transaction records filtered by the given params.
+// To get the next page, pass the returned cursor in the pagination of the next call.
+func (d *DB) TransactionsPage(params QueryTransactionsParams, pagination Pagination) (*driver.Page[*TransactionRecord], error) {
+	return d.db.QueryTransactionsPage(params, pagination)
+}
+
 // TokenRequests returns an iterator over the token requests matching the passed params
 func (d *DB) TokenRequests(params QueryTokenRequestsParams) (driver.TokenRequestIterator, error) {
 	return d.db.QueryTokenRequests(params)
@@ -259,9 +271,10 @@ func (d *DB) SetStatus(ctx context.Context, txID string, status driver.TxStatus,
 
 	// notify the listeners
 	d.Notify(common.StatusEvent{
-		Ctx:            ctx,
-		TxID:           txID,
-		ValidationCode: status,
+		Ctx:               ctx,
+		TxID:              txID,
+		ValidationCode:    status,
+		ValidationMessage: message,
 	})
 	logger.Debugf("set status [%s][%s] done", txID, driver.TxStatusMessage[status])
 	return nil
@@ -298,6 +311,26 @@ func (d *DB) GetTransactionEndorsementAcks(txID string) (map[string][]byte, erro
 	return d.db.GetTransactionEndorsementAcks(txID)
 }
 
+// ParkTransaction stores the serialized transaction with the passed id at the passed stage, replacing any previous one
+func (d *DB) ParkTransaction(txID string, stage string, raw []byte) error {
+	return d.db.ParkTransaction(txID, stage, raw)
+}
+
+// GetParkedTransaction returns the serialized transaction parked with the passed id and its stage, nil if none exists
+func (d *DB) GetParkedTransaction(txID string) (string, []byte, error) {
+	return d.db.GetParkedTransaction(txID)
+}
+
+// UnparkTransaction removes the transaction parked with the passed id
+func (d *DB) UnparkTransaction(txID string) error {
+	return d.db.UnparkTransaction(txID)
+}
+
+// QueryParkedTransactions returns the records of all the parked transactions, oldest first
+func (d *DB) QueryParkedTransactions() ([]ParkedTransactionRecord, error) {
+	return d.db.QueryParkedTransactions()
+}
+
 // AppendValidationRecord appends the given validation metadata related to the given transaction id
 func (d *DB) AppendValidationRecord(txID string, tokenRequest []byte, meta map[string][]byte, ppHash driver2.PPHash) error {
 	logger.Debugf("appending new validation record... [%s]", txID)
@@ -38,6 +38,7 @@ type ManagementService struct {
 	vaultProvider               VaultProvider
 	certificationClientProvider CertificationClientProvider
 	selectorManagerProvider     SelectorManagerProvider
+	spendingPolicyProvider      SpendingPolicyProvider
 	signatureService            *SignatureService
 	vault                       *Vault
 	logger                      logging.Logger
@@ -174,6 +175,14 @@ func (t *ManagementService) init() error {
 	return nil
 }
 
+// SpendingPolicy returns the spending policy consulted by Request.Transfer and Request.Redeem, nil if there is none
+func (t *ManagementService) SpendingPolicy() (SpendingPolicy, error) {
+	if t.spendingPolicyProvider == nil {
+		return nil, nil
+	}
+	return t.spendingPolicyProvider.SpendingPolicy(t)
+}
+
 func (t *ManagementService) TokensService() *TokensService {
 	return &TokensService{ts: t.tms.TokensService(), tus: t.tms.TokensUpgradeService()}
 }